@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
@@ -14,10 +15,12 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	chatstore "github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/chat"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/cli"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/git"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/state"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/confighistory"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/control"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/diagnostics"
@@ -43,22 +46,29 @@ for workflow control:
   /cancel          Cancel current workflow
   /model <name>    Set current model
   /agent <name>    Set current agent
+  /sessions        List saved chat sessions
+  /resume <id>     Resume a saved chat session
   /help            Show all commands
 
+Conversations are saved in the same chat store as the web UI, so a
+session can be continued from either interface.
+
 The workflow phases use the configured agents and consensus mechanism
 from your .quorum/config.yaml configuration.
 
 Example:
   quorum chat
   quorum chat --agent gemini
-  quorum chat --model opus`,
+  quorum chat --model opus
+  quorum chat --session 3f2a9c1e`,
 	RunE: runChat,
 }
 
 var (
-	chatAgent string
-	chatModel string
-	chatTrace string
+	chatAgent   string
+	chatModel   string
+	chatTrace   string
+	chatSession string
 )
 
 func init() {
@@ -67,6 +77,7 @@ func init() {
 	chatCmd.Flags().StringVar(&chatAgent, "agent", "", "Default agent (claude, gemini, codex, copilot)")
 	chatCmd.Flags().StringVar(&chatModel, "model", "", "Default model override")
 	chatCmd.Flags().StringVar(&chatTrace, "trace", "", "Trace mode override (off, summary, full)")
+	chatCmd.Flags().StringVar(&chatSession, "session", "", "Resume a saved chat session by ID or ID prefix")
 
 	// Single-agent mode flags
 	chatCmd.Flags().BoolVar(&singleAgent, "single-agent", false,
@@ -233,13 +244,45 @@ func runChat(_ *cobra.Command, _ []string) error {
 	}
 
 	// Create chat model with workflow runner, config, and version
-	model := chat.NewModel(controlPlane, registry, defaultAgent, defaultModel)
+	model := chat.NewModel(controlPlane, service.GetGlobalRateLimiter().WrapAgents(registry), defaultAgent, defaultModel)
 	model = model.WithWorkflowRunner(runner, eventBus, logger)
 	model = model.WithChatConfig(chatTimeout, chatProgressInterval)
+	model = model.WithContextConfig(cfg.Chat.Context)
+	model = model.WithAskAllConfig(cfg.Chat.AskAll)
 	model = model.WithAgentModels(availableAgents, agentModels)
 	model = model.WithEditor(cfg.Chat.Editor)
+	model = model.WithConfigSetter(func(key, value string) (string, error) {
+		// Write the file the chat loaded its configuration from.
+		path := loader.ConfigFile()
+		if path == "" {
+			return "", fmt.Errorf("no configuration file in use")
+		}
+		_, err := setConfigKey(path, key, value, confighistory.Change{
+			Source:  confighistory.SourceTUI,
+			Message: fmt.Sprintf("/config set %s=%s", key, value),
+		})
+		return path, err
+	})
 	model = model.WithVersion(GetVersion())
 
+	// Persist conversations in the chat store shared with the web UI
+	chatStore, err := openChatStore(cfg)
+	if err != nil {
+		if chatSession != "" {
+			return err
+		}
+		logger.Warn("chat history disabled", slog.String("error", err.Error()))
+	} else {
+		defer func() { _ = chatstore.CloseChatStore(chatStore) }()
+		projectRoot, _ := os.Getwd()
+		model = model.WithChatStore(chatStore, projectRoot)
+		if chatSession != "" {
+			if model, err = model.ResumeSession(ctx, chatSession); err != nil {
+				return fmt.Errorf("resuming chat session: %w", err)
+			}
+		}
+	}
+
 	// Run the TUI
 	// Note: Mouse capture disabled to allow native terminal text selection
 	p := tea.NewProgram(
@@ -256,6 +299,19 @@ func runChat(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// openChatStore opens the chat store next to the state database, as quorum serve does.
+func openChatStore(cfg *config.Config) (core.ChatStore, error) {
+	statePath := cfg.State.Path
+	if statePath == "" {
+		statePath = ".quorum/state/state.db"
+	}
+	store, err := chatstore.NewChatStore(filepath.Join(filepath.Dir(statePath), "chat.db"))
+	if err != nil {
+		return nil, fmt.Errorf("opening chat store: %w", err)
+	}
+	return store, nil
+}
+
 func createWorkflowRunnerInternal(
 	ctx context.Context,
 	cfg *config.Config,
@@ -294,8 +350,9 @@ func createWorkflowRunnerInternal(
 			"copilot":  cfg.Agents.Copilot.PhaseModels,
 			"opencode": cfg.Agents.OpenCode.PhaseModels,
 		},
-		WorktreeAutoClean: cfg.Git.Worktree.AutoClean,
-		WorktreeMode:      cfg.Git.Worktree.Mode,
+		WorktreeAutoClean:      cfg.Git.Worktree.AutoClean,
+		WorktreeMode:           cfg.Git.Worktree.Mode,
+		TaskCheckpointInterval: workflow.ParseTaskCheckpointInterval(cfg.Git.Task.CheckpointInterval),
 		Refiner: workflow.RefinerConfig{
 			Enabled: cfg.Phases.Analyze.Refiner.Enabled,
 			Agent:   cfg.Phases.Analyze.Refiner.Agent,
@@ -328,6 +385,8 @@ func createWorkflowRunnerInternal(
 			Plan:    planTimeout,
 			Execute: executeTimeout,
 		},
+		TaskInput:      workflow.NewTaskInputConfig(cfg.Phases.Execute.Input),
+		SkipDependents: cfg.Phases.Execute.SkipDependents,
 	}
 
 	// Create service components
@@ -367,7 +426,7 @@ func createWorkflowRunnerInternal(
 	runner, err := workflow.NewRunner(workflow.RunnerDeps{
 		Config:           runnerConfig,
 		State:            stateAdapter,
-		Agents:           registry,
+		Agents:           rateLimiterRegistry.WrapAgents(registry),
 		DAG:              dagAdapter,
 		Checkpoint:       checkpointAdapter,
 		ResumeProvider:   resumeAdapter,
@@ -455,13 +514,13 @@ func connectRegistryToOutputNotifier(registry *cli.Registry, outputNotifier work
 	}
 	// Connect registry to output notifier for real-time streaming events from CLI adapters.
 	// (OutputNotifier itself is responsible for publishing into the UI/event bus layer.)
-	registry.SetEventHandler(func(event core.AgentEvent) {
+	registry.SetEventHandler(service.GetGlobalRateLimiter().ObserveEvents(func(event core.AgentEvent) {
 		data := make(map[string]interface{})
 		for k, v := range event.Data {
 			data[k] = v
 		}
 		outputNotifier.AgentEvent(string(event.Type), event.Agent, event.Message, data)
-	})
+	}))
 }
 
 // createWorkflowRunner creates a workflow runner with all dependencies.
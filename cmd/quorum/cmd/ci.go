@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/cli"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/github"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/state"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/fsutil"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/ci"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/tui"
+)
+
+var ciCmd = &cobra.Command{
+	Use:   "ci",
+	Short: "Run a workflow headlessly in a CI pipeline",
+	Long: `Run a complete workflow non-interactively for GitHub Actions, GitLab CI
+and other pipelines.
+
+The prompt is read from --file, or from the environment variable named by
+--prompt-env (default QUORUM_PROMPT). The run is bounded by --timeout.
+
+Exit codes:
+  0  success
+  1  usage or configuration error
+  2  low consensus, human review required
+  3  one or more tasks failed
+  4  infrastructure error (timeout, agents, state, GitHub)
+
+On GitHub Actions pull_request jobs the result is posted as a PR comment
+(disable with --no-comment). Findings can be written as SARIF for
+code-scanning upload with --sarif.`,
+	Example: `  # GitHub Actions
+  QUORUM_PROMPT="Fix the flaky auth tests" quorum ci --timeout 45m --sarif quorum.sarif
+
+  # Prompt from a file, fail when consensus is below 80%
+  quorum ci --file .github/quorum-prompt.md --min-consensus 0.8`,
+	Args: cobra.NoArgs,
+	RunE: runCI,
+}
+
+var (
+	ciFile         string
+	ciPromptEnv    string
+	ciTimeout      time.Duration
+	ciMinConsensus float64
+	ciPRNumber     int
+	ciNoComment    bool
+	ciSARIFPath    string
+	ciOutput       string
+)
+
+func init() {
+	rootCmd.AddCommand(ciCmd)
+
+	ciCmd.Flags().StringVarP(&ciFile, "file", "f", "", "Read prompt from file")
+	ciCmd.Flags().StringVar(&ciPromptEnv, "prompt-env", ci.DefaultPromptEnv, "Environment variable holding the prompt")
+	ciCmd.Flags().DurationVar(&ciTimeout, "timeout", time.Hour, "Hard timeout for the whole workflow")
+	ciCmd.Flags().Float64Var(&ciMinConsensus, "min-consensus", 0,
+		"Fail with exit code 2 when the final consensus score is below this value (0-1, 0 disables)")
+	ciCmd.Flags().IntVar(&ciPRNumber, "pr", 0, "Pull request to comment on (default: detected from GITHUB_REF)")
+	ciCmd.Flags().BoolVar(&ciNoComment, "no-comment", false, "Do not post the result as a PR comment")
+	ciCmd.Flags().StringVar(&ciSARIFPath, "sarif", "", "Write findings as SARIF 2.1.0 to this path")
+	ciCmd.Flags().StringVarP(&ciOutput, "output", "o", "plain", "Output mode (plain, json, quiet)")
+
+	ciCmd.Flags().BoolVar(&singleAgent, "single-agent", false,
+		"Run in single-agent mode (faster execution, no multi-agent consensus)")
+	ciCmd.Flags().StringVar(&agentName, "agent", "",
+		"Agent to use for single-agent mode (e.g., 'claude', 'gemini', 'codex')")
+	ciCmd.Flags().StringVar(&agentModel, "model", "",
+		"Override the agent's default model (optional, requires --single-agent)")
+}
+
+func runCI(_ *cobra.Command, _ []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := validateSingleAgentFlags(); err != nil {
+		return err
+	}
+	if ciMinConsensus < 0 || ciMinConsensus > 1 {
+		return fmt.Errorf("--min-consensus must be between 0 and 1, got %v", ciMinConsensus)
+	}
+	prompt, err := resolveCIPrompt(ciFile, ciPromptEnv, os.Getenv)
+	if err != nil {
+		return err
+	}
+
+	outputMode := tui.ParseOutputMode(ciOutput)
+	if outputMode == tui.ModeTUI {
+		outputMode = tui.ModePlain
+	}
+	eventBus := events.New(100)
+	defer eventBus.Close()
+	output := tui.NewOutputWithEventBus(outputMode, false, false, eventBus)
+	defer func() { _ = output.Close() }()
+	if jsonOutput, ok := output.(*tui.JSONOutputAdapter); ok {
+		jsonOutput.SetProjectID(GetProjectID())
+	}
+
+	loader := config.NewLoaderWithViper(viper.GetViper())
+	if cfgFile != "" {
+		loader.WithConfigFile(cfgFile)
+	}
+	cfg, err := loader.Load()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if err := config.ValidateConfig(cfg); err != nil {
+		return fmt.Errorf("validating config: %w", err)
+	}
+	projectRoot := loader.ProjectDir()
+	logger := createRunLogger(cfg, outputMode, nil)
+
+	prNumber := ciPRNumber
+	if prNumber == 0 {
+		prNumber = ci.DetectPRNumber(os.Getenv)
+	}
+	var githubClient core.GitHubClient
+	if prNumber > 0 && !ciNoComment {
+		ghClient, ghErr := github.NewClientFromRepo()
+		if ghErr != nil {
+			return withExitCode(ci.ExitInfraError, fmt.Errorf("creating GitHub client for PR #%d: %w", prNumber, ghErr))
+		}
+		githubClient = ghClient
+	}
+
+	stateManager, err := createRunStateManager(cfg, logger)
+	if err != nil {
+		return withExitCode(ci.ExitInfraError, err)
+	}
+	defer func() {
+		if closeErr := state.CloseStateManager(stateManager); closeErr != nil {
+			logger.Warn("closing state manager", "error", closeErr)
+		}
+	}()
+
+	registry := cli.NewRegistry()
+	if err := configureAgentsFromConfig(registry, cfg, loader); err != nil {
+		return withExitCode(ci.ExitInfraError, fmt.Errorf("configuring agents: %w", err))
+	}
+	runnerConfig, err := buildRunnerConfig(cfg)
+	if err != nil {
+		return err
+	}
+	runnerConfig.ConfigVersion = configVersion(ctx, loader.ConfigFile())
+	traceWriter := service.NewTraceWriter(service.TraceConfig{Mode: "off"}, logger)
+	runner, outputNotifier, err := createRunnerWithDeps(ctx, cfg, runnerConfig, stateManager, registry, logger, output, traceWriter, projectRoot)
+	if err != nil {
+		return withExitCode(ci.ExitInfraError, err)
+	}
+	registry.SetEventHandler(service.GetGlobalRateLimiter().ObserveEvents(func(event core.AgentEvent) {
+		outputNotifier.AgentEvent(string(event.Type), event.Agent, event.Message, event.Data)
+	}))
+
+	output.WorkflowStarted(prompt)
+	result := ci.Run(ctx, runner, ci.Options{
+		Prompt:       prompt,
+		Timeout:      ciTimeout,
+		MinConsensus: ciMinConsensus,
+		GitHub:       githubClient,
+		PRNumber:     prNumber,
+		SARIFPath:    ciSARIFPath,
+		ToolVersion:  GetVersion(),
+		Logger:       logger.Logger,
+	})
+
+	if result.Outcome == ci.OutcomeSuccess {
+		if st, stErr := runner.GetState(context.WithoutCancel(ctx)); stErr == nil && st != nil {
+			output.WorkflowCompleted(st)
+		}
+		return nil
+	}
+	runErr := fmt.Errorf("ci: %s: %s", result.Outcome, result.Error)
+	output.WorkflowFailed(runErr)
+	return withExitCode(result.ExitCode(), runErr)
+}
+
+// resolveCIPrompt reads the prompt from file, falling back to the named
+// environment variable.
+func resolveCIPrompt(file, envName string, getenv func(string) string) (string, error) {
+	if file != "" {
+		data, err := fsutil.ReadFileScoped(file)
+		if err != nil {
+			return "", fmt.Errorf("reading prompt file: %w", err)
+		}
+		if strings.TrimSpace(string(data)) == "" {
+			return "", fmt.Errorf("prompt file %s is empty", file)
+		}
+		return string(data), nil
+	}
+	if envName == "" {
+		envName = ci.DefaultPromptEnv
+	}
+	prompt := getenv(envName)
+	if strings.TrimSpace(prompt) == "" {
+		return "", fmt.Errorf("prompt required: set %s or use --file", envName)
+	}
+	return prompt, nil
+}
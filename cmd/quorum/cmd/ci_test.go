@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, 0, ExitCode(nil))
+	assert.Equal(t, 1, ExitCode(errors.New("bad flag")))
+	assert.Equal(t, 3, ExitCode(withExitCode(3, errors.New("task failed"))))
+	assert.Equal(t, 4, ExitCode(fmt.Errorf("wrapped: %w", withExitCode(4, errors.New("timeout")))))
+}
+
+func TestResolveCIPrompt(t *testing.T) {
+	env := map[string]string{"QUORUM_PROMPT": "from env", "CUSTOM": "custom"}
+	getenv := func(k string) string { return env[k] }
+
+	prompt, err := resolveCIPrompt("", "", getenv)
+	require.NoError(t, err)
+	assert.Equal(t, "from env", prompt)
+
+	prompt, err = resolveCIPrompt("", "CUSTOM", getenv)
+	require.NoError(t, err)
+	assert.Equal(t, "custom", prompt)
+
+	_, err = resolveCIPrompt("", "MISSING", getenv)
+	assert.ErrorContains(t, err, "MISSING")
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "prompt.md")
+	require.NoError(t, os.WriteFile(file, []byte("from file"), 0o600))
+	prompt, err = resolveCIPrompt(file, "", getenv)
+	require.NoError(t, err)
+	assert.Equal(t, "from file", prompt)
+}
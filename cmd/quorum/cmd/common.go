@@ -15,6 +15,7 @@ import (
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/github"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/state"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/confighistory"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service"
@@ -47,6 +48,7 @@ type PhaseRunnerDeps struct {
 	CheckpointAdapter *workflow.CheckpointAdapter
 	RetryAdapter      *workflow.RetryAdapter
 	RateLimiterAdapt  *workflow.RateLimiterRegistryAdapter
+	RateLimiter       *service.RateLimiterRegistry
 	PromptAdapter     *workflow.PromptRendererAdapter
 	ResumeAdapter     *workflow.ResumePointAdapter
 	DAGAdapter        *workflow.DAGAdapter
@@ -181,8 +183,9 @@ func InitPhaseRunner(ctx context.Context, phase core.Phase, maxRetries int, dryR
 			"codex":   cfg.Agents.Codex.PhaseModels,
 			"copilot": cfg.Agents.Copilot.PhaseModels,
 		},
-		WorktreeAutoClean: cfg.Git.Worktree.AutoClean,
-		WorktreeMode:      cfg.Git.Worktree.Mode,
+		WorktreeAutoClean:      cfg.Git.Worktree.AutoClean,
+		WorktreeMode:           cfg.Git.Worktree.Mode,
+		TaskCheckpointInterval: workflow.ParseTaskCheckpointInterval(cfg.Git.Task.CheckpointInterval),
 		// Refiner disabled by default for independent phase runners
 		// (only enabled when running full workflow via `run` command)
 		Refiner: workflow.RefinerConfig{
@@ -208,6 +211,8 @@ func InitPhaseRunner(ctx context.Context, phase core.Phase, maxRetries int, dryR
 			Plan:    planTimeout,
 			Execute: executeTimeout,
 		},
+		TaskInput:      workflow.NewTaskInputConfig(cfg.Phases.Execute.Input),
+		SkipDependents: cfg.Phases.Execute.SkipDependents,
 		Finalization: workflow.FinalizationConfig{
 			AutoCommit:    cfg.Git.Task.AutoCommit,
 			AutoPush:      cfg.Git.Finalization.AutoPush,
@@ -216,7 +221,9 @@ func InitPhaseRunner(ctx context.Context, phase core.Phase, maxRetries int, dryR
 			PRBaseBranch:  cfg.Git.Finalization.PRBaseBranch,
 			MergeStrategy: cfg.Git.Finalization.MergeStrategy,
 			Remote:        cfg.GitHub.Remote,
+			Babysit:       workflow.NewPRBabysitConfig(cfg.Git.Finalization.Babysit),
 		},
+		ConfigVersion: configVersion(ctx, loader.ConfigFile()),
 	}
 
 	// Create service components
@@ -290,6 +297,7 @@ func InitPhaseRunner(ctx context.Context, phase core.Phase, maxRetries int, dryR
 		CheckpointAdapter: checkpointAdapter,
 		RetryAdapter:      retryAdapter,
 		RateLimiterAdapt:  rateLimiterAdapter,
+		RateLimiter:       rateLimiterRegistry,
 		PromptAdapter:     promptAdapter,
 		ResumeAdapter:     resumeAdapter,
 		DAGAdapter:        dagAdapter,
@@ -304,6 +312,15 @@ func InitPhaseRunner(ctx context.Context, phase core.Phase, maxRetries int, dryR
 	}, nil
 }
 
+// agents returns the agent registry, rate limited when the deps carry rate
+// limiters.
+func (d *PhaseRunnerDeps) agents() core.AgentRegistry {
+	if d.RateLimiter == nil {
+		return d.Registry
+	}
+	return d.RateLimiter.WrapAgents(d.Registry)
+}
+
 // CreateWorkflowContext creates a workflow context from dependencies and state.
 func CreateWorkflowContext(deps *PhaseRunnerDeps, state *core.WorkflowState) *workflow.Context {
 	finalizationCfg := deps.RunnerConfig.Finalization
@@ -316,7 +333,7 @@ func CreateWorkflowContext(deps *PhaseRunnerDeps, state *core.WorkflowState) *wo
 
 	return &workflow.Context{
 		State:             state,
-		Agents:            deps.Registry,
+		Agents:            deps.agents(),
 		Prompts:           deps.PromptAdapter,
 		Checkpoint:        deps.CheckpointAdapter,
 		Retry:             deps.RetryAdapter,
@@ -328,16 +345,19 @@ func CreateWorkflowContext(deps *PhaseRunnerDeps, state *core.WorkflowState) *wo
 		GitHub:            deps.GitHubClient,
 		Logger:            deps.Logger,
 		Config: &workflow.Config{
-			DryRun:            deps.RunnerConfig.DryRun,
-			DenyTools:         deps.RunnerConfig.DenyTools,
-			DefaultAgent:      deps.RunnerConfig.DefaultAgent,
-			AgentPhaseModels:  deps.RunnerConfig.AgentPhaseModels,
-			WorktreeAutoClean: deps.RunnerConfig.WorktreeAutoClean,
-			WorktreeMode:      deps.RunnerConfig.WorktreeMode,
-			PhaseTimeouts:     deps.RunnerConfig.PhaseTimeouts,
-			Moderator:         deps.ModeratorConfig,
-			SingleAgent:       deps.RunnerConfig.SingleAgent,
-			Finalization:      finalizationCfg,
+			DryRun:                 deps.RunnerConfig.DryRun,
+			DenyTools:              deps.RunnerConfig.DenyTools,
+			DefaultAgent:           deps.RunnerConfig.DefaultAgent,
+			AgentPhaseModels:       deps.RunnerConfig.AgentPhaseModels,
+			WorktreeAutoClean:      deps.RunnerConfig.WorktreeAutoClean,
+			WorktreeMode:           deps.RunnerConfig.WorktreeMode,
+			TaskCheckpointInterval: deps.RunnerConfig.TaskCheckpointInterval,
+			PhaseTimeouts:          deps.RunnerConfig.PhaseTimeouts,
+			TaskInput:              deps.RunnerConfig.TaskInput,
+			SkipDependents:         deps.RunnerConfig.SkipDependents,
+			Moderator:              deps.ModeratorConfig,
+			SingleAgent:            deps.RunnerConfig.SingleAgent,
+			Finalization:           finalizationCfg,
 		},
 	}
 }
@@ -527,3 +547,17 @@ func validateSingleAgentFlags() error {
 	}
 	return nil
 }
+
+// configVersion returns the history version of the config file at path, so
+// workflows record which configuration they ran with. It is best effort: 0
+// when no config file was used or the history is unavailable.
+func configVersion(ctx context.Context, path string) int {
+	if path == "" {
+		return 0
+	}
+	v, err := confighistory.CurrentVersion(ctx, path)
+	if err != nil {
+		return 0
+	}
+	return v.Version
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/confighistory"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/tui"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect, validate and edit the configuration",
+	Long: `Inspect, validate and edit the configuration, and roll back changes.
+
+Every write made by quorum (init, open, config set, the web UI and the API) is
+recorded with when, how and by whom it was made. Edits made by hand are
+recorded as "external" versions the next time quorum reads or writes the file.`,
+}
+
+var configHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List the recorded versions of the configuration",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigHistory,
+}
+
+var configRollbackCmd = &cobra.Command{
+	Use:   "rollback <version>",
+	Short: "Restore a recorded version of the configuration",
+	Long: `Restore a recorded version of the configuration.
+
+The version is validated before it replaces the current file, and the rollback
+is recorded as a new version so it can itself be undone.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigRollback,
+}
+
+var (
+	configGlobal          bool
+	configHistoryLimit    int
+	configHistoryDiff     bool
+	configHistoryOutput   string
+	configRollbackMessage string
+)
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configHistoryCmd)
+	configCmd.AddCommand(configRollbackCmd)
+
+	configCmd.PersistentFlags().BoolVar(&configGlobal, "global", false, "Use the global configuration instead of the project's")
+
+	configHistoryCmd.Flags().IntVarP(&configHistoryLimit, "limit", "n", 20, "Maximum number of versions to show (0 for all)")
+	configHistoryCmd.Flags().BoolVar(&configHistoryDiff, "diff", false, "Show the diff of each version")
+	configHistoryCmd.Flags().StringVarP(&configHistoryOutput, "output", "o", "", "Output mode (plain, json)")
+
+	configRollbackCmd.Flags().StringVarP(&configRollbackMessage, "message", "m", "", "Reason for the rollback")
+}
+
+// configFilePath resolves the config file the config subcommands work on:
+// --global, --config, or the project's .quorum/config.yaml.
+func configFilePath() (string, error) {
+	if configGlobal {
+		return config.EnsureGlobalConfigFile()
+	}
+	return projectConfigPath()
+}
+
+// projectConfigPath resolves --config, or the project's .quorum/config.yaml.
+func projectConfigPath() (string, error) {
+	if cfgFile != "" {
+		return filepath.Abs(cfgFile)
+	}
+	path, err := filepath.Abs(filepath.Join(".quorum", "config.yaml"))
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("no project configuration at %s (use --global for the global configuration)", path)
+	}
+	return path, nil
+}
+
+func runConfigHistory(_ *cobra.Command, _ []string) error {
+	ctx := context.Background()
+	path, err := configFilePath()
+	if err != nil {
+		return err
+	}
+
+	detector := tui.NewDetector()
+	if configHistoryOutput != "" {
+		detector.ForceMode(tui.ParseOutputMode(configHistoryOutput))
+	}
+	outputMode := detector.Detect()
+
+	// Record the file as it is now, so manual edits show up in the history.
+	if _, err := confighistory.CurrentVersion(ctx, path); err != nil {
+		return fmt.Errorf("reading config history: %w", err)
+	}
+	store, err := confighistory.OpenFor(path)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	versions, err := store.List(ctx, configHistoryLimit)
+	if err != nil {
+		return err
+	}
+
+	if outputMode == tui.ModeJSON {
+		if versions == nil {
+			versions = []*confighistory.Version{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(versions)
+	}
+
+	fmt.Printf("History of %s\n\n", path)
+	if configHistoryDiff {
+		for _, v := range versions {
+			fmt.Printf("Version %d  %s  %s  %s\n", v.Version, formatWorkflowTime(v.CreatedAt), v.Source, v.Actor)
+			if v.Message != "" {
+				fmt.Printf("    %s\n", v.Message)
+			}
+			fmt.Println()
+			fmt.Println(v.Diff)
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tCREATED\tSOURCE\tACTOR\tMESSAGE")
+	fmt.Fprintln(w, "-------\t-------\t------\t-----\t-------")
+	for _, v := range versions {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", v.Version, formatWorkflowTime(v.CreatedAt), v.Source, v.Actor, truncateString(v.Message, 60))
+	}
+	return w.Flush()
+}
+
+func runConfigRollback(_ *cobra.Command, args []string) error {
+	version, err := strconv.Atoi(args[0])
+	if err != nil || version < 1 {
+		return fmt.Errorf("invalid version %q", args[0])
+	}
+	path, err := configFilePath()
+	if err != nil {
+		return err
+	}
+
+	v, err := confighistory.Rollback(context.Background(), path, version, confighistory.Change{
+		Source:  confighistory.SourceCLI,
+		Message: configRollbackMessage,
+	})
+	if err != nil {
+		return fmt.Errorf("rolling back config: %w", err)
+	}
+
+	if quiet {
+		fmt.Println(v.Version)
+		return nil
+	}
+	fmt.Printf("Restored version %d of %s as version %d\n", version, path, v.Version)
+	return nil
+}
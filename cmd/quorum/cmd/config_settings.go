@@ -0,0 +1,581 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/api"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/confighistory"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/tui"
+)
+
+var configShowCmd = &cobra.Command{
+	Use:   "show [prefix]",
+	Short: "Show the effective configuration",
+	Long: `Show the effective configuration: built-in defaults, overridden by the config
+file, QUORUM_* environment variables and command-line flags, in that order.
+
+With --origin, each key is shown with the layer that set it. A prefix such as
+"agents.claude" limits the output to the keys under it.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigShow,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the effective value of a configuration key",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a key in the configuration file",
+	Long: `Set a key in the configuration file, keeping its comments.
+
+The value is checked against the key's type and allowed values, and the whole
+file is validated before it is written. Lists are given comma-separated. The
+change is recorded in the configuration history.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runConfigSet,
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the configuration file",
+	Long: `Validate the configuration file and report errors with their line numbers.
+
+Unknown keys, which quorum ignores, and legacy keys, which "quorum config
+migrate" rewrites, are reported as warnings.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigValidate,
+}
+
+var configDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare the project configuration with the defaults or the global one",
+	Long: `List the keys whose values in the project configuration differ from the
+built-in defaults, or from the global configuration with --global.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigDiff,
+}
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Rewrite legacy keys in the configuration file",
+	Long: `Rewrite the legacy keys of the configuration file (e.g. "maxretries" or
+"git.worktree_dir") to their current names. Comments are kept and the change
+is recorded in the configuration history.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigMigrate,
+}
+
+var (
+	configShowOrigin   bool
+	configShowOutput   string
+	configMigrateCheck bool
+)
+
+// configFlagKeys maps the config keys bound to persistent flags in root.go to
+// the flags' names.
+var configFlagKeys = map[string]string{
+	"log.level":  "log-level",
+	"log.format": "log-format",
+}
+
+func init() {
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configDiffCmd)
+	configCmd.AddCommand(configMigrateCmd)
+
+	for _, c := range []*cobra.Command{configShowCmd, configGetCmd} {
+		c.Flags().BoolVar(&configShowOrigin, "origin", false, "Show the layer each value comes from")
+	}
+	for _, c := range []*cobra.Command{configShowCmd, configValidateCmd, configDiffCmd} {
+		c.Flags().StringVarP(&configShowOutput, "output", "o", "", "Output mode (plain, json)")
+	}
+	configMigrateCmd.Flags().BoolVar(&configMigrateCheck, "check", false, "Only list the legacy keys, exit non-zero if there are any")
+}
+
+// loadConfigSettings loads the effective configuration as quorum commands
+// do, or the global one with --global, and returns its settings.
+func loadConfigSettings() ([]config.Setting, error) {
+	loader := config.NewLoaderWithViper(viper.GetViper())
+	flags := make(map[string]string)
+	if configGlobal {
+		path, err := config.EnsureGlobalConfigFile()
+		if err != nil {
+			return nil, err
+		}
+		loader = config.NewLoader().WithConfigFile(path)
+	} else {
+		if cfgFile != "" {
+			loader.WithConfigFile(cfgFile)
+		}
+		for key, flag := range configFlagKeys {
+			if rootCmd.PersistentFlags().Changed(flag) {
+				flags[key] = flag
+			}
+		}
+	}
+	cfg, err := loader.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	return loader.Settings(cfg, flags)
+}
+
+// configOutputMode returns the output mode chosen with -o, or detected.
+func configOutputMode() tui.OutputMode {
+	detector := tui.NewDetector()
+	if configShowOutput != "" {
+		detector.ForceMode(tui.ParseOutputMode(configShowOutput))
+	}
+	return detector.Detect()
+}
+
+func runConfigShow(_ *cobra.Command, args []string) error {
+	settings, err := loadConfigSettings()
+	if err != nil {
+		return err
+	}
+	if len(args) == 1 {
+		settings = filterSettings(settings, args[0])
+		if len(settings) == 0 {
+			return fmt.Errorf("unknown config key %q", args[0])
+		}
+	}
+
+	if configOutputMode() == tui.ModeJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(settings)
+	}
+	return printSettings(settings)
+}
+
+func runConfigGet(_ *cobra.Command, args []string) error {
+	settings, err := loadConfigSettings()
+	if err != nil {
+		return err
+	}
+	settings = filterSettings(settings, args[0])
+	switch {
+	case len(settings) == 0:
+		return fmt.Errorf("unknown config key %q", args[0])
+	case len(settings) == 1 && settings[0].Key == args[0] && !configShowOrigin:
+		fmt.Println(formatSettingValue(settings[0].Value))
+		return nil
+	default:
+		return printSettings(settings)
+	}
+}
+
+// filterSettings returns the settings at key or under it.
+func filterSettings(settings []config.Setting, key string) []config.Setting {
+	var out []config.Setting
+	for _, s := range settings {
+		if s.Key == key || strings.HasPrefix(s.Key, key+".") {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func printSettings(settings []config.Setting) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, s := range settings {
+		if configShowOrigin {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Key, formatSettingValue(s.Value), s.Origin, s.Source)
+		} else {
+			fmt.Fprintf(w, "%s\t%s\n", s.Key, formatSettingValue(s.Value))
+		}
+	}
+	return w.Flush()
+}
+
+func formatSettingValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		if val == "" {
+			return `""`
+		}
+		return val
+	case []interface{}, map[string]interface{}:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprint(val)
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+func runConfigSet(_ *cobra.Command, args []string) error {
+	key, raw := args[0], args[1]
+	path, err := configFilePath()
+	if err != nil {
+		return err
+	}
+	value, err := setConfigKey(path, key, raw, confighistory.Change{
+		Source:  confighistory.SourceCLI,
+		Message: fmt.Sprintf("quorum config set %s=%s", key, raw),
+	})
+	if err != nil {
+		return err
+	}
+	if !quiet {
+		fmt.Printf("Set %s = %s in %s\n", key, formatSettingValue(value), path)
+	}
+	return nil
+}
+
+// setConfigKey sets key to the command-line value raw in the config file at
+// path, keeping its comments, and records the write in the configuration
+// history. It returns the parsed value.
+func setConfigKey(path, key, raw string, change confighistory.Change) (interface{}, error) {
+	value, err := parseConfigValue(key, raw)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- config path chosen by the user
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+	content, err := config.SetKey(data, key, value)
+	if err != nil {
+		return nil, fmt.Errorf("setting %s: %w", key, err)
+	}
+	if _, err := confighistory.Write(context.Background(), path, content, change); err != nil {
+		return nil, fmt.Errorf("setting %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// parseConfigValue converts a command-line value to the type of key, checking
+// it against the key's schema when there is one.
+func parseConfigValue(key, raw string) (interface{}, error) {
+	t, ok := config.KeyType(key)
+	if !ok {
+		return nil, fmt.Errorf("unknown config key %q", key)
+	}
+
+	var value interface{}
+	var err error
+	switch t.Kind() {
+	case reflect.String:
+		value = raw
+	case reflect.Bool:
+		value, err = strconv.ParseBool(raw)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		value, err = strconv.ParseInt(raw, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		value, err = strconv.ParseFloat(raw, 64)
+	case reflect.Slice:
+		if t.Elem().Kind() != reflect.String {
+			return nil, fmt.Errorf("%s cannot be set from the command line; edit the file instead", key)
+		}
+		items := []string{}
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		value = items
+	default:
+		return nil, fmt.Errorf("%s is a section; set one of its keys", key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %q is not a valid %s", key, raw, t.Kind())
+	}
+
+	field, ok := api.BuildConfigSchema().Field(key)
+	if !ok {
+		return value, nil
+	}
+	if field.Type == "duration" {
+		if _, err := time.ParseDuration(raw); err != nil {
+			return nil, fmt.Errorf("%s: %q is not a valid duration (e.g. 30s, 5m, 2h)", key, raw)
+		}
+	}
+	if len(field.ValidValues) > 0 {
+		values := []string{raw}
+		if items, ok := value.([]string); ok {
+			values = items
+		}
+		for _, v := range values {
+			if !slices.Contains(field.ValidValues, v) {
+				return nil, fmt.Errorf("%s: %q is not one of: %s", key, v, strings.Join(field.ValidValues, ", "))
+			}
+		}
+	}
+	if n, err := strconv.ParseFloat(raw, 64); err == nil {
+		if field.Min != nil && n < *field.Min {
+			return nil, fmt.Errorf("%s: must be at least %v", key, *field.Min)
+		}
+		if field.Max != nil && n > *field.Max {
+			return nil, fmt.Errorf("%s: must be at most %v", key, *field.Max)
+		}
+	}
+	return value, nil
+}
+
+// configProblem is an error or warning found by config validate.
+type configProblem struct {
+	Line    int         `json:"line,omitempty"`
+	Key     string      `json:"key,omitempty"`
+	Message string      `json:"message"`
+	Value   interface{} `json:"value,omitempty"`
+}
+
+func runConfigValidate(_ *cobra.Command, _ []string) error {
+	path, err := configFilePath()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- config path chosen by the user
+	if err != nil {
+		return fmt.Errorf("reading config: %w", err)
+	}
+
+	var problems, warnings []configProblem
+	issues, err := config.CheckKeys(data)
+	if err != nil {
+		problems = append(problems, configProblem{Message: err.Error()})
+	}
+	for _, issue := range issues {
+		msg := issue.Message
+		if issue.Legacy {
+			msg += ` (run "quorum config migrate")`
+		}
+		warnings = append(warnings, configProblem{Line: issue.Line, Key: issue.Key, Message: msg})
+	}
+
+	if len(problems) == 0 {
+		cfg, err := config.NewLoader().WithConfigFile(path).Load()
+		if err != nil {
+			problems = append(problems, configProblem{Message: err.Error()})
+		} else {
+			v := config.NewValidator()
+			_ = v.Validate(cfg)
+			for _, e := range v.Errors() {
+				problems = append(problems, configProblem{
+					Line:    config.KeyLine(data, e.Field),
+					Key:     e.Field,
+					Message: e.Message,
+					Value:   e.Value,
+				})
+			}
+		}
+	}
+
+	if configOutputMode() == tui.ModeJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(map[string]interface{}{
+			"path":     path,
+			"valid":    len(problems) == 0,
+			"errors":   emptyIfNil(problems),
+			"warnings": emptyIfNil(warnings),
+		}); err != nil {
+			return err
+		}
+	} else {
+		for _, w := range warnings {
+			fmt.Printf("%s: warning: %s\n", problemLocation(path, w), w.Message)
+		}
+		for _, p := range problems {
+			msg := p.Message
+			if p.Value != nil {
+				msg = fmt.Sprintf("%s (got: %v)", msg, p.Value)
+			}
+			fmt.Printf("%s: error: %s\n", problemLocation(path, p), msg)
+		}
+		if len(problems) == 0 && !quiet {
+			fmt.Printf("%s is valid\n", path)
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%s has %d error(s)", path, len(problems))
+	}
+	return nil
+}
+
+// problemLocation formats path:line: key, leaving out what is unknown.
+func problemLocation(path string, p configProblem) string {
+	loc := path
+	if p.Line > 0 {
+		loc += ":" + strconv.Itoa(p.Line)
+	}
+	if p.Key != "" {
+		loc += ": " + p.Key
+	}
+	return loc
+}
+
+func emptyIfNil(problems []configProblem) []configProblem {
+	if problems == nil {
+		return []configProblem{}
+	}
+	return problems
+}
+
+// configDifference is a key whose project value differs from the base one.
+type configDifference struct {
+	Key     string      `json:"key"`
+	Base    interface{} `json:"base"`
+	Project interface{} `json:"project"`
+}
+
+func runConfigDiff(_ *cobra.Command, _ []string) error {
+	path, err := projectConfigPath()
+	if err != nil {
+		return err
+	}
+	project, err := loadFlatConfig(path)
+	if err != nil {
+		return err
+	}
+
+	baseName := "DEFAULT"
+	var base map[string]interface{}
+	if configGlobal {
+		baseName = "GLOBAL"
+		globalPath, err := config.EnsureGlobalConfigFile()
+		if err != nil {
+			return err
+		}
+		if base, err = loadFlatConfig(globalPath); err != nil {
+			return err
+		}
+	} else {
+		defaults, err := config.Defaults()
+		if err != nil {
+			return err
+		}
+		if base, err = config.Flatten(defaults); err != nil {
+			return err
+		}
+	}
+
+	diffs := diffFlatConfigs(base, project)
+	if configOutputMode() == tui.ModeJSON {
+		if diffs == nil {
+			diffs = []configDifference{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(diffs)
+	}
+
+	if len(diffs) == 0 {
+		fmt.Printf("%s does not differ from the %s configuration\n", path, strings.ToLower(baseName))
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "KEY\t%s\tPROJECT\n", baseName)
+	fmt.Fprintf(w, "---\t%s\t-------\n", strings.Repeat("-", len(baseName)))
+	for _, d := range diffs {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", d.Key, formatDiffValue(d.Base), formatDiffValue(d.Project))
+	}
+	return w.Flush()
+}
+
+// loadFlatConfig loads a config file on its own, keeping relative paths as
+// written, and flattens it.
+func loadFlatConfig(path string) (map[string]interface{}, error) {
+	cfg, err := config.NewLoader().WithConfigFile(path).WithResolvePaths(false).Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", path, err)
+	}
+	return config.Flatten(cfg)
+}
+
+func diffFlatConfigs(base, project map[string]interface{}) []configDifference {
+	keys := make(map[string]bool)
+	for k := range base {
+		keys[k] = true
+	}
+	for k := range project {
+		keys[k] = true
+	}
+	var diffs []configDifference
+	for k := range keys {
+		b, inBase := base[k]
+		p, inProject := project[k]
+		if inBase && inProject && reflect.DeepEqual(b, p) {
+			continue
+		}
+		diffs = append(diffs, configDifference{Key: k, Base: b, Project: p})
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Key < diffs[j].Key })
+	return diffs
+}
+
+func formatDiffValue(v interface{}) string {
+	if v == nil {
+		return "(unset)"
+	}
+	return formatSettingValue(v)
+}
+
+func runConfigMigrate(_ *cobra.Command, _ []string) error {
+	path, err := configFilePath()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- config path chosen by the user
+	if err != nil {
+		return fmt.Errorf("reading config: %w", err)
+	}
+	content, changes, err := config.MigrateLegacyKeys(data)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if len(changes) == 0 {
+		if !quiet {
+			fmt.Printf("%s has no legacy keys\n", path)
+		}
+		return nil
+	}
+
+	for _, c := range changes {
+		fmt.Printf("%s:%d: %s: %s\n", path, c.Line, c.Key, c.Message)
+	}
+	if configMigrateCheck {
+		return errors.New("legacy keys found")
+	}
+
+	_, err = confighistory.Write(context.Background(), path, content, confighistory.Change{
+		Source:  confighistory.SourceCLI,
+		Message: "quorum config migrate",
+	})
+	if err != nil {
+		return fmt.Errorf("migrating config: %w", err)
+	}
+	if !quiet {
+		fmt.Printf("Rewrote %d legacy key(s) in %s\n", len(changes), path)
+	}
+	return nil
+}
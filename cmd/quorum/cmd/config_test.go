@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/confighistory"
+)
+
+func TestConfigRollback_RestoresInitVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	defer os.Chdir(oldDir)
+	require.NoError(t, os.Chdir(tmpDir))
+
+	initForce = false
+	require.NoError(t, runInit(initCmd, []string{}))
+
+	configPath := filepath.Join(tmpDir, ".quorum", "config.yaml")
+	edited := config.DefaultConfigYAML + "\nlog:\n  level: debug\n"
+	require.NoError(t, os.WriteFile(configPath, []byte(edited), 0o600))
+
+	configGlobal = false
+	configHistoryOutput = "json"
+	require.NoError(t, runConfigHistory(configHistoryCmd, nil))
+
+	configRollbackMessage = "undo manual edit"
+	defer func() { configHistoryOutput, configRollbackMessage = "", "" }()
+	require.NoError(t, runConfigRollback(configRollbackCmd, []string{"1"}))
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, config.DefaultConfigYAML, string(data))
+
+	store, err := confighistory.OpenFor(configPath)
+	require.NoError(t, err)
+	defer store.Close()
+	versions, err := store.List(context.Background(), 0)
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	assert.Equal(t, confighistory.SourceCLI, versions[0].Source)
+	assert.Equal(t, 1, versions[0].RollbackOf)
+	assert.Equal(t, "undo manual edit", versions[0].Message)
+	assert.Equal(t, confighistory.SourceExternal, versions[1].Source)
+	assert.Equal(t, "quorum init", versions[2].Message)
+
+	assert.Error(t, runConfigRollback(configRollbackCmd, []string{"abc"}))
+}
+
+func TestConfigSetValidateMigrate(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	defer os.Chdir(oldDir)
+	require.NoError(t, os.Chdir(tmpDir))
+
+	initForce = false
+	require.NoError(t, runInit(initCmd, []string{}))
+	configPath := filepath.Join(tmpDir, ".quorum", "config.yaml")
+
+	configGlobal = false
+	assert.Error(t, runConfigSet(configSetCmd, []string{"log.level", "verbose"}))
+	assert.Error(t, runConfigSet(configSetCmd, []string{"workflow.max_retries", "many"}))
+	assert.Error(t, runConfigSet(configSetCmd, []string{"workflow.max_retries", "50"}), "rejected by the validator")
+	assert.Error(t, runConfigSet(configSetCmd, []string{"workflow", "x"}))
+	assert.Error(t, runConfigSet(configSetCmd, []string{"no.such.key", "x"}))
+	require.NoError(t, runConfigSet(configSetCmd, []string{"workflow.max_retries", "5"}))
+	require.NoError(t, runConfigSet(configSetCmd, []string{"issues.labels", "a, b"}))
+
+	cfg, err := config.NewLoader().WithConfigFile(configPath).Load()
+	require.NoError(t, err)
+	assert.Equal(t, 5, cfg.Workflow.MaxRetries)
+	assert.Equal(t, []string{"a", "b"}, cfg.Issues.Labels)
+
+	configShowOutput = "json"
+	defer func() { configShowOutput = "" }()
+	require.NoError(t, runConfigValidate(configValidateCmd, nil))
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	legacy := strings.Replace(string(data), "max_retries: 5", "maxretries: 7", 1)
+	require.NoError(t, os.WriteFile(configPath, []byte(legacy), 0o600))
+
+	configMigrateCheck = true
+	assert.Error(t, runConfigMigrate(configMigrateCmd, nil))
+	configMigrateCheck = false
+	require.NoError(t, runConfigMigrate(configMigrateCmd, nil))
+
+	cfg, err = config.NewLoader().WithConfigFile(configPath).Load()
+	require.NoError(t, err)
+	assert.Equal(t, 7, cfg.Workflow.MaxRetries)
+	data, err = os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "maxretries")
+
+	store, err := confighistory.OpenFor(configPath)
+	require.NoError(t, err)
+	defer store.Close()
+	versions, err := store.List(context.Background(), 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, versions)
+	assert.Equal(t, "quorum config migrate", versions[0].Message)
+
+	invalid := strings.Replace(string(data), "max_retries: 7", "max_retries: 70", 1)
+	require.NoError(t, os.WriteFile(configPath, []byte(invalid), 0o600))
+	assert.Error(t, runConfigValidate(configValidateCmd, nil))
+}
+
+func TestDiffFlatConfigs(t *testing.T) {
+	t.Parallel()
+	base := map[string]interface{}{"log.level": "info", "trace.mode": "off", "issues.labels": []interface{}{"a"}}
+	project := map[string]interface{}{"log.level": "debug", "trace.mode": "off", "issues.labels": []interface{}{"a"},
+		"agents.claude.phases.plan": true}
+
+	diffs := diffFlatConfigs(base, project)
+	require.Len(t, diffs, 2)
+	assert.Equal(t, configDifference{Key: "agents.claude.phases.plan", Project: true}, diffs[0])
+	assert.Equal(t, configDifference{Key: "log.level", Base: "info", Project: "debug"}, diffs[1])
+}
+
+func TestSetConfigKey_RecordsSource(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	defer os.Chdir(oldDir)
+	require.NoError(t, os.Chdir(tmpDir))
+
+	initForce = false
+	require.NoError(t, runInit(initCmd, []string{}))
+	configPath := filepath.Join(tmpDir, ".quorum", "config.yaml")
+
+	_, err := setConfigKey(configPath, "log.level", "debug", confighistory.Change{
+		Source:  confighistory.SourceTUI,
+		Message: "/config set log.level=debug",
+	})
+	require.NoError(t, err)
+
+	store, err := confighistory.OpenFor(configPath)
+	require.NoError(t, err)
+	defer store.Close()
+	versions, err := store.List(context.Background(), 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, versions)
+	assert.Equal(t, confighistory.SourceTUI, versions[0].Source)
+	assert.Equal(t, "/config set log.level=debug", versions[0].Message)
+}
@@ -162,6 +162,7 @@ func runExecute(_ *cobra.Command, _ []string) error {
 				CLI:          taskState.CLI,
 				Model:        taskState.Model,
 				Dependencies: taskState.Dependencies,
+				Repository:   taskState.Repository,
 			}
 			_ = deps.DAGAdapter.AddTask(task)
 		}
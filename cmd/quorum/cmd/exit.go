@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+)
+
+// exitError carries a specific process exit code through cobra's error path.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit status %d", e.code)
+	}
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error { return e.err }
+
+// withExitCode wraps err so that the process exits with code.
+func withExitCode(code int, err error) error {
+	return &exitError{code: code, err: err}
+}
+
+// ExitCode returns the process exit code for an error returned by Execute.
+// Commands that need distinct exit codes (e.g. `quorum ci`) wrap their errors
+// with withExitCode; every other error maps to 1.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var ee *exitError
+	if errors.As(err, &ee) {
+		return ee.code
+	}
+	return 1
+}
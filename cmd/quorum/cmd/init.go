@@ -1,12 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/confighistory"
 	"github.com/spf13/cobra"
 )
 
@@ -54,9 +56,16 @@ func runInit(_ *cobra.Command, _ []string) error {
 	}
 
 	// Create default config using shared constant
+	previous, _ := confighistory.ReadFile(configPath)
 	if err := os.WriteFile(configPath, []byte(config.DefaultConfigYAML), 0o600); err != nil {
 		return fmt.Errorf("writing config: %w", err)
 	}
+	if _, err := confighistory.RecordWrite(context.Background(), configPath, previous, confighistory.Change{
+		Source:  confighistory.SourceCLI,
+		Message: "quorum init",
+	}); err != nil {
+		fmt.Printf("Warning: Could not record config history: %v\n", err)
+	}
 
 	// Create directories
 	dirs := []string{
@@ -36,7 +36,7 @@ var newInteractiveRunnerFn = func(deps *PhaseRunnerDeps, output tui.Output) (res
 	return workflow.NewRunner(workflow.RunnerDeps{
 		Config:            deps.RunnerConfig,
 		State:             deps.StateAdapter,
-		Agents:            deps.Registry,
+		Agents:            deps.agents(),
 		DAG:               deps.DAGAdapter,
 		Checkpoint:        deps.CheckpointAdapter,
 		ResumeProvider:    deps.ResumeAdapter,
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/github"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/issues"
+)
+
+// fetchIssueSource resolves an issue reference (number, owner/repo#number or
+// URL) and reads the issue with its comments. The repository named by the
+// reference wins over issues.repository, which wins over the git remote.
+func fetchIssueSource(ctx context.Context, cfg config.IssuesConfig, ref string) (*issues.Source, error) {
+	repository, number, err := issues.ParseIssueRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	if repository == "" {
+		repository = cfg.Repository
+	}
+
+	provider := core.IssueProvider(cfg.Provider)
+	if provider == "" {
+		provider = core.IssueProviderGitHub
+	}
+	if provider != core.IssueProviderGitHub {
+		return nil, fmt.Errorf("--from-issue is not supported for issue provider %s", provider)
+	}
+
+	var client core.IssueClient
+	if owner, repo, ok := strings.Cut(repository, "/"); ok {
+		client, err = github.NewIssueClient(owner, repo)
+	} else {
+		client, err = github.NewIssueClientFromRepo()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating issue client: %w", err)
+	}
+
+	src, err := issues.FetchSource(ctx, client, provider, repository, number)
+	if err != nil {
+		return nil, fmt.Errorf("fetching issue #%d: %w", number, err)
+	}
+	return src, nil
+}
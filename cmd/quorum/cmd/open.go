@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/confighistory"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
 )
 
@@ -233,9 +234,15 @@ func initializeProjectWithMode(absPath string, force, createProjectConfig bool)
 
 	if createProjectConfig {
 		// Create default config
+		previous, _ := confighistory.ReadFile(configPath)
 		if err := os.WriteFile(configPath, []byte(config.DefaultConfigYAML), 0o600); err != nil {
 			return fmt.Errorf("writing config: %w", err)
 		}
+		// History is best effort: the project is usable without it.
+		_, _ = confighistory.RecordWrite(context.Background(), configPath, previous, confighistory.Change{
+			Source:  confighistory.SourceCLI,
+			Message: "quorum open",
+		})
 	} else if force {
 		// Explicitly force inheriting global config by removing project config.
 		if err := os.Remove(configPath); err != nil && !os.IsNotExist(err) {
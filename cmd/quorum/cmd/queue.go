@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/runqueue"
+)
+
+var queueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "Inspect and reorder the run queue of quorum serve",
+	Long: `Show the durable run queue of quorum serve: the workflow runs executing
+under a lease, then the queued runs in the order they will start.
+
+The queue is enabled with queue.enabled in the server config. Changes made
+here are picked up by a running server within seconds.
+
+Examples:
+  quorum queue
+  quorum queue priority 3f2a 10
+  quorum queue move 3f2a 1
+  quorum queue remove wf-20250101-abc`,
+	Args: cobra.NoArgs,
+	RunE: runQueueList,
+}
+
+var queuePriorityCmd = &cobra.Command{
+	Use:   "priority <item> <priority>",
+	Short: "Change the priority of a queued run",
+	Long: `Change the priority of a queued run. Higher priorities start first; runs of
+equal priority start in queue order.
+
+<item> is a queue item ID, a unique prefix of one, or a workflow ID.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runQueuePriority,
+}
+
+var queueMoveCmd = &cobra.Command{
+	Use:   "move <item> <position>",
+	Short: "Move a queued run to a position in the queue",
+	Long: `Move a queued run to a 1-based position in the queue. The run takes the
+priority of the runs around its new place.
+
+<item> is a queue item ID, a unique prefix of one, or a workflow ID.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runQueueMove,
+}
+
+var queueRemoveCmd = &cobra.Command{
+	Use:   "remove <item>",
+	Short: "Remove a queued run",
+	Long: `Remove a run from the queue without starting it. Runs already executing
+cannot be removed; cancel their workflow instead.
+
+<item> is a queue item ID, a unique prefix of one, or a workflow ID.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runQueueRemove,
+}
+
+var queueJSON bool
+
+func init() {
+	rootCmd.AddCommand(queueCmd)
+	queueCmd.AddCommand(queuePriorityCmd)
+	queueCmd.AddCommand(queueMoveCmd)
+	queueCmd.AddCommand(queueRemoveCmd)
+
+	queueCmd.Flags().BoolVar(&queueJSON, "json", false, "Output as JSON")
+}
+
+// openRunQueue opens the queue database configured by queue.path.
+func openRunQueue() (*runqueue.Store, error) {
+	loader := config.NewLoaderWithViper(viper.GetViper())
+	if cfgFile != "" {
+		loader.WithConfigFile(cfgFile)
+	}
+	cfg, err := loader.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	path := cfg.Queue.Path
+	if path == "" {
+		if path, err = runqueue.DefaultPath(); err != nil {
+			return nil, err
+		}
+	}
+	return runqueue.Open(path)
+}
+
+// resolveQueueItem finds the item a command argument refers to: an item ID,
+// a unique prefix of one, or the ID of a queued workflow.
+func resolveQueueItem(ctx context.Context, store *runqueue.Store, ref string) (*runqueue.Item, error) {
+	items, err := store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var matches []*runqueue.Item
+	for _, item := range items {
+		if item.ID == ref || item.WorkflowID == ref {
+			return item, nil
+		}
+		if strings.HasPrefix(item.ID, ref) {
+			matches = append(matches, item)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no queue item matches %q", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("%q matches %d queue items", ref, len(matches))
+	}
+}
+
+func runQueueList(_ *cobra.Command, _ []string) error {
+	store, err := openRunQueue()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	items, err := store.List(context.Background())
+	if err != nil {
+		return err
+	}
+	if queueJSON {
+		if items == nil {
+			items = []*runqueue.Item{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+	}
+	if len(items) == 0 {
+		fmt.Println("The run queue is empty.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "POS\tID\tWORKFLOW\tPROJECT\tPRIORITY\tSTATE\tWAITING\tNOTE")
+	fmt.Fprintln(w, "───\t──\t────────\t───────\t────────\t─────\t───────\t────")
+	for _, item := range items {
+		pos := "-"
+		if item.State == runqueue.StateQueued {
+			pos = strconv.Itoa(item.Position)
+		}
+		state := string(item.State)
+		if item.State == runqueue.StateLeased {
+			state = "running"
+		}
+		project := item.ProjectID
+		if project == "" {
+			project = "-"
+		}
+		note := item.LastError
+		if item.NotBefore != nil && item.NotBefore.After(time.Now()) {
+			note = fmt.Sprintf("retry in %s: %s", time.Until(*item.NotBefore).Round(time.Second), note)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\n",
+			pos, item.ID[:8], item.WorkflowID, project, item.Priority, state,
+			time.Since(item.EnqueuedAt).Round(time.Second), note)
+	}
+	return w.Flush()
+}
+
+func runQueuePriority(_ *cobra.Command, args []string) error {
+	priority, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid priority %q", args[1])
+	}
+	return updateQueueItem(args[0], func(ctx context.Context, store *runqueue.Store, id string) (*runqueue.Item, error) {
+		return store.SetPriority(ctx, id, priority)
+	})
+}
+
+func runQueueMove(_ *cobra.Command, args []string) error {
+	position, err := strconv.Atoi(args[1])
+	if err != nil || position < 1 {
+		return fmt.Errorf("invalid position %q", args[1])
+	}
+	return updateQueueItem(args[0], func(ctx context.Context, store *runqueue.Store, id string) (*runqueue.Item, error) {
+		return store.Move(ctx, id, position)
+	})
+}
+
+func runQueueRemove(_ *cobra.Command, args []string) error {
+	item, err := withQueueItem(args[0], func(ctx context.Context, store *runqueue.Store, id string) (*runqueue.Item, error) {
+		return store.Remove(ctx, id)
+	})
+	if err != nil {
+		return err
+	}
+	if !quiet {
+		fmt.Printf("Removed the queued run of %s.\n", item.WorkflowID)
+	}
+	return nil
+}
+
+// updateQueueItem applies a change to a queued item and prints its new place.
+func updateQueueItem(ref string, update func(context.Context, *runqueue.Store, string) (*runqueue.Item, error)) error {
+	item, err := withQueueItem(ref, update)
+	if err != nil {
+		return err
+	}
+	if !quiet {
+		fmt.Printf("%s is at position %d with priority %d.\n", item.WorkflowID, item.Position, item.Priority)
+	}
+	return nil
+}
+
+// withQueueItem opens the queue, resolves ref and runs fn on the item.
+func withQueueItem(ref string, fn func(context.Context, *runqueue.Store, string) (*runqueue.Item, error)) (*runqueue.Item, error) {
+	store, err := openRunQueue()
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	item, err := resolveQueueItem(ctx, store, ref)
+	if err != nil {
+		return nil, err
+	}
+	item, err = fn(ctx, store, item.ID)
+	if errors.Is(err, runqueue.ErrLeased) {
+		return nil, fmt.Errorf("%s is running; cancel the workflow instead", ref)
+	}
+	return item, err
+}
@@ -19,8 +19,10 @@ import (
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/state"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/fsutil"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/report"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/workflow"
@@ -31,7 +33,13 @@ var runCmd = &cobra.Command{
 	Use:   "run [prompt]",
 	Short: "Run a complete workflow",
 	Long: `Execute a complete workflow including analyze, plan, and execute phases.
-The prompt can be provided as an argument or via --file flag.
+The prompt can be provided as an argument, via --file flag, or taken from an
+issue with --from-issue. Workflows created from an issue reference it in the
+pull request ("Fixes #N") and post a status comment on it when they finish.
+
+With --repo, the workflow also changes other registered projects: the planner
+assigns each task to a repository, every repository gets its own workflow
+branch, and finalization opens linked pull requests in all of them.
 
 By default, workflows use multi-agent consensus mode where multiple agents
 analyze the task and reach agreement through moderated discussion.
@@ -46,6 +54,12 @@ For simpler tasks, use --single-agent mode for faster execution with a single ag
   # Single-agent with specific model
   quorum run "Add docstrings" --single-agent --agent claude --model claude-3-haiku
 
+  # Work on a GitHub issue (number, owner/repo#number or URL)
+  quorum run --from-issue 42
+
+  # Change this project and the registered "web" project together
+  quorum run "Add the orders endpoint and use it in the web client" --repo web
+
   # Available agents: claude, gemini, codex (if enabled in config)`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runWorkflow,
@@ -61,12 +75,18 @@ var (
 	runTrace        string
 	runOutput       string
 	runSkipOptimize bool
+	runFromIssue    string
+	runRepos        []string
 )
 
 func init() {
 	rootCmd.AddCommand(runCmd)
 
 	runCmd.Flags().StringVarP(&runFile, "file", "f", "", "Read prompt from file")
+	runCmd.Flags().StringVar(&runFromIssue, "from-issue", "",
+		"Create the workflow from an issue (number, owner/repo#number or URL)")
+	runCmd.Flags().StringArrayVar(&runRepos, "repo", nil,
+		"Registered project (ID, name or path) the workflow also changes; repeatable")
 	runCmd.Flags().BoolVar(&runDryRun, "dry-run", false, "Simulate without executing")
 	runCmd.Flags().BoolVar(&runYolo, "yolo", false, "Skip confirmations")
 	runCmd.Flags().BoolVar(&runResume, "resume", false, "Resume from last checkpoint")
@@ -116,11 +136,20 @@ func runWorkflow(_ *cobra.Command, args []string) error {
 	if err := validateSingleAgentFlags(); err != nil {
 		return err
 	}
+	if runFromIssue != "" && (len(args) > 0 || runFile != "" || runResume || runInteractive) {
+		return fmt.Errorf("--from-issue cannot be combined with a prompt, --file, --resume or --interactive")
+	}
+	if len(runRepos) > 0 && (runResume || runInteractive) {
+		return fmt.Errorf("--repo cannot be combined with --resume or --interactive")
+	}
 	if runInteractive {
 		return runInteractiveWorkflow(ctx, args)
 	}
 
-	output, outputMode, tuiLogHandler := setupRunOutput()
+	// The workflow event stream of the command; -o json writes it as NDJSON.
+	eventBus := events.New(100)
+	defer eventBus.Close()
+	output, outputMode, tuiLogHandler := setupRunOutput(eventBus)
 	defer func() { _ = output.Close() }()
 
 	loader := config.NewLoaderWithViper(viper.GetViper())
@@ -135,6 +164,10 @@ func runWorkflow(_ *cobra.Command, args []string) error {
 	if err := config.ValidateConfig(cfg); err != nil {
 		return fmt.Errorf("validating config: %w", err)
 	}
+	repositories, err := resolveRunRepositories(ctx, runRepos, projectRoot)
+	if err != nil {
+		return err
+	}
 
 	logger := createRunLogger(cfg, outputMode, tuiLogHandler)
 
@@ -159,6 +192,7 @@ func runWorkflow(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	runnerConfig.ConfigVersion = configVersion(ctx, loader.ConfigFile())
 
 	traceWriter, traceCleanup := setupRunTrace(ctx, cfg, logger)
 	if traceCleanup != nil {
@@ -170,9 +204,9 @@ func runWorkflow(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	registry.SetEventHandler(func(event core.AgentEvent) {
+	registry.SetEventHandler(service.GetGlobalRateLimiter().ObserveEvents(func(event core.AgentEvent) {
 		outputNotifier.AgentEvent(string(event.Type), event.Agent, event.Message, event.Data)
-	})
+	}))
 
 	if runResume {
 		logger.Info("resuming workflow from checkpoint")
@@ -187,9 +221,25 @@ func runWorkflow(_ *cobra.Command, args []string) error {
 		return handleTUICompletion(tuiErrCh, nil)
 	}
 
-	prompt, err := getPrompt(args, runFile)
-	if err != nil {
-		return err
+	var prompt string
+	if runFromIssue != "" {
+		src, err := fetchIssueSource(ctx, cfg.Issues, runFromIssue)
+		if err != nil {
+			return err
+		}
+		runner.SetSourceIssue(src)
+		prompt = src.Prompt()
+		logger.Info("creating workflow from issue", "issue", src.Issue.Number, "comments", len(src.Comments))
+	} else {
+		prompt, err = getPrompt(args, runFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(repositories) > 0 {
+		runner.SetRepositories(repositories)
+		logger.Info("workflow changes several repositories", "repositories", len(repositories)+1)
 	}
 
 	logger.Info("starting new workflow", "prompt_length", len(prompt))
@@ -205,7 +255,25 @@ func runWorkflow(_ *cobra.Command, args []string) error {
 	return handleTUICompletion(tuiErrCh, nil)
 }
 
-func setupRunOutput() (tui.Output, tui.OutputMode, *tui.TUILogHandler) {
+// resolveRunRepositories resolves the --repo projects against the project registry.
+func resolveRunRepositories(ctx context.Context, refs []string, projectRoot string) ([]core.WorkflowRepository, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	registry, err := project.NewFileRegistry()
+	if err != nil {
+		return nil, fmt.Errorf("opening project registry: %w", err)
+	}
+	defer registry.Close()
+
+	root, err := filepath.Abs(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("resolving project root: %w", err)
+	}
+	return project.ResolveWorkflowRepositories(ctx, registry, refs, root)
+}
+
+func setupRunOutput(eventBus *events.EventBus) (tui.Output, tui.OutputMode, *tui.TUILogHandler) {
 	detector := tui.NewDetector()
 	if runOutput != "" {
 		detector.ForceMode(tui.ParseOutputMode(runOutput))
@@ -213,7 +281,10 @@ func setupRunOutput() (tui.Output, tui.OutputMode, *tui.TUILogHandler) {
 	outputMode := detector.Detect()
 	useColor := detector.ShouldUseColor()
 	verboseOutput := runTrace != ""
-	output := tui.NewOutput(outputMode, useColor, verboseOutput)
+	output := tui.NewOutputWithEventBus(outputMode, useColor, verboseOutput, eventBus)
+	if jsonOutput, ok := output.(*tui.JSONOutputAdapter); ok {
+		jsonOutput.SetProjectID(GetProjectID())
+	}
 
 	var tuiLogHandler *tui.TUILogHandler
 	if outputMode == tui.ModeTUI {
@@ -228,7 +299,12 @@ func createRunLogger(cfg *config.Config, outputMode tui.OutputMode, tuiLogHandle
 		*tuiLogHandler = *handler
 		return logging.NewWithHandler(tuiLogHandler)
 	}
-	return logging.New(logging.Config{Level: cfg.Log.Level, Format: cfg.Log.Format, Output: os.Stdout})
+	logOutput := os.Stdout
+	if outputMode == tui.ModeJSON {
+		// Keep stdout a clean NDJSON event stream for machine consumers.
+		logOutput = os.Stderr
+	}
+	return logging.New(logging.Config{Level: cfg.Log.Level, Format: cfg.Log.Format, Output: logOutput})
 }
 
 func setupRunTUI(output tui.Output, outputMode tui.OutputMode, tuiLogHandler *tui.TUILogHandler, projectRoot string) (*tui.TUIOutput, chan error) {
@@ -301,6 +377,7 @@ func buildRunnerConfig(cfg *config.Config) (*workflow.RunnerConfig, error) {
 			"opencode": cfg.Agents.OpenCode.PhaseModels,
 		},
 		WorktreeAutoClean: cfg.Git.Worktree.AutoClean, WorktreeMode: cfg.Git.Worktree.Mode,
+		TaskCheckpointInterval: workflow.ParseTaskCheckpointInterval(cfg.Git.Task.CheckpointInterval),
 		Refiner: workflow.RefinerConfig{
 			Enabled: refinerEnabled, Agent: cfg.Phases.Analyze.Refiner.Agent, Template: cfg.Phases.Analyze.Refiner.Template,
 		},
@@ -312,13 +389,15 @@ func buildRunnerConfig(cfg *config.Config) (*workflow.RunnerConfig, error) {
 			MaxRounds: cfg.Phases.Analyze.Moderator.MaxRounds, WarningThreshold: cfg.Phases.Analyze.Moderator.WarningThreshold,
 			StagnationThreshold: cfg.Phases.Analyze.Moderator.StagnationThreshold,
 		},
-		SingleAgent:   buildSingleAgentConfig(cfg),
-		PhaseTimeouts: workflow.PhaseTimeouts{Analyze: analyzeTimeout, Plan: planTimeout, Execute: executeTimeout},
+		SingleAgent:    buildSingleAgentConfig(cfg),
+		PhaseTimeouts:  workflow.PhaseTimeouts{Analyze: analyzeTimeout, Plan: planTimeout, Execute: executeTimeout},
+		TaskInput:      workflow.NewTaskInputConfig(cfg.Phases.Execute.Input),
+		SkipDependents: cfg.Phases.Execute.SkipDependents,
 		Finalization: workflow.FinalizationConfig{
 			AutoCommit: cfg.Git.Task.AutoCommit, AutoPush: cfg.Git.Finalization.AutoPush,
 			AutoPR: cfg.Git.Finalization.AutoPR, AutoMerge: cfg.Git.Finalization.AutoMerge,
 			PRBaseBranch: cfg.Git.Finalization.PRBaseBranch, MergeStrategy: cfg.Git.Finalization.MergeStrategy,
-			Remote: cfg.GitHub.Remote,
+			Remote: cfg.GitHub.Remote, Babysit: workflow.NewPRBabysitConfig(cfg.Git.Finalization.Babysit),
 		},
 		Report: report.Config{Enabled: cfg.Report.Enabled, BaseDir: cfg.Report.BaseDir, UseUTC: cfg.Report.UseUTC, IncludeRaw: cfg.Report.IncludeRaw},
 	}, nil
@@ -404,18 +483,22 @@ func createRunnerWithDeps(
 	}
 
 	modeEnforcer := service.NewModeEnforcer(service.ExecutionMode{DryRun: runnerConfig.DryRun, DeniedTools: runnerConfig.DenyTools})
+	gitIsolation := workflow.DefaultGitIsolationConfig()
+	repositories := workflow.NewGitRepositorySet(cfg.Git.Worktree.Dir, gitIsolation.Enabled, cfg.Git.Finalization.AutoPR, logger)
 
 	runner, err := workflow.NewRunner(workflow.RunnerDeps{
-		Config: runnerConfig, State: stateManager, Agents: registry,
+		Config: runnerConfig, State: stateManager, Agents: rateLimiterRegistry.WrapAgents(registry),
 		DAG: workflow.NewDAGAdapter(dagBuilder), Checkpoint: workflow.NewCheckpointAdapter(checkpointManager, ctx),
 		ResumeProvider: workflow.NewResumePointAdapter(checkpointManager),
 		Prompts: workflow.NewPromptRendererAdapter(promptRenderer),
 		Retry: workflow.NewRetryAdapter(retryPolicy, ctx),
 		RateLimits: workflow.NewRateLimiterRegistryAdapter(rateLimiterRegistry, ctx),
 		Worktrees: worktreeManager, WorkflowWorktrees: workflowWorktrees,
-		GitIsolation: workflow.DefaultGitIsolationConfig(), GitClientFactory: git.NewClientFactory(),
+		GitIsolation: gitIsolation, GitClientFactory: git.NewClientFactory(),
 		Git: gitClient, GitHub: githubClient, Logger: logger, Output: outputNotifier,
 		ModeEnforcer: workflow.NewModeEnforcerAdapter(modeEnforcer), ProjectRoot: projectRoot,
+		Repositories: repositories, ContextIndex: workflow.NewContextIndexer(projectRoot, cfg.Index),
+		AgentHealth: workflow.NewAgentHealthMonitor(cfg.AgentHealth, logger.Logger),
 	})
 	if err != nil {
 		return nil, nil, err
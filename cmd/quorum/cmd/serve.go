@@ -16,6 +16,7 @@ import (
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/chat"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/cli"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/state"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/agenthealth"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/api"
 	apimiddleware "github.com/hugo-lorenzo-mato/quorum-ai/internal/api/middleware"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
@@ -25,8 +26,12 @@ import (
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/kanban"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/runqueue"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/workflow"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/snapshot"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/web"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/worker"
 )
 
 var serveCmd = &cobra.Command{
@@ -72,6 +77,7 @@ type serveInfra struct {
 	loader           *config.Loader
 	quorumCfg        *config.Config
 	registry         *cli.Registry
+	rateLimiter      *service.RateLimiterRegistry
 	stateManager     core.StateManager
 	chatStore        core.ChatStore
 	eventBus         *events.EventBus
@@ -83,6 +89,11 @@ type serveInfra struct {
 	projectReg       *project.FileRegistry
 	statePool        *project.StatePool
 	kanbanEngine     *kanban.Engine
+	configWatcher    *config.Watcher
+	backupScheduler  *snapshot.BackupScheduler
+	workers          *worker.Coordinator
+	runQueue         *runqueue.Dispatcher
+	agentHealth      *agenthealth.Monitor
 }
 
 func runServe(_ *cobra.Command, _ []string) error {
@@ -118,10 +129,21 @@ func runServe(_ *cobra.Command, _ []string) error {
 	infra.eventBus = events.New(100)
 	defer infra.eventBus.Close()
 
+	watchServeConfig(infra)
+	if infra.configWatcher != nil {
+		defer func() { _ = infra.configWatcher.Close() }()
+	}
+
 	ctx := context.Background()
 	setupServeDiagnostics(ctx, infra)
+	setupServeWorkers(infra)
+	setupServeAgentHealth(infra)
 	setupServeWorkflowInfra(infra)
 	setupServeProjectInfra(infra)
+	setupServeRunQueue(infra)
+	if infra.runQueue != nil {
+		defer func() { _ = infra.runQueue.Store().Close() }()
+	}
 	setupServeKanbanEngine(infra)
 
 	serverOpts := buildServeServerOptions(infra)
@@ -173,11 +195,78 @@ func setupServeConfigAndAgents(infra *serveInfra) {
 			logger.Warn("failed to configure agents", slog.String("error", err.Error()))
 		} else {
 			infra.registry = registry
+			// The server's runners and chats share the process-wide limiters.
+			infra.rateLimiter = service.GetGlobalRateLimiter()
 			logger.Info("agents configured", slog.Any("available", registry.List()))
 		}
 	}
 }
 
+// watchServeConfig reloads the server's own config when it changes on disk and
+// reconfigures the shared agent registry (chat, issues) and rate limiters.
+// Workflows resolve their config through the project context, which has its
+// own watcher.
+func watchServeConfig(infra *serveInfra) {
+	path := infra.loader.ConfigFile()
+	if path == "" || infra.registry == nil {
+		return
+	}
+	w, err := config.NewWatcher(path, func() { reloadServeConfig(infra, path) })
+	if err != nil {
+		infra.logger.Warn("failed to watch config, changes need a restart", slog.String("error", err.Error()))
+		return
+	}
+	infra.configWatcher = w
+}
+
+// reloadServeConfig applies a changed server config to the agent registry and
+// the rate limiters of the agents. An invalid change is rejected and the
+// agents keep their current configuration.
+func reloadServeConfig(infra *serveInfra, path string) {
+	scope := "project"
+	if globalPath, err := config.GlobalConfigPath(); err == nil && globalPath == path {
+		scope = "global"
+	}
+
+	cfg, err := infra.loader.Load()
+	if err == nil {
+		err = config.ValidateConfig(cfg)
+	}
+	if err != nil {
+		infra.logger.Warn("config change rejected, keeping the active config",
+			slog.String("config_path", path), slog.String("error", err.Error()))
+		infra.eventBus.Publish(events.NewConfigReloadFailedEvent("", path, scope, err.Error()))
+		return
+	}
+
+	// Configure a fresh registry and apply it only once it is complete, so a
+	// failed reload keeps the agents and Get never finds the registry empty.
+	next := cli.NewRegistry()
+	if err := configureAgentsFromConfig(next, cfg, infra.loader); err != nil {
+		infra.logger.Warn("failed to reconfigure agents", slog.String("error", err.Error()))
+		infra.eventBus.Publish(events.NewConfigReloadFailedEvent("", path, scope, err.Error()))
+		return
+	}
+	infra.registry.ReplaceConfigs(next)
+	if infra.rateLimiter != nil {
+		resizeRateLimiters(infra.rateLimiter, infra.registry)
+	}
+	infra.logger.Info("config reloaded", slog.String("config_path", path), slog.Any("agents", infra.registry.ListEnabled()))
+	infra.eventBus.Publish(events.NewConfigLoadedEvent("", "", path, scope, "", "", "", 0, "", ""))
+}
+
+// resizeRateLimiters sizes the rate limiters of the configured agents of
+// registry from their reloaded limits.
+func resizeRateLimiters(limits *service.RateLimiterRegistry, registry *cli.Registry) {
+	for _, name := range registry.ListEnabled() {
+		agent, err := registry.Get(name)
+		if err != nil {
+			continue
+		}
+		limits.ConfigureFromCapabilities(name, agent.Capabilities())
+	}
+}
+
 func setupServeStateAndChat(infra *serveInfra) {
 	logger := infra.logger
 	quorumCfg := infra.quorumCfg
@@ -277,11 +366,62 @@ func setupServeWorkflowInfra(infra *serveInfra) {
 
 	if infra.registry != nil && infra.stateManager != nil && infra.quorumCfg != nil {
 		runnerFactory := api.NewRunnerFactory(infra.stateManager, infra.registry, infra.eventBus, infra.loader, logger)
+		if infra.workers != nil {
+			runnerFactory.WithWorkers(infra.workers)
+		}
+		if infra.agentHealth != nil {
+			runnerFactory.WithAgentHealth(infra.agentHealth)
+		}
 		infra.workflowExecutor = api.NewWorkflowExecutor(runnerFactory, infra.stateManager, infra.eventBus, logger.Logger, infra.unifiedTracker)
 		logger.Info("workflow executor initialized for Kanban engine")
 	}
 }
 
+// setupServeAgentHealth creates the agent health monitor shared by the
+// workflows of all projects when agent health tracking is enabled.
+func setupServeAgentHealth(infra *serveInfra) {
+	if infra.quorumCfg == nil {
+		return
+	}
+	infra.agentHealth = workflow.NewAgentHealthMonitor(infra.quorumCfg.AgentHealth, infra.logger.Logger)
+	if infra.agentHealth != nil {
+		infra.logger.Info("agent health tracking enabled",
+			slog.Int("fallbacks", len(infra.quorumCfg.AgentHealth.Fallbacks)))
+	}
+}
+
+// setupServeWorkers creates the coordinator that remote workers (quorum
+// worker) register with when workers are enabled.
+func setupServeWorkers(infra *serveInfra) {
+	logger := infra.logger
+	if infra.quorumCfg == nil || !infra.quorumCfg.Workers.Enabled {
+		return
+	}
+	cfg := infra.quorumCfg.Workers
+
+	timeout, err := time.ParseDuration(cfg.Timeout)
+	if err != nil || timeout <= 0 {
+		logger.Error("remote workers disabled", slog.String("error", fmt.Sprintf("invalid workers.timeout %q", cfg.Timeout)))
+		return
+	}
+	token := ""
+	if cfg.TokenEnv != "" {
+		if token = os.Getenv(cfg.TokenEnv); token == "" {
+			logger.Error("remote workers disabled", slog.String("error", fmt.Sprintf("worker token variable %s is not set", cfg.TokenEnv)))
+			return
+		}
+	} else {
+		logger.Warn("worker endpoints are not authenticated, set workers.token_env when the server is reachable from other hosts")
+	}
+
+	infra.workers = worker.NewCoordinator(worker.CoordinatorOptions{
+		Token:   token,
+		Timeout: timeout,
+		Logger:  logger.Logger,
+	})
+	logger.Info("remote workers enabled", slog.String("endpoint", worker.APIPath), slog.Duration("timeout", timeout))
+}
+
 func setupServeProjectInfra(infra *serveInfra) {
 	logger := infra.logger
 	projectReg, err := project.NewFileRegistry(project.WithLogger(logger.Logger))
@@ -299,11 +439,50 @@ func setupServeProjectInfra(infra *serveInfra) {
 			project.WithPoolLogger(logger.Logger),
 			project.WithMaxActiveContexts(20),
 			project.WithEvictionGracePeriod(30*time.Minute),
+			project.WithPoolConfigWatch(true),
 		)
 		logger.Info("state pool initialized for multi-project support")
 	}
 }
 
+// setupServeRunQueue opens the durable run queue that workflow runs requested
+// through the API wait in until a slot is free.
+func setupServeRunQueue(infra *serveInfra) {
+	logger := infra.logger
+	if infra.quorumCfg == nil || !infra.quorumCfg.Queue.Enabled || infra.workflowExecutor == nil {
+		return
+	}
+	cfg := infra.quorumCfg.Queue
+
+	leaseTTL, err := time.ParseDuration(cfg.LeaseTTL)
+	if err != nil || leaseTTL <= 0 {
+		logger.Error("run queue disabled", slog.String("error", fmt.Sprintf("invalid queue.lease_ttl %q", cfg.LeaseTTL)))
+		return
+	}
+	path := cfg.Path
+	if path == "" {
+		if path, err = runqueue.DefaultPath(); err != nil {
+			logger.Error("run queue disabled", slog.String("error", err.Error()))
+			return
+		}
+	}
+	store, err := runqueue.Open(path)
+	if err != nil {
+		logger.Error("run queue disabled", slog.String("error", err.Error()))
+		return
+	}
+
+	infra.runQueue = runqueue.NewDispatcher(runqueue.DispatcherOptions{
+		Store:        store,
+		Executor:     api.NewRunQueueExecutor(infra.workflowExecutor, infra.unifiedTracker, infra.statePool),
+		Slots:        cfg.Slots,
+		ProjectLimit: cfg.ProjectLimit,
+		LeaseTTL:     leaseTTL,
+		Logger:       logger.Logger,
+	})
+	logger.Info("run queue enabled", slog.String("path", path))
+}
+
 func setupServeKanbanEngine(infra *serveInfra) {
 	logger := infra.logger
 	if infra.workflowExecutor == nil {
@@ -367,6 +546,15 @@ func buildServeServerOptions(infra *serveInfra) []web.ServerOption {
 	if infra.statePool != nil {
 		opts = append(opts, web.WithStatePool(infra.statePool))
 	}
+	if infra.workers != nil {
+		opts = append(opts, web.WithWorkerCoordinator(infra.workers))
+	}
+	if infra.runQueue != nil {
+		opts = append(opts, web.WithRunQueue(infra.runQueue))
+	}
+	if infra.agentHealth != nil {
+		opts = append(opts, web.WithAgentHealth(infra.agentHealth))
+	}
 	return opts
 }
 
@@ -459,6 +647,19 @@ func runOrphanCleanup(ctx context.Context, infra *serveInfra) {
 func startServeBackgroundServices(ctx context.Context, infra *serveInfra) {
 	logger := infra.logger
 
+	if infra.quorumCfg != nil && infra.quorumCfg.Backup.Enabled {
+		if scheduler, err := newServeBackupScheduler(infra.quorumCfg.Backup, logger); err != nil {
+			logger.Error("automatic backups disabled", slog.String("error", err.Error()))
+		} else {
+			infra.backupScheduler = scheduler
+			scheduler.Start(ctx)
+		}
+	}
+
+	if infra.runQueue != nil {
+		infra.runQueue.Start(ctx)
+	}
+
 	if infra.kanbanEngine != nil {
 		if err := infra.kanbanEngine.Start(ctx); err != nil {
 			logger.Error("failed to start kanban engine", slog.String("error", err.Error()))
@@ -493,6 +694,16 @@ func startServeBackgroundServices(ctx context.Context, infra *serveInfra) {
 }
 
 func stopServeBackgroundServices(infra *serveInfra) {
+	// Stop the run queue first so runs interrupted by the shutdown keep
+	// their items and are resumed after the restart.
+	if infra.runQueue != nil {
+		infra.runQueue.Stop()
+	}
+
+	if infra.backupScheduler != nil {
+		infra.backupScheduler.Stop()
+	}
+
 	if infra.heartbeatManager != nil {
 		infra.heartbeatManager.Shutdown()
 	}
@@ -506,6 +717,47 @@ func stopServeBackgroundServices(infra *serveInfra) {
 	}
 }
 
+// newServeBackupScheduler creates the automatic backup scheduler from the
+// backup configuration. Backups meant to be encrypted are not taken when the
+// passphrase is missing.
+func newServeBackupScheduler(cfg config.BackupConfig, logger *logging.Logger) (*snapshot.BackupScheduler, error) {
+	interval, err := time.ParseDuration(cfg.Interval)
+	if err != nil || interval <= 0 {
+		return nil, fmt.Errorf("invalid backup.interval %q", cfg.Interval)
+	}
+	dir := cfg.Dir
+	if dir == "" {
+		if dir, err = snapshot.DefaultBackupDir(); err != nil {
+			return nil, err
+		}
+	}
+
+	encryption := snapshot.Encryption{Recipients: cfg.Recipients}
+	if cfg.PassphraseEnv != "" {
+		encryption.Passphrase = os.Getenv(cfg.PassphraseEnv)
+		if encryption.Passphrase == "" {
+			return nil, fmt.Errorf("backup passphrase variable %s is not set", cfg.PassphraseEnv)
+		}
+	}
+	if cfg.IdentityFile != "" {
+		encryption.IdentityFiles = []string{cfg.IdentityFile}
+	}
+
+	logger.Info("automatic backups enabled",
+		slog.String("dir", dir), slog.Duration("interval", interval),
+		slog.Int("keep_daily", cfg.KeepDaily), slog.Int("keep_weekly", cfg.KeepWeekly),
+		slog.Bool("encrypted", encryption.Passphrase != "" || len(encryption.Recipients) > 0))
+	return snapshot.NewBackupScheduler(snapshot.BackupOptions{
+		Dir:              dir,
+		KeepDaily:        cfg.KeepDaily,
+		KeepWeekly:       cfg.KeepWeekly,
+		FullEvery:        cfg.FullEvery,
+		IncludeWorktrees: cfg.IncludeWorktrees,
+		Encryption:       encryption,
+		QuorumVersion:    GetVersion(),
+	}, interval, logger.Logger), nil
+}
+
 // recoverZombieWorkflows marks workflows stuck in "running" state as failed.
 // This handles cases where the server crashed or restarted while workflows were executing.
 func recoverZombieWorkflows(ctx context.Context, stateManager core.StateManager, logger *slog.Logger) (int, error) {
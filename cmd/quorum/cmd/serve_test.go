@@ -1,10 +1,16 @@
 package cmd
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/cli"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/workflow"
 )
 
@@ -181,3 +187,48 @@ func TestParseLogLevel_Empty(t *testing.T) {
 		t.Errorf("expected INFO for empty level, got %s", lvl.String())
 	}
 }
+
+// --- reloadServeConfig ---
+
+func TestReloadServeConfig_ResizesRateLimiters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeRPM := func(rpm int) {
+		t.Helper()
+		content, err := config.SetKey([]byte(config.DefaultConfigYAML), "agents.claude.rate_limit_rpm", int64(rpm))
+		if err != nil {
+			t.Fatalf("SetKey() error = %v", err)
+		}
+		if err := os.WriteFile(path, content, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeRPM(60)
+
+	loader := config.NewLoader().WithConfigFile(path)
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	infra := &serveInfra{
+		logger:      logging.NewNop(),
+		loader:      loader,
+		registry:    cli.NewRegistry(),
+		rateLimiter: service.NewRateLimiterRegistry(),
+		eventBus:    events.New(10),
+	}
+	defer infra.eventBus.Close()
+	if err := configureAgentsFromConfig(infra.registry, cfg, loader); err != nil {
+		t.Fatalf("configureAgentsFromConfig() error = %v", err)
+	}
+	resizeRateLimiters(infra.rateLimiter, infra.registry)
+	infra.rateLimiter.Get("claude")
+	if got := infra.rateLimiter.Status()["claude"].MaxTokens; got != 10 {
+		t.Fatalf("claude burst = %v, want 10 from 60 RPM", got)
+	}
+
+	writeRPM(120)
+	reloadServeConfig(infra, path)
+	if status := infra.rateLimiter.Status()["claude"]; status.MaxTokens != 20 || status.RefillRate != 2 {
+		t.Errorf("claude limiter = %+v, want it sized from the reloaded 120 RPM", status)
+	}
+}
@@ -3,11 +3,13 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/snapshot"
 )
@@ -39,6 +41,10 @@ var (
 	snapshotExportOutputPath       string
 	snapshotExportProjectIDs       []string
 	snapshotExportIncludeWorktrees bool
+	snapshotExportParentPath       string
+	snapshotExportEncrypt          bool
+	snapshotExportRecipients       []string
+	snapshotIdentityFiles          []string
 
 	snapshotImportInputPath        string
 	snapshotImportMode             string
@@ -59,6 +65,10 @@ func init() {
 	snapshotExportCmd.Flags().StringVarP(&snapshotExportOutputPath, "output", "o", "", "Output .tar.gz path (default: ./quorum-snapshot-<timestamp>.tar.gz)")
 	snapshotExportCmd.Flags().StringSliceVar(&snapshotExportProjectIDs, "project-id", nil, "Project IDs to export (repeatable). If omitted, exports all projects")
 	snapshotExportCmd.Flags().BoolVar(&snapshotExportIncludeWorktrees, "include-worktrees", false, "Include .worktrees directories in the snapshot")
+	snapshotExportCmd.Flags().StringVar(&snapshotExportParentPath, "parent", "", "Parent snapshot: only files changed since it are stored (incremental snapshot)")
+	snapshotExportCmd.Flags().BoolVar(&snapshotExportEncrypt, "encrypt", false, "Encrypt with a passphrase (from "+snapshotPassphraseEnv+" or prompted)")
+	snapshotExportCmd.Flags().StringArrayVar(&snapshotExportRecipients, "recipient", nil, "Encrypt to an age public key (repeatable)")
+	snapshotExportCmd.Flags().StringArrayVar(&snapshotIdentityFiles, "identity", nil, "age identity file to decrypt an encrypted parent (repeatable)")
 
 	snapshotImportCmd.Flags().StringVarP(&snapshotImportInputPath, "input", "i", "", "Input .tar.gz snapshot path")
 	snapshotImportCmd.Flags().StringVar(&snapshotImportMode, "mode", string(snapshot.ImportModeMerge), "Import mode: merge | replace")
@@ -67,16 +77,41 @@ func init() {
 	snapshotImportCmd.Flags().StringArrayVar(&snapshotImportPathMap, "path-map", nil, "Path remap in form old=new (repeatable)")
 	snapshotImportCmd.Flags().BoolVar(&snapshotImportPreserveIDs, "preserve-ids", false, "Preserve project IDs from snapshot")
 	snapshotImportCmd.Flags().BoolVar(&snapshotImportIncludeWorktrees, "include-worktrees", true, "Restore .worktrees files from the snapshot")
+	snapshotImportCmd.Flags().StringArrayVar(&snapshotIdentityFiles, "identity", nil, "age identity file to decrypt the snapshot (repeatable)")
 	_ = snapshotImportCmd.MarkFlagRequired("input")
 
 	snapshotValidateCmd.Flags().StringVarP(&snapshotValidateInputPath, "input", "i", "", "Input .tar.gz snapshot path")
+	snapshotValidateCmd.Flags().StringArrayVar(&snapshotIdentityFiles, "identity", nil, "age identity file to decrypt the snapshot (repeatable)")
 	_ = snapshotValidateCmd.MarkFlagRequired("input")
 }
 
 func runSnapshotExport(_ *cobra.Command, _ []string) error {
+	encryption := snapshot.Encryption{
+		Recipients:    snapshotExportRecipients,
+		IdentityFiles: snapshotIdentityFiles,
+	}
+	if snapshotExportEncrypt {
+		if len(snapshotExportRecipients) > 0 {
+			return fmt.Errorf("--encrypt and --recipient cannot be combined")
+		}
+		passphrase, err := snapshotPassphrase(true)
+		if err != nil {
+			return err
+		}
+		encryption.Passphrase = passphrase
+	} else if snapshotExportParentPath != "" && len(snapshotIdentityFiles) == 0 {
+		// An encrypted parent is read with the passphrase it was written with.
+		if encrypted, err := snapshot.IsEncrypted(snapshotExportParentPath); err == nil && encrypted {
+			return fmt.Errorf("parent snapshot is encrypted: use --encrypt or --identity")
+		}
+	}
+
 	outputPath := strings.TrimSpace(snapshotExportOutputPath)
 	if outputPath == "" {
 		outputPath = filepath.Join(".", fmt.Sprintf("quorum-snapshot-%s.tar.gz", time.Now().UTC().Format("20060102-150405")))
+		if encryption.Passphrase != "" || len(encryption.Recipients) > 0 {
+			outputPath += ".age"
+		}
 	}
 
 	result, err := snapshot.Export(&snapshot.ExportOptions{
@@ -84,6 +119,8 @@ func runSnapshotExport(_ *cobra.Command, _ []string) error {
 		IncludeWorktrees: snapshotExportIncludeWorktrees,
 		ProjectIDs:       snapshotExportProjectIDs,
 		QuorumVersion:    GetVersion(),
+		ParentPath:       snapshotExportParentPath,
+		Encryption:       encryption,
 	})
 	if err != nil {
 		return err
@@ -97,6 +134,11 @@ func runSnapshotExport(_ *cobra.Command, _ []string) error {
 	fmt.Printf("Snapshot exported to %s\n", result.OutputPath)
 	fmt.Printf("Projects: %d\n", result.Manifest.ProjectCount)
 	fmt.Printf("Files: %d\n", len(result.Manifest.Files))
+	if result.Manifest.Parent != nil {
+		stored := countStoredFiles(result.Manifest)
+		fmt.Printf("Incremental: %d files stored, %d inherited from %s\n", stored, len(result.Manifest.Files)-stored, result.Manifest.Parent.File)
+	}
+	fmt.Printf("Databases: %d (consistent copies)\n", len(result.Manifest.Databases))
 	fmt.Printf("Include worktrees: %t\n", result.Manifest.IncludeWorktrees)
 	return nil
 }
@@ -107,6 +149,11 @@ func runSnapshotImport(_ *cobra.Command, _ []string) error {
 		return err
 	}
 
+	encryption, err := snapshotDecryption(snapshotImportInputPath)
+	if err != nil {
+		return err
+	}
+
 	report, err := snapshot.Import(&snapshot.ImportOptions{
 		InputPath:          snapshotImportInputPath,
 		Mode:               snapshot.ImportMode(snapshotImportMode),
@@ -115,6 +162,7 @@ func runSnapshotImport(_ *cobra.Command, _ []string) error {
 		PathMap:            pathMap,
 		PreserveProjectIDs: snapshotImportPreserveIDs,
 		IncludeWorktrees:   snapshotImportIncludeWorktrees,
+		Encryption:         encryption,
 	})
 	if err != nil {
 		return err
@@ -133,6 +181,11 @@ func runSnapshotImport(_ *cobra.Command, _ []string) error {
 	fmt.Printf("Projects processed: %d\n", len(report.Projects))
 	fmt.Printf("Files restored: %d\n", report.RestoredFiles)
 	fmt.Printf("Files skipped: %d\n", report.SkippedFiles)
+	for _, db := range report.Databases {
+		if db.Upgraded {
+			fmt.Printf("Database upgraded: %s (%s schema v%d -> v%d)\n", db.Path, db.Kind, db.FromVersion, db.ToVersion)
+		}
+	}
 	if len(report.Conflicts) > 0 {
 		fmt.Printf("Conflicts: %d\n", len(report.Conflicts))
 	}
@@ -143,7 +196,15 @@ func runSnapshotImport(_ *cobra.Command, _ []string) error {
 }
 
 func runSnapshotValidate(_ *cobra.Command, _ []string) error {
-	manifest, err := snapshot.ValidateSnapshot(snapshotValidateInputPath)
+	encryption, err := snapshotDecryption(snapshotValidateInputPath)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := snapshot.ValidateSnapshot(&snapshot.ValidateOptions{
+		InputPath:  snapshotValidateInputPath,
+		Encryption: encryption,
+	})
 	if err != nil {
 		return err
 	}
@@ -158,9 +219,76 @@ func runSnapshotValidate(_ *cobra.Command, _ []string) error {
 	}
 
 	fmt.Printf("Snapshot valid: projects=%d files=%d include_worktrees=%t\n", manifest.ProjectCount, len(manifest.Files), manifest.IncludeWorktrees)
+	if manifest.Parent != nil {
+		fmt.Printf("Incremental on %s (chain verified)\n", manifest.Parent.File)
+	}
 	return nil
 }
 
+// snapshotPassphraseEnv holds the snapshot passphrase for non-interactive use.
+const snapshotPassphraseEnv = "QUORUM_SNAPSHOT_PASSPHRASE"
+
+// snapshotPassphrase returns the snapshot passphrase from the environment, or
+// prompts for it on the terminal.
+func snapshotPassphrase(confirm bool) (string, error) {
+	if passphrase := os.Getenv(snapshotPassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+	fd := int(os.Stdin.Fd()) // #nosec G115 -- file descriptors fit in int
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("snapshot passphrase required: set %s", snapshotPassphraseEnv)
+	}
+	fmt.Fprint(os.Stderr, "Snapshot passphrase: ")
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("reading passphrase: %w", err)
+	}
+	if confirm {
+		fmt.Fprint(os.Stderr, "Confirm passphrase: ")
+		again, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("reading passphrase: %w", err)
+		}
+		if string(again) != string(passphrase) {
+			return "", fmt.Errorf("passphrases do not match")
+		}
+	}
+	if len(passphrase) == 0 {
+		return "", fmt.Errorf("snapshot passphrase required")
+	}
+	return string(passphrase), nil
+}
+
+// snapshotDecryption returns the keys to read the snapshot at path: the
+// --identity files, or a passphrase when the snapshot is encrypted.
+func snapshotDecryption(path string) (snapshot.Encryption, error) {
+	encryption := snapshot.Encryption{IdentityFiles: snapshotIdentityFiles}
+	if len(snapshotIdentityFiles) > 0 {
+		return encryption, nil
+	}
+	encrypted, err := snapshot.IsEncrypted(path)
+	if err != nil || !encrypted {
+		// Open errors are reported by the snapshot operation itself.
+		return encryption, nil
+	}
+	encryption.Passphrase, err = snapshotPassphrase(false)
+	return encryption, err
+}
+
+// countStoredFiles returns the number of files a snapshot stores itself
+// rather than inheriting from its parent.
+func countStoredFiles(manifest *snapshot.Manifest) int {
+	n := 0
+	for _, f := range manifest.Files {
+		if !f.Inherited {
+			n++
+		}
+	}
+	return n
+}
+
 func parsePathMapFlags(raw []string) (map[string]string, error) {
 	if len(raw) == 0 {
 		return map[string]string{}, nil
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -10,25 +11,39 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/state"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
 )
 
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show workflow status",
-	Long:  "Display the current state of the workflow including task progress.",
-	RunE:  runStatus,
+	Long: `Display the current state of the workflow including task progress.
+
+With --all-projects, list the workflows of every registered project instead,
+most recently updated first.`,
+	RunE: runStatus,
 }
 
 var (
-	statusJSON bool
+	statusJSON        bool
+	statusAllProjects bool
+	statusRunning     bool
+	statusLimit       int
 )
 
 func init() {
 	rootCmd.AddCommand(statusCmd)
 	statusCmd.Flags().BoolVar(&statusJSON, "json", false, "Output as JSON")
+	statusCmd.Flags().BoolVar(&statusAllProjects, "all-projects", false, "Show the workflows of all registered projects")
+	statusCmd.Flags().BoolVar(&statusRunning, "running", false, "With --all-projects, show only executing workflows")
+	statusCmd.Flags().IntVarP(&statusLimit, "limit", "n", 20, "With --all-projects, maximum number of workflows to show (0 for all)")
 }
 
 func runStatus(cmd *cobra.Command, _ []string) error {
+	if statusAllProjects {
+		return runStatusAllProjects(cmd.Context())
+	}
+
 	statePath := viper.GetString("state.path")
 	if statePath == "" {
 		statePath = ".quorum/state/state.db"
@@ -83,6 +98,59 @@ func runStatus(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// runStatusAllProjects prints the workflows of all registered projects, as
+// GET /api/v1/overview/workflows returns them.
+func runStatusAllProjects(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	registry, err := project.NewFileRegistry()
+	if err != nil {
+		return fmt.Errorf("opening project registry: %w", err)
+	}
+	defer registry.Close()
+
+	overview, err := project.CollectOverview(ctx, registry, nil, project.OverviewOptions{
+		RunningOnly: statusRunning,
+		Limit:       statusLimit,
+	})
+	if err != nil {
+		return err
+	}
+	if statusJSON {
+		return outputJSON(overview)
+	}
+
+	if len(overview.Workflows) == 0 {
+		fmt.Printf("No workflows found in %d project(s).\n", overview.Projects)
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PROJECT\tID\tSTATUS\tPHASE\tUPDATED\tPROMPT")
+		fmt.Fprintln(w, "-------\t--\t------\t-----\t-------\t------")
+		for _, wf := range overview.Workflows {
+			id := "  " + string(wf.WorkflowID)
+			if wf.Running {
+				id = "> " + string(wf.WorkflowID)
+			}
+			prompt := wf.Title
+			if prompt == "" {
+				prompt = wf.Prompt
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", truncateString(wf.ProjectName, 20), id,
+				formatStatus(wf.Status), formatPhase(wf.CurrentPhase), formatWorkflowTime(wf.UpdatedAt), truncateString(prompt, 50))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Println()
+		fmt.Println("> = executing")
+	}
+	for _, e := range overview.Errors {
+		fmt.Printf("warning: %s: %s\n", e.ProjectName, e.Error)
+	}
+	return nil
+}
+
 func outputJSON(v interface{}) error {
 	enc := json.NewEncoder(os.Stdout)
 	enc.SetIndent("", "  ")
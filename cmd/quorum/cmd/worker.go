@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/cli"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/worker"
+)
+
+var workerCmd = &cobra.Command{
+	Use:   "worker",
+	Short: "Run execute-phase tasks for a quorum server",
+	Long: `Register with a quorum server and run the execute-phase tasks it dispatches.
+
+The worker advertises the agents enabled for the execute phase in its config
+that pass their availability checks. Each task runs in a temporary clone of
+the task's branch; the resulting commits are sent back to the server, which
+applies them to the task's worktree. The server must enable workers
+(workers.enabled in its config).
+
+Examples:
+  # Run tasks for a server on another machine
+  quorum worker --coordinator http://build-host:8080
+
+  # Run two tasks at once, authenticating with the server's worker token
+  QUORUM_WORKER_TOKEN=... quorum worker --coordinator http://build-host:8080 --capacity 2`,
+	RunE: runWorker,
+}
+
+var (
+	workerCoordinator string
+	workerName        string
+	workerCapacity    int
+	workerWorkDir     string
+	workerTokenEnv    string
+)
+
+func init() {
+	rootCmd.AddCommand(workerCmd)
+
+	workerCmd.Flags().StringVar(&workerCoordinator, "coordinator", "",
+		"Base URL of the quorum server (required)")
+	workerCmd.Flags().StringVar(&workerName, "name", "",
+		"Worker name shown by the server (default: host name)")
+	workerCmd.Flags().IntVar(&workerCapacity, "capacity", 1,
+		"Number of tasks to run at once")
+	workerCmd.Flags().StringVar(&workerWorkDir, "work-dir", "",
+		"Directory for task checkouts (default: the temp directory)")
+	workerCmd.Flags().StringVar(&workerTokenEnv, "token-env", "QUORUM_WORKER_TOKEN",
+		"Environment variable holding the server's worker token")
+	_ = workerCmd.MarkFlagRequired("coordinator")
+}
+
+func runWorker(_ *cobra.Command, _ []string) error {
+	logger := logging.New(logging.Config{
+		Level: logLevel, Format: logFormat, Output: os.Stdout,
+	})
+
+	loader := config.NewLoaderWithViper(viper.GetViper())
+	if cfgFile != "" {
+		loader.WithConfigFile(cfgFile)
+	}
+	cfg, err := loader.Load()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	w, err := worker.New(worker.Options{
+		CoordinatorURL: workerCoordinator,
+		Token:          os.Getenv(workerTokenEnv),
+		Name:           workerName,
+		Capacity:       workerCapacity,
+		WorkDir:        workerWorkDir,
+		Version:        GetVersion(),
+		NewRegistry: func() (core.AgentRegistry, error) {
+			registry := cli.NewRegistry()
+			if err := configureAgentsFromConfig(registry, cfg, loader); err != nil {
+				return nil, err
+			}
+			// Concurrent jobs of the worker share its rate limiters.
+			return service.GetGlobalRateLimiter().WrapAgents(registry), nil
+		},
+		Logger: logger.Logger,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	return w.Run(ctx)
+}
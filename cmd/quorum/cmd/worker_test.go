@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/testutil"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/worker"
+)
+
+// cliEnv makes the test binary run the quorum command line instead of the
+// tests, so commands can be tested as separate processes.
+const cliEnv = "QUORUM_TEST_RUN_CLI"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(cliEnv) != "" {
+		if err := Execute(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(ExitCode(err))
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// fakeClaude is a claude CLI that rewrites README.md in its working
+// directory and reports success in the stream-json format.
+const fakeClaude = `#!/bin/sh
+if [ "$1" = "--version" ]; then
+	echo "2.1.50 (Claude Code)"
+	exit 0
+fi
+cat >/dev/null
+printf 'hello, world\n' > README.md
+printf '{"type":"result","subtype":"success","result":"done remotely"}\n'
+`
+
+func TestWorkerCommand_RunsTaskForCoordinator(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts a worker process")
+	}
+
+	repo := testutil.NewGitRepo(t)
+	repo.WriteFile("README.md", "hello\n")
+	base := repo.Commit("initial")
+
+	coordinator := worker.NewCoordinator(worker.CoordinatorOptions{Token: "secret", Timeout: 30 * time.Second})
+	r := chi.NewRouter()
+	r.Route("/api/v1", coordinator.RegisterRoutes)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	dir := t.TempDir()
+	claudePath := filepath.Join(dir, "claude")
+	if err := os.WriteFile(claudePath, []byte(fakeClaude), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(dir, "config.yaml")
+	config := "agents:\n  default: claude\n  claude:\n    enabled: true\n    path: " + claudePath + "\n" +
+		"    phases:\n      execute: true\n"
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(os.Args[0], "worker", "--config", configPath,
+		"--coordinator", srv.URL, "--name", "remote", "--work-dir", t.TempDir())
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), cliEnv+"=1", "QUORUM_WORKER_TOKEN=secret")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	exited := make(chan struct{})
+	var exitErr error
+	go func() { exitErr = cmd.Wait(); close(exited) }()
+	defer func() {
+		_ = cmd.Process.Signal(syscall.SIGINT)
+		select {
+		case <-exited:
+			if exitErr != nil {
+				t.Errorf("worker exited with %v\n%s", exitErr, out.String())
+			}
+		case <-time.After(10 * time.Second):
+			_ = cmd.Process.Kill()
+			t.Errorf("worker did not stop on SIGINT\n%s", out.String())
+		}
+	}()
+
+	deadline := time.Now().Add(15 * time.Second)
+	for !coordinator.HasWorkerFor("claude") {
+		select {
+		case <-exited:
+			t.Fatalf("worker exited before registering: %v\n%s", exitErr, out.String())
+		default:
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("worker did not register\n%s", out.String())
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	local := testutil.NewMockRegistry()
+	local.Add("claude", testutil.NewMockAgent("claude").WithResponse("done locally"))
+	agent, err := worker.NewRegistry(local, coordinator, nil).Get("claude")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	result, err := agent.Execute(context.Background(), core.ExecuteOptions{
+		Prompt:  "Greet the world in README.md",
+		Phase:   core.PhaseExecute,
+		WorkDir: repo.Path,
+		Timeout: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v\n%s", err, out.String())
+	}
+	if !strings.Contains(result.Output, "done remotely") {
+		t.Errorf("Execute() output = %q, want the worker result", result.Output)
+	}
+	if head, _ := repo.Run("rev-parse", "HEAD"); head != base {
+		t.Errorf("HEAD = %s, want %s", head, base)
+	}
+	if status, _ := repo.Run("status", "--porcelain"); status != "M  README.md" {
+		t.Errorf("git status = %q, want README.md changed by the worker", status)
+	}
+}
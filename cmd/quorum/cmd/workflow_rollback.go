@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/workflow"
+)
+
+var workflowRollbackCmd = &cobra.Command{
+	Use:   "rollback <workflow-id>",
+	Short: "Reopen an earlier phase of a workflow",
+	Long: `Reopen an earlier phase of a workflow and discard what it produced from
+that phase on.
+
+The checkpoints of the phase and the later phases are removed. Rolling back to
+plan or an earlier phase removes the tasks; rolling back to execute resets them
+to pending. The worktrees and branches of the workflow are deleted, and the
+report outputs of the discarded attempt are moved to the attempts directory of
+the workflow's report directory for comparison.
+
+The workflow becomes the active workflow, and the next run restarts at the
+reopened phase.
+
+Examples:
+  quorum workflow rollback wf-20250121-153045-k7m9p --to analyze
+  quorum workflow rollback wf-20250121-153045-k7m9p --to execute --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorkflowRollback,
+}
+
+var (
+	rollbackToPhase string
+	rollbackForce   bool
+)
+
+func init() {
+	workflowCmd.AddCommand(workflowRollbackCmd)
+	workflowRollbackCmd.Flags().StringVar(&rollbackToPhase, "to", "", "Phase to reopen (refine, analyze, plan, execute)")
+	workflowRollbackCmd.Flags().BoolVarP(&rollbackForce, "force", "f", false, "Skip confirmation prompt")
+	_ = workflowRollbackCmd.MarkFlagRequired("to")
+}
+
+func runWorkflowRollback(_ *cobra.Command, args []string) error {
+	ctx := context.Background()
+	workflowID := core.WorkflowID(args[0])
+	toPhase := core.Phase(strings.ToLower(strings.TrimSpace(rollbackToPhase)))
+	if core.PhaseOrder(toPhase) < 0 || toPhase == core.PhaseDone {
+		return fmt.Errorf("invalid phase %q: must be refine, analyze, plan or execute", rollbackToPhase)
+	}
+
+	deps, err := InitPhaseRunner(ctx, toPhase, 0, false)
+	if err != nil {
+		return err
+	}
+
+	if err := deps.StateAdapter.AcquireLock(ctx); err != nil {
+		return fmt.Errorf("acquiring lock: %w", err)
+	}
+	defer func() { _ = deps.StateAdapter.ReleaseLock(ctx) }()
+
+	wf, err := deps.StateAdapter.LoadByID(ctx, workflowID)
+	if err != nil || wf == nil {
+		return fmt.Errorf("workflow not found: %s", workflowID)
+	}
+	if err := workflow.ValidateRollback(wf, toPhase); err != nil {
+		return err
+	}
+
+	if !rollbackForce {
+		fmt.Printf("Roll back workflow %s to the %s phase?\n", workflowID, toPhase)
+		fmt.Printf("  Status: %s\n", formatStatus(wf.Status))
+		fmt.Printf("  Phase:  %s\n", formatPhase(wf.CurrentPhase))
+		fmt.Printf("  Tasks:  %d\n", len(wf.Tasks))
+		fmt.Print("\nWork done from this phase on, including its branches, will be discarded. Continue? [y/N] ")
+
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+
+		if response != "y" && response != "yes" {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	rollback := &workflow.PhaseRollback{
+		State:             deps.StateAdapter,
+		Checkpoint:        deps.CheckpointAdapter,
+		WorkflowWorktrees: deps.WorkflowWorktrees,
+		Logger:            deps.Logger,
+	}
+	// The git client is only usable when the worktree manager could be created.
+	if deps.WorktreeManager != nil {
+		rollback.Git = deps.GitClient
+	}
+	if deps.RunnerConfig != nil {
+		rollback.ReportBaseDir = deps.RunnerConfig.Report.BaseDir
+	}
+	result, err := rollback.Rollback(ctx, wf, toPhase)
+	if err != nil {
+		return fmt.Errorf("rolling back workflow: %w", err)
+	}
+
+	if err := deps.StateManager.SetActiveWorkflowID(ctx, workflowID); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: activating workflow: %v\n", err)
+	}
+
+	fmt.Printf("Workflow %s rolled back from %s to %s.\n", workflowID, formatPhase(result.FromPhase), formatPhase(result.ToPhase))
+	if result.DiscardedTasks > 0 {
+		fmt.Printf("  Discarded tasks: %d\n", result.DiscardedTasks)
+	}
+	if result.ArchivePath != "" {
+		fmt.Printf("  Previous outputs: %s\n", result.ArchivePath)
+	}
+	switch toPhase {
+	case core.PhasePlan:
+		fmt.Println("Continue with 'quorum plan'.")
+	case core.PhaseExecute:
+		fmt.Println("Continue with 'quorum execute'.")
+	default:
+		fmt.Println("Continue with 'quorum run --resume'.")
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -306,3 +307,16 @@ func TestFormatTime_ValidTime(t *testing.T) {
 		t.Errorf("expected %s, got %s", expected, got)
 	}
 }
+
+func TestRunWorkflowRollback_InvalidPhase(t *testing.T) {
+	orig := rollbackToPhase
+	t.Cleanup(func() { rollbackToPhase = orig })
+
+	for _, phase := range []string{"done", "review", ""} {
+		rollbackToPhase = phase
+		err := runWorkflowRollback(workflowRollbackCmd, []string{"wf-1"})
+		if err == nil || !strings.Contains(err.Error(), "invalid phase") {
+			t.Errorf("--to %q: error = %v, want invalid phase", phase, err)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"github.com/hugo-lorenzo-mato/quorum-ai/cmd/quorum/cmd"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/sandbox"
 )
 
 // Version information - set by goreleaser at build time
@@ -14,11 +15,14 @@ var (
 )
 
 func main() {
+	// Become the sandbox init process when re-executed by the sandbox
+	sandbox.MaybeRunInit()
+
 	// Inject version info into command package
 	cmd.SetVersion(version, commit, date)
 
 	// Execute root command
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
@@ -0,0 +1,207 @@
+import { useEffect, useState } from 'react';
+import PropTypes from 'prop-types';
+import { X, GitBranchPlus, Loader2, RefreshCw } from 'lucide-react';
+import { chatApi } from '../../lib/api';
+
+const COLUMN_OPTIONS = [
+  { value: 'refinement', label: 'Refinement' },
+  { value: 'todo', label: 'To Do' },
+];
+
+/**
+ * PromoteDialog - Drafts a workflow from a chat session for review before
+ * creating it.
+ */
+export default function PromoteDialog({ isOpen, onClose, session, onPromote }) {
+  const [title, setTitle] = useState('');
+  const [prompt, setPrompt] = useState('');
+  const [kanbanColumn, setKanbanColumn] = useState('refinement');
+  const [drafting, setDrafting] = useState(false);
+  const [creating, setCreating] = useState(false);
+  const [error, setError] = useState(null);
+  const sessionId = session?.id;
+
+  const draft = async () => {
+    setDrafting(true);
+    setError(null);
+    try {
+      const result = await chatApi.promote(sessionId, { draftOnly: true });
+      setTitle(result.title || '');
+      setPrompt(result.prompt || '');
+    } catch (err) {
+      setError(err.message || 'Failed to draft the workflow');
+    } finally {
+      setDrafting(false);
+    }
+  };
+
+  useEffect(() => {
+    if (!isOpen || !sessionId) return;
+    setTitle('');
+    setPrompt('');
+    setKanbanColumn('refinement');
+    draft();
+    // eslint-disable-next-line react-hooks/exhaustive-deps
+  }, [isOpen, sessionId]);
+
+  useEffect(() => {
+    if (!isOpen) return;
+    const handleEscape = (e) => {
+      if (e.key === 'Escape') onClose();
+    };
+    document.addEventListener('keydown', handleEscape);
+    return () => document.removeEventListener('keydown', handleEscape);
+  }, [isOpen, onClose]);
+
+  const handleCreate = async () => {
+    if (!prompt.trim()) {
+      setError('Prompt is required');
+      return;
+    }
+    setCreating(true);
+    setError(null);
+    try {
+      await onPromote({ title: title.trim(), prompt, kanbanColumn });
+      onClose();
+    } catch (err) {
+      setError(err.message || 'Failed to create the workflow');
+    } finally {
+      setCreating(false);
+    }
+  };
+
+  if (!isOpen) return null;
+
+  const busy = drafting || creating;
+
+  return (
+    <div className="fixed inset-0 z-50 flex items-center justify-center">
+      {/* Backdrop */}
+      <button
+        type="button"
+        className="absolute inset-0 bg-background/80 backdrop-blur-sm animate-fade-in"
+        onClick={onClose}
+        aria-label="Close modal"
+      />
+
+      {/* Modal */}
+      <div className="relative w-full max-w-3xl mx-4 bg-card border border-border rounded-xl shadow-2xl animate-fade-up">
+        {/* Header */}
+        <div className="flex items-center justify-between p-4 border-b border-border">
+          <div className="flex items-center gap-2">
+            <GitBranchPlus className="w-4 h-4 text-muted-foreground" />
+            <h2 className="text-lg font-semibold text-foreground">Promote to Workflow</h2>
+          </div>
+          <button
+            onClick={onClose}
+            className="p-1.5 rounded-lg hover:bg-accent text-muted-foreground hover:text-foreground transition-colors"
+          >
+            <X className="w-5 h-5" />
+          </button>
+        </div>
+
+        {/* Body */}
+        <div className="p-4 space-y-4 overflow-y-auto max-h-[calc(100vh-14rem)]">
+          {drafting ? (
+            <div className="flex items-center justify-center gap-2 py-12 text-sm text-muted-foreground">
+              <Loader2 className="w-4 h-4 animate-spin" />
+              Drafting the workflow from the conversation...
+            </div>
+          ) : (
+            <>
+              <div>
+                <label htmlFor="promote-workflow-title" className="block text-sm font-medium text-foreground mb-1.5">
+                  Title
+                </label>
+                <input
+                  id="promote-workflow-title"
+                  type="text"
+                  value={title}
+                  onChange={(e) => setTitle(e.target.value)}
+                  className="w-full px-3 py-2 rounded-lg border border-input bg-background text-foreground placeholder:text-muted-foreground focus:outline-none focus:ring-2 focus:ring-ring focus:ring-offset-2 focus:ring-offset-background transition-shadow"
+                />
+              </div>
+
+              <div>
+                <label htmlFor="promote-workflow-prompt" className="block text-sm font-medium text-foreground mb-1.5">
+                  Prompt
+                </label>
+                <textarea
+                  id="promote-workflow-prompt"
+                  value={prompt}
+                  onChange={(e) => setPrompt(e.target.value)}
+                  rows={14}
+                  className="w-full px-3 py-2 rounded-lg border border-input bg-background text-foreground placeholder:text-muted-foreground focus:outline-none focus:ring-2 focus:ring-ring focus:ring-offset-2 focus:ring-offset-background transition-shadow text-sm font-mono"
+                />
+                <p className="text-xs text-muted-foreground mt-1">
+                  The chat transcript and its attachments are attached to the workflow.
+                </p>
+              </div>
+
+              <div>
+                <label htmlFor="promote-workflow-column" className="block text-sm font-medium text-foreground mb-1.5">
+                  Kanban column
+                </label>
+                <select
+                  id="promote-workflow-column"
+                  value={kanbanColumn}
+                  onChange={(e) => setKanbanColumn(e.target.value)}
+                  className="w-full px-3 py-2 border border-input rounded-lg bg-background text-foreground text-sm focus:outline-none focus:ring-2 focus:ring-ring focus:ring-offset-2 focus:ring-offset-background"
+                >
+                  {COLUMN_OPTIONS.map((column) => (
+                    <option key={column.value} value={column.value}>
+                      {column.label}
+                    </option>
+                  ))}
+                </select>
+              </div>
+            </>
+          )}
+
+          {/* Error */}
+          {error && (
+            <p className="text-sm text-error">{error}</p>
+          )}
+        </div>
+
+        {/* Footer */}
+        <div className="flex items-center justify-between p-4 border-t border-border bg-muted/30 rounded-b-xl">
+          <button
+            onClick={draft}
+            disabled={busy}
+            className="flex items-center gap-1.5 px-3 py-2 rounded-lg text-sm text-muted-foreground hover:bg-accent hover:text-foreground transition-colors disabled:opacity-50"
+          >
+            <RefreshCw className="w-3.5 h-3.5" />
+            Redraft
+          </button>
+          <div className="flex items-center gap-2">
+            <button
+              onClick={onClose}
+              disabled={creating}
+              className="px-4 py-2 rounded-lg text-sm font-medium text-foreground hover:bg-accent transition-colors disabled:opacity-50"
+            >
+              Cancel
+            </button>
+            <button
+              onClick={handleCreate}
+              disabled={busy || !prompt.trim()}
+              className="px-4 py-2 rounded-lg text-sm font-medium bg-primary text-primary-foreground hover:bg-primary/90 transition-colors disabled:opacity-50"
+            >
+              {creating ? 'Creating...' : 'Create Workflow'}
+            </button>
+          </div>
+        </div>
+      </div>
+    </div>
+  );
+}
+
+PromoteDialog.propTypes = {
+  isOpen: PropTypes.bool.isRequired,
+  onClose: PropTypes.func.isRequired,
+  onPromote: PropTypes.func.isRequired,
+  session: PropTypes.shape({
+    id: PropTypes.string,
+    title: PropTypes.string,
+  }),
+};
@@ -2,3 +2,4 @@ export { default as AgentSelector } from './AgentSelector';
 export { default as ModelSelector } from './ModelSelector';
 export { default as ReasoningSelector } from './ReasoningSelector';
 export { default as AttachmentPicker } from './AttachmentPicker';
+export { default as PromoteDialog } from './PromoteDialog';
@@ -3,6 +3,7 @@ import { useConfigField, useConfigSelect } from '../../../hooks/useConfigField';
 import {
   SettingSection,
   TextInputSetting,
+  NumberInputSetting,
   SelectSetting,
   ToggleSetting,
 } from '../index';
@@ -105,10 +106,14 @@ function FinalizationSection() {
   const autoMerge = useConfigField('git.finalization.auto_merge');
   const prBaseBranch = useConfigField('git.finalization.pr_base_branch');
   const mergeStrategy = useConfigSelect('git.finalization.merge_strategy', 'merge_strategies');
+  const babysit = useConfigField('git.finalization.babysit.enabled');
+  const babysitAttempts = useConfigField('git.finalization.babysit.max_attempts');
+  const babysitAgent = useConfigField('git.finalization.babysit.agent');
 
   const { value: autoPushValue } = autoPush;
   const { value: autoPrValue, onChange: setAutoPr } = autoPr;
   const { value: autoMergeValue, onChange: setAutoMerge } = autoMerge;
+  const { value: babysitValue, onChange: setBabysit } = babysit;
 
   // Handle dependency chain: when a toggle is disabled, disable all dependents
   useEffect(() => {
@@ -123,6 +128,12 @@ function FinalizationSection() {
     }
   }, [autoPrValue, autoMergeValue, setAutoMerge]);
 
+  useEffect(() => {
+    if (!autoPrValue && babysitValue) {
+      setBabysit(false);
+    }
+  }, [autoPrValue, babysitValue, setBabysit]);
+
   return (
     <SettingSection
       title="Workflow Finalization"
@@ -184,6 +195,43 @@ function FinalizationSection() {
         disabled={prBaseBranch.disabled || !autoPr.value}
       />
 
+      <ToggleSetting
+        label="Babysit PR Checks"
+        description="Wait for CI checks and let an agent fix failures"
+        tooltip="When enabled, waits for the PR checks after creating it. If a check fails, its failed log is fed to an agent that pushes a fix to the workflow branch, up to the configured number of attempts. Auto merge only happens once checks pass. Requires auto_pr."
+        checked={babysit.value}
+        onChange={babysit.onChange}
+        error={babysit.error}
+        disabled={babysit.disabled || !autoPr.value}
+        helperText={!autoPr.value ? "Enable 'Auto PR' first" : undefined}
+      />
+
+      {babysit.value && (
+        <>
+          <NumberInputSetting
+            label="Fix Attempts"
+            tooltip="Number of fix attempts before the workflow is handed over for review with a summary. 0 only waits for checks."
+            min={0}
+            max={10}
+            value={babysitAttempts.value}
+            onChange={babysitAttempts.onChange}
+            error={babysitAttempts.error}
+            disabled={babysitAttempts.disabled}
+          />
+
+          <TextInputSetting
+            label="Fix Agent"
+            description="Agent that fixes failing checks"
+            tooltip="Name of the agent that runs fix tasks. Leave empty to use the default agent."
+            placeholder="(default agent)"
+            value={babysitAgent.value || ''}
+            onChange={babysitAgent.onChange}
+            error={babysitAgent.error}
+            disabled={babysitAgent.disabled}
+          />
+        </>
+      )}
+
       <ToggleSetting
         label="Auto Merge"
         description="Automatically merge PR when checks pass"
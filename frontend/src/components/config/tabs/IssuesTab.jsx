@@ -16,6 +16,7 @@ export function IssuesTab() {
       <GeneratorSection />
       <PromptSection />
       <LabelsSection />
+      <ImportSection />
       <GitLabSection />
     </div>
   );
@@ -445,6 +446,54 @@ function LabelsSection() {
   );
 }
 
+function ImportSection() {
+  const enabled = useConfigField('issues.enabled');
+  const provider = useConfigField('issues.provider');
+  const importEnabled = useConfigField('issues.import.enabled');
+  const importLabels = useConfigField('issues.import.labels');
+  const importInterval = useConfigField('issues.import.interval');
+
+  const isDisabled = !enabled.value || provider.value === 'gitlab';
+
+  return (
+    <SettingSection
+      title="Issue Import"
+      description="Create Kanban workflows from labeled GitHub issues"
+    >
+      <ToggleSetting
+        label="Import Labeled Issues"
+        description="Add open issues with an import label to the To Do column"
+        tooltip="While the server runs, labeled issues become workflows. The label is removed and a comment with the workflow ID is posted on the issue."
+        checked={importEnabled.value}
+        onChange={importEnabled.onChange}
+        error={importEnabled.error}
+        disabled={importEnabled.disabled || isDisabled}
+      />
+
+      <ArrayInputSetting
+        label="Import Labels"
+        description="Issues carrying any of these labels are imported"
+        tooltip="Add one of these labels to an issue to queue it as a workflow."
+        value={importLabels.value || []}
+        onChange={importLabels.onChange}
+        error={importLabels.error}
+        disabled={importLabels.disabled || isDisabled || !importEnabled.value}
+        placeholder="Add label (e.g., 'quorum')..."
+      />
+
+      <DurationInputSetting
+        label="Poll Interval"
+        description="Minimum time between two imports"
+        tooltip="How often the issue tracker is checked for newly labeled issues."
+        value={importInterval.value}
+        onChange={importInterval.onChange}
+        error={importInterval.error}
+        disabled={importInterval.disabled || isDisabled || !importEnabled.value}
+      />
+    </SettingSection>
+  );
+}
+
 function GitLabSection() {
   const enabled = useConfigField('issues.enabled');
   const provider = useConfigField('issues.provider');
@@ -0,0 +1,144 @@
+import { useEffect, useState } from 'react';
+import PropTypes from 'prop-types';
+import { HelpCircle, Send, X } from 'lucide-react';
+import useWorkflowStore from '../../stores/workflowStore';
+
+const EMPTY = [];
+
+function formatExpiry(expiresAt) {
+  if (!expiresAt) return null;
+  const minutes = Math.round((new Date(expiresAt).getTime() - Date.now()) / 60000);
+  if (minutes <= 0) return 'expires now';
+  return `expires in ${minutes} min`;
+}
+
+/**
+ * TaskInputRequest answers one question asked by a running task. Submitting
+ * an empty answer accepts the answer the agent proposed.
+ */
+function TaskInputRequest({ workflowId, request }) {
+  const [answer, setAnswer] = useState('');
+  const [sending, setSending] = useState(false);
+  const answerInputRequest = useWorkflowStore(state => state.answerInputRequest);
+
+  const send = async (options) => {
+    setSending(true);
+    await answerInputRequest(workflowId, request.id, options);
+    setSending(false);
+  };
+
+  const expiry = formatExpiry(request.expires_at);
+
+  return (
+    <div className="space-y-3">
+      <div>
+        <p className="text-sm font-medium text-foreground">
+          Task {request.task_id} asks: {request.prompt}
+        </p>
+        {request.context && (
+          <p className="mt-1 text-sm text-muted-foreground whitespace-pre-wrap">{request.context}</p>
+        )}
+      </div>
+
+      {request.options?.length > 0 && (
+        <div className="flex flex-wrap gap-2">
+          {request.options.map(option => (
+            <button
+              key={option}
+              onClick={() => send({ input: option })}
+              disabled={sending}
+              className="px-3 py-1.5 rounded-lg border border-border text-sm hover:bg-accent disabled:opacity-50 transition-all"
+            >
+              {option}
+            </button>
+          ))}
+        </div>
+      )}
+
+      <form
+        onSubmit={(e) => { e.preventDefault(); send({ input: answer.trim() }); }}
+        className="flex gap-2"
+      >
+        <input
+          type="text"
+          value={answer}
+          onChange={(e) => setAnswer(e.target.value)}
+          placeholder={request.default ? `Leave empty to accept: ${request.default}` : 'Your answer'}
+          aria-label={`Answer for task ${request.task_id}`}
+          className="flex-1 px-3 py-2 rounded-lg border border-input bg-background text-foreground placeholder:text-muted-foreground focus:outline-none focus:ring-2 focus:ring-ring text-sm"
+        />
+        <button
+          type="submit"
+          disabled={sending || (!answer.trim() && !request.default)}
+          className="inline-flex items-center gap-2 px-3 py-2 rounded-lg bg-primary text-primary-foreground hover:bg-primary/90 disabled:opacity-50 transition-all text-sm"
+        >
+          <Send className="w-4 h-4" />
+          Answer
+        </button>
+        <button
+          type="button"
+          onClick={() => send({ cancel: true })}
+          disabled={sending}
+          title="Dismiss; the task follows the configured timeout policy"
+          className="inline-flex items-center gap-2 px-3 py-2 rounded-lg bg-destructive/10 text-destructive hover:bg-destructive/20 disabled:opacity-50 transition-all text-sm"
+        >
+          <X className="w-4 h-4" />
+          Dismiss
+        </button>
+      </form>
+
+      {expiry && <p className="text-xs text-muted-foreground">Without an answer this question {expiry}.</p>}
+    </div>
+  );
+}
+
+/**
+ * TaskInputRequests lists the questions running tasks of a workflow wait on.
+ */
+export default function TaskInputRequests({ workflow }) {
+  const requests = useWorkflowStore(state => state.inputRequests[workflow.id] || EMPTY);
+  const fetchInputRequests = useWorkflowStore(state => state.fetchInputRequests);
+  const running = workflow.status === 'running';
+
+  useEffect(() => {
+    if (running) fetchInputRequests(workflow.id);
+  }, [fetchInputRequests, workflow.id, running]);
+
+  if (!running || requests.length === 0) return null;
+
+  return (
+    <div className="rounded-lg border border-warning/30 bg-warning/5 p-4 space-y-4">
+      <div className="flex items-center gap-2">
+        <HelpCircle className="w-5 h-5 text-warning" />
+        <h3 className="font-medium text-foreground">
+          {requests.length === 1 ? 'A task needs your input' : `${requests.length} tasks need your input`}
+        </h3>
+      </div>
+      {requests.map(request => (
+        <TaskInputRequest key={request.id} workflowId={workflow.id} request={request} />
+      ))}
+    </div>
+  );
+}
+
+const requestProp = PropTypes.shape({
+  id: PropTypes.string.isRequired,
+  task_id: PropTypes.string,
+  prompt: PropTypes.string.isRequired,
+  context: PropTypes.string,
+  options: PropTypes.arrayOf(PropTypes.string),
+  default: PropTypes.string,
+  expires_at: PropTypes.string,
+});
+
+TaskInputRequests.propTypes = {
+  workflow: PropTypes.shape({
+    id: PropTypes.string.isRequired,
+    status: PropTypes.string.isRequired,
+  }).isRequired,
+};
+
+TaskInputRequest.propTypes = {
+  workflowId: PropTypes.string.isRequired,
+  request: requestProp.isRequired,
+};
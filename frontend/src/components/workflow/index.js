@@ -2,5 +2,6 @@ export { ExecutionModeBadge } from './ExecutionModeBadge';
 export { default as PhaseControls } from './PhaseControls';
 export { default as PhaseStepper } from './PhaseStepper';
 export { default as ReplanModal } from './ReplanModal';
+export { default as TaskInputRequests } from './TaskInputRequests';
 export { default as IssuesPanel } from './IssuesPanel';
 export { default as WorkflowPipelineLive } from './WorkflowPipelineLive';
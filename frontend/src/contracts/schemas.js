@@ -122,6 +122,25 @@ export const chatConfigSchema = {
     timeout: { type: 'string' },
     progress_interval: { type: 'string' },
     editor: { type: 'string' },
+    context: {
+      type: 'object',
+      properties: {
+        recent_turns: { type: 'integer' },
+        max_tokens: { type: 'integer' },
+        summary_agent: { type: 'string' },
+        summary_model: { type: 'string' },
+      },
+      additionalProperties: false,
+    },
+    ask_all: {
+      type: 'object',
+      properties: {
+        agents: { type: 'array', items: { type: 'string' } },
+        moderator: { type: 'string' },
+        moderator_model: { type: 'string' },
+      },
+      additionalProperties: false,
+    },
   },
   additionalProperties: false,
 };
@@ -58,6 +58,8 @@ export default function useSSE() {
   const handlePhaseAwaitingReview = useWorkflowStore(state => state.handlePhaseAwaitingReview);
   const handlePhaseReviewApproved = useWorkflowStore(state => state.handlePhaseReviewApproved);
   const handlePhaseReviewRejected = useWorkflowStore(state => state.handlePhaseReviewRejected);
+  const handleUserInputRequested = useWorkflowStore(state => state.handleUserInputRequested);
+  const handleUserInputProvided = useWorkflowStore(state => state.handleUserInputProvided);
   const setWorkflows = useWorkflowStore(state => state.setWorkflows);
 
   // Task event handlers
@@ -222,11 +224,20 @@ export default function useSSE() {
     task_skipped:   (data) => handleTaskSkipped(data),
     task_retry:     (data) => handleTaskRetry(data),
 
+    // Task questions
+    user_input_requested: (data) => {
+      handleUserInputRequested(data);
+      if (data.task_id) notifyInfo(`Task ${data.task_id} asks: ${data.prompt}`);
+    },
+    user_input_provided:  (data) => handleUserInputProvided(data),
+
     // Agent events
     agent_event: (data) => handleAgentEvent(data),
 
-    // Config / provenance & log events (persisted by ingestSSEEvent; no store updates)
-    config_loaded: () => {},
+    // Config / provenance & log events (persisted by ingestSSEEvent; no store updates).
+    // Without a workflow_id they report a live reload of the config file.
+    config_loaded: (data) => { if (!data.workflow_id) notifyInfo('Configuration reloaded from disk'); },
+    config_reload_failed: (data) => notifyError(`Configuration change rejected, keeping the active config: ${data.error}`),
     log:           () => {},
 
     // Kanban events
@@ -262,6 +273,8 @@ export default function useSSE() {
     handleTaskFailed,
     handleTaskSkipped,
     handleTaskRetry,
+    handleUserInputRequested,
+    handleUserInputProvided,
     handleAgentEvent,
     handleKanbanWorkflowMoved,
     handleKanbanExecutionStarted,
@@ -374,10 +387,13 @@ export default function useSSE() {
       'task_failed',
       'task_skipped',
       'task_retry',
+      'user_input_requested',
+      'user_input_provided',
       'agent_event',
       'issues_generation_progress',
       'issues_publishing_progress',
       'config_loaded',
+      'config_reload_failed',
       'log',
       'kanban_workflow_moved',
       'kanban_execution_started',
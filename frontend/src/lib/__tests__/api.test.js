@@ -227,6 +227,26 @@ describe('workflowApi', () => {
     });
   });
 
+  describe('rollback', () => {
+    it('posts the phase to reopen', async () => {
+      globalThis.fetch.mockResolvedValue({
+        ok: true,
+        status: 200,
+        json: () => Promise.resolve({ to_phase: 'analyze' }),
+      });
+
+      await workflowApi.rollback('wf-1', 'analyze');
+
+      expect(globalThis.fetch).toHaveBeenCalledWith(
+        '/api/v1/workflows/wf-1/rollback',
+        expect.objectContaining({
+          method: 'POST',
+          body: JSON.stringify({ to_phase: 'analyze' }),
+        })
+      );
+    });
+  });
+
   describe('review', () => {
     it('maps continueUnattended to continue_unattended in request body', async () => {
       globalThis.fetch.mockResolvedValue({
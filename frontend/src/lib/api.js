@@ -171,6 +171,19 @@ export const workflowApi = {
     return request(`/workflows/${id}/replan`, options);
   },
 
+  /**
+   * Reopen an earlier phase of a workflow.
+   * Discards the tasks, worktrees and branches created from that phase on and
+   * archives the report outputs of the discarded attempt.
+   * @param {string} id - Workflow ID
+   * @param {string} toPhase - Phase to reopen: refine, analyze, plan or execute
+   * @returns {Promise<Object>} - RollbackResponse with from_phase, to_phase, status, archive_path, discarded_tasks
+   */
+  rollback: (id, toPhase) => request(`/workflows/${id}/rollback`, {
+    method: 'POST',
+    body: JSON.stringify({ to_phase: toPhase }),
+  }),
+
   /**
    * Run only the execute phase of a workflow.
    * Requires completed plan phase with tasks defined.
@@ -207,6 +220,18 @@ export const workflowApi = {
     method: 'POST',
   }),
 
+  // Questions asked by running execute tasks
+  listInputs: (id) => request(`/workflows/${id}/inputs`),
+
+  /**
+   * Answer a task question. An empty input accepts the answer the agent
+   * proposed; cancel dismisses the question.
+   */
+  answerInput: (id, requestId, { input = '', cancel = false } = {}) => request(`/workflows/${id}/inputs/${requestId}`, {
+    method: 'POST',
+    body: JSON.stringify({ input, cancel }),
+  }),
+
   // Task mutation endpoints
   createTask: (workflowId, data) => request(`/workflows/${workflowId}/tasks/`, {
     method: 'POST',
@@ -227,6 +252,24 @@ export const workflowApi = {
     body: JSON.stringify({ task_order: taskOrder }),
   }),
 
+  /**
+   * Skip a pending, running or failed task. A running workflow skips the
+   * task itself; otherwise the saved task is marked skipped.
+   */
+  skipTask: (workflowId, taskId, reason = '') => request(`/workflows/${workflowId}/tasks/${taskId}/skip`, {
+    method: 'POST',
+    body: JSON.stringify({ reason }),
+  }),
+
+  /**
+   * Retry a failed task, optionally with another agent, model or extra
+   * instructions.
+   */
+  retryTask: (workflowId, taskId, { agent = '', model = '', instructions = '' } = {}) => request(`/workflows/${workflowId}/tasks/${taskId}/retry`, {
+    method: 'POST',
+    body: JSON.stringify({ agent, model, instructions }),
+  }),
+
   // Issue generation
   /**
    * Generate GitHub/GitLab issues from workflow artifacts.
@@ -429,6 +472,29 @@ export const chatApi = {
     }),
   }),
 
+  askAll: (sessionId, content, options = {}) => request(`/chat/sessions/${sessionId}/ask-all`, {
+    method: 'POST',
+    body: JSON.stringify({
+      content,
+      agents: options.agents?.length > 0 ? options.agents : undefined,
+      moderator: options.moderator || undefined,
+      reasoning_effort: options.reasoningEffort || undefined,
+      attachments: options.attachments?.length > 0 ? options.attachments : undefined,
+    }),
+  }),
+
+  promote: (sessionId, options = {}) => request(`/chat/sessions/${sessionId}/promote`, {
+    method: 'POST',
+    body: JSON.stringify({
+      prompt: options.prompt || undefined,
+      title: options.title || undefined,
+      draft_only: options.draftOnly || undefined,
+      agent: options.agent || undefined,
+      model: options.model || undefined,
+      kanban_column: options.kanbanColumn || undefined,
+    }),
+  }),
+
   uploadAttachments: async (sessionId, files) => {
     const formData = new FormData();
     for (const file of files) {
@@ -1,6 +1,7 @@
 import { useEffect, useRef, useState } from 'react';
 import { createPortal } from 'react-dom';
-import { useChatStore } from '../stores';
+import { Link } from 'react-router-dom';
+import { useChatStore, useWorkflowStore } from '../stores';
 import {
   Send,
   Plus,
@@ -17,6 +18,10 @@ import {
   ArrowLeft,
   PanelLeftClose,
   PanelLeft,
+  Paperclip,
+  Users,
+  GitBranchPlus,
+  Workflow,
 } from 'lucide-react';
 import Logo from '../components/Logo';
 import {
@@ -24,8 +29,10 @@ import {
   ModelSelector,
   ReasoningSelector,
   AttachmentPicker,
+  PromoteDialog,
 } from '../components/chat';
 import ChatMarkdown from '../components/ChatMarkdown';
+import StatusBadge from '../components/StatusBadge';
 import VoiceInputButton from '../components/VoiceInputButton';
 import { supportsReasoning } from '../lib/agents';
 
@@ -165,11 +172,25 @@ function MessageBubble({ message, isLast }) {
 
   if (isUser) {
     return (
-      <div className={`w-full py-4 flex flex-col items-end px-4 md:px-8 ${isLast ? 'animate-fade-up' : ''}`}>
+      <div className={`w-full py-4 flex flex-col items-end px-4 md:px-8 ${isLast ? 'animate-fade-up' : ''} ${message.summarized ? 'opacity-60' : ''}`}>
         <div className="max-w-[85%] md:max-w-[70%] bg-primary text-primary-foreground rounded-2xl rounded-tr-sm px-4 py-2.5 shadow-sm relative group">
           <div className="text-sm leading-relaxed">
             <ChatMarkdown content={message.content} isUser={true} />
           </div>
+          {message.attachments?.length > 0 && (
+            <div className="mt-2 flex flex-wrap gap-1.5">
+              {message.attachments.map((path) => (
+                <span
+                  key={path}
+                  className="inline-flex items-center gap-1 text-[10px] font-mono bg-primary-foreground/10 rounded px-1.5 py-0.5"
+                  title={path}
+                >
+                  <Paperclip className="w-3 h-3" />
+                  {path.split('/').pop()}
+                </span>
+              ))}
+            </div>
+          )}
           <div className="absolute top-0 right-full mr-2 hidden group-hover:flex items-center gap-2 h-full">
             <button
               type="button"
@@ -181,6 +202,7 @@ function MessageBubble({ message, isLast }) {
             </button>
             <span className="text-[10px] text-muted-foreground whitespace-nowrap font-mono">
               {new Date(message.timestamp).toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' })}
+              {message.summarized && ' · summarized'}
             </span>
           </div>
         </div>
@@ -189,7 +211,7 @@ function MessageBubble({ message, isLast }) {
   }
 
   return (
-    <div className={`w-full py-8 border-b border-border/30 bg-muted/5 ${isLast ? 'animate-fade-up' : ''}`}>
+    <div className={`w-full py-8 border-b border-border/30 ${message.consensus != null ? 'bg-primary/5' : 'bg-muted/5'} ${message.group_id && message.consensus == null ? 'border-l-2 border-l-primary/20' : ''} ${isLast ? 'animate-fade-up' : ''} ${message.summarized ? 'opacity-60' : ''}`}>
       <div className="w-full px-4 md:px-8 flex gap-4 md:gap-6">
         <div className="w-8 h-8 rounded-lg bg-primary/10 flex items-center justify-center flex-shrink-0 mt-0.5 border border-primary/20">
           <Logo className="w-4 h-4 text-primary" />
@@ -200,9 +222,41 @@ function MessageBubble({ message, isLast }) {
               <span className="text-[10px] font-bold uppercase tracking-widest text-primary bg-primary/5 px-2 py-0.5 rounded">
                 {agentName}
               </span>
+              {message.model && (
+                <span className="text-[10px] text-muted-foreground font-mono">{message.model}</span>
+              )}
               <span className="text-[10px] text-muted-foreground font-mono opacity-60">
                 {new Date(message.timestamp).toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' })}
               </span>
+              {(message.tokens?.input > 0 || message.tokens?.output > 0) && (
+                <span className="text-[10px] text-muted-foreground font-mono opacity-60">
+                  ↑{message.tokens.input} ↓{message.tokens.output} tok
+                </span>
+              )}
+              {message.consensus != null && (
+                <span
+                  className="text-[10px] font-mono text-primary bg-primary/5 px-1.5 py-0.5 rounded"
+                  title="Synthesis of the agents' answers and their agreement"
+                >
+                  synthesis · {Math.round(message.consensus * 100)}% agreement
+                </span>
+              )}
+              {message.summarized && (
+                <span
+                  className="text-[10px] text-muted-foreground font-mono opacity-60"
+                  title="Sent to agents only through the conversation summary"
+                >
+                  summarized
+                </span>
+              )}
+              {message.context?.notice && (
+                <span
+                  className="text-[10px] text-muted-foreground font-mono opacity-60"
+                  title={message.context.summary_error || message.context.notice}
+                >
+                  {message.context.summary_error ? 'context reduced (summary failed)' : 'context summarized'}
+                </span>
+              )}
             </div>
             <button
               type="button"
@@ -342,11 +396,11 @@ function EmptyChat({ onCreateSession }) {
 export default function Chat() {
   const {
     sessions, activeSessionId, loading, sending, error, sidebarCollapsed,
-    fetchSessions, createSession, selectSession, deleteSession, updateSession,
+    fetchSessions, createSession, selectSession, deleteSession, updateSession, promoteSession,
     sendMessage, getActiveMessages, clearError, toggleSidebar,
     // Per-message options
-    currentAgent, currentModel, currentReasoningEffort, attachments,
-    setCurrentAgent, setCurrentModel, setCurrentReasoningEffort,
+    currentAgent, currentModel, currentReasoningEffort, attachments, askAll,
+    setCurrentAgent, setCurrentModel, setCurrentReasoningEffort, setAskAll,
     addAttachment, removeAttachment, clearAttachments, uploadAttachments,
   } = useChatStore();
 
@@ -354,12 +408,15 @@ export default function Chat() {
   const [isEditingTitle, setIsEditingTitle] = useState(false);
   const [editTitleValue, setEditTitleValue] = useState('');
   const [imagePreviews, setImagePreviews] = useState([]); // [{path, previewUrl, name}]
+  const [promoteOpen, setPromoteOpen] = useState(false);
   const messagesEndRef = useRef(null);
   const inputRef = useRef(null);
   const titleInputRef = useRef(null);
 
   const activeMessages = getActiveMessages();
   const activeSession = sessions.find((s) => s.id === activeSessionId);
+  const linkedWorkflowId = activeSession?.workflow_id;
+  const linkedWorkflow = useWorkflowStore((state) => state.workflows.find((w) => w.id === linkedWorkflowId));
 
   // Collapse sidebar on mount
   useEffect(() => {
@@ -367,6 +424,11 @@ export default function Chat() {
   }, []);
 
   useEffect(() => { fetchSessions(); }, [fetchSessions]);
+  useEffect(() => {
+    if (linkedWorkflowId && !linkedWorkflow) {
+      useWorkflowStore.getState().fetchWorkflow(linkedWorkflowId, { silent: true });
+    }
+  }, [linkedWorkflowId, linkedWorkflow]);
   useEffect(() => {
     messagesEndRef.current?.scrollIntoView({ behavior: 'smooth' });
   }, [activeMessages, activeSessionId]);
@@ -386,6 +448,10 @@ export default function Chat() {
     inputRef.current?.focus();
   };
 
+  const handlePromote = async (options) => {
+    await promoteSession(activeSessionId, options);
+  };
+
   const handleCreateSession = async () => {
     await createSession();
   };
@@ -597,6 +663,25 @@ export default function Chat() {
               </div>
 
               <div className="flex items-center gap-1">
+                {linkedWorkflowId ? (
+                  <Link
+                    to={`/workflows/${linkedWorkflowId}`}
+                    className="flex items-center gap-1.5 px-2 py-1 rounded-lg text-xs text-muted-foreground hover:bg-accent hover:text-foreground transition-colors"
+                    title={linkedWorkflow?.title || 'Open the promoted workflow'}
+                  >
+                    <Workflow className="w-4 h-4" />
+                    {linkedWorkflow?.status && <StatusBadge status={linkedWorkflow.status} />}
+                  </Link>
+                ) : (
+                  <button
+                    onClick={() => setPromoteOpen(true)}
+                    disabled={sending || activeMessages.length === 0}
+                    className="p-1.5 rounded-lg text-muted-foreground hover:text-foreground hover:bg-accent transition-colors disabled:opacity-50"
+                    title="Promote to workflow"
+                  >
+                    <GitBranchPlus className="w-4 h-4" />
+                  </button>
+                )}
                 <button
                   onClick={() => deleteSession(activeSession.id)}
                   className="p-1.5 rounded-lg text-muted-foreground hover:text-destructive hover:bg-destructive/10 transition-colors"
@@ -722,6 +807,17 @@ export default function Chat() {
                       onTranscript={(text) => setInput((prev) => (prev ? prev + ' ' + text : text))}
                       disabled={sending}
                     />
+                    <button
+                      type="button"
+                      onClick={() => setAskAll(!askAll)}
+                      disabled={sending}
+                      className={`h-8 px-2 flex items-center gap-1.5 rounded-lg text-xs transition-colors disabled:opacity-50 ${askAll ? 'bg-primary/10 text-primary' : 'text-muted-foreground hover:bg-muted'}`}
+                      title="Ask several agents at once and get a moderated synthesis"
+                      aria-pressed={askAll}
+                    >
+                      <Users className="w-3.5 h-3.5" />
+                      <span className="hidden sm:inline">Ask all</span>
+                    </button>
                   </div>
                   
                   <button
@@ -736,6 +832,13 @@ export default function Chat() {
               </form>
               </div>
             </div>
+
+            <PromoteDialog
+              isOpen={promoteOpen}
+              onClose={() => setPromoteOpen(false)}
+              session={activeSession}
+              onPromote={handlePromote}
+            />
           </>
         ) : (
           <EmptyChat onCreateSession={handleCreateSession} />
@@ -308,8 +308,8 @@ function KanbanCard({ workflow, isExecuting, columnAccent, onDragStart, onDragEn
                     target="_blank"
                     rel="noopener noreferrer"
                     onClick={(e) => e.stopPropagation()}
-                    className="flex items-center gap-1.5 text-xs hover:text-primary transition-colors px-2 py-1 rounded-md hover:bg-primary/5"
-                    title={`PR #${workflow.pr_number}`}
+                    className={`flex items-center gap-1.5 text-xs hover:text-primary transition-colors px-2 py-1 rounded-md hover:bg-primary/5 ${workflow.pr_checks_summary ? 'text-amber-600 dark:text-amber-400' : ''}`}
+                    title={workflow.pr_checks_summary || `PR #${workflow.pr_number}`}
                 >
                 <GitPullRequest className="w-3.5 h-3.5" />
                 <span className="font-medium">#{workflow.pr_number}</span>
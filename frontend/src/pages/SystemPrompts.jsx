@@ -4,7 +4,7 @@ import { Badge } from '../components/ui/Badge';
 import { Search, FileCode2, RefreshCw, X } from 'lucide-react';
 
 const PHASES = ['All', 'refine', 'analyze', 'plan', 'execute'];
-const USED_BY = ['All', 'workflow', 'issues'];
+const USED_BY = ['All', 'workflow', 'issues', 'chat'];
 const STATUSES = ['All', 'active', 'reserved', 'deprecated'];
 
 function statusVariant(status) {
@@ -33,6 +33,7 @@ import {
   Trash2,
   FastForward,
   RotateCcw,
+  SkipForward,
   Search,
   List,
   FileText,
@@ -49,6 +50,7 @@ import { ConfirmDialog } from '../components/config/ConfirmDialog';
 import { ExecutionModeBadge, ReplanModal, WorkflowPipelineLive } from '../components/workflow';
 import TaskSelectionModal from '../components/workflow/TaskSelectionModal';
 import ReviewGate from '../components/workflow/ReviewGate';
+import TaskInputRequests from '../components/workflow/TaskInputRequests';
 import PipelineExpandedPanel from '../components/workflow/pipeline/PipelineExpandedPanel';
 import usePipelineState from '../components/workflow/hooks/usePipelineState';
 import { GenerationOptionsModal } from '../components/issues';
@@ -219,7 +221,7 @@ function WorkflowCard({ workflow, onClick, onDelete }) {
   );
 }
 
-function TaskItem({ task, selected, onClick }) {
+function TaskItem({ task, selected, onClick, onSkip, onRetry }) {
   const { bg, text } = getStatusColor(task.status);
   
   // Custom icon map for tasks
@@ -234,7 +236,7 @@ function TaskItem({ task, selected, onClick }) {
   const StatusIcon = iconMap[task.status] || Clock;
   const isRunning = task.status === 'running';
 
-  return (
+  const item = (
     <button
       type="button"
       onClick={onClick}
@@ -256,6 +258,34 @@ function TaskItem({ task, selected, onClick }) {
       </div>
     </button>
   );
+
+  if (!onSkip && !onRetry) return item;
+
+  return (
+    <div className="flex items-center gap-1">
+      <div className="flex-1 min-w-0">{item}</div>
+      {onRetry && (
+        <button
+          type="button"
+          onClick={onRetry}
+          className="p-2 rounded-lg hover:bg-accent text-muted-foreground hover:text-foreground transition-colors"
+          title="Retry task"
+        >
+          <RotateCcw className="w-4 h-4" />
+        </button>
+      )}
+      {onSkip && (
+        <button
+          type="button"
+          onClick={onSkip}
+          className="p-2 rounded-lg hover:bg-accent text-muted-foreground hover:text-foreground transition-colors"
+          title="Skip task"
+        >
+          <SkipForward className="w-4 h-4" />
+        </button>
+      )}
+    </div>
+  );
 }
 
 function WorkflowDetail({ workflow, tasks, onBack }) {
@@ -272,6 +302,8 @@ function WorkflowDetail({ workflow, tasks, onBack }) {
     planWorkflow,
     replanWorkflow,
     executeWorkflow,
+    skipTask,
+    retryTask,
     loading,
     error,
     clearError,
@@ -294,6 +326,29 @@ function WorkflowDetail({ workflow, tasks, onBack }) {
   const attachmentInputRef = useRef(null);
   const [attachmentUploading, setAttachmentUploading] = useState(false);
 
+  // Tasks of a running workflow are skipped or retried by its executor;
+  // otherwise the saved task changes and the next resume picks it up.
+  const canSkipTask = (task) => ['pending', 'running', 'failed'].includes(task.status)
+    && workflow.status !== 'cancelling';
+
+  const handleSkipTask = async (task) => {
+    if (await skipTask(workflow.id, task.id)) {
+      notifyInfo(`Skipping task ${task.name || task.id}`);
+    } else {
+      notifyError(`Failed to skip task ${task.name || task.id}`);
+    }
+  };
+
+  const handleRetryTask = async (task) => {
+    if (await retryTask(workflow.id, task.id)) {
+      notifyInfo(workflow.status === 'running'
+        ? `Retrying task ${task.name || task.id}`
+        : `Task ${task.name || task.id} will run again when the workflow resumes`);
+    } else {
+      notifyError(`Failed to retry task ${task.name || task.id}`);
+    }
+  };
+
   const handleUploadAttachments = useCallback(async (fileList) => {
     if (!fileList || fileList.length === 0) return;
     if (!canModifyAttachments) return;
@@ -1158,6 +1213,9 @@ function WorkflowDetail({ workflow, tasks, onBack }) {
       {/* Interactive Review Gate (shows only when workflow is awaiting_review) */}
       <ReviewGate workflow={workflow} />
 
+      {/* Questions of running tasks */}
+      <TaskInputRequests workflow={workflow} />
+
       {/* Pipeline Detail Panel */}
       <PipelineExpandedPanel
         expandedPhase={expandedPhase}
@@ -1433,6 +1491,8 @@ function WorkflowDetail({ workflow, tasks, onBack }) {
                       task={task}
                       selected={selectedDoc?.key?.includes(`:${task.id}`)}
                       onClick={() => selectTask(task)}
+                      onSkip={canSkipTask(task) ? () => handleSkipTask(task) : undefined}
+                      onRetry={task.status === 'failed' ? () => handleRetryTask(task) : undefined}
                     />
                   ))}
                 </div>
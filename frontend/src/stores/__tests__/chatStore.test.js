@@ -9,6 +9,8 @@ vi.mock('../../lib/api', () => ({
     updateSession: vi.fn(),
     getMessages: vi.fn(),
     sendMessage: vi.fn(),
+    askAll: vi.fn(),
+    promote: vi.fn(),
     uploadAttachments: vi.fn(),
   },
 }));
@@ -33,6 +35,7 @@ function resetStore() {
     sidebarCollapsed: true,
     currentModel: '',
     attachments: [],
+    askAll: false,
   });
 }
 
@@ -90,6 +93,17 @@ describe('chatStore', () => {
     expect(useChatStore.getState().sessions[0].title).toBe('new');
   });
 
+  it('promoteSession links the created workflow to the session', async () => {
+    useChatStore.setState({ sessions: [{ id: 's1', title: 'Retries' }, { id: 's2' }] });
+    chatApi.promote.mockResolvedValue({ title: 'Retry 503', prompt: 'Add retries', workflow_id: 'wf-1' });
+
+    const result = await useChatStore.getState().promoteSession('s1', { prompt: 'Add retries' });
+
+    expect(chatApi.promote).toHaveBeenCalledWith('s1', { prompt: 'Add retries' });
+    expect(result.workflow_id).toBe('wf-1');
+    expect(useChatStore.getState().sessions).toEqual([{ id: 's1', title: 'Retries', workflow_id: 'wf-1' }, { id: 's2' }]);
+  });
+
   it('sendMessage errors when there is no active session', async () => {
     const res = await useChatStore.getState().sendMessage('hi');
     expect(res).toBeNull();
@@ -129,6 +143,55 @@ describe('chatStore', () => {
     vi.useRealTimers();
   });
 
+  it('sendMessage refetches messages when older ones were summarized', async () => {
+    useChatStore.setState({
+      activeSessionId: 's1',
+      messages: { s1: [] },
+      currentAgent: 'claude',
+      attachments: [],
+    });
+
+    chatApi.sendMessage.mockResolvedValue({
+      id: 'm2',
+      content: 'hello',
+      agent: 'claude',
+      context: { summarized_messages: 4, summarized_through: 'm0', estimated_tokens: 900, budget_tokens: 1000 },
+    });
+    const refreshed = [
+      { id: 'm0', role: 'user', content: 'old', summarized: true },
+      { id: 'm1', role: 'user', content: 'hi' },
+      { id: 'm2', role: 'agent', content: 'hello' },
+    ];
+    chatApi.getMessages.mockResolvedValue(refreshed);
+
+    await useChatStore.getState().sendMessage('hi');
+
+    expect(chatApi.getMessages).toHaveBeenCalledWith('s1');
+    expect(useChatStore.getState().messages.s1).toEqual(refreshed);
+  });
+
+  it('sendMessage asks all agents when askAll is set', async () => {
+    useChatStore.setState({ activeSessionId: 's1', messages: { s1: [] }, askAll: true });
+    chatApi.askAll.mockResolvedValue({
+      group_id: 'g1',
+      user_message: { id: 'u1', role: 'user', content: 'hi', group_id: 'g1' },
+      responses: [
+        { id: 'a1', role: 'agent', agent: 'claude', content: 'one', group_id: 'g1' },
+        { id: 'a2', role: 'system', agent: 'gemini', content: 'Error from gemini', group_id: 'g1' },
+      ],
+      synthesis: { id: 's', role: 'agent', agent: 'claude', content: 'both', group_id: 'g1', consensus: 0.8 },
+    });
+
+    await useChatStore.getState().sendMessage('hi');
+
+    expect(chatApi.sendMessage).not.toHaveBeenCalled();
+    expect(chatApi.askAll).toHaveBeenCalledWith('s1', 'hi', expect.any(Object));
+    const msgs = useChatStore.getState().messages.s1;
+    expect(msgs.map(m => m.id)).toEqual(['u1', 'a1', 'a2', 's']);
+    expect(msgs[3].consensus).toBe(0.8);
+    expect(useChatStore.getState().sending).toBe(false);
+  });
+
   it('sendMessage removes optimistic message on failure', async () => {
     vi.useFakeTimers();
     vi.setSystemTime(new Date('2026-02-10T00:00:00.000Z'));
@@ -54,6 +54,7 @@ describe('workflowStore', () => {
       activeWorkflow: null,
       selectedWorkflowId: null,
       tasks: {},
+      inputRequests: {},
       loading: false,
       error: null,
     });
@@ -430,7 +431,68 @@ describe('workflowStore', () => {
     });
   });
 
+  describe('task questions', () => {
+    it('tracks task questions from SSE events until they are answered', () => {
+      const { handleUserInputRequested, handleUserInputProvided } = useWorkflowStore.getState();
+      handleUserInputRequested({
+        workflow_id: 'wf-1', request_id: 'req-1', task_id: 'task-1', prompt: 'Which cache?',
+        options: ['redis'], default: 'redis', timeout_ms: 60000, timestamp: '2026-01-01T00:00:00Z',
+      });
+      // Questions without a task belong to the chat flow.
+      handleUserInputRequested({ workflow_id: 'wf-1', request_id: 'req-2', prompt: 'Continue?' });
+
+      const pending = useWorkflowStore.getState().inputRequests['wf-1'];
+      expect(pending).toHaveLength(1);
+      expect(pending[0]).toMatchObject({ id: 'req-1', task_id: 'task-1', expires_at: '2026-01-01T00:01:00.000Z' });
+
+      handleUserInputProvided({ workflow_id: 'wf-1', request_id: 'req-1' });
+      expect(useWorkflowStore.getState().inputRequests['wf-1']).toHaveLength(0);
+    });
+
+    it('answerInputRequest posts the answer and drops the question', async () => {
+      useWorkflowStore.setState({ inputRequests: { 'wf-1': [{ id: 'req-1', prompt: 'Which cache?' }] } });
+      workflowApi.answerInput = vi.fn().mockResolvedValue(null);
+
+      const ok = await useWorkflowStore.getState().answerInputRequest('wf-1', 'req-1', { input: 'redis' });
+      expect(ok).toBe(true);
+      expect(workflowApi.answerInput).toHaveBeenCalledWith('wf-1', 'req-1', { input: 'redis', cancel: undefined });
+      expect(useWorkflowStore.getState().inputRequests['wf-1']).toHaveLength(0);
+    });
+
+    it('answerInputRequest refreshes the questions when the answer is rejected', async () => {
+      useWorkflowStore.setState({ inputRequests: { 'wf-1': [{ id: 'req-1', prompt: 'Which cache?' }] } });
+      workflowApi.answerInput = vi.fn().mockRejectedValue(new Error('input request not found'));
+      workflowApi.listInputs = vi.fn().mockResolvedValue({ inputs: [] });
+
+      const ok = await useWorkflowStore.getState().answerInputRequest('wf-1', 'req-1', { input: 'redis' });
+      expect(ok).toBe(false);
+      expect(workflowApi.listInputs).toHaveBeenCalledWith('wf-1');
+      expect(useWorkflowStore.getState().inputRequests['wf-1']).toHaveLength(0);
+    });
+  });
+
   describe('task mutations', () => {
+    it('skipTask and retryTask post the request and refresh the tasks', async () => {
+      const fetchTasksSpy = vi.fn().mockResolvedValue([]);
+      useWorkflowStore.setState({ fetchTasks: fetchTasksSpy });
+      workflowApi.skipTask = vi.fn().mockResolvedValue({});
+      workflowApi.retryTask = vi.fn().mockResolvedValue({});
+
+      expect(await useWorkflowStore.getState().skipTask('wf-1', 't1', 'not needed')).toBe(true);
+      expect(workflowApi.skipTask).toHaveBeenCalledWith('wf-1', 't1', 'not needed');
+      expect(await useWorkflowStore.getState().retryTask('wf-1', 't2', { agent: 'codex' })).toBe(true);
+      expect(workflowApi.retryTask).toHaveBeenCalledWith('wf-1', 't2', { agent: 'codex' });
+      expect(fetchTasksSpy).toHaveBeenCalledTimes(2);
+    });
+
+    it('retryTask stores error and returns false on failure', async () => {
+      useWorkflowStore.setState({ fetchTasks: vi.fn() });
+      workflowApi.retryTask = vi.fn().mockRejectedValue(new Error('task is pending and cannot be retried'));
+
+      expect(await useWorkflowStore.getState().retryTask('wf-1', 't1')).toBe(false);
+      expect(useWorkflowStore.getState().error).toBe('task is pending and cannot be retried');
+    });
+
     it('createTask triggers a refresh via fetchTasks', async () => {
       const fetchTasksSpy = vi.fn().mockResolvedValue([{ id: 't1' }]);
       useWorkflowStore.setState({ fetchTasks: fetchTasksSpy });
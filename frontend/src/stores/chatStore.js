@@ -20,6 +20,8 @@ const useChatStore = create(
   currentModel: '',
   currentReasoningEffort: DEFAULT_REASONING,
   attachments: [],
+  // When set, messages are sent to several agents and synthesized
+  askAll: false,
 
   // Actions
   fetchSessions: async () => {
@@ -89,6 +91,15 @@ const useChatStore = create(
     }
   },
 
+  promoteSession: async (sessionId, options) => {
+    const result = await chatApi.promote(sessionId, options);
+    const { sessions } = get();
+    set({
+      sessions: sessions.map(s => s.id === sessionId ? { ...s, workflow_id: result.workflow_id } : s),
+    });
+    return result;
+  },
+
   fetchMessages: async (sessionId) => {
     try {
       const messageList = await chatApi.getMessages(sessionId);
@@ -102,6 +113,9 @@ const useChatStore = create(
   },
 
   sendMessage: async (content) => {
+    if (get().askAll) {
+      return get().sendToAll(content);
+    }
     const {
       activeSessionId, messages, currentAgent, currentModel,
       currentReasoningEffort, attachments,
@@ -144,6 +158,9 @@ const useChatStore = create(
         content: response.content,
         timestamp: response.timestamp || new Date().toISOString(),
         agent: response.agent,
+        model: response.model,
+        tokens: response.tokens,
+        context: response.context,
       };
 
       const { messages: currentMessages } = get();
@@ -159,6 +176,11 @@ const useChatStore = create(
         attachments: [],
       });
 
+      // Older messages were folded into the summary: refetch to mark them.
+      if (response.context?.summarized_messages > 0) {
+        await get().fetchMessages(activeSessionId);
+      }
+
       return response;
     } catch (error) {
       // Remove optimistic message on error
@@ -176,6 +198,69 @@ const useChatStore = create(
     }
   },
 
+  // Ask the configured agents at once; their answers and the moderator's
+  // synthesis are added as separate messages of the same group.
+  sendToAll: async (content) => {
+    const { activeSessionId, messages, currentReasoningEffort, attachments } = get();
+    if (!activeSessionId) {
+      set({ error: 'No active session' });
+      return null;
+    }
+
+    set({ sending: true, error: null });
+
+    const userMessage = {
+      id: `temp-${Date.now()}`,
+      role: 'user',
+      content,
+      timestamp: new Date().toISOString(),
+    };
+    const sessionMessages = messages[activeSessionId] || [];
+    set({
+      messages: {
+        ...messages,
+        [activeSessionId]: [...sessionMessages, userMessage],
+      },
+    });
+
+    try {
+      const response = await chatApi.askAll(activeSessionId, content, {
+        reasoningEffort: currentReasoningEffort,
+        attachments,
+      });
+
+      const added = [response.user_message, ...(response.responses || [])];
+      if (response.synthesis) {
+        added.push(response.synthesis);
+      }
+      const { messages: currentMessages } = get();
+      const currentSessionMessages = (currentMessages[activeSessionId] || [])
+        .filter(m => m.id !== userMessage.id);
+
+      set({
+        messages: {
+          ...currentMessages,
+          [activeSessionId]: [...currentSessionMessages, ...added],
+        },
+        sending: false,
+        attachments: [],
+      });
+      return response;
+    } catch (error) {
+      const { messages: currentMessages } = get();
+      const currentSessionMessages = currentMessages[activeSessionId] || [];
+      set({
+        messages: {
+          ...currentMessages,
+          [activeSessionId]: currentSessionMessages.filter(m => m.id !== userMessage.id),
+        },
+        error: error.message,
+        sending: false,
+      });
+      return null;
+    }
+  },
+
   getActiveMessages: () => {
     const { activeSessionId, messages } = get();
     return messages[activeSessionId] || [];
@@ -226,6 +311,7 @@ const useChatStore = create(
     set({ attachments: attachments.filter(a => a !== path) });
   },
   clearAttachments: () => set({ attachments: [] }),
+  setAskAll: (askAll) => set({ askAll }),
   resetMessageOptions: () => set({
     currentModel: '',
     currentReasoningEffort: DEFAULT_REASONING,
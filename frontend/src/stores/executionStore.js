@@ -234,6 +234,24 @@ function entryFromSSE(eventType, data, executionId) {
     };
   }
 
+  if (eventType === 'user_input_requested' || eventType === 'user_input_provided') {
+    const taskId = safeStr(data?.task_id);
+    if (!taskId) return null;
+    const asked = eventType === 'user_input_requested';
+    let message = asked ? safeStr(data?.prompt) : safeStr(data?.input);
+    if (!asked && data?.cancelled) message = message ? `No answer, continuing with: ${message}` : 'No answer';
+    return {
+      kind: 'task',
+      event: eventType,
+      taskId,
+      title: `${asked ? 'Task question' : 'Task answer'} · ${taskId}`,
+      message,
+      ts,
+      data,
+      executionId,
+    };
+  }
+
   if (eventType.startsWith('task_')) {
     const taskId = safeStr(data?.task_id);
     if (!taskId) return null;
@@ -9,6 +9,7 @@ const useWorkflowStore = create((set, get) => ({
   activeWorkflow: null,
   selectedWorkflowId: null,
   tasks: {},
+  inputRequests: {}, // workflowId -> questions of running tasks
   loading: false,
   error: null,
 
@@ -563,6 +564,60 @@ const useWorkflowStore = create((set, get) => ({
     }
   },
 
+  // Task questions
+  fetchInputRequests: async (id) => {
+    try {
+      const result = await workflowApi.listInputs(id);
+      set({ inputRequests: { ...get().inputRequests, [id]: result?.inputs || [] } });
+    } catch (error) {
+      set({ error: error.message });
+    }
+  },
+
+  answerInputRequest: async (id, requestId, { input, cancel } = {}) => {
+    try {
+      await workflowApi.answerInput(id, requestId, { input, cancel });
+      get().removeInputRequest(id, requestId);
+      return true;
+    } catch (error) {
+      set({ error: error.message });
+      // The question may have timed out meanwhile.
+      await get().fetchInputRequests(id);
+      return false;
+    }
+  },
+
+  removeInputRequest: (id, requestId) => {
+    const { inputRequests } = get();
+    const pending = (inputRequests[id] || []).filter(r => r.id !== requestId);
+    set({ inputRequests: { ...inputRequests, [id]: pending } });
+  },
+
+  handleUserInputRequested: (data) => {
+    if (!data?.task_id) return; // Only task questions are answered here.
+    const { inputRequests } = get();
+    const pending = (inputRequests[data.workflow_id] || []).filter(r => r.id !== data.request_id);
+    const requestedAt = data.timestamp || new Date().toISOString();
+    pending.push({
+      id: data.request_id,
+      task_id: data.task_id,
+      prompt: data.prompt,
+      context: data.context,
+      options: data.options || [],
+      default: data.default,
+      timeout_ms: data.timeout_ms,
+      requested_at: requestedAt,
+      expires_at: data.timeout_ms
+        ? new Date(new Date(requestedAt).getTime() + data.timeout_ms).toISOString()
+        : undefined,
+    });
+    set({ inputRequests: { ...inputRequests, [data.workflow_id]: pending } });
+  },
+
+  handleUserInputProvided: (data) => {
+    get().removeInputRequest(data.workflow_id, data.request_id);
+  },
+
   // Task mutation actions
   createTask: async (workflowId, data) => {
     try {
@@ -600,6 +655,28 @@ const useWorkflowStore = create((set, get) => ({
     }
   },
 
+  skipTask: async (workflowId, taskId, reason) => {
+    try {
+      await workflowApi.skipTask(workflowId, taskId, reason);
+      await get().fetchTasks(workflowId);
+      return true;
+    } catch (error) {
+      set({ error: error.message });
+      return false;
+    }
+  },
+
+  retryTask: async (workflowId, taskId, options) => {
+    try {
+      await workflowApi.retryTask(workflowId, taskId, options);
+      await get().fetchTasks(workflowId);
+      return true;
+    } catch (error) {
+      set({ error: error.message });
+      return false;
+    }
+  },
+
   reorderTasks: async (workflowId, taskOrder) => {
     try {
       const tasks = await workflowApi.reorderTasks(workflowId, taskOrder);
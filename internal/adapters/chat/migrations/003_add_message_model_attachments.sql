@@ -0,0 +1,4 @@
+-- Record the model and attachments of each message so sessions can be
+-- resumed from any interface
+ALTER TABLE chat_messages ADD COLUMN model TEXT;
+ALTER TABLE chat_messages ADD COLUMN attachments TEXT;
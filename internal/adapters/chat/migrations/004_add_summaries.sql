@@ -0,0 +1,12 @@
+-- Rolling summaries of the oldest turns of each session, used to fit long
+-- conversations into the agent's context window
+CREATE TABLE IF NOT EXISTS chat_summaries (
+    session_id TEXT PRIMARY KEY,
+    content TEXT NOT NULL,
+    through_message_id TEXT NOT NULL,
+    message_count INTEGER NOT NULL DEFAULT 0,
+    agent TEXT,
+    model TEXT,
+    updated_at TEXT NOT NULL,
+    FOREIGN KEY (session_id) REFERENCES chat_sessions(id) ON DELETE CASCADE
+);
@@ -0,0 +1,5 @@
+-- Group the messages of a fan-out question (the question, each agent's reply
+-- and the moderator's synthesis) and record the synthesis agreement score
+ALTER TABLE chat_messages ADD COLUMN group_id TEXT;
+ALTER TABLE chat_messages ADD COLUMN consensus REAL;
+CREATE INDEX IF NOT EXISTS idx_chat_messages_group ON chat_messages(group_id);
@@ -0,0 +1,2 @@
+-- Link a chat session to the workflow it was promoted into
+ALTER TABLE chat_sessions ADD COLUMN workflow_id TEXT;
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -21,6 +22,18 @@ var chatMigrationV1 string
 //go:embed migrations/002_add_title.sql
 var chatMigrationV2 string
 
+//go:embed migrations/003_add_message_model_attachments.sql
+var chatMigrationV3 string
+
+//go:embed migrations/004_add_summaries.sql
+var chatMigrationV4 string
+
+//go:embed migrations/005_add_message_groups.sql
+var chatMigrationV5 string
+
+//go:embed migrations/006_add_session_workflow.sql
+var chatMigrationV6 string
+
 // SQLiteChatStore implements ChatStore with SQLite storage.
 type SQLiteChatStore struct {
 	dbPath string
@@ -103,7 +116,7 @@ func (s *SQLiteChatStore) migrate() error {
 	}
 
 	// Apply pending migrations
-	migrations := []string{chatMigrationV1, chatMigrationV2}
+	migrations := []string{chatMigrationV1, chatMigrationV2, chatMigrationV3, chatMigrationV4, chatMigrationV5, chatMigrationV6}
 	for i, migration := range migrations {
 		version := i + 1
 		if version <= currentVersion {
@@ -200,16 +213,23 @@ func isSQLiteBusy(err error) bool {
 
 // SaveSession persists a chat session.
 func (s *SQLiteChatStore) SaveSession(ctx context.Context, session *core.ChatSessionState) error {
+	// An empty workflow ID keeps an existing link: callers that rebuild the
+	// session from their own state must not drop the promotion.
+	var workflowID sql.NullString
+	if session.WorkflowID != "" {
+		workflowID = sql.NullString{String: session.WorkflowID, Valid: true}
+	}
 	return s.retryWrite(ctx, "SaveSession", func() error {
 		_, err := s.db.ExecContext(ctx, `
-			INSERT INTO chat_sessions (id, title, created_at, updated_at, agent, model, project_root)
-			VALUES (?, ?, ?, ?, ?, ?, ?)
+			INSERT INTO chat_sessions (id, title, created_at, updated_at, agent, model, project_root, workflow_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 			ON CONFLICT(id) DO UPDATE SET
 				title = excluded.title,
 				updated_at = excluded.updated_at,
 				agent = excluded.agent,
 				model = excluded.model,
-				project_root = excluded.project_root
+				project_root = excluded.project_root,
+				workflow_id = COALESCE(excluded.workflow_id, chat_sessions.workflow_id)
 		`,
 			session.ID,
 			session.Title,
@@ -218,6 +238,7 @@ func (s *SQLiteChatStore) SaveSession(ctx context.Context, session *core.ChatSes
 			session.Agent,
 			session.Model,
 			session.ProjectRoot,
+			workflowID,
 		)
 		return err
 	})
@@ -229,15 +250,15 @@ func (s *SQLiteChatStore) LoadSession(ctx context.Context, id string) (*core.Cha
 	defer s.mu.RUnlock()
 
 	row := s.readDB.QueryRowContext(ctx, `
-		SELECT id, title, created_at, updated_at, agent, model, project_root
+		SELECT id, title, created_at, updated_at, agent, model, project_root, workflow_id
 		FROM chat_sessions WHERE id = ?
 	`, id)
 
 	var session core.ChatSessionState
 	var createdAt, updatedAt string
-	var title, model, projectRoot sql.NullString
+	var title, model, projectRoot, workflowID sql.NullString
 
-	err := row.Scan(&session.ID, &title, &createdAt, &updatedAt, &session.Agent, &model, &projectRoot)
+	err := row.Scan(&session.ID, &title, &createdAt, &updatedAt, &session.Agent, &model, &projectRoot, &workflowID)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -250,6 +271,7 @@ func (s *SQLiteChatStore) LoadSession(ctx context.Context, id string) (*core.Cha
 	session.UpdatedAt, _ = time.Parse(time.RFC3339Nano, updatedAt)
 	session.Model = model.String
 	session.ProjectRoot = projectRoot.String
+	session.WorkflowID = workflowID.String
 
 	return &session, nil
 }
@@ -260,7 +282,7 @@ func (s *SQLiteChatStore) ListSessions(ctx context.Context) ([]*core.ChatSession
 	defer s.mu.RUnlock()
 
 	rows, err := s.readDB.QueryContext(ctx, `
-		SELECT id, title, created_at, updated_at, agent, model, project_root
+		SELECT id, title, created_at, updated_at, agent, model, project_root, workflow_id
 		FROM chat_sessions
 		ORDER BY updated_at DESC
 	`)
@@ -273,9 +295,9 @@ func (s *SQLiteChatStore) ListSessions(ctx context.Context) ([]*core.ChatSession
 	for rows.Next() {
 		var session core.ChatSessionState
 		var createdAt, updatedAt string
-		var title, model, projectRoot sql.NullString
+		var title, model, projectRoot, workflowID sql.NullString
 
-		if err := rows.Scan(&session.ID, &title, &createdAt, &updatedAt, &session.Agent, &model, &projectRoot); err != nil {
+		if err := rows.Scan(&session.ID, &title, &createdAt, &updatedAt, &session.Agent, &model, &projectRoot, &workflowID); err != nil {
 			return nil, fmt.Errorf("scanning session: %w", err)
 		}
 
@@ -284,6 +306,7 @@ func (s *SQLiteChatStore) ListSessions(ctx context.Context) ([]*core.ChatSession
 		session.UpdatedAt, _ = time.Parse(time.RFC3339Nano, updatedAt)
 		session.Model = model.String
 		session.ProjectRoot = projectRoot.String
+		session.WorkflowID = workflowID.String
 
 		sessions = append(sessions, &session)
 	}
@@ -307,10 +330,28 @@ func (s *SQLiteChatStore) SaveMessage(ctx context.Context, msg *core.ChatMessage
 			return err
 		}
 
+		var attachments sql.NullString
+		if len(msg.Attachments) > 0 {
+			data, err := json.Marshal(msg.Attachments)
+			if err != nil {
+				_ = tx.Rollback()
+				return fmt.Errorf("encoding attachments: %w", err)
+			}
+			attachments = sql.NullString{String: string(data), Valid: true}
+		}
+		var groupID sql.NullString
+		if msg.GroupID != "" {
+			groupID = sql.NullString{String: msg.GroupID, Valid: true}
+		}
+		var consensus sql.NullFloat64
+		if msg.Consensus != nil {
+			consensus = sql.NullFloat64{Float64: *msg.Consensus, Valid: true}
+		}
+
 		// Insert message
 		_, err = tx.ExecContext(ctx, `
-				INSERT INTO chat_messages (id, session_id, role, agent, content, timestamp, tokens_in, tokens_out)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+				INSERT INTO chat_messages (id, session_id, role, agent, content, timestamp, tokens_in, tokens_out, model, attachments, group_id, consensus)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 			`,
 			msg.ID,
 			msg.SessionID,
@@ -320,6 +361,10 @@ func (s *SQLiteChatStore) SaveMessage(ctx context.Context, msg *core.ChatMessage
 			msg.Timestamp.UTC().Format(time.RFC3339Nano),
 			msg.TokensIn,
 			msg.TokensOut,
+			msg.Model,
+			attachments,
+			groupID,
+			consensus,
 		)
 		if err != nil {
 			_ = tx.Rollback()
@@ -345,7 +390,7 @@ func (s *SQLiteChatStore) LoadMessages(ctx context.Context, sessionID string) ([
 	defer s.mu.RUnlock()
 
 	rows, err := s.readDB.QueryContext(ctx, `
-		SELECT id, session_id, role, agent, content, timestamp, tokens_in, tokens_out
+		SELECT id, session_id, role, agent, content, timestamp, tokens_in, tokens_out, model, attachments, group_id, consensus
 		FROM chat_messages
 		WHERE session_id = ?
 		ORDER BY timestamp ASC
@@ -359,14 +404,23 @@ func (s *SQLiteChatStore) LoadMessages(ctx context.Context, sessionID string) ([
 	for rows.Next() {
 		var msg core.ChatMessageState
 		var timestamp string
-		var agent sql.NullString
+		var agent, model, attachments, groupID sql.NullString
+		var consensus sql.NullFloat64
 
-		if err := rows.Scan(&msg.ID, &msg.SessionID, &msg.Role, &agent, &msg.Content, &timestamp, &msg.TokensIn, &msg.TokensOut); err != nil {
+		if err := rows.Scan(&msg.ID, &msg.SessionID, &msg.Role, &agent, &msg.Content, &timestamp, &msg.TokensIn, &msg.TokensOut, &model, &attachments, &groupID, &consensus); err != nil {
 			return nil, fmt.Errorf("scanning message: %w", err)
 		}
 
 		msg.Timestamp, _ = time.Parse(time.RFC3339Nano, timestamp)
 		msg.Agent = agent.String
+		msg.Model = model.String
+		if attachments.String != "" {
+			_ = json.Unmarshal([]byte(attachments.String), &msg.Attachments)
+		}
+		msg.GroupID = groupID.String
+		if consensus.Valid {
+			msg.Consensus = &consensus.Float64
+		}
 
 		messages = append(messages, &msg)
 	}
@@ -374,6 +428,59 @@ func (s *SQLiteChatStore) LoadMessages(ctx context.Context, sessionID string) ([
 	return messages, rows.Err()
 }
 
+// SaveSummary creates or replaces the rolling summary of a session.
+func (s *SQLiteChatStore) SaveSummary(ctx context.Context, summary *core.ChatSummaryState) error {
+	return s.retryWrite(ctx, "SaveSummary", func() error {
+		_, err := s.db.ExecContext(ctx, `
+			INSERT INTO chat_summaries (session_id, content, through_message_id, message_count, agent, model, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(session_id) DO UPDATE SET
+				content = excluded.content,
+				through_message_id = excluded.through_message_id,
+				message_count = excluded.message_count,
+				agent = excluded.agent,
+				model = excluded.model,
+				updated_at = excluded.updated_at
+		`,
+			summary.SessionID,
+			summary.Content,
+			summary.ThroughMessageID,
+			summary.MessageCount,
+			summary.Agent,
+			summary.Model,
+			summary.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		)
+		return err
+	})
+}
+
+// LoadSummary retrieves the rolling summary of a session.
+func (s *SQLiteChatStore) LoadSummary(ctx context.Context, sessionID string) (*core.ChatSummaryState, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	row := s.readDB.QueryRowContext(ctx, `
+		SELECT session_id, content, through_message_id, message_count, agent, model, updated_at
+		FROM chat_summaries WHERE session_id = ?
+	`, sessionID)
+
+	var summary core.ChatSummaryState
+	var agent, model sql.NullString
+	var updatedAt string
+	err := row.Scan(&summary.SessionID, &summary.Content, &summary.ThroughMessageID, &summary.MessageCount, &agent, &model, &updatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scanning summary: %w", err)
+	}
+
+	summary.Agent = agent.String
+	summary.Model = model.String
+	summary.UpdatedAt, _ = time.Parse(time.RFC3339Nano, updatedAt)
+	return &summary, nil
+}
+
 // Close closes both database connections.
 func (s *SQLiteChatStore) Close() error {
 	var errs []error
@@ -42,25 +42,53 @@ func TestSQLiteChatStore_SessionAndMessages_RoundTrip(t *testing.T) {
 	}
 
 	msg := &core.ChatMessageState{
-		ID:        "m1",
-		SessionID: "s1",
-		Role:      "user",
-		Content:   "hi",
-		Timestamp: now.Add(1 * time.Second),
-		TokensIn:  1,
-		TokensOut: 2,
+		ID:          "m1",
+		SessionID:   "s1",
+		Role:        "user",
+		Content:     "hi",
+		Timestamp:   now.Add(1 * time.Second),
+		TokensIn:    1,
+		TokensOut:   2,
+		Attachments: []string{"docs/a.md", ".quorum/attachments/chat/s1/b.png"},
 	}
 	if err := store.SaveMessage(ctx, msg); err != nil {
 		t.Fatalf("SaveMessage: %v", err)
 	}
+	score := 0.8
+	reply := &core.ChatMessageState{
+		ID:        "m2",
+		SessionID: "s1",
+		Role:      "agent",
+		Agent:     "gemini",
+		Model:     "gemini-2.5-pro",
+		Content:   "hello",
+		Timestamp: now.Add(2 * time.Second),
+		GroupID:   "g1",
+		Consensus: &score,
+	}
+	if err := store.SaveMessage(ctx, reply); err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
 
 	msgs, err := store.LoadMessages(ctx, "s1")
 	if err != nil {
 		t.Fatalf("LoadMessages: %v", err)
 	}
-	if len(msgs) != 1 || msgs[0].ID != "m1" {
+	if len(msgs) != 2 || msgs[0].ID != "m1" {
 		t.Fatalf("unexpected messages: %#v", msgs)
 	}
+	if len(msgs[0].Attachments) != 2 || msgs[0].Attachments[1] != ".quorum/attachments/chat/s1/b.png" {
+		t.Errorf("attachments = %v", msgs[0].Attachments)
+	}
+	if msgs[1].Agent != "gemini" || msgs[1].Model != "gemini-2.5-pro" || len(msgs[1].Attachments) != 0 {
+		t.Errorf("unexpected agent message: %#v", msgs[1])
+	}
+	if msgs[1].GroupID != "g1" || msgs[1].Consensus == nil || *msgs[1].Consensus != 0.8 {
+		t.Errorf("group = %q, consensus = %v", msgs[1].GroupID, msgs[1].Consensus)
+	}
+	if msgs[0].GroupID != "" || msgs[0].Consensus != nil {
+		t.Errorf("ungrouped message loaded as group %q, consensus %v", msgs[0].GroupID, msgs[0].Consensus)
+	}
 
 	sessions, err := store.ListSessions(ctx)
 	if err != nil {
@@ -70,6 +98,20 @@ func TestSQLiteChatStore_SessionAndMessages_RoundTrip(t *testing.T) {
 		t.Fatalf("unexpected sessions: %#v", sessions)
 	}
 
+	// The workflow link survives saves that don't carry it.
+	sess.WorkflowID = "wf-1"
+	if err := store.SaveSession(ctx, sess); err != nil {
+		t.Fatalf("SaveSession: %v", err)
+	}
+	sess.WorkflowID = ""
+	sess.Title = "renamed"
+	if err := store.SaveSession(ctx, sess); err != nil {
+		t.Fatalf("SaveSession: %v", err)
+	}
+	if loaded, err = store.LoadSession(ctx, "s1"); err != nil || loaded.WorkflowID != "wf-1" || loaded.Title != "renamed" {
+		t.Fatalf("LoadSession = %#v, %v; want workflow link kept", loaded, err)
+	}
+
 	if err := store.DeleteSession(ctx, "s1"); err != nil {
 		t.Fatalf("DeleteSession: %v", err)
 	}
@@ -82,6 +124,58 @@ func TestSQLiteChatStore_SessionAndMessages_RoundTrip(t *testing.T) {
 	}
 }
 
+func TestSQLiteChatStore_Summary_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewSQLiteChatStore(filepath.Join(t.TempDir(), "chat.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteChatStore: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	var _ core.ChatSummaryStore = store
+
+	now := time.Now().UTC().Truncate(time.Second)
+	if err := store.SaveSession(ctx, &core.ChatSessionState{ID: "s1", CreatedAt: now, UpdatedAt: now, Agent: "claude"}); err != nil {
+		t.Fatalf("SaveSession: %v", err)
+	}
+
+	summary, err := store.LoadSummary(ctx, "s1")
+	if err != nil || summary != nil {
+		t.Fatalf("LoadSummary before save = %#v, %v; want nil", summary, err)
+	}
+
+	for _, count := range []int{4, 8} {
+		if err := store.SaveSummary(ctx, &core.ChatSummaryState{
+			SessionID:        "s1",
+			Content:          "user wants a parser",
+			ThroughMessageID: "m" + string(rune('0'+count)),
+			MessageCount:     count,
+			Agent:            "gemini",
+			Model:            "gemini-2.5-flash",
+			UpdatedAt:        now,
+		}); err != nil {
+			t.Fatalf("SaveSummary: %v", err)
+		}
+	}
+
+	summary, err = store.LoadSummary(ctx, "s1")
+	if err != nil {
+		t.Fatalf("LoadSummary: %v", err)
+	}
+	if summary == nil || summary.MessageCount != 8 || summary.ThroughMessageID != "m8" ||
+		summary.Agent != "gemini" || !summary.UpdatedAt.Equal(now) {
+		t.Fatalf("unexpected summary: %#v", summary)
+	}
+
+	// Summaries are removed with their session.
+	if err := store.DeleteSession(ctx, "s1"); err != nil {
+		t.Fatalf("DeleteSession: %v", err)
+	}
+	if summary, err = store.LoadSummary(ctx, "s1"); err != nil || summary != nil {
+		t.Fatalf("LoadSummary after delete = %#v, %v; want nil", summary, err)
+	}
+}
+
 func TestNewChatStore_AppendsDBExtension(t *testing.T) {
 	dir := t.TempDir()
 	store, err := NewChatStore(filepath.Join(dir, "chat"))
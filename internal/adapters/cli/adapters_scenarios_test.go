@@ -162,6 +162,30 @@ func TestRegistry_Clear(t *testing.T) {
 	}
 }
 
+func TestRegistry_ReplaceConfigs(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	r.Configure("claude", AgentConfig{Name: "claude", Path: "claude"})
+	r.Register("mock", &mockAgentForTest{name: "mock"})
+
+	next := NewRegistry()
+	next.Configure("gemini", AgentConfig{Name: "gemini", Path: "gemini"})
+	r.ReplaceConfigs(next)
+
+	r.mu.RLock()
+	_, hasClaude := r.configs["claude"]
+	_, hasGemini := r.configs["gemini"]
+	agents := len(r.agents)
+	r.mu.RUnlock()
+	if hasClaude || !hasGemini || agents != 0 {
+		t.Errorf("expected only the gemini config and no cached agents, got claude=%v gemini=%v agents=%d",
+			hasClaude, hasGemini, agents)
+	}
+	if _, err := r.Get("claude"); err != nil {
+		t.Errorf("factories should survive a replace: %v", err)
+	}
+}
+
 // Test GeminiAdapter specific functions
 
 func TestGeminiAdapter_BuildArgsExtended(t *testing.T) {
@@ -11,6 +11,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/diagnostics"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/sandbox"
 )
 
 // LogCallback is called for each line of stderr output during execution.
@@ -60,6 +62,16 @@ type AgentConfig struct {
 	// IdleTimeout is the max time allowed without stdout/stderr activity before
 	// killing a hung process. Default: 15m. Set to 0 to disable.
 	IdleTimeout time.Duration
+	// Sandbox, when set, runs the CLI inside a restricted environment.
+	Sandbox *sandbox.Policy
+	// SandboxPhases limits the sandbox to specific phases. If empty, every
+	// execution is sandboxed.
+	SandboxPhases map[string]bool
+	// RateLimitRPM and RateLimitTPM are the requests and tokens per minute
+	// allowed by the agent's plan, reported in its capabilities to size the
+	// rate limiter. Zero leaves the defaults.
+	RateLimitRPM int
+	RateLimitTPM int
 }
 
 // DefaultTokenDiscrepancyThreshold is the default ratio for token discrepancy detection.
@@ -264,6 +276,16 @@ func (b *BaseAdapter) ExecuteCommand(ctx context.Context, args []string, stdin,
 		"timeout", timeout,
 	)
 
+	// Restrict the command to the sandbox configured for this phase, if any
+	sandboxCleanup, sandboxErr := b.sandboxCommand(ctx, cmd)
+	if sandboxErr != nil {
+		if stderrPipe != nil {
+			_ = stderrPipe.Close()
+		}
+		return nil, sandboxErr
+	}
+	defer sandboxCleanup()
+
 	startTime := time.Now()
 
 	// Start the command
@@ -604,6 +626,14 @@ func (b *BaseAdapter) executeWithJSONStreaming(
 		"args", streamArgs,
 	)
 
+	sandboxCleanup, err := b.sandboxCommand(ctx, cmd)
+	if err != nil {
+		_ = stdoutPipe.Close()
+		_ = stderrPipe.Close()
+		return nil, err
+	}
+	defer sandboxCleanup()
+
 	startTime := time.Now()
 
 	if err := cmd.Start(); err != nil {
@@ -1010,6 +1040,13 @@ func (b *BaseAdapter) executeWithLogFileStreaming(
 		"log_dir", logDir,
 	)
 
+	// The CLI writes its logs outside the working directory
+	sandboxCleanup, err := b.sandboxCommand(ctx, cmd, logDir)
+	if err != nil {
+		return nil, err
+	}
+	defer sandboxCleanup()
+
 	startTime := time.Now()
 
 	if err := cmd.Start(); err != nil {
@@ -1242,7 +1279,10 @@ func (b *BaseAdapter) classifyError(result *CommandResult) error {
 	}
 
 	// Rate limit detection
-	if containsAny(errorMsgLower, []string{"rate limit", "too many requests", "429", "quota"}) {
+	if isRateLimitMessage(errorMsgLower) {
+		if retryAfter := retryAfterHint(errorMsg); retryAfter > 0 {
+			return core.ErrRateLimitRetryAfter(errorMsg, retryAfter)
+		}
 		return core.ErrRateLimit(errorMsg)
 	}
 
@@ -1320,6 +1360,41 @@ func extractErrorFromOutput(stdout string) string {
 	return ""
 }
 
+// isRateLimitMessage reports whether a lowercased error message is a rate
+// limit or quota error.
+func isRateLimitMessage(msgLower string) bool {
+	return containsAny(msgLower, []string{"rate limit", "rate_limit", "too many requests", "429", "quota", "resource_exhausted", "resource exhausted"})
+}
+
+// retryAfterPattern matches the retry hints of the agent CLIs and their
+// APIs: "Retry-After: 30", "retry after 2m", "try again in 45 seconds",
+// "Please retry in 12.5s" and Gemini's "retryDelay": "30s".
+var retryAfterPattern = regexp.MustCompile(
+	`(?i)(?:retry[-_ ]?after|retry_?delay|(?:try|retry) (?:again )?in)["':=\s]*(\d+(?:\.\d+)?)\s*(ms|milliseconds?|s|secs?|seconds?|m|mins?|minutes?|h|hours?)?\b`)
+
+// retryAfterHint returns the retry hint of a rate limit message, or 0 when
+// it has none. A bare number is in seconds.
+func retryAfterHint(msg string) time.Duration {
+	match := retryAfterPattern.FindStringSubmatch(msg)
+	if match == nil {
+		return 0
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil || value <= 0 {
+		return 0
+	}
+	unit := time.Second
+	switch u := strings.ToLower(match[2]); {
+	case strings.HasPrefix(u, "ms"), strings.HasPrefix(u, "milli"):
+		unit = time.Millisecond
+	case strings.HasPrefix(u, "m"):
+		unit = time.Minute
+	case strings.HasPrefix(u, "h"):
+		unit = time.Hour
+	}
+	return time.Duration(value * float64(unit))
+}
+
 func containsAny(s string, substrings []string) bool {
 	for _, sub := range substrings {
 		if strings.Contains(s, sub) {
@@ -884,6 +884,30 @@ func TestClassifyError_RateLimitPatterns(t *testing.T) {
 	}
 }
 
+func TestClassifyError_RateLimitRetryAfter(t *testing.T) {
+	t.Parallel()
+	base := NewBaseAdapter(AgentConfig{}, nil)
+
+	tests := map[string]time.Duration{
+		"rate limit exceeded, retry after 30s":                          30 * time.Second,
+		"429 Too Many Requests (Retry-After: 20)":                       20 * time.Second,
+		"quota exceeded; try again in 2 minutes":                        2 * time.Minute,
+		"Resource exhausted. Please retry in 12.5s.":                    12500 * time.Millisecond,
+		`{"code":429,"status":"RESOURCE_EXHAUSTED","retryDelay":"45s"}`: 45 * time.Second,
+		"rate limit reached":                                            0,
+	}
+	for msg, want := range tests {
+		err := base.classifyError(&CommandResult{Stderr: msg, ExitCode: 1})
+		if !core.IsCategory(err, core.ErrCatRateLimit) {
+			t.Errorf("classifyError(%q) = %v, want a rate limit error", msg, err)
+			continue
+		}
+		if got := core.GetRetryAfter(err); got != want {
+			t.Errorf("GetRetryAfter(classifyError(%q)) = %v, want %v", msg, got, want)
+		}
+	}
+}
+
 func TestClassifyError_AuthPatterns(t *testing.T) {
 	t.Parallel()
 	base := NewBaseAdapter(AgentConfig{}, nil)
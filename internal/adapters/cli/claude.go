@@ -37,6 +37,8 @@ func NewClaudeAdapter(cfg AgentConfig) (core.Agent, error) {
 			MaxOutputTokens:   128000,
 			SupportedModels:   core.GetSupportedModels(core.AgentClaude),
 			DefaultModel:      core.GetDefaultModel(core.AgentClaude),
+			RateLimitRPM:      cfg.RateLimitRPM,
+			RateLimitTPM:      cfg.RateLimitTPM,
 		},
 	}
 
@@ -75,6 +77,7 @@ func (c *ClaudeAdapter) Ping(ctx context.Context) error {
 
 // Execute runs a prompt through Claude CLI.
 func (c *ClaudeAdapter) Execute(ctx context.Context, opts core.ExecuteOptions) (*core.ExecuteResult, error) {
+	ctx = withExecPhase(ctx, opts.Phase)
 	args := c.buildArgs(opts)
 
 	// Build the full prompt, including conversation history if provided
@@ -36,6 +36,8 @@ func NewCodexAdapter(cfg AgentConfig) (core.Agent, error) {
 			MaxOutputTokens:   16384,
 			SupportedModels:   core.GetSupportedModels(core.AgentCodex),
 			DefaultModel:      core.GetDefaultModel(core.AgentCodex),
+			RateLimitRPM:      cfg.RateLimitRPM,
+			RateLimitTPM:      cfg.RateLimitTPM,
 		},
 	}
 
@@ -69,6 +71,7 @@ func (c *CodexAdapter) Ping(ctx context.Context) error {
 
 // Execute runs a prompt through Codex CLI.
 func (c *CodexAdapter) Execute(ctx context.Context, opts core.ExecuteOptions) (*core.ExecuteResult, error) {
+	ctx = withExecPhase(ctx, opts.Phase)
 	args := c.buildArgs(opts)
 
 	// Codex CLI handles system prompt via -c developer_instructions or prepend to user prompt
@@ -37,6 +37,10 @@ func ConfigureRegistryFromConfig(registry *Registry, cfg *config.Config) error {
 			ReasoningEffortPhases:     cfg.Agents.Claude.ReasoningEffortPhases,
 			TokenDiscrepancyThreshold: getTokenDiscrepancyThreshold(cfg.Agents.Claude.TokenDiscrepancyThreshold),
 			IdleTimeout:               parseIdleTimeout(cfg.Agents.Claude.IdleTimeout),
+			Sandbox:                   sandboxPolicy("claude", cfg.Agents.Claude.Sandbox),
+			SandboxPhases:             cfg.Agents.Claude.Sandbox.Phases,
+			RateLimitRPM:              cfg.Agents.Claude.RateLimitRPM,
+			RateLimitTPM:              cfg.Agents.Claude.RateLimitTPM,
 		})
 	}
 
@@ -52,6 +56,10 @@ func ConfigureRegistryFromConfig(registry *Registry, cfg *config.Config) error {
 			ReasoningEffortPhases:     cfg.Agents.Gemini.ReasoningEffortPhases,
 			TokenDiscrepancyThreshold: getTokenDiscrepancyThreshold(cfg.Agents.Gemini.TokenDiscrepancyThreshold),
 			IdleTimeout:               parseIdleTimeout(cfg.Agents.Gemini.IdleTimeout),
+			Sandbox:                   sandboxPolicy("gemini", cfg.Agents.Gemini.Sandbox),
+			SandboxPhases:             cfg.Agents.Gemini.Sandbox.Phases,
+			RateLimitRPM:              cfg.Agents.Gemini.RateLimitRPM,
+			RateLimitTPM:              cfg.Agents.Gemini.RateLimitTPM,
 		})
 	}
 
@@ -67,6 +75,10 @@ func ConfigureRegistryFromConfig(registry *Registry, cfg *config.Config) error {
 			ReasoningEffortPhases:     cfg.Agents.Codex.ReasoningEffortPhases,
 			TokenDiscrepancyThreshold: getTokenDiscrepancyThreshold(cfg.Agents.Codex.TokenDiscrepancyThreshold),
 			IdleTimeout:               parseIdleTimeout(cfg.Agents.Codex.IdleTimeout),
+			Sandbox:                   sandboxPolicy("codex", cfg.Agents.Codex.Sandbox),
+			SandboxPhases:             cfg.Agents.Codex.Sandbox.Phases,
+			RateLimitRPM:              cfg.Agents.Codex.RateLimitRPM,
+			RateLimitTPM:              cfg.Agents.Codex.RateLimitTPM,
 		})
 	}
 
@@ -82,6 +94,10 @@ func ConfigureRegistryFromConfig(registry *Registry, cfg *config.Config) error {
 			ReasoningEffortPhases:     cfg.Agents.Copilot.ReasoningEffortPhases,
 			TokenDiscrepancyThreshold: getTokenDiscrepancyThreshold(cfg.Agents.Copilot.TokenDiscrepancyThreshold),
 			IdleTimeout:               parseIdleTimeout(cfg.Agents.Copilot.IdleTimeout),
+			Sandbox:                   sandboxPolicy("copilot", cfg.Agents.Copilot.Sandbox),
+			SandboxPhases:             cfg.Agents.Copilot.Sandbox.Phases,
+			RateLimitRPM:              cfg.Agents.Copilot.RateLimitRPM,
+			RateLimitTPM:              cfg.Agents.Copilot.RateLimitTPM,
 		})
 	}
 
@@ -97,6 +113,10 @@ func ConfigureRegistryFromConfig(registry *Registry, cfg *config.Config) error {
 			ReasoningEffortPhases:     cfg.Agents.OpenCode.ReasoningEffortPhases,
 			TokenDiscrepancyThreshold: getTokenDiscrepancyThreshold(cfg.Agents.OpenCode.TokenDiscrepancyThreshold),
 			IdleTimeout:               parseIdleTimeout(cfg.Agents.OpenCode.IdleTimeout),
+			Sandbox:                   sandboxPolicy("opencode", cfg.Agents.OpenCode.Sandbox),
+			SandboxPhases:             cfg.Agents.OpenCode.Sandbox.Phases,
+			RateLimitRPM:              cfg.Agents.OpenCode.RateLimitRPM,
+			RateLimitTPM:              cfg.Agents.OpenCode.RateLimitTPM,
 		})
 	}
 
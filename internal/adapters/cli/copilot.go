@@ -15,6 +15,7 @@ import (
 
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/sandbox"
 )
 
 // CopilotAdapter implements Agent for GitHub Copilot CLI (standalone).
@@ -48,6 +49,8 @@ func NewCopilotAdapter(cfg AgentConfig) (core.Agent, error) {
 			MaxOutputTokens:   16384,
 			SupportedModels:   core.GetSupportedModels(core.AgentCopilot),
 			DefaultModel:      core.GetDefaultModel(core.AgentCopilot),
+			RateLimitRPM:      cfg.RateLimitRPM,
+			RateLimitTPM:      cfg.RateLimitTPM,
 		},
 	}
 
@@ -109,6 +112,7 @@ func (c *CopilotAdapter) Ping(ctx context.Context) error {
 
 // Execute runs a prompt through Copilot CLI.
 func (c *CopilotAdapter) Execute(ctx context.Context, opts core.ExecuteOptions) (*core.ExecuteResult, error) {
+	ctx = withExecPhase(ctx, opts.Phase)
 	args := c.buildArgs(opts)
 
 	// Create command
@@ -160,6 +164,13 @@ func (c *CopilotAdapter) Execute(ctx context.Context, opts core.ExecuteOptions)
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
 
+	sandboxCleanup, err := applySandbox(ctx, cmd, c.config, c.logger, sandbox.Wrap)
+	if err != nil {
+		_ = stdoutPipe.Close()
+		return nil, err
+	}
+	defer sandboxCleanup()
+
 	startTime := time.Now()
 
 	if err := cmd.Start(); err != nil {
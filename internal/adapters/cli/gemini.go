@@ -36,6 +36,8 @@ func NewGeminiAdapter(cfg AgentConfig) (core.Agent, error) {
 			MaxOutputTokens:   8192,
 			SupportedModels:   core.GetSupportedModels(core.AgentGemini),
 			DefaultModel:      core.GetDefaultModel(core.AgentGemini),
+			RateLimitRPM:      cfg.RateLimitRPM,
+			RateLimitTPM:      cfg.RateLimitTPM,
 		},
 	}
 
@@ -74,6 +76,7 @@ func (g *GeminiAdapter) Ping(ctx context.Context) error {
 
 // Execute runs a prompt through Gemini CLI.
 func (g *GeminiAdapter) Execute(ctx context.Context, opts core.ExecuteOptions) (*core.ExecuteResult, error) {
+	ctx = withExecPhase(ctx, opts.Phase)
 	args := g.buildArgs(opts)
 
 	// Gemini CLI doesn't have --system-prompt, so prepend to user prompt
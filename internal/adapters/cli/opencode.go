@@ -69,6 +69,8 @@ func NewOpenCodeAdapter(cfg AgentConfig) (core.Agent, error) {
 			MaxOutputTokens:   8192,
 			SupportedModels:   core.GetSupportedModels(core.AgentOpenCode),
 			DefaultModel:      core.GetDefaultModel(core.AgentOpenCode),
+			RateLimitRPM:      cfg.RateLimitRPM,
+			RateLimitTPM:      cfg.RateLimitTPM,
 		},
 		ollamaURL: ollamaURL,
 		ollamaKey: ollamaKey,
@@ -144,6 +146,7 @@ func (o *OpenCodeAdapter) checkOllamaConnectivity(ctx context.Context) {
 
 // Execute runs a prompt through OpenCode CLI.
 func (o *OpenCodeAdapter) Execute(ctx context.Context, opts core.ExecuteOptions) (*core.ExecuteResult, error) {
+	ctx = withExecPhase(ctx, opts.Phase)
 	// Determine model using profile detection
 	model := o.resolveModel(opts)
 
@@ -18,6 +18,21 @@ func truncateDataValue(s string, maxLen int) string {
 	return s[:maxLen] + "...[truncated]"
 }
 
+// errorEvent creates an error event. Rate limit errors are flagged in the
+// event data, with the retry hint in milliseconds when the message has one,
+// so that the rate limiter can back off while the CLI is still running.
+func errorEvent(agent, msg string) core.AgentEvent {
+	event := core.NewAgentEvent(core.AgentEventError, agent, msg)
+	if !isRateLimitMessage(strings.ToLower(msg)) {
+		return event
+	}
+	data := map[string]any{"rate_limited": true}
+	if retryAfter := retryAfterHint(msg); retryAfter > 0 {
+		data["retry_after_ms"] = retryAfter.Milliseconds()
+	}
+	return event.WithData(data)
+}
+
 // truncateDataAny handles any-typed values (e.g. content.Input): if small leaves intact,
 // if large serializes to JSON and truncates.
 func truncateDataAny(v any, maxLen int) any {
@@ -153,19 +168,11 @@ func (p *ClaudeStreamParser) ParseLine(line string) []core.AgentEvent {
 				"Completed",
 			))
 		} else if event.Subtype == "error" {
-			events = append(events, core.NewAgentEvent(
-				core.AgentEventError,
-				"claude",
-				event.Error,
-			))
+			events = append(events, errorEvent("claude", event.Error))
 		}
 
 	case "error":
-		events = append(events, core.NewAgentEvent(
-			core.AgentEventError,
-			"claude",
-			event.Error,
-		))
+		events = append(events, errorEvent("claude", event.Error))
 	}
 
 	return events
@@ -277,11 +284,7 @@ func (p *GeminiStreamParser) ParseLine(line string) []core.AgentEvent {
 		))
 
 	case "error":
-		events = append(events, core.NewAgentEvent(
-			core.AgentEventError,
-			"gemini",
-			event.Error,
-		))
+		events = append(events, errorEvent("gemini", event.Error))
 	}
 
 	return events
@@ -472,11 +475,7 @@ func (p *CodexStreamParser) ParseLine(line string) []core.AgentEvent {
 		).WithData(data))
 
 	case "error":
-		events = append(events, core.NewAgentEvent(
-			core.AgentEventError,
-			"codex",
-			event.Error,
-		))
+		events = append(events, errorEvent("codex", event.Error))
 	}
 
 	return events
@@ -556,11 +555,7 @@ func (p *CopilotLogParser) ParseLine(line string) []core.AgentEvent {
 
 	// Check for errors
 	if p.errorPattern.MatchString(line) {
-		events = append(events, core.NewAgentEvent(
-			core.AgentEventError,
-			"copilot",
-			line,
-		))
+		events = append(events, errorEvent("copilot", line))
 	}
 
 	return events
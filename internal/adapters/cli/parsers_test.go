@@ -105,6 +105,23 @@ func TestClaudeStreamParser_ParseLine_InvalidJSON(t *testing.T) {
 	}
 }
 
+func TestStreamParsers_FlagRateLimitErrors(t *testing.T) {
+	t.Parallel()
+
+	events := (&ClaudeStreamParser{}).ParseLine(`{"type":"error","error":"429 rate limit exceeded, retry after 30s"}`)
+	if len(events) != 1 || events[0].Type != core.AgentEventError {
+		t.Fatalf("ParseLine() = %+v, want one error event", events)
+	}
+	if events[0].Data["rate_limited"] != true || events[0].Data["retry_after_ms"] != int64(30000) {
+		t.Errorf("rate limit event data = %v", events[0].Data)
+	}
+
+	events = (&GeminiStreamParser{}).ParseLine(`{"type":"error","error":"file not found"}`)
+	if len(events) != 1 || events[0].Data != nil {
+		t.Errorf("ParseLine() of another error = %+v, want no rate limit data", events)
+	}
+}
+
 func TestGeminiStreamParser_ParseLine(t *testing.T) {
 	t.Parallel()
 	parser := &GeminiStreamParser{}
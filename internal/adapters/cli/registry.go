@@ -333,6 +333,26 @@ func (r *Registry) Clear() {
 	r.agents = make(map[string]core.Agent)
 }
 
+// ReplaceConfigs replaces the agent configurations with those of next in one
+// step and drops the cached agents, keeping the factories, event handler and
+// diagnostics. A reloaded config is applied by configuring a fresh registry
+// and replacing the configurations of the shared one with it, so a failed
+// reload leaves the registry untouched and no caller sees it empty. Agents
+// already handed out keep working.
+func (r *Registry) ReplaceConfigs(next *Registry) {
+	next.mu.RLock()
+	configs := make(map[string]AgentConfig, len(next.configs))
+	for name, cfg := range next.configs {
+		configs[name] = cfg
+	}
+	next.mu.RUnlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.agents = make(map[string]core.Agent)
+	r.configs = configs
+}
+
 // defaultConfig returns default configuration for an agent.
 // NOTE: Model has NO default - it must be configured in the config file
 // or the CLI will use its own default. The source of truth is always
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/sandbox"
+)
+
+// sandboxDefaults are the endpoints and state directories each CLI needs to
+// work at all. They are added to the user's sandbox configuration so that an
+// allowlist only has to name the extra hosts a task needs.
+var sandboxDefaults = map[string]struct {
+	hosts      []string
+	writePaths []string
+}{
+	"claude": {
+		hosts:      []string{"api.anthropic.com", "statsig.anthropic.com", "console.anthropic.com"},
+		writePaths: []string{"~/.claude", "~/.claude.json", "~/.cache/claude"},
+	},
+	"gemini": {
+		hosts:      []string{"generativelanguage.googleapis.com", "cloudcode-pa.googleapis.com", "oauth2.googleapis.com"},
+		writePaths: []string{"~/.gemini"},
+	},
+	"codex": {
+		hosts:      []string{"api.openai.com", "chatgpt.com", "auth.openai.com"},
+		writePaths: []string{"~/.codex"},
+	},
+	"copilot": {
+		hosts:      []string{"api.github.com", "github.com", "*.githubcopilot.com"},
+		writePaths: []string{"~/.copilot", "~/.config/github-copilot"},
+	},
+	"opencode": {
+		hosts:      []string{"api.anthropic.com", "api.openai.com", "opencode.ai", "*.opencode.ai"},
+		writePaths: []string{"~/.local/share/opencode", "~/.config/opencode", "~/.cache/opencode"},
+	},
+}
+
+// sandboxPolicy builds the sandbox policy for an agent from its config.
+// Returns nil when the sandbox is disabled.
+func sandboxPolicy(name string, cfg config.AgentSandboxConfig) *sandbox.Policy {
+	if !cfg.Enabled {
+		return nil
+	}
+	defaults := sandboxDefaults[name]
+	policy := &sandbox.Policy{
+		WritePaths: append(append([]string{}, defaults.writePaths...), cfg.WritePaths...),
+		DenyPaths:  append([]string{}, cfg.DenyPaths...),
+		Network:    sandbox.NetworkMode(cfg.Network),
+		Limits: sandbox.Limits{
+			MemoryMB:   cfg.MemoryMB,
+			CPUPercent: cfg.CPUPercent,
+			MaxPids:    cfg.MaxPids,
+		},
+	}
+	if policy.Network == sandbox.NetworkAllowlist {
+		policy.AllowHosts = append(append([]string{}, defaults.hosts...), cfg.AllowHosts...)
+	}
+	return policy
+}
+
+type execPhaseKey struct{}
+
+// withExecPhase records the workflow phase of an Execute call so that the
+// command runner can pick the phase's sandbox settings.
+func withExecPhase(ctx context.Context, phase core.Phase) context.Context {
+	return context.WithValue(ctx, execPhaseKey{}, string(phase))
+}
+
+// execPhase returns the phase recorded by withExecPhase, or "".
+func execPhase(ctx context.Context) string {
+	phase, _ := ctx.Value(execPhaseKey{}).(string)
+	return phase
+}
+
+// sandboxCommand applies the agent's sandbox policy to cmd when one is
+// configured for the current phase. extraWritable are paths the CLI must be
+// able to write besides its working directory (e.g. a log directory). The
+// returned cleanup must be called after the command has exited.
+func (b *BaseAdapter) sandboxCommand(ctx context.Context, cmd *exec.Cmd, extraWritable ...string) (func(), error) {
+	wrap := sandbox.Wrap
+	if b.safeExec != nil {
+		wrap = b.safeExec.Sandbox
+	}
+	return applySandbox(ctx, cmd, b.config, b.logger, wrap, extraWritable...)
+}
+
+// applySandbox is sandboxCommand for adapters that build their own commands.
+func applySandbox(
+	ctx context.Context,
+	cmd *exec.Cmd,
+	cfg AgentConfig,
+	logger *logging.Logger,
+	wrap func(*exec.Cmd, sandbox.Policy) (func(), error),
+	extraWritable ...string,
+) (func(), error) {
+	if cfg.Sandbox == nil || !sandboxAppliesTo(cfg.SandboxPhases, execPhase(ctx)) {
+		return func() {}, nil
+	}
+
+	policy := *cfg.Sandbox
+	policy.WritePaths = append(append([]string{}, policy.WritePaths...), extraWritable...)
+	if policy.Logger == nil && logger != nil {
+		policy.Logger = logger.Logger
+	}
+
+	cleanup, err := wrap(cmd, policy)
+	if err != nil {
+		return nil, core.ErrExecution("SANDBOX_FAILED",
+			fmt.Sprintf("sandboxing %s: %v", cfg.Name, err))
+	}
+	return cleanup, nil
+}
+
+// sandboxAppliesTo reports whether the sandbox covers phase. An empty phase
+// set covers every call, including those made outside a workflow phase.
+func sandboxAppliesTo(phases map[string]bool, phase string) bool {
+	if len(phases) == 0 {
+		return true
+	}
+	return phases[phase]
+}
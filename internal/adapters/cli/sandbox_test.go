@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/sandbox"
+)
+
+func TestSandboxPolicy(t *testing.T) {
+	t.Parallel()
+
+	if p := sandboxPolicy("claude", config.AgentSandboxConfig{}); p != nil {
+		t.Errorf("disabled sandbox policy = %+v, want nil", p)
+	}
+
+	p := sandboxPolicy("claude", config.AgentSandboxConfig{
+		Enabled:    true,
+		Network:    "allowlist",
+		AllowHosts: []string{"proxy.golang.org"},
+		WritePaths: []string{"~/go/pkg/mod"},
+		DenyPaths:  []string{"~/.ssh"},
+		MemoryMB:   2048,
+	})
+	if p == nil {
+		t.Fatal("sandboxPolicy() = nil")
+	}
+	if p.Network != sandbox.NetworkAllowlist {
+		t.Errorf("Network = %q", p.Network)
+	}
+	for _, host := range []string{"api.anthropic.com", "proxy.golang.org"} {
+		if !slices.Contains(p.AllowHosts, host) {
+			t.Errorf("AllowHosts = %v, missing %s", p.AllowHosts, host)
+		}
+	}
+	for _, path := range []string{"~/.claude", "~/go/pkg/mod"} {
+		if !slices.Contains(p.WritePaths, path) {
+			t.Errorf("WritePaths = %v, missing %s", p.WritePaths, path)
+		}
+	}
+	if !slices.Equal(p.DenyPaths, []string{"~/.ssh"}) || p.Limits.MemoryMB != 2048 {
+		t.Errorf("policy = %+v", p)
+	}
+
+	// Hosts only matter in allowlist mode.
+	if p := sandboxPolicy("codex", config.AgentSandboxConfig{Enabled: true, Network: "none"}); len(p.AllowHosts) != 0 {
+		t.Errorf("AllowHosts = %v, want none outside allowlist mode", p.AllowHosts)
+	}
+}
+
+func TestApplySandbox_Phases(t *testing.T) {
+	t.Parallel()
+
+	cfg := AgentConfig{
+		Name:          "claude",
+		Sandbox:       &sandbox.Policy{WritePaths: []string{"~/.claude"}},
+		SandboxPhases: map[string]bool{"execute": true},
+	}
+	var got *sandbox.Policy
+	wrap := func(_ *exec.Cmd, p sandbox.Policy) (func(), error) {
+		got = &p
+		return func() {}, nil
+	}
+
+	ctx := withExecPhase(context.Background(), core.PhaseAnalyze)
+	if _, err := applySandbox(ctx, exec.Command("true"), cfg, nil, wrap); err != nil || got != nil {
+		t.Fatalf("analyze phase: err = %v, sandboxed = %v", err, got != nil)
+	}
+
+	ctx = withExecPhase(context.Background(), core.PhaseExecute)
+	if _, err := applySandbox(ctx, exec.Command("true"), cfg, nil, wrap, "/tmp/logs"); err != nil || got == nil {
+		t.Fatalf("execute phase: err = %v, sandboxed = %v", err, got != nil)
+	}
+	if !slices.Equal(got.WritePaths, []string{"~/.claude", "/tmp/logs"}) {
+		t.Errorf("WritePaths = %v", got.WritePaths)
+	}
+	if !slices.Equal(cfg.Sandbox.WritePaths, []string{"~/.claude"}) {
+		t.Errorf("configured policy was modified: %v", cfg.Sandbox.WritePaths)
+	}
+
+	// Calls outside a phase (e.g. version checks) are only sandboxed when the
+	// sandbox covers every phase.
+	got = nil
+	if _, err := applySandbox(context.Background(), exec.Command("true"), cfg, nil, wrap); err != nil || got != nil {
+		t.Errorf("no phase: err = %v, sandboxed = %v", err, got != nil)
+	}
+	cfg.SandboxPhases = nil
+	if _, err := applySandbox(context.Background(), exec.Command("true"), cfg, nil, wrap); err != nil || got == nil {
+		t.Errorf("all phases: err = %v, sandboxed = %v", err, got != nil)
+	}
+}
+
+func TestApplySandbox_Error(t *testing.T) {
+	t.Parallel()
+
+	cfg := AgentConfig{Name: "gemini", Sandbox: &sandbox.Policy{}}
+	wrap := func(*exec.Cmd, sandbox.Policy) (func(), error) {
+		return nil, errors.New("user namespaces disabled")
+	}
+
+	_, err := applySandbox(context.Background(), exec.Command("true"), cfg, nil, wrap)
+	var domainErr *core.DomainError
+	if !errors.As(err, &domainErr) || domainErr.Code != "SANDBOX_FAILED" {
+		t.Fatalf("err = %v, want SANDBOX_FAILED", err)
+	}
+	if !strings.Contains(err.Error(), "user namespaces disabled") {
+		t.Errorf("err = %v, should include the cause", err)
+	}
+}
@@ -82,6 +82,11 @@ func (c *Client) verifyRepo() error {
 
 // run executes a git command.
 func (c *Client) run(ctx context.Context, args ...string) (string, error) {
+	return c.runEnv(ctx, nil, args...)
+}
+
+// runEnv executes a git command with extra environment variables.
+func (c *Client) runEnv(ctx context.Context, env []string, args ...string) (string, error) {
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
@@ -100,6 +105,9 @@ func (c *Client) run(ctx context.Context, args ...string) (string, error) {
 	cmd := exec.CommandContext(ctx, "git", args...)
 	cmd.Path = c.gitPath
 	cmd.Dir = c.repoPath
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -506,6 +514,18 @@ func (c *Client) PushForce(ctx context.Context, remote, branch string) error {
 	return err
 }
 
+// DeleteRemoteBranch deletes a branch on the remote (internal use).
+func (c *Client) DeleteRemoteBranch(ctx context.Context, remote, branch string) error {
+	if err := validateGitRemoteName(remote); err != nil {
+		return err
+	}
+	if err := validateGitBranchName(branch); err != nil {
+		return err
+	}
+	_, err := c.run(ctx, "push", remote, "--delete", branch)
+	return err
+}
+
 // Pull pulls from remote.
 func (c *Client) Pull(ctx context.Context, remote, branch string) error {
 	if err := validateGitRemoteName(remote); err != nil {
@@ -1218,6 +1238,92 @@ func (c *Client) HasUncommittedChanges(ctx context.Context) (bool, error) {
 	return untracked != "", nil
 }
 
+// =============================================================================
+// Snapshot Operations
+// =============================================================================
+
+// Compile-time interface conformance check.
+var _ core.GitSnapshotter = (*Client)(nil)
+
+// SnapshotWorkingTree commits the working tree on top of ref, or of HEAD when
+// ref does not exist yet, and moves ref to the commit (implements
+// core.GitSnapshotter). The tree is built in a temporary index, so HEAD, the
+// branch and the index of the working tree are left alone. It returns "" when
+// the working tree matches the tip of ref.
+func (c *Client) SnapshotWorkingTree(ctx context.Context, ref, message string) (string, error) {
+	if err := validateGitRev(ref); err != nil {
+		return "", err
+	}
+	if err := validateGitMessage(message); err != nil {
+		return "", err
+	}
+
+	parent, err := c.run(ctx, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil || parent == "" {
+		if parent, err = c.run(ctx, "rev-parse", "--verify", "HEAD^{commit}"); err != nil {
+			return "", fmt.Errorf("resolving snapshot parent: %w", err)
+		}
+	}
+
+	index, err := os.CreateTemp("", "quorum-snapshot-index-*")
+	if err != nil {
+		return "", fmt.Errorf("creating snapshot index: %w", err)
+	}
+	indexPath := index.Name()
+	_ = index.Close()
+	// Git refuses an empty index file but creates a missing one.
+	_ = os.Remove(indexPath)
+	defer os.Remove(indexPath)
+	env := []string{"GIT_INDEX_FILE=" + indexPath}
+
+	if _, err := c.runEnv(ctx, env, "read-tree", "HEAD"); err != nil {
+		return "", err
+	}
+	if _, err := c.runEnv(ctx, env, "add", "--all"); err != nil {
+		return "", err
+	}
+	tree, err := c.runEnv(ctx, env, "write-tree")
+	if err != nil {
+		return "", err
+	}
+	if parentTree, err := c.run(ctx, "rev-parse", parent+"^{tree}"); err == nil && parentTree == tree {
+		return "", nil
+	}
+
+	commit, err := c.run(ctx, "commit-tree", tree, "-p", parent, "-m", message)
+	if err != nil {
+		return "", err
+	}
+	if _, err := c.run(ctx, "update-ref", ref, commit); err != nil {
+		return "", err
+	}
+	return commit, nil
+}
+
+// RestoreWorkingTree writes the files of commit into the working tree
+// (implements core.GitSnapshotter). Files the commit does not have are
+// removed and files HEAD does not have are left untracked; HEAD and the index
+// are not changed.
+func (c *Client) RestoreWorkingTree(ctx context.Context, commit string) error {
+	if err := validateGitRev(commit); err != nil {
+		return err
+	}
+	if _, err := c.run(ctx, "read-tree", "--reset", "-u", commit); err != nil {
+		return err
+	}
+	_, err := c.run(ctx, "reset", "--quiet")
+	return err
+}
+
+// DeleteRef deletes ref (implements core.GitSnapshotter).
+func (c *Client) DeleteRef(ctx context.Context, ref string) error {
+	if err := validateGitRev(ref); err != nil {
+		return err
+	}
+	_, err := c.run(ctx, "update-ref", "-d", ref)
+	return err
+}
+
 // =============================================================================
 // Helper Functions
 // =============================================================================
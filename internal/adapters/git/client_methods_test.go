@@ -432,6 +432,32 @@ func TestGitClient_PushForce(t *testing.T) {
 	testutil.AssertNoError(t, client.PushForce(context.Background(), "origin", "main"))
 }
 
+func TestGitClient_DeleteRemoteBranch(t *testing.T) {
+	t.Parallel()
+	repo := testutil.NewGitRepo(t)
+	repo.WriteFile("README.md", "# Test")
+	repo.Commit("Initial commit")
+
+	remote := testutil.CreateBareRemote(t)
+	repo.SetRemote("origin", remote)
+
+	client, err := git.NewClient(repo.Path)
+	testutil.AssertNoError(t, err)
+
+	ctx := context.Background()
+	testutil.AssertNoError(t, client.CreateBranch(ctx, "feature", "main"))
+	testutil.AssertNoError(t, client.Push(ctx, "origin", "feature"))
+	testutil.AssertNoError(t, client.DeleteRemoteBranch(ctx, "origin", "feature"))
+
+	remoteClient, err := git.NewClient(remote)
+	testutil.AssertNoError(t, err)
+	exists, err := remoteClient.BranchExists(ctx, "feature")
+	testutil.AssertNoError(t, err)
+	testutil.AssertFalse(t, exists, "remote branch should be deleted")
+
+	testutil.AssertError(t, client.DeleteRemoteBranch(ctx, "", "feature"))
+}
+
 func TestGitClient_PushForce_InvalidInputs(t *testing.T) {
 	t.Parallel()
 	repo := testutil.NewGitRepo(t)
@@ -994,3 +1020,67 @@ func TestGitClient_RemoteValidation_NulByte(t *testing.T) {
 
 	testutil.AssertError(t, client.Fetch(context.Background(), "bad\x00remote"))
 }
+
+// =============================================================================
+// Snapshot operations
+// =============================================================================
+
+func TestGitClient_SnapshotAndRestoreWorkingTree(t *testing.T) {
+	t.Parallel()
+	repo := testutil.NewGitRepo(t)
+	repo.WriteFile("README.md", "# Test")
+	repo.WriteFile("old.txt", "old")
+	repo.Commit("Initial commit")
+	head, _ := repo.Run("rev-parse", "HEAD")
+
+	client, err := git.NewClient(repo.Path)
+	testutil.AssertNoError(t, err)
+	ctx := context.Background()
+	const ref = "refs/quorum/wip/wf-1/task-1"
+
+	sha, err := client.SnapshotWorkingTree(ctx, ref, "wip")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, sha, "")
+
+	repo.WriteFile("README.md", "# Changed")
+	repo.WriteFile("new.txt", "new")
+	testutil.AssertNoError(t, os.Remove(filepath.Join(repo.Path, "old.txt")))
+	first, err := client.SnapshotWorkingTree(ctx, ref, "wip 1")
+	testutil.AssertNoError(t, err)
+	if first == "" {
+		t.Fatal("SnapshotWorkingTree() with changes returned no commit")
+	}
+	again, err := client.SnapshotWorkingTree(ctx, ref, "wip 2")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, again, "")
+
+	// The snapshot leaves HEAD and the index alone.
+	after, _ := repo.Run("rev-parse", "HEAD")
+	testutil.AssertEqual(t, after, head)
+	staged, err := client.DiffStaged(ctx)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, staged, "")
+
+	// Restore into a clean working tree.
+	testutil.AssertNoError(t, client.ResetHard(ctx, "HEAD"))
+	testutil.AssertNoError(t, client.Clean(ctx, true, true))
+	testutil.AssertNoError(t, client.RestoreWorkingTree(ctx, ref))
+
+	data, err := os.ReadFile(filepath.Join(repo.Path, "README.md"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, string(data), "# Changed")
+	if _, err := os.Stat(filepath.Join(repo.Path, "new.txt")); err != nil {
+		t.Errorf("new.txt not restored: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(repo.Path, "old.txt")); !os.IsNotExist(err) {
+		t.Errorf("old.txt still present after restore: %v", err)
+	}
+	staged, _ = client.DiffStaged(ctx)
+	testutil.AssertEqual(t, staged, "")
+
+	testutil.AssertNoError(t, client.DeleteRef(ctx, ref))
+	testutil.AssertNoError(t, client.DeleteRef(ctx, ref))
+	if _, err := client.RevParse(ctx, ref); err == nil {
+		t.Error("RevParse() of a deleted ref succeeded")
+	}
+}
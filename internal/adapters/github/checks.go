@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
@@ -273,3 +275,82 @@ func (r *ChecksResult) Summary() string {
 
 	return fmt.Sprintf("%d passed, %d failed out of %d checks", passed, failed, total)
 }
+
+// toCheckStatus converts the aggregated result into the core representation.
+func (r *ChecksResult) toCheckStatus() *core.CheckStatus {
+	status := &core.CheckStatus{
+		State:      "success",
+		TotalCount: len(r.Checks),
+		Checks:     make([]core.Check, 0, len(r.Checks)),
+		Failed:     len(r.FailedChecks),
+		Pending:    len(r.PendingChecks),
+		UpdatedAt:  time.Now(),
+	}
+	status.Passed = status.TotalCount - status.Failed - status.Pending
+	switch {
+	case status.Pending > 0 || status.TotalCount == 0:
+		status.State = "pending"
+	case status.Failed > 0:
+		status.State = "failure"
+	}
+	for _, c := range r.Checks {
+		status.Checks = append(status.Checks, core.Check{
+			Name:        c.Name,
+			Status:      c.Status,
+			Conclusion:  c.Conclusion,
+			HTMLURL:     c.URL,
+			StartedAt:   c.StartedAt,
+			CompletedAt: c.CompletedAt,
+		})
+	}
+	return status
+}
+
+// WaitForPRChecks waits for the checks of a pull request to complete
+// (implements core.PRChecksClient).
+func (c *Client) WaitForPRChecks(ctx context.Context, prNumber int, opts core.PRChecksOptions) (*core.CheckStatus, error) {
+	waiter := NewChecksWaiter(c).WithRequiredChecks(opts.RequiredChecks)
+	if opts.PollInterval > 0 {
+		waiter.WithPollInterval(opts.PollInterval)
+	}
+	if opts.Timeout > 0 {
+		waiter.WithTimeout(opts.Timeout)
+	}
+
+	result, err := waiter.Wait(ctx, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	return result.toCheckStatus(), nil
+}
+
+var actionsRunIDRe = regexp.MustCompile(`/actions/runs/(\d+)`)
+
+// GetFailedCheckLog returns the tail of the failed steps' log for a GitHub
+// Actions check via `gh run view --log-failed` (implements core.PRChecksClient).
+func (c *Client) GetFailedCheckLog(ctx context.Context, check core.Check, maxBytes int) (string, error) {
+	m := actionsRunIDRe.FindStringSubmatch(check.HTMLURL)
+	if m == nil {
+		return "", fmt.Errorf("check %q is not a GitHub Actions run: %s", check.Name, check.HTMLURL)
+	}
+
+	output, err := c.run(ctx, "run", "view", m[1], "--repo", c.Repo(), "--log-failed")
+	if err != nil {
+		return "", fmt.Errorf("getting failed log for run %s: %w", m[1], err)
+	}
+	return tailLog(output, maxBytes), nil
+}
+
+// tailLog keeps the last maxBytes of a log, starting at a line boundary.
+// Failures are usually reported at the end of a job, so the tail is the
+// most useful excerpt.
+func tailLog(log string, maxBytes int) string {
+	if maxBytes <= 0 || len(log) <= maxBytes {
+		return log
+	}
+	tail := log[len(log)-maxBytes:]
+	if i := strings.IndexByte(tail, '\n'); i >= 0 && i < len(tail)-1 {
+		tail = tail[i+1:]
+	}
+	return tail
+}
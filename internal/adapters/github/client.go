@@ -10,8 +10,11 @@ import (
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
 )
 
-// Compile-time interface conformance check.
-var _ core.GitHubClient = (*Client)(nil)
+// Compile-time interface conformance checks.
+var (
+	_ core.GitHubClient   = (*Client)(nil)
+	_ core.PRChecksClient = (*Client)(nil)
+)
 
 // Client wraps GitHub CLI operations.
 type Client struct {
@@ -62,10 +65,32 @@ func NewClientFromRepo() (*Client, error) {
 
 // NewClientFromRepoWithRunner creates a client detecting repo from git remote with a custom runner.
 func NewClientFromRepoWithRunner(runner CommandRunner) (*Client, error) {
+	return newClientFromView(runner)
+}
+
+// NewClientForRemote creates a client for the repository a git remote URL
+// points to, such as another repository than the current one.
+func NewClientForRemote(remoteURL string) (*Client, error) {
+	return NewClientForRemoteWithRunner(remoteURL, NewExecRunner())
+}
+
+// NewClientForRemoteWithRunner creates a client for a git remote URL with a custom runner.
+func NewClientForRemoteWithRunner(remoteURL string, runner CommandRunner) (*Client, error) {
+	if strings.TrimSpace(remoteURL) == "" {
+		return nil, fmt.Errorf("remote URL is empty")
+	}
+	return newClientFromView(runner, remoteURL)
+}
+
+// newClientFromView resolves the owner and name of a repository with
+// gh repo view; without a repository argument gh uses the current one.
+func newClientFromView(runner CommandRunner, repository ...string) (*Client, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
 
-	output, err := runner.Run(ctx, "gh", "repo", "view", "--json", "owner,name")
+	args := append([]string{"repo", "view"}, repository...)
+	args = append(args, "--json", "owner,name")
+	output, err := runner.Run(ctx, "gh", args...)
 	if err != nil {
 		return nil, fmt.Errorf("detecting repo: %w", err)
 	}
@@ -62,6 +62,28 @@ func TestNewClientFromRepoWithRunner(t *testing.T) {
 	}
 }
 
+func TestNewClientForRemoteWithRunner(t *testing.T) {
+	t.Parallel()
+	runner := NewMockRunner()
+	runner.OnCommand("gh repo view git@github.com:acme/client.git --json owner,name").ReturnJSON(`{
+		"owner": {"login": "acme"},
+		"name": "client"
+	}`)
+	runner.OnCommand("gh auth status").Return("")
+
+	client, err := NewClientForRemoteWithRunner("git@github.com:acme/client.git", runner)
+	if err != nil {
+		t.Fatalf("NewClientForRemoteWithRunner() error = %v", err)
+	}
+	if client.Repo() != "acme/client" {
+		t.Errorf("Repo() = %q, want %q", client.Repo(), "acme/client")
+	}
+
+	if _, err := NewClientForRemoteWithRunner(" ", runner); err == nil {
+		t.Error("expected error for empty remote URL")
+	}
+}
+
 func TestClient_CreatePR(t *testing.T) {
 	t.Parallel()
 	runner := NewMockRunner()
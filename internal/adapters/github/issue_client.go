@@ -13,8 +13,11 @@ import (
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
 )
 
-// Compile-time interface conformance check.
-var _ core.IssueClient = (*IssueClientAdapter)(nil)
+// Compile-time interface conformance checks.
+var (
+	_ core.IssueClient = (*IssueClientAdapter)(nil)
+	_ core.IssueInbox  = (*IssueClientAdapter)(nil)
+)
 
 // IssueClientAdapter wraps the GitHub Client to implement core.IssueClient.
 type IssueClientAdapter struct {
@@ -234,6 +237,82 @@ func (a *IssueClientAdapter) GetIssue(ctx context.Context, number int) (*core.Is
 	return parseIssueJSON(output)
 }
 
+// ListIssueComments returns the comments of an issue, oldest first.
+func (a *IssueClientAdapter) ListIssueComments(ctx context.Context, number int) ([]core.IssueComment, error) {
+	output, err := a.client.run(ctx, "issue", "view", strconv.Itoa(number),
+		"--repo", a.client.Repo(),
+		"--json", "comments")
+	if err != nil {
+		return nil, fmt.Errorf("listing comments of issue #%d: %w", number, err)
+	}
+
+	var data struct {
+		Comments []struct {
+			Author struct {
+				Login string `json:"login"`
+			} `json:"author"`
+			Body      string    `json:"body"`
+			CreatedAt time.Time `json:"createdAt"`
+		} `json:"comments"`
+	}
+	if err := json.Unmarshal([]byte(output), &data); err != nil {
+		return nil, fmt.Errorf("parsing issue comments JSON: %w", err)
+	}
+
+	comments := make([]core.IssueComment, 0, len(data.Comments))
+	for _, c := range data.Comments {
+		comments = append(comments, core.IssueComment{
+			Author:    c.Author.Login,
+			Body:      c.Body,
+			CreatedAt: c.CreatedAt,
+		})
+	}
+	return comments, nil
+}
+
+// ListIssues returns open issues, optionally restricted to a label.
+func (a *IssueClientAdapter) ListIssues(ctx context.Context, opts core.ListIssuesOptions) ([]*core.Issue, error) {
+	args := []string{"issue", "list",
+		"--repo", a.client.Repo(),
+		"--state", "open",
+		"--json", "id,number,title,body,url,state,labels,assignees,createdAt,updatedAt",
+	}
+	if opts.Label != "" {
+		args = append(args, "--label", opts.Label)
+	}
+	if opts.Limit > 0 {
+		args = append(args, "--limit", strconv.Itoa(opts.Limit))
+	}
+
+	output, err := a.client.run(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("listing issues: %w", err)
+	}
+
+	var data []issueJSON
+	if err := json.Unmarshal([]byte(output), &data); err != nil {
+		return nil, fmt.Errorf("parsing issue list JSON: %w", err)
+	}
+
+	issues := make([]*core.Issue, 0, len(data))
+	for i := range data {
+		issues = append(issues, data[i].toCore())
+	}
+	return issues, nil
+}
+
+// RemoveIssueLabel removes a label from an issue.
+func (a *IssueClientAdapter) RemoveIssueLabel(ctx context.Context, number int, label string) error {
+	_, err := a.client.run(ctx, "issue", "edit", strconv.Itoa(number),
+		"--repo", a.client.Repo(),
+		"--remove-label", label)
+	if err != nil {
+		return fmt.Errorf("removing label %q from issue #%d: %w", label, number, err)
+	}
+
+	return nil
+}
+
 // LinkIssues creates a parent-child relationship between issues.
 // For GitHub: Creates a sub-issue relationship via the REST API.
 func (a *IssueClientAdapter) LinkIssues(ctx context.Context, parent, child int) error {
@@ -259,28 +338,25 @@ func (a *IssueClientAdapter) LinkIssues(ctx context.Context, parent, child int)
 }
 
 // parseIssueJSON parses GitHub issue JSON output to core.Issue.
-func parseIssueJSON(output string) (*core.Issue, error) {
-	var data struct {
-		ID        int64     `json:"id"`
-		Number    int       `json:"number"`
-		Title     string    `json:"title"`
-		Body      string    `json:"body"`
-		URL       string    `json:"url"`
-		State     string    `json:"state"`
-		CreatedAt time.Time `json:"createdAt"`
-		UpdatedAt time.Time `json:"updatedAt"`
-		Labels    []struct {
-			Name string `json:"name"`
-		} `json:"labels"`
-		Assignees []struct {
-			Login string `json:"login"`
-		} `json:"assignees"`
-	}
-
-	if err := json.Unmarshal([]byte(output), &data); err != nil {
-		return nil, fmt.Errorf("parsing issue JSON: %w", err)
-	}
+// issueJSON is the gh --json shape of an issue.
+type issueJSON struct {
+	ID        int64     `json:"id"`
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	URL       string    `json:"url"`
+	State     string    `json:"state"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Labels    []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Assignees []struct {
+		Login string `json:"login"`
+	} `json:"assignees"`
+}
 
+func (data *issueJSON) toCore() *core.Issue {
 	labels := make([]string, len(data.Labels))
 	for i, l := range data.Labels {
 		labels[i] = l.Name
@@ -302,7 +378,15 @@ func parseIssueJSON(output string) (*core.Issue, error) {
 		Assignees: assignees,
 		CreatedAt: data.CreatedAt,
 		UpdatedAt: data.UpdatedAt,
-	}, nil
+	}
+}
+
+func parseIssueJSON(output string) (*core.Issue, error) {
+	var data issueJSON
+	if err := json.Unmarshal([]byte(output), &data); err != nil {
+		return nil, fmt.Errorf("parsing issue JSON: %w", err)
+	}
+	return data.toCore(), nil
 }
 
 // parseIssueNumberFromURL extracts issue number from GitHub issue URL.
@@ -298,3 +298,65 @@ func TestIssueClientAdapter_UpdateIssue(t *testing.T) {
 		t.Error("Expected issue edit command to be called")
 	}
 }
+
+func TestIssueClientAdapter_ListIssueComments(t *testing.T) {
+	t.Parallel()
+	mockRunner := NewMockRunner()
+	mockRunner.OnCommand("gh issue view 42").Return(`{"comments": [
+		{"author": {"login": "alice"}, "body": "Repro: log in twice", "createdAt": "2024-01-10T08:00:00Z"},
+		{"author": {"login": "bob"}, "body": "Same here", "createdAt": "2024-01-11T08:00:00Z"}
+	]}`)
+
+	adapter := NewIssueClientAdapter(NewClientSkipAuth("owner", "repo", mockRunner))
+
+	comments, err := adapter.ListIssueComments(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("ListIssueComments() error = %v", err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("len(comments) = %d, want 2", len(comments))
+	}
+	if comments[0].Author != "alice" || comments[0].Body != "Repro: log in twice" {
+		t.Errorf("comments[0] = %+v", comments[0])
+	}
+	if mockRunner.CallCount("--json comments") == 0 {
+		t.Error("Expected comments to be requested")
+	}
+}
+
+func TestIssueClientAdapter_ListIssues(t *testing.T) {
+	t.Parallel()
+	mockRunner := NewMockRunner()
+	mockRunner.OnCommand("gh issue list").Return(`[
+		{"number": 7, "title": "First", "body": "a", "state": "OPEN", "labels": [{"name": "quorum"}]},
+		{"number": 9, "title": "Second", "body": "b", "state": "OPEN", "labels": [{"name": "quorum"}]}
+	]`)
+
+	adapter := NewIssueClientAdapter(NewClientSkipAuth("owner", "repo", mockRunner))
+
+	issues, err := adapter.ListIssues(context.Background(), core.ListIssuesOptions{Label: "quorum", Limit: 20})
+	if err != nil {
+		t.Fatalf("ListIssues() error = %v", err)
+	}
+	if len(issues) != 2 || issues[1].Number != 9 || issues[1].State != "open" {
+		t.Errorf("issues = %+v", issues)
+	}
+	if mockRunner.CallCount("--label quorum --limit 20") == 0 {
+		t.Error("Expected label and limit to be passed")
+	}
+}
+
+func TestIssueClientAdapter_RemoveIssueLabel(t *testing.T) {
+	t.Parallel()
+	mockRunner := NewMockRunner()
+	mockRunner.OnCommand("gh issue edit 42").Return("")
+
+	adapter := NewIssueClientAdapter(NewClientSkipAuth("owner", "repo", mockRunner))
+
+	if err := adapter.RemoveIssueLabel(context.Background(), 42, "quorum"); err != nil {
+		t.Fatalf("RemoveIssueLabel() error = %v", err)
+	}
+	if mockRunner.CallCount("--remove-label quorum") == 0 {
+		t.Error("Expected remove-label to be called")
+	}
+}
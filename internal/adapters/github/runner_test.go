@@ -2035,3 +2035,53 @@ func TestRunError_ImplementsError(t *testing.T) {
 		t.Error("Error() should return non-empty string")
 	}
 }
+
+func TestClient_WaitForPRChecks_Failure(t *testing.T) {
+	t.Parallel()
+	runner := NewMockRunner()
+	runner.OnCommand("gh pr checks 7").ReturnJSON(`[
+		{"name": "build", "status": "completed", "conclusion": "success",
+		 "detailsUrl": "https://github.com/owner/repo/actions/runs/10/job/1"},
+		{"name": "test", "status": "completed", "conclusion": "failure",
+		 "detailsUrl": "https://github.com/owner/repo/actions/runs/11/job/2"},
+		{"name": "lint", "status": "completed", "conclusion": "failure",
+		 "detailsUrl": "https://github.com/owner/repo/actions/runs/12/job/3"}
+	]`)
+	client := NewClientSkipAuth("owner", "repo", runner)
+
+	status, err := client.WaitForPRChecks(context.Background(), 7, core.PRChecksOptions{
+		RequiredChecks: []string{"build", "test"},
+	})
+	if err != nil {
+		t.Fatalf("WaitForPRChecks() error = %v", err)
+	}
+	if status.State != "failure" || status.Passed != 1 || status.Failed != 1 {
+		t.Errorf("status = %+v, want failure with 1 passed, 1 failed", status)
+	}
+	failed := status.FailedChecks()
+	if len(failed) != 1 || failed[0].Name != "test" {
+		t.Errorf("FailedChecks() = %+v, want [test]", failed)
+	}
+}
+
+func TestClient_GetFailedCheckLog(t *testing.T) {
+	t.Parallel()
+	runner := NewMockRunner()
+	runner.OnCommand("gh run view 11 --repo owner/repo --log-failed").
+		Return("test\tsetup\tok\ntest\trun\tFAIL: TestLogin\ntest\trun\texit status 1\n")
+	client := NewClientSkipAuth("owner", "repo", runner)
+
+	check := core.Check{Name: "test", HTMLURL: "https://github.com/owner/repo/actions/runs/11/job/2"}
+	log, err := client.GetFailedCheckLog(context.Background(), check, 40)
+	if err != nil {
+		t.Fatalf("GetFailedCheckLog() error = %v", err)
+	}
+	if log != "test\trun\texit status 1\n" {
+		t.Errorf("log = %q, want last full line", log)
+	}
+
+	_, err = client.GetFailedCheckLog(context.Background(), core.Check{Name: "ext", HTMLURL: "https://ci.example.com/1"}, 40)
+	if err == nil {
+		t.Error("expected error for non-Actions check")
+	}
+}
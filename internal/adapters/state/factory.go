@@ -1,7 +1,6 @@
 package state
 
 import (
-	"path/filepath"
 	"strings"
 	"time"
 
@@ -28,9 +27,7 @@ func NewStateManager(path string) (core.StateManager, error) {
 // NewStateManagerWithOptions creates a StateManager (SQLite) with additional options.
 func NewStateManagerWithOptions(path string, opts StateManagerOptions) (core.StateManager, error) {
 	// Ensure path has .db extension for SQLite
-	if !strings.HasSuffix(path, ".db") {
-		path = strings.TrimSuffix(path, filepath.Ext(path)) + ".db"
-	}
+	path = normalizeStatePath(path)
 
 	var sqliteOpts []SQLiteStateManagerOption
 	if opts.LockTTL > 0 {
@@ -0,0 +1,7 @@
+-- Migration 012: Add pr_babysit column to workflows table
+-- Stores the post-PR CI loop (check results and fix attempts) as JSON
+
+ALTER TABLE workflows ADD COLUMN pr_babysit TEXT;
+
+-- Insert migration record
+INSERT INTO schema_migrations (version, description) VALUES (12, 'Add PR babysit column');
@@ -0,0 +1,7 @@
+-- Migration 013: Add source_issue column to workflows table
+-- Stores the issue a workflow was created from (provider, repository, number) as JSON
+
+ALTER TABLE workflows ADD COLUMN source_issue TEXT;
+
+-- Insert migration record
+INSERT INTO schema_migrations (version, description) VALUES (13, 'Add source issue column');
@@ -0,0 +1,7 @@
+-- Migration 014: Add source_chat column to workflows table
+-- Stores the chat session a workflow was promoted from as JSON
+
+ALTER TABLE workflows ADD COLUMN source_chat TEXT;
+
+-- Insert migration record
+INSERT INTO schema_migrations (version, description) VALUES (14, 'Add source chat column');
@@ -0,0 +1,7 @@
+-- Migration 015: Add config_version column to workflows table
+-- Stores the config history version the workflow last ran with
+
+ALTER TABLE workflows ADD COLUMN config_version INTEGER;
+
+-- Insert migration record
+INSERT INTO schema_migrations (version, description) VALUES (15, 'Add config version column');
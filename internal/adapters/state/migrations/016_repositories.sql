@@ -0,0 +1,10 @@
+-- Migration 016: Add multi-repository workflow columns
+-- Stores the repositories a workflow changes, their git state as JSON,
+-- and the repository each task targets
+
+ALTER TABLE workflows ADD COLUMN repositories TEXT;
+ALTER TABLE workflows ADD COLUMN repository_states TEXT;
+ALTER TABLE tasks ADD COLUMN repository TEXT;
+
+-- Insert migration record
+INSERT INTO schema_migrations (version, description) VALUES (16, 'Add multi-repository columns');
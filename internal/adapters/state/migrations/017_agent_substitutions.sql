@@ -0,0 +1,8 @@
+-- Migration 017: Add agent substitution column
+-- Stores the calls that ran on a fallback agent while the configured agent
+-- was quarantined by the agent health monitor, as JSON
+
+ALTER TABLE workflows ADD COLUMN agent_substitutions TEXT;
+
+-- Insert migration record
+INSERT INTO schema_migrations (version, description) VALUES (17, 'Add agent substitutions column');
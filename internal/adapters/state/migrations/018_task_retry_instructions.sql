@@ -0,0 +1,8 @@
+-- Migration 018: Add task retry instructions column
+-- Stores the instructions added to the prompt of a failed task retried on
+-- request
+
+ALTER TABLE tasks ADD COLUMN retry_instructions TEXT;
+
+-- Insert migration record
+INSERT INTO schema_migrations (version, description) VALUES (18, 'Add task retry instructions column');
@@ -0,0 +1,60 @@
+package state
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// Reader reads workflow summaries from a state database over a single
+// read-only connection. Unlike a StateManager it does not migrate the
+// database, clean it up or take locks, so it is cheap to open for a quick look
+// at a project that is not otherwise in use.
+type Reader struct {
+	db *sql.DB
+}
+
+// OpenReader opens the state database at path read-only. The path is
+// normalized like NewStateManager does. It returns an error satisfying
+// os.IsNotExist when the database has not been created yet.
+func OpenReader(path string) (*Reader, error) {
+	path = normalizeStatePath(path)
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro&_pragma=query_only(1)&_pragma=busy_timeout(1000)")
+	if err != nil {
+		return nil, fmt.Errorf("opening read database: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	return &Reader{db: db}, nil
+}
+
+// ListWorkflows returns the summaries of all workflows, most recently updated first.
+func (r *Reader) ListWorkflows(ctx context.Context) ([]core.WorkflowSummary, error) {
+	return listWorkflowSummaries(ctx, r.db)
+}
+
+// ListRunningWorkflowRecords returns the running_workflows rows, oldest first.
+func (r *Reader) ListRunningWorkflowRecords(ctx context.Context) ([]core.RunningWorkflowRecord, error) {
+	return listRunningWorkflowRecords(ctx, r.db)
+}
+
+// Close closes the connection.
+func (r *Reader) Close() error {
+	return r.db.Close()
+}
+
+// normalizeStatePath gives path the .db extension of SQLite state databases.
+func normalizeStatePath(path string) string {
+	if !strings.HasSuffix(path, ".db") {
+		path = strings.TrimSuffix(path, filepath.Ext(path)) + ".db"
+	}
+	return path
+}
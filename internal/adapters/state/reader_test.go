@@ -0,0 +1,60 @@
+package state
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+func TestReader_ListsWorkflowsReadOnly(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	m := newTestManager(t)
+	for _, wf := range []*core.WorkflowState{
+		makeWorkflow("wf-reader-1", core.WorkflowStatusCompleted),
+		makeWorkflow("wf-reader-2", core.WorkflowStatusRunning),
+	} {
+		if err := m.Save(ctx, wf); err != nil {
+			t.Fatalf("Save(%s) error = %v", wf.WorkflowID, err)
+		}
+	}
+	if err := m.SetWorkflowRunning(ctx, "wf-reader-2"); err != nil {
+		t.Fatalf("SetWorkflowRunning() error = %v", err)
+	}
+
+	r, err := OpenReader(m.dbPath)
+	if err != nil {
+		t.Fatalf("OpenReader() error = %v", err)
+	}
+	defer r.Close()
+
+	summaries, err := r.ListWorkflows(ctx)
+	if err != nil {
+		t.Fatalf("ListWorkflows() error = %v", err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("ListWorkflows() = %d summaries, want 2", len(summaries))
+	}
+	records, err := r.ListRunningWorkflowRecords(ctx)
+	if err != nil {
+		t.Fatalf("ListRunningWorkflowRecords() error = %v", err)
+	}
+	if len(records) != 1 || records[0].WorkflowID != "wf-reader-2" || records[0].LockHolderPID == nil {
+		t.Errorf("ListRunningWorkflowRecords() = %+v", records)
+	}
+
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM workflows"); err == nil {
+		t.Error("reader connection should not write")
+	}
+}
+
+func TestOpenReader_MissingDatabase(t *testing.T) {
+	t.Parallel()
+	_, err := OpenReader(filepath.Join(t.TempDir(), "state.json"))
+	if !os.IsNotExist(err) {
+		t.Errorf("OpenReader() error = %v, want not exist", err)
+	}
+}
@@ -56,6 +56,27 @@ var migrationV10 string
 //go:embed migrations/011_blueprint.sql
 var migrationV11 string
 
+//go:embed migrations/012_pr_babysit.sql
+var migrationV12 string
+
+//go:embed migrations/013_source_issue.sql
+var migrationV13 string
+
+//go:embed migrations/014_source_chat.sql
+var migrationV14 string
+
+//go:embed migrations/015_config_version.sql
+var migrationV15 string
+
+//go:embed migrations/016_repositories.sql
+var migrationV16 string
+
+//go:embed migrations/017_agent_substitutions.sql
+var migrationV17 string
+
+//go:embed migrations/018_task_retry_instructions.sql
+var migrationV18 string
+
 // SQLiteStateManager implements StateManager with SQLite storage.
 type SQLiteStateManager struct {
 	dbPath     string
@@ -229,6 +250,13 @@ var schemaMigrations = []schemaMigration{
 	{9, migrationV9, []string{"already exists", "duplicate column"}},
 	{10, migrationV10, []string{"already exists", "duplicate column"}},
 	{11, migrationV11, []string{"already exists", "no such column"}},
+	{12, migrationV12, []string{"already exists", "duplicate column"}},
+	{13, migrationV13, []string{"already exists", "duplicate column"}},
+	{14, migrationV14, []string{"already exists", "duplicate column"}},
+	{15, migrationV15, []string{"already exists", "duplicate column"}},
+	{16, migrationV16, []string{"already exists", "duplicate column"}},
+	{17, migrationV17, []string{"duplicate column"}},
+	{18, migrationV18, []string{"duplicate column"}},
 }
 
 // migrate runs pending migrations.
@@ -351,6 +379,52 @@ func (m *SQLiteStateManager) saveWithOptions(ctx context.Context, state *core.Wo
 		}
 	}
 
+	var prBabysitJSON []byte
+	if state.PRBabysit != nil {
+		prBabysitJSON, err = json.Marshal(state.PRBabysit)
+		if err != nil {
+			return fmt.Errorf("marshaling PR babysit: %w", err)
+		}
+	}
+
+	var sourceIssueJSON []byte
+	if state.SourceIssue != nil {
+		sourceIssueJSON, err = json.Marshal(state.SourceIssue)
+		if err != nil {
+			return fmt.Errorf("marshaling source issue: %w", err)
+		}
+	}
+
+	var sourceChatJSON []byte
+	if state.SourceChat != nil {
+		sourceChatJSON, err = json.Marshal(state.SourceChat)
+		if err != nil {
+			return fmt.Errorf("marshaling source chat: %w", err)
+		}
+	}
+
+	var repositoriesJSON, repositoryStatesJSON []byte
+	if len(state.Repositories) > 0 {
+		repositoriesJSON, err = json.Marshal(state.Repositories)
+		if err != nil {
+			return fmt.Errorf("marshaling repositories: %w", err)
+		}
+	}
+	if len(state.RepositoryStates) > 0 {
+		repositoryStatesJSON, err = json.Marshal(state.RepositoryStates)
+		if err != nil {
+			return fmt.Errorf("marshaling repository states: %w", err)
+		}
+	}
+
+	var agentSubstitutionsJSON []byte
+	if len(state.AgentSubstitutions) > 0 {
+		agentSubstitutionsJSON, err = json.Marshal(state.AgentSubstitutions)
+		if err != nil {
+			return fmt.Errorf("marshaling agent substitutions: %w", err)
+		}
+	}
+
 	// Calculate prompt hash for duplicate detection
 	promptHash := ""
 	if state.Prompt != "" {
@@ -366,8 +440,9 @@ func (m *SQLiteStateManager) saveWithOptions(ctx context.Context, state *core.Wo
 			agent_events, workflow_branch,
 			kanban_column, kanban_position, pr_url, pr_number,
 			kanban_started_at, kanban_completed_at, kanban_execution_count, kanban_last_error,
-			prompt_hash
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			prompt_hash, pr_babysit, source_issue, source_chat, config_version,
+			repositories, repository_states, agent_substitutions
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			version = excluded.version,
 			title = excluded.title,
@@ -391,7 +466,14 @@ func (m *SQLiteStateManager) saveWithOptions(ctx context.Context, state *core.Wo
 			kanban_completed_at = excluded.kanban_completed_at,
 			kanban_execution_count = excluded.kanban_execution_count,
 			kanban_last_error = excluded.kanban_last_error,
-			prompt_hash = excluded.prompt_hash
+			prompt_hash = excluded.prompt_hash,
+			pr_babysit = excluded.pr_babysit,
+			source_issue = excluded.source_issue,
+			source_chat = excluded.source_chat,
+			config_version = excluded.config_version,
+			repositories = excluded.repositories,
+			repository_states = excluded.repository_states,
+			agent_substitutions = excluded.agent_substitutions
 	`,
 		state.WorkflowID, state.Version, state.Title, state.Status, state.CurrentPhase,
 		state.Prompt, state.OptimizedPrompt, string(taskOrderJSON),
@@ -404,7 +486,11 @@ func (m *SQLiteStateManager) saveWithOptions(ctx context.Context, state *core.Wo
 		nullableString([]byte(state.PRURL)), state.PRNumber,
 		nullableTime(state.KanbanStartedAt), nullableTime(state.KanbanCompletedAt),
 		state.KanbanExecutionCount, nullableString([]byte(state.KanbanLastError)),
-		nullableString([]byte(promptHash)),
+		nullableString([]byte(promptHash)), nullableString(prBabysitJSON),
+		nullableString(sourceIssueJSON), nullableString(sourceChatJSON),
+		nullableInt(state.ConfigVersion),
+		nullableString(repositoriesJSON), nullableString(repositoryStatesJSON),
+		nullableString(agentSubstitutionsJSON),
 	)
 	if err != nil {
 		return fmt.Errorf("upserting workflow: %w", err)
@@ -495,8 +581,8 @@ func (m *SQLiteStateManager) insertTask(ctx context.Context, tx *sql.Tx, workflo
 				error, worktree_path, started_at, completed_at,
 				output, output_file, model_used, finish_reason, tool_calls,
 				last_commit, files_modified, branch, resumable, resume_hint,
-				merge_pending, merge_commit
-			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+				merge_pending, merge_commit, repository, retry_instructions
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`,
 		task.ID, workflowID, task.Phase, task.Name, nullableString([]byte(task.Description)), task.Status,
 		task.CLI, task.Model, string(depsJSON),
@@ -509,6 +595,7 @@ func (m *SQLiteStateManager) insertTask(ctx context.Context, tx *sql.Tx, workflo
 		nullableString([]byte(task.LastCommit)), nullableString(filesModifiedJSON),
 		nullableString([]byte(task.Branch)), resumableInt, nullableString([]byte(task.ResumeHint)),
 		mergePendingInt, nullableString([]byte(task.MergeCommit)),
+		nullableString([]byte(task.Repository)), nullableString([]byte(task.RetryInstructions)),
 	)
 	return err
 }
@@ -557,7 +644,9 @@ const workflowSelectQuery = `
 	       task_order, blueprint, metrics, checksum, created_at, updated_at, report_path,
 	       agent_events, workflow_branch,
 	       kanban_column, kanban_position, pr_url, pr_number,
-	       kanban_started_at, kanban_completed_at, kanban_execution_count, kanban_last_error
+	       kanban_started_at, kanban_completed_at, kanban_execution_count, kanban_last_error,
+	       pr_babysit, source_issue, source_chat, config_version,
+	       repositories, repository_states, agent_substitutions
 	FROM workflows WHERE id = ?
 `
 
@@ -568,6 +657,10 @@ type workflowNullableFields struct {
 	kanbanPosition, prNumber, kanbanExecutionCount               sql.NullInt64
 	kanbanStartedAt, kanbanCompletedAt                           sql.NullTime
 	taskOrderJSON, blueprintJSON, metricsJSON, agentEventsJSON   sql.NullString
+	prBabysitJSON, sourceIssueJSON, sourceChatJSON               sql.NullString
+	configVersion                                                sql.NullInt64
+	repositoriesJSON, repositoryStatesJSON                       sql.NullString
+	agentSubstitutionsJSON                                       sql.NullString
 }
 
 // applyNullableWorkflowFields maps nullable DB columns and JSON fields onto a WorkflowState.
@@ -602,6 +695,7 @@ func applyNullableWorkflowFields(state *core.WorkflowState, f *workflowNullableF
 		state.KanbanCompletedAt = &f.kanbanCompletedAt.Time
 	}
 	state.KanbanExecutionCount = int(f.kanbanExecutionCount.Int64)
+	state.ConfigVersion = int(f.configVersion.Int64)
 	if f.kanbanLastError.Valid {
 		state.KanbanLastError = f.kanbanLastError.String
 	}
@@ -629,6 +723,39 @@ func applyNullableWorkflowFields(state *core.WorkflowState, f *workflowNullableF
 			return fmt.Errorf("unmarshaling agent events: %w", err)
 		}
 	}
+	if f.prBabysitJSON.Valid && f.prBabysitJSON.String != "" {
+		state.PRBabysit = &core.PRBabysitState{}
+		if err := json.Unmarshal([]byte(f.prBabysitJSON.String), state.PRBabysit); err != nil {
+			return fmt.Errorf("unmarshaling PR babysit: %w", err)
+		}
+	}
+	if f.sourceIssueJSON.Valid && f.sourceIssueJSON.String != "" {
+		state.SourceIssue = &core.IssueLink{}
+		if err := json.Unmarshal([]byte(f.sourceIssueJSON.String), state.SourceIssue); err != nil {
+			return fmt.Errorf("unmarshaling source issue: %w", err)
+		}
+	}
+	if f.sourceChatJSON.Valid && f.sourceChatJSON.String != "" {
+		state.SourceChat = &core.ChatLink{}
+		if err := json.Unmarshal([]byte(f.sourceChatJSON.String), state.SourceChat); err != nil {
+			return fmt.Errorf("unmarshaling source chat: %w", err)
+		}
+	}
+	if f.repositoriesJSON.Valid && f.repositoriesJSON.String != "" {
+		if err := json.Unmarshal([]byte(f.repositoriesJSON.String), &state.Repositories); err != nil {
+			return fmt.Errorf("unmarshaling repositories: %w", err)
+		}
+	}
+	if f.repositoryStatesJSON.Valid && f.repositoryStatesJSON.String != "" {
+		if err := json.Unmarshal([]byte(f.repositoryStatesJSON.String), &state.RepositoryStates); err != nil {
+			return fmt.Errorf("unmarshaling repository states: %w", err)
+		}
+	}
+	if f.agentSubstitutionsJSON.Valid && f.agentSubstitutionsJSON.String != "" {
+		if err := json.Unmarshal([]byte(f.agentSubstitutionsJSON.String), &state.AgentSubstitutions); err != nil {
+			return fmt.Errorf("unmarshaling agent substitutions: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -646,7 +773,7 @@ func (m *SQLiteStateManager) loadTasksAndCheckpoints(ctx context.Context, q quer
 		       worktree_path, started_at, completed_at, output,
 		       output_file, model_used, finish_reason, tool_calls,
 		       last_commit, files_modified, branch, resumable, resume_hint,
-		       merge_pending, merge_commit
+		       merge_pending, merge_commit, repository, retry_instructions
 		FROM tasks WHERE workflow_id = ?
 	`, id)
 	if err != nil {
@@ -698,6 +825,8 @@ func (m *SQLiteStateManager) loadWorkflowByID(ctx context.Context, id core.Workf
 		&nf.checksum, &state.CreatedAt, &state.UpdatedAt, &nf.reportPath, &nf.agentEventsJSON, &nf.workflowBranch,
 		&nf.kanbanColumn, &nf.kanbanPosition, &nf.prURL, &nf.prNumber,
 		&nf.kanbanStartedAt, &nf.kanbanCompletedAt, &nf.kanbanExecutionCount, &nf.kanbanLastError,
+		&nf.prBabysitJSON, &nf.sourceIssueJSON, &nf.sourceChatJSON, &nf.configVersion,
+		&nf.repositoriesJSON, &nf.repositoryStatesJSON, &nf.agentSubstitutionsJSON,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -725,7 +854,7 @@ func (m *SQLiteStateManager) scanTask(rows *sql.Rows) (*core.TaskState, error) {
 	var lastCommit, filesModifiedJSON, branch, resumeHint sql.NullString
 	var resumable int
 	var mergePending sql.NullInt64
-	var mergeCommit sql.NullString
+	var mergeCommit, repository, retryInstructions sql.NullString
 
 	err := rows.Scan(
 		&task.ID, &task.Phase, &task.Name, &description, &task.Status,
@@ -734,7 +863,7 @@ func (m *SQLiteStateManager) scanTask(rows *sql.Rows) (*core.TaskState, error) {
 		&errorStr, &worktreePath, &startedAt, &completedAt,
 		&output, &outputFile, &modelUsed, &finishReason, &toolCallsJSON,
 		&lastCommit, &filesModifiedJSON, &branch, &resumable, &resumeHint,
-		&mergePending, &mergeCommit,
+		&mergePending, &mergeCommit, &repository, &retryInstructions,
 	)
 	if err != nil {
 		return nil, err
@@ -789,6 +918,12 @@ func (m *SQLiteStateManager) scanTask(rows *sql.Rows) (*core.TaskState, error) {
 	if mergeCommit.Valid {
 		task.MergeCommit = mergeCommit.String
 	}
+	if repository.Valid {
+		task.Repository = repository.String
+	}
+	if retryInstructions.Valid {
+		task.RetryInstructions = retryInstructions.String
+	}
 
 	if depsJSON.Valid && depsJSON.String != "" {
 		if err := json.Unmarshal([]byte(depsJSON.String), &task.Dependencies); err != nil {
@@ -834,12 +969,17 @@ func (m *SQLiteStateManager) scanCheckpoint(rows *sql.Rows) (*core.Checkpoint, e
 func (m *SQLiteStateManager) ListWorkflows(ctx context.Context) ([]core.WorkflowSummary, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
+	return listWorkflowSummaries(ctx, m.readDB)
+}
 
+// listWorkflowSummaries returns the summaries of all workflows, most recently
+// updated first.
+func listWorkflowSummaries(ctx context.Context, db *sql.DB) ([]core.WorkflowSummary, error) {
 	// Get active workflow ID using read connection
 	var activeID sql.NullString
-	_ = m.readDB.QueryRowContext(ctx, "SELECT workflow_id FROM active_workflow WHERE id = 1").Scan(&activeID)
+	_ = db.QueryRowContext(ctx, "SELECT workflow_id FROM active_workflow WHERE id = 1").Scan(&activeID)
 
-	rows, err := m.readDB.QueryContext(ctx, `
+	rows, err := db.QueryContext(ctx, `
 		SELECT id, title, status, current_phase, prompt, created_at, updated_at
 		FROM workflows
 		ORDER BY updated_at DESC
@@ -1344,6 +1484,58 @@ func (m *SQLiteStateManager) ListRunningWorkflows(ctx context.Context) ([]core.W
 	return ids, rows.Err()
 }
 
+// ListRunningWorkflowRecords returns the running_workflows rows, oldest first.
+func (m *SQLiteStateManager) ListRunningWorkflowRecords(ctx context.Context) ([]core.RunningWorkflowRecord, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return listRunningWorkflowRecords(ctx, m.readDB)
+}
+
+func listRunningWorkflowRecords(ctx context.Context, db *sql.DB) ([]core.RunningWorkflowRecord, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT workflow_id, started_at, lock_holder_pid, lock_holder_host, heartbeat_at
+		FROM running_workflows
+		ORDER BY started_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("querying running workflows: %w", err)
+	}
+	defer rows.Close()
+
+	var records []core.RunningWorkflowRecord
+	for rows.Next() {
+		var id string
+		var startedAt sql.NullTime
+		var lockPID sql.NullInt64
+		var lockHost sql.NullString
+		var heartbeatAt sql.NullTime
+		if err := rows.Scan(&id, &startedAt, &lockPID, &lockHost, &heartbeatAt); err != nil {
+			return nil, fmt.Errorf("scanning running workflow: %w", err)
+		}
+		records = append(records, newRunningWorkflowRecord(id, startedAt, lockPID, lockHost, heartbeatAt))
+	}
+	return records, rows.Err()
+}
+
+func newRunningWorkflowRecord(id string, startedAt sql.NullTime, lockPID sql.NullInt64, lockHost sql.NullString, heartbeatAt sql.NullTime) core.RunningWorkflowRecord {
+	record := core.RunningWorkflowRecord{
+		WorkflowID:     core.WorkflowID(id),
+		LockHolderHost: lockHost.String,
+	}
+	if lockPID.Valid {
+		p := int(lockPID.Int64)
+		record.LockHolderPID = &p
+	}
+	if heartbeatAt.Valid {
+		hb := heartbeatAt.Time
+		record.HeartbeatAt = &hb
+	}
+	if startedAt.Valid {
+		record.StartedAt = startedAt.Time
+	}
+	return record
+}
+
 // GetRunningWorkflowRecord returns the running_workflows row for a given workflow ID.
 // Returns (nil, nil) when the workflow is not marked as running.
 func (m *SQLiteStateManager) GetRunningWorkflowRecord(ctx context.Context, workflowID core.WorkflowID) (*core.RunningWorkflowRecord, error) {
@@ -1368,29 +1560,8 @@ func (m *SQLiteStateManager) GetRunningWorkflowRecord(ctx context.Context, workf
 		return nil, fmt.Errorf("querying running_workflows: %w", err)
 	}
 
-	var pidPtr *int
-	if lockPID.Valid {
-		p := int(lockPID.Int64)
-		pidPtr = &p
-	}
-
-	var heartbeatPtr *time.Time
-	if heartbeatAt.Valid {
-		hb := heartbeatAt.Time
-		heartbeatPtr = &hb
-	}
-
-	record := &core.RunningWorkflowRecord{
-		WorkflowID:     core.WorkflowID(id),
-		LockHolderPID:  pidPtr,
-		LockHolderHost: lockHost.String,
-		HeartbeatAt:    heartbeatPtr,
-	}
-	if startedAt.Valid {
-		record.StartedAt = startedAt.Time
-	}
-
-	return record, nil
+	record := newRunningWorkflowRecord(id, startedAt, lockPID, lockHost, heartbeatAt)
+	return &record, nil
 }
 
 // IsWorkflowRunning checks if a specific workflow is currently executing.
@@ -1612,6 +1783,13 @@ func nullableTime(t *time.Time) sql.NullTime {
 	return sql.NullTime{Time: *t, Valid: true}
 }
 
+func nullableInt(n int) sql.NullInt64 {
+	if n == 0 {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(n), Valid: true}
+}
+
 // DeactivateWorkflow clears the active workflow without deleting any data.
 func (m *SQLiteStateManager) DeactivateWorkflow(ctx context.Context) error {
 	m.mu.Lock()
@@ -2204,6 +2382,8 @@ func (a *sqliteAtomicContext) LoadByID(id core.WorkflowID) (*core.WorkflowState,
 		&nf.checksum, &state.CreatedAt, &state.UpdatedAt, &nf.reportPath, &nf.agentEventsJSON, &nf.workflowBranch,
 		&nf.kanbanColumn, &nf.kanbanPosition, &nf.prURL, &nf.prNumber,
 		&nf.kanbanStartedAt, &nf.kanbanCompletedAt, &nf.kanbanExecutionCount, &nf.kanbanLastError,
+		&nf.prBabysitJSON, &nf.sourceIssueJSON, &nf.sourceChatJSON, &nf.configVersion,
+		&nf.repositoriesJSON, &nf.repositoryStatesJSON, &nf.agentSubstitutionsJSON,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -2262,14 +2442,62 @@ func (a *sqliteAtomicContext) Save(state *core.WorkflowState) error {
 		}
 	}
 
+	var prBabysitJSON []byte
+	if state.PRBabysit != nil {
+		prBabysitJSON, err = json.Marshal(state.PRBabysit)
+		if err != nil {
+			return fmt.Errorf("marshaling PR babysit: %w", err)
+		}
+	}
+
+	var sourceIssueJSON []byte
+	if state.SourceIssue != nil {
+		sourceIssueJSON, err = json.Marshal(state.SourceIssue)
+		if err != nil {
+			return fmt.Errorf("marshaling source issue: %w", err)
+		}
+	}
+
+	var sourceChatJSON []byte
+	if state.SourceChat != nil {
+		sourceChatJSON, err = json.Marshal(state.SourceChat)
+		if err != nil {
+			return fmt.Errorf("marshaling source chat: %w", err)
+		}
+	}
+
+	var repositoriesJSON, repositoryStatesJSON []byte
+	if len(state.Repositories) > 0 {
+		repositoriesJSON, err = json.Marshal(state.Repositories)
+		if err != nil {
+			return fmt.Errorf("marshaling repositories: %w", err)
+		}
+	}
+	if len(state.RepositoryStates) > 0 {
+		repositoryStatesJSON, err = json.Marshal(state.RepositoryStates)
+		if err != nil {
+			return fmt.Errorf("marshaling repository states: %w", err)
+		}
+	}
+
+	var agentSubstitutionsJSON []byte
+	if len(state.AgentSubstitutions) > 0 {
+		agentSubstitutionsJSON, err = json.Marshal(state.AgentSubstitutions)
+		if err != nil {
+			return fmt.Errorf("marshaling agent substitutions: %w", err)
+		}
+	}
+
 	_, err = a.tx.ExecContext(a.ctx, `
 		INSERT INTO workflows (
 			id, version, title, status, current_phase, prompt, optimized_prompt,
 			task_order, blueprint, metrics, checksum, created_at, updated_at, report_path,
 			agent_events, workflow_branch,
 			kanban_column, kanban_position, pr_url, pr_number,
-			kanban_started_at, kanban_completed_at, kanban_execution_count, kanban_last_error
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			kanban_started_at, kanban_completed_at, kanban_execution_count, kanban_last_error,
+			pr_babysit, source_issue, source_chat, config_version,
+			repositories, repository_states, agent_substitutions
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			version = excluded.version,
 			title = excluded.title,
@@ -2292,7 +2520,14 @@ func (a *sqliteAtomicContext) Save(state *core.WorkflowState) error {
 			kanban_started_at = excluded.kanban_started_at,
 			kanban_completed_at = excluded.kanban_completed_at,
 			kanban_execution_count = excluded.kanban_execution_count,
-			kanban_last_error = excluded.kanban_last_error
+			kanban_last_error = excluded.kanban_last_error,
+			pr_babysit = excluded.pr_babysit,
+			source_issue = excluded.source_issue,
+			source_chat = excluded.source_chat,
+			config_version = excluded.config_version,
+			repositories = excluded.repositories,
+			repository_states = excluded.repository_states,
+			agent_substitutions = excluded.agent_substitutions
 	`,
 		state.WorkflowID, state.Version, state.Title, state.Status, state.CurrentPhase,
 		state.Prompt, state.OptimizedPrompt, string(taskOrderJSON),
@@ -2305,6 +2540,10 @@ func (a *sqliteAtomicContext) Save(state *core.WorkflowState) error {
 		nullableString([]byte(state.PRURL)), state.PRNumber,
 		nullableTime(state.KanbanStartedAt), nullableTime(state.KanbanCompletedAt),
 		state.KanbanExecutionCount, nullableString([]byte(state.KanbanLastError)),
+		nullableString(prBabysitJSON), nullableString(sourceIssueJSON),
+		nullableString(sourceChatJSON), nullableInt(state.ConfigVersion),
+		nullableString(repositoriesJSON), nullableString(repositoryStatesJSON),
+		nullableString(agentSubstitutionsJSON),
 	)
 	if err != nil {
 		return fmt.Errorf("upserting workflow: %w", err)
@@ -704,6 +704,48 @@ func TestSave_KanbanFields(t *testing.T) {
 	}
 }
 
+func TestSave_PRBabysit(t *testing.T) {
+	t.Parallel()
+	m := newTestManager(t)
+	ctx := context.Background()
+
+	now := time.Now().Truncate(time.Second)
+	wf := makeWorkflow("wf-babysit", core.WorkflowStatusRunning)
+	wf.PRBabysit = &core.PRBabysitState{
+		PRNumber: 42,
+		Status:   core.PRBabysitExhausted,
+		Summary:  "Checks still failing on PR #42 after 1 fix attempt(s): test",
+		Attempts: []core.PRBabysitAttempt{{
+			Attempt:      1,
+			FailedChecks: []string{"test"},
+			LogExcerpt:   "FAIL: TestLogin",
+			Agent:        "claude",
+			CommitSHA:    "abc123",
+			StartedAt:    now,
+			CompletedAt:  &now,
+		}},
+		UpdatedAt: now,
+	}
+
+	if err := m.Save(ctx, wf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := m.LoadByID(ctx, "wf-babysit")
+	if err != nil {
+		t.Fatalf("LoadByID: %v", err)
+	}
+	if loaded.PRBabysit == nil {
+		t.Fatal("PRBabysit should not be nil")
+	}
+	if loaded.PRBabysit.Status != core.PRBabysitExhausted || loaded.PRBabysit.PRNumber != 42 {
+		t.Errorf("PRBabysit = %+v", loaded.PRBabysit)
+	}
+	if len(loaded.PRBabysit.Attempts) != 1 || loaded.PRBabysit.Attempts[0].CommitSHA != "abc123" {
+		t.Errorf("Attempts = %+v", loaded.PRBabysit.Attempts)
+	}
+}
+
 // ============================================================================
 // Save with OptimizedPrompt and ReportPath
 // ============================================================================
@@ -1977,3 +2019,146 @@ func TestConcurrentReadWrite(t *testing.T) {
 		t.Errorf("concurrent operation failed: %v", err)
 	}
 }
+
+func TestSave_SourceIssue(t *testing.T) {
+	t.Parallel()
+	m := newTestManager(t)
+	ctx := context.Background()
+
+	wf := makeWorkflow("wf-issue", core.WorkflowStatusPending)
+	wf.SourceIssue = &core.IssueLink{
+		Provider:   core.IssueProviderGitHub,
+		Repository: "acme/widgets",
+		Number:     17,
+		URL:        "https://github.com/acme/widgets/issues/17",
+		Title:      "Login fails",
+	}
+
+	if err := m.Save(ctx, wf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := m.LoadByID(ctx, "wf-issue")
+	if err != nil {
+		t.Fatalf("LoadByID: %v", err)
+	}
+	if loaded.SourceIssue == nil {
+		t.Fatal("SourceIssue should not be nil")
+	}
+	if *loaded.SourceIssue != *wf.SourceIssue {
+		t.Errorf("SourceIssue = %+v, want %+v", loaded.SourceIssue, wf.SourceIssue)
+	}
+}
+
+func TestSave_SourceChatAndConfigVersion(t *testing.T) {
+	t.Parallel()
+	m := newTestManager(t)
+	ctx := context.Background()
+
+	wf := makeWorkflow("wf-chat", core.WorkflowStatusPending)
+	wf.SourceChat = &core.ChatLink{
+		SessionID:            "chat-1",
+		Title:                "Retry strategy",
+		TranscriptAttachment: "att-1",
+	}
+	wf.ConfigVersion = 7
+
+	if err := m.Save(ctx, wf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := m.LoadByID(ctx, "wf-chat")
+	if err != nil {
+		t.Fatalf("LoadByID: %v", err)
+	}
+	if loaded.SourceChat == nil || *loaded.SourceChat != *wf.SourceChat {
+		t.Errorf("SourceChat = %+v, want %+v", loaded.SourceChat, wf.SourceChat)
+	}
+	if loaded.ConfigVersion != 7 {
+		t.Errorf("ConfigVersion = %d, want 7", loaded.ConfigVersion)
+	}
+}
+
+func TestSave_Repositories(t *testing.T) {
+	t.Parallel()
+	m := newTestManager(t)
+	ctx := context.Background()
+
+	wf := makeWorkflow("wf-repos", core.WorkflowStatusRunning)
+	wf.Repositories = []core.WorkflowRepository{
+		{Name: "client", ProjectID: "proj-client", Path: "/src/client"},
+	}
+	wf.RepositoryStates = map[string]*core.RepositoryState{
+		"client": {WorkflowBranch: "quorum/wf-repos", PRURL: "https://github.com/o/client/pull/3", PRNumber: 3},
+	}
+	wf.Tasks["task-1"] = &core.TaskState{ID: "task-1", Phase: core.PhaseExecute, Name: "Update client", Repository: "client"}
+	wf.TaskOrder = []core.TaskID{"task-1"}
+
+	if err := m.Save(ctx, wf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := m.LoadByID(ctx, "wf-repos")
+	if err != nil {
+		t.Fatalf("LoadByID: %v", err)
+	}
+	if len(loaded.Repositories) != 1 || loaded.Repositories[0] != wf.Repositories[0] {
+		t.Errorf("Repositories = %+v, want %+v", loaded.Repositories, wf.Repositories)
+	}
+	if got := loaded.RepositoryStates["client"]; got == nil || *got != *wf.RepositoryStates["client"] {
+		t.Errorf("RepositoryStates[client] = %+v, want %+v", got, wf.RepositoryStates["client"])
+	}
+	if got := loaded.Tasks["task-1"].Repository; got != "client" {
+		t.Errorf("task Repository = %q, want client", got)
+	}
+}
+
+func TestSave_TaskRetryInstructions(t *testing.T) {
+	t.Parallel()
+	m := newTestManager(t)
+	ctx := context.Background()
+
+	wf := makeWorkflow("wf-retry", core.WorkflowStatusFailed)
+	wf.Tasks["task-1"] = &core.TaskState{
+		ID: "task-1", Phase: core.PhaseExecute, Name: "Fix tests", Status: core.TaskStatusPending,
+		RetryInstructions: "Run the tests before committing.",
+	}
+	wf.TaskOrder = []core.TaskID{"task-1"}
+
+	if err := m.Save(ctx, wf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := m.LoadByID(ctx, "wf-retry")
+	if err != nil {
+		t.Fatalf("LoadByID: %v", err)
+	}
+	if got := loaded.Tasks["task-1"].RetryInstructions; got != "Run the tests before committing." {
+		t.Errorf("task RetryInstructions = %q", got)
+	}
+}
+
+func TestSave_AgentSubstitutions(t *testing.T) {
+	t.Parallel()
+	m := newTestManager(t)
+	ctx := context.Background()
+
+	wf := makeWorkflow("wf-subs", core.WorkflowStatusRunning)
+	wf.AgentSubstitutions = []core.AgentSubstitution{{
+		Agent: "claude", Fallback: "gemini", Phase: core.PhaseAnalyze,
+		Reason: "1 authentication errors", At: time.Now().UTC().Truncate(time.Second),
+	}}
+
+	if err := m.Save(ctx, wf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := m.LoadByID(ctx, "wf-subs")
+	if err != nil {
+		t.Fatalf("LoadByID: %v", err)
+	}
+	if len(loaded.AgentSubstitutions) != 1 || !loaded.AgentSubstitutions[0].At.Equal(wf.AgentSubstitutions[0].At) ||
+		loaded.AgentSubstitutions[0].Fallback != "gemini" || loaded.AgentSubstitutions[0].Phase != core.PhaseAnalyze {
+		t.Errorf("AgentSubstitutions = %+v, want %+v", loaded.AgentSubstitutions, wf.AgentSubstitutions)
+	}
+}
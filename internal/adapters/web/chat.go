@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -17,8 +18,12 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/attachments"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/chatcontext"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/chatpromote"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/chatquorum"
 )
 
 // ChatMessage represents a message in a chat conversation.
@@ -30,6 +35,34 @@ type ChatMessage struct {
 	Content   string     `json:"content"`
 	Timestamp time.Time  `json:"timestamp"`
 	Tokens    *TokenInfo `json:"tokens,omitempty"`
+	// Model is the model that produced an agent message.
+	Model string `json:"model,omitempty"`
+	// Attachments are the file paths sent along with a user message.
+	Attachments []string `json:"attachments,omitempty"`
+	// Summarized is set on messages that are sent to agents only through the
+	// session's rolling summary.
+	Summarized bool `json:"summarized,omitempty"`
+	// Context describes the conversation context an agent message was produced with.
+	Context *ContextInfo `json:"context,omitempty"`
+	// GroupID links a question asked to several agents with their answers
+	// and the moderator's synthesis.
+	GroupID string `json:"group_id,omitempty"`
+	// Consensus is the agreement score (0-1) of a synthesis message.
+	Consensus *float64 `json:"consensus,omitempty"`
+}
+
+// ContextInfo describes how the conversation history was fitted into the
+// agent's context window.
+type ContextInfo struct {
+	SummarizedThrough  string `json:"summarized_through,omitempty"`
+	SummarizedMessages int    `json:"summarized_messages,omitempty"`
+	OmittedMessages    int    `json:"omitted_messages,omitempty"`
+	TruncatedMessages  int    `json:"truncated_messages,omitempty"`
+	EstimatedTokens    int    `json:"estimated_tokens"`
+	BudgetTokens       int    `json:"budget_tokens"`
+	Notice             string `json:"notice,omitempty"`
+	// SummaryError reports why older messages could not be summarized.
+	SummaryError string `json:"summary_error,omitempty"`
 }
 
 // TokenInfo contains token usage information.
@@ -48,6 +81,8 @@ type ChatSession struct {
 	Model        string        `json:"model,omitempty"`
 	MessageCount int           `json:"message_count"`
 	Messages     []ChatMessage `json:"messages,omitempty"`
+	// WorkflowID is the workflow the session was promoted into.
+	WorkflowID string `json:"workflow_id,omitempty"`
 }
 
 // SendMessageRequest is the request body for sending a chat message.
@@ -65,6 +100,54 @@ type SendMessageResponse struct {
 	AgentMessage ChatMessage `json:"agent_message"`
 }
 
+// AskAllRequest is the request body for asking several agents the same question.
+type AskAllRequest struct {
+	Content string `json:"content"`
+	// Agents are the agents asked; defaults to chat.ask_all.agents, then every enabled agent.
+	Agents []string `json:"agents,omitempty"`
+	// Moderator synthesizes the answers; defaults to chat.ask_all.moderator, then the first agent.
+	Moderator       string   `json:"moderator,omitempty"`
+	ReasoningEffort string   `json:"reasoning_effort,omitempty"`
+	Attachments     []string `json:"attachments,omitempty"`
+}
+
+// AskAllResponse is the response for asking several agents the same question.
+type AskAllResponse struct {
+	GroupID     string      `json:"group_id"`
+	UserMessage ChatMessage `json:"user_message"`
+	// Responses holds one message per agent, in the order asked. Agents that
+	// failed are reported as system messages.
+	Responses []ChatMessage `json:"responses"`
+	// Synthesis is the moderator's answer; nil when fewer than two agents answered.
+	Synthesis *ChatMessage `json:"synthesis,omitempty"`
+}
+
+// PromoteRequest is the request body for promoting a chat session into a workflow.
+type PromoteRequest struct {
+	// Prompt is the reviewed workflow prompt; a draft is generated when empty.
+	Prompt string `json:"prompt,omitempty"`
+	// Title is the workflow title; defaults to the drafted title, then the session title.
+	Title string `json:"title,omitempty"`
+	// DraftOnly returns the draft for review without creating the workflow.
+	DraftOnly bool `json:"draft_only,omitempty"`
+	// Agent drafts the prompt; defaults to chat.context.summary_agent, then the session agent.
+	Agent string `json:"agent,omitempty"`
+	Model string `json:"model,omitempty"`
+	// KanbanColumn is "refinement" (default) or "todo".
+	KanbanColumn string `json:"kanban_column,omitempty"`
+}
+
+// PromoteResponse is the response for promoting a chat session. WorkflowID is
+// empty for a draft.
+type PromoteResponse struct {
+	Title      string     `json:"title"`
+	Prompt     string     `json:"prompt"`
+	Agent      string     `json:"agent,omitempty"`
+	Model      string     `json:"model,omitempty"`
+	Tokens     *TokenInfo `json:"tokens,omitempty"`
+	WorkflowID string     `json:"workflow_id,omitempty"`
+}
+
 // CreateSessionRequest is the request body for creating a chat session.
 type CreateSessionRequest struct {
 	Agent string `json:"agent,omitempty"`
@@ -93,18 +176,27 @@ type AttachmentStoreResolver func(ctx context.Context) *attachments.Store
 // ProjectRootResolver is a function that returns the project root directory for the current request context.
 type ProjectRootResolver func(ctx context.Context) string
 
+// ChatConfigResolver is a function that returns the chat settings for the current request context.
+type ChatConfigResolver func(ctx context.Context) config.ChatConfig
+
+// WorkflowCreator creates a pending workflow from a chat conversation in the
+// given Kanban column, for the project of the request context.
+type WorkflowCreator func(ctx context.Context, conv *chatpromote.Conversation, prompt, column string) (core.WorkflowID, error)
+
 // ChatHandler handles chat-related HTTP requests.
 type ChatHandler struct {
 	mu                      sync.RWMutex
 	agents                  core.AgentRegistry
 	eventBus                *events.EventBus
 	sessions                map[string]*chatSessionState
-	loadedProjectRoots      map[string]bool // best-effort cache to avoid reloading persisted sessions repeatedly
 	attachmentStore         *attachments.Store
 	attachmentStoreResolver AttachmentStoreResolver // Per-request attachment store resolver
 	chatStore               core.ChatStore          // Fallback global store
 	chatStoreResolver       ChatStoreResolver       // Per-request store resolver
 	projectRootResolver     ProjectRootResolver     // Per-request project root resolver
+	chatConfigResolver      ChatConfigResolver
+	workflowCreator         WorkflowCreator
+	contextBuilder          *chatcontext.Builder
 }
 
 // chatSessionState holds the internal state of a chat session.
@@ -115,6 +207,8 @@ type chatSessionState struct {
 	model       string
 	title       string
 	projectRoot string // Directory where .quorum is located, for file access scoping
+	// summarizedThrough is the ID of the last message covered by the rolling summary.
+	summarizedThrough string
 }
 
 // ChatHandlerOption is a functional option for configuring ChatHandler.
@@ -141,15 +235,30 @@ func WithProjectRootResolver(resolver ProjectRootResolver) ChatHandlerOption {
 	}
 }
 
+// WithChatConfigResolver sets the resolver for the chat settings (history
+// budget, summarization and ask-all defaults).
+func WithChatConfigResolver(resolver ChatConfigResolver) ChatHandlerOption {
+	return func(h *ChatHandler) {
+		h.chatConfigResolver = resolver
+	}
+}
+
+// WithWorkflowCreator enables promoting chat sessions into workflows.
+func WithWorkflowCreator(creator WorkflowCreator) ChatHandlerOption {
+	return func(h *ChatHandler) {
+		h.workflowCreator = creator
+	}
+}
+
 // NewChatHandler creates a new ChatHandler.
 func NewChatHandler(agents core.AgentRegistry, eventBus *events.EventBus, attachmentStore *attachments.Store, chatStore core.ChatStore, opts ...ChatHandlerOption) *ChatHandler {
 	h := &ChatHandler{
-		agents:             agents,
-		eventBus:           eventBus,
-		sessions:           make(map[string]*chatSessionState),
-		loadedProjectRoots: make(map[string]bool),
-		attachmentStore:    attachmentStore,
-		chatStore:          chatStore,
+		agents:          agents,
+		eventBus:        eventBus,
+		sessions:        make(map[string]*chatSessionState),
+		attachmentStore: attachmentStore,
+		chatStore:       chatStore,
+		contextBuilder:  chatcontext.NewBuilder(agents),
 	}
 
 	// Apply options
@@ -165,50 +274,46 @@ func NewChatHandler(agents core.AgentRegistry, eventBus *events.EventBus, attach
 	return h
 }
 
-// ensureProjectSessionsLoaded loads persisted sessions for a project root into memory if needed.
+// ensureProjectSessionsLoaded merges persisted sessions for a project root into memory.
 // This is necessary because in multi-project mode, the ChatStore is resolved per request and cannot
-// be loaded at process startup.
+// be loaded at process startup, and because sessions can be created or continued from the
+// terminal (quorum chat) while the server is running.
 func (h *ChatHandler) ensureProjectSessionsLoaded(ctx context.Context, projectRoot string) {
 	if projectRoot == "" {
 		projectRoot = h.getProjectRoot(ctx)
 	}
 
-	h.mu.RLock()
-	alreadyLoaded := h.loadedProjectRoots[projectRoot]
-	h.mu.RUnlock()
-	if alreadyLoaded {
-		return
-	}
-
 	store := h.getChatStore(ctx)
 	if store == nil {
 		return
 	}
 
 	h.loadPersistedSessionsFromStore(ctx, store, projectRoot)
-
-	h.mu.Lock()
-	h.loadedProjectRoots[projectRoot] = true
-	h.mu.Unlock()
 }
 
 // ensureSessionLoaded ensures a session exists in memory by loading it from the resolved ChatStore on-demand.
+// Sessions already in memory are refreshed when the store holds a newer version, e.g. after
+// the conversation was continued from the terminal.
 func (h *ChatHandler) ensureSessionLoaded(ctx context.Context, sessionID string) bool {
 	h.mu.RLock()
-	_, ok := h.sessions[sessionID]
-	h.mu.RUnlock()
+	existing, ok := h.sessions[sessionID]
+	var updatedAt time.Time
 	if ok {
-		return true
+		updatedAt = existing.session.UpdatedAt
 	}
+	h.mu.RUnlock()
 
 	store := h.getChatStore(ctx)
 	if store == nil {
-		return false
+		return ok
 	}
 
 	sess, err := store.LoadSession(ctx, sessionID)
 	if err != nil || sess == nil {
-		return false
+		return ok
+	}
+	if ok && !sess.UpdatedAt.After(updatedAt) {
+		return true
 	}
 
 	projectRoot := sess.ProjectRoot
@@ -224,18 +329,7 @@ func (h *ChatHandler) ensureSessionLoaded(ctx context.Context, sessionID string)
 
 	chatMessages := make([]ChatMessage, 0, len(messages))
 	for _, msg := range messages {
-		chatMessages = append(chatMessages, ChatMessage{
-			ID:        msg.ID,
-			SessionID: msg.SessionID,
-			Role:      msg.Role,
-			Agent:     msg.Agent,
-			Content:   msg.Content,
-			Timestamp: msg.Timestamp,
-			Tokens: &TokenInfo{
-				Input:  msg.TokensIn,
-				Output: msg.TokensOut,
-			},
-		})
+		chatMessages = append(chatMessages, chatMessageFromState(msg))
 	}
 
 	state := &chatSessionState{
@@ -247,17 +341,21 @@ func (h *ChatHandler) ensureSessionLoaded(ctx context.Context, sessionID string)
 			Agent:        sess.Agent,
 			Model:        sess.Model,
 			MessageCount: len(chatMessages),
+			WorkflowID:   sess.WorkflowID,
 		},
 		messages:    chatMessages,
 		agent:       sess.Agent,
 		model:       sess.Model,
 		title:       sess.Title,
 		projectRoot: projectRoot,
+		// Summaries are written after the message that triggered them, so they
+		// do not affect UpdatedAt; always read the current one.
+		summarizedThrough: loadSummarizedThrough(ctx, store, sess.ID),
 	}
 
 	h.mu.Lock()
 	// Re-check under lock to avoid overwriting newer in-memory state.
-	if _, exists := h.sessions[sessionID]; !exists {
+	if current, exists := h.sessions[sessionID]; !exists || current.session.UpdatedAt.Before(sess.UpdatedAt) {
 		h.sessions[sessionID] = state
 	}
 	h.mu.Unlock()
@@ -277,6 +375,14 @@ func (h *ChatHandler) getChatStore(ctx context.Context) core.ChatStore {
 	return h.chatStore
 }
 
+// getChatConfig returns the chat settings for the request context.
+func (h *ChatHandler) getChatConfig(ctx context.Context) config.ChatConfig {
+	if h.chatConfigResolver != nil {
+		return h.chatConfigResolver(ctx)
+	}
+	return config.ChatConfig{}
+}
+
 // getAttachmentStore returns the attachment Store for the given context.
 // If a resolver is configured and returns a non-nil store, that is used.
 // Otherwise, falls back to the global attachmentStore.
@@ -321,18 +427,7 @@ func (h *ChatHandler) loadPersistedSessions() {
 		// Convert persisted messages to ChatMessage
 		chatMessages := make([]ChatMessage, 0, len(messages))
 		for _, msg := range messages {
-			chatMessages = append(chatMessages, ChatMessage{
-				ID:        msg.ID,
-				SessionID: msg.SessionID,
-				Role:      msg.Role,
-				Agent:     msg.Agent,
-				Content:   msg.Content,
-				Timestamp: msg.Timestamp,
-				Tokens: &TokenInfo{
-					Input:  msg.TokensIn,
-					Output: msg.TokensOut,
-				},
-			})
+			chatMessages = append(chatMessages, chatMessageFromState(msg))
 		}
 
 		h.sessions[sess.ID] = &chatSessionState{
@@ -344,12 +439,14 @@ func (h *ChatHandler) loadPersistedSessions() {
 				Agent:        sess.Agent,
 				Model:        sess.Model,
 				MessageCount: len(chatMessages),
+				WorkflowID:   sess.WorkflowID,
 			},
-			messages:    chatMessages,
-			agent:       sess.Agent,
-			model:       sess.Model,
-			title:       sess.Title,
-			projectRoot: sess.ProjectRoot,
+			messages:          chatMessages,
+			agent:             sess.Agent,
+			model:             sess.Model,
+			title:             sess.Title,
+			projectRoot:       sess.ProjectRoot,
+			summarizedThrough: loadSummarizedThrough(ctx, h.chatStore, sess.ID),
 		}
 	}
 }
@@ -372,6 +469,15 @@ func (h *ChatHandler) loadPersistedSessionsFromStore(ctx context.Context, store
 			sess.ProjectRoot = projectRoot
 		}
 
+		// Skip sessions whose in-memory copy is already up to date.
+		h.mu.RLock()
+		existing, exists := h.sessions[sess.ID]
+		upToDate := exists && !sess.UpdatedAt.After(existing.session.UpdatedAt)
+		h.mu.RUnlock()
+		if upToDate {
+			continue
+		}
+
 		messages, err := store.LoadMessages(ctx, sess.ID)
 		if err != nil {
 			continue
@@ -379,18 +485,7 @@ func (h *ChatHandler) loadPersistedSessionsFromStore(ctx context.Context, store
 
 		chatMessages := make([]ChatMessage, 0, len(messages))
 		for _, msg := range messages {
-			chatMessages = append(chatMessages, ChatMessage{
-				ID:        msg.ID,
-				SessionID: msg.SessionID,
-				Role:      msg.Role,
-				Agent:     msg.Agent,
-				Content:   msg.Content,
-				Timestamp: msg.Timestamp,
-				Tokens: &TokenInfo{
-					Input:  msg.TokensIn,
-					Output: msg.TokensOut,
-				},
-			})
+			chatMessages = append(chatMessages, chatMessageFromState(msg))
 		}
 
 		loaded := &chatSessionState{
@@ -402,16 +497,18 @@ func (h *ChatHandler) loadPersistedSessionsFromStore(ctx context.Context, store
 				Agent:        sess.Agent,
 				Model:        sess.Model,
 				MessageCount: len(chatMessages),
+				WorkflowID:   sess.WorkflowID,
 			},
-			messages:    chatMessages,
-			agent:       sess.Agent,
-			model:       sess.Model,
-			title:       sess.Title,
-			projectRoot: sess.ProjectRoot,
+			messages:          chatMessages,
+			agent:             sess.Agent,
+			model:             sess.Model,
+			title:             sess.Title,
+			projectRoot:       sess.ProjectRoot,
+			summarizedThrough: loadSummarizedThrough(ctx, store, sess.ID),
 		}
 
 		h.mu.Lock()
-		existing, exists := h.sessions[sess.ID]
+		existing, exists = h.sessions[sess.ID]
 		if !exists {
 			h.sessions[sess.ID] = loaded
 		} else if len(existing.messages) < len(loaded.messages) || existing.session.UpdatedAt.Before(loaded.session.UpdatedAt) {
@@ -422,6 +519,80 @@ func (h *ChatHandler) loadPersistedSessionsFromStore(ctx context.Context, store
 	}
 }
 
+// chatMessageFromState converts a persisted message to its API representation.
+func chatMessageFromState(msg *core.ChatMessageState) ChatMessage {
+	return ChatMessage{
+		ID:        msg.ID,
+		SessionID: msg.SessionID,
+		Role:      msg.Role,
+		Agent:     msg.Agent,
+		Content:   msg.Content,
+		Timestamp: msg.Timestamp,
+		Tokens: &TokenInfo{
+			Input:  msg.TokensIn,
+			Output: msg.TokensOut,
+		},
+		Model:       msg.Model,
+		Attachments: msg.Attachments,
+		GroupID:     msg.GroupID,
+		Consensus:   msg.Consensus,
+	}
+}
+
+// chatMessageState converts a message to its persisted representation.
+func chatMessageState(msg ChatMessage) *core.ChatMessageState {
+	state := &core.ChatMessageState{
+		ID:          msg.ID,
+		SessionID:   msg.SessionID,
+		Role:        msg.Role,
+		Agent:       msg.Agent,
+		Content:     msg.Content,
+		Timestamp:   msg.Timestamp,
+		Model:       msg.Model,
+		Attachments: msg.Attachments,
+		GroupID:     msg.GroupID,
+		Consensus:   msg.Consensus,
+	}
+	if msg.Tokens != nil {
+		state.TokensIn = msg.Tokens.Input
+		state.TokensOut = msg.Tokens.Output
+	}
+	return state
+}
+
+// loadSummarizedThrough returns the ID of the last message covered by the
+// session's rolling summary, if the store keeps summaries.
+func loadSummarizedThrough(ctx context.Context, store core.ChatStore, sessionID string) string {
+	summaries, ok := store.(core.ChatSummaryStore)
+	if !ok {
+		return ""
+	}
+	summary, err := summaries.LoadSummary(ctx, sessionID)
+	if err != nil || summary == nil {
+		return ""
+	}
+	return summary.ThroughMessageID
+}
+
+// markSummarized returns a copy of messages with the messages up to and
+// including through flagged as summarized.
+func markSummarized(messages []ChatMessage, through string) []ChatMessage {
+	out := make([]ChatMessage, len(messages))
+	copy(out, messages)
+	if through == "" {
+		return out
+	}
+	for i := range out {
+		if out[i].ID == through {
+			for j := 0; j <= i; j++ {
+				out[j].Summarized = true
+			}
+			break
+		}
+	}
+	return out
+}
+
 // RegisterRoutes registers chat routes on the given router.
 func (h *ChatHandler) RegisterRoutes(r chi.Router) {
 	r.Route("/chat", func(r chi.Router) {
@@ -435,6 +606,8 @@ func (h *ChatHandler) RegisterRoutes(r chi.Router) {
 		// Messages
 		r.Get("/sessions/{sessionID}/messages", h.GetMessages)
 		r.Post("/sessions/{sessionID}/messages", h.SendMessage)
+		r.Post("/sessions/{sessionID}/ask-all", h.AskAll)
+		r.Post("/sessions/{sessionID}/promote", h.Promote)
 
 		// Session settings
 		r.Put("/sessions/{sessionID}/agent", h.SetAgent)
@@ -553,17 +726,17 @@ func (h *ChatHandler) GetSession(w http.ResponseWriter, r *http.Request) {
 
 	h.mu.RLock()
 	state, exists := h.sessions[sessionID]
-	h.mu.RUnlock()
-
 	if !exists {
+		h.mu.RUnlock()
 		writeError(w, http.StatusNotFound, "session not found")
 		return
 	}
 
 	// Include messages in full session view
 	session := state.session
-	session.Messages = state.messages
+	session.Messages = markSummarized(state.messages, state.summarizedThrough)
 	session.MessageCount = len(state.messages)
+	h.mu.RUnlock()
 
 	writeJSON(w, http.StatusOK, session)
 }
@@ -683,8 +856,7 @@ func (h *ChatHandler) GetMessages(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusNotFound, "session not found")
 		return
 	}
-	messages := make([]ChatMessage, len(state.messages))
-	copy(messages, state.messages)
+	messages := markSummarized(state.messages, state.summarizedThrough)
 	h.mu.RUnlock()
 
 	// Return array directly for frontend compatibility
@@ -733,28 +905,24 @@ func (h *ChatHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
 	// Create user message
 	now := time.Now()
 	userMsg := ChatMessage{
-		ID:        uuid.New().String(),
-		SessionID: sessionID,
-		Role:      "user",
-		Content:   req.Content,
-		Timestamp: now,
+		ID:          uuid.New().String(),
+		SessionID:   sessionID,
+		Role:        "user",
+		Content:     req.Content,
+		Timestamp:   now,
+		Attachments: req.Attachments,
 	}
 	state.messages = append(state.messages, userMsg)
 	state.session.UpdatedAt = now
+	history := make([]ChatMessage, len(state.messages))
+	copy(history, state.messages)
 
 	h.mu.Unlock()
 
 	// Persist user message
 	chatStore := h.getChatStore(ctx)
 	if chatStore != nil {
-		persistedMsg := &core.ChatMessageState{
-			ID:        userMsg.ID,
-			SessionID: sessionID,
-			Role:      userMsg.Role,
-			Content:   userMsg.Content,
-			Timestamp: userMsg.Timestamp,
-		}
-		_ = chatStore.SaveMessage(ctx, persistedMsg)
+		_ = chatStore.SaveMessage(ctx, chatMessageState(userMsg))
 	}
 
 	// Publish user message event
@@ -770,7 +938,7 @@ func (h *ChatHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
 		reasoningEffort: req.ReasoningEffort,
 		attachments:     req.Attachments,
 		projectRoot:     state.projectRoot,
-		history:         state.messages,
+		history:         history,
 	})
 	if err != nil {
 		// Add error as system message
@@ -793,26 +961,14 @@ func (h *ChatHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
 	h.mu.Lock()
 	state.messages = append(state.messages, agentMsg)
 	state.session.UpdatedAt = agentMsg.Timestamp
+	if agentMsg.Context != nil && agentMsg.Context.SummarizedThrough != "" {
+		state.summarizedThrough = agentMsg.Context.SummarizedThrough
+	}
 	h.mu.Unlock()
 
 	// Persist agent message (reuse chatStore from earlier in this function)
 	if chatStore != nil {
-		var tokensIn, tokensOut int
-		if agentMsg.Tokens != nil {
-			tokensIn = agentMsg.Tokens.Input
-			tokensOut = agentMsg.Tokens.Output
-		}
-		persistedMsg := &core.ChatMessageState{
-			ID:        agentMsg.ID,
-			SessionID: sessionID,
-			Role:      agentMsg.Role,
-			Agent:     agentMsg.Agent,
-			Content:   agentMsg.Content,
-			Timestamp: agentMsg.Timestamp,
-			TokensIn:  tokensIn,
-			TokensOut: tokensOut,
-		}
-		_ = chatStore.SaveMessage(ctx, persistedMsg)
+		_ = chatStore.SaveMessage(ctx, chatMessageState(agentMsg))
 	}
 
 	// Return agent message directly for frontend compatibility
@@ -820,6 +976,326 @@ func (h *ChatHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, agentMsg)
 }
 
+// AskAll sends a message to several agents in parallel, then has a moderator
+// score their agreement and synthesize a single answer. The question, every
+// answer and the synthesis share a group ID.
+func (h *ChatHandler) AskAll(w http.ResponseWriter, r *http.Request) {
+	sessionID := chi.URLParam(r, "sessionID")
+	ctx := r.Context()
+
+	if !h.ensureSessionLoaded(ctx, sessionID) {
+		writeError(w, http.StatusNotFound, "session not found")
+		return
+	}
+
+	var req AskAllRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Content == "" {
+		writeError(w, http.StatusBadRequest, "content is required")
+		return
+	}
+	if h.agents == nil {
+		writeError(w, http.StatusInternalServerError, "no agent registry configured")
+		return
+	}
+
+	askCfg := h.getChatConfig(ctx).AskAll
+	available := slices.Sorted(slices.Values(h.agents.ListEnabled()))
+	targets, err := chatquorum.Targets(req.Agents, askCfg, available)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Moderator != "" {
+		askCfg.Moderator = strings.ToLower(req.Moderator)
+	}
+	if _, err := h.agents.Get(chatquorum.Moderator(askCfg, targets).Agent); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("moderator not available: %v", err))
+		return
+	}
+
+	h.mu.Lock()
+	state, exists := h.sessions[sessionID]
+	if !exists {
+		h.mu.Unlock()
+		writeError(w, http.StatusNotFound, "session not found")
+		return
+	}
+	// The session model belongs to the session agent; other agents use their default.
+	for i := range targets {
+		if targets[i].Agent == state.agent {
+			targets[i].Model = state.model
+		}
+	}
+
+	groupID := uuid.New().String()
+	now := time.Now()
+	userMsg := ChatMessage{
+		ID:          uuid.New().String(),
+		SessionID:   sessionID,
+		Role:        "user",
+		Content:     req.Content,
+		Timestamp:   now,
+		Attachments: req.Attachments,
+		GroupID:     groupID,
+	}
+	state.messages = append(state.messages, userMsg)
+	state.session.UpdatedAt = now
+	history := make([]ChatMessage, len(state.messages))
+	copy(history, state.messages)
+	projectRoot := state.projectRoot
+	h.mu.Unlock()
+
+	chatStore := h.getChatStore(ctx)
+	if chatStore != nil {
+		_ = chatStore.SaveMessage(ctx, chatMessageState(userMsg))
+	}
+	if h.eventBus != nil {
+		h.eventBus.Publish(events.NewChatMessageEvent("", "", events.RoleUser, "", req.Content))
+	}
+
+	// Build every prompt up front: the context builder may summarize and
+	// persist older history, which should not happen concurrently.
+	prepared := make(map[string]core.ExecuteOptions, len(targets))
+	contexts := make(map[string]*chatcontext.Context, len(targets))
+	for _, target := range targets {
+		execOpts, convCtx, err := h.buildExecuteOptions(ctx, executeAgentOptions{
+			sessionID:       sessionID,
+			agentName:       target.Agent,
+			model:           target.Model,
+			reasoningEffort: req.ReasoningEffort,
+			attachments:     req.Attachments,
+			projectRoot:     projectRoot,
+			history:         history,
+		})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		prepared[target.Agent] = execOpts
+		contexts[target.Agent] = convCtx
+	}
+
+	asker, err := chatquorum.NewAsker(h.agents)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	var transcript string
+	if convCtx := contexts[targets[0].Agent]; convCtx != nil {
+		transcript = convCtx.Transcript()
+	}
+	result, err := asker.Ask(ctx, chatquorum.Request{
+		Question: req.Content,
+		Context:  transcript,
+		Targets:  targets,
+		Options: func(_ context.Context, t chatquorum.Target) (core.ExecuteOptions, error) {
+			return prepared[t.Agent], nil
+		},
+		Moderator: chatquorum.Moderator(askCfg, targets),
+	})
+	if err != nil {
+		errMsg := ChatMessage{
+			ID:        uuid.New().String(),
+			SessionID: sessionID,
+			Role:      "system",
+			Content:   fmt.Sprintf("Error: %v", err),
+			Timestamp: time.Now(),
+			GroupID:   groupID,
+		}
+		h.mu.Lock()
+		state.messages = append(state.messages, errMsg)
+		h.mu.Unlock()
+
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resp := AskAllResponse{GroupID: groupID, UserMessage: userMsg}
+	var summarizedThrough string
+	for _, ans := range result.Answers {
+		msg := ChatMessage{
+			ID:        uuid.New().String(),
+			SessionID: sessionID,
+			Agent:     ans.Agent,
+			Timestamp: time.Now(),
+			GroupID:   groupID,
+		}
+		if ans.Err != nil {
+			msg.Role = "system"
+			msg.Content = fmt.Sprintf("Error from %s: %v", ans.Agent, ans.Err)
+		} else {
+			msg.Role = "agent"
+			msg.Content = ans.Content
+			msg.Model = ans.Model
+			msg.Tokens = &TokenInfo{Input: ans.TokensIn, Output: ans.TokensOut}
+			msg.Context = contextInfo(contexts[ans.Agent])
+			if summarizedThrough == "" {
+				summarizedThrough = msg.Context.SummarizedThrough
+			}
+			if h.eventBus != nil {
+				h.eventBus.Publish(events.NewChatMessageEvent("", "", events.RoleAgent, ans.Agent, ans.Content))
+			}
+		}
+		resp.Responses = append(resp.Responses, msg)
+	}
+	if syn := result.Synthesis; syn != nil {
+		msg := ChatMessage{
+			ID:        uuid.New().String(),
+			SessionID: sessionID,
+			Agent:     syn.Agent,
+			Timestamp: time.Now(),
+			GroupID:   groupID,
+		}
+		if syn.Err != nil {
+			msg.Role = "system"
+			msg.Content = fmt.Sprintf("Error synthesizing the answers: %v", syn.Err)
+		} else {
+			consensus := syn.Score
+			msg.Role = "agent"
+			msg.Content = syn.Content
+			msg.Model = syn.Model
+			msg.Tokens = &TokenInfo{Input: syn.TokensIn, Output: syn.TokensOut}
+			msg.Consensus = &consensus
+			if h.eventBus != nil {
+				h.eventBus.Publish(events.NewChatMessageEvent("", "", events.RoleAgent, syn.Agent, syn.Content))
+			}
+		}
+		resp.Synthesis = &msg
+	}
+
+	added := slices.Clone(resp.Responses)
+	if resp.Synthesis != nil {
+		added = append(added, *resp.Synthesis)
+	}
+	h.mu.Lock()
+	state.messages = append(state.messages, added...)
+	state.session.UpdatedAt = time.Now()
+	if summarizedThrough != "" {
+		state.summarizedThrough = summarizedThrough
+	}
+	h.mu.Unlock()
+
+	if chatStore != nil {
+		for _, msg := range added {
+			_ = chatStore.SaveMessage(ctx, chatMessageState(msg))
+		}
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Promote turns a chat session into a workflow. Without a prompt, an agent
+// drafts the workflow title and prompt from the conversation; with
+// draft_only the draft is returned for review. Otherwise a pending workflow
+// is created with the transcript and session attachments, and the session is
+// linked to it.
+func (h *ChatHandler) Promote(w http.ResponseWriter, r *http.Request) {
+	sessionID := chi.URLParam(r, "sessionID")
+	ctx := r.Context()
+
+	if !h.ensureSessionLoaded(ctx, sessionID) {
+		writeError(w, http.StatusNotFound, "session not found")
+		return
+	}
+
+	var req PromoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	column := req.KanbanColumn
+	if column == "" {
+		column = "refinement"
+	}
+	if column != "refinement" && column != "todo" {
+		writeError(w, http.StatusBadRequest, "kanban_column must be refinement or todo")
+		return
+	}
+	if !req.DraftOnly && h.workflowCreator == nil {
+		writeError(w, http.StatusServiceUnavailable, "workflow management not available")
+		return
+	}
+
+	h.mu.RLock()
+	state, exists := h.sessions[sessionID]
+	if !exists {
+		h.mu.RUnlock()
+		writeError(w, http.StatusNotFound, "session not found")
+		return
+	}
+	conv := &chatpromote.Conversation{
+		Session: &core.ChatSessionState{
+			ID:          sessionID,
+			Title:       state.title,
+			CreatedAt:   state.session.CreatedAt,
+			UpdatedAt:   state.session.UpdatedAt,
+			Agent:       state.agent,
+			Model:       state.model,
+			ProjectRoot: state.projectRoot,
+		},
+		Title: strings.TrimSpace(req.Title),
+	}
+	for _, msg := range state.messages {
+		conv.Messages = append(conv.Messages, chatMessageState(msg))
+	}
+	h.mu.RUnlock()
+
+	resp := PromoteResponse{Prompt: strings.TrimSpace(req.Prompt)}
+	if resp.Prompt == "" {
+		if h.agents == nil {
+			writeError(w, http.StatusInternalServerError, "no agent registry configured")
+			return
+		}
+		target := chatpromote.DraftTarget(h.getChatConfig(ctx).Context, conv.Session.Agent, conv.Session.Model)
+		if req.Agent != "" {
+			target = chatpromote.Target{Agent: strings.ToLower(req.Agent), Model: req.Model}
+		}
+		drafter, err := chatpromote.NewDrafter(h.agents)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		draft, err := drafter.Draft(ctx, conv, target)
+		if err != nil {
+			writeError(w, http.StatusBadGateway, err.Error())
+			return
+		}
+		resp.Prompt = draft.Prompt
+		resp.Agent = draft.Agent
+		resp.Model = draft.Model
+		resp.Tokens = &TokenInfo{Input: draft.TokensIn, Output: draft.TokensOut}
+		if conv.Title == "" {
+			conv.Title = draft.Title
+		}
+	}
+	resp.Title = conv.WorkflowTitle()
+
+	if req.DraftOnly {
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	workflowID, err := h.workflowCreator(ctx, conv, resp.Prompt, column)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create workflow: %v", err))
+		return
+	}
+	resp.WorkflowID = string(workflowID)
+
+	h.mu.Lock()
+	state.session.WorkflowID = resp.WorkflowID
+	h.mu.Unlock()
+	if chatStore := h.getChatStore(ctx); chatStore != nil {
+		_ = conv.LinkSession(ctx, chatStore, workflowID)
+	}
+
+	writeJSON(w, http.StatusCreated, resp)
+}
+
 // executeAgentOptions contains options for executing an agent.
 type executeAgentOptions struct {
 	sessionID       string
@@ -853,25 +1329,50 @@ func (h *ChatHandler) executeAgent(ctx context.Context, opts executeAgentOptions
 		return ChatMessage{}, fmt.Errorf("agent %s not available: %w", opts.agentName, err)
 	}
 
-	// Build context from recent history
-	contextBuilder := ""
-	start := 0
-	if len(opts.history) > 10 {
-		start = len(opts.history) - 10
+	execOpts, convCtx, err := h.buildExecuteOptions(ctx, opts)
+	if err != nil {
+		return ChatMessage{}, err
+	}
+
+	result, err := agent.Execute(ctx, execOpts)
+	if err != nil {
+		return ChatMessage{}, err
 	}
-	for _, msg := range opts.history[start:] {
-		role := msg.Role
-		if role == "agent" {
-			role = "assistant"
-		}
-		contextBuilder += fmt.Sprintf("[%s]: %s\n\n", role, msg.Content)
+
+	msg := ChatMessage{
+		ID:        uuid.New().String(),
+		SessionID: opts.sessionID,
+		Role:      "agent",
+		Agent:     opts.agentName,
+		Content:   result.Output,
+		Timestamp: time.Now(),
+		Tokens: &TokenInfo{
+			Input:  result.TokensIn,
+			Output: result.TokensOut,
+		},
+		Model:   opts.model,
+		Context: contextInfo(convCtx),
 	}
 
-	// Get the last user message
+	// Publish agent response event
+	if h.eventBus != nil {
+		h.eventBus.Publish(events.NewChatMessageEvent("", "", events.RoleAgent, opts.agentName, result.Output))
+	}
+
+	return msg, nil
+}
+
+// buildExecuteOptions builds the prompt for the last user message in the
+// history, with its attached files and the conversation fitted into the
+// agent's context window.
+func (h *ChatHandler) buildExecuteOptions(ctx context.Context, opts executeAgentOptions) (core.ExecuteOptions, *chatcontext.Context, error) {
+	// Get the last user message; the history before it is the conversation context.
 	var lastContent string
+	previous := opts.history
 	for i := len(opts.history) - 1; i >= 0; i-- {
 		if opts.history[i].Role == "user" {
 			lastContent = opts.history[i].Content
+			previous = opts.history[:i]
 			break
 		}
 	}
@@ -925,6 +1426,26 @@ func (h *ChatHandler) executeAgent(ctx context.Context, opts executeAgentOptions
 		}
 	}
 
+	// Fit the history into the agent's context window, summarizing older turns.
+	systemPrompt := buildChatSystemPrompt()
+	turns := make([]chatcontext.Turn, 0, len(previous))
+	for _, msg := range previous {
+		turns = append(turns, contextTurn(msg))
+	}
+	contextConfig := h.getChatConfig(ctx).Context
+	convCtx, err := h.contextBuilder.Build(ctx, chatcontext.Request{
+		SessionID: opts.sessionID,
+		Agent:     opts.agentName,
+		Model:     opts.model,
+		Fixed:     []string{systemPrompt, fileContext, lastContent},
+		History:   turns,
+		Store:     h.getChatStore(ctx),
+		Config:    contextConfig,
+	})
+	if err != nil {
+		return core.ExecuteOptions{}, nil, err
+	}
+
 	prompt := fmt.Sprintf(`You are in an interactive chat session.
 
 ## Conversation Context
@@ -933,11 +1454,11 @@ func (h *ChatHandler) executeAgent(ctx context.Context, opts executeAgentOptions
 ## Current Message
 %s
 
-Respond helpfully and concisely.`, contextBuilder, fileContext, lastContent)
+Respond helpfully and concisely.`, convCtx.Transcript(), fileContext, lastContent)
 
 	execOpts := core.ExecuteOptions{
 		Prompt:          prompt,
-		SystemPrompt:    buildChatSystemPrompt(),
+		SystemPrompt:    systemPrompt,
 		Model:           opts.model,
 		Format:          core.OutputFormatText,
 		Phase:           core.PhaseExecute,
@@ -945,30 +1466,35 @@ Respond helpfully and concisely.`, contextBuilder, fileContext, lastContent)
 		WorkDir:         opts.projectRoot,
 	}
 
-	result, err := agent.Execute(ctx, execOpts)
-	if err != nil {
-		return ChatMessage{}, err
-	}
-
-	msg := ChatMessage{
-		ID:        uuid.New().String(),
-		SessionID: opts.sessionID,
-		Role:      "agent",
-		Agent:     opts.agentName,
-		Content:   result.Output,
-		Timestamp: time.Now(),
-		Tokens: &TokenInfo{
-			Input:  result.TokensIn,
-			Output: result.TokensOut,
-		},
-	}
+	return execOpts, convCtx, nil
+}
 
-	// Publish agent response event
-	if h.eventBus != nil {
-		h.eventBus.Publish(events.NewChatMessageEvent("", "", events.RoleAgent, opts.agentName, result.Output))
+// contextTurn converts a chat message to a conversation context turn.
+func contextTurn(msg ChatMessage) chatcontext.Turn {
+	return chatcontext.Turn{
+		ID:        msg.ID,
+		Role:      msg.Role,
+		Content:   msg.Content,
+		Group:     msg.GroupID,
+		Synthesis: msg.Consensus != nil,
 	}
+}
 
-	return msg, nil
+// contextInfo describes a conversation context for the API.
+func contextInfo(c *chatcontext.Context) *ContextInfo {
+	info := &ContextInfo{
+		SummarizedThrough:  c.SummarizedThrough,
+		SummarizedMessages: c.Summarized,
+		OmittedMessages:    c.Omitted,
+		TruncatedMessages:  c.Truncated,
+		EstimatedTokens:    c.Tokens,
+		BudgetTokens:       c.Budget,
+		Notice:             c.Notice(),
+	}
+	if c.SummaryErr != nil {
+		info.SummaryError = c.SummaryErr.Error()
+	}
+	return info
 }
 
 // SetAgent updates the agent for a chat session.
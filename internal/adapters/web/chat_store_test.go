@@ -9,14 +9,17 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/attachments"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/chatpromote"
 )
 
 // =============================================================================
@@ -561,6 +564,146 @@ func TestSendMessage_NilAgentRegistry(t *testing.T) {
 // SetAgent coverage
 // =============================================================================
 
+func TestAskAll_PersistsGroupAndSynthesis(t *testing.T) {
+	t.Parallel()
+	judge := &mockAgent{name: "judge", result: &core.ExecuteResult{
+		Output: "---\nconsensus_score: 80\n---\n\n## Answer\nUse a mutex.\n\n## Divergences\n- None\n\n>> FINAL SCORE: 80 <<",
+	}}
+	registry := &mockAgentRegistry{agents: map[string]core.Agent{
+		"claude": &mockAgent{name: "claude"},
+		"gemini": &mockAgent{name: "gemini", err: fmt.Errorf("rate limited")},
+		"codex":  &mockAgent{name: "codex"},
+		"judge":  judge,
+	}}
+	store := newMockChatStore()
+	h := NewChatHandler(registry, nil, nil, store, WithChatConfigResolver(func(context.Context) config.ChatConfig {
+		return config.ChatConfig{AskAll: config.ChatAskAllConfig{Moderator: "judge"}}
+	}))
+	r := setupTestRouter(h)
+	h.sessions["session-1"] = &chatSessionState{
+		session: ChatSession{ID: "session-1", Agent: "claude"},
+		agent:   "claude",
+		model:   "session-model",
+	}
+
+	body := `{"content":"mutex or channel?","agents":["claude","gemini","codex"]}`
+	req := httptest.NewRequest(http.MethodPost, "/chat/sessions/session-1/ask-all", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp AskAllResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Responses) != 3 || resp.Responses[0].Agent != "claude" || resp.Responses[0].Model != "session-model" {
+		t.Fatalf("responses = %+v", resp.Responses)
+	}
+	if resp.Responses[1].Role != "system" || !strings.Contains(resp.Responses[1].Content, "rate limited") {
+		t.Errorf("failed agent = %+v, want a system message", resp.Responses[1])
+	}
+	if resp.Synthesis == nil || resp.Synthesis.Agent != "judge" || resp.Synthesis.Consensus == nil || *resp.Synthesis.Consensus != 0.8 {
+		t.Fatalf("synthesis = %+v", resp.Synthesis)
+	}
+	if !strings.HasPrefix(resp.Synthesis.Content, "## Answer") {
+		t.Errorf("synthesis content = %q", resp.Synthesis.Content)
+	}
+
+	persisted := store.messages["session-1"]
+	if len(persisted) != 5 {
+		t.Fatalf("persisted %d messages, want question, 3 responses and synthesis", len(persisted))
+	}
+	for _, msg := range persisted {
+		if msg.GroupID != resp.GroupID {
+			t.Errorf("message %s group = %q, want %q", msg.Role, msg.GroupID, resp.GroupID)
+		}
+	}
+	if persisted[4].Consensus == nil {
+		t.Error("synthesis consensus was not persisted")
+	}
+
+	// Too few agents is a client error.
+	req = httptest.NewRequest(http.MethodPost, "/chat/sessions/session-1/ask-all", bytes.NewBufferString(`{"content":"q","agents":["claude"]}`))
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("got status %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestPromote_DraftThenCreate(t *testing.T) {
+	t.Parallel()
+	drafter := &mockAgent{name: "gemini", result: &core.ExecuteResult{
+		Output: "---\ntitle: Retry 503 responses\n---\n\nAdd retries with backoff.",
+	}}
+	registry := &mockAgentRegistry{agents: map[string]core.Agent{
+		"claude": &mockAgent{name: "claude"},
+		"gemini": drafter,
+	}}
+	store := newMockChatStore()
+	var created *chatpromote.Conversation
+	var createdPrompt string
+	h := NewChatHandler(registry, nil, nil, store,
+		WithChatConfigResolver(func(context.Context) config.ChatConfig {
+			return config.ChatConfig{Context: config.ChatContextConfig{SummaryAgent: "gemini"}}
+		}),
+		WithWorkflowCreator(func(_ context.Context, conv *chatpromote.Conversation, prompt, column string) (core.WorkflowID, error) {
+			created, createdPrompt = conv, prompt
+			return "wf-1", nil
+		}),
+	)
+	r := setupTestRouter(h)
+	h.sessions["session-1"] = &chatSessionState{
+		session: ChatSession{ID: "session-1", Agent: "claude"},
+		messages: []ChatMessage{
+			{ID: "m1", SessionID: "session-1", Role: "user", Content: "Retry on 503?"},
+			{ID: "m2", SessionID: "session-1", Role: "agent", Agent: "claude", Content: "Yes, with backoff."},
+		},
+		agent: "claude",
+		title: "Retries",
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/chat/sessions/session-1/promote", bytes.NewBufferString(`{"draft_only":true}`))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("draft: got status %d: %s", w.Code, w.Body.String())
+	}
+	var draft PromoteResponse
+	if err := json.NewDecoder(w.Body).Decode(&draft); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if draft.Title != "Retry 503 responses" || draft.Prompt != "Add retries with backoff." || draft.Agent != "gemini" || draft.WorkflowID != "" {
+		t.Fatalf("draft = %+v", draft)
+	}
+	if drafter.lastOpts == nil || !strings.Contains(drafter.lastOpts.Prompt, "[claude]: Yes, with backoff.") {
+		t.Error("the drafter should receive the conversation")
+	}
+	if created != nil {
+		t.Fatal("a draft must not create a workflow")
+	}
+
+	body := `{"title":"Retries with backoff","prompt":"Add retries, at most 5 attempts."}`
+	req = httptest.NewRequest(http.MethodPost, "/chat/sessions/session-1/promote", bytes.NewBufferString(body))
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: got status %d: %s", w.Code, w.Body.String())
+	}
+	if created == nil || created.WorkflowTitle() != "Retries with backoff" || len(created.Messages) != 2 ||
+		createdPrompt != "Add retries, at most 5 attempts." {
+		t.Fatalf("created = %+v, prompt %q", created, createdPrompt)
+	}
+	if h.sessions["session-1"].session.WorkflowID != "wf-1" {
+		t.Error("the session should link the workflow")
+	}
+	if len(store.sessions) != 1 || store.sessions[0].WorkflowID != "wf-1" {
+		t.Errorf("persisted sessions = %+v", store.sessions)
+	}
+}
+
 func TestSetAgent_InvalidBody(t *testing.T) {
 	t.Parallel()
 	h := NewChatHandler(nil, nil, nil, nil)
@@ -991,6 +1134,64 @@ func TestExecuteAgent_WithImageAttachment(t *testing.T) {
 	}
 }
 
+func TestExecuteAgent_SummarizesOlderHistory(t *testing.T) {
+	t.Parallel()
+	registry := newMockAgentRegistry()
+	h := NewChatHandler(registry, nil, nil, nil, WithChatConfigResolver(func(context.Context) config.ChatConfig {
+		return config.ChatConfig{Context: config.ChatContextConfig{MaxTokens: 3000, RecentTurns: 2}}
+	}))
+
+	history := make([]ChatMessage, 21)
+	for i := range history {
+		role := "user"
+		if i%2 == 1 {
+			role = "agent"
+		}
+		history[i] = ChatMessage{ID: fmt.Sprintf("m%d", i), Role: role, Content: fmt.Sprintf("message %d %s", i, strings.Repeat("x", 1000))}
+	}
+
+	msg, err := h.executeAgent(context.Background(), executeAgentOptions{
+		sessionID: "s1",
+		agentName: "claude",
+		history:   history,
+	})
+	if err != nil {
+		t.Fatalf("executeAgent: %v", err)
+	}
+	info := msg.Context
+	if info == nil || info.SummarizedMessages == 0 || info.SummarizedThrough == "" || info.SummaryError != "" {
+		t.Fatalf("context = %+v, want older messages summarized", info)
+	}
+	if info.EstimatedTokens > info.BudgetTokens || info.BudgetTokens != 3000 {
+		t.Errorf("tokens = %d of %d", info.EstimatedTokens, info.BudgetTokens)
+	}
+	prompt := registry.agents["claude"].(*mockAgent).lastOpts.Prompt
+	if !strings.Contains(prompt, "[summary of") || strings.Contains(prompt, "message 0 ") {
+		t.Errorf("prompt should carry the summary instead of the oldest messages")
+	}
+	if !strings.Contains(prompt, "message 20 ") {
+		t.Error("prompt should include the current message")
+	}
+}
+
+func TestMarkSummarized(t *testing.T) {
+	t.Parallel()
+	messages := []ChatMessage{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+
+	marked := markSummarized(messages, "b")
+	if !marked[0].Summarized || !marked[1].Summarized || marked[2].Summarized {
+		t.Errorf("marked = %+v", marked)
+	}
+	if messages[0].Summarized {
+		t.Error("markSummarized should not modify the session messages")
+	}
+	for _, msg := range markSummarized(messages, "gone") {
+		if msg.Summarized {
+			t.Error("unknown boundary should not mark messages")
+		}
+	}
+}
+
 // =============================================================================
 // ensureSessionLoaded tests
 // =============================================================================
@@ -1092,6 +1293,66 @@ func TestEnsureSessionLoaded_MessageLoadError(t *testing.T) {
 	}
 }
 
+func TestEnsureSessionLoaded_RefreshesNewerStoreState(t *testing.T) {
+	t.Parallel()
+	store := newMockChatStore()
+	then := time.Now().Add(-time.Minute)
+	now := time.Now()
+
+	// The session was continued from the terminal after the web handler cached it.
+	store.sessions = append(store.sessions, &core.ChatSessionState{
+		ID:        "s1",
+		Agent:     "codex",
+		Model:     "gpt-5",
+		CreatedAt: then,
+		UpdatedAt: now,
+	})
+	store.messages["s1"] = []*core.ChatMessageState{
+		{ID: "m1", SessionID: "s1", Role: "user", Content: "see @main.go", Timestamp: then, Attachments: []string{"main.go"}},
+		{ID: "m2", SessionID: "s1", Role: "agent", Agent: "codex", Model: "gpt-5", Content: "ok", Timestamp: now, TokensIn: 10, TokensOut: 5},
+	}
+
+	h := NewChatHandler(nil, nil, nil, store)
+	h.sessions["s1"] = &chatSessionState{session: ChatSession{ID: "s1", Agent: "claude", UpdatedAt: then}}
+
+	if !h.ensureSessionLoaded(context.Background(), "s1") {
+		t.Fatal("expected true for known session")
+	}
+
+	h.mu.RLock()
+	state := h.sessions["s1"]
+	h.mu.RUnlock()
+	if state.session.Agent != "codex" || state.session.Model != "gpt-5" {
+		t.Errorf("session = %+v, want agent/model from store", state.session)
+	}
+	if len(state.messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(state.messages))
+	}
+	if got := state.messages[0].Attachments; len(got) != 1 || got[0] != "main.go" {
+		t.Errorf("attachments = %v", got)
+	}
+	if got := state.messages[1]; got.Model != "gpt-5" || got.Tokens.Input != 10 || got.Tokens.Output != 5 {
+		t.Errorf("agent message = %+v", got)
+	}
+}
+
+func TestEnsureSessionLoaded_KeepsUpToDateMemoryState(t *testing.T) {
+	t.Parallel()
+	store := newMockChatStore()
+	now := time.Now()
+	store.sessions = append(store.sessions, &core.ChatSessionState{ID: "s1", Agent: "codex", UpdatedAt: now})
+
+	h := NewChatHandler(nil, nil, nil, store)
+	h.sessions["s1"] = &chatSessionState{session: ChatSession{ID: "s1", Agent: "claude", UpdatedAt: now}}
+
+	if !h.ensureSessionLoaded(context.Background(), "s1") {
+		t.Fatal("expected true for known session")
+	}
+	if agent := h.sessions["s1"].session.Agent; agent != "claude" {
+		t.Errorf("agent = %q, in-memory state should be kept", agent)
+	}
+}
+
 // messageLoadErrorStore is a mock that fails on LoadMessages but not on LoadSession.
 type messageLoadErrorStore struct {
 	*mockChatStore
@@ -1131,8 +1392,22 @@ func TestEnsureProjectSessionsLoaded(t *testing.T) {
 		t.Error("session should be loaded from store")
 	}
 
-	// Second call should be a no-op (already loaded)
+	// Sessions created from the terminal after the first load are picked up.
+	store.sessions = append(store.sessions, &core.ChatSessionState{
+		ID:          "s2",
+		Agent:       "gemini",
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		ProjectRoot: "/project-a",
+	})
 	h.ensureProjectSessionsLoaded(context.Background(), "/project-a")
+
+	h.mu.RLock()
+	count := len(h.sessions)
+	h.mu.RUnlock()
+	if count != 2 {
+		t.Errorf("expected 2 sessions after second load, got %d", count)
+	}
 }
 
 func TestEnsureProjectSessionsLoaded_NilStore(t *testing.T) {
@@ -1152,9 +1427,8 @@ func TestLoadPersistedSessions_ListError(t *testing.T) {
 	store.listErr = fmt.Errorf("list error")
 
 	h := &ChatHandler{
-		sessions:           make(map[string]*chatSessionState),
-		loadedProjectRoots: make(map[string]bool),
-		chatStore:          store,
+		sessions:  make(map[string]*chatSessionState),
+		chatStore: store,
 	}
 	h.loadPersistedSessions()
 
@@ -1209,8 +1483,7 @@ func TestLoadPersistedSessionsFromStore_ProjectIsolation(t *testing.T) {
 	)
 
 	h := &ChatHandler{
-		sessions:           make(map[string]*chatSessionState),
-		loadedProjectRoots: make(map[string]bool),
+		sessions: make(map[string]*chatSessionState),
 	}
 
 	h.loadPersistedSessionsFromStore(context.Background(), store, "/project-a")
@@ -1241,8 +1514,7 @@ func TestLoadPersistedSessionsFromStore_PreferNewerData(t *testing.T) {
 	}
 
 	h := &ChatHandler{
-		sessions:           make(map[string]*chatSessionState),
-		loadedProjectRoots: make(map[string]bool),
+		sessions: make(map[string]*chatSessionState),
 	}
 
 	// Pre-populate with an older version
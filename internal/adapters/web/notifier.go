@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/control"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/workflow"
@@ -170,6 +171,38 @@ func (n *WebOutputNotifier) TaskSkipped(task *core.Task, reason string) {
 	n.eventBus.Publish(events.NewTaskSkippedEvent(n.workflowID, "", string(task.ID), reason))
 }
 
+// TaskRetry emits a task_retry event.
+// NOTE: This is NOT part of the OutputNotifier interface; the executor calls it when supported.
+func (n *WebOutputNotifier) TaskRetry(task *core.Task, attempt, maxAttempts int, err error) {
+	n.eventBus.Publish(events.NewTaskRetryEvent(n.workflowID, "", string(task.ID), attempt, maxAttempts, err))
+}
+
+// TaskInputRequested emits a user_input_requested event for a task question.
+// NOTE: This is NOT part of the OutputNotifier interface; the executor calls it when supported.
+func (n *WebOutputNotifier) TaskInputRequested(task *core.Task, req control.InputRequest) {
+	evt := events.NewUserInputRequestedEvent(n.workflowID, "", req.ID, req.Prompt, req.Options)
+	evt.TaskID = string(task.ID)
+	evt.Context = req.Context
+	evt.Default = req.Default
+	evt.Timeout = req.Timeout
+	n.eventBus.Publish(evt)
+}
+
+// TaskInputResolved emits a user_input_provided event once a task question is
+// answered, or goes unanswered (cancelled).
+// NOTE: This is NOT part of the OutputNotifier interface; the executor calls it when supported.
+func (n *WebOutputNotifier) TaskInputResolved(task *core.Task, requestID, answer string, cancelled bool) {
+	evt := events.NewUserInputProvidedEvent(n.workflowID, "", requestID, answer, cancelled)
+	evt.TaskID = string(task.ID)
+	n.eventBus.Publish(evt)
+}
+
+// ModeratorRound emits a moderator_round event.
+// NOTE: This is NOT part of the OutputNotifier interface; the analyzer calls it when supported.
+func (n *WebOutputNotifier) ModeratorRound(round int, score, threshold float64, agreements, divergences int) {
+	n.eventBus.Publish(events.NewModeratorRoundEvent(n.workflowID, "", round, score, threshold, agreements, divergences))
+}
+
 // WorkflowStateUpdated is called when the workflow state changes.
 func (n *WebOutputNotifier) WorkflowStateUpdated(state *core.WorkflowState) {
 	var completed, failed, skipped int
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/control"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
 )
@@ -237,6 +238,48 @@ func TestWebOutputNotifier_TaskSkipped(t *testing.T) {
 	}
 }
 
+func TestWebOutputNotifier_TaskInput(t *testing.T) {
+	t.Parallel()
+	bus := events.New(10)
+	ch := bus.Subscribe()
+
+	notifier := NewWebOutputNotifier(bus, "wf-test-123")
+	task := &core.Task{ID: "task-1", Name: "Test Task"}
+	notifier.TaskInputRequested(task, control.InputRequest{
+		ID:      "req-1",
+		Prompt:  "Which cache?",
+		Options: []string{"redis", "memcached"},
+		Default: "redis",
+		Timeout: time.Minute,
+	})
+	notifier.TaskInputResolved(task, "req-1", "memcached", false)
+
+	select {
+	case event := <-ch:
+		e, ok := event.(events.UserInputRequestedEvent)
+		if !ok {
+			t.Fatalf("event = %T, want UserInputRequestedEvent", event)
+		}
+		if e.TaskID != "task-1" || e.RequestID != "req-1" || e.Default != "redis" || e.Timeout != time.Minute || len(e.Options) != 2 {
+			t.Errorf("requested event = %+v", e)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("timeout waiting for event")
+	}
+	select {
+	case event := <-ch:
+		e, ok := event.(events.UserInputProvidedEvent)
+		if !ok {
+			t.Fatalf("event = %T, want UserInputProvidedEvent", event)
+		}
+		if e.TaskID != "task-1" || e.Input != "memcached" || e.Cancelled {
+			t.Errorf("provided event = %+v", e)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("timeout waiting for event")
+	}
+}
+
 func TestWebOutputNotifier_WorkflowStateUpdated(t *testing.T) {
 	t.Parallel()
 	bus := events.New(10)
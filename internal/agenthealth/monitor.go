@@ -0,0 +1,287 @@
+// Package agenthealth tracks the health of agents from the results of their
+// calls. An agent that keeps failing, is rate limited or loses its
+// credentials is quarantined for a cool-down period, during which the agents
+// of a wrapped registry run its calls on a configured fallback agent.
+package agenthealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// Monitor defaults.
+const (
+	DefaultWindow   = time.Hour
+	DefaultCooldown = 15 * time.Minute
+	DefaultMinCalls = 5
+	// maxSubstitutions is the number of recent substitutions kept for Stats.
+	maxSubstitutions = 20
+)
+
+// Options configures a Monitor. Zero durations and MinCalls take the
+// defaults; zero RateLimitErrors or AuthErrors disable those checks.
+type Options struct {
+	// Window is the rolling period calls are judged over.
+	Window time.Duration
+	// Cooldown is how long an unhealthy agent stays quarantined.
+	Cooldown time.Duration
+	// MinCalls is the number of calls in the window before the success rate
+	// can quarantine an agent.
+	MinCalls int
+	// MinSuccessRate below which an agent is quarantined.
+	MinSuccessRate float64
+	// RateLimitErrors and AuthErrors in the window that quarantine an agent.
+	RateLimitErrors int
+	AuthErrors      int
+	// Fallbacks maps an agent to the agent that takes its calls while it is
+	// quarantined.
+	Fallbacks map[string]string
+	Logger    *slog.Logger
+}
+
+// Counts summarizes the calls of an agent or model in the window.
+type Counts struct {
+	Calls           int     `json:"calls"`
+	Failures        int     `json:"failures"`
+	SuccessRate     float64 `json:"success_rate"`
+	AvgLatencyMS    int64   `json:"avg_latency_ms"`
+	RateLimitErrors int     `json:"rate_limit_errors"`
+	AuthErrors      int     `json:"auth_errors"`
+}
+
+// Stats is the health of an agent.
+type Stats struct {
+	Counts
+	// Models breaks the counts down by the model that served the calls.
+	Models           map[string]Counts `json:"models,omitempty"`
+	Quarantined      bool              `json:"quarantined"`
+	QuarantinedUntil *time.Time        `json:"quarantined_until,omitempty"`
+	QuarantineReason string            `json:"quarantine_reason,omitempty"`
+	Fallback         string            `json:"fallback,omitempty"`
+	// Substitutions are the recent calls of the agent that ran on its
+	// fallback.
+	Substitutions []core.AgentSubstitution `json:"substitutions,omitempty"`
+}
+
+// call is the result of one agent call.
+type call struct {
+	at       time.Time
+	model    string
+	ok       bool
+	latency  time.Duration
+	category core.ErrorCategory
+}
+
+// agentHealth is the record of one agent.
+type agentHealth struct {
+	calls []call
+	// judgedSince excludes the calls made before the end of the last
+	// quarantine from the next decision.
+	judgedSince      time.Time
+	quarantinedUntil time.Time
+	reason           string
+}
+
+// Monitor records agent calls and quarantines unhealthy agents. It is safe
+// for concurrent use and meant to be shared by all the workflows of a
+// process.
+type Monitor struct {
+	opts Options
+	now  func() time.Time
+
+	mu            sync.Mutex
+	agents        map[string]*agentHealth
+	substitutions []core.AgentSubstitution
+}
+
+// New creates a monitor.
+func New(opts Options) *Monitor {
+	if opts.Window <= 0 {
+		opts.Window = DefaultWindow
+	}
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = DefaultCooldown
+	}
+	if opts.MinCalls <= 0 {
+		opts.MinCalls = DefaultMinCalls
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+	return &Monitor{opts: opts, now: time.Now, agents: make(map[string]*agentHealth)}
+}
+
+// Record records the result of a call to agent. Cancelled calls say nothing
+// about the agent and are ignored.
+func (m *Monitor) Record(agent, model string, latency time.Duration, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	c := call{at: m.now(), model: model, ok: err == nil, latency: latency}
+	if err != nil {
+		c.category = core.GetCategory(err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h := m.agents[agent]
+	if h == nil {
+		h = &agentHealth{}
+		m.agents[agent] = h
+	}
+	h.calls = append(h.calls, c)
+	m.prune(h, c.at)
+	if c.at.Before(h.quarantinedUntil) {
+		return
+	}
+	if reason := m.judge(h); reason != "" {
+		h.quarantinedUntil = c.at.Add(m.opts.Cooldown)
+		h.judgedSince = h.quarantinedUntil
+		h.reason = reason
+		m.opts.Logger.Warn("agent quarantined",
+			slog.String("agent", agent), slog.String("reason", reason),
+			slog.Time("until", h.quarantinedUntil), slog.String("fallback", m.opts.Fallbacks[agent]))
+	}
+}
+
+// prune drops the calls that left the window.
+func (m *Monitor) prune(h *agentHealth, now time.Time) {
+	cutoff := now.Add(-m.opts.Window)
+	i := 0
+	for i < len(h.calls) && h.calls[i].at.Before(cutoff) {
+		i++
+	}
+	h.calls = h.calls[i:]
+}
+
+// judge returns why the agent should be quarantined, or "" when it is
+// healthy.
+func (m *Monitor) judge(h *agentHealth) string {
+	var calls []call
+	for _, c := range h.calls {
+		if !c.at.Before(h.judgedSince) {
+			calls = append(calls, c)
+		}
+	}
+	counts := count(calls)
+	switch {
+	case m.opts.AuthErrors > 0 && counts.AuthErrors >= m.opts.AuthErrors:
+		return fmt.Sprintf("%d authentication errors", counts.AuthErrors)
+	case m.opts.RateLimitErrors > 0 && counts.RateLimitErrors >= m.opts.RateLimitErrors:
+		return fmt.Sprintf("%d rate limit errors", counts.RateLimitErrors)
+	case counts.Calls >= m.opts.MinCalls && counts.SuccessRate < m.opts.MinSuccessRate:
+		return fmt.Sprintf("success rate %.0f%% over %d calls", counts.SuccessRate*100, counts.Calls)
+	}
+	return ""
+}
+
+// count summarizes calls.
+func count(calls []call) Counts {
+	var counts Counts
+	var latency time.Duration
+	for _, c := range calls {
+		counts.Calls++
+		latency += c.latency
+		if c.ok {
+			continue
+		}
+		counts.Failures++
+		switch c.category {
+		case core.ErrCatRateLimit:
+			counts.RateLimitErrors++
+		case core.ErrCatAuth:
+			counts.AuthErrors++
+		}
+	}
+	if counts.Calls > 0 {
+		counts.SuccessRate = float64(counts.Calls-counts.Failures) / float64(counts.Calls)
+		counts.AvgLatencyMS = (latency / time.Duration(counts.Calls)).Milliseconds()
+	}
+	return counts
+}
+
+// Quarantined reports whether agent is quarantined, and why.
+func (m *Monitor) Quarantined(agent string) (bool, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.quarantined(agent)
+}
+
+func (m *Monitor) quarantined(agent string) (bool, string) {
+	h := m.agents[agent]
+	if h == nil || !m.now().Before(h.quarantinedUntil) {
+		return false, ""
+	}
+	return true, h.reason
+}
+
+// Fallback returns the agent that takes the calls of agent while it is
+// quarantined, or "" when none is configured or the fallback is quarantined
+// too.
+func (m *Monitor) Fallback(agent string) string {
+	fallback := m.opts.Fallbacks[agent]
+	if fallback == "" {
+		return ""
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if quarantined, _ := m.quarantined(fallback); quarantined {
+		return ""
+	}
+	return fallback
+}
+
+// recordSubstitution keeps a substitution for Stats.
+func (m *Monitor) recordSubstitution(sub core.AgentSubstitution) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.substitutions = append(m.substitutions, sub)
+	if len(m.substitutions) > maxSubstitutions {
+		m.substitutions = m.substitutions[len(m.substitutions)-maxSubstitutions:]
+	}
+}
+
+// Stats returns the health of agent over the window.
+func (m *Monitor) Stats(agent string) Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats := Stats{Fallback: m.opts.Fallbacks[agent]}
+	for _, sub := range m.substitutions {
+		if sub.Agent == agent {
+			stats.Substitutions = append(stats.Substitutions, sub)
+		}
+	}
+	h := m.agents[agent]
+	if h == nil {
+		return stats
+	}
+	m.prune(h, m.now())
+	stats.Counts = count(h.calls)
+
+	byModel := make(map[string][]call)
+	for _, c := range h.calls {
+		if c.model != "" {
+			byModel[c.model] = append(byModel[c.model], c)
+		}
+	}
+	if len(byModel) > 0 {
+		stats.Models = make(map[string]Counts, len(byModel))
+		for model, calls := range byModel {
+			stats.Models[model] = count(calls)
+		}
+	}
+
+	if quarantined, reason := m.quarantined(agent); quarantined {
+		until := h.quarantinedUntil
+		stats.Quarantined = true
+		stats.QuarantinedUntil = &until
+		stats.QuarantineReason = reason
+	}
+	return stats
+}
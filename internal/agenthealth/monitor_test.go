@@ -0,0 +1,97 @@
+package agenthealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// testClock is a settable clock for windows and cool-downs.
+type testClock struct{ t time.Time }
+
+func (c *testClock) now() time.Time { return c.t }
+
+func newTestMonitor(opts Options) (*Monitor, *testClock) {
+	m := New(opts)
+	clock := &testClock{t: time.Now()}
+	m.now = clock.now
+	return m, clock
+}
+
+func TestMonitor_QuarantinesOnLowSuccessRate(t *testing.T) {
+	t.Parallel()
+	m, clock := newTestMonitor(Options{MinCalls: 4, MinSuccessRate: 0.5, Cooldown: 10 * time.Minute})
+
+	m.Record("claude", "opus", 2*time.Second, nil)
+	for i := 0; i < 2; i++ {
+		m.Record("claude", "opus", time.Second, errors.New("exit status 1"))
+	}
+	if quarantined, _ := m.Quarantined("claude"); quarantined {
+		t.Fatal("quarantined before min_calls")
+	}
+	m.Record("claude", "sonnet", time.Second, core.ErrExecution("FAILED", "boom"))
+
+	quarantined, reason := m.Quarantined("claude")
+	if !quarantined || reason != "success rate 25% over 4 calls" {
+		t.Fatalf("Quarantined() = %v, %q", quarantined, reason)
+	}
+	stats := m.Stats("claude")
+	if stats.Calls != 4 || stats.Failures != 3 || stats.AvgLatencyMS != 1250 || !stats.Quarantined {
+		t.Errorf("Stats() = %+v", stats)
+	}
+	if opus := stats.Models["opus"]; opus.Calls != 3 || opus.Failures != 2 {
+		t.Errorf("Stats().Models[opus] = %+v", opus)
+	}
+
+	// After the cool-down the agent is judged on new calls only.
+	clock.t = clock.t.Add(11 * time.Minute)
+	m.Record("claude", "opus", time.Second, nil)
+	if quarantined, _ := m.Quarantined("claude"); quarantined {
+		t.Error("still quarantined after the cool-down")
+	}
+}
+
+func TestMonitor_QuarantinesOnRateLimitAndAuthErrors(t *testing.T) {
+	t.Parallel()
+	m, clock := newTestMonitor(Options{RateLimitErrors: 2, AuthErrors: 1, Window: time.Minute})
+
+	m.Record("gemini", "", 0, core.ErrRateLimit("slow down"))
+	clock.t = clock.t.Add(2 * time.Minute)
+	m.Record("gemini", "", 0, fmt.Errorf("analyzing: %w", core.ErrRateLimit("slow down")))
+	if quarantined, _ := m.Quarantined("gemini"); quarantined {
+		t.Error("quarantined for rate limit errors outside the window")
+	}
+	m.Record("gemini", "", 0, core.ErrRateLimit("slow down"))
+	if quarantined, reason := m.Quarantined("gemini"); !quarantined || reason != "2 rate limit errors" {
+		t.Errorf("Quarantined() = %v, %q, want rate limit quarantine", quarantined, reason)
+	}
+
+	m.Record("codex", "", 0, core.ErrAuth("not logged in"))
+	if quarantined, _ := m.Quarantined("codex"); !quarantined {
+		t.Error("not quarantined after an auth error")
+	}
+
+	m.Record("claude", "", 0, context.Canceled)
+	if stats := m.Stats("claude"); stats.Calls != 0 {
+		t.Errorf("cancelled call recorded: %+v", stats)
+	}
+}
+
+func TestMonitor_FallbackSkipsQuarantinedAgents(t *testing.T) {
+	t.Parallel()
+	m, _ := newTestMonitor(Options{AuthErrors: 1, Fallbacks: map[string]string{"claude": "gemini"}})
+	if got := m.Fallback("claude"); got != "gemini" {
+		t.Errorf("Fallback() = %q, want gemini", got)
+	}
+	if got := m.Fallback("gemini"); got != "" {
+		t.Errorf("Fallback() of an agent without one = %q", got)
+	}
+	m.Record("gemini", "", 0, core.ErrAuth("expired"))
+	if got := m.Fallback("claude"); got != "" {
+		t.Errorf("Fallback() to a quarantined agent = %q, want none", got)
+	}
+}
@@ -0,0 +1,94 @@
+package agenthealth
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// Registry wraps an agent registry so that the calls of the agents it
+// returns are recorded by the monitor, and the calls of quarantined agents
+// run on their fallback.
+type Registry struct {
+	core.AgentRegistry
+	monitor      *Monitor
+	onSubstitute func(core.AgentSubstitution)
+}
+
+// Wrap wraps registry. onSubstitute, which may be nil, is called for every
+// call that runs on a fallback agent.
+func (m *Monitor) Wrap(registry core.AgentRegistry, onSubstitute func(core.AgentSubstitution)) *Registry {
+	return &Registry{AgentRegistry: registry, monitor: m, onSubstitute: onSubstitute}
+}
+
+// Get returns the agent, recording its calls.
+func (r *Registry) Get(name string) (core.Agent, error) {
+	agent, err := r.AgentRegistry.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return &trackedAgent{Agent: agent, name: name, registry: r}, nil
+}
+
+// trackedAgent records its calls and hands them to its fallback while it is
+// quarantined.
+type trackedAgent struct {
+	core.Agent
+	name     string
+	registry *Registry
+}
+
+// SetEventHandler sets the handler for streaming events.
+func (a *trackedAgent) SetEventHandler(handler core.AgentEventHandler) {
+	if sc, ok := a.Agent.(core.StreamingCapable); ok {
+		sc.SetEventHandler(handler)
+	}
+}
+
+// Execute runs the call on the agent, or on its fallback while the agent is
+// quarantined. The model chosen for the agent is dropped on the fallback,
+// which runs its own default model.
+func (a *trackedAgent) Execute(ctx context.Context, opts core.ExecuteOptions) (*core.ExecuteResult, error) {
+	monitor := a.registry.monitor
+	name, agent := a.name, a.Agent
+	if quarantined, reason := monitor.Quarantined(a.name); quarantined {
+		if fallbackName, fallback := a.fallback(); fallback != nil {
+			sub := core.AgentSubstitution{
+				Agent: a.name, Fallback: fallbackName, Phase: opts.Phase, Reason: reason, At: monitor.now(),
+			}
+			monitor.recordSubstitution(sub)
+			if a.registry.onSubstitute != nil {
+				a.registry.onSubstitute(sub)
+			}
+			monitor.opts.Logger.Info("running call on fallback agent",
+				slog.String("agent", a.name), slog.String("fallback", sub.Fallback),
+				slog.String("phase", string(opts.Phase)), slog.String("reason", reason))
+			name, agent = fallbackName, fallback
+			opts.Model = ""
+		}
+	}
+
+	start := time.Now()
+	result, err := agent.Execute(ctx, opts)
+	model := opts.Model
+	if result != nil && result.Model != "" {
+		model = result.Model
+	}
+	monitor.Record(name, model, time.Since(start), err)
+	return result, err
+}
+
+// fallback returns the fallback agent, or nil when there is none.
+func (a *trackedAgent) fallback() (string, core.Agent) {
+	name := a.registry.monitor.Fallback(a.name)
+	if name == "" {
+		return "", nil
+	}
+	agent, err := a.registry.AgentRegistry.Get(name)
+	if err != nil {
+		return "", nil
+	}
+	return name, agent
+}
@@ -0,0 +1,53 @@
+package agenthealth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/testutil"
+)
+
+func TestRegistry_SubstitutesQuarantinedAgents(t *testing.T) {
+	t.Parallel()
+	m, _ := newTestMonitor(Options{AuthErrors: 1, Fallbacks: map[string]string{"claude": "gemini"}})
+	claude := testutil.NewMockAgent("claude").WithError(core.ErrAuth("not logged in"))
+	gemini := testutil.NewMockAgent("gemini").WithResponse("from gemini")
+	base := testutil.NewMockRegistry()
+	base.Add("claude", claude)
+	base.Add("gemini", gemini)
+
+	var subs []core.AgentSubstitution
+	registry := m.Wrap(base, func(sub core.AgentSubstitution) { subs = append(subs, sub) })
+	agent, err := registry.Get("claude")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	ctx := context.Background()
+	opts := core.ExecuteOptions{Prompt: "hi", Model: "opus", Phase: core.PhaseAnalyze}
+
+	// The failure quarantines claude; the next call runs on gemini.
+	if _, err := agent.Execute(ctx, opts); err == nil {
+		t.Fatal("Execute() on claude succeeded")
+	}
+	result, err := agent.Execute(ctx, opts)
+	if err != nil || result.Output != "from gemini" {
+		t.Fatalf("Execute() = %+v, %v, want the fallback's result", result, err)
+	}
+	if claude.CallCount("Execute") != 1 || gemini.CallCount("Execute") != 1 {
+		t.Errorf("calls = claude %d, gemini %d", claude.CallCount("Execute"), gemini.CallCount("Execute"))
+	}
+	if got := gemini.Calls()[0].Args.(core.ExecuteOptions).Model; got != "" {
+		t.Errorf("fallback ran with model %q, want its default", got)
+	}
+
+	if len(subs) != 1 || subs[0].Agent != "claude" || subs[0].Fallback != "gemini" || subs[0].Phase != core.PhaseAnalyze {
+		t.Errorf("substitutions = %+v", subs)
+	}
+	if stats := m.Stats("claude"); len(stats.Substitutions) != 1 || stats.Fallback != "gemini" {
+		t.Errorf("Stats(claude) = %+v", stats)
+	}
+	if stats := m.Stats("gemini"); stats.Calls != 1 || stats.Failures != 0 {
+		t.Errorf("Stats(gemini) = %+v, want the substituted call", stats)
+	}
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/chatpromote"
+)
+
+// createChatWorkflow saves a pending workflow promoted from a chat session in
+// the given Kanban column, with the transcript and the session attachments
+// attached. It implements web.WorkflowCreator.
+func (s *Server) createChatWorkflow(ctx context.Context, conv *chatpromote.Conversation, prompt, column string) (core.WorkflowID, error) {
+	stateManager := s.getProjectStateManager(ctx)
+	if stateManager == nil {
+		return "", errors.New("workflow management not available")
+	}
+
+	workflowID := generateWorkflowID()
+	reportPath := filepath.Join(".quorum", "runs", string(workflowID))
+	fullReportPath := filepath.Join(s.getProjectRootPath(ctx), reportPath)
+	if err := os.MkdirAll(fullReportPath, 0o750); err != nil {
+		s.logger.Warn("failed to create report directory", "path", fullReportPath, "error", err)
+	}
+
+	state := newPendingWorkflowState(workflowID, conv.WorkflowTitle(), conv.Prompt(prompt), &core.Blueprint{}, reportPath)
+	state.KanbanColumn = column
+
+	transcriptID := ""
+	if store := s.getProjectAttachmentStore(ctx); store != nil {
+		saved, err := conv.SaveAttachments(store, workflowID)
+		if err != nil {
+			s.logger.Warn("failed to attach chat conversation", "workflow_id", workflowID, "error", err)
+		}
+		if len(saved) > 0 {
+			transcriptID = saved[0].ID
+		}
+		state.Attachments = append(state.Attachments, saved...)
+	}
+	state.SourceChat = conv.Link(transcriptID)
+
+	if err := stateManager.Save(ctx, state); err != nil {
+		return "", fmt.Errorf("saving workflow: %w", err)
+	}
+	return workflowID, nil
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+func TestChatPromote_CreatesLinkedWorkflow(t *testing.T) {
+	ts := newIssueTestServer(t)
+	handler := ts.srv.Handler()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/chat/sessions", bytes.NewBufferString(`{"agent":"claude"}`)))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create session: expected %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var session struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&session); err != nil {
+		t.Fatalf("decode session: %v", err)
+	}
+
+	body := `{"title":"Retry 503 responses","prompt":"Add retries with backoff.","kanban_column":"todo"}`
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/chat/sessions/"+session.ID+"/promote", bytes.NewBufferString(body)))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("promote: expected %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var resp struct {
+		WorkflowID string `json:"workflow_id"`
+		Title      string `json:"title"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+
+	state := ts.sm.workflows[core.WorkflowID(resp.WorkflowID)]
+	if state == nil {
+		t.Fatalf("workflow %q was not saved", resp.WorkflowID)
+	}
+	if state.Title != "Retry 503 responses" || state.KanbanColumn != "todo" {
+		t.Errorf("workflow = title %q, column %q", state.Title, state.KanbanColumn)
+	}
+	if !strings.HasPrefix(state.Prompt, "Add retries with backoff.") || !strings.Contains(state.Prompt, "chat-transcript.md") {
+		t.Errorf("Prompt = %q", state.Prompt)
+	}
+	if state.SourceChat == nil || state.SourceChat.SessionID != session.ID {
+		t.Fatalf("SourceChat = %+v", state.SourceChat)
+	}
+	if len(state.Attachments) != 1 || state.Attachments[0].ID != state.SourceChat.TranscriptAttachment {
+		t.Errorf("Attachments = %+v, want the transcript", state.Attachments)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/chat/sessions/"+session.ID, nil))
+	if !strings.Contains(w.Body.String(), `"workflow_id":"`+resp.WorkflowID+`"`) {
+		t.Errorf("session should link the workflow: %s", w.Body.String())
+	}
+}
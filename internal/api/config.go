@@ -12,6 +12,7 @@ import (
 
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/api/middleware"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/confighistory"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
 )
@@ -225,11 +226,13 @@ func (s *Server) handleUpdateConfig(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Save with atomic write
+	previous, _ := confighistory.ReadFile(configPath)
 	if err := atomicWriteConfig(cfg, configPath); err != nil {
 		s.logger.Error("failed to save config", "error", err)
 		respondError(w, http.StatusInternalServerError, "failed to save configuration")
 		return
 	}
+	version := s.recordConfigWrite(ctx, configPath, previous, req.Message)
 
 	// Calculate new ETag from file (must match how PATCH validation calculates it)
 	newETag, _ := calculateETagFromFile(configPath)
@@ -242,6 +245,7 @@ func (s *Server) handleUpdateConfig(w http.ResponseWriter, r *http.Request) {
 			Source:            "file",
 			Scope:             "project",
 			ProjectConfigMode: mode,
+			Version:           version,
 		},
 	}
 
@@ -276,12 +280,14 @@ func (s *Server) handleResetConfig(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Write the default configuration (same as quorum init)
+	previous, _ := confighistory.ReadFile(configPath)
 	if err := os.WriteFile(configPath, []byte(config.DefaultConfigYAML), 0o600); err != nil {
 		s.logger.Error("failed to write default config", "error", err)
 		respondError(w, http.StatusInternalServerError, "failed to write default configuration")
 		return
 	}
 	s.logger.Info("config reset to defaults", "path", configPath)
+	version := s.recordConfigWrite(ctx, configPath, previous, "Reset to defaults")
 
 	// Load the newly written config
 	cfg, err := config.NewLoader().WithConfigFile(configPath).WithResolvePaths(false).Load()
@@ -304,6 +310,7 @@ func (s *Server) handleResetConfig(w http.ResponseWriter, r *http.Request) {
 			Source:            "file",
 			Scope:             "project",
 			ProjectConfigMode: mode,
+			Version:           version,
 		},
 	}
 
@@ -435,11 +442,13 @@ func (s *Server) handleUpdateGlobalConfig(w http.ResponseWriter, r *http.Request
 	}
 
 	// Save with atomic write
+	previous, _ := confighistory.ReadFile(globalPath)
 	if err := atomicWriteConfig(cfg, globalPath); err != nil {
 		s.logger.Error("failed to save global config", "error", err)
 		respondError(w, http.StatusInternalServerError, "failed to save configuration")
 		return
 	}
+	version := s.recordConfigWrite(r.Context(), globalPath, previous, req.Message)
 
 	// New ETag from file bytes
 	newETag, _ := calculateETagFromFile(globalPath)
@@ -448,15 +457,16 @@ func (s *Server) handleUpdateGlobalConfig(w http.ResponseWriter, r *http.Request
 	respondJSON(w, http.StatusOK, ConfigResponseWithMeta{
 		Config: configToFullResponse(cfg),
 		Meta: ConfigMeta{
-			ETag:   newETag,
-			Source: "file",
-			Scope:  "global",
+			ETag:    newETag,
+			Source:  "file",
+			Scope:   "global",
+			Version: version,
 		},
 	})
 }
 
 // handleResetGlobalConfig resets the global configuration to defaults.
-func (s *Server) handleResetGlobalConfig(w http.ResponseWriter, _ *http.Request) {
+func (s *Server) handleResetGlobalConfig(w http.ResponseWriter, r *http.Request) {
 	// Use write lock to prevent concurrent modifications
 	s.configMu.Lock()
 	defer s.configMu.Unlock()
@@ -475,11 +485,13 @@ func (s *Server) handleResetGlobalConfig(w http.ResponseWriter, _ *http.Request)
 		return
 	}
 
+	previous, _ := confighistory.ReadFile(globalPath)
 	if err := os.WriteFile(globalPath, []byte(config.DefaultConfigYAML), 0o600); err != nil {
 		s.logger.Error("failed to write default global config", "error", err)
 		respondError(w, http.StatusInternalServerError, "failed to write default configuration")
 		return
 	}
+	version := s.recordConfigWrite(r.Context(), globalPath, previous, "Reset to defaults")
 
 	cfg, err := config.NewLoader().WithConfigFile(globalPath).WithResolvePaths(false).Load()
 	if err != nil {
@@ -496,9 +508,10 @@ func (s *Server) handleResetGlobalConfig(w http.ResponseWriter, _ *http.Request)
 	respondJSON(w, http.StatusOK, ConfigResponseWithMeta{
 		Config: configToFullResponse(cfg),
 		Meta: ConfigMeta{
-			ETag:   etag,
-			Source: "file",
-			Scope:  "global",
+			ETag:    etag,
+			Source:  "file",
+			Scope:   "global",
+			Version: version,
 		},
 	})
 }
@@ -527,6 +540,9 @@ func (s *Server) handleGetAgents(w http.ResponseWriter, _ *http.Request) {
 			entry["hasReasoningEffort"] = true
 			entry["reasoningEfforts"] = core.GetReasoningEfforts(name)
 		}
+		if s.agentHealth != nil {
+			entry["health"] = s.agentHealth.Stats(name)
+		}
 		agents = append(agents, entry)
 	}
 
@@ -652,6 +668,11 @@ func configToFullResponse(cfg *config.Config) FullConfigResponse {
 			},
 			Execute: ExecutePhaseConfigResponse{
 				Timeout: cfg.Phases.Execute.Timeout,
+				Input: ExecuteInputConfigResponse{
+					Timeout:   cfg.Phases.Execute.Input.Timeout,
+					OnTimeout: cfg.Phases.Execute.Input.OnTimeout,
+				},
+				SkipDependents: cfg.Phases.Execute.SkipDependents,
 			},
 		},
 		Agents: AgentsConfigResponse{
@@ -674,7 +695,8 @@ func configToFullResponse(cfg *config.Config) FullConfigResponse {
 				AutoClean: cfg.Git.Worktree.AutoClean,
 			},
 			Task: GitTaskConfigResponse{
-				AutoCommit: cfg.Git.Task.AutoCommit,
+				AutoCommit:         cfg.Git.Task.AutoCommit,
+				CheckpointInterval: cfg.Git.Task.CheckpointInterval,
 			},
 			Finalization: GitFinalizationConfigResponse{
 				AutoPush:      cfg.Git.Finalization.AutoPush,
@@ -682,6 +704,14 @@ func configToFullResponse(cfg *config.Config) FullConfigResponse {
 				AutoMerge:     cfg.Git.Finalization.AutoMerge,
 				PRBaseBranch:  cfg.Git.Finalization.PRBaseBranch,
 				MergeStrategy: cfg.Git.Finalization.MergeStrategy,
+				Babysit: PRBabysitConfigResponse{
+					Enabled:        cfg.Git.Finalization.Babysit.Enabled,
+					MaxAttempts:    cfg.Git.Finalization.Babysit.MaxAttempts,
+					Agent:          cfg.Git.Finalization.Babysit.Agent,
+					ChecksTimeout:  cfg.Git.Finalization.Babysit.ChecksTimeout,
+					PollInterval:   cfg.Git.Finalization.Babysit.PollInterval,
+					RequiredChecks: cfg.Git.Finalization.Babysit.RequiredChecks,
+				},
 			},
 		},
 		GitHub: GitHubConfigResponse{
@@ -691,6 +721,17 @@ func configToFullResponse(cfg *config.Config) FullConfigResponse {
 			Timeout:          cfg.Chat.Timeout,
 			ProgressInterval: cfg.Chat.ProgressInterval,
 			Editor:           cfg.Chat.Editor,
+			Context: ChatContextConfigResponse{
+				RecentTurns:  cfg.Chat.Context.RecentTurns,
+				MaxTokens:    cfg.Chat.Context.MaxTokens,
+				SummaryAgent: cfg.Chat.Context.SummaryAgent,
+				SummaryModel: cfg.Chat.Context.SummaryModel,
+			},
+			AskAll: ChatAskAllConfigResponse{
+				Agents:         cfg.Chat.AskAll.Agents,
+				Moderator:      cfg.Chat.AskAll.Moderator,
+				ModeratorModel: cfg.Chat.AskAll.ModeratorModel,
+			},
 		},
 		Report: ReportConfigResponse{
 			Enabled:    cfg.Report.Enabled,
@@ -733,6 +774,10 @@ func issuesToResponse(cfg *config.IssuesConfig) IssuesConfigResponse {
 	if assignees == nil {
 		assignees = []string{}
 	}
+	importLabels := cfg.Import.Labels
+	if importLabels == nil {
+		importLabels = []string{}
+	}
 
 	return IssuesConfigResponse{
 		Enabled:        cfg.Enabled,
@@ -768,6 +813,11 @@ func issuesToResponse(cfg *config.IssuesConfig) IssuesConfigResponse {
 			Instructions:      cfg.Generator.Instructions,
 			TitleInstructions: cfg.Generator.TitleInstructions,
 		},
+		Import: IssueImportConfigResponse{
+			Enabled:  cfg.Import.Enabled,
+			Labels:   importLabels,
+			Interval: cfg.Import.Interval,
+		},
 	}
 }
 
@@ -1006,6 +1056,17 @@ func applyExecutePhaseUpdates(cfg *config.ExecutePhaseConfig, update *ExecutePha
 	if update.Timeout != nil {
 		cfg.Timeout = *update.Timeout
 	}
+	if update.Input != nil {
+		if update.Input.Timeout != nil {
+			cfg.Input.Timeout = *update.Input.Timeout
+		}
+		if update.Input.OnTimeout != nil {
+			cfg.Input.OnTimeout = *update.Input.OnTimeout
+		}
+	}
+	if update.SkipDependents != nil {
+		cfg.SkipDependents = *update.SkipDependents
+	}
 }
 
 func applyAgentsUpdates(cfg *config.AgentsConfig, update *AgentsConfigUpdate) {
@@ -1086,6 +1147,9 @@ func applyGitUpdates(cfg *config.GitConfig, update *GitConfigUpdate) {
 		if update.Task.AutoCommit != nil {
 			cfg.Task.AutoCommit = *update.Task.AutoCommit
 		}
+		if update.Task.CheckpointInterval != nil {
+			cfg.Task.CheckpointInterval = *update.Task.CheckpointInterval
+		}
 	}
 	// Finalization updates
 	if update.Finalization != nil {
@@ -1104,6 +1168,30 @@ func applyGitUpdates(cfg *config.GitConfig, update *GitConfigUpdate) {
 		if update.Finalization.MergeStrategy != nil {
 			cfg.Finalization.MergeStrategy = *update.Finalization.MergeStrategy
 		}
+		if update.Finalization.Babysit != nil {
+			applyPRBabysitUpdates(&cfg.Finalization.Babysit, update.Finalization.Babysit)
+		}
+	}
+}
+
+func applyPRBabysitUpdates(cfg *config.PRBabysitConfig, update *PRBabysitConfigUpdate) {
+	if update.Enabled != nil {
+		cfg.Enabled = *update.Enabled
+	}
+	if update.MaxAttempts != nil {
+		cfg.MaxAttempts = *update.MaxAttempts
+	}
+	if update.Agent != nil {
+		cfg.Agent = *update.Agent
+	}
+	if update.ChecksTimeout != nil {
+		cfg.ChecksTimeout = *update.ChecksTimeout
+	}
+	if update.PollInterval != nil {
+		cfg.PollInterval = *update.PollInterval
+	}
+	if update.RequiredChecks != nil {
+		cfg.RequiredChecks = *update.RequiredChecks
 	}
 }
 
@@ -1123,6 +1211,39 @@ func applyChatUpdates(cfg *config.ChatConfig, update *ChatConfigUpdate) {
 	if update.Editor != nil {
 		cfg.Editor = *update.Editor
 	}
+	if update.Context != nil {
+		applyChatContextUpdates(&cfg.Context, update.Context)
+	}
+	if update.AskAll != nil {
+		applyChatAskAllUpdates(&cfg.AskAll, update.AskAll)
+	}
+}
+
+func applyChatContextUpdates(cfg *config.ChatContextConfig, update *ChatContextConfigUpdate) {
+	if update.RecentTurns != nil {
+		cfg.RecentTurns = *update.RecentTurns
+	}
+	if update.MaxTokens != nil {
+		cfg.MaxTokens = *update.MaxTokens
+	}
+	if update.SummaryAgent != nil {
+		cfg.SummaryAgent = *update.SummaryAgent
+	}
+	if update.SummaryModel != nil {
+		cfg.SummaryModel = *update.SummaryModel
+	}
+}
+
+func applyChatAskAllUpdates(cfg *config.ChatAskAllConfig, update *ChatAskAllConfigUpdate) {
+	if update.Agents != nil {
+		cfg.Agents = *update.Agents
+	}
+	if update.Moderator != nil {
+		cfg.Moderator = *update.Moderator
+	}
+	if update.ModeratorModel != nil {
+		cfg.ModeratorModel = *update.ModeratorModel
+	}
 }
 
 func applyReportUpdates(cfg *config.ReportConfig, update *ReportConfigUpdate) {
@@ -1240,6 +1361,21 @@ func applyIssuesUpdates(cfg *config.IssuesConfig, update *IssuesConfigUpdate) {
 	if update.Generator != nil {
 		applyIssueGeneratorUpdates(&cfg.Generator, update.Generator)
 	}
+	if update.Import != nil {
+		applyIssueImportUpdates(&cfg.Import, update.Import)
+	}
+}
+
+func applyIssueImportUpdates(cfg *config.IssueImportConfig, update *IssueImportConfigUpdate) {
+	if update.Enabled != nil {
+		cfg.Enabled = *update.Enabled
+	}
+	if update.Labels != nil {
+		cfg.Labels = *update.Labels
+	}
+	if update.Interval != nil {
+		cfg.Interval = *update.Interval
+	}
 }
 
 func applyIssuePromptUpdates(cfg *config.IssuePromptConfig, update *IssuePromptConfigUpdate) {
@@ -0,0 +1,181 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/confighistory"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
+)
+
+// defaultConfigHistoryLimit bounds the versions returned by GET /config/history.
+const defaultConfigHistoryLimit = 50
+
+// ConfigHistoryResponse lists the versions of a config file, newest first.
+type ConfigHistoryResponse struct {
+	Scope    string                   `json:"scope"` // "global" | "project"
+	Versions []*confighistory.Version `json:"versions"`
+}
+
+// ConfigRollbackRequest is the optional body of POST /config/rollback/{version}.
+type ConfigRollbackRequest struct {
+	Message string `json:"message,omitempty"`
+}
+
+// recordConfigWrite records a config write in the file's history and returns
+// the new version. Failures are logged and reported as version 0: the write
+// itself already succeeded.
+func (s *Server) recordConfigWrite(ctx context.Context, path string, previous []byte, message string) int {
+	v, err := confighistory.RecordWrite(ctx, path, previous, confighistory.Change{
+		Source:  confighistory.SourceAPI,
+		Message: message,
+	})
+	if err != nil {
+		s.logger.Warn("failed to record config history", "path", path, "error", err)
+		return 0
+	}
+	return v.Version
+}
+
+// historyConfigPath resolves the config file of the "scope" query parameter:
+// "global", or the project's effective config when empty.
+func (s *Server) historyConfigPath(r *http.Request) (path, scope string, err error) {
+	if r.URL.Query().Get("scope") == "global" {
+		path, err = config.EnsureGlobalConfigFile()
+		return path, "global", err
+	}
+	path, scope, _, err = s.effectiveConfigPath(r.Context())
+	return path, scope, err
+}
+
+// handleGetConfigHistory lists the recorded versions of the config file.
+func (s *Server) handleGetConfigHistory(w http.ResponseWriter, r *http.Request) {
+	path, scope, err := s.historyConfigPath(r)
+	if err != nil {
+		s.logger.Error("failed to resolve config path", "error", err)
+		respondError(w, http.StatusInternalServerError, "failed to resolve configuration path")
+		return
+	}
+
+	limit := defaultConfigHistoryLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 1 {
+			respondError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+	}
+
+	response := ConfigHistoryResponse{Scope: scope, Versions: []*confighistory.Version{}}
+	// Do not create a history database just to read it.
+	if _, err := os.Stat(confighistory.PathFor(path)); os.IsNotExist(err) {
+		respondJSON(w, http.StatusOK, response)
+		return
+	}
+
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+
+	store, err := confighistory.OpenFor(path)
+	if err != nil {
+		s.logger.Error("failed to open config history", "path", path, "error", err)
+		respondError(w, http.StatusInternalServerError, "failed to load configuration history")
+		return
+	}
+	defer store.Close()
+
+	versions, err := store.List(r.Context(), limit)
+	if err != nil {
+		s.logger.Error("failed to list config history", "path", path, "error", err)
+		respondError(w, http.StatusInternalServerError, "failed to load configuration history")
+		return
+	}
+	if versions != nil {
+		response.Versions = versions
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// handleRollbackConfig restores a recorded version of the config file.
+func (s *Server) handleRollbackConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil || version < 1 {
+		respondError(w, http.StatusBadRequest, "invalid version")
+		return
+	}
+
+	var req ConfigRollbackRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	mode, _ := s.getProjectConfigMode(ctx)
+	if r.URL.Query().Get("scope") != "global" && mode == project.ConfigModeInheritGlobal {
+		respondJSON(w, http.StatusConflict, map[string]interface{}{
+			"error": "project inherits global configuration; roll back the global configuration with scope=global",
+			"code":  "INHERITS_GLOBAL",
+		})
+		return
+	}
+	path, scope, err := s.historyConfigPath(r)
+	if err != nil {
+		s.logger.Error("failed to resolve config path", "error", err)
+		respondError(w, http.StatusInternalServerError, "failed to resolve configuration path")
+		return
+	}
+
+	v, err := confighistory.Rollback(ctx, path, version, confighistory.Change{
+		Source:  confighistory.SourceAPI,
+		Message: req.Message,
+	})
+	switch {
+	case errors.Is(err, confighistory.ErrVersionNotFound):
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, confighistory.ErrInvalidConfig):
+		respondError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	case err != nil:
+		s.logger.Error("failed to roll back config", "path", path, "version", version, "error", err)
+		respondError(w, http.StatusInternalServerError, "failed to roll back configuration")
+		return
+	}
+	s.logger.Info("config rolled back", "path", path, "to_version", version, "version", v.Version)
+
+	cfg, err := config.NewLoader().WithConfigFile(path).WithResolvePaths(false).Load()
+	if err != nil {
+		s.logger.Error("failed to load config", "error", err)
+		respondError(w, http.StatusInternalServerError, "failed to load configuration")
+		return
+	}
+	etag, _ := calculateETagFromFile(path)
+	w.Header().Set("ETag", fmt.Sprintf("%q", etag))
+
+	meta := ConfigMeta{
+		ETag:    etag,
+		Source:  "file",
+		Scope:   scope,
+		Version: v.Version,
+	}
+	if scope == "project" {
+		meta.ProjectConfigMode = mode
+	}
+	respondJSON(w, http.StatusOK, ConfigResponseWithMeta{
+		Config: configToFullResponse(cfg),
+		Meta:   meta,
+	})
+}
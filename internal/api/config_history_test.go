@@ -0,0 +1,82 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConfigHistory_RecordAndRollback(t *testing.T) {
+	t.Parallel()
+	srv := setupConfigTestServer(t)
+
+	patch := func(body string) ConfigResponseWithMeta {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/config?force=true", bytes.NewBufferString(body))
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("PATCH status = %d: %s", rec.Code, rec.Body.String())
+		}
+		var resp ConfigResponseWithMeta
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("unmarshal response: %v", err)
+		}
+		return resp
+	}
+
+	first := patch(`{
+		"message": "verbose logs",
+		"log": {"level": "debug"},
+		"agents": {"default": "claude", "claude": {"enabled": true, "phases": {"plan": true, "execute": true, "synthesize": true}}},
+		"phases": {"analyze": {"refiner": {"enabled": false}, "moderator": {"enabled": false}, "synthesizer": {"agent": "claude"}}},
+		"git": {"worktree": {"dir": ".worktrees", "mode": "parallel"}, "task": {"auto_commit": true}}
+	}`)
+	if first.Meta.Version != 1 {
+		t.Errorf("first write version = %d", first.Meta.Version)
+	}
+	if second := patch(`{"log": {"level": "warn"}}`); second.Meta.Version != 2 {
+		t.Errorf("second write version = %d", second.Meta.Version)
+	}
+
+	rec := httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/config/history", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET history status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var history ConfigHistoryResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &history); err != nil {
+		t.Fatalf("unmarshal history: %v", err)
+	}
+	if history.Scope != "project" || len(history.Versions) != 2 {
+		t.Fatalf("history = %+v", history)
+	}
+	if v := history.Versions[1]; v.Version != 1 || v.Source != "api" || v.Message != "verbose logs" {
+		t.Errorf("oldest version = %+v", v)
+	}
+	if history.Versions[0].Diff == "" {
+		t.Error("versions should carry a diff")
+	}
+
+	rec = httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/config/rollback/1",
+		bytes.NewBufferString(`{"message": "warn hid the failure"}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("rollback status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var rolledBack ConfigResponseWithMeta
+	if err := json.Unmarshal(rec.Body.Bytes(), &rolledBack); err != nil {
+		t.Fatalf("unmarshal rollback: %v", err)
+	}
+	if rolledBack.Config.Log.Level != "debug" || rolledBack.Meta.Version != 3 {
+		t.Errorf("rollback = level %q, version %d", rolledBack.Config.Log.Level, rolledBack.Meta.Version)
+	}
+
+	rec = httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/config/rollback/99", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("rollback to a missing version status = %d", rec.Code)
+	}
+}
@@ -41,13 +41,26 @@ type FieldDependency struct {
 	Value interface{} `json:"value"`
 }
 
+// Field returns the schema of the field at a dotted config path.
+func (s ConfigSchema) Field(path string) (SchemaField, bool) {
+	for _, section := range s.Sections {
+		for _, field := range section.Fields {
+			if field.Path == path {
+				return field, true
+			}
+		}
+	}
+	return SchemaField{}, false
+}
+
 // handleGetConfigSchema returns the configuration schema for UI generation.
 func (s *Server) handleGetConfigSchema(w http.ResponseWriter, _ *http.Request) {
-	schema := buildConfigSchema()
+	schema := BuildConfigSchema()
 	respondJSON(w, http.StatusOK, schema)
 }
 
-func buildConfigSchema() ConfigSchema {
+// BuildConfigSchema returns the schema of the editable configuration fields.
+func BuildConfigSchema() ConfigSchema {
 	return ConfigSchema{
 		Sections: []SchemaSection{
 			buildLogSection(),
@@ -101,6 +114,7 @@ func buildLogSection() SchemaSection {
 }
 
 func buildChatSection() SchemaSection {
+	min0 := float64(0)
 	return SchemaSection{
 		ID:          "chat",
 		Title:       "Chat Settings",
@@ -134,6 +148,73 @@ func buildChatSection() SchemaSection {
 				Default:     "vim",
 				Category:    "basic",
 			},
+			{
+				Path:        "chat.context.recent_turns",
+				Type:        "int",
+				Title:       "Recent Turns",
+				Description: "Most recent messages always sent verbatim",
+				Tooltip:     "Older messages that do not fit the context window are summarized.",
+				Default:     6,
+				Min:         &min0,
+				Category:    "advanced",
+			},
+			{
+				Path:        "chat.context.max_tokens",
+				Type:        "int",
+				Title:       "Context Token Limit",
+				Description: "Caps the tokens of conversation context sent per message",
+				Tooltip:     "0 uses the context window of the agent's model.",
+				Default:     0,
+				Min:         &min0,
+				Category:    "advanced",
+			},
+			{
+				Path:        "chat.context.summary_agent",
+				Type:        "string",
+				Title:       "Summary Agent",
+				Description: "Agent that summarizes older messages",
+				Tooltip:     "Prefer a fast, cheap agent. Empty uses the chat agent.",
+				Default:     "",
+				ValidValues: []string{"claude", "gemini", "codex", "copilot", "opencode"},
+				Category:    "advanced",
+			},
+			{
+				Path:        "chat.context.summary_model",
+				Type:        "string",
+				Title:       "Summary Model",
+				Description: "Model used for summaries",
+				Tooltip:     "Empty uses the summary agent's default model.",
+				Default:     "",
+				Category:    "advanced",
+			},
+			{
+				Path:        "chat.ask_all.agents",
+				Type:        "[]string",
+				Title:       "Ask-All Agents",
+				Description: "Agents asked by default when asking all agents",
+				Tooltip:     "Empty asks every enabled agent. At least two are needed.",
+				Default:     []string{},
+				Category:    "advanced",
+			},
+			{
+				Path:        "chat.ask_all.moderator",
+				Type:        "string",
+				Title:       "Ask-All Moderator",
+				Description: "Agent that synthesizes the answers",
+				Tooltip:     "Empty uses the first agent asked.",
+				Default:     "",
+				ValidValues: []string{"claude", "gemini", "codex", "copilot", "opencode"},
+				Category:    "advanced",
+			},
+			{
+				Path:        "chat.ask_all.moderator_model",
+				Type:        "string",
+				Title:       "Ask-All Moderator Model",
+				Description: "Model used for the synthesis",
+				Tooltip:     "Empty uses the moderator's default model.",
+				Default:     "",
+				Category:    "advanced",
+			},
 		},
 	}
 }
@@ -9,9 +9,12 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/agenthealth"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/api/middleware"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
 )
@@ -451,6 +454,42 @@ func TestHandleGetAgents_ModelLists(t *testing.T) {
 	}
 }
 
+func TestHandleGetAgents_ReportsHealth(t *testing.T) {
+	t.Parallel()
+	monitor := agenthealth.New(agenthealth.Options{AuthErrors: 1, Fallbacks: map[string]string{"claude": "gemini"}})
+	monitor.Record("claude", "opus", time.Second, core.ErrAuth("not logged in"))
+	eb := events.New(100)
+	t.Cleanup(eb.Close)
+	srv := NewServer(newMockStateManager(), eb, WithRoot(t.TempDir()), WithAgentHealth(monitor))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/config/agents", nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	var agents []struct {
+		Name   string             `json:"name"`
+		Health *agenthealth.Stats `json:"health"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &agents); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	for _, agent := range agents {
+		if agent.Health == nil {
+			t.Fatalf("agent %q has no health", agent.Name)
+		}
+		if agent.Name != "claude" {
+			continue
+		}
+		h := agent.Health
+		if !h.Quarantined || h.Fallback != "gemini" || h.AuthErrors != 1 || h.Models["opus"].Calls != 1 {
+			t.Errorf("claude health = %+v", h)
+		}
+	}
+}
+
 // ---------------------------------------------------------------------------
 // configToFullResponse coverage
 // ---------------------------------------------------------------------------
@@ -22,6 +22,8 @@ type ConfigMeta struct {
 	// or uses a project-specific config file.
 	// Values: "inherit_global" | "custom"
 	ProjectConfigMode string `json:"project_config_mode,omitempty"`
+	// Version is the config history version written by an update, reset or rollback.
+	Version int `json:"version,omitempty"`
 
 	// Runtime apply info (best-effort): whether this config was applied to the server runtime.
 	RuntimeApplyStatus string `json:"runtime_apply_status,omitempty"` // "applied" | "failed"
@@ -145,7 +147,15 @@ type PlanSynthesizerConfigResponse struct {
 
 // ExecutePhaseConfigResponse represents execute phase configuration.
 type ExecutePhaseConfigResponse struct {
-	Timeout string `json:"timeout"`
+	Timeout        string                     `json:"timeout"`
+	Input          ExecuteInputConfigResponse `json:"input"`
+	SkipDependents string                     `json:"skip_dependents"`
+}
+
+// ExecuteInputConfigResponse represents task question configuration.
+type ExecuteInputConfigResponse struct {
+	Timeout   string `json:"timeout"`
+	OnTimeout string `json:"on_timeout"`
 }
 
 // AgentsConfigResponse represents all agent configurations.
@@ -193,16 +203,28 @@ type WorktreeConfigResponse struct {
 
 // GitTaskConfigResponse represents per-task progress configuration.
 type GitTaskConfigResponse struct {
-	AutoCommit bool `json:"auto_commit"`
+	AutoCommit         bool   `json:"auto_commit"`
+	CheckpointInterval string `json:"checkpoint_interval"`
 }
 
 // GitFinalizationConfigResponse represents workflow finalization configuration.
 type GitFinalizationConfigResponse struct {
-	AutoPush      bool   `json:"auto_push"`
-	AutoPR        bool   `json:"auto_pr"`
-	AutoMerge     bool   `json:"auto_merge"`
-	PRBaseBranch  string `json:"pr_base_branch"`
-	MergeStrategy string `json:"merge_strategy"`
+	AutoPush      bool                    `json:"auto_push"`
+	AutoPR        bool                    `json:"auto_pr"`
+	AutoMerge     bool                    `json:"auto_merge"`
+	PRBaseBranch  string                  `json:"pr_base_branch"`
+	MergeStrategy string                  `json:"merge_strategy"`
+	Babysit       PRBabysitConfigResponse `json:"babysit"`
+}
+
+// PRBabysitConfigResponse represents the post-PR CI babysitting configuration.
+type PRBabysitConfigResponse struct {
+	Enabled        bool     `json:"enabled"`
+	MaxAttempts    int      `json:"max_attempts"`
+	Agent          string   `json:"agent"`
+	ChecksTimeout  string   `json:"checks_timeout"`
+	PollInterval   string   `json:"poll_interval"`
+	RequiredChecks []string `json:"required_checks"`
 }
 
 // GitHubConfigResponse represents GitHub configuration.
@@ -212,9 +234,26 @@ type GitHubConfigResponse struct {
 
 // ChatConfigResponse represents chat configuration.
 type ChatConfigResponse struct {
-	Timeout          string `json:"timeout"`
-	ProgressInterval string `json:"progress_interval"`
-	Editor           string `json:"editor"`
+	Timeout          string                    `json:"timeout"`
+	ProgressInterval string                    `json:"progress_interval"`
+	Editor           string                    `json:"editor"`
+	Context          ChatContextConfigResponse `json:"context"`
+	AskAll           ChatAskAllConfigResponse  `json:"ask_all"`
+}
+
+// ChatContextConfigResponse represents chat context management configuration.
+type ChatContextConfigResponse struct {
+	RecentTurns  int    `json:"recent_turns"`
+	MaxTokens    int    `json:"max_tokens"`
+	SummaryAgent string `json:"summary_agent"`
+	SummaryModel string `json:"summary_model"`
+}
+
+// ChatAskAllConfigResponse represents the defaults for asking several agents at once.
+type ChatAskAllConfigResponse struct {
+	Agents         []string `json:"agents"`
+	Moderator      string   `json:"moderator"`
+	ModeratorModel string   `json:"moderator_model"`
 }
 
 // ReportConfigResponse represents report configuration.
@@ -272,6 +311,14 @@ type IssuesConfigResponse struct {
 	Assignees      []string                     `json:"default_assignees"`
 	GitLab         GitLabIssueConfigResponse    `json:"gitlab"`
 	Generator      IssueGeneratorConfigResponse `json:"generator"`
+	Import         IssueImportConfigResponse    `json:"import"`
+}
+
+// IssueImportConfigResponse represents label-based issue import configuration.
+type IssueImportConfigResponse struct {
+	Enabled  bool     `json:"enabled"`
+	Labels   []string `json:"labels"`
+	Interval string   `json:"interval"`
 }
 
 // IssuePromptConfigResponse represents issue prompt configuration.
@@ -322,6 +369,8 @@ type FullConfigUpdate struct {
 	Report      *ReportConfigUpdate      `json:"report,omitempty"`
 	Diagnostics *DiagnosticsConfigUpdate `json:"diagnostics,omitempty"`
 	Issues      *IssuesConfigUpdate      `json:"issues,omitempty"`
+	// Message is recorded in the config history; it is not a config value.
+	Message string `json:"message,omitempty"`
 }
 
 // LogConfigUpdate represents log configuration update.
@@ -424,7 +473,15 @@ type PlanSynthesizerConfigUpdate struct {
 
 // ExecutePhaseConfigUpdate represents execute phase update.
 type ExecutePhaseConfigUpdate struct {
-	Timeout *string `json:"timeout,omitempty"`
+	Timeout        *string                   `json:"timeout,omitempty"`
+	Input          *ExecuteInputConfigUpdate `json:"input,omitempty"`
+	SkipDependents *string                   `json:"skip_dependents,omitempty"`
+}
+
+// ExecuteInputConfigUpdate represents task question update.
+type ExecuteInputConfigUpdate struct {
+	Timeout   *string `json:"timeout,omitempty"`
+	OnTimeout *string `json:"on_timeout,omitempty"`
 }
 
 // AgentsConfigUpdate represents agents configuration update.
@@ -472,16 +529,28 @@ type WorktreeConfigUpdate struct {
 
 // GitTaskConfigUpdate represents task configuration update.
 type GitTaskConfigUpdate struct {
-	AutoCommit *bool `json:"auto_commit,omitempty"`
+	AutoCommit         *bool   `json:"auto_commit,omitempty"`
+	CheckpointInterval *string `json:"checkpoint_interval,omitempty"`
 }
 
 // GitFinalizationConfigUpdate represents finalization configuration update.
 type GitFinalizationConfigUpdate struct {
-	AutoPush      *bool   `json:"auto_push,omitempty"`
-	AutoPR        *bool   `json:"auto_pr,omitempty"`
-	AutoMerge     *bool   `json:"auto_merge,omitempty"`
-	PRBaseBranch  *string `json:"pr_base_branch,omitempty"`
-	MergeStrategy *string `json:"merge_strategy,omitempty"`
+	AutoPush      *bool                  `json:"auto_push,omitempty"`
+	AutoPR        *bool                  `json:"auto_pr,omitempty"`
+	AutoMerge     *bool                  `json:"auto_merge,omitempty"`
+	PRBaseBranch  *string                `json:"pr_base_branch,omitempty"`
+	MergeStrategy *string                `json:"merge_strategy,omitempty"`
+	Babysit       *PRBabysitConfigUpdate `json:"babysit,omitempty"`
+}
+
+// PRBabysitConfigUpdate represents PR babysitting configuration update.
+type PRBabysitConfigUpdate struct {
+	Enabled        *bool     `json:"enabled,omitempty"`
+	MaxAttempts    *int      `json:"max_attempts,omitempty"`
+	Agent          *string   `json:"agent,omitempty"`
+	ChecksTimeout  *string   `json:"checks_timeout,omitempty"`
+	PollInterval   *string   `json:"poll_interval,omitempty"`
+	RequiredChecks *[]string `json:"required_checks,omitempty"`
 }
 
 // GitHubConfigUpdate represents GitHub configuration update.
@@ -491,9 +560,26 @@ type GitHubConfigUpdate struct {
 
 // ChatConfigUpdate represents chat configuration update.
 type ChatConfigUpdate struct {
-	Timeout          *string `json:"timeout,omitempty"`
-	ProgressInterval *string `json:"progress_interval,omitempty"`
-	Editor           *string `json:"editor,omitempty"`
+	Timeout          *string                  `json:"timeout,omitempty"`
+	ProgressInterval *string                  `json:"progress_interval,omitempty"`
+	Editor           *string                  `json:"editor,omitempty"`
+	Context          *ChatContextConfigUpdate `json:"context,omitempty"`
+	AskAll           *ChatAskAllConfigUpdate  `json:"ask_all,omitempty"`
+}
+
+// ChatContextConfigUpdate represents chat context management update.
+type ChatContextConfigUpdate struct {
+	RecentTurns  *int    `json:"recent_turns,omitempty"`
+	MaxTokens    *int    `json:"max_tokens,omitempty"`
+	SummaryAgent *string `json:"summary_agent,omitempty"`
+	SummaryModel *string `json:"summary_model,omitempty"`
+}
+
+// ChatAskAllConfigUpdate represents the ask-all defaults update.
+type ChatAskAllConfigUpdate struct {
+	Agents         *[]string `json:"agents,omitempty"`
+	Moderator      *string   `json:"moderator,omitempty"`
+	ModeratorModel *string   `json:"moderator_model,omitempty"`
 }
 
 // ReportConfigUpdate represents report configuration update.
@@ -551,6 +637,14 @@ type IssuesConfigUpdate struct {
 	Assignees      *[]string                   `json:"default_assignees,omitempty"`
 	GitLab         *GitLabIssueConfigUpdate    `json:"gitlab,omitempty"`
 	Generator      *IssueGeneratorConfigUpdate `json:"generator,omitempty"`
+	Import         *IssueImportConfigUpdate    `json:"import,omitempty"`
+}
+
+// IssueImportConfigUpdate represents issue import update.
+type IssueImportConfigUpdate struct {
+	Enabled  *bool     `json:"enabled,omitempty"`
+	Labels   *[]string `json:"labels,omitempty"`
+	Interval *string   `json:"interval,omitempty"`
 }
 
 // IssuePromptConfigUpdate represents issue prompt update.
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/api/middleware"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
@@ -23,6 +24,8 @@ type EffectiveExecutionConfig struct {
 	ConfigMode    string // "inherit_global" | "custom"
 	FileETag      string
 	EffectiveETag string
+	// Warning explains why the config file on disk was not used, if it was not.
+	Warning string
 
 	ProjectID   string
 	ProjectRoot string
@@ -62,7 +65,8 @@ func ResolveEffectiveExecutionConfig(ctx context.Context) (*EffectiveExecutionCo
 
 	// Try to read explicit config_mode from the concrete project context if available.
 	explicitMode := ""
-	if concrete, ok := pc.(*project.ProjectContext); ok && concrete != nil {
+	concrete, _ := pc.(*project.ProjectContext)
+	if concrete != nil {
 		explicitMode = concrete.ConfigMode
 	}
 
@@ -82,6 +86,15 @@ func ResolveEffectiveExecutionConfig(ctx context.Context) (*EffectiveExecutionCo
 		configPath = globalPath
 	}
 
+	// A context that watches its config keeps the last valid version active:
+	// an invalid edit on disk is rejected and new workflows keep using it.
+	if concrete != nil {
+		snapshot, reloadErr := concrete.ReloadConfig()
+		if snapshot != nil && snapshot.Path == configPath {
+			return effectiveConfigFromSnapshot(pc, snapshot, mode, reloadErr)
+		}
+	}
+
 	// Enforce that the effective config file exists.
 	if _, statErr := os.Stat(configPath); statErr != nil {
 		if os.IsNotExist(statErr) {
@@ -144,3 +157,29 @@ func ResolveEffectiveExecutionConfig(ctx context.Context) (*EffectiveExecutionCo
 		ProjectRoot:   projectRoot,
 	}, nil
 }
+
+// effectiveConfigFromSnapshot builds the execution config from a project
+// context's active config. reloadErr is the rejection of the file on disk.
+func effectiveConfigFromSnapshot(pc middleware.ProjectContext, snapshot *project.ConfigSnapshot, mode string, reloadErr error) (*EffectiveExecutionConfig, error) {
+	effectiveETag, err := calculateETag(snapshot.Config)
+	if err != nil {
+		return nil, fmt.Errorf("calculating effective config ETag: %w", err)
+	}
+	warning := ""
+	if reloadErr != nil {
+		warning = fmt.Sprintf("config file change rejected, using the version loaded at %s: %v",
+			snapshot.LoadedAt.Format(time.RFC3339), reloadErr)
+	}
+	return &EffectiveExecutionConfig{
+		Config:        snapshot.Config,
+		RawYAML:       snapshot.Raw,
+		ConfigPath:    snapshot.Path,
+		ConfigScope:   snapshot.Scope,
+		ConfigMode:    mode,
+		FileETag:      snapshot.FileETag(),
+		EffectiveETag: effectiveETag,
+		Warning:       warning,
+		ProjectID:     pc.ProjectID(),
+		ProjectRoot:   pc.ProjectRoot(),
+	}, nil
+}
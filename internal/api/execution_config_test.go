@@ -295,3 +295,49 @@ func TestEffectiveExecutionConfig_StructFields(t *testing.T) {
 		t.Errorf("expected 'abc123', got %q", cfg.FileETag)
 	}
 }
+
+func TestResolveEffectiveExecutionConfig_WatchedContextKeepsLastValidConfig(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".quorum", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, []byte(config.DefaultConfigYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	pc, err := project.NewProjectContext("p-watch", tmpDir, project.WithConfigWatch(true))
+	if err != nil {
+		t.Fatalf("NewProjectContext: %v", err)
+	}
+	defer pc.Close()
+	ctx := middleware.WithProjectContext(context.Background(), pc)
+
+	// A valid edit is used right away, without waiting for the watcher.
+	if err := os.WriteFile(configPath, []byte(config.DefaultConfigYAML+"\nlog:\n  level: debug\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	result, err := ResolveEffectiveExecutionConfig(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Config.Log.Level != "debug" || result.Warning != "" {
+		t.Errorf("valid edit: level %q, warning %q", result.Config.Log.Level, result.Warning)
+	}
+
+	// An invalid edit does not block new workflows: the last valid config is used.
+	if err := os.WriteFile(configPath, []byte(config.DefaultConfigYAML+"\nlog:\n  level: loud\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	result, err = ResolveEffectiveExecutionConfig(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Config.Log.Level != "debug" || result.Warning == "" {
+		t.Errorf("invalid edit: level %q, warning %q", result.Config.Log.Level, result.Warning)
+	}
+	if string(result.RawYAML) == "" || result.FileETag == "" {
+		t.Error("expected the active config's content and ETag")
+	}
+}
@@ -0,0 +1,248 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/issues"
+)
+
+// errActiveIssueWorkflow is returned when a pending or running workflow was
+// already created from the same issue.
+var errActiveIssueWorkflow = errors.New("an active workflow already exists for this issue")
+
+// CreateWorkflowFromIssueRequest is the request body for creating a workflow
+// from an issue.
+type CreateWorkflowFromIssueRequest struct {
+	// Issue is "123", "#123", "owner/repo#123" or an issue URL.
+	Issue string `json:"issue"`
+	// Title overrides the default "#123 issue title" workflow title.
+	Title string `json:"title,omitempty"`
+	// KanbanColumn is "refinement" (default) or "todo".
+	KanbanColumn string `json:"kanban_column,omitempty"`
+}
+
+// ImportIssuesResponse is the response of a manual issue import.
+type ImportIssuesResponse struct {
+	Imported []ImportedIssueResponse `json:"imported"`
+	Error    string                  `json:"error,omitempty"`
+}
+
+// ImportedIssueResponse describes one imported issue.
+type ImportedIssueResponse struct {
+	Number     int    `json:"number"`
+	WorkflowID string `json:"workflow_id"`
+}
+
+// handleCreateWorkflowFromIssue creates a pending workflow from an issue.
+// POST /api/v1/workflows/from-issue
+func (s *Server) handleCreateWorkflowFromIssue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	stateManager := s.getProjectStateManager(ctx)
+	if stateManager == nil {
+		respondError(w, http.StatusServiceUnavailable, "workflow management not available")
+		return
+	}
+
+	var req CreateWorkflowFromIssueRequest
+	if json.NewDecoder(r.Body).Decode(&req) != nil {
+		respondError(w, http.StatusBadRequest, msgInvalidRequestBody)
+		return
+	}
+	if req.Issue == "" {
+		respondError(w, http.StatusBadRequest, "issue is required")
+		return
+	}
+	repository, number, err := issues.ParseIssueRef(req.Issue)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	column := req.KanbanColumn
+	if column == "" {
+		column = "refinement"
+	}
+	if column != "refinement" && column != "todo" {
+		respondError(w, http.StatusBadRequest, "kanban_column must be refinement or todo")
+		return
+	}
+
+	cfg, err := s.loadConfigForContext(ctx)
+	if err != nil {
+		s.logger.Error("failed to load config", "error", err)
+		respondError(w, http.StatusInternalServerError, "failed to load configuration")
+		return
+	}
+	issuesCfg := cfg.Issues
+	if repository != "" {
+		issuesCfg.Repository = repository
+	}
+	client, err := createIssueClient(issuesCfg)
+	if err != nil {
+		writeIssueClientError(w, err)
+		return
+	}
+
+	src, err := issues.FetchSource(ctx, client, core.IssueProvider(issuesCfg.Provider), issuesCfg.Repository, number)
+	if err != nil {
+		respondError(w, http.StatusBadGateway, fmt.Sprintf("failed to fetch issue #%d: %v", number, err))
+		return
+	}
+
+	state, err := s.createIssueWorkflow(ctx, src, column, req.Title)
+	if err != nil {
+		if errors.Is(err, errActiveIssueWorkflow) {
+			respondError(w, http.StatusConflict, err.Error())
+			return
+		}
+		s.logger.Error("failed to create workflow from issue", "issue", number, "error", err)
+		respondError(w, http.StatusInternalServerError, "failed to create workflow")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, s.stateToWorkflowResponse(ctx, state, state.WorkflowID))
+}
+
+// createIssueWorkflow saves a pending workflow for an issue in the given
+// Kanban column, with the issue discussion attached.
+func (s *Server) createIssueWorkflow(ctx context.Context, src *issues.Source, column, title string) (*core.WorkflowState, error) {
+	stateManager := s.getProjectStateManager(ctx)
+	if stateManager == nil {
+		return nil, errors.New("workflow management not available")
+	}
+
+	prompt := src.Prompt()
+	duplicates, err := stateManager.FindWorkflowsByPrompt(ctx, prompt)
+	if err != nil {
+		s.logger.Warn("failed to check for duplicate prompts", "error", err)
+	}
+	for _, dup := range duplicates {
+		if dup.Status == core.WorkflowStatusPending || dup.Status == core.WorkflowStatusRunning {
+			return nil, fmt.Errorf("%w: %s (%s)", errActiveIssueWorkflow, dup.WorkflowID, dup.Status)
+		}
+	}
+
+	workflowID := generateWorkflowID()
+	reportPath := filepath.Join(".quorum", "runs", string(workflowID))
+	fullReportPath := filepath.Join(s.getProjectRootPath(ctx), reportPath)
+	if err := os.MkdirAll(fullReportPath, 0o750); err != nil {
+		s.logger.Warn("failed to create report directory", "path", fullReportPath, "error", err)
+	}
+
+	if title == "" {
+		title = src.Title()
+	}
+	state := newPendingWorkflowState(workflowID, title, prompt, &core.Blueprint{}, reportPath)
+	state.SourceIssue = src.Link()
+	state.KanbanColumn = column
+
+	if store := s.getProjectAttachmentStore(ctx); store != nil {
+		saved, err := src.SaveAttachments(store, workflowID)
+		if err != nil {
+			s.logger.Warn("failed to attach issue discussion", "workflow_id", workflowID, "error", err)
+		}
+		state.Attachments = append(state.Attachments, saved...)
+	}
+
+	if err := stateManager.Save(ctx, state); err != nil {
+		return nil, fmt.Errorf("saving workflow: %w", err)
+	}
+	return state, nil
+}
+
+// ImportIssues implements kanban.IssueImporter. It imports the labeled
+// issues of the project in ctx into the To Do column when issues.import is
+// enabled and the configured interval has elapsed since the last import.
+func (s *Server) ImportIssues(ctx context.Context) (int, error) {
+	cfg, err := s.loadConfigForContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !cfg.Issues.Enabled || !cfg.Issues.Import.Enabled {
+		return 0, nil
+	}
+	interval, _ := time.ParseDuration(cfg.Issues.Import.Interval)
+
+	root := s.getProjectRootPath(ctx)
+	s.issueImportMu.Lock()
+	if last, ok := s.lastIssueImport[root]; ok && time.Since(last) < interval {
+		s.issueImportMu.Unlock()
+		return 0, nil
+	}
+	if s.lastIssueImport == nil {
+		s.lastIssueImport = make(map[string]time.Time)
+	}
+	s.lastIssueImport[root] = time.Now()
+	s.issueImportMu.Unlock()
+
+	client, err := createIssueClient(cfg.Issues)
+	if err != nil {
+		return 0, err
+	}
+	imported, err := s.importLabeledIssues(ctx, client, cfg.Issues)
+	return len(imported), err
+}
+
+// importLabeledIssues creates a To Do workflow for every open issue that
+// carries one of the configured import labels.
+func (s *Server) importLabeledIssues(ctx context.Context, client core.IssueClient, issuesCfg config.IssuesConfig) ([]issues.ImportedIssue, error) {
+	return issues.ImportLabeled(ctx, client, issues.ImportOptions{
+		Labels:     issuesCfg.Import.Labels,
+		Provider:   core.IssueProvider(issuesCfg.Provider),
+		Repository: issuesCfg.Repository,
+	}, func(ctx context.Context, src *issues.Source) (core.WorkflowID, error) {
+		state, err := s.createIssueWorkflow(ctx, src, "todo", "")
+		if err != nil {
+			return "", err
+		}
+		return state.WorkflowID, nil
+	})
+}
+
+// handleImportIssues imports labeled issues now, regardless of the import
+// interval.
+// POST /api/v1/kanban/import-issues
+func (ks *KanbanServer) handleImportIssues(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cfg, err := ks.server.loadConfigForContext(ctx)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to load configuration")
+		return
+	}
+	if !cfg.Issues.Enabled {
+		respondError(w, http.StatusBadRequest, msgIssuesDisabled)
+		return
+	}
+	if len(cfg.Issues.Import.Labels) == 0 {
+		respondError(w, http.StatusBadRequest, "issues.import.labels is not configured")
+		return
+	}
+
+	client, err := createIssueClient(cfg.Issues)
+	if err != nil {
+		writeIssueClientError(w, err)
+		return
+	}
+	imported, err := ks.server.importLabeledIssues(ctx, client, cfg.Issues)
+	if err != nil && len(imported) == 0 {
+		respondError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	resp := ImportIssuesResponse{Imported: make([]ImportedIssueResponse, 0, len(imported))}
+	for _, imp := range imported {
+		resp.Imported = append(resp.Imported, ImportedIssueResponse{Number: imp.Number, WorkflowID: string(imp.WorkflowID)})
+	}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
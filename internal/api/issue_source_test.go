@@ -0,0 +1,184 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// sourceIssueClient serves issues for the from-issue and import handlers.
+type sourceIssueClient struct {
+	mockIssueClient
+	issues        map[int]*core.Issue
+	comments      map[int][]core.IssueComment
+	removedLabels map[int][]string
+}
+
+func (c *sourceIssueClient) GetIssue(_ context.Context, number int) (*core.Issue, error) {
+	issue, ok := c.issues[number]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return issue, nil
+}
+
+func (c *sourceIssueClient) ListIssueComments(_ context.Context, number int) ([]core.IssueComment, error) {
+	return c.comments[number], nil
+}
+
+func (c *sourceIssueClient) ListIssues(_ context.Context, opts core.ListIssuesOptions) ([]*core.Issue, error) {
+	var out []*core.Issue
+	for _, issue := range c.issues {
+		for _, l := range issue.Labels {
+			if l == opts.Label {
+				out = append(out, issue)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (c *sourceIssueClient) RemoveIssueLabel(_ context.Context, number int, label string) error {
+	if c.removedLabels == nil {
+		c.removedLabels = make(map[int][]string)
+	}
+	c.removedLabels[number] = append(c.removedLabels[number], label)
+	return nil
+}
+
+// configLoaderWithIssueImport writes a config with issues and issue import
+// enabled for the "quorum" label.
+func configLoaderWithIssueImport(t *testing.T) *config.Loader {
+	t.Helper()
+	cfgDir := filepath.Join(t.TempDir(), ".quorum")
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir config dir: %v", err)
+	}
+	cfgFile := filepath.Join(cfgDir, "config.yaml")
+	yamlContent := `
+issues:
+  enabled: true
+  provider: github
+  repository: "acme/widgets"
+  import:
+    enabled: true
+    labels: ["quorum"]
+    interval: 1h
+`
+	if err := os.WriteFile(cfgFile, []byte(yamlContent), 0o644); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+	return config.NewLoader().WithConfigFile(cfgFile)
+}
+
+func TestHandleCreateWorkflowFromIssue(t *testing.T) {
+	client := &sourceIssueClient{
+		issues: map[int]*core.Issue{
+			17: {Number: 17, Title: "Login fails", Body: "Steps...", URL: "https://github.com/acme/widgets/issues/17"},
+		},
+		comments: map[int][]core.IssueComment{17: {{Author: "alice", Body: "Safari only"}}},
+	}
+	swapCreateIssueClient(t, client)
+	ts := newIssueTestServer(t, WithConfigLoader(configLoaderWithIssueImport(t)))
+
+	body := `{"issue":"acme/widgets#17","kanban_column":"todo"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/workflows/from-issue", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+	ts.srv.handleCreateWorkflowFromIssue(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var resp WorkflowResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Title != "#17 Login fails" {
+		t.Errorf("Title = %q", resp.Title)
+	}
+	if resp.SourceIssue == nil || resp.SourceIssue.Repository != "acme/widgets" || resp.SourceIssue.Number != 17 {
+		t.Errorf("SourceIssue = %+v", resp.SourceIssue)
+	}
+
+	state := ts.sm.workflows[core.WorkflowID(resp.ID)]
+	if state == nil {
+		t.Fatal("workflow was not saved")
+	}
+	if state.KanbanColumn != "todo" {
+		t.Errorf("KanbanColumn = %q, want todo", state.KanbanColumn)
+	}
+	if !strings.Contains(state.Prompt, "Resolve issue #17") {
+		t.Errorf("Prompt = %q", state.Prompt)
+	}
+	if len(state.Attachments) != 1 || state.Attachments[0].Name != "issue-17-comments.md" {
+		t.Errorf("Attachments = %+v", state.Attachments)
+	}
+}
+
+func TestHandleCreateWorkflowFromIssue_InvalidInput(t *testing.T) {
+	swapCreateIssueClient(t, &sourceIssueClient{})
+	ts := newIssueTestServer(t, WithConfigLoader(configLoaderWithIssueImport(t)))
+
+	tests := []struct {
+		body string
+		want int
+	}{
+		{`{}`, http.StatusBadRequest},
+		{`{"issue":"not-an-issue"}`, http.StatusBadRequest},
+		{`{"issue":"17","kanban_column":"done"}`, http.StatusBadRequest},
+		{`{"issue":"17"}`, http.StatusBadGateway},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/workflows/from-issue", bytes.NewBufferString(tt.body))
+		w := httptest.NewRecorder()
+		ts.srv.handleCreateWorkflowFromIssue(w, req)
+		if w.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.body, tt.want, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestServer_ImportIssues(t *testing.T) {
+	client := &sourceIssueClient{
+		issues: map[int]*core.Issue{
+			1: {Number: 1, Title: "A", Labels: []string{"quorum"}},
+			2: {Number: 2, Title: "B", Labels: []string{"bug"}},
+		},
+	}
+	swapCreateIssueClient(t, client)
+	ts := newIssueTestServer(t, WithConfigLoader(configLoaderWithIssueImport(t)))
+
+	n, err := ts.srv.ImportIssues(context.Background())
+	if err != nil {
+		t.Fatalf("ImportIssues() error = %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("imported %d issues, want 1", n)
+	}
+	if len(ts.sm.workflows) != 1 {
+		t.Fatalf("saved %d workflows, want 1", len(ts.sm.workflows))
+	}
+	for _, state := range ts.sm.workflows {
+		if state.KanbanColumn != "todo" || state.SourceIssue == nil || state.SourceIssue.Number != 1 {
+			t.Errorf("imported workflow = column %q, source %+v", state.KanbanColumn, state.SourceIssue)
+		}
+	}
+	if got := client.removedLabels[1]; len(got) != 1 || got[0] != "quorum" {
+		t.Errorf("removed labels = %v", got)
+	}
+
+	// The interval has not elapsed: the next tick does nothing.
+	client.issues[3] = &core.Issue{Number: 3, Title: "C", Labels: []string{"quorum"}}
+	if n, _ := ts.srv.ImportIssues(context.Background()); n != 0 {
+		t.Errorf("second import within interval imported %d issues", n)
+	}
+}
@@ -46,6 +46,7 @@ type KanbanWorkflowResponse struct {
 	KanbanCompletedAt    *time.Time `json:"kanban_completed_at,omitempty"`
 	KanbanExecutionCount int        `json:"kanban_execution_count"`
 	KanbanLastError      string     `json:"kanban_last_error,omitempty"`
+	PRChecksSummary      string     `json:"pr_checks_summary,omitempty"`
 	CreatedAt            time.Time  `json:"created_at"`
 	UpdatedAt            time.Time  `json:"updated_at"`
 	Prompt               string     `json:"prompt"`
@@ -91,6 +92,7 @@ func (ks *KanbanServer) RegisterRoutes(r chi.Router) {
 
 		// Workflow operations
 		r.Post("/workflows/{workflowID}/move", ks.handleMoveWorkflow)
+		r.Post("/import-issues", ks.handleImportIssues)
 
 		// Engine control
 		r.Get("/engine", ks.handleGetEngineState)
@@ -337,6 +339,11 @@ func workflowToKanbanResponse(wf *core.WorkflowState) KanbanWorkflowResponse {
 		prompt = prompt[:200] + "..."
 	}
 
+	checksSummary := ""
+	if wf.PRBabysit != nil && wf.PRBabysit.Status != core.PRBabysitPassed {
+		checksSummary = wf.PRBabysit.Summary
+	}
+
 	return KanbanWorkflowResponse{
 		ID:                   string(wf.WorkflowID),
 		Title:                wf.Title,
@@ -349,6 +356,7 @@ func workflowToKanbanResponse(wf *core.WorkflowState) KanbanWorkflowResponse {
 		KanbanCompletedAt:    wf.KanbanCompletedAt,
 		KanbanExecutionCount: wf.KanbanExecutionCount,
 		KanbanLastError:      wf.KanbanLastError,
+		PRChecksSummary:      checksSummary,
 		CreatedAt:            wf.CreatedAt,
 		UpdatedAt:            wf.UpdatedAt,
 		Prompt:               prompt,
@@ -588,6 +588,7 @@ func TestWorkflowToKanbanResponse(t *testing.T) {
 			KanbanCompletedAt:    &completedAt,
 			KanbanExecutionCount: 2,
 			KanbanLastError:      "timeout",
+			PRBabysit:            &core.PRBabysitState{Status: core.PRBabysitExhausted, Summary: "checks still failing"},
 		},
 	}
 
@@ -617,6 +618,9 @@ func TestWorkflowToKanbanResponse(t *testing.T) {
 	if resp.KanbanLastError != "timeout" {
 		t.Errorf("expected 'timeout', got %q", resp.KanbanLastError)
 	}
+	if resp.PRChecksSummary != "checks still failing" {
+		t.Errorf("expected the babysit summary, got %q", resp.PRChecksSummary)
+	}
 	// Prompt should be truncated to 200 + "..."
 	if len(resp.Prompt) != 203 {
 		t.Errorf("expected truncated prompt length 203, got %d", len(resp.Prompt))
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
+)
+
+// handleOverviewWorkflows lists the workflows of all enabled projects, most
+// recently updated first.
+func (s *Server) handleOverviewWorkflows(w http.ResponseWriter, r *http.Request) {
+	s.respondOverview(w, r, false)
+}
+
+// handleOverviewRunning lists the executing workflows of all enabled projects.
+func (s *Server) handleOverviewRunning(w http.ResponseWriter, r *http.Request) {
+	s.respondOverview(w, r, true)
+}
+
+func (s *Server) respondOverview(w http.ResponseWriter, r *http.Request, runningOnly bool) {
+	if s.projectRegistry == nil {
+		respondError(w, http.StatusServiceUnavailable, "project registry not available")
+		return
+	}
+	opts := project.OverviewOptions{RunningOnly: runningOnly}
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			respondError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		opts.Limit = limit
+	}
+
+	overview, err := project.CollectOverview(r.Context(), s.projectRegistry, s.statePool, opts)
+	if err != nil {
+		s.logger.Error("failed to collect workflow overview", "error", err)
+		respondError(w, http.StatusInternalServerError, "failed to collect workflow overview")
+		return
+	}
+	respondJSON(w, http.StatusOK, overview)
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/state"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
+)
+
+func TestHandleOverviewWorkflows(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	sm, err := state.NewStateManager(filepath.Join(root, ".quorum", "state", "state.db"))
+	if err != nil {
+		t.Fatalf("NewStateManager() error = %v", err)
+	}
+	wf := &core.WorkflowState{
+		WorkflowDefinition: core.WorkflowDefinition{Version: 1, WorkflowID: "wf-overview", Prompt: "p", CreatedAt: time.Now()},
+		WorkflowRun: core.WorkflowRun{
+			Status:       core.WorkflowStatusCompleted,
+			CurrentPhase: core.PhaseDone,
+			Tasks:        map[core.TaskID]*core.TaskState{},
+		},
+	}
+	if err := sm.Save(context.Background(), wf); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	_ = state.CloseStateManager(sm)
+
+	eb := events.New(10)
+	t.Cleanup(func() { eb.Close() })
+	reg := &mockRegistryForKanban{projects: []*project.Project{
+		{ID: "p1", Name: "Project 1", Path: root, Status: project.StatusHealthy},
+	}}
+	srv := NewServer(newMockStateManager(), eb, WithRoot(root), WithProjectRegistry(reg))
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/overview/workflows", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	var overview project.Overview
+	if err := json.NewDecoder(rec.Body).Decode(&overview); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if overview.Projects != 1 || len(overview.Workflows) != 1 {
+		t.Fatalf("overview = %+v", overview)
+	}
+	if got := overview.Workflows[0]; got.WorkflowID != "wf-overview" || got.ProjectID != "p1" || got.ProjectName != "Project 1" {
+		t.Errorf("workflow = %+v", got)
+	}
+
+	rec = httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/overview/running", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("running status = %d", rec.Code)
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&overview); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(overview.Workflows) != 0 {
+		t.Errorf("running workflows = %+v", overview.Workflows)
+	}
+
+	rec = httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/overview/workflows?limit=x", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid limit status = %d, want 400", rec.Code)
+	}
+}
+
+func TestHandleOverviewWorkflows_NoRegistry(t *testing.T) {
+	t.Parallel()
+	eb := events.New(10)
+	t.Cleanup(func() { eb.Close() })
+	srv := NewServer(newMockStateManager(), eb)
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/overview/workflows", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", rec.Code)
+	}
+}
@@ -139,3 +139,13 @@ func (s *Server) getProjectAttachmentStore(ctx context.Context) *attachments.Sto
 func getProjectID(ctx context.Context) string {
 	return middleware.GetProjectID(ctx)
 }
+
+// getChatConfig returns the chat settings of the project in the request
+// context, or the defaults when its configuration cannot be loaded.
+func (s *Server) getChatConfig(ctx context.Context) config.ChatConfig {
+	cfg, err := s.loadConfigForContext(ctx)
+	if err != nil || cfg == nil {
+		return config.ChatConfig{}
+	}
+	return cfg.Chat
+}
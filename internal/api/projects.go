@@ -13,6 +13,7 @@ import (
 	"github.com/go-chi/chi/v5"
 
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/confighistory"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
 )
 
@@ -295,6 +296,11 @@ func (h *ProjectsHandler) handleUpdateProject(w http.ResponseWriter, r *http.Req
 					respondError(w, http.StatusInternalServerError, "failed to create project config from global configuration")
 					return
 				}
+				// Best-effort: the config history is an audit aid.
+				_, _ = confighistory.RecordWrite(ctx, projectConfigPath, nil, confighistory.Change{
+					Source:  confighistory.SourceAPI,
+					Message: "Created from the global configuration",
+				})
 			}
 		}
 
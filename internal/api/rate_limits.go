@@ -0,0 +1,20 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service"
+)
+
+// RateLimitsResponse is the state of the rate limiters of the agents that
+// were called in this process, with their models.
+type RateLimitsResponse struct {
+	Agents map[string]service.RateLimiterStatus `json:"agents"`
+}
+
+// handleGetRateLimits reports the adaptive rate limiters shared by the
+// workflows and chats of the server.
+// GET /api/v1/rate-limits
+func (s *Server) handleGetRateLimits(w http.ResponseWriter, _ *http.Request) {
+	respondJSON(w, http.StatusOK, RateLimitsResponse{Agents: s.rateLimiter.Status()})
+}
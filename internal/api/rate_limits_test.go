@@ -0,0 +1,46 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service"
+)
+
+func TestHandleGetRateLimits(t *testing.T) {
+	t.Parallel()
+	limits := service.NewRateLimiterRegistry()
+	limits.SetTokenBudget("claude", 40000)
+	limits.Report("claude", "opus", 1200, nil)
+	limits.Report("claude", "sonnet", 0, core.ErrRateLimitRetryAfter("429", time.Minute))
+	eb := events.New(100)
+	t.Cleanup(eb.Close)
+	srv := NewServer(newMockStateManager(), eb, WithRoot(t.TempDir()), WithRateLimiter(limits))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/rate-limits", nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	var resp RateLimitsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	claude, ok := resp.Agents["claude"]
+	if !ok || claude.RateLimitErrors != 1 {
+		t.Fatalf("claude = %+v, want one rate limit error", claude)
+	}
+	if opus := claude.Models["opus"]; opus.TokensPerMinute != 40000 || opus.TokensUsed != 1200 {
+		t.Errorf("opus = %+v, want 1200 of 40000 tokens used", opus)
+	}
+	if sonnet := claude.Models["sonnet"]; sonnet.PausedUntil == nil {
+		t.Errorf("sonnet = %+v, want it paused", sonnet)
+	}
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/control"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/workflow"
+)
+
+// RollbackRequest is the request body for rolling a workflow back to a phase.
+type RollbackRequest struct {
+	ToPhase string `json:"to_phase"`
+}
+
+// RollbackResponse describes a completed rollback.
+type RollbackResponse struct {
+	ID             string `json:"id"`
+	FromPhase      string `json:"from_phase"`
+	ToPhase        string `json:"to_phase"`
+	Status         string `json:"status"`
+	ArchivePath    string `json:"archive_path,omitempty"`
+	DiscardedTasks int    `json:"discarded_tasks"`
+	Message        string `json:"message"`
+}
+
+// HandleRollbackWorkflow reopens an earlier phase of a workflow. The tasks,
+// worktrees and branches created from that phase on are discarded, the report
+// outputs of the discarded attempt are archived, and the next run restarts at
+// the phase.
+// POST /api/v1/workflows/{workflowID}/rollback
+//
+// Request Body:
+//
+//	{ "to_phase": "analyze" }
+//
+// Returns:
+//   - 200 OK: Workflow rolled back
+//   - 400 Bad Request: Missing or invalid phase
+//   - 404 Not Found: Workflow not found
+//   - 409 Conflict: Workflow running or phase not reached
+//   - 503 Service Unavailable: Workflow management not available
+func (s *Server) HandleRollbackWorkflow(w http.ResponseWriter, r *http.Request) {
+	workflowID := chi.URLParam(r, "workflowID")
+	if workflowID == "" {
+		respondError(w, http.StatusBadRequest, "workflow ID is required")
+		return
+	}
+
+	var req RollbackRequest
+	if !decodeOptionalBody(w, r, &req) {
+		return
+	}
+	toPhase := core.Phase(strings.TrimSpace(req.ToPhase))
+	if toPhase == "" {
+		respondError(w, http.StatusBadRequest, "to_phase is required")
+		return
+	}
+	if core.PhaseOrder(toPhase) < 0 || toPhase == core.PhaseDone {
+		respondError(w, http.StatusBadRequest, "to_phase must be refine, analyze, plan or execute")
+		return
+	}
+
+	ctx := r.Context()
+	stateManager := s.getProjectStateManager(ctx)
+	if stateManager == nil {
+		respondError(w, http.StatusServiceUnavailable, "workflow management not available")
+		return
+	}
+
+	state, err := stateManager.LoadByID(ctx, core.WorkflowID(workflowID))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to load workflow")
+		return
+	}
+	if state == nil {
+		respondError(w, http.StatusNotFound, "workflow not found")
+		return
+	}
+
+	if s.isWorkflowRunning(ctx, workflowID) {
+		respondError(w, http.StatusConflict, "workflow is running; cancel it before rolling back")
+		return
+	}
+	if err := workflow.ValidateRollback(state, toPhase); err != nil {
+		respondError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	factory := s.RunnerFactoryForContext(ctx)
+	if factory == nil {
+		respondError(w, http.StatusServiceUnavailable, "workflow management not available: missing configuration")
+		return
+	}
+	runner, _, err := factory.CreateRunner(ctx, workflowID, control.New(), state.Blueprint, state)
+	if err != nil {
+		s.logger.Error("failed to create runner", "workflow_id", workflowID, "error", err)
+		respondError(w, http.StatusServiceUnavailable, "workflow management not available: "+err.Error())
+		return
+	}
+
+	result, err := runner.RollbackWithState(ctx, state, toPhase)
+	if err != nil {
+		s.logger.Error("failed to roll back workflow", "workflow_id", workflowID, "to_phase", toPhase, "error", err)
+		respondError(w, http.StatusInternalServerError, "failed to roll back workflow: "+err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, RollbackResponse{
+		ID:             workflowID,
+		FromPhase:      string(result.FromPhase),
+		ToPhase:        string(result.ToPhase),
+		Status:         string(state.Status),
+		ArchivePath:    result.ArchivePath,
+		DiscardedTasks: result.DiscardedTasks,
+		Message:        fmt.Sprintf("Workflow rolled back to the %s phase. Use /%s to continue.", toPhase, rollbackContinuation(toPhase)),
+	})
+}
+
+// rollbackContinuation returns the endpoint that continues a workflow rolled
+// back to phase.
+func rollbackContinuation(phase core.Phase) string {
+	switch phase {
+	case core.PhasePlan:
+		return "plan"
+	case core.PhaseExecute:
+		return "execute"
+	default:
+		return "run"
+	}
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+func TestHandleRollbackWorkflow_Validation(t *testing.T) {
+	t.Parallel()
+	state := newMutableWorkflowState("wf-rollback")
+	state.Status = core.WorkflowStatusFailed
+	state.CurrentPhase = core.PhasePlan
+	srv, sm := newTestServerWithState(t, "wf-rollback", state)
+
+	running := newMutableWorkflowState("wf-running")
+	running.Status = core.WorkflowStatusRunning
+	sm.workflows["wf-running"] = running
+
+	tests := []struct {
+		name string
+		path string
+		body string
+		want int
+	}{
+		{"missing phase", "/api/v1/workflows/wf-rollback/rollback", "", http.StatusBadRequest},
+		{"unknown phase", "/api/v1/workflows/wf-rollback/rollback", `{"to_phase":"done"}`, http.StatusBadRequest},
+		{"unknown workflow", "/api/v1/workflows/wf-none/rollback", `{"to_phase":"analyze"}`, http.StatusNotFound},
+		{"phase not reached", "/api/v1/workflows/wf-rollback/rollback", `{"to_phase":"execute"}`, http.StatusConflict},
+		{"running workflow", "/api/v1/workflows/wf-running/rollback", `{"to_phase":"plan"}`, http.StatusConflict},
+		{"no runner factory", "/api/v1/workflows/wf-rollback/rollback", `{"to_phase":"analyze"}`, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		if rec := postTaskControl(t, srv, tt.path, tt.body); rec.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.want, rec.Code, rec.Body.String())
+		}
+	}
+
+	if got := sm.workflows["wf-rollback"]; got.CurrentPhase != core.PhasePlan || len(got.Tasks) != 2 {
+		t.Errorf("workflow changed by a rejected rollback: phase %s, %d tasks", got.CurrentPhase, len(got.Tasks))
+	}
+}
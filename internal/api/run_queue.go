@@ -0,0 +1,228 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/api/middleware"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/runqueue"
+)
+
+// QueueResponse lists the run queue: running items first, then queued items
+// in the order they will run.
+type QueueResponse struct {
+	Items []*runqueue.Item `json:"items"`
+}
+
+// QueueItemUpdateRequest is the body of PATCH /queue/{itemID}. Position is
+// applied after Priority.
+type QueueItemUpdateRequest struct {
+	Priority *int `json:"priority,omitempty"`
+	Position *int `json:"position,omitempty"`
+}
+
+// RunQueueExecutor starts queued runs through the WorkflowExecutor, in the
+// context of the run's project.
+type RunQueueExecutor struct {
+	executor  *WorkflowExecutor
+	tracker   *UnifiedTracker
+	statePool *project.StatePool
+}
+
+// NewRunQueueExecutor creates the executor of queued runs. statePool may be
+// nil when the server runs a single project.
+func NewRunQueueExecutor(executor *WorkflowExecutor, tracker *UnifiedTracker, statePool *project.StatePool) *RunQueueExecutor {
+	return &RunQueueExecutor{executor: executor, tracker: tracker, statePool: statePool}
+}
+
+// Start runs or resumes the workflow and returns a channel closed when the
+// run ends.
+func (e *RunQueueExecutor) Start(ctx context.Context, projectID string, workflowID core.WorkflowID) (<-chan struct{}, error) {
+	if projectID != "" && e.statePool != nil {
+		pc, err := e.statePool.GetContext(ctx, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("getting project context: %w", err)
+		}
+		ctx = middleware.WithProjectContext(ctx, pc)
+	}
+
+	state, err := GetStateManagerFromContext(ctx, e.executor.stateManager).LoadByID(ctx, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("loading workflow: %w", err)
+	}
+	if state == nil {
+		return nil, fmt.Errorf("%w: workflow not found", runqueue.ErrNotRunnable)
+	}
+	if state.Status == core.WorkflowStatusCompleted {
+		return nil, fmt.Errorf("%w: workflow is already completed", runqueue.ErrNotRunnable)
+	}
+
+	if isResumableState(state) {
+		err = e.executor.Resume(ctx, workflowID)
+	} else {
+		err = e.executor.Run(ctx, workflowID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	handle, ok := e.tracker.GetHandle(workflowID)
+	if !ok {
+		// The run already ended.
+		done := make(chan struct{})
+		close(done)
+		return done, nil
+	}
+	return handle.Done(), nil
+}
+
+// Healthy reports whether the run's heartbeat is current.
+func (e *RunQueueExecutor) Healthy(workflowID core.WorkflowID) bool {
+	return e.tracker.IsHeartbeatHealthy(workflowID)
+}
+
+// isResumableState reports whether running the workflow resumes it rather
+// than starting it from the beginning.
+func isResumableState(state *core.WorkflowState) bool {
+	return state.Status == core.WorkflowStatusFailed ||
+		state.Status == core.WorkflowStatusPaused ||
+		len(state.Checkpoints) > 0
+}
+
+// enqueueWorkflowRun queues a run of the workflow instead of starting it.
+// POST /api/v1/workflows/{workflowID}/run?priority=N
+func (s *Server) enqueueWorkflowRun(w http.ResponseWriter, r *http.Request, state *core.WorkflowState) {
+	switch state.Status {
+	case core.WorkflowStatusRunning:
+		respondError(w, http.StatusConflict, "workflow is already running")
+		return
+	case core.WorkflowStatusCompleted:
+		respondError(w, http.StatusConflict, "workflow is already completed; create a new workflow to re-run")
+		return
+	}
+
+	priority := 0
+	if raw := r.URL.Query().Get("priority"); raw != "" {
+		var err error
+		if priority, err = strconv.Atoi(raw); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid priority")
+			return
+		}
+	}
+
+	workflowID := string(state.WorkflowID)
+	item, err := s.runQueue.Enqueue(r.Context(), middleware.GetProjectID(r.Context()), workflowID, priority)
+	if errors.Is(err, runqueue.ErrAlreadyQueued) {
+		respondError(w, http.StatusConflict, "workflow is already queued")
+		return
+	}
+	if err != nil {
+		s.logger.Error("failed to queue workflow run", "workflow_id", workflowID, "error", err)
+		respondError(w, http.StatusInternalServerError, "failed to queue workflow run")
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, RunWorkflowResponse{
+		ID:           workflowID,
+		Status:       string(state.Status),
+		CurrentPhase: string(state.CurrentPhase),
+		Prompt:       state.Prompt,
+		Message:      fmt.Sprintf("Workflow run queued at position %d", item.Position),
+		QueueItem:    item,
+	})
+}
+
+// requireRunQueue responds 503 when the run queue is disabled.
+func (s *Server) requireRunQueue(w http.ResponseWriter) bool {
+	if s.runQueue == nil {
+		respondError(w, http.StatusServiceUnavailable, "run queue is not enabled")
+		return false
+	}
+	return true
+}
+
+// handleListQueue lists the run queue of all projects.
+func (s *Server) handleListQueue(w http.ResponseWriter, r *http.Request) {
+	if !s.requireRunQueue(w) {
+		return
+	}
+	items, err := s.runQueue.Store().List(r.Context())
+	if err != nil {
+		s.logger.Error("failed to list run queue", "error", err)
+		respondError(w, http.StatusInternalServerError, "failed to list run queue")
+		return
+	}
+	if items == nil {
+		items = []*runqueue.Item{}
+	}
+	respondJSON(w, http.StatusOK, QueueResponse{Items: items})
+}
+
+// handleUpdateQueueItem changes the priority or position of a queued item.
+func (s *Server) handleUpdateQueueItem(w http.ResponseWriter, r *http.Request) {
+	if !s.requireRunQueue(w) {
+		return
+	}
+	var req QueueItemUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Priority == nil && req.Position == nil {
+		respondError(w, http.StatusBadRequest, "priority or position is required")
+		return
+	}
+	if req.Position != nil && *req.Position < 1 {
+		respondError(w, http.StatusBadRequest, "position must be at least 1")
+		return
+	}
+
+	store := s.runQueue.Store()
+	itemID := chi.URLParam(r, "itemID")
+	var item *runqueue.Item
+	var err error
+	if req.Priority != nil {
+		item, err = store.SetPriority(r.Context(), itemID, *req.Priority)
+	}
+	if err == nil && req.Position != nil {
+		item, err = store.Move(r.Context(), itemID, *req.Position)
+	}
+	if err != nil {
+		respondQueueError(w, err)
+		return
+	}
+	s.runQueue.Notify()
+	respondJSON(w, http.StatusOK, item)
+}
+
+// handleRemoveQueueItem removes a queued item.
+func (s *Server) handleRemoveQueueItem(w http.ResponseWriter, r *http.Request) {
+	if !s.requireRunQueue(w) {
+		return
+	}
+	item, err := s.runQueue.Store().Remove(r.Context(), chi.URLParam(r, "itemID"))
+	if err != nil {
+		respondQueueError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, item)
+}
+
+// respondQueueError maps run queue errors to HTTP responses.
+func respondQueueError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, runqueue.ErrNotFound):
+		respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, runqueue.ErrLeased):
+		respondError(w, http.StatusConflict, "queue item is running; cancel its workflow instead")
+	default:
+		respondError(w, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/runqueue"
+)
+
+func TestRunQueue_QueuesAndReordersRuns(t *testing.T) {
+	t.Parallel()
+	sm := newMockStateManager()
+	for _, id := range []string{"wf-1", "wf-2"} {
+		sm.workflows[core.WorkflowID(id)] = &core.WorkflowState{
+			WorkflowDefinition: core.WorkflowDefinition{WorkflowID: core.WorkflowID(id), Prompt: "test", CreatedAt: time.Now()},
+			WorkflowRun:        core.WorkflowRun{Status: core.WorkflowStatusPending, CurrentPhase: core.PhaseAnalyze},
+		}
+	}
+	store, err := runqueue.Open(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("runqueue.Open() error = %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	// The dispatcher is not started, so runs stay queued.
+	dispatcher := runqueue.NewDispatcher(runqueue.DispatcherOptions{Store: store})
+	eb := events.New(100)
+	t.Cleanup(func() { eb.Close() })
+	srv := NewServer(sm, eb, WithLogger(slog.Default()), WithRunQueue(dispatcher))
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, httptest.NewRequest(method, path, bytes.NewBufferString(body)))
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/api/v1/workflows/wf-1/run", "")
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("POST run status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var run RunWorkflowResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &run)
+	if run.Status != string(core.WorkflowStatusPending) || run.QueueItem == nil || run.QueueItem.Position != 1 {
+		t.Errorf("run response = %+v, want the pending workflow queued at position 1", run)
+	}
+	if rec := do(http.MethodPost, "/api/v1/workflows/wf-1/run", ""); rec.Code != http.StatusConflict {
+		t.Errorf("second POST run status = %d, want 409", rec.Code)
+	}
+	if rec := do(http.MethodPost, "/api/v1/workflows/wf-2/run?priority=5", ""); rec.Code != http.StatusAccepted {
+		t.Fatalf("POST run with priority status = %d: %s", rec.Code, rec.Body.String())
+	}
+
+	list := func() []*runqueue.Item {
+		t.Helper()
+		rec := do(http.MethodGet, "/api/v1/queue", "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET queue status = %d: %s", rec.Code, rec.Body.String())
+		}
+		var resp QueueResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("unmarshal queue: %v", err)
+		}
+		return resp.Items
+	}
+	items := list()
+	if len(items) != 2 || items[0].WorkflowID != "wf-2" {
+		t.Fatalf("queue = %+v, want wf-2 first by priority", items)
+	}
+
+	rec = do(http.MethodPatch, "/api/v1/queue/"+items[1].ID, `{"position": 1}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PATCH queue item status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if items = list(); items[0].WorkflowID != "wf-1" {
+		t.Errorf("queue after move = %s, %s, want wf-1 first", items[0].WorkflowID, items[1].WorkflowID)
+	}
+
+	if rec := do(http.MethodDelete, "/api/v1/queue/"+items[0].ID, ""); rec.Code != http.StatusOK {
+		t.Errorf("DELETE queue item status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do(http.MethodDelete, "/api/v1/queue/"+items[0].ID, ""); rec.Code != http.StatusNotFound {
+		t.Errorf("second DELETE status = %d, want 404", rec.Code)
+	}
+	if items = list(); len(items) != 1 || items[0].WorkflowID != "wf-2" {
+		t.Errorf("queue after remove = %+v, want only wf-2", items)
+	}
+}
+
+func TestRunQueueExecutor_RefusesFinishedWorkflows(t *testing.T) {
+	t.Parallel()
+	sm := newMockStateManager()
+	sm.workflows["wf-done"] = &core.WorkflowState{
+		WorkflowDefinition: core.WorkflowDefinition{WorkflowID: "wf-done"},
+		WorkflowRun:        core.WorkflowRun{Status: core.WorkflowStatusCompleted},
+	}
+	tracker := NewUnifiedTracker(sm, nil, slog.Default(), DefaultUnifiedTrackerConfig())
+	executor := NewRunQueueExecutor(NewWorkflowExecutor(nil, sm, nil, slog.Default(), tracker), tracker, nil)
+
+	for _, id := range []core.WorkflowID{"wf-done", "wf-missing"} {
+		if _, err := executor.Start(context.Background(), "", id); !errors.Is(err, runqueue.ErrNotRunnable) {
+			t.Errorf("Start(%s) error = %v, want ErrNotRunnable", id, err)
+		}
+	}
+}
@@ -12,12 +12,16 @@ import (
 
 	cli "github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/cli"
 	webadapters "github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/web"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/agenthealth"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/confighistory"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/control"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/workflow"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/worker"
 )
 
 // RunnerFactory creates workflow.Runner instances for web execution context.
@@ -30,6 +34,9 @@ type RunnerFactory struct {
 	configLoader  *config.Loader
 	logger        *logging.Logger
 	heartbeat     *workflow.HeartbeatManager
+	workers       *worker.Coordinator
+	agentHealth   *agenthealth.Monitor
+	rateLimiter   *service.RateLimiterRegistry
 }
 
 // NewRunnerFactory creates a new runner factory.
@@ -55,6 +62,25 @@ func (f *RunnerFactory) WithHeartbeat(hb *workflow.HeartbeatManager) *RunnerFact
 	return f
 }
 
+// WithWorkers dispatches execute-phase tasks to the coordinator's workers.
+func (f *RunnerFactory) WithWorkers(coordinator *worker.Coordinator) *RunnerFactory {
+	f.workers = coordinator
+	return f
+}
+
+// WithAgentHealth shares the agent health monitor between runners.
+func (f *RunnerFactory) WithAgentHealth(monitor *agenthealth.Monitor) *RunnerFactory {
+	f.agentHealth = monitor
+	return f
+}
+
+// WithRateLimiter shares the rate limiters between runners. Without it the
+// process-wide limiters are used.
+func (f *RunnerFactory) WithRateLimiter(rl *service.RateLimiterRegistry) *RunnerFactory {
+	f.rateLimiter = rl
+	return f
+}
+
 // CreateRunner creates a new workflow.Runner for executing a workflow.
 // It creates all necessary dependencies and adapters for the web context.
 // The StateManager is obtained from the context if a ProjectContext is available,
@@ -105,6 +131,18 @@ func (f *RunnerFactory) CreateRunner(ctx context.Context, workflowID string, cp
 	}
 	cfg := effCfg.Config
 
+	// Resolve the config history version, recording the file when it changed
+	// outside quorum, so the workflow records what it ran with (best-effort).
+	// Skipped when a rejected file on disk was bypassed for the active config.
+	configVersion := 0
+	if effCfg.ConfigPath != "" && effCfg.Warning == "" {
+		if v, err := confighistory.CurrentVersion(ctx, effCfg.ConfigPath); err != nil {
+			logger.Warn("failed to resolve config history version", "path", effCfg.ConfigPath, "error", err)
+		} else {
+			configVersion = v.Version
+		}
+	}
+
 	// Build a fresh agent registry from the (project-scoped) config.
 	// This makes config changes effective immediately without requiring server restart.
 	registry := cli.NewRegistry()
@@ -122,9 +160,20 @@ func (f *RunnerFactory) CreateRunner(ctx context.Context, workflowID string, cp
 	outputNotifier := webadapters.NewWebOutputNotifier(eventBus, workflowID)
 
 	// Connect agent streaming events to the output notifier for real-time progress
-	registry.SetEventHandler(func(event core.AgentEvent) {
+	eventHandler := func(event core.AgentEvent) {
 		outputNotifier.AgentEvent(string(event.Type), event.Agent, event.Message, event.Data)
-	})
+	}
+	rateLimiter := f.rateLimiter
+	if rateLimiter == nil {
+		rateLimiter = service.GetGlobalRateLimiter()
+	}
+	registry.SetEventHandler(rateLimiter.ObserveEvents(eventHandler))
+
+	// Run execute-phase tasks on remote workers when some are registered.
+	var agents core.AgentRegistry = registry
+	if f.workers != nil {
+		agents = worker.NewRegistry(registry, f.workers, eventHandler)
+	}
 
 	// Snapshot the config used for this execution attempt (best-effort).
 	// This must happen before the runner starts so that failures are still auditable.
@@ -177,6 +226,7 @@ func (f *RunnerFactory) CreateRunner(ctx context.Context, workflowID string, cp
 			"config_path":    effCfg.ConfigPath,
 			"config_scope":   effCfg.ConfigScope,
 			"config_mode":    effCfg.ConfigMode,
+			"config_version": configVersion,
 			"file_etag":      effCfg.FileETag,
 			"effective_etag": effCfg.EffectiveETag,
 			"snapshot_path":  snapshotRelPath,
@@ -206,7 +256,7 @@ func (f *RunnerFactory) CreateRunner(ctx context.Context, workflowID string, cp
 			effCfg.EffectiveETag,
 			predictedExecID,
 			snapshotRelPath,
-			"",
+			effCfg.Warning,
 		))
 	}
 
@@ -214,12 +264,15 @@ func (f *RunnerFactory) CreateRunner(ctx context.Context, workflowID string, cp
 	builder := workflow.NewRunnerBuilder().
 		WithConfig(cfg).
 		WithStateManager(stateManager).
-		WithAgentRegistry(registry).
+		WithAgentRegistry(agents).
 		WithLogger(logger).
 		WithOutputNotifier(outputNotifier).
 		WithControlPlane(cp).
 		WithHeartbeat(f.heartbeat).
-		WithProjectRoot(projectRoot)
+		WithAgentHealth(f.agentHealth).
+		WithSharedRateLimiter(rateLimiter).
+		WithProjectRoot(projectRoot).
+		WithConfigVersion(configVersion)
 
 	// Apply workflow-level overrides if provided
 	if bp != nil {
@@ -286,6 +339,13 @@ func (s *Server) RunnerFactoryForContext(ctx context.Context) *RunnerFactory {
 	if s.heartbeat != nil {
 		factory.WithHeartbeat(s.heartbeat)
 	}
+	if s.workers != nil {
+		factory.WithWorkers(s.workers)
+	}
+	if s.agentHealth != nil {
+		factory.WithAgentHealth(s.agentHealth)
+	}
+	factory.WithRateLimiter(s.rateLimiter)
 
 	return factory
 }
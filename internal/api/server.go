@@ -15,6 +15,7 @@ import (
 	"github.com/rs/cors"
 
 	webadapters "github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/web"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/agenthealth"
 	apimiddleware "github.com/hugo-lorenzo-mato/quorum-ai/internal/api/middleware"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/attachments"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
@@ -23,7 +24,10 @@ import (
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/kanban"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/runqueue"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/workflow"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/worker"
 )
 
 // Server provides HTTP REST API endpoints for workflow management.
@@ -58,8 +62,24 @@ type Server struct {
 	// State pool for multi-project context management
 	statePool *project.StatePool
 
+	// Coordinator of the remote workers running execute-phase tasks
+	workers *worker.Coordinator
+
+	// Dispatcher of the durable run queue (nil = runs start immediately)
+	runQueue *runqueue.Dispatcher
+
+	// Agent health monitor shared by all runners (nil = per-runner or none)
+	agentHealth *agenthealth.Monitor
+
+	// Rate limiters shared by all runners and chats
+	rateLimiter *service.RateLimiterRegistry
+
 	// Mutex for config file operations to prevent race conditions
 	configMu sync.RWMutex
+
+	// Last automatic issue import per project root
+	issueImportMu   sync.Mutex
+	lastIssueImport map[string]time.Time
 }
 
 // ServerOption configures the server.
@@ -149,6 +169,38 @@ func WithStatePool(pool *project.StatePool) ServerOption {
 	}
 }
 
+// WithWorkerCoordinator serves the remote worker endpoints and dispatches
+// execute-phase tasks to the registered workers.
+func WithWorkerCoordinator(coordinator *worker.Coordinator) ServerOption {
+	return func(s *Server) {
+		s.workers = coordinator
+	}
+}
+
+// WithRunQueue queues workflow runs requested through the API in the run
+// queue of dispatcher instead of starting them immediately.
+func WithRunQueue(dispatcher *runqueue.Dispatcher) ServerOption {
+	return func(s *Server) {
+		s.runQueue = dispatcher
+	}
+}
+
+// WithAgentHealth shares the agent health monitor between the runners of
+// all workflows and reports agent health in /config/agents.
+func WithAgentHealth(monitor *agenthealth.Monitor) ServerOption {
+	return func(s *Server) {
+		s.agentHealth = monitor
+	}
+}
+
+// WithRateLimiter sets the rate limiters shared by the runners and chats of
+// the server. Without it the process-wide limiters are used.
+func WithRateLimiter(rl *service.RateLimiterRegistry) ServerOption {
+	return func(s *Server) {
+		s.rateLimiter = rl
+	}
+}
+
 // NewServer creates a new API server.
 func NewServer(stateManager core.StateManager, eventBus *events.EventBus, opts ...ServerOption) *Server {
 	wd, _ := os.Getwd() // Best effort default
@@ -164,17 +216,32 @@ func NewServer(stateManager core.StateManager, eventBus *events.EventBus, opts .
 	}
 
 	s.attachments = attachments.NewStore(s.root)
+	if s.rateLimiter == nil {
+		s.rateLimiter = service.GetGlobalRateLimiter()
+	}
+
+	// Chat calls share the rate limiters of the workflows.
+	chatAgents := s.agentRegistry
+	if chatAgents != nil {
+		chatAgents = s.rateLimiter.WrapAgents(chatAgents)
+	}
+
+	if s.kanbanEngine != nil {
+		s.kanbanEngine.SetIssueImporter(s)
+	}
 
 	// Create chat handler with agent registry and chat store (may be nil)
 	// Pass resolvers for project-scoped chat storage
 	s.chatHandler = webadapters.NewChatHandler(
-		s.agentRegistry,
+		chatAgents,
 		eventBus,
 		s.attachments,
 		s.chatStore,
 		webadapters.WithChatStoreResolver(s.getProjectChatStore),
 		webadapters.WithProjectRootResolver(s.getProjectRootPath),
 		webadapters.WithAttachmentStoreResolver(s.getProjectAttachmentStore),
+		webadapters.WithChatConfigResolver(s.getChatConfig),
+		webadapters.WithWorkflowCreator(s.createChatWorkflow),
 	)
 
 	s.router = s.setupRouter()
@@ -224,6 +291,7 @@ func (s *Server) setupRouter() chi.Router {
 			// List/create/active endpoints with standard timeout
 			r.With(chimiddleware.Timeout(60*time.Second)).Get("/", s.handleListWorkflows)
 			r.With(chimiddleware.Timeout(60*time.Second)).Post("/", s.handleCreateWorkflow)
+			r.With(chimiddleware.Timeout(60*time.Second)).Post("/from-issue", s.handleCreateWorkflowFromIssue)
 			r.With(chimiddleware.Timeout(60*time.Second)).Get("/active", s.handleGetActiveWorkflow)
 
 			r.Route("/{workflowID}", func(r chi.Router) {
@@ -245,11 +313,16 @@ func (s *Server) setupRouter() chi.Router {
 				r.With(chimiddleware.Timeout(60*time.Second)).Post("/plan", s.HandlePlanWorkflow)
 				r.With(chimiddleware.Timeout(60*time.Second)).Post("/replan", s.HandleReplanWorkflow)
 				r.With(chimiddleware.Timeout(60*time.Second)).Post("/execute", s.HandleExecuteWorkflow)
+				r.With(chimiddleware.Timeout(60*time.Second)).Post("/rollback", s.HandleRollbackWorkflow)
 
 				// Interactive workflow endpoints
 				r.With(chimiddleware.Timeout(60*time.Second)).Post("/review", s.HandleReviewWorkflow)
 				r.With(chimiddleware.Timeout(60*time.Second)).Post("/switch-interactive", s.HandleSwitchInteractive)
 
+				// Questions asked by running tasks
+				r.With(chimiddleware.Timeout(60*time.Second)).Get("/inputs", s.handleListWorkflowInputs)
+				r.With(chimiddleware.Timeout(60*time.Second)).Post("/inputs/{requestID}", s.handleAnswerWorkflowInput)
+
 				// Task endpoints nested under workflow
 				r.Route("/tasks", func(r chi.Router) {
 					r.Use(chimiddleware.Timeout(60 * time.Second))
@@ -260,6 +333,8 @@ func (s *Server) setupRouter() chi.Router {
 					r.Get(taskIDPath, s.handleGetTask)
 					r.Patch(taskIDPath, s.handleUpdateTask)
 					r.Delete(taskIDPath, s.handleDeleteTask)
+					r.Post(taskIDPath+"/skip", s.handleSkipTask)
+					r.Post(taskIDPath+"/retry", s.handleRetryTask)
 				})
 
 				// Workflow attachments
@@ -303,6 +378,8 @@ func (s *Server) setupRouter() chi.Router {
 			r.Delete("/sessions/{sessionID}", s.chatHandler.DeleteSession)
 			r.Get("/sessions/{sessionID}/messages", s.chatHandler.GetMessages)
 			r.Post("/sessions/{sessionID}/messages", s.chatHandler.SendMessage)
+			r.Post("/sessions/{sessionID}/ask-all", s.chatHandler.AskAll)
+			r.Post("/sessions/{sessionID}/promote", s.chatHandler.Promote)
 			r.Get("/sessions/{sessionID}/attachments", s.chatHandler.ListAttachments)
 			r.Post("/sessions/{sessionID}/attachments", s.chatHandler.UploadAttachments)
 			r.Get("/sessions/{sessionID}/attachments/{attachmentID}/download", s.chatHandler.DownloadAttachment)
@@ -333,6 +410,8 @@ func (s *Server) setupRouter() chi.Router {
 			r.Patch("/", s.handleUpdateConfig)
 			r.Post("/validate", s.handleValidateConfig)
 			r.Post("/reset", s.handleResetConfig)
+			r.Get("/history", s.handleGetConfigHistory)
+			r.Post("/rollback/{version}", s.handleRollbackConfig)
 			// Global config endpoints (shared defaults for all projects)
 			r.Get("/global", s.handleGetGlobalConfig)
 			r.Patch("/global", s.handleUpdateGlobalConfig)
@@ -344,6 +423,13 @@ func (s *Server) setupRouter() chi.Router {
 			r.Get("/issues", s.handleGetIssuesConfig)
 		})
 
+		// Cross-project overview endpoints
+		r.Route("/overview", func(r chi.Router) {
+			r.Use(chimiddleware.Timeout(60 * time.Second))
+			r.Get("/workflows", s.handleOverviewWorkflows)
+			r.Get("/running", s.handleOverviewRunning)
+		})
+
 		// Snapshot endpoints (backup/restore of registry and project state)
 		r.Route("/snapshots", func(r chi.Router) {
 			r.Use(chimiddleware.Timeout(60 * time.Second))
@@ -353,6 +439,21 @@ func (s *Server) setupRouter() chi.Router {
 			r.Post("/import/validate", s.handleSnapshotValidate)
 		})
 
+		// Rate limiter status of the agents and their models
+		r.Get("/rate-limits", s.handleGetRateLimits)
+
+		// Run queue endpoints (inspection and reordering)
+		r.Route("/queue", func(r chi.Router) {
+			r.Get("/", s.handleListQueue)
+			r.Patch("/{itemID}", s.handleUpdateQueueItem)
+			r.Delete("/{itemID}", s.handleRemoveQueueItem)
+		})
+
+		// Remote worker endpoints (registration, job polling, results)
+		if s.workers != nil {
+			s.workers.RegisterRoutes(r)
+		}
+
 		// Kanban board endpoints
 		kanbanServer := NewKanbanServer(s, s.kanbanEngine, s.eventBus)
 		kanbanServer.RegisterRoutes(r)
@@ -934,7 +934,7 @@ func TestGetProjectConfigPath_WithProjectContext_Root(t *testing.T) {
 
 func TestBuildConfigSchema_AllSections(t *testing.T) {
 	t.Parallel()
-	schema := buildConfigSchema()
+	schema := BuildConfigSchema()
 
 	if len(schema.Sections) == 0 {
 		t.Fatal("expected non-empty schema sections")
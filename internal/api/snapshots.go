@@ -14,6 +14,25 @@ type SnapshotExportRequest struct {
 	ProjectIDs       []string `json:"project_ids,omitempty"`
 	IncludeWorktrees bool     `json:"include_worktrees,omitempty"`
 	QuorumVersion    string   `json:"quorum_version,omitempty"`
+	// ParentPath makes the snapshot incremental on top of that snapshot.
+	ParentPath string `json:"parent_path,omitempty"`
+	SnapshotEncryption
+}
+
+// SnapshotEncryption carries the age encryption of a snapshot: a passphrase,
+// or recipients to encrypt and identity files to decrypt.
+type SnapshotEncryption struct {
+	Passphrase    string   `json:"passphrase,omitempty"`
+	Recipients    []string `json:"recipients,omitempty"`
+	IdentityFiles []string `json:"identity_files,omitempty"`
+}
+
+func (e SnapshotEncryption) encryption() snapshot.Encryption {
+	return snapshot.Encryption{
+		Passphrase:    e.Passphrase,
+		Recipients:    e.Recipients,
+		IdentityFiles: e.IdentityFiles,
+	}
 }
 
 // SnapshotImportRequest defines request payload for snapshot import.
@@ -25,11 +44,13 @@ type SnapshotImportRequest struct {
 	PathMap            map[string]string `json:"path_map,omitempty"`
 	PreserveProjectIDs bool              `json:"preserve_project_ids,omitempty"`
 	IncludeWorktrees   bool              `json:"include_worktrees,omitempty"`
+	SnapshotEncryption
 }
 
 // SnapshotValidateRequest defines request payload for snapshot validation.
 type SnapshotValidateRequest struct {
 	InputPath string `json:"input_path"`
+	SnapshotEncryption
 }
 
 func (s *Server) handleSnapshotExport(w http.ResponseWriter, r *http.Request) {
@@ -48,6 +69,8 @@ func (s *Server) handleSnapshotExport(w http.ResponseWriter, r *http.Request) {
 		ProjectIDs:       req.ProjectIDs,
 		IncludeWorktrees: req.IncludeWorktrees,
 		QuorumVersion:    req.QuorumVersion,
+		ParentPath:       req.ParentPath,
+		Encryption:       req.encryption(),
 	})
 	if err != nil {
 		respondError(w, statusForSnapshotError(err), err.Error())
@@ -76,6 +99,7 @@ func (s *Server) handleSnapshotImport(w http.ResponseWriter, r *http.Request) {
 		PathMap:            req.PathMap,
 		PreserveProjectIDs: req.PreserveProjectIDs,
 		IncludeWorktrees:   req.IncludeWorktrees,
+		Encryption:         req.encryption(),
 	})
 	if err != nil {
 		respondError(w, statusForSnapshotError(err), err.Error())
@@ -96,7 +120,10 @@ func (s *Server) handleSnapshotValidate(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	manifest, err := snapshot.ValidateSnapshot(req.InputPath)
+	manifest, err := snapshot.ValidateSnapshot(&snapshot.ValidateOptions{
+		InputPath:  req.InputPath,
+		Encryption: req.encryption(),
+	})
 	if err != nil {
 		respondError(w, statusForSnapshotError(err), err.Error())
 		return
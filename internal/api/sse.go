@@ -208,6 +208,40 @@ func (s *Server) sendEventToClient(w http.ResponseWriter, flusher http.Flusher,
 			"timestamp":    e.Timestamp(),
 		}
 
+	case events.UserInputRequestedEvent:
+		payload = map[string]interface{}{
+			"workflow_id": e.WorkflowID(),
+			"request_id":  e.RequestID,
+			"task_id":     e.TaskID,
+			"prompt":      e.Prompt,
+			"context":     e.Context,
+			"options":     e.Options,
+			"default":     e.Default,
+			"timeout_ms":  e.Timeout.Milliseconds(),
+			"timestamp":   e.Timestamp(),
+		}
+
+	case events.UserInputProvidedEvent:
+		payload = map[string]interface{}{
+			"workflow_id": e.WorkflowID(),
+			"request_id":  e.RequestID,
+			"task_id":     e.TaskID,
+			"input":       e.Input,
+			"cancelled":   e.Cancelled,
+			"timestamp":   e.Timestamp(),
+		}
+
+	case events.ModeratorRoundEvent:
+		payload = map[string]interface{}{
+			"workflow_id": e.WorkflowID(),
+			"round":       e.Round,
+			"score":       e.Score,
+			"threshold":   e.Threshold,
+			"agreements":  e.Agreements,
+			"divergences": e.Divergences,
+			"timestamp":   e.Timestamp(),
+		}
+
 	case events.AgentStreamEvent:
 		payload = map[string]interface{}{
 			"workflow_id": e.WorkflowID(),
@@ -263,6 +297,14 @@ func (s *Server) sendEventToClient(w http.ResponseWriter, flusher http.Flusher,
 			"timestamp":      e.Timestamp(),
 		}
 
+	case events.ConfigReloadFailedEvent:
+		payload = map[string]interface{}{
+			"config_path":  e.ConfigPath,
+			"config_scope": e.ConfigScope,
+			"error":        e.Error,
+			"timestamp":    e.Timestamp(),
+		}
+
 	// Kanban events
 	case events.KanbanWorkflowMovedEvent:
 		payload = map[string]interface{}{
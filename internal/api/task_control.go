@@ -0,0 +1,194 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/control"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// SkipTaskRequest is the request body for skipping a task.
+type SkipTaskRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// RetryTaskRequest is the request body for retrying a failed task. Empty
+// fields keep the agent and model of the task.
+type RetryTaskRequest struct {
+	Agent        string `json:"agent,omitempty"`
+	Model        string `json:"model,omitempty"`
+	Instructions string `json:"instructions,omitempty"`
+}
+
+// handleSkipTask skips a pending, running or failed task. The executor of a
+// running workflow skips the task itself; otherwise the task is marked
+// skipped in the saved state and the next resume goes past it.
+// POST /api/v1/workflows/{workflowID}/tasks/{taskID}/skip
+func (s *Server) handleSkipTask(w http.ResponseWriter, r *http.Request) {
+	var req SkipTaskRequest
+	if !decodeOptionalBody(w, r, &req) {
+		return
+	}
+	reason := strings.TrimSpace(req.Reason)
+
+	state, task, ok := s.loadControlledTask(w, r)
+	if !ok {
+		return
+	}
+
+	switch task.Status {
+	case core.TaskStatusPending, core.TaskStatusRunning, core.TaskStatusFailed:
+	default:
+		respondError(w, http.StatusConflict, fmt.Sprintf("task is %s and cannot be skipped", task.Status))
+		return
+	}
+
+	if cp, running := s.workflowControlPlane(string(state.WorkflowID)); running && cp != nil {
+		cp.SkipTask(task.ID, reason)
+		respondJSON(w, http.StatusAccepted, WorkflowControlResponse{
+			ID:      string(task.ID),
+			Status:  "skip_requested",
+			Message: "The running workflow will skip the task.",
+		})
+		return
+	}
+	if !canControlSavedTask(w, state, task) {
+		return
+	}
+
+	if reason == "" {
+		reason = "skipped on request"
+	}
+	now := time.Now()
+	task.Status = core.TaskStatusSkipped
+	task.Error = reason
+	task.CompletedAt = &now
+	if !s.saveMutatedTaskState(w, r.Context(), state, GetStateManagerFromContext(r.Context(), s.stateManager)) {
+		return
+	}
+	respondJSON(w, http.StatusOK, taskStateToResponse(task))
+}
+
+// handleRetryTask retries a failed task, optionally with another agent, model
+// or extra instructions. The executor of a running workflow re-queues the task
+// when it has failed; otherwise the task goes back to pending in the saved
+// state and runs on the next resume.
+// POST /api/v1/workflows/{workflowID}/tasks/{taskID}/retry
+func (s *Server) handleRetryTask(w http.ResponseWriter, r *http.Request) {
+	var req RetryTaskRequest
+	if !decodeOptionalBody(w, r, &req) {
+		return
+	}
+	req.Agent = strings.TrimSpace(req.Agent)
+	req.Model = strings.TrimSpace(req.Model)
+	req.Instructions = strings.TrimSpace(req.Instructions)
+
+	state, task, ok := s.loadControlledTask(w, r)
+	if !ok {
+		return
+	}
+
+	if cp, running := s.workflowControlPlane(string(state.WorkflowID)); running && cp != nil {
+		if task.Status != core.TaskStatusFailed && task.Status != core.TaskStatusRunning {
+			respondError(w, http.StatusConflict, fmt.Sprintf("task is %s and cannot be retried", task.Status))
+			return
+		}
+		cp.RequestRetry(control.RetryRequest{
+			TaskID:       task.ID,
+			Agent:        req.Agent,
+			Model:        req.Model,
+			Instructions: req.Instructions,
+		})
+		respondJSON(w, http.StatusAccepted, WorkflowControlResponse{
+			ID:      string(task.ID),
+			Status:  "retry_requested",
+			Message: "The running workflow will retry the task if it fails.",
+		})
+		return
+	}
+
+	if task.Status != core.TaskStatusFailed {
+		respondError(w, http.StatusConflict, fmt.Sprintf("task is %s and cannot be retried", task.Status))
+		return
+	}
+	if !canControlSavedTask(w, state, task) {
+		return
+	}
+
+	if req.Agent != "" {
+		task.CLI = req.Agent
+	}
+	if req.Model != "" {
+		task.Model = req.Model
+	}
+	if req.Instructions != "" {
+		task.RetryInstructions = req.Instructions
+	}
+	task.Status = core.TaskStatusPending
+	task.Error = ""
+	task.StartedAt = nil
+	task.CompletedAt = nil
+	if !s.saveMutatedTaskState(w, r.Context(), state, GetStateManagerFromContext(r.Context(), s.stateManager)) {
+		return
+	}
+	respondJSON(w, http.StatusOK, taskStateToResponse(task))
+}
+
+// loadControlledTask loads the workflow and the task of a skip or retry
+// request. If ok is false, an error response was already written.
+func (s *Server) loadControlledTask(w http.ResponseWriter, r *http.Request) (*core.WorkflowState, *core.TaskState, bool) {
+	ctx := r.Context()
+	workflowID := chi.URLParam(r, "workflowID")
+	stateManager := GetStateManagerFromContext(ctx, s.stateManager)
+	if stateManager == nil {
+		respondError(w, http.StatusNotFound, "workflow not found")
+		return nil, nil, false
+	}
+
+	state, err := stateManager.LoadByID(ctx, core.WorkflowID(workflowID))
+	if err != nil {
+		s.logger.Error("failed to load workflow", "workflow_id", workflowID, "error", err)
+		respondError(w, http.StatusInternalServerError, "failed to load workflow")
+		return nil, nil, false
+	}
+	if state == nil {
+		respondError(w, http.StatusNotFound, "workflow not found")
+		return nil, nil, false
+	}
+
+	task, ok := state.Tasks[core.TaskID(chi.URLParam(r, "taskID"))]
+	if !ok {
+		respondError(w, http.StatusNotFound, msgTaskNotFound)
+		return nil, nil, false
+	}
+	return state, task, true
+}
+
+// canControlSavedTask reports whether the saved state of a workflow that is
+// not running in this server may be changed. If not, an error response was
+// already written.
+func canControlSavedTask(w http.ResponseWriter, state *core.WorkflowState, task *core.TaskState) bool {
+	if state.Status == core.WorkflowStatusRunning || task.Status == core.TaskStatusRunning {
+		respondError(w, http.StatusConflict, "workflow is running in another process")
+		return false
+	}
+	return true
+}
+
+// decodeOptionalBody decodes a JSON request body that may be empty. If it
+// returns false, an error response was already written.
+func decodeOptionalBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, http.StatusBadRequest, msgInvalidRequestBody)
+		return false
+	}
+	return true
+}
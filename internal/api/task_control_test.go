@@ -0,0 +1,134 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/testutil"
+)
+
+func postTaskControl(t *testing.T, srv *Server, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	return rec
+}
+
+func TestHandleSkipTask_SavedWorkflow(t *testing.T) {
+	t.Parallel()
+	state := newMutableWorkflowState("wf-skip")
+	state.Status = core.WorkflowStatusFailed
+	state.Tasks["task-2"].Status = core.TaskStatusCompleted
+	srv, sm := newTestServerWithState(t, "wf-skip", state)
+
+	rec := postTaskControl(t, srv, "/api/v1/workflows/wf-skip/tasks/task-1/skip", `{"reason":"done by hand"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("skip: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	task := sm.workflows["wf-skip"].Tasks["task-1"]
+	if task.Status != core.TaskStatusSkipped || task.Error != "done by hand" || task.CompletedAt == nil {
+		t.Errorf("skipped task = %+v", task)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{"completed task", "/api/v1/workflows/wf-skip/tasks/task-2/skip", http.StatusConflict},
+		{"unknown task", "/api/v1/workflows/wf-skip/tasks/task-9/skip", http.StatusNotFound},
+		{"unknown workflow", "/api/v1/workflows/wf-none/tasks/task-1/skip", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		if rec := postTaskControl(t, srv, tt.path, ""); rec.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.want, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestHandleRetryTask_SavedWorkflow(t *testing.T) {
+	t.Parallel()
+	state := newMutableWorkflowState("wf-retry")
+	state.Status = core.WorkflowStatusFailed
+	state.Tasks["task-1"].Status = core.TaskStatusFailed
+	state.Tasks["task-1"].Error = "tests failed"
+	srv, sm := newTestServerWithState(t, "wf-retry", state)
+
+	rec := postTaskControl(t, srv, "/api/v1/workflows/wf-retry/tasks/task-2/retry", "")
+	if rec.Code != http.StatusConflict {
+		t.Errorf("retry pending task: expected 409, got %d", rec.Code)
+	}
+
+	rec = postTaskControl(t, srv, "/api/v1/workflows/wf-retry/tasks/task-1/retry",
+		`{"agent":"gemini","model":"gemini-2.5-pro","instructions":"Run the tests first."}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("retry: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp TaskResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if resp.Status != string(core.TaskStatusPending) || resp.CLI != "gemini" || resp.Model != "gemini-2.5-pro" || resp.Error != "" {
+		t.Errorf("retried task = %+v", resp)
+	}
+	if got := sm.workflows["wf-retry"].Tasks["task-1"].RetryInstructions; got != "Run the tests first." {
+		t.Errorf("retry instructions = %q", got)
+	}
+}
+
+func TestHandleTaskControl_StaleRunningWorkflow(t *testing.T) {
+	t.Parallel()
+	state := newMutableWorkflowState("wf-stale")
+	state.Status = core.WorkflowStatusRunning
+	state.Tasks["task-1"].Status = core.TaskStatusFailed
+	srv, _ := newTestServerWithState(t, "wf-stale", state)
+
+	for _, action := range []string{"skip", "retry"} {
+		rec := postTaskControl(t, srv, "/api/v1/workflows/wf-stale/tasks/task-1/"+action, "")
+		if rec.Code != http.StatusConflict {
+			t.Errorf("%s: expected 409, got %d: %s", action, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestHandleTaskControl_RunningWorkflow(t *testing.T) {
+	t.Parallel()
+	state := newMutableWorkflowState("wf-run")
+	state.Status = core.WorkflowStatusRunning
+	state.Tasks["task-1"].Status = core.TaskStatusRunning
+	sm := newMockStateManager()
+	sm.workflows["wf-run"] = state
+
+	tracker := NewUnifiedTracker(testutil.NewMockStateManager(), nil, newTestLogger(), DefaultUnifiedTrackerConfig())
+	handle := newTestHandle("wf-run")
+	tracker.mu.Lock()
+	tracker.handles["wf-run"] = handle
+	tracker.mu.Unlock()
+	eb := events.New(100)
+	t.Cleanup(eb.Close)
+	srv := NewServer(sm, eb, WithRoot(t.TempDir()), WithUnifiedTracker(tracker))
+
+	rec := postTaskControl(t, srv, "/api/v1/workflows/wf-run/tasks/task-1/retry", `{"agent":"codex"}`)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("retry: expected 202, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if req := <-handle.ControlPlane.GetRetryQueue(); req.TaskID != "task-1" || req.Agent != "codex" {
+		t.Errorf("retry request = %+v", req)
+	}
+
+	rec = postTaskControl(t, srv, "/api/v1/workflows/wf-run/tasks/task-2/skip", `{"reason":"not needed"}`)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("skip: expected 202, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if req := <-handle.ControlPlane.GetSkipQueue(); req.TaskID != "task-2" || req.Reason != "not needed" {
+		t.Errorf("skip request = %+v", req)
+	}
+	if status := sm.workflows["wf-run"].Tasks["task-2"].Status; status != core.TaskStatusPending {
+		t.Errorf("saved task status = %s, want the executor to change it", status)
+	}
+}
@@ -24,6 +24,7 @@ type TaskResponse struct {
 	CLI          string     `json:"cli"`
 	Model        string     `json:"model"`
 	Dependencies []string   `json:"dependencies"`
+	Repository   string     `json:"repository,omitempty"` // Declared repository the task changes
 	TokensIn     int        `json:"tokens_in"`
 	TokensOut    int        `json:"tokens_out"`
 	Retries      int        `json:"retries"`
@@ -482,6 +483,7 @@ func taskStateToResponse(task *core.TaskState) TaskResponse {
 		CLI:          task.CLI,
 		Model:        task.Model,
 		Dependencies: deps,
+		Repository:   task.Repository,
 		TokensIn:     task.TokensIn,
 		TokensOut:    task.TokensOut,
 		Retries:      task.Retries,
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/control"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// InputRequestResponse is a question a running workflow waits on.
+type InputRequestResponse struct {
+	ID          string     `json:"id"`
+	TaskID      string     `json:"task_id,omitempty"`
+	Prompt      string     `json:"prompt"`
+	Context     string     `json:"context,omitempty"`
+	Options     []string   `json:"options,omitempty"`
+	Default     string     `json:"default,omitempty"`
+	TimeoutMS   int64      `json:"timeout_ms,omitempty"`
+	RequestedAt time.Time  `json:"requested_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+// WorkflowInputsResponse lists the questions a running workflow waits on.
+type WorkflowInputsResponse struct {
+	Inputs []InputRequestResponse `json:"inputs"`
+}
+
+// AnswerInputRequest answers a question. An empty input accepts the proposed
+// answer; Cancel dismisses the question, which then follows the timeout
+// policy of phases.execute.input.
+type AnswerInputRequest struct {
+	Input  string `json:"input"`
+	Cancel bool   `json:"cancel,omitempty"`
+}
+
+// workflowControlPlane returns the control plane of a workflow running in this
+// server.
+func (s *Server) workflowControlPlane(workflowID string) (*control.ControlPlane, bool) {
+	if s.unifiedTracker != nil {
+		return s.unifiedTracker.GetControlPlane(core.WorkflowID(workflowID))
+	}
+	if s.executor != nil {
+		return s.executor.GetControlPlane(workflowID)
+	}
+	return nil, false
+}
+
+// handleListWorkflowInputs lists the questions a running workflow waits on.
+// GET /api/v1/workflows/{workflowID}/inputs
+func (s *Server) handleListWorkflowInputs(w http.ResponseWriter, r *http.Request) {
+	resp := WorkflowInputsResponse{Inputs: []InputRequestResponse{}}
+	if cp, ok := s.workflowControlPlane(chi.URLParam(r, "workflowID")); ok && cp != nil {
+		for _, req := range cp.PendingInputs() {
+			resp.Inputs = append(resp.Inputs, inputRequestToResponse(req))
+		}
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// handleAnswerWorkflowInput answers or dismisses a question of a running
+// workflow.
+// POST /api/v1/workflows/{workflowID}/inputs/{requestID}
+func (s *Server) handleAnswerWorkflowInput(w http.ResponseWriter, r *http.Request) {
+	cp, ok := s.workflowControlPlane(chi.URLParam(r, "workflowID"))
+	if !ok || cp == nil {
+		respondError(w, http.StatusConflict, "workflow is not running")
+		return
+	}
+
+	var req AnswerInputRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, msgInvalidRequestBody)
+		return
+	}
+
+	requestID := chi.URLParam(r, "requestID")
+	var err error
+	if req.Cancel {
+		err = cp.CancelUserInput(requestID)
+	} else {
+		err = cp.ProvideUserInput(requestID, strings.TrimSpace(req.Input))
+	}
+	if err != nil {
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func inputRequestToResponse(req control.InputRequest) InputRequestResponse {
+	resp := InputRequestResponse{
+		ID:          req.ID,
+		TaskID:      string(req.TaskID),
+		Prompt:      req.Prompt,
+		Context:     req.Context,
+		Options:     req.Options,
+		Default:     req.Default,
+		TimeoutMS:   req.Timeout.Milliseconds(),
+		RequestedAt: req.RequestedAt,
+	}
+	if req.Timeout > 0 {
+		expiresAt := req.RequestedAt.Add(req.Timeout)
+		resp.ExpiresAt = &expiresAt
+	}
+	return resp
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/control"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/testutil"
+)
+
+func TestWorkflowInputs_ListAndAnswer(t *testing.T) {
+	t.Parallel()
+	sm := testutil.NewMockStateManager()
+	tracker := NewUnifiedTracker(sm, nil, newTestLogger(), DefaultUnifiedTrackerConfig())
+	id := core.WorkflowID("wf-input")
+	handle := newTestHandle(id)
+	tracker.mu.Lock()
+	tracker.handles[id] = handle
+	tracker.mu.Unlock()
+	eb := events.New(100)
+	t.Cleanup(eb.Close)
+	srv := NewServer(newMockStateManager(), eb, WithRoot(t.TempDir()), WithUnifiedTracker(tracker))
+
+	answered := make(chan control.InputResponse, 1)
+	go func() {
+		resp, _ := handle.ControlPlane.RequestUserInput(context.Background(), control.InputRequest{
+			ID:      "req-1",
+			Prompt:  "Which cache?",
+			Options: []string{"redis", "memcached"},
+			Default: "redis",
+			TaskID:  "task-1",
+			Timeout: time.Minute,
+		})
+		answered <- resp
+	}()
+
+	var list WorkflowInputsResponse
+	deadline := time.Now().Add(time.Second)
+	for len(list.Inputs) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("question never listed")
+		}
+		time.Sleep(5 * time.Millisecond)
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/workflows/wf-input/inputs", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("list: expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+	}
+	got := list.Inputs[0]
+	if got.ID != "req-1" || got.TaskID != "task-1" || got.Default != "redis" || got.TimeoutMS != 60000 || got.ExpiresAt == nil {
+		t.Errorf("listed input = %+v", got)
+	}
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/workflows/wf-input/inputs/req-1",
+		strings.NewReader(`{"input":"memcached"}`)))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("answer: expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	select {
+	case resp := <-answered:
+		if resp.Input != "memcached" {
+			t.Errorf("answer = %q, want memcached", resp.Input)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("answer never delivered")
+	}
+
+	// The question is gone once answered.
+	rec = httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/workflows/wf-input/inputs/req-1",
+		strings.NewReader(`{"cancel":true}`)))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("answer after resolution: expected 404, got %d", rec.Code)
+	}
+}
+
+func TestWorkflowInputs_NotRunning(t *testing.T) {
+	t.Parallel()
+	eb := events.New(100)
+	t.Cleanup(eb.Close)
+	srv := NewServer(newMockStateManager(), eb, WithRoot(t.TempDir()))
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/workflows/wf-1/inputs", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"inputs":[]`) {
+		t.Errorf("list: got %d %s, want 200 with no inputs", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/workflows/wf-1/inputs/req-1",
+		strings.NewReader(`{"input":"yes"}`)))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("answer: expected 409, got %d", rec.Code)
+	}
+}
+
+func TestSendEventToClient_UserInputRequested(t *testing.T) {
+	t.Parallel()
+	bus := events.New(10)
+	s := newTestServer(bus)
+
+	event := events.NewUserInputRequestedEvent("wf-1", "", "req-1", "Which cache?", []string{"redis"})
+	event.TaskID = "task-1"
+	event.Default = "redis"
+	event.Timeout = time.Minute
+	rec := httptest.NewRecorder()
+	s.sendEventToClient(rec, mockFlusher{}, event)
+
+	eventType, payload := parseSSEPayload(t, rec.Body.String())
+	if eventType != "user_input_requested" {
+		t.Errorf("expected event type 'user_input_requested', got %q", eventType)
+	}
+	if payload["task_id"] != "task-1" || payload["request_id"] != "req-1" || payload["default"] != "redis" {
+		t.Errorf("payload = %v", payload)
+	}
+	if payload["timeout_ms"] != float64(60000) {
+		t.Errorf("timeout_ms = %v, want 60000", payload["timeout_ms"])
+	}
+}
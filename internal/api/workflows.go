@@ -21,6 +21,8 @@ import (
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/runqueue"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/workflow"
 )
 
@@ -49,9 +51,17 @@ type WorkflowResponse struct {
 	LockHolderHost     string            `json:"lock_holder_host,omitempty"`
 	TaskCount          int               `json:"task_count"`
 	Metrics            *Metrics          `json:"metrics,omitempty"`
-	AgentEvents        []core.AgentEvent `json:"agent_events,omitempty"` // Persisted agent activity
-	Tasks              []TaskResponse    `json:"tasks,omitempty"`        // Persisted task state for reload
-	Blueprint          *BlueprintDTO     `json:"blueprint,omitempty"`    // Workflow orchestration blueprint
+	AgentEvents        []core.AgentEvent `json:"agent_events,omitempty"`   // Persisted agent activity
+	Tasks              []TaskResponse    `json:"tasks,omitempty"`          // Persisted task state for reload
+	Blueprint          *BlueprintDTO     `json:"blueprint,omitempty"`      // Workflow orchestration blueprint
+	SourceIssue        *core.IssueLink   `json:"source_issue,omitempty"`   // Issue the workflow was created from
+	SourceChat         *core.ChatLink    `json:"source_chat,omitempty"`    // Chat session the workflow was promoted from
+	ConfigVersion      int               `json:"config_version,omitempty"` // Config history version of the latest execution
+	// Other repositories of a multi-repository workflow and their branches and PRs.
+	Repositories     []core.WorkflowRepository        `json:"repositories,omitempty"`
+	RepositoryStates map[string]*core.RepositoryState `json:"repository_states,omitempty"`
+	// Calls that ran on a fallback agent while their agent was quarantined.
+	AgentSubstitutions []core.AgentSubstitution `json:"agent_substitutions,omitempty"`
 }
 
 // Metrics represents workflow metrics in API responses.
@@ -66,6 +76,9 @@ type CreateWorkflowRequest struct {
 	Prompt    string        `json:"prompt"`
 	Title     string        `json:"title,omitempty"`
 	Blueprint *BlueprintDTO `json:"blueprint,omitempty"`
+	// Repositories are registered projects (ID, name or path) the workflow
+	// also changes.
+	Repositories []string `json:"repositories,omitempty"`
 }
 
 // BlueprintDTO represents the workflow blueprint in API requests/responses.
@@ -155,6 +168,8 @@ type RunWorkflowResponse struct {
 	CurrentPhase string `json:"current_phase"`
 	Prompt       string `json:"prompt"`
 	Message      string `json:"message"`
+	// QueueItem is set when the run was queued rather than started.
+	QueueItem *runqueue.Item `json:"queue_item,omitempty"`
 }
 
 // ReplanRequest is the request body for replanning with additional context.
@@ -507,12 +522,25 @@ func (s *Server) handleCreateWorkflow(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	projectRoot := s.getProjectRootPath(ctx)
+	var repositories []core.WorkflowRepository
+	if len(req.Repositories) > 0 {
+		if s.projectRegistry == nil {
+			respondError(w, http.StatusServiceUnavailable, "project registry not available")
+			return
+		}
+		repositories, err = project.ResolveWorkflowRepositories(ctx, s.projectRegistry, req.Repositories, projectRoot)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
 	// Generate workflow ID
 	workflowID := generateWorkflowID()
 
 	// Create report directory eagerly to ensure it exists before execution
 	// This prevents issues where ReportPath is empty if execution fails early
-	projectRoot := s.getProjectRootPath(ctx)
 	reportPath := filepath.Join(".quorum", "runs", string(workflowID))
 	fullReportPath := filepath.Join(projectRoot, reportPath)
 	if err := os.MkdirAll(fullReportPath, 0o750); err != nil {
@@ -545,12 +573,31 @@ func (s *Server) handleCreateWorkflow(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Create workflow state
-	state := &core.WorkflowState{
+	state := newPendingWorkflowState(workflowID, req.Title, req.Prompt, blueprint, reportPath)
+	state.Repositories = repositories
+
+	if err := stateManager.Save(ctx, state); err != nil {
+		s.logger.Error("failed to save workflow", "workflow_id", workflowID, "error", err)
+		respondError(w, http.StatusInternalServerError, "failed to create workflow")
+		return
+	}
+
+	response := s.stateToWorkflowResponse(ctx, state, workflowID)
+	if duplicateWarning != "" {
+		response.Warning = duplicateWarning
+	}
+	respondJSON(w, http.StatusCreated, response)
+}
+
+// newPendingWorkflowState builds the state of a workflow created through the
+// API: pending, in the Kanban refinement column, with auto-resume enabled.
+func newPendingWorkflowState(workflowID core.WorkflowID, title, prompt string, blueprint *core.Blueprint, reportPath string) *core.WorkflowState {
+	return &core.WorkflowState{
 		WorkflowDefinition: core.WorkflowDefinition{
 			Version:    core.CurrentStateVersion,
 			WorkflowID: workflowID,
-			Title:      req.Title,
-			Prompt:     req.Prompt,
+			Title:      title,
+			Prompt:     prompt,
 			Blueprint:  blueprint,
 			CreatedAt:  time.Now(),
 		},
@@ -569,18 +616,6 @@ func (s *Server) handleCreateWorkflow(w http.ResponseWriter, r *http.Request) {
 			ReportPath:     reportPath, // Set eagerly to ensure it exists even if execution fails early
 		},
 	}
-
-	if err := stateManager.Save(ctx, state); err != nil {
-		s.logger.Error("failed to save workflow", "workflow_id", workflowID, "error", err)
-		respondError(w, http.StatusInternalServerError, "failed to create workflow")
-		return
-	}
-
-	response := s.stateToWorkflowResponse(ctx, state, workflowID)
-	if duplicateWarning != "" {
-		response.Warning = duplicateWarning
-	}
-	respondJSON(w, http.StatusCreated, response)
 }
 
 // handleUpdateWorkflow updates an existing workflow.
@@ -878,25 +913,31 @@ func (s *Server) stateToWorkflowResponse(ctx context.Context, state *core.Workfl
 	}
 
 	resp := WorkflowResponse{
-		ID:               string(state.WorkflowID),
-		ExecutionID:      state.ExecutionID,
-		Title:            state.Title,
-		Status:           string(state.Status),
-		CurrentPhase:     string(state.CurrentPhase),
-		Prompt:           state.Prompt,
-		OptimizedPrompt:  state.OptimizedPrompt,
-		Attachments:      state.Attachments,
-		Error:            state.Error,
-		ReportPath:       state.ReportPath,
-		CreatedAt:        state.CreatedAt,
-		UpdatedAt:        state.UpdatedAt,
-		HeartbeatAt:      heartbeatAt,
-		IsActive:         state.WorkflowID == activeID,
-		ActuallyRunning:  s.isWorkflowRunning(ctx, string(state.WorkflowID)),
-		RunningInDB:      runningInDB,
-		ControlAvailable: controlAvailable,
-		TaskCount:        len(state.Tasks),
-		AgentEvents:      state.AgentEvents,
+		ID:                 string(state.WorkflowID),
+		ExecutionID:        state.ExecutionID,
+		Title:              state.Title,
+		Status:             string(state.Status),
+		CurrentPhase:       string(state.CurrentPhase),
+		Prompt:             state.Prompt,
+		OptimizedPrompt:    state.OptimizedPrompt,
+		Attachments:        state.Attachments,
+		Error:              state.Error,
+		ReportPath:         state.ReportPath,
+		CreatedAt:          state.CreatedAt,
+		UpdatedAt:          state.UpdatedAt,
+		HeartbeatAt:        heartbeatAt,
+		IsActive:           state.WorkflowID == activeID,
+		ActuallyRunning:    s.isWorkflowRunning(ctx, string(state.WorkflowID)),
+		RunningInDB:        runningInDB,
+		ControlAvailable:   controlAvailable,
+		TaskCount:          len(state.Tasks),
+		AgentEvents:        state.AgentEvents,
+		SourceIssue:        state.SourceIssue,
+		SourceChat:         state.SourceChat,
+		ConfigVersion:      state.ConfigVersion,
+		Repositories:       state.Repositories,
+		RepositoryStates:   state.RepositoryStates,
+		AgentSubstitutions: state.AgentSubstitutions,
 	}
 
 	if runningRec != nil {
@@ -1062,7 +1103,7 @@ func (s *Server) handleDownloadWorkflow(w http.ResponseWriter, r *http.Request)
 // POST /api/v1/workflows/{workflowID}/run
 //
 // Returns:
-//   - 202 Accepted: Workflow execution started
+//   - 202 Accepted: Workflow execution started, or queued when the run queue is enabled
 //   - 404 Not Found: Workflow not found
 //   - 409 Conflict: Workflow already running or completed
 //   - 503 Service Unavailable: Execution not available (missing dependencies)
@@ -1095,10 +1136,15 @@ func (s *Server) HandleRunWorkflow(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Queue the run when the run queue is enabled; the dispatcher starts it
+	// once a slot is free.
+	if s.runQueue != nil {
+		s.enqueueWorkflowRun(w, r, state)
+		return
+	}
+
 	// Determine if this is a resume based on original state before validation
-	isResume := state.Status == core.WorkflowStatusFailed ||
-		state.Status == core.WorkflowStatusPaused ||
-		len(state.Checkpoints) > 0
+	isResume := isResumableState(state)
 
 	// Use WorkflowExecutor if available (preferred path with heartbeat support)
 	if s.executor != nil {
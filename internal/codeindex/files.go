@@ -0,0 +1,87 @@
+package codeindex
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// skippedDirs are never indexed: VCS metadata, dependencies and quorum's own
+// state, reports and worktrees.
+var skippedDirs = map[string]bool{
+	".git":         true,
+	".quorum":      true,
+	".worktrees":   true,
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// gitHead returns the commit checked out at root, or "" outside a git
+// repository.
+func gitHead(ctx context.Context, root string) string {
+	out, err := exec.CommandContext(ctx, "git", "-C", root, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// listFiles returns the slash-separated paths, relative to root, of the files
+// to index. In a git repository these are the tracked and untracked files
+// that .gitignore does not exclude.
+func listFiles(ctx context.Context, root string) ([]string, error) {
+	out, err := exec.CommandContext(ctx, "git", "-C", root, "ls-files", "-z", "--cached", "--others", "--exclude-standard").Output()
+	if err != nil {
+		return walkFiles(root)
+	}
+	var files []string
+	for _, p := range bytes.Split(out, []byte{0}) {
+		if len(p) > 0 && !inSkippedDir(string(p)) {
+			files = append(files, string(p))
+		}
+	}
+	return files, nil
+}
+
+// walkFiles lists the files under root when it is not a git repository.
+func walkFiles(root string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != root && skippedDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	return files, err
+}
+
+func inSkippedDir(p string) bool {
+	dir, _, found := strings.Cut(p, "/")
+	return found && skippedDirs[dir]
+}
+
+// isBinary reports whether content looks like a binary file.
+func isBinary(content []byte) bool {
+	const sniffLen = 8000
+	if len(content) > sniffLen {
+		content = content[:sniffLen]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
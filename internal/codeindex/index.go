@@ -0,0 +1,508 @@
+// Package codeindex maintains a local keyword index of a project's files and
+// symbols. It grounds the analyze and plan prompts with the files most likely
+// relevant to a request, so that agents do not each rediscover them.
+//
+// The index is a SQLite database holding a BM25 inverted index over file
+// contents, paths and declared symbols. It is refreshed incrementally when the
+// checked out commit changes: only files whose size or modification time
+// changed are read again.
+package codeindex
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	_ "modernc.org/sqlite"
+)
+
+// Defaults applied to zero Options.
+const (
+	DefaultMaxResults  = 20
+	DefaultMaxBytes    = 6000
+	DefaultMaxFileSize = 512 * 1024
+)
+
+const (
+	// schemaVersion is bumped when the schema or the tokenizer changes; an
+	// index built by another version is rebuilt from scratch.
+	schemaVersion = "1"
+
+	// BM25 parameters.
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	// Terms of the path and of declared symbols weigh more than the content.
+	pathWeight   = 5
+	symbolWeight = 3
+
+	maxQueryTerms     = 64
+	maxSymbolsPerFile = 5
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS meta (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS files (
+	id       INTEGER PRIMARY KEY,
+	path     TEXT NOT NULL UNIQUE,
+	size     INTEGER NOT NULL,
+	mod_time INTEGER NOT NULL,
+	length   INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS symbols (
+	file_id INTEGER NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+	name    TEXT NOT NULL,
+	kind    TEXT NOT NULL,
+	line    INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_symbols_file ON symbols(file_id);
+CREATE TABLE IF NOT EXISTS postings (
+	term    TEXT NOT NULL,
+	file_id INTEGER NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+	tf      INTEGER NOT NULL,
+	PRIMARY KEY (term, file_id)
+) WITHOUT ROWID;
+CREATE INDEX IF NOT EXISTS idx_postings_file ON postings(file_id);
+`
+
+// Options configures an Indexer.
+type Options struct {
+	// DBPath is the index database.
+	DBPath string
+	// MaxResults caps the files returned by RelevantContext.
+	MaxResults int
+	// MaxBytes caps the size of the section returned by RelevantContext.
+	MaxBytes int
+	// MaxFileSize is the size above which files are not indexed.
+	MaxFileSize int64
+}
+
+// Indexer indexes the files under a project root.
+type Indexer struct {
+	root string
+	opts Options
+	// mu serializes refreshes of the same index within the process.
+	mu sync.Mutex
+}
+
+// Result is a file matching a search.
+type Result struct {
+	Path  string
+	Score float64
+	// Symbols are the file's declarations that match the query, or its first
+	// declarations when none do.
+	Symbols []Symbol
+}
+
+// Stats describes a refresh.
+type Stats struct {
+	Files   int
+	Indexed int
+	Removed int
+	// Skipped is true when the index was already up to date.
+	Skipped bool
+}
+
+// New creates an Indexer for the files under root.
+func New(root string, opts Options) *Indexer {
+	if opts.MaxResults <= 0 {
+		opts.MaxResults = DefaultMaxResults
+	}
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = DefaultMaxBytes
+	}
+	if opts.MaxFileSize <= 0 {
+		opts.MaxFileSize = DefaultMaxFileSize
+	}
+	if opts.DBPath == "" {
+		opts.DBPath = filepath.Join(root, ".quorum", "index", "index.db")
+	}
+	return &Indexer{root: root, opts: opts}
+}
+
+// RelevantContext refreshes the index and returns a markdown list of the
+// files and symbols most relevant to query, within the configured budget.
+// It returns an empty string when nothing matches.
+func (ix *Indexer) RelevantContext(ctx context.Context, query string) (string, error) {
+	if _, err := ix.Refresh(ctx); err != nil {
+		return "", err
+	}
+	results, err := ix.Search(ctx, query, ix.opts.MaxResults)
+	if err != nil {
+		return "", err
+	}
+	return Format(results, ix.opts.MaxBytes), nil
+}
+
+// Refresh brings the index up to date with the files under the root. It does
+// nothing when the index was built at the commit checked out now; outside a
+// git repository it always compares the files' sizes and modification times.
+func (ix *Indexer) Refresh(ctx context.Context) (Stats, error) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	db, err := ix.open()
+	if err != nil {
+		return Stats{}, err
+	}
+	defer db.Close()
+
+	head := gitHead(ctx, ix.root)
+	indexed, err := getMeta(ctx, db, "head")
+	if err != nil {
+		return Stats{}, err
+	}
+	if head != "" && head == indexed {
+		return Stats{Skipped: true}, nil
+	}
+
+	paths, err := listFiles(ctx, ix.root)
+	if err != nil {
+		return Stats{}, fmt.Errorf("listing files: %w", err)
+	}
+
+	type fileMeta struct{ size, modTime int64 }
+	existing := make(map[string]fileMeta)
+	rows, err := db.QueryContext(ctx, `SELECT path, size, mod_time FROM files`)
+	if err != nil {
+		return Stats{}, fmt.Errorf("reading index: %w", err)
+	}
+	for rows.Next() {
+		var p string
+		var m fileMeta
+		if err := rows.Scan(&p, &m.size, &m.modTime); err != nil {
+			rows.Close()
+			return Stats{}, fmt.Errorf("reading index: %w", err)
+		}
+		existing[p] = m
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return Stats{}, fmt.Errorf("reading index: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return Stats{}, fmt.Errorf("starting refresh: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var stats Stats
+	for _, p := range paths {
+		if err := ctx.Err(); err != nil {
+			return Stats{}, err
+		}
+		info, err := os.Stat(filepath.Join(ix.root, filepath.FromSlash(p)))
+		if err != nil || !info.Mode().IsRegular() || info.Size() > ix.opts.MaxFileSize {
+			continue
+		}
+		m, ok := existing[p]
+		if ok && m.size == info.Size() && m.modTime == info.ModTime().UnixNano() {
+			delete(existing, p)
+			stats.Files++
+			continue
+		}
+		// Unreadable and binary files stay in existing and are removed below.
+		content, err := os.ReadFile(filepath.Join(ix.root, filepath.FromSlash(p)))
+		if err != nil || isBinary(content) {
+			continue
+		}
+		delete(existing, p)
+		stats.Files++
+		if err := deleteFile(ctx, tx, p); err != nil {
+			return Stats{}, err
+		}
+		if err := indexFile(ctx, tx, p, content, info.Size(), info.ModTime().UnixNano()); err != nil {
+			return Stats{}, err
+		}
+		stats.Indexed++
+	}
+	for p := range existing {
+		if err := deleteFile(ctx, tx, p); err != nil {
+			return Stats{}, err
+		}
+		stats.Removed++
+	}
+	if err := setMeta(ctx, tx, "head", head); err != nil {
+		return Stats{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return Stats{}, fmt.Errorf("committing refresh: %w", err)
+	}
+	return stats, nil
+}
+
+// Search ranks the indexed files against query with BM25 and returns the
+// best limit of them.
+func (ix *Indexer) Search(ctx context.Context, query string, limit int) ([]Result, error) {
+	terms := queryTerms(query, maxQueryTerms)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	db, err := ix.open()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	var total int
+	var avgLen float64
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*), COALESCE(AVG(length), 0) FROM files`).Scan(&total, &avgLen); err != nil {
+		return nil, fmt.Errorf("reading index: %w", err)
+	}
+	if total == 0 {
+		return nil, nil
+	}
+
+	scores := make(map[int64]float64)
+	for _, term := range terms {
+		rows, err := db.QueryContext(ctx,
+			`SELECT p.file_id, p.tf, f.length FROM postings p JOIN files f ON f.id = p.file_id WHERE p.term = ?`, term)
+		if err != nil {
+			return nil, fmt.Errorf("searching index: %w", err)
+		}
+		type posting struct {
+			fileID int64
+			tf     int
+			length int
+		}
+		var postings []posting
+		for rows.Next() {
+			var p posting
+			if err := rows.Scan(&p.fileID, &p.tf, &p.length); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("searching index: %w", err)
+			}
+			postings = append(postings, p)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("searching index: %w", err)
+		}
+
+		df := float64(len(postings))
+		idf := math.Log((float64(total)-df+0.5)/(df+0.5) + 1)
+		for _, p := range postings {
+			tf := float64(p.tf)
+			norm := 1 - bm25B + bm25B*float64(p.length)/avgLen
+			scores[p.fileID] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+	}
+
+	ids := make([]int64, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	if limit > 0 && len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	wanted := make(map[string]bool, len(terms))
+	for _, t := range terms {
+		wanted[t] = true
+	}
+	results := make([]Result, 0, len(ids))
+	for _, id := range ids {
+		r := Result{Score: scores[id]}
+		if err := db.QueryRowContext(ctx, `SELECT path FROM files WHERE id = ?`, id).Scan(&r.Path); err != nil {
+			return nil, fmt.Errorf("searching index: %w", err)
+		}
+		symbols, err := fileSymbols(ctx, db, id)
+		if err != nil {
+			return nil, err
+		}
+		r.Symbols = matchingSymbols(symbols, wanted)
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// Format renders results as a markdown list no longer than maxBytes; files
+// that do not fit are left out.
+func Format(results []Result, maxBytes int) string {
+	var b strings.Builder
+	for _, r := range results {
+		line := "- `" + r.Path + "`"
+		if len(r.Symbols) > 0 {
+			parts := make([]string, len(r.Symbols))
+			for i, s := range r.Symbols {
+				parts[i] = fmt.Sprintf("`%s` (%s, line %d)", s.Name, s.Kind, s.Line)
+			}
+			line += ": " + strings.Join(parts, ", ")
+		}
+		line += "\n"
+		if maxBytes > 0 && b.Len()+len(line) > maxBytes {
+			break
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+// open opens the index database, creating or rebuilding it as needed.
+func (ix *Indexer) open() (*sql.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(ix.opts.DBPath), 0o750); err != nil {
+		return nil, fmt.Errorf("creating index directory: %w", err)
+	}
+	db, err := sql.Open("sqlite", ix.opts.DBPath+"?_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("opening index: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+func migrate(db *sql.DB) error {
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		return fmt.Errorf("creating index schema: %w", err)
+	}
+	version, err := getMeta(ctx, db, "schema")
+	if err != nil {
+		return err
+	}
+	if version == schemaVersion {
+		return nil
+	}
+	// Built by another version (or new): start over.
+	if _, err := db.ExecContext(ctx, `DELETE FROM files; DELETE FROM meta;`); err != nil {
+		return fmt.Errorf("resetting index: %w", err)
+	}
+	return setMeta(ctx, db, "schema", schemaVersion)
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func getMeta(ctx context.Context, db *sql.DB, key string) (string, error) {
+	var value string
+	err := db.QueryRowContext(ctx, `SELECT value FROM meta WHERE key = ?`, key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading index metadata: %w", err)
+	}
+	return value, nil
+}
+
+func setMeta(ctx context.Context, db execer, key, value string) error {
+	if _, err := db.ExecContext(ctx,
+		`INSERT INTO meta (key, value) VALUES (?, ?) ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+		key, value); err != nil {
+		return fmt.Errorf("writing index metadata: %w", err)
+	}
+	return nil
+}
+
+func deleteFile(ctx context.Context, tx *sql.Tx, p string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM files WHERE path = ?`, p); err != nil {
+		return fmt.Errorf("removing %s from index: %w", p, err)
+	}
+	return nil
+}
+
+// indexFile adds a file, its symbols and its postings to the index.
+func indexFile(ctx context.Context, tx *sql.Tx, p string, content []byte, size, modTime int64) error {
+	symbols := extractSymbols(p, content)
+	counts := termCounts(string(content))
+	tokenize(p, func(term string) { counts[term] += pathWeight })
+	for _, s := range symbols {
+		tokenize(s.Name, func(term string) { counts[term] += symbolWeight })
+	}
+	length := 0
+	for _, n := range counts {
+		length += n
+	}
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO files (path, size, mod_time, length) VALUES (?, ?, ?, ?)`, p, size, modTime, length)
+	if err != nil {
+		return fmt.Errorf("indexing %s: %w", p, err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("indexing %s: %w", p, err)
+	}
+
+	for _, s := range symbols {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO symbols (file_id, name, kind, line) VALUES (?, ?, ?, ?)`, id, s.Name, s.Kind, s.Line); err != nil {
+			return fmt.Errorf("indexing %s: %w", p, err)
+		}
+	}
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO postings (term, file_id, tf) VALUES (?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("indexing %s: %w", p, err)
+	}
+	defer stmt.Close()
+	for term, tf := range counts {
+		if _, err := stmt.ExecContext(ctx, term, id, tf); err != nil {
+			return fmt.Errorf("indexing %s: %w", p, err)
+		}
+	}
+	return nil
+}
+
+func fileSymbols(ctx context.Context, db *sql.DB, fileID int64) ([]Symbol, error) {
+	rows, err := db.QueryContext(ctx, `SELECT name, kind, line FROM symbols WHERE file_id = ? ORDER BY line`, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("reading symbols: %w", err)
+	}
+	defer rows.Close()
+	var symbols []Symbol
+	for rows.Next() {
+		var s Symbol
+		if err := rows.Scan(&s.Name, &s.Kind, &s.Line); err != nil {
+			return nil, fmt.Errorf("reading symbols: %w", err)
+		}
+		symbols = append(symbols, s)
+	}
+	return symbols, rows.Err()
+}
+
+// matchingSymbols returns the symbols whose names contain a query term, or
+// the first symbols when none do, at most maxSymbolsPerFile.
+func matchingSymbols(symbols []Symbol, wanted map[string]bool) []Symbol {
+	var matched []Symbol
+	for _, s := range symbols {
+		hit := false
+		tokenize(s.Name, func(term string) { hit = hit || wanted[term] })
+		if hit {
+			matched = append(matched, s)
+		}
+		if len(matched) == maxSymbolsPerFile {
+			break
+		}
+	}
+	if len(matched) > 0 {
+		return matched
+	}
+	if len(symbols) > maxSymbolsPerFile {
+		symbols = symbols[:maxSymbolsPerFile]
+	}
+	return symbols
+}
@@ -0,0 +1,239 @@
+package codeindex
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func newTestIndexer(t *testing.T, root string) *Indexer {
+	t.Helper()
+	return New(root, Options{DBPath: filepath.Join(t.TempDir(), "index.db")})
+}
+
+var sampleFiles = map[string]string{
+	"internal/billing/invoice.go": `package billing
+
+// Invoice is a customer invoice.
+type Invoice struct{ Total int }
+
+// ComputeInvoiceTotal sums the invoice lines.
+func ComputeInvoiceTotal(lines []int) int { return 0 }
+
+func (i *Invoice) Refund() {}
+`,
+	"internal/auth/session.go": `package auth
+
+type Session struct{ Token string }
+
+func NewSession(token string) *Session { return &Session{Token: token} }
+`,
+	"web/src/cart.ts": `export class Cart {}
+
+export async function addToCart(item: string) {}
+`,
+	"README.md": "# Shop\n\nAn online shop with sessions, a cart and invoices.\n",
+}
+
+func TestSplitIdentifier(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string][]string{
+		"WorkflowRunner":   {"Workflow", "Runner"},
+		"HTTPServer":       {"HTTP", "Server"},
+		"parse_task_file":  {"parse", "task", "file"},
+		"base64Encode":     {"base", "64", "Encode"},
+		"lowercase":        {"lowercase"},
+		"getURLForProject": {"get", "URL", "For", "Project"},
+	}
+	for in, want := range tests {
+		if got := splitIdentifier(in); !reflect.DeepEqual(got, want) {
+			t.Errorf("splitIdentifier(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestQueryTerms(t *testing.T) {
+	t.Parallel()
+
+	got := queryTerms("Fix the invoice total: ComputeInvoiceTotal returns a wrong invoice total", 3)
+	want := []string{"invoice", "total", "fix"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("queryTerms() = %v, want %v", got, want)
+	}
+}
+
+func TestExtractSymbols(t *testing.T) {
+	t.Parallel()
+
+	got := extractSymbols("invoice.go", []byte(sampleFiles["internal/billing/invoice.go"]))
+	want := []Symbol{
+		{Name: "Invoice", Kind: "type", Line: 4},
+		{Name: "ComputeInvoiceTotal", Kind: "func", Line: 7},
+		{Name: "Invoice.Refund", Kind: "method", Line: 9},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Go symbols = %+v, want %+v", got, want)
+	}
+
+	got = extractSymbols("cart.ts", []byte(sampleFiles["web/src/cart.ts"]))
+	want = []Symbol{
+		{Name: "Cart", Kind: "class", Line: 1},
+		{Name: "addToCart", Kind: "func", Line: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("regex symbols = %+v, want %+v", got, want)
+	}
+
+	if got := extractSymbols("README.md", []byte("type Foo\n")); got != nil {
+		t.Errorf("markdown symbols = %+v, want none", got)
+	}
+}
+
+func TestIndexer_Search(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFiles(t, root, sampleFiles)
+	ix := newTestIndexer(t, root)
+	ctx := context.Background()
+
+	if _, err := ix.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	results, err := ix.Search(ctx, "Refunds are missing from the invoice total", 2)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(results) == 0 || results[0].Path != "internal/billing/invoice.go" {
+		t.Fatalf("Search() = %+v, want invoice.go first", results)
+	}
+	if len(results) > 2 {
+		t.Errorf("Search() returned %d results, want at most 2", len(results))
+	}
+	var names []string
+	for _, s := range results[0].Symbols {
+		names = append(names, s.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"Invoice", "ComputeInvoiceTotal", "Invoice.Refund"}) {
+		t.Errorf("matching symbols = %v", names)
+	}
+
+	section, err := ix.RelevantContext(ctx, "add an item to the shopping cart")
+	if err != nil {
+		t.Fatalf("RelevantContext() error = %v", err)
+	}
+	if !strings.HasPrefix(section, "- `web/src/cart.ts`: `Cart` (class, line 1), `addToCart` (func, line 3)\n") {
+		t.Errorf("RelevantContext() =\n%s", section)
+	}
+}
+
+func TestIndexer_RefreshIsIncremental(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFiles(t, root, sampleFiles)
+	writeFiles(t, root, map[string]string{"node_modules/dep/index.js": "function dep() {}\n"})
+	ix := newTestIndexer(t, root)
+	ctx := context.Background()
+
+	stats, err := ix.Refresh(ctx)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if stats.Files != len(sampleFiles) || stats.Indexed != len(sampleFiles) {
+		t.Fatalf("first Refresh() = %+v, want %d files indexed", stats, len(sampleFiles))
+	}
+
+	stats, _ = ix.Refresh(ctx)
+	if stats.Indexed != 0 || stats.Removed != 0 {
+		t.Errorf("unchanged Refresh() = %+v, want nothing reindexed", stats)
+	}
+
+	writeFiles(t, root, map[string]string{"internal/auth/session.go": "package auth\n\nfunc Logout() {}\n"})
+	if err := os.Remove(filepath.Join(root, "README.md")); err != nil {
+		t.Fatal(err)
+	}
+	stats, _ = ix.Refresh(ctx)
+	if stats.Indexed != 1 || stats.Removed != 1 {
+		t.Errorf("Refresh() after changes = %+v, want 1 indexed and 1 removed", stats)
+	}
+	results, _ := ix.Search(ctx, "logout", 5)
+	if len(results) != 1 || results[0].Path != "internal/auth/session.go" {
+		t.Errorf("Search(logout) = %+v", results)
+	}
+}
+
+func TestIndexer_RefreshFollowsGitHead(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	writeFiles(t, root, sampleFiles)
+	writeFiles(t, root, map[string]string{".gitignore": "generated/\n", "generated/big.go": "package generated\n"})
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", root, "-c", "user.email=t@example.com", "-c", "user.name=t"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	git("add", ".")
+	git("commit", "-q", "-m", "init")
+
+	ix := newTestIndexer(t, root)
+	ctx := context.Background()
+	stats, err := ix.Refresh(ctx)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if stats.Files != len(sampleFiles)+1 {
+		t.Errorf("Refresh() indexed %d files, want the %d not ignored", stats.Files, len(sampleFiles)+1)
+	}
+
+	if stats, _ := ix.Refresh(ctx); !stats.Skipped {
+		t.Errorf("Refresh() at the same HEAD = %+v, want skipped", stats)
+	}
+
+	writeFiles(t, root, map[string]string{"internal/billing/tax.go": "package billing\n\nfunc VAT() {}\n"})
+	git("add", ".")
+	git("commit", "-q", "-m", "tax")
+	if stats, _ := ix.Refresh(ctx); stats.Skipped || stats.Indexed != 1 {
+		t.Errorf("Refresh() after a commit = %+v, want 1 indexed", stats)
+	}
+}
+
+func TestFormat_Budget(t *testing.T) {
+	t.Parallel()
+
+	results := []Result{
+		{Path: "a.go", Symbols: []Symbol{{Name: "A", Kind: "func", Line: 3}}},
+		{Path: "b.go"},
+	}
+	if got := Format(results, 0); got != "- `a.go`: `A` (func, line 3)\n- `b.go`\n" {
+		t.Errorf("Format() = %q", got)
+	}
+	if got := Format(results, 35); got != "- `a.go`: `A` (func, line 3)\n" {
+		t.Errorf("Format() within 35 bytes = %q", got)
+	}
+}
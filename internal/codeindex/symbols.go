@@ -0,0 +1,121 @@
+package codeindex
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"regexp"
+)
+
+// Symbol is a declaration found in an indexed file.
+type Symbol struct {
+	Name string
+	// Kind is func, method, type, const, var, class, interface, struct, enum,
+	// trait or module.
+	Kind string
+	Line int
+}
+
+// regexExtensions are the languages whose symbols are found by
+// symbolPattern; Go files are parsed.
+var regexExtensions = map[string]bool{
+	".c": true, ".cc": true, ".cpp": true, ".cs": true, ".h": true, ".hpp": true,
+	".java": true, ".js": true, ".jsx": true, ".kt": true, ".mjs": true,
+	".php": true, ".py": true, ".rb": true, ".rs": true, ".scala": true,
+	".swift": true, ".ts": true, ".tsx": true,
+}
+
+// symbolPattern matches the declarations of most C-like and scripting
+// languages: "def name", "export async function name", "pub struct Name"...
+var symbolPattern = regexp.MustCompile(`(?m)^[ \t]*(?:(?:export|default|public|private|protected|static|abstract|final|async|pub(?:\([a-z]+\))?)[ \t]+)*(def|class|function|fn|func|interface|struct|enum|trait|type|module)[ \t]+([A-Za-z_$][A-Za-z0-9_$]*)`)
+
+// extractSymbols returns the declarations of a file.
+func extractSymbols(name string, src []byte) []Symbol {
+	ext := path.Ext(name)
+	if ext == ".go" {
+		if symbols, ok := goSymbols(src); ok {
+			return symbols
+		}
+		return regexSymbols(src)
+	}
+	if regexExtensions[ext] {
+		return regexSymbols(src)
+	}
+	return nil
+}
+
+// goSymbols returns the functions, methods and types of a Go file and its
+// exported constants and variables. It reports false when src does not parse.
+func goSymbols(src []byte) ([]Symbol, bool) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.SkipObjectResolution)
+	if err != nil && f == nil {
+		return nil, false
+	}
+
+	var symbols []Symbol
+	add := func(name, kind string, pos token.Pos) {
+		symbols = append(symbols, Symbol{Name: name, Kind: kind, Line: fset.Position(pos).Line})
+	}
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				add(receiverName(d.Recv.List[0].Type)+"."+d.Name.Name, "method", d.Name.Pos())
+			} else {
+				add(d.Name.Name, "func", d.Name.Pos())
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					add(s.Name.Name, "type", s.Name.Pos())
+				case *ast.ValueSpec:
+					kind := "var"
+					if d.Tok == token.CONST {
+						kind = "const"
+					}
+					for _, n := range s.Names {
+						if n.IsExported() {
+							add(n.Name, kind, n.Pos())
+						}
+					}
+				}
+			}
+		}
+	}
+	return symbols, true
+}
+
+func receiverName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(e.X)
+	case *ast.IndexExpr:
+		return receiverName(e.X)
+	case *ast.IndexListExpr:
+		return receiverName(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+	return "?"
+}
+
+// regexSymbols finds declarations with symbolPattern.
+func regexSymbols(src []byte) []Symbol {
+	var symbols []Symbol
+	line, offset := 1, 0
+	for _, m := range symbolPattern.FindAllSubmatchIndex(src, -1) {
+		line += bytes.Count(src[offset:m[0]], []byte{'\n'})
+		offset = m[0]
+		kind := string(src[m[2]:m[3]])
+		switch kind {
+		case "def", "function", "fn":
+			kind = "func"
+		}
+		symbols = append(symbols, Symbol{Name: string(src[m[4]:m[5]]), Kind: kind, Line: line})
+	}
+	return symbols
+}
@@ -0,0 +1,114 @@
+package codeindex
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	minTermLen = 2
+	maxTermLen = 40
+)
+
+// stopwords are English words too common in prompts to rank files by.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "but": true, "by": true, "can": true, "do": true, "for": true,
+	"from": true, "has": true, "have": true, "if": true, "in": true, "into": true,
+	"is": true, "it": true, "its": true, "no": true, "not": true, "of": true,
+	"on": true, "or": true, "our": true, "should": true, "so": true, "that": true,
+	"the": true, "their": true, "then": true, "there": true, "these": true,
+	"this": true, "to": true, "was": true, "we": true, "when": true, "which": true,
+	"will": true, "with": true, "would": true, "you": true,
+}
+
+// tokenize calls fn with the index terms of text. Identifiers are split on
+// underscores, case changes and letter-digit boundaries; compound identifiers
+// are also emitted whole, so "WorkflowRunner" yields "workflowrunner",
+// "workflow" and "runner".
+func tokenize(text string, fn func(term string)) {
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+		switch {
+		case isWord && start < 0:
+			start = i
+		case !isWord && start >= 0:
+			emitWord(text[start:i], fn)
+			start = -1
+		}
+	}
+	if start >= 0 {
+		emitWord(text[start:], fn)
+	}
+}
+
+func emitWord(word string, fn func(term string)) {
+	parts := splitIdentifier(word)
+	if len(parts) > 1 {
+		emitTerm(strings.ToLower(strings.ReplaceAll(word, "_", "")), fn)
+	}
+	for _, p := range parts {
+		emitTerm(strings.ToLower(p), fn)
+	}
+}
+
+func emitTerm(term string, fn func(term string)) {
+	if len(term) < minTermLen || len(term) > maxTermLen || stopwords[term] {
+		return
+	}
+	if strings.IndexFunc(term, func(r rune) bool { return !unicode.IsDigit(r) }) < 0 {
+		return
+	}
+	fn(term)
+}
+
+// splitIdentifier splits snake_case and camelCase identifiers into words.
+// Acronyms stay together: "HTTPServer" splits into "HTTP" and "Server".
+func splitIdentifier(word string) []string {
+	var parts []string
+	for _, seg := range strings.Split(word, "_") {
+		runes := []rune(seg)
+		start := 0
+		for i := 1; i < len(runes); i++ {
+			prev, cur := runes[i-1], runes[i]
+			boundary := unicode.IsLower(prev) && unicode.IsUpper(cur) ||
+				unicode.IsLetter(prev) != unicode.IsLetter(cur) ||
+				i+1 < len(runes) && unicode.IsUpper(prev) && unicode.IsUpper(cur) && unicode.IsLower(runes[i+1])
+			if boundary {
+				parts = append(parts, string(runes[start:i]))
+				start = i
+			}
+		}
+		if start < len(runes) {
+			parts = append(parts, string(runes[start:]))
+		}
+	}
+	return parts
+}
+
+// termCounts returns the frequency of each term of text.
+func termCounts(text string) map[string]int {
+	counts := make(map[string]int)
+	tokenize(text, func(term string) { counts[term]++ })
+	return counts
+}
+
+// queryTerms returns the distinct terms of a query, most frequent first,
+// capped at limit so long queries (e.g. a consolidated analysis) stay cheap.
+func queryTerms(query string, limit int) []string {
+	counts := make(map[string]int)
+	var terms []string
+	tokenize(query, func(term string) {
+		if counts[term] == 0 {
+			terms = append(terms, term)
+		}
+		counts[term]++
+	})
+	sort.SliceStable(terms, func(i, j int) bool { return counts[terms[i]] > counts[terms[j]] })
+	if limit > 0 && len(terms) > limit {
+		terms = terms[:limit]
+	}
+	return terms
+}
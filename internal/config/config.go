@@ -19,6 +19,11 @@ type Config struct {
 	Chat        ChatConfig        `mapstructure:"chat" yaml:"chat"`
 	Report      ReportConfig      `mapstructure:"report" yaml:"report"`
 	Issues      IssuesConfig      `mapstructure:"issues" yaml:"issues"`
+	Index       IndexConfig       `mapstructure:"index" yaml:"index"`
+	Backup      BackupConfig      `mapstructure:"backup" yaml:"backup"`
+	Workers     WorkersConfig     `mapstructure:"workers" yaml:"workers"`
+	Queue       QueueConfig       `mapstructure:"queue" yaml:"queue"`
+	AgentHealth AgentHealthConfig `mapstructure:"agent_health" yaml:"agent_health"`
 }
 
 // ChatConfig configures chat behavior in the TUI.
@@ -26,6 +31,27 @@ type ChatConfig struct {
 	Timeout          string `mapstructure:"timeout" yaml:"timeout"`                     // Timeout for chat messages (e.g., "3m", "5m")
 	ProgressInterval string `mapstructure:"progress_interval" yaml:"progress_interval"` // Interval for progress logs (e.g., "15s")
 	Editor           string `mapstructure:"editor" yaml:"editor"`                       // Editor for file editing (e.g., "code", "nvim", "vim")
+	// Context controls how conversation history is fitted into the agent's context window.
+	Context ChatContextConfig `mapstructure:"context" yaml:"context"`
+	// AskAll configures questions asked to several agents at once (/ask-all).
+	AskAll ChatAskAllConfig `mapstructure:"ask_all" yaml:"ask_all"`
+}
+
+// ChatContextConfig configures chat context management. Older turns that do not
+// fit the token budget are replaced by a rolling summary.
+type ChatContextConfig struct {
+	RecentTurns  int    `mapstructure:"recent_turns" yaml:"recent_turns"`   // Most recent turns always sent verbatim
+	MaxTokens    int    `mapstructure:"max_tokens" yaml:"max_tokens"`       // Caps the context budget (0 = model window)
+	SummaryAgent string `mapstructure:"summary_agent" yaml:"summary_agent"` // Agent that summarizes older turns (empty = chat agent)
+	SummaryModel string `mapstructure:"summary_model" yaml:"summary_model"` // Model used for summaries (empty = agent default)
+}
+
+// ChatAskAllConfig configures fan-out questions: one message answered by several
+// agents in parallel, followed by a moderated synthesis of their replies.
+type ChatAskAllConfig struct {
+	Agents         []string `mapstructure:"agents" yaml:"agents"`                   // Agents asked by default (empty = all enabled agents)
+	Moderator      string   `mapstructure:"moderator" yaml:"moderator"`             // Agent that synthesizes the replies (empty = first asked agent)
+	ModeratorModel string   `mapstructure:"moderator_model" yaml:"moderator_model"` // Model for the synthesis (empty = agent default)
 }
 
 // LogConfig configures logging behavior.
@@ -159,6 +185,33 @@ type PlanPhaseConfig struct {
 type ExecutePhaseConfig struct {
 	// Timeout for the entire execution phase (e.g., "2h").
 	Timeout string `mapstructure:"timeout" yaml:"timeout"`
+	// Input configures the questions tasks ask a human while they run.
+	Input ExecuteInputConfig `mapstructure:"input" yaml:"input"`
+	// SkipDependents is what happens to the tasks depending on a skipped task:
+	// "run" runs them as if it had completed, "skip" skips them too.
+	SkipDependents string `mapstructure:"skip_dependents" yaml:"skip_dependents"`
+}
+
+// Policies for the dependents of a skipped task.
+const (
+	SkipDependentsRun  = "run"  // Run them as if the skipped task had completed
+	SkipDependentsSkip = "skip" // Skip them too
+)
+
+// Task input timeout policies.
+const (
+	InputTimeoutDefault = "default" // Continue with the answer the agent proposed
+	InputTimeoutSkip    = "skip"    // Skip the task
+	InputTimeoutFail    = "fail"    // Fail the task
+)
+
+// ExecuteInputConfig configures how execute tasks wait for human answers.
+type ExecuteInputConfig struct {
+	// Timeout is how long a task waits for an answer (e.g., "30m"; "0" = no limit).
+	Timeout string `mapstructure:"timeout" yaml:"timeout"`
+	// OnTimeout is what happens to an unanswered question: "default", "skip" or "fail".
+	// With "default", a question without a proposed answer fails the task.
+	OnTimeout string `mapstructure:"on_timeout" yaml:"on_timeout"`
 }
 
 // RefinerConfig configures prompt refinement before analysis.
@@ -392,6 +445,41 @@ type AgentConfig struct {
 	// IdleTimeout is the max duration without stdout activity before killing the process.
 	// Examples: "5m", "10m", "0" (disabled). Default: 5m.
 	IdleTimeout string `mapstructure:"idle_timeout" yaml:"idle_timeout"`
+	// Sandbox runs the agent CLI inside a restricted environment (Linux only).
+	Sandbox AgentSandboxConfig `mapstructure:"sandbox" yaml:"sandbox"`
+	// RateLimitRPM and RateLimitTPM are the requests and tokens per minute of
+	// the agent's plan. They size its rate limiter; 0 keeps the built-in
+	// request rate and no token budget.
+	RateLimitRPM int `mapstructure:"rate_limit_rpm" yaml:"rate_limit_rpm"`
+	RateLimitTPM int `mapstructure:"rate_limit_tpm" yaml:"rate_limit_tpm"`
+}
+
+// AgentSandboxConfig configures sandboxed execution of an agent CLI.
+// The sandbox mounts the filesystem read-only except for the task working
+// directory and WritePaths, hides other home directories and DenyPaths, and
+// optionally restricts network access and resources.
+type AgentSandboxConfig struct {
+	// Enabled turns the sandbox on for this agent.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// Phases limits sandboxing to specific phases. If empty, every phase is
+	// sandboxed. Keys: "refine", "analyze", "moderate", "synthesize", "plan", "execute"
+	Phases map[string]bool `mapstructure:"phases" yaml:"phases"`
+	// Network is the network mode: host (default), none or allowlist.
+	Network string `mapstructure:"network" yaml:"network"`
+	// AllowHosts are reachable in allowlist mode, in addition to the agent's
+	// API endpoints. Entries may be "host", "host:port" or "*.domain".
+	AllowHosts []string `mapstructure:"allow_hosts" yaml:"allow_hosts"`
+	// WritePaths are writable in addition to the working directory and the
+	// agent's own state directory (e.g. ~/.claude).
+	WritePaths []string `mapstructure:"write_paths" yaml:"write_paths"`
+	// DenyPaths are hidden from the agent.
+	DenyPaths []string `mapstructure:"deny_paths" yaml:"deny_paths"`
+	// MemoryMB caps the memory of the agent process tree (0 = unlimited).
+	MemoryMB int `mapstructure:"memory_mb" yaml:"memory_mb"`
+	// CPUPercent caps CPU usage; 100 is one core (0 = unlimited).
+	CPUPercent int `mapstructure:"cpu_percent" yaml:"cpu_percent"`
+	// MaxPids caps the number of processes and threads (0 = unlimited).
+	MaxPids int `mapstructure:"max_pids" yaml:"max_pids"`
 }
 
 // IsEnabledForPhase returns true if the agent is enabled for the given phase.
@@ -466,6 +554,10 @@ type GitTaskConfig struct {
 	// AutoCommit commits changes after each task completes.
 	// This ensures work is saved even if the workflow crashes.
 	AutoCommit bool `mapstructure:"auto_commit" yaml:"auto_commit"`
+	// CheckpointInterval commits the work in progress of a running task to a
+	// hidden ref at this interval, so that an interrupted task resumes from
+	// its last checkpoint instead of from scratch (e.g., "5m"; "0" disables).
+	CheckpointInterval string `mapstructure:"checkpoint_interval" yaml:"checkpoint_interval"`
 }
 
 // GitFinalizationConfig configures workflow result delivery.
@@ -482,6 +574,25 @@ type GitFinalizationConfig struct {
 	PRBaseBranch string `mapstructure:"pr_base_branch" yaml:"pr_base_branch"`
 	// MergeStrategy for auto-merge: merge, squash, rebase (default: squash).
 	MergeStrategy string `mapstructure:"merge_strategy" yaml:"merge_strategy"`
+	// Babysit waits for PR checks and feeds CI failures back to an agent.
+	Babysit PRBabysitConfig `mapstructure:"babysit" yaml:"babysit"`
+}
+
+// PRBabysitConfig configures the post-PR loop that waits for CI checks and,
+// when they fail, runs a fix task on the workflow branch and pushes again.
+type PRBabysitConfig struct {
+	// Enabled activates the loop after the workflow PR is created.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// MaxAttempts is the number of fix attempts before giving up (default: 3).
+	MaxAttempts int `mapstructure:"max_attempts" yaml:"max_attempts"`
+	// Agent runs the fix tasks (empty = agents.default).
+	Agent string `mapstructure:"agent" yaml:"agent"`
+	// ChecksTimeout bounds each wait for checks to complete (default: 30m).
+	ChecksTimeout string `mapstructure:"checks_timeout" yaml:"checks_timeout"`
+	// PollInterval is how often checks are polled (default: 30s).
+	PollInterval string `mapstructure:"poll_interval" yaml:"poll_interval"`
+	// RequiredChecks limits the loop to these check names (empty = all checks).
+	RequiredChecks []string `mapstructure:"required_checks" yaml:"required_checks"`
 }
 
 // GitHubConfig configures GitHub integration.
@@ -530,6 +641,23 @@ type IssuesConfig struct {
 
 	// Generator configures LLM-based issue generation.
 	Generator IssueGeneratorConfig `mapstructure:"generator" yaml:"generator" json:"generator"`
+
+	// Import configures label-based import of issues into the Kanban To Do column.
+	Import IssueImportConfig `mapstructure:"import" yaml:"import" json:"import"`
+}
+
+// IssueImportConfig configures the automatic import of labeled issues as
+// workflows. Imported issues lose the import label and get a comment with
+// the workflow ID.
+type IssueImportConfig struct {
+	// Enabled polls the repository for labeled issues while the server runs.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled" json:"enabled"`
+
+	// Labels marks issues for import; an issue carrying any of them is imported.
+	Labels []string `mapstructure:"labels" yaml:"labels" json:"labels"`
+
+	// Interval between polls (e.g., "5m").
+	Interval string `mapstructure:"interval" yaml:"interval" json:"interval"`
 }
 
 // IssuePromptConfig configures issue content formatting.
@@ -694,6 +822,104 @@ type ReportConfig struct {
 	IncludeRaw bool   `mapstructure:"include_raw" yaml:"include_raw"`
 }
 
+// IndexConfig configures the local codebase index. When enabled, the analyze
+// and plan prompts list the files and symbols most relevant to the request, so
+// that each agent does not explore the repository from scratch.
+type IndexConfig struct {
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// Path is the index database, relative to the project root.
+	Path string `mapstructure:"path" yaml:"path"`
+	// MaxResults caps the number of files listed in a prompt.
+	MaxResults int `mapstructure:"max_results" yaml:"max_results"`
+	// MaxBytes caps the size of the section added to a prompt.
+	MaxBytes int `mapstructure:"max_bytes" yaml:"max_bytes"`
+	// MaxFileSize is the size in bytes above which files are not indexed.
+	MaxFileSize int64 `mapstructure:"max_file_size" yaml:"max_file_size"`
+}
+
+// BackupConfig configures the automatic snapshot backups taken by quorum serve.
+// Backups cover every registered project and are retained per day and week.
+type BackupConfig struct {
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// Dir holds the backups (empty = backups/ next to the project registry).
+	Dir string `mapstructure:"dir" yaml:"dir"`
+	// Interval between backups (e.g., "24h").
+	Interval string `mapstructure:"interval" yaml:"interval"`
+	// KeepDaily and KeepWeekly are the number of days and weeks for which the
+	// newest backup is kept.
+	KeepDaily  int `mapstructure:"keep_daily" yaml:"keep_daily"`
+	KeepWeekly int `mapstructure:"keep_weekly" yaml:"keep_weekly"`
+	// FullEvery is the number of backups per chain: one full backup followed
+	// by incremental ones (1 = every backup is full).
+	FullEvery        int  `mapstructure:"full_every" yaml:"full_every"`
+	IncludeWorktrees bool `mapstructure:"include_worktrees" yaml:"include_worktrees"`
+	// PassphraseEnv names the environment variable holding the encryption
+	// passphrase. The passphrase itself is never stored in the config.
+	PassphraseEnv string `mapstructure:"passphrase_env" yaml:"passphrase_env"`
+	// Recipients are the age public keys backups are encrypted to, instead
+	// of a passphrase.
+	Recipients []string `mapstructure:"recipients" yaml:"recipients"`
+	// IdentityFile is the age identity file used to read the previous backup
+	// when chaining incremental backups encrypted to recipients.
+	IdentityFile string `mapstructure:"identity_file" yaml:"identity_file"`
+}
+
+// WorkersConfig configures the remote workers (quorum worker) that quorum serve
+// dispatches execute-phase tasks to.
+type WorkersConfig struct {
+	// Enabled serves the worker endpoints and dispatches tasks to the
+	// registered workers.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// TokenEnv names the environment variable holding the token workers must
+	// present (empty = no authentication).
+	TokenEnv string `mapstructure:"token_env" yaml:"token_env"`
+	// Timeout after which a silent worker is considered lost and its tasks
+	// fail (e.g., "1m").
+	Timeout string `mapstructure:"timeout" yaml:"timeout"`
+}
+
+// QueueConfig configures the durable run queue of quorum serve. Workflow runs
+// requested through the API wait in the queue until a slot is free, and runs
+// interrupted by a server restart are queued again.
+type QueueConfig struct {
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// Path of the queue database (empty = run-queue.db next to the project
+	// registry).
+	Path string `mapstructure:"path" yaml:"path"`
+	// Slots is the number of queued runs executing at once across all
+	// projects.
+	Slots int `mapstructure:"slots" yaml:"slots"`
+	// ProjectLimit is the number of queued runs executing at once in one
+	// project (0 = only limited by Slots).
+	ProjectLimit int `mapstructure:"project_limit" yaml:"project_limit"`
+	// LeaseTTL is how long a run keeps its slot without a heartbeat before it
+	// is queued again (e.g., "1m").
+	LeaseTTL string `mapstructure:"lease_ttl" yaml:"lease_ttl"`
+}
+
+// AgentHealthConfig configures agent health tracking. Every agent call is
+// recorded; an agent that keeps failing is quarantined for a cool-down period
+// and its calls run on its fallback agent meanwhile.
+type AgentHealthConfig struct {
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// Window is the rolling period calls are judged over (e.g., "1h").
+	Window string `mapstructure:"window" yaml:"window"`
+	// Cooldown is how long an unhealthy agent stays quarantined (e.g., "15m").
+	Cooldown string `mapstructure:"cooldown" yaml:"cooldown"`
+	// MinCalls is the number of calls in the window before the success rate
+	// can quarantine an agent.
+	MinCalls int `mapstructure:"min_calls" yaml:"min_calls"`
+	// MinSuccessRate below which an agent is quarantined (0-1).
+	MinSuccessRate float64 `mapstructure:"min_success_rate" yaml:"min_success_rate"`
+	// RateLimitErrors and AuthErrors in the window that quarantine an agent
+	// (0 = not judged on them).
+	RateLimitErrors int `mapstructure:"rate_limit_errors" yaml:"rate_limit_errors"`
+	AuthErrors      int `mapstructure:"auth_errors" yaml:"auth_errors"`
+	// Fallbacks maps an agent to the agent that takes its calls while it is
+	// quarantined. Agents without a fallback keep running.
+	Fallbacks map[string]string `mapstructure:"fallbacks" yaml:"fallbacks"`
+}
+
 // ExtractAgentPhases extracts the enabled phases for each agent.
 // Returns a map of agent name -> list of enabled phases.
 // An empty list means no phases are enabled (strict allowlist).
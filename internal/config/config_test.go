@@ -730,6 +730,43 @@ func TestValidator_ValidMergeStrategies(t *testing.T) {
 	}
 }
 
+func TestValidator_Babysit(t *testing.T) {
+	t.Parallel()
+
+	cfg := validConfig()
+	cfg.Git.Finalization.AutoPR = false
+	cfg.Git.Finalization.AutoMerge = false
+	cfg.Git.Finalization.Babysit = PRBabysitConfig{Enabled: true, ChecksTimeout: "soon"}
+
+	err := NewValidator().Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() error = nil, want babysit errors")
+	}
+	for _, field := range []string{"git.finalization.babysit.enabled", "git.finalization.babysit.checks_timeout"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("error = %v, should mention %s", err, field)
+		}
+	}
+}
+
+func TestValidator_IssueImport(t *testing.T) {
+	t.Parallel()
+
+	cfg := validConfig()
+	cfg.Issues.Enabled = true
+	cfg.Issues.Import = IssueImportConfig{Enabled: true, Interval: "often"}
+
+	err := NewValidator().Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() error = nil, want import errors")
+	}
+	for _, field := range []string{"issues.import.labels", "issues.import.interval"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("error = %v, should mention %s", err, field)
+		}
+	}
+}
+
 func TestValidator_TraceInvalidMode(t *testing.T) {
 	t.Parallel()
 
@@ -122,6 +122,7 @@ git:
   # Task progress - incremental saving
   task:
     auto_commit: true  # Commit after each task completes
+    checkpoint_interval: 5m  # Checkpoint the work in progress of running tasks ("0" disables)
 
   # Finalization - workflow result delivery
   finalization:
@@ -130,6 +131,9 @@ git:
     auto_merge: true      # Merge PR automatically (disable for manual review)
     pr_base_branch: ""    # Target branch (empty = repository default)
     merge_strategy: squash  # merge | squash | rebase
+    babysit:
+      enabled: false      # Wait for PR checks and auto-fix CI failures
+      max_attempts: 3     # Fix attempts before handing over for review
 
 # Workflow execution settings
 workflow:
@@ -174,4 +178,9 @@ issues:
     reasoning_effort: ""
     instructions: ""
     title_instructions: ""
+  import:
+    enabled: false
+    labels:
+      - quorum
+    interval: 5m
 `
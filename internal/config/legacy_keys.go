@@ -1,8 +1,12 @@
 package config
 
 import (
+	"bytes"
+	"fmt"
 	"reflect"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 // normalizeLegacyConfigMap maps legacy YAML keys (without underscores) to the
@@ -16,38 +20,27 @@ func normalizeLegacyConfigMap(data map[string]interface{}) map[string]interface{
 	return normalizeMapForStruct(data, reflect.TypeOf(Config{}))
 }
 
+// legacyGitKeys maps keys that used to live directly under git to their
+// section and name today.
+var legacyGitKeys = map[string][2]string{
+	"worktree_dir":  {"worktree", "dir"},
+	"worktree_mode": {"worktree", "mode"},
+	"auto_clean":    {"worktree", "auto_clean"},
+	"auto_commit":   {"task", "auto_commit"},
+}
+
 func applyLegacyPathMappings(data map[string]interface{}) {
 	if git, ok := data["git"].(map[string]interface{}); ok {
-		if val, ok := git["worktree_dir"]; ok {
-			worktree := ensureMap(git, "worktree")
-			if _, exists := worktree["dir"]; !exists {
-				worktree["dir"] = val
-			}
-			delete(git, "worktree_dir")
-		}
-
-		if val, ok := git["worktree_mode"]; ok {
-			worktree := ensureMap(git, "worktree")
-			if _, exists := worktree["mode"]; !exists {
-				worktree["mode"] = val
-			}
-			delete(git, "worktree_mode")
-		}
-
-		if val, ok := git["auto_clean"]; ok {
-			worktree := ensureMap(git, "worktree")
-			if _, exists := worktree["auto_clean"]; !exists {
-				worktree["auto_clean"] = val
+		for legacy, target := range legacyGitKeys {
+			val, ok := git[legacy]
+			if !ok {
+				continue
 			}
-			delete(git, "auto_clean")
-		}
-
-		if val, ok := git["auto_commit"]; ok {
-			task := ensureMap(git, "task")
-			if _, exists := task["auto_commit"]; !exists {
-				task["auto_commit"] = val
+			section := ensureMap(git, target[0])
+			if _, exists := section[target[1]]; !exists {
+				section[target[1]] = val
 			}
-			delete(git, "auto_commit")
+			delete(git, legacy)
 		}
 	}
 
@@ -172,3 +165,301 @@ var issueLanguageAliases = map[string]string{
 	"ja":    "japanese",
 	"jp":    "japanese",
 }
+
+// KeyIssue is a key of a config file that quorum does not read as written.
+type KeyIssue struct {
+	Key     string `json:"key"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+	// Legacy is set for keys still read under another name or value, which
+	// MigrateLegacyKeys rewrites. Other keys are unknown and ignored.
+	Legacy bool `json:"legacy"`
+}
+
+// CheckKeys reports the legacy and unknown keys of a YAML config file.
+func CheckKeys(data []byte) ([]KeyIssue, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	w := &keyWalker{}
+	if len(doc.Content) > 0 {
+		w.walk(doc.Content[0], reflect.TypeOf(Config{}), "")
+	}
+	return w.issues, nil
+}
+
+// MigrateLegacyKeys rewrites the legacy keys of a YAML config file to their
+// canonical names, keeping comments and key order. Where both the legacy and
+// the canonical key are set, the legacy one is dropped, as when loading. It
+// returns the new content and the legacy keys found; data is returned as is
+// when there are none.
+func MigrateLegacyKeys(data []byte) ([]byte, []KeyIssue, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+	w := &keyWalker{fix: true}
+	if len(doc.Content) > 0 {
+		w.walk(doc.Content[0], reflect.TypeOf(Config{}), "")
+	}
+	var legacy []KeyIssue
+	for _, issue := range w.issues {
+		if issue.Legacy {
+			legacy = append(legacy, issue)
+		}
+	}
+	if len(legacy) == 0 {
+		return data, nil, nil
+	}
+	out, err := encodeYAMLNode(&doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, legacy, nil
+}
+
+// encodeYAMLNode encodes a document with the indentation of the default config.
+func encodeYAMLNode(doc *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// keyWalker walks a config document along the Config struct, collecting the
+// keys the loader would rename or ignore and, with fix set, renaming them.
+type keyWalker struct {
+	fix    bool
+	issues []KeyIssue
+}
+
+func (w *keyWalker) walk(node *yaml.Node, t reflect.Type, path string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind == yaml.MappingNode {
+			w.walkStruct(node, t, path)
+		}
+	case reflect.Map:
+		if node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				w.walk(node.Content[i+1], t.Elem(), joinKey(path, node.Content[i].Value))
+			}
+		}
+	case reflect.Slice:
+		if node.Kind == yaml.SequenceNode {
+			for _, item := range node.Content {
+				w.walk(item, t.Elem(), path)
+			}
+		}
+	}
+}
+
+func (w *keyWalker) walkStruct(node *yaml.Node, t reflect.Type, path string) {
+	if path == "git" {
+		w.moveGitKeys(node)
+	}
+
+	fields := make(map[string]reflect.Type)
+	legacyNames := make(map[string]string)
+	for i := 0; i < t.NumField(); i++ {
+		name := canonicalTagName(t.Field(i))
+		if !t.Field(i).IsExported() || name == "" || name == "-" {
+			continue
+		}
+		fields[name] = t.Field(i).Type
+		if legacy := strings.ReplaceAll(name, "_", ""); legacy != name {
+			legacyNames[legacy] = name
+		}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valNode := node.Content[i], node.Content[i+1]
+		name := keyNode.Value
+		fieldType, ok := fields[name]
+		if !ok {
+			if _, moved := legacyGitKeys[name]; moved && path == "git" {
+				continue
+			}
+			canonical, isLegacy := legacyNames[name]
+			if !isLegacy {
+				w.issues = append(w.issues, KeyIssue{
+					Key:     joinKey(path, name),
+					Line:    keyNode.Line,
+					Message: "unknown key, ignored",
+				})
+				continue
+			}
+			shadowed := mappingIndex(node, canonical) >= 0
+			issue := KeyIssue{
+				Key:     joinKey(path, name),
+				Line:    keyNode.Line,
+				Message: "renamed to " + joinKey(path, canonical),
+				Legacy:  true,
+			}
+			if shadowed {
+				issue.Message += ", which is also set and takes precedence"
+			}
+			w.issues = append(w.issues, issue)
+			if shadowed {
+				if w.fix {
+					node.Content = append(node.Content[:i], node.Content[i+2:]...)
+					i -= 2
+				}
+				continue
+			}
+			if w.fix {
+				keyNode.Value = canonical
+			}
+			name, fieldType = canonical, fields[canonical]
+		}
+
+		if path == "issues.prompt" && name == "language" && valNode.Kind == yaml.ScalarNode {
+			if lang := normalizeIssueLanguage(valNode.Value); lang != valNode.Value {
+				w.issues = append(w.issues, KeyIssue{
+					Key:     joinKey(path, name),
+					Line:    valNode.Line,
+					Message: fmt.Sprintf("%q is read as %q", valNode.Value, lang),
+					Legacy:  true,
+				})
+				if w.fix {
+					valNode.Value = lang
+				}
+			}
+		}
+		w.walk(valNode, fieldType, joinKey(path, name))
+	}
+}
+
+// moveGitKeys reports, and with fix set moves, the keys of legacyGitKeys.
+func (w *keyWalker) moveGitKeys(git *yaml.Node) {
+	for i := 0; i+1 < len(git.Content); i += 2 {
+		keyNode := git.Content[i]
+		target, ok := legacyGitKeys[keyNode.Value]
+		if !ok {
+			continue
+		}
+		w.issues = append(w.issues, KeyIssue{
+			Key:     "git." + keyNode.Value,
+			Line:    keyNode.Line,
+			Message: "moved to git." + target[0] + "." + target[1],
+			Legacy:  true,
+		})
+		if !w.fix {
+			continue
+		}
+		valNode := git.Content[i+1]
+		git.Content = append(git.Content[:i], git.Content[i+2:]...)
+		i -= 2
+
+		section := mappingValue(git, target[0])
+		if section == nil {
+			section = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			git.Content = append(git.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: target[0]}, section)
+		}
+		if section.Kind != yaml.MappingNode || mappingIndex(section, target[1]) >= 0 {
+			continue
+		}
+		keyNode.Value = target[1]
+		section.Content = append(section.Content, keyNode, valNode)
+	}
+}
+
+// mappingIndex returns the index of key's node in a mapping node, or -1.
+func mappingIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// mappingValue returns the value node of key in a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if i := mappingIndex(node, key); i >= 0 {
+		return node.Content[i+1]
+	}
+	return nil
+}
+
+// KeyLine returns the line of a dotted key in a YAML config file, also
+// matching legacy names without underscores, or 0 when the key is not set.
+func KeyLine(data []byte, key string) int {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return 0
+	}
+	node, line := doc.Content[0], 0
+	for _, part := range strings.Split(key, ".") {
+		if node.Kind != yaml.MappingNode {
+			return 0
+		}
+		i := mappingIndex(node, part)
+		if i < 0 {
+			i = mappingIndex(node, strings.ReplaceAll(part, "_", ""))
+		}
+		if i < 0 {
+			return 0
+		}
+		node, line = node.Content[i+1], node.Content[i].Line
+	}
+	return line
+}
+
+// SetKey sets a dotted key of a YAML config file to value, creating the
+// sections it needs and keeping comments. A key set under its legacy name is
+// updated in place.
+func SetKey(data []byte, key string, value interface{}) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	var valNode yaml.Node
+	if err := valNode.Encode(value); err != nil {
+		return nil, err
+	}
+
+	node := doc.Content[0]
+	parts := strings.Split(key, ".")
+	for n, part := range parts {
+		if node.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s is not a section", strings.Join(parts[:n], "."))
+		}
+		i := mappingIndex(node, part)
+		if i < 0 {
+			i = mappingIndex(node, strings.ReplaceAll(part, "_", ""))
+		}
+		if n == len(parts)-1 {
+			if i < 0 {
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: part}, &valNode)
+			} else {
+				valNode.HeadComment = node.Content[i+1].HeadComment
+				valNode.LineComment = node.Content[i+1].LineComment
+				node.Content[i+1] = &valNode
+			}
+			break
+		}
+		if i < 0 {
+			next := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: part}, next)
+			node = next
+			continue
+		}
+		node = node.Content[i+1]
+	}
+	return encodeYAMLNode(&doc)
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -70,3 +72,135 @@ func collectConfigStructTypes(t reflect.Type, seen map[reflect.Type]struct{}) {
 		}
 	}
 }
+
+const legacyConfigYAML = `# project config
+workflow:
+  maxretries: 5 # retries per task
+  max_retries: 4
+git:
+  worktree_dir: .wt
+  autocommit: true
+  auto_commit: false
+issues:
+  prompt:
+    language: es
+`
+
+func TestCheckKeys_ReportsLegacyAndUnknownKeys(t *testing.T) {
+	t.Parallel()
+	issues, err := CheckKeys([]byte(legacyConfigYAML))
+	if err != nil {
+		t.Fatalf("CheckKeys() error = %v", err)
+	}
+	got := map[string]KeyIssue{}
+	for _, issue := range issues {
+		got[issue.Key] = issue
+	}
+	want := map[string]struct {
+		line   int
+		legacy bool
+	}{
+		"workflow.maxretries":    {3, true},
+		"git.worktree_dir":       {6, true},
+		"git.auto_commit":        {8, true},
+		"git.autocommit":         {7, false},
+		"issues.prompt.language": {11, true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("CheckKeys() = %+v, want %d issues", issues, len(want))
+	}
+	for key, w := range want {
+		issue, ok := got[key]
+		if !ok {
+			t.Errorf("missing issue for %s", key)
+			continue
+		}
+		if issue.Line != w.line || issue.Legacy != w.legacy {
+			t.Errorf("%s: line=%d legacy=%v, want line=%d legacy=%v", key, issue.Line, issue.Legacy, w.line, w.legacy)
+		}
+	}
+}
+
+func TestMigrateLegacyKeys_MatchesLoader(t *testing.T) {
+	t.Parallel()
+	out, changes, err := MigrateLegacyKeys([]byte(legacyConfigYAML))
+	if err != nil {
+		t.Fatalf("MigrateLegacyKeys() error = %v", err)
+	}
+	if len(changes) != 4 {
+		t.Errorf("changes = %+v, want 4", changes)
+	}
+	if !strings.Contains(string(out), "# project config") {
+		t.Errorf("comments not kept:\n%s", out)
+	}
+
+	issues, err := CheckKeys(out)
+	if err != nil {
+		t.Fatalf("CheckKeys() error = %v", err)
+	}
+	for _, issue := range issues {
+		if issue.Legacy {
+			t.Errorf("legacy key left after migration: %+v", issue)
+		}
+	}
+
+	dir := t.TempDir()
+	before := loadTestConfig(t, dir, "before.yaml", legacyConfigYAML)
+	after := loadTestConfig(t, dir, "after.yaml", string(out))
+	if !reflect.DeepEqual(before, after) {
+		t.Errorf("migrated config loads differently:\nbefore %+v\nafter  %+v", before, after)
+	}
+	if after.Workflow.MaxRetries != 4 || after.Git.Worktree.Dir != ".wt" || after.Git.Task.AutoCommit ||
+		after.Issues.Prompt.Language != "spanish" {
+		t.Errorf("unexpected migrated values: %+v %+v %+v", after.Workflow, after.Git, after.Issues.Prompt)
+	}
+
+	again, changes, err := MigrateLegacyKeys(out)
+	if err != nil || len(changes) != 0 || string(again) != string(out) {
+		t.Errorf("second migration changed the file: %v %+v", err, changes)
+	}
+}
+
+func TestKeyLineAndSetKey(t *testing.T) {
+	t.Parallel()
+	data := []byte(legacyConfigYAML)
+	if got := KeyLine(data, "workflow.max_retries"); got != 4 {
+		t.Errorf("KeyLine(workflow.max_retries) = %d, want 4", got)
+	}
+	if got := KeyLine(data, "log.level"); got != 0 {
+		t.Errorf("KeyLine(log.level) = %d, want 0", got)
+	}
+
+	out, err := SetKey(data, "log.level", "debug")
+	if err != nil {
+		t.Fatalf("SetKey() error = %v", err)
+	}
+	out, err = SetKey(out, "workflow.max_retries", 2)
+	if err != nil {
+		t.Fatalf("SetKey() error = %v", err)
+	}
+	if !strings.Contains(string(out), "# retries per task") {
+		t.Errorf("comments not kept:\n%s", out)
+	}
+	cfg := loadTestConfig(t, t.TempDir(), "config.yaml", string(out))
+	if cfg.Log.Level != "debug" || cfg.Workflow.MaxRetries != 2 {
+		t.Errorf("log.level=%q workflow.max_retries=%d", cfg.Log.Level, cfg.Workflow.MaxRetries)
+	}
+
+	if _, err := SetKey(out, "log.level.x", "debug"); err == nil {
+		t.Error("SetKey() under a scalar should fail")
+	}
+}
+
+func loadTestConfig(t *testing.T, dir, name, content string) *Config {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := NewLoader().WithConfigFile(path).WithResolvePaths(false).Load()
+	if err != nil {
+		t.Fatalf("loading %s: %v", name, err)
+	}
+	return cfg
+}
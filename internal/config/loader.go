@@ -319,6 +319,9 @@ func (l *Loader) setDefaults() {
 
 	// Execute phase
 	l.v.SetDefault("phases.execute.timeout", "2h")
+	l.v.SetDefault("phases.execute.input.timeout", "30m")
+	l.v.SetDefault("phases.execute.input.on_timeout", InputTimeoutDefault)
+	l.v.SetDefault("phases.execute.skip_dependents", SkipDependentsRun)
 
 	// Agent defaults
 	// NOTE: agents.default has NO default - user must explicitly configure it
@@ -349,6 +352,11 @@ func (l *Loader) setDefaults() {
 	l.v.SetDefault("agents.opencode.model", "")
 	l.v.SetDefault("agents.opencode.max_tokens", 16384)
 	l.v.SetDefault("agents.opencode.temperature", 0.7)
+	for _, agent := range []string{"claude", "gemini", "codex", "copilot", "opencode"} {
+		l.v.SetDefault("agents."+agent+".sandbox.enabled", false)
+		l.v.SetDefault("agents."+agent+".sandbox.network", "host")
+		l.v.SetDefault("agents."+agent+".sandbox.deny_paths", []string{"~/.ssh", "~/.gnupg", "~/.aws", "~/.kube", "~/.docker"})
+	}
 
 	// State defaults
 	l.v.SetDefault("state.path", ".quorum/state/state.db")
@@ -360,14 +368,19 @@ func (l *Loader) setDefaults() {
 	l.v.SetDefault("git.worktree.auto_clean", false) // Must be false when task.auto_commit is false to preserve changes
 	l.v.SetDefault("git.worktree.mode", "always")
 	l.v.SetDefault("git.task.auto_commit", true) // Commit changes after task completion
+	l.v.SetDefault("git.task.checkpoint_interval", "5m")
 
 	// GitHub defaults
+	l.v.SetDefault("git.finalization.babysit.max_attempts", 3)
+	l.v.SetDefault("git.finalization.babysit.checks_timeout", "30m")
+	l.v.SetDefault("git.finalization.babysit.poll_interval", "30s")
 	l.v.SetDefault("github.remote", "origin")
 
 	// Chat defaults (TUI interactive chat)
 	l.v.SetDefault("chat.timeout", "20m")
 	l.v.SetDefault("chat.progress_interval", "15s")
 	l.v.SetDefault("chat.editor", "vim")
+	l.v.SetDefault("chat.context.recent_turns", 6)
 
 	// Report defaults (markdown report generation)
 	l.v.SetDefault("report.enabled", true)
@@ -390,6 +403,47 @@ func (l *Loader) setDefaults() {
 	l.v.SetDefault("diagnostics.preflight_checks.min_free_fd_percent", 20)
 	l.v.SetDefault("diagnostics.preflight_checks.min_free_memory_mb", 256)
 
+	// Codebase index defaults (grounds analyze and plan prompts)
+	l.v.SetDefault("index.enabled", false)
+	l.v.SetDefault("index.path", ".quorum/index/index.db")
+	l.v.SetDefault("index.max_results", 20)
+	l.v.SetDefault("index.max_bytes", 6000)
+	l.v.SetDefault("index.max_file_size", 524288)
+
+	// Backup defaults
+	l.v.SetDefault("backup.enabled", false)
+	l.v.SetDefault("backup.dir", "")
+	l.v.SetDefault("backup.interval", "24h")
+	l.v.SetDefault("backup.keep_daily", 7)
+	l.v.SetDefault("backup.keep_weekly", 4)
+	l.v.SetDefault("backup.full_every", 7)
+	l.v.SetDefault("backup.include_worktrees", false)
+	l.v.SetDefault("backup.passphrase_env", "")
+	l.v.SetDefault("backup.recipients", []string{})
+	l.v.SetDefault("backup.identity_file", "")
+
+	// Remote worker defaults
+	l.v.SetDefault("workers.enabled", false)
+	l.v.SetDefault("workers.token_env", "")
+	l.v.SetDefault("workers.timeout", "1m")
+
+	// Run queue defaults
+	l.v.SetDefault("queue.enabled", false)
+	l.v.SetDefault("queue.path", "")
+	l.v.SetDefault("queue.slots", 2)
+	l.v.SetDefault("queue.project_limit", 1)
+	l.v.SetDefault("queue.lease_ttl", "1m")
+
+	// Agent health defaults
+	l.v.SetDefault("agent_health.enabled", false)
+	l.v.SetDefault("agent_health.window", "1h")
+	l.v.SetDefault("agent_health.cooldown", "15m")
+	l.v.SetDefault("agent_health.min_calls", 5)
+	l.v.SetDefault("agent_health.min_success_rate", 0.5)
+	l.v.SetDefault("agent_health.rate_limit_errors", 3)
+	l.v.SetDefault("agent_health.auth_errors", 1)
+	l.v.SetDefault("agent_health.fallbacks", map[string]string{})
+
 	// Issue generation defaults
 	l.v.SetDefault("issues.enabled", true)
 	l.v.SetDefault("issues.provider", "github")
@@ -419,6 +473,9 @@ func (l *Loader) setDefaults() {
 	l.v.SetDefault("issues.generator.resilience.backoff_multiplier", 2.0)
 	l.v.SetDefault("issues.generator.resilience.failure_threshold", 3)
 	l.v.SetDefault("issues.generator.resilience.reset_timeout", "30s")
+	l.v.SetDefault("issues.import.enabled", false)
+	l.v.SetDefault("issues.import.labels", []string{"quorum"})
+	l.v.SetDefault("issues.import.interval", "5m")
 }
 
 // ConfigFile returns the config file path if one was used.
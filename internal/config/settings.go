@@ -0,0 +1,183 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Origins of an effective configuration value, from lowest to highest
+// precedence.
+const (
+	OriginDefault = "default"
+	OriginGlobal  = "global"
+	OriginProject = "project"
+	OriginEnv     = "env"
+	OriginFlag    = "flag"
+)
+
+// Setting is an effective configuration value and the layer it came from.
+type Setting struct {
+	Key    string      `json:"key"`
+	Value  interface{} `json:"value"`
+	Origin string      `json:"origin"`
+	// Source is the file, environment variable or flag that set the value.
+	Source string `json:"source,omitempty"`
+}
+
+// Flatten returns the configuration as dotted keys (e.g. "log.level") mapped
+// to their values. Lists are leaves; empty maps are left out.
+func Flatten(cfg *Config) (map[string]interface{}, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling config: %w", err)
+	}
+	var tree map[string]interface{}
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return nil, fmt.Errorf("unmarshaling config: %w", err)
+	}
+	out := make(map[string]interface{})
+	flattenInto(out, "", tree)
+	return out, nil
+}
+
+func flattenInto(out map[string]interface{}, prefix string, tree map[string]interface{}) {
+	for key, value := range tree {
+		path := joinKey(prefix, key)
+		if m, ok := value.(map[string]interface{}); ok {
+			flattenInto(out, path, m)
+			continue
+		}
+		out[path] = value
+	}
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// Settings returns the settings of cfg sorted by key, each with the layer it
+// came from. cfg must be the result of the loader's last Load. flags maps the
+// keys bound to command-line flags that were set to the flags' names.
+func (l *Loader) Settings(cfg *Config, flags map[string]string) ([]Setting, error) {
+	values, err := Flatten(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var fileKeys map[string]interface{}
+	configPath := l.ConfigFile()
+	if configPath != "" {
+		raw, err := loadNormalizedConfigMap(configPath)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("reading %s: %w", configPath, err)
+		}
+		fileKeys = make(map[string]interface{})
+		flattenInto(fileKeys, "", raw)
+	}
+	fileOrigin := configFileOrigin(configPath)
+
+	settings := make([]Setting, 0, len(values))
+	for key, value := range values {
+		s := Setting{Key: key, Value: value, Origin: OriginDefault}
+		envName := l.envName(key)
+		if flag, ok := flags[key]; ok {
+			s.Origin, s.Source = OriginFlag, "--"+flag
+		} else if _, ok := os.LookupEnv(envName); ok {
+			s.Origin, s.Source = OriginEnv, envName
+		} else if setInFile(fileKeys, key) {
+			s.Origin, s.Source = fileOrigin, configPath
+		}
+		settings = append(settings, s)
+	}
+	sort.Slice(settings, func(i, j int) bool { return settings[i].Key < settings[j].Key })
+	return settings, nil
+}
+
+// envName returns the environment variable that overrides key.
+func (l *Loader) envName(key string) string {
+	return l.envPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// setInFile reports whether key, or a section containing it, is set in the
+// flattened file keys.
+func setInFile(fileKeys map[string]interface{}, key string) bool {
+	for k := key; k != ""; {
+		if _, ok := fileKeys[k]; ok {
+			return true
+		}
+		i := strings.LastIndex(k, ".")
+		if i < 0 {
+			break
+		}
+		k = k[:i]
+	}
+	return false
+}
+
+// configFileOrigin returns the layer of a config file: global for the
+// registry's global config and the user config in ~/.config/quorum, project
+// otherwise.
+func configFileOrigin(path string) string {
+	abs, err := filepath.Abs(path)
+	if path == "" || err != nil {
+		return OriginProject
+	}
+	if global, err := GlobalConfigPath(); err == nil && abs == filepath.Clean(global) {
+		return OriginGlobal
+	}
+	if home, err := os.UserHomeDir(); err == nil && filepath.Dir(abs) == filepath.Join(home, ".config", "quorum") {
+		return OriginGlobal
+	}
+	return OriginProject
+}
+
+// Defaults returns the configuration used when no file, environment variable
+// or flag sets a value.
+func Defaults() (*Config, error) {
+	l := NewLoader()
+	l.setDefaults()
+	var cfg Config
+	if err := l.v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unmarshaling defaults: %w", err)
+	}
+	return &cfg, nil
+}
+
+// KeyType returns the type of the value at a dotted config key, following
+// struct fields by their canonical names and maps by their entries.
+func KeyType(key string) (reflect.Type, bool) {
+	t := reflect.TypeOf(Config{})
+	for _, part := range strings.Split(key, ".") {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			found := false
+			for i := 0; i < t.NumField(); i++ {
+				field := t.Field(i)
+				if field.IsExported() && canonicalTagName(field) == part {
+					t, found = field.Type, true
+					break
+				}
+			}
+			if !found {
+				return nil, false
+			}
+		case reflect.Map:
+			t = t.Elem()
+		default:
+			return nil, false
+		}
+	}
+	return t, true
+}
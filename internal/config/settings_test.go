@@ -0,0 +1,113 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoaderSettings_Origins(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".quorum", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	content := "log:\n  level: debug\nagents:\n  claude:\n    phase_models:\n      plan: opus\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("QUORUM_TRACE_MODE", "summary")
+
+	loader := NewLoader().WithConfigFile(path)
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	settings, err := loader.Settings(cfg, map[string]string{"log.format": "log-format"})
+	if err != nil {
+		t.Fatalf("Settings() error = %v", err)
+	}
+
+	got := map[string]Setting{}
+	for i, s := range settings {
+		if i > 0 && settings[i-1].Key >= s.Key {
+			t.Errorf("settings not sorted: %q before %q", settings[i-1].Key, s.Key)
+		}
+		got[s.Key] = s
+	}
+	tests := []struct {
+		key    string
+		value  interface{}
+		origin string
+		source string
+	}{
+		{"log.level", "debug", OriginProject, path},
+		{"agents.claude.phase_models.plan", "opus", OriginProject, path},
+		{"trace.mode", "summary", OriginEnv, "QUORUM_TRACE_MODE"},
+		{"log.format", "auto", OriginFlag, "--log-format"},
+		{"workflow.max_retries", 3, OriginDefault, ""},
+	}
+	for _, tt := range tests {
+		s, ok := got[tt.key]
+		if !ok {
+			t.Errorf("missing setting %s", tt.key)
+			continue
+		}
+		if s.Value != tt.value || s.Origin != tt.origin || s.Source != tt.source {
+			t.Errorf("%s = %+v, want value=%v origin=%s source=%s", tt.key, s, tt.value, tt.origin, tt.source)
+		}
+	}
+}
+
+func TestFlatten_KeepsListsAsLeaves(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{}
+	cfg.Issues.Labels = []string{"a", "b"}
+	flat, err := Flatten(cfg)
+	if err != nil {
+		t.Fatalf("Flatten() error = %v", err)
+	}
+	labels, ok := flat["issues.labels"].([]interface{})
+	if !ok || len(labels) != 2 {
+		t.Errorf("issues.labels = %#v, want a two-item list", flat["issues.labels"])
+	}
+	if _, ok := flat["issues"]; ok {
+		t.Error("sections should not be leaves")
+	}
+}
+
+func TestKeyType(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		key  string
+		kind reflect.Kind
+		ok   bool
+	}{
+		{"log.level", reflect.String, true},
+		{"workflow.max_retries", reflect.Int, true},
+		{"trace.max_bytes", reflect.Int64, true},
+		{"issues.labels", reflect.Slice, true},
+		{"agents.claude.phases.plan", reflect.Bool, true},
+		{"agents.claude", reflect.Struct, true},
+		{"log.nope", reflect.Invalid, false},
+		{"log.level.deeper", reflect.Invalid, false},
+	}
+	for _, tt := range tests {
+		typ, ok := KeyType(tt.key)
+		if ok != tt.ok || (ok && typ.Kind() != tt.kind) {
+			t.Errorf("KeyType(%q) = %v, %v; want %v, %v", tt.key, typ, ok, tt.kind, tt.ok)
+		}
+	}
+}
+
+func TestDefaults_IgnoresEnvironment(t *testing.T) {
+	t.Setenv("QUORUM_LOG_LEVEL", "error")
+	cfg, err := Defaults()
+	if err != nil {
+		t.Fatalf("Defaults() error = %v", err)
+	}
+	if cfg.Log.Level != "info" || cfg.Workflow.MaxRetries != 3 {
+		t.Errorf("Defaults() log.level=%q workflow.max_retries=%d", cfg.Log.Level, cfg.Workflow.MaxRetries)
+	}
+}
@@ -63,6 +63,12 @@ func (v *Validator) Validate(cfg *Config) error {
 	v.validateGit(&cfg.Git)
 	v.validateGitHub(&cfg.GitHub)
 	v.validateIssues(&cfg.Issues)
+	v.validateChat(&cfg.Chat)
+	v.validateIndex(&cfg.Index)
+	v.validateBackup(&cfg.Backup)
+	v.validateWorkers(&cfg.Workers)
+	v.validateQueue(&cfg.Queue)
+	v.validateAgentHealth(&cfg.AgentHealth, &cfg.Agents)
 
 	if len(v.errors) > 0 {
 		return v.errors
@@ -216,6 +222,40 @@ func (v *Validator) validateAgent(prefix string, cfg *AgentConfig) {
 	}
 	v.validateReasoningEffortDefault(prefix+".reasoning_effort", agentName, cfg.ReasoningEffort)
 	v.validateReasoningEffortPhases(prefix+".reasoning_effort_phases", agentName, cfg.ReasoningEffortPhases)
+	v.validateAgentSandbox(prefix+".sandbox", &cfg.Sandbox)
+
+	if cfg.RateLimitRPM < 0 {
+		v.addError(prefix+".rate_limit_rpm", cfg.RateLimitRPM, "must be non-negative")
+	}
+	if cfg.RateLimitTPM < 0 {
+		v.addError(prefix+".rate_limit_tpm", cfg.RateLimitTPM, "must be non-negative")
+	}
+}
+
+func (v *Validator) validateAgentSandbox(prefix string, cfg *AgentSandboxConfig) {
+	if !cfg.Enabled {
+		return
+	}
+
+	switch cfg.Network {
+	case "", "host", "none", "allowlist":
+	default:
+		v.addError(prefix+".network", cfg.Network, "must be one of: host, none, allowlist")
+	}
+	for key := range cfg.Phases {
+		if !core.IsValidPhaseModelKey(key) {
+			v.addError(prefix+".phases", key, "unknown phase (valid: refine, analyze, moderate, synthesize, plan, execute)")
+		}
+	}
+	if cfg.MemoryMB < 0 {
+		v.addError(prefix+".memory_mb", cfg.MemoryMB, "must be >= 0")
+	}
+	if cfg.CPUPercent < 0 {
+		v.addError(prefix+".cpu_percent", cfg.CPUPercent, "must be >= 0")
+	}
+	if cfg.MaxPids < 0 {
+		v.addError(prefix+".max_pids", cfg.MaxPids, "must be >= 0")
+	}
 }
 
 func (v *Validator) validatePhaseModels(prefix string, phaseModels map[string]string) {
@@ -292,6 +332,14 @@ func (v *Validator) validateGit(cfg *GitConfig) {
 			"auto_clean cannot be true when task.auto_commit is false (uncommitted changes would be lost)")
 	}
 
+	if interval := strings.TrimSpace(cfg.Task.CheckpointInterval); interval != "" {
+		if d, err := time.ParseDuration(interval); err != nil {
+			v.addError("git.task.checkpoint_interval", cfg.Task.CheckpointInterval, "invalid duration format")
+		} else if d < 0 {
+			v.addError("git.task.checkpoint_interval", cfg.Task.CheckpointInterval, "must be non-negative")
+		}
+	}
+
 	// Finalization validation
 	if cfg.Finalization.MergeStrategy != "" {
 		switch strings.ToLower(cfg.Finalization.MergeStrategy) {
@@ -309,6 +357,17 @@ func (v *Validator) validateGit(cfg *GitConfig) {
 	if cfg.Finalization.AutoMerge && !cfg.Finalization.AutoPR {
 		v.addError("git.finalization.auto_merge", cfg.Finalization.AutoMerge, "auto_merge requires auto_pr to be enabled")
 	}
+
+	if babysit := cfg.Finalization.Babysit; babysit.Enabled {
+		if !cfg.Finalization.AutoPR {
+			v.addError("git.finalization.babysit.enabled", babysit.Enabled, "babysit requires auto_pr to be enabled")
+		}
+		if babysit.MaxAttempts < 0 {
+			v.addError("git.finalization.babysit.max_attempts", babysit.MaxAttempts, "must be non-negative")
+		}
+		v.validatePhaseTimeout("git.finalization.babysit.checks_timeout", babysit.ChecksTimeout)
+		v.validatePhaseTimeout("git.finalization.babysit.poll_interval", babysit.PollInterval)
+	}
 }
 
 func (v *Validator) validateGitHub(cfg *GitHubConfig) {
@@ -318,6 +377,154 @@ func (v *Validator) validateGitHub(cfg *GitHubConfig) {
 	}
 }
 
+func (v *Validator) validateChat(cfg *ChatConfig) {
+	if cfg.Context.RecentTurns < 0 {
+		v.addError("chat.context.recent_turns", cfg.Context.RecentTurns, "must be non-negative")
+	}
+	if cfg.Context.MaxTokens < 0 {
+		v.addError("chat.context.max_tokens", cfg.Context.MaxTokens, "must be non-negative")
+	}
+	if cfg.Context.SummaryAgent != "" && !core.IsValidAgent(cfg.Context.SummaryAgent) {
+		v.addError("chat.context.summary_agent", cfg.Context.SummaryAgent,
+			"must be one of: "+strings.Join(core.Agents, ", "))
+	}
+	for _, agent := range cfg.AskAll.Agents {
+		if !core.IsValidAgent(agent) {
+			v.addError("chat.ask_all.agents", agent, "must be one of: "+strings.Join(core.Agents, ", "))
+		}
+	}
+	if cfg.AskAll.Moderator != "" && !core.IsValidAgent(cfg.AskAll.Moderator) {
+		v.addError("chat.ask_all.moderator", cfg.AskAll.Moderator,
+			"must be one of: "+strings.Join(core.Agents, ", "))
+	}
+}
+
+func (v *Validator) validateIndex(cfg *IndexConfig) {
+	if !cfg.Enabled {
+		return
+	}
+	if strings.TrimSpace(cfg.Path) == "" {
+		v.addError("index.path", cfg.Path, "path required when enabled")
+	}
+	if cfg.MaxResults <= 0 {
+		v.addError("index.max_results", cfg.MaxResults, "must be positive")
+	}
+	if cfg.MaxBytes <= 0 {
+		v.addError("index.max_bytes", cfg.MaxBytes, "must be positive")
+	}
+	if cfg.MaxFileSize <= 0 {
+		v.addError("index.max_file_size", cfg.MaxFileSize, "must be positive")
+	}
+}
+
+func (v *Validator) validateBackup(cfg *BackupConfig) {
+	if !cfg.Enabled {
+		return
+	}
+	if d, err := time.ParseDuration(cfg.Interval); err != nil {
+		v.addError("backup.interval", cfg.Interval, "invalid duration format")
+	} else if d < time.Minute {
+		v.addError("backup.interval", cfg.Interval, "must be at least 1m")
+	}
+	if cfg.KeepDaily < 0 {
+		v.addError("backup.keep_daily", cfg.KeepDaily, "must be non-negative")
+	}
+	if cfg.KeepWeekly < 0 {
+		v.addError("backup.keep_weekly", cfg.KeepWeekly, "must be non-negative")
+	}
+	if cfg.KeepDaily <= 0 && cfg.KeepWeekly <= 0 {
+		v.addError("backup.keep_daily", cfg.KeepDaily, "keep_daily or keep_weekly must be positive")
+	}
+	if cfg.FullEvery < 1 {
+		v.addError("backup.full_every", cfg.FullEvery, "must be at least 1")
+	}
+	if strings.TrimSpace(cfg.PassphraseEnv) != "" && len(cfg.Recipients) > 0 {
+		v.addError("backup.passphrase_env", cfg.PassphraseEnv, "cannot be combined with recipients")
+	}
+	for _, r := range cfg.Recipients {
+		if !strings.HasPrefix(strings.TrimSpace(r), "age1") {
+			v.addError("backup.recipients", r, "must be an age public key (age1...)")
+		}
+	}
+}
+
+func (v *Validator) validateWorkers(cfg *WorkersConfig) {
+	if !cfg.Enabled {
+		return
+	}
+	if d, err := time.ParseDuration(cfg.Timeout); err != nil {
+		v.addError("workers.timeout", cfg.Timeout, "invalid duration format")
+	} else if d < 10*time.Second {
+		v.addError("workers.timeout", cfg.Timeout, "must be at least 10s")
+	}
+}
+
+func (v *Validator) validateQueue(cfg *QueueConfig) {
+	if !cfg.Enabled {
+		return
+	}
+	if cfg.Slots < 1 {
+		v.addError("queue.slots", cfg.Slots, "must be at least 1")
+	}
+	if cfg.ProjectLimit < 0 {
+		v.addError("queue.project_limit", cfg.ProjectLimit, "must be non-negative")
+	}
+	if d, err := time.ParseDuration(cfg.LeaseTTL); err != nil {
+		v.addError("queue.lease_ttl", cfg.LeaseTTL, "invalid duration format")
+	} else if d < 15*time.Second {
+		v.addError("queue.lease_ttl", cfg.LeaseTTL, "must be at least 15s")
+	}
+}
+
+func (v *Validator) validateAgentHealth(cfg *AgentHealthConfig, agents *AgentsConfig) {
+	if !cfg.Enabled {
+		return
+	}
+	if d, err := time.ParseDuration(cfg.Window); err != nil {
+		v.addError("agent_health.window", cfg.Window, "invalid duration format")
+	} else if d < time.Minute {
+		v.addError("agent_health.window", cfg.Window, "must be at least 1m")
+	}
+	if d, err := time.ParseDuration(cfg.Cooldown); err != nil {
+		v.addError("agent_health.cooldown", cfg.Cooldown, "invalid duration format")
+	} else if d <= 0 {
+		v.addError("agent_health.cooldown", cfg.Cooldown, "must be positive")
+	}
+	if cfg.MinCalls < 1 {
+		v.addError("agent_health.min_calls", cfg.MinCalls, "must be at least 1")
+	}
+	if cfg.MinSuccessRate < 0 || cfg.MinSuccessRate > 1 {
+		v.addError("agent_health.min_success_rate", cfg.MinSuccessRate, "must be between 0 and 1")
+	}
+	if cfg.RateLimitErrors < 0 {
+		v.addError("agent_health.rate_limit_errors", cfg.RateLimitErrors, "must be non-negative")
+	}
+	if cfg.AuthErrors < 0 {
+		v.addError("agent_health.auth_errors", cfg.AuthErrors, "must be non-negative")
+	}
+
+	agentEnabled := map[string]bool{
+		core.AgentClaude:   agents.Claude.Enabled,
+		core.AgentGemini:   agents.Gemini.Enabled,
+		core.AgentCodex:    agents.Codex.Enabled,
+		core.AgentCopilot:  agents.Copilot.Enabled,
+		core.AgentOpenCode: agents.OpenCode.Enabled,
+	}
+	for agent, fallback := range cfg.Fallbacks {
+		field := "agent_health.fallbacks." + agent
+		switch {
+		case !core.IsValidAgent(agent):
+			v.addError(field, agent, "unknown agent")
+		case !core.IsValidAgent(fallback):
+			v.addError(field, fallback, "unknown fallback agent")
+		case fallback == agent:
+			v.addError(field, fallback, "must differ from the agent")
+		case !agentEnabled[fallback]:
+			v.addError(field, fallback, "fallback agent must be enabled")
+		}
+	}
+}
+
 func (v *Validator) validateIssues(cfg *IssuesConfig) {
 	if !cfg.Enabled {
 		return
@@ -361,6 +568,16 @@ func (v *Validator) validateIssues(cfg *IssuesConfig) {
 
 	// Validate generator
 	v.validateIssueGenerator(&cfg.Generator)
+
+	if cfg.Import.Enabled {
+		if len(cfg.Import.Labels) == 0 {
+			v.addError("issues.import.labels", cfg.Import.Labels, "at least one label is required when import is enabled")
+		}
+		if cfg.Provider == "gitlab" {
+			v.addError("issues.import.enabled", cfg.Import.Enabled, "issue import is only supported for provider 'github'")
+		}
+	}
+	v.validatePhaseTimeout("issues.import.interval", cfg.Import.Interval)
 }
 
 func (v *Validator) validateIssuePrompt(p *IssuePromptConfig) {
@@ -425,6 +642,13 @@ func (v *Validator) validatePhases(cfg *PhasesConfig, agents *AgentsConfig) {
 
 	// Validate execute phase
 	v.validatePhaseTimeout("phases.execute.timeout", cfg.Execute.Timeout)
+	v.validateExecuteInput(&cfg.Execute.Input)
+	switch cfg.Execute.SkipDependents {
+	case "", SkipDependentsRun, SkipDependentsSkip:
+	default:
+		v.addError("phases.execute.skip_dependents", cfg.Execute.SkipDependents,
+			"must be one of: run, skip")
+	}
 
 	// Fail-fast: validate phase participation consistency
 	v.validatePhaseParticipation(cfg, agents)
@@ -534,6 +758,22 @@ func (v *Validator) validatePhaseTimeout(field, value string) {
 	}
 }
 
+func (v *Validator) validateExecuteInput(cfg *ExecuteInputConfig) {
+	if value := strings.TrimSpace(cfg.Timeout); value != "" {
+		if d, err := time.ParseDuration(value); err != nil {
+			v.addError("phases.execute.input.timeout", cfg.Timeout, "invalid duration format")
+		} else if d < 0 {
+			v.addError("phases.execute.input.timeout", cfg.Timeout, "must be non-negative")
+		}
+	}
+	switch cfg.OnTimeout {
+	case "", InputTimeoutDefault, InputTimeoutSkip, InputTimeoutFail:
+	default:
+		v.addError("phases.execute.input.on_timeout", cfg.OnTimeout,
+			"must be one of: default, skip, fail")
+	}
+}
+
 func (v *Validator) validateRefiner(cfg *RefinerConfig, agents *AgentsConfig) {
 	// Validate template regardless of enabled state (config can be pre-set)
 	validTemplates := map[string]bool{"refine-prompt": true, "refine-prompt-v2": true}
@@ -838,3 +838,234 @@ func TestSingleAgentConfig_IsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestValidator_AgentSandbox(t *testing.T) {
+	t.Parallel()
+	cfg := validConfig()
+	cfg.Agents.Claude.Sandbox = AgentSandboxConfig{
+		Enabled:  true,
+		Network:  "vpn",
+		Phases:   map[string]bool{"deploy": true},
+		MemoryMB: -1,
+	}
+
+	err := NewValidator().Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() error = nil, want sandbox errors")
+	}
+	for _, field := range []string{"agents.claude.sandbox.network", "agents.claude.sandbox.phases", "agents.claude.sandbox.memory_mb"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("error = %v, should mention %s", err, field)
+		}
+	}
+}
+
+func TestValidator_Chat(t *testing.T) {
+	t.Parallel()
+	cfg := validConfig()
+	cfg.Chat.Context = ChatContextConfig{
+		RecentTurns:  -1,
+		MaxTokens:    -100,
+		SummaryAgent: "gpt",
+	}
+	cfg.Chat.AskAll = ChatAskAllConfig{
+		Agents:    []string{"claude", "llama"},
+		Moderator: "gpt",
+	}
+
+	err := NewValidator().Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() error = nil, want chat errors")
+	}
+	for _, field := range []string{
+		"chat.context.recent_turns", "chat.context.max_tokens", "chat.context.summary_agent",
+		"chat.ask_all.agents", "chat.ask_all.moderator",
+	} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("error = %v, should mention %s", err, field)
+		}
+	}
+}
+
+func TestValidator_Index(t *testing.T) {
+	t.Parallel()
+	cfg := validConfig()
+	cfg.Index = IndexConfig{Enabled: true, MaxResults: 0, MaxBytes: -1, MaxFileSize: 1024}
+
+	err := NewValidator().Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() error = nil, want index errors")
+	}
+	for _, field := range []string{"index.path", "index.max_results", "index.max_bytes"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("error = %v, should mention %s", err, field)
+		}
+	}
+
+	cfg.Index.Enabled = false
+	if err := NewValidator().Validate(cfg); err != nil {
+		t.Errorf("Validate() with the index disabled error = %v", err)
+	}
+}
+
+func TestValidator_Backup(t *testing.T) {
+	t.Parallel()
+	cfg := validConfig()
+	cfg.Backup = BackupConfig{
+		Enabled:       true,
+		Interval:      "30s",
+		FullEvery:     0,
+		PassphraseEnv: "QUORUM_BACKUP_PASSPHRASE",
+		Recipients:    []string{"ssh-ed25519 AAAA"},
+	}
+
+	err := NewValidator().Validate(cfg)
+	if err == nil {
+		t.Fatal("Validate() error = nil, want backup errors")
+	}
+	for _, field := range []string{"backup.interval", "backup.keep_daily", "backup.full_every", "backup.passphrase_env", "backup.recipients"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("error = %v, should mention %s", err, field)
+		}
+	}
+
+	cfg.Backup = BackupConfig{Enabled: true, Interval: "24h", KeepDaily: 7, KeepWeekly: 4, FullEvery: 7}
+	if err := NewValidator().Validate(cfg); err != nil {
+		t.Errorf("Validate() with a valid backup config error = %v", err)
+	}
+}
+
+func TestValidator_Workers(t *testing.T) {
+	t.Parallel()
+	cfg := validConfig()
+	cfg.Workers = WorkersConfig{Enabled: true, Timeout: "5s"}
+	err := NewValidator().Validate(cfg)
+	if err == nil || !strings.Contains(err.Error(), "workers.timeout") {
+		t.Errorf("Validate() error = %v, should mention workers.timeout", err)
+	}
+
+	cfg.Workers.Timeout = "1m"
+	if err := NewValidator().Validate(cfg); err != nil {
+		t.Errorf("Validate() with a valid workers config error = %v", err)
+	}
+}
+
+func TestValidator_Queue(t *testing.T) {
+	t.Parallel()
+	cfg := validConfig()
+	cfg.Queue = QueueConfig{Enabled: true, Slots: 0, ProjectLimit: -1, LeaseTTL: "5s"}
+	err := NewValidator().Validate(cfg)
+	for _, field := range []string{"queue.slots", "queue.project_limit", "queue.lease_ttl"} {
+		if err == nil || !strings.Contains(err.Error(), field) {
+			t.Errorf("Validate() error = %v, should mention %s", err, field)
+		}
+	}
+
+	cfg.Queue = QueueConfig{Enabled: true, Slots: 2, ProjectLimit: 1, LeaseTTL: "1m"}
+	if err := NewValidator().Validate(cfg); err != nil {
+		t.Errorf("Validate() with a valid queue config error = %v", err)
+	}
+}
+
+func TestValidator_AgentHealth(t *testing.T) {
+	t.Parallel()
+	cfg := validConfig()
+	cfg.AgentHealth = AgentHealthConfig{
+		Enabled: true, Window: "10s", Cooldown: "soon", MinCalls: 0, MinSuccessRate: 1.5,
+		Fallbacks: map[string]string{"claude": "claude", "gpt": "gemini", "gemini": "codex"},
+	}
+	err := NewValidator().Validate(cfg)
+	for _, field := range []string{
+		"agent_health.window", "agent_health.cooldown", "agent_health.min_calls",
+		"agent_health.min_success_rate", "agent_health.fallbacks.claude", "agent_health.fallbacks.gpt",
+		"agent_health.fallbacks.gemini",
+	} {
+		if err == nil || !strings.Contains(err.Error(), field) {
+			t.Errorf("Validate() error = %v, should mention %s", err, field)
+		}
+	}
+
+	cfg.Agents.Gemini = AgentConfig{Enabled: true, Path: "gemini", Phases: map[string]bool{"execute": true}}
+	cfg.AgentHealth = AgentHealthConfig{
+		Enabled: true, Window: "1h", Cooldown: "15m", MinCalls: 5, MinSuccessRate: 0.5,
+		RateLimitErrors: 3, AuthErrors: 1, Fallbacks: map[string]string{"claude": "gemini"},
+	}
+	if err := NewValidator().Validate(cfg); err != nil {
+		t.Errorf("Validate() with a valid agent health config error = %v", err)
+	}
+}
+
+func TestValidator_AgentRateLimits(t *testing.T) {
+	t.Parallel()
+	cfg := validConfig()
+	cfg.Agents.Claude.RateLimitRPM = -1
+	cfg.Agents.Claude.RateLimitTPM = -5
+	err := NewValidator().Validate(cfg)
+	for _, field := range []string{"agents.claude.rate_limit_rpm", "agents.claude.rate_limit_tpm"} {
+		if err == nil || !strings.Contains(err.Error(), field) {
+			t.Errorf("Validate() error = %v, should mention %s", err, field)
+		}
+	}
+}
+
+func TestValidator_TaskCheckpointInterval(t *testing.T) {
+	t.Parallel()
+	for _, interval := range []string{"", "0", "90s", "5m"} {
+		cfg := validConfig()
+		cfg.Git.Task.CheckpointInterval = interval
+		if err := NewValidator().Validate(cfg); err != nil {
+			t.Errorf("Validate() with checkpoint_interval %q error = %v", interval, err)
+		}
+	}
+	for _, interval := range []string{"soon", "-1m"} {
+		cfg := validConfig()
+		cfg.Git.Task.CheckpointInterval = interval
+		err := NewValidator().Validate(cfg)
+		if err == nil || !strings.Contains(err.Error(), "git.task.checkpoint_interval") {
+			t.Errorf("Validate() with checkpoint_interval %q error = %v, want checkpoint_interval error", interval, err)
+		}
+	}
+}
+
+func TestValidator_SkipDependents(t *testing.T) {
+	t.Parallel()
+	for _, policy := range []string{"", SkipDependentsRun, SkipDependentsSkip} {
+		cfg := validConfig()
+		cfg.Phases.Execute.SkipDependents = policy
+		if err := NewValidator().Validate(cfg); err != nil {
+			t.Errorf("Validate() with skip_dependents %q error = %v", policy, err)
+		}
+	}
+	cfg := validConfig()
+	cfg.Phases.Execute.SkipDependents = "fail"
+	if err := NewValidator().Validate(cfg); err == nil || !strings.Contains(err.Error(), "phases.execute.skip_dependents") {
+		t.Errorf("Validate() with skip_dependents fail error = %v, want skip_dependents error", err)
+	}
+}
+
+func TestValidator_ExecuteInput(t *testing.T) {
+	t.Parallel()
+	for _, input := range []ExecuteInputConfig{
+		{},
+		{Timeout: "0", OnTimeout: InputTimeoutSkip},
+		{Timeout: "30m", OnTimeout: InputTimeoutDefault},
+		{Timeout: "1h", OnTimeout: InputTimeoutFail},
+	} {
+		cfg := validConfig()
+		cfg.Phases.Execute.Input = input
+		if err := NewValidator().Validate(cfg); err != nil {
+			t.Errorf("Validate() with input %+v error = %v", input, err)
+		}
+	}
+	for field, input := range map[string]ExecuteInputConfig{
+		"phases.execute.input.timeout":    {Timeout: "-5m"},
+		"phases.execute.input.on_timeout": {OnTimeout: "retry"},
+	} {
+		cfg := validConfig()
+		cfg.Phases.Execute.Input = input
+		err := NewValidator().Validate(cfg)
+		if err == nil || !strings.Contains(err.Error(), field) {
+			t.Errorf("Validate() with input %+v error = %v, want %s error", input, err, field)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultWatchDebounce groups the burst of events an editor or git produces
+// for a single save into one notification.
+const DefaultWatchDebounce = 250 * time.Millisecond
+
+// Watcher calls a function when a config file changes on disk. It watches the
+// file's directory rather than the file, so editors and git, which replace the
+// file instead of writing it in place, are picked up as well.
+type Watcher struct {
+	path     string
+	watcher  *fsnotify.Watcher
+	onChange func()
+	debounce time.Duration
+	done     chan struct{}
+	wg       sync.WaitGroup
+	once     sync.Once
+}
+
+// NewWatcher starts watching the config file at path. onChange is called from
+// the watcher goroutine after changes settle; it is not called for the
+// initial content.
+func NewWatcher(path string, onChange func()) (*Watcher, error) {
+	return newWatcher(path, DefaultWatchDebounce, onChange)
+}
+
+func newWatcher(path string, debounce time.Duration, onChange func()) (*Watcher, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolving config path: %w", err)
+	}
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("creating config watcher: %w", err)
+	}
+	if err := fw.Add(filepath.Dir(absPath)); err != nil {
+		_ = fw.Close()
+		return nil, fmt.Errorf("watching %s: %w", filepath.Dir(absPath), err)
+	}
+
+	w := &Watcher{
+		path:     absPath,
+		watcher:  fw,
+		onChange: onChange,
+		debounce: debounce,
+		done:     make(chan struct{}),
+	}
+	w.wg.Add(1)
+	go w.loop()
+	return w, nil
+}
+
+// Path returns the absolute path of the watched config file.
+func (w *Watcher) Path() string {
+	return w.path
+}
+
+// Close stops the watcher and waits for a pending notification to finish.
+func (w *Watcher) Close() error {
+	var err error
+	w.once.Do(func() {
+		close(w.done)
+		err = w.watcher.Close()
+		w.wg.Wait()
+	})
+	return err
+}
+
+func (w *Watcher) loop() {
+	defer w.wg.Done()
+
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != w.path || event.Op == fsnotify.Chmod {
+				continue
+			}
+			timer.Reset(w.debounce)
+		case _, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+		case <-timer.C:
+			w.onChange()
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func waitForCalls(t *testing.T, calls *atomic.Int32, want int32) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for calls.Load() < want {
+		if time.Now().After(deadline) {
+			t.Fatalf("onChange called %d times, want %d", calls.Load(), want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWatcher_NotifiesOnWriteAndReplace(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("log:\n  level: info\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var calls atomic.Int32
+	w, err := newWatcher(path, 20*time.Millisecond, func() { calls.Add(1) })
+	if err != nil {
+		t.Fatalf("newWatcher() error = %v", err)
+	}
+	defer w.Close()
+
+	// Several writes in a burst are reported once.
+	for _, level := range []string{"debug", "warn", "error"} {
+		if err := os.WriteFile(path, []byte("log:\n  level: "+level+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitForCalls(t, &calls, 1)
+	time.Sleep(100 * time.Millisecond)
+	if got := calls.Load(); got != 1 {
+		t.Errorf("burst of writes notified %d times, want 1", got)
+	}
+
+	// Editors and git replace the file.
+	tmp := filepath.Join(dir, "config.yaml.tmp")
+	if err := os.WriteFile(tmp, []byte("log:\n  level: info\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+	waitForCalls(t, &calls, 2)
+
+	// Other files in the directory are ignored.
+	if err := os.WriteFile(filepath.Join(dir, "state.db"), []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if got := calls.Load(); got != 2 {
+		t.Errorf("unrelated file notified: %d calls", got)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+}
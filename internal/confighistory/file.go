@@ -0,0 +1,120 @@
+package confighistory
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+)
+
+// ReadFile returns the content of the config file at path, or nil when it
+// does not exist. Callers read it before a write to pass it to RecordWrite.
+func ReadFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- config path chosen by quorum
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// RecordWrite records the content now in the config file at path as a new
+// version. previous is the content before the write, as returned by ReadFile.
+func RecordWrite(ctx context.Context, path string, previous []byte, change Change) (*Version, error) {
+	content, err := os.ReadFile(path) // #nosec G304 -- config path chosen by quorum
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+	store, err := OpenFor(path)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+	return store.Record(ctx, previous, content, change)
+}
+
+// CurrentVersion returns the version of the config file at path, recording
+// its content when it has not been recorded yet.
+func CurrentVersion(ctx context.Context, path string) (*Version, error) {
+	content, err := os.ReadFile(path) // #nosec G304 -- config path chosen by quorum
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+	store, err := OpenFor(path)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+	return store.Current(ctx, content)
+}
+
+// Rollback restores a version of the config file at path and records it as a
+// new version. The restored content is validated before it replaces the file.
+func Rollback(ctx context.Context, path string, version int, change Change) (*Version, error) {
+	store, err := OpenFor(path)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	target, err := store.Get(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+	if err := replaceValidated(path, []byte(target.Content)); err != nil {
+		return nil, err
+	}
+
+	change.RollbackOf = version
+	if change.Message == "" {
+		change.Message = fmt.Sprintf("Rollback to version %d", version)
+	}
+	return store.Record(ctx, previous, []byte(target.Content), change)
+}
+
+// Write validates content as a quorum config, replaces the config file at
+// path with it and records the write as a new version.
+func Write(ctx context.Context, path string, content []byte, change Change) (*Version, error) {
+	previous, err := ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+	if err := replaceValidated(path, content); err != nil {
+		return nil, err
+	}
+	return RecordWrite(ctx, path, previous, change)
+}
+
+// replaceValidated writes content to a temp file next to path, validates it as
+// a quorum config and renames it over path.
+func replaceValidated(path string, content []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, ".config-*.yaml")
+	if err != nil {
+		return fmt.Errorf("creating temp config: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing temp config: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing temp config: %w", err)
+	}
+
+	cfg, err := config.NewLoader().WithConfigFile(tmpPath).WithResolvePaths(false).Load()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if err := config.ValidateConfig(cfg); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,14 @@
+-- Config history: one row per configuration version
+CREATE TABLE IF NOT EXISTS config_versions (
+    version INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at TEXT NOT NULL,
+    source TEXT NOT NULL,
+    actor TEXT,
+    message TEXT,
+    checksum TEXT NOT NULL,
+    content TEXT NOT NULL,
+    diff TEXT,
+    rollback_of INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS idx_config_versions_checksum ON config_versions(checksum);
@@ -0,0 +1,332 @@
+// Package confighistory keeps every version of a quorum configuration file,
+// with when, how and by whom it was written, so a change can be audited and
+// rolled back. Each config file has its own history database next to it:
+// .quorum/config-history.db for a project and
+// ~/.quorum-registry/config-history.db for the global config.
+package confighistory
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+	_ "modernc.org/sqlite"
+)
+
+//go:embed migrations/001_initial_schema.sql
+var migrationV1 string
+
+// dbFilename is the history database created next to each config file.
+const dbFilename = "config-history.db"
+
+// Sources of a config version.
+const (
+	SourceCLI = "cli"
+	SourceAPI = "api"
+	SourceTUI = "tui"
+	// SourceExternal marks content found on disk that quorum did not write,
+	// such as a manual edit or the config that existed before history was kept.
+	SourceExternal = "external"
+)
+
+var (
+	// ErrVersionNotFound is returned when a version does not exist.
+	ErrVersionNotFound = errors.New("config version not found")
+	// ErrInvalidConfig is returned when a version to restore is not a valid config.
+	ErrInvalidConfig = errors.New("invalid config")
+)
+
+// Version is a stored version of a config file.
+type Version struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Source    string    `json:"source"`
+	Actor     string    `json:"actor,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	Checksum  string    `json:"checksum"`
+	// Diff is the unified diff from the previous version.
+	Diff string `json:"diff,omitempty"`
+	// RollbackOf is the version this one restored, if it is a rollback.
+	RollbackOf int `json:"rollback_of,omitempty"`
+	// Content is only loaded by Get.
+	Content string `json:"content,omitempty"`
+}
+
+// Change describes a config write being recorded.
+type Change struct {
+	Source string
+	// Actor defaults to the current OS user.
+	Actor      string
+	Message    string
+	RollbackOf int
+}
+
+// Store is the SQLite history of one config file.
+type Store struct {
+	db *sql.DB
+}
+
+// PathFor returns the history database path for the config file at configPath.
+func PathFor(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), dbFilename)
+}
+
+// OpenFor opens the history of the config file at configPath.
+func OpenFor(configPath string) (*Store, error) {
+	return Open(PathFor(configPath))
+}
+
+// Open opens, creating if needed, the history database at dbPath.
+func Open(dbPath string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0o750); err != nil {
+		return nil, fmt.Errorf("creating config history directory: %w", err)
+	}
+	// Immediate transactions serialize concurrent writers (CLI and server).
+	db, err := sql.Open("sqlite", dbPath+"?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)&_txlock=immediate")
+	if err != nil {
+		return nil, fmt.Errorf("opening config history: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	s := &Store{db: db}
+	if err := s.migrate(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("running config history migrations: %w", err)
+	}
+	return s, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) migrate() error {
+	if _, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		applied_at TEXT NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("creating migrations table: %w", err)
+	}
+	var current int
+	if err := s.db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&current); err != nil {
+		return fmt.Errorf("checking schema version: %w", err)
+	}
+
+	migrations := []string{migrationV1}
+	for i, migration := range migrations {
+		version := i + 1
+		if version <= current {
+			continue
+		}
+		tx, err := s.db.Begin()
+		if err != nil {
+			return fmt.Errorf("beginning migration transaction: %w", err)
+		}
+		for _, stmt := range strings.Split(migration, ";") {
+			if strings.TrimSpace(stmt) == "" {
+				continue
+			}
+			if _, err := tx.Exec(stmt); err != nil {
+				_ = tx.Rollback()
+				return fmt.Errorf("executing migration v%d: %w", version, err)
+			}
+		}
+		if _, err := tx.Exec("INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)",
+			version, time.Now().UTC().Format(time.RFC3339)); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("recording migration v%d: %w", version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("committing migration v%d: %w", version, err)
+		}
+	}
+	return nil
+}
+
+// Record stores content as a new version. previous is the file content before
+// the write, nil when the file did not exist; when it differs from the latest
+// version, it is first recorded as an external change so the history shows
+// edits made outside quorum. Content identical to the latest version is not
+// stored again and the latest version is returned.
+func (s *Store) Record(ctx context.Context, previous, content []byte, change Change) (*Version, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning config history transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	latest, err := latestVersion(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil && (latest == nil || latest.Checksum != checksum(previous)) {
+		external := Change{Source: SourceExternal, Message: "Changed outside quorum"}
+		if latest == nil {
+			external.Message = "Configuration before history was recorded"
+		}
+		if latest, err = insertVersion(ctx, tx, latest, previous, external); err != nil {
+			return nil, err
+		}
+	}
+	if latest != nil && latest.Checksum == checksum(content) {
+		return latest, tx.Commit()
+	}
+
+	v, err := insertVersion(ctx, tx, latest, content, change)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing config version: %w", err)
+	}
+	return v, nil
+}
+
+// Current returns the version matching content, recording it as an external
+// change when it is not the latest version.
+func (s *Store) Current(ctx context.Context, content []byte) (*Version, error) {
+	return s.Record(ctx, content, content, Change{})
+}
+
+// List returns up to limit versions, newest first, without their content.
+// A limit of zero or less returns all versions.
+func (s *Store) List(ctx context.Context, limit int) ([]*Version, error) {
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT version, created_at, source, actor, message, checksum, diff, rollback_of
+		FROM config_versions ORDER BY version DESC LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("listing config versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []*Version
+	for rows.Next() {
+		v, err := scanVersion(rows, false)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
+// Get returns a version with its content.
+func (s *Store) Get(ctx context.Context, version int) (*Version, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT version, created_at, source, actor, message, checksum, diff, rollback_of, content
+		FROM config_versions WHERE version = ?`, version)
+	v, err := scanVersion(row, true)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", ErrVersionNotFound, version)
+	}
+	return v, err
+}
+
+func latestVersion(ctx context.Context, tx *sql.Tx) (*Version, error) {
+	row := tx.QueryRowContext(ctx, `SELECT version, created_at, source, actor, message, checksum, diff, rollback_of, content
+		FROM config_versions ORDER BY version DESC LIMIT 1`)
+	v, err := scanVersion(row, true)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return v, err
+}
+
+func insertVersion(ctx context.Context, tx *sql.Tx, prev *Version, content []byte, change Change) (*Version, error) {
+	v := &Version{
+		Version:    1,
+		CreatedAt:  time.Now().UTC(),
+		Source:     change.Source,
+		Actor:      change.Actor,
+		Message:    change.Message,
+		Checksum:   checksum(content),
+		RollbackOf: change.RollbackOf,
+		Content:    string(content),
+	}
+	if v.Actor == "" {
+		v.Actor = currentActor()
+	}
+	prevContent, prevLabel := "", "(none)"
+	if prev != nil {
+		v.Version = prev.Version + 1
+		prevContent, prevLabel = prev.Content, fmt.Sprintf("version %d", prev.Version)
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(prevContent),
+		B:        difflib.SplitLines(v.Content),
+		FromFile: prevLabel,
+		ToFile:   fmt.Sprintf("version %d", v.Version),
+		Context:  3,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("diffing config versions: %w", err)
+	}
+	v.Diff = diff
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO config_versions
+		(version, created_at, source, actor, message, checksum, content, diff, rollback_of)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		v.Version, v.CreatedAt.Format(time.RFC3339Nano), v.Source, nullString(v.Actor), nullString(v.Message),
+		v.Checksum, v.Content, nullString(v.Diff), sql.NullInt64{Int64: int64(v.RollbackOf), Valid: v.RollbackOf > 0})
+	if err != nil {
+		return nil, fmt.Errorf("recording config version: %w", err)
+	}
+	return v, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanVersion(row rowScanner, withContent bool) (*Version, error) {
+	var (
+		v                    Version
+		createdAt            string
+		actor, message, diff sql.NullString
+		rollbackOf           sql.NullInt64
+	)
+	dest := []any{&v.Version, &createdAt, &v.Source, &actor, &message, &v.Checksum, &diff, &rollbackOf}
+	if withContent {
+		dest = append(dest, &v.Content)
+	}
+	if err := row.Scan(dest...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("reading config version: %w", err)
+	}
+	v.CreatedAt, _ = time.Parse(time.RFC3339Nano, createdAt)
+	v.Actor = actor.String
+	v.Message = message.String
+	v.Diff = diff.String
+	v.RollbackOf = int(rollbackOf.Int64)
+	return &v, nil
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// currentActor returns the OS user recorded as the author of a change.
+func currentActor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
@@ -0,0 +1,148 @@
+package confighistory
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+)
+
+func openStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := Open(filepath.Join(t.TempDir(), dbFilename))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	return store
+}
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := openStore(t)
+
+	v1, err := store.Record(ctx, nil, []byte("log:\n  level: info\n"), Change{Source: SourceCLI, Actor: "alice", Message: "init"})
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if v1.Version != 1 || v1.Source != SourceCLI || v1.Actor != "alice" {
+		t.Errorf("v1 = %+v", v1)
+	}
+
+	v2, err := store.Record(ctx, []byte("log:\n  level: info\n"), []byte("log:\n  level: debug\n"), Change{Source: SourceAPI, Actor: "bob"})
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if v2.Version != 2 || !strings.Contains(v2.Diff, "-  level: info") || !strings.Contains(v2.Diff, "+  level: debug") {
+		t.Errorf("v2 = %+v", v2)
+	}
+
+	same, err := store.Record(ctx, []byte("log:\n  level: debug\n"), []byte("log:\n  level: debug\n"), Change{Source: SourceAPI})
+	if err != nil || same.Version != 2 {
+		t.Errorf("unchanged content should not add a version: %+v, %v", same, err)
+	}
+
+	// The file was edited by hand before the next write.
+	v4, err := store.Record(ctx, []byte("log:\n  level: warn\n"), []byte("log:\n  level: error\n"), Change{Source: SourceAPI})
+	if err != nil || v4.Version != 4 {
+		t.Fatalf("Record() = %+v, %v", v4, err)
+	}
+	versions, err := store.List(ctx, 0)
+	if err != nil || len(versions) != 4 {
+		t.Fatalf("List() = %d versions, %v", len(versions), err)
+	}
+	if versions[1].Version != 3 || versions[1].Source != SourceExternal || versions[0].Content != "" {
+		t.Errorf("versions = %+v, %+v", versions[0], versions[1])
+	}
+
+	got, err := store.Get(ctx, 2)
+	if err != nil || got.Content != "log:\n  level: debug\n" {
+		t.Errorf("Get(2) = %+v, %v", got, err)
+	}
+	if _, err := store.Get(ctx, 9); !errors.Is(err, ErrVersionNotFound) {
+		t.Errorf("Get(9) error = %v", err)
+	}
+}
+
+func TestCurrent_RecordsBaseline(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := openStore(t)
+
+	v, err := store.Current(ctx, []byte("a: 1\n"))
+	if err != nil || v.Version != 1 || v.Source != SourceExternal {
+		t.Fatalf("Current() = %+v, %v", v, err)
+	}
+	again, err := store.Current(ctx, []byte("a: 1\n"))
+	if err != nil || again.Version != 1 {
+		t.Errorf("Current() again = %+v, %v", again, err)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	original := []byte(config.DefaultConfigYAML)
+	if err := os.WriteFile(path, original, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RecordWrite(ctx, path, nil, Change{Source: SourceCLI}); err != nil {
+		t.Fatalf("RecordWrite() error = %v", err)
+	}
+
+	edited := config.DefaultConfigYAML + "\nlog:\n  level: debug\n"
+	if err := os.WriteFile(path, []byte(edited), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RecordWrite(ctx, path, original, Change{Source: SourceAPI, Message: "more logs"}); err != nil {
+		t.Fatalf("RecordWrite() error = %v", err)
+	}
+
+	v, err := Rollback(ctx, path, 1, Change{Source: SourceCLI})
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if v.Version != 3 || v.RollbackOf != 1 || v.Message != "Rollback to version 1" {
+		t.Errorf("rollback version = %+v", v)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != config.DefaultConfigYAML {
+		t.Error("rollback should restore the version's content")
+	}
+
+	current, err := CurrentVersion(ctx, path)
+	if err != nil || current.Version != 3 {
+		t.Errorf("CurrentVersion() = %+v, %v", current, err)
+	}
+}
+
+func TestRollback_RejectsInvalidConfig(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	store, err := OpenFor(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Record(ctx, nil, []byte("log:\n  level: loud\n"), Change{Source: SourceCLI}); err != nil {
+		t.Fatal(err)
+	}
+	_ = store.Close()
+	if err := os.WriteFile(path, []byte(config.DefaultConfigYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Rollback(ctx, path, 1, Change{Source: SourceCLI}); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("Rollback() error = %v, want ErrInvalidConfig", err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != config.DefaultConfigYAML {
+		t.Error("a rejected rollback should leave the config untouched")
+	}
+}
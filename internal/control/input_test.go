@@ -108,3 +108,51 @@ func TestControlPlane_WaitIfPaused_AlreadyCancelled(t *testing.T) {
 		t.Error("expected error when already cancelled")
 	}
 }
+
+func TestControlPlane_PendingInputs(t *testing.T) {
+	cp := New()
+
+	// Fill the TUI channel: requests must still be answerable without it.
+	for i := 0; i < cap(cp.inputRequestCh); i++ {
+		cp.inputRequestCh <- InputRequest{}
+	}
+
+	done := make(chan InputResponse, 1)
+	go func() {
+		resp, _ := cp.RequestUserInput(context.Background(), InputRequest{
+			ID:      "req-1",
+			Prompt:  "Which cache?",
+			TaskID:  "task-1",
+			Default: "redis",
+		})
+		done <- resp
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	var pending []InputRequest
+	for len(pending) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("request never became pending")
+		}
+		time.Sleep(5 * time.Millisecond)
+		pending = cp.PendingInputs()
+	}
+	if pending[0].ID != "req-1" || pending[0].TaskID != "task-1" || pending[0].RequestedAt.IsZero() {
+		t.Errorf("PendingInputs() = %+v", pending)
+	}
+
+	if err := cp.ProvideUserInput("req-1", "memcached"); err != nil {
+		t.Fatalf("ProvideUserInput failed: %v", err)
+	}
+	select {
+	case resp := <-done:
+		if resp.Input != "memcached" {
+			t.Errorf("got input %q", resp.Input)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for response")
+	}
+	if pending := cp.PendingInputs(); len(pending) != 0 {
+		t.Errorf("PendingInputs() after answer = %+v, want none", pending)
+	}
+}
@@ -3,6 +3,7 @@ package control
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -17,6 +18,11 @@ type InputRequest struct {
 	Context string        `json:"context,omitempty"`
 	Options []string      `json:"options,omitempty"`
 	Timeout time.Duration `json:"timeout,omitempty"`
+	// TaskID is the task waiting for the input, if any.
+	TaskID core.TaskID `json:"task_id,omitempty"`
+	// Default is the answer suggested by the requester, if any.
+	Default     string    `json:"default,omitempty"`
+	RequestedAt time.Time `json:"requested_at"`
 }
 
 // InputResponse represents the user's response to an input request.
@@ -27,12 +33,29 @@ type InputResponse struct {
 	Error     error  `json:"-"`
 }
 
+// RetryRequest asks the executor to run a failed task again. Empty fields
+// keep the task's settings.
+type RetryRequest struct {
+	TaskID core.TaskID `json:"task_id"`
+	Agent  string      `json:"agent,omitempty"`
+	Model  string      `json:"model,omitempty"`
+	// Instructions are added to the task prompt of the new attempt.
+	Instructions string `json:"instructions,omitempty"`
+}
+
+// SkipRequest asks the executor to skip a pending or running task.
+type SkipRequest struct {
+	TaskID core.TaskID `json:"task_id"`
+	Reason string      `json:"reason,omitempty"`
+}
+
 // ControlPlane provides workflow control capabilities.
 type ControlPlane struct {
 	mu         sync.RWMutex
 	paused     atomic.Bool
 	cancelled  atomic.Bool
-	retryQueue chan core.TaskID
+	retryQueue chan RetryRequest
+	skipQueue  chan SkipRequest
 	pauseCh    chan struct{}
 	resumeCh   chan struct{}
 	cancelOnce sync.Once
@@ -42,17 +65,20 @@ type ControlPlane struct {
 	inputMu        sync.RWMutex
 	inputRequestCh chan InputRequest
 	pendingInputs  map[string]chan InputResponse
+	pendingReqs    map[string]InputRequest
 }
 
 // New creates a new ControlPlane.
 func New() *ControlPlane {
 	return &ControlPlane{
-		retryQueue:     make(chan core.TaskID, 100),
+		retryQueue:     make(chan RetryRequest, 100),
+		skipQueue:      make(chan SkipRequest, 100),
 		pauseCh:        make(chan struct{}),
 		resumeCh:       make(chan struct{}),
 		cancelCh:       make(chan struct{}),
 		inputRequestCh: make(chan InputRequest, 10),
 		pendingInputs:  make(map[string]chan InputResponse),
+		pendingReqs:    make(map[string]InputRequest),
 	}
 }
 
@@ -91,8 +117,22 @@ func (cp *ControlPlane) Cancel() {
 
 // RetryTask queues a task for retry.
 func (cp *ControlPlane) RetryTask(taskID core.TaskID) {
+	cp.RequestRetry(RetryRequest{TaskID: taskID})
+}
+
+// RequestRetry queues a task for retry with other settings.
+func (cp *ControlPlane) RequestRetry(req RetryRequest) {
 	select {
-	case cp.retryQueue <- taskID:
+	case cp.retryQueue <- req:
+	default:
+		// Queue full, drop (shouldn't happen with reasonable buffer)
+	}
+}
+
+// SkipTask queues a task to be skipped.
+func (cp *ControlPlane) SkipTask(taskID core.TaskID, reason string) {
+	select {
+	case cp.skipQueue <- SkipRequest{TaskID: taskID, Reason: reason}:
 	default:
 		// Queue full, drop (shouldn't happen with reasonable buffer)
 	}
@@ -141,10 +181,15 @@ func (cp *ControlPlane) CheckCancelled() error {
 }
 
 // GetRetryQueue returns the retry queue channel for the executor.
-func (cp *ControlPlane) GetRetryQueue() <-chan core.TaskID {
+func (cp *ControlPlane) GetRetryQueue() <-chan RetryRequest {
 	return cp.retryQueue
 }
 
+// GetSkipQueue returns the skip queue channel for the executor.
+func (cp *ControlPlane) GetSkipQueue() <-chan SkipRequest {
+	return cp.skipQueue
+}
+
 // PausedCh returns a channel that's closed when paused.
 // Useful for select statements.
 func (cp *ControlPlane) PausedCh() <-chan struct{} {
@@ -163,6 +208,7 @@ type Status struct {
 	Paused    bool
 	Cancelled bool
 	Retries   int
+	Skips     int
 }
 
 func (cp *ControlPlane) Status() Status {
@@ -170,33 +216,43 @@ func (cp *ControlPlane) Status() Status {
 		Paused:    cp.paused.Load(),
 		Cancelled: cp.cancelled.Load(),
 		Retries:   len(cp.retryQueue),
+		Skips:     len(cp.skipQueue),
 	}
 }
 
 // RequestUserInput blocks until the user provides input.
 // This follows the same pattern as WaitIfPaused - blocking until signal.
+// The request is offered to the TUI on InputRequestCh and listed by
+// PendingInputs while it waits, so that other clients can answer it.
 func (cp *ControlPlane) RequestUserInput(ctx context.Context, req InputRequest) (InputResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return InputResponse{}, err
+	}
+	if req.RequestedAt.IsZero() {
+		req.RequestedAt = time.Now()
+	}
+
 	// Create response channel for this request
 	responseCh := make(chan InputResponse, 1)
 
 	cp.inputMu.Lock()
 	cp.pendingInputs[req.ID] = responseCh
+	cp.pendingReqs[req.ID] = req
 	cp.inputMu.Unlock()
 
 	// Cleanup on exit
 	defer func() {
 		cp.inputMu.Lock()
 		delete(cp.pendingInputs, req.ID)
+		delete(cp.pendingReqs, req.ID)
 		cp.inputMu.Unlock()
 	}()
 
-	// Send request to TUI (non-blocking with timeout)
+	// Offer the request to the TUI. Without a TUI draining the channel the
+	// request stays reachable through PendingInputs.
 	select {
 	case cp.inputRequestCh <- req:
-	case <-ctx.Done():
-		return InputResponse{}, ctx.Err()
-	case <-time.After(5 * time.Second):
-		return InputResponse{}, fmt.Errorf("timeout sending input request")
+	default:
 	}
 
 	// Wait for response
@@ -258,6 +314,21 @@ func (cp *ControlPlane) InputRequestCh() <-chan InputRequest {
 	return cp.inputRequestCh
 }
 
+// PendingInputs returns the input requests waiting for an answer, oldest
+// first.
+func (cp *ControlPlane) PendingInputs() []InputRequest {
+	cp.inputMu.RLock()
+	reqs := make([]InputRequest, 0, len(cp.pendingReqs))
+	for _, req := range cp.pendingReqs {
+		reqs = append(reqs, req)
+	}
+	cp.inputMu.RUnlock()
+	sort.Slice(reqs, func(i, j int) bool {
+		return reqs[i].RequestedAt.Before(reqs[j].RequestedAt)
+	})
+	return reqs
+}
+
 // HasPendingInput returns true if there are pending input requests.
 func (cp *ControlPlane) HasPendingInput() bool {
 	cp.inputMu.RLock()
@@ -128,15 +128,42 @@ func TestControlPlane_RetryTask(t *testing.T) {
 	queue := cp.GetRetryQueue()
 
 	select {
-	case taskID := <-queue:
-		if taskID != "task-1" {
-			t.Errorf("Expected task-1, got %s", taskID)
+	case req := <-queue:
+		if req.TaskID != "task-1" {
+			t.Errorf("Expected task-1, got %s", req.TaskID)
 		}
 	default:
 		t.Error("Expected task in queue")
 	}
 }
 
+func TestControlPlane_RequestRetryAndSkip(t *testing.T) {
+	cp := New()
+
+	cp.RequestRetry(RetryRequest{TaskID: "task-1", Agent: "codex", Instructions: "use the v2 client"})
+	cp.SkipTask("task-2", "not needed")
+
+	select {
+	case req := <-cp.GetRetryQueue():
+		if req.TaskID != "task-1" || req.Agent != "codex" || req.Instructions != "use the v2 client" {
+			t.Errorf("retry request = %+v", req)
+		}
+	default:
+		t.Error("Expected retry request in queue")
+	}
+	if status := cp.Status(); status.Skips != 1 {
+		t.Errorf("Status.Skips = %d, want 1", status.Skips)
+	}
+	select {
+	case req := <-cp.GetSkipQueue():
+		if req.TaskID != "task-2" || req.Reason != "not needed" {
+			t.Errorf("skip request = %+v", req)
+		}
+	default:
+		t.Error("Expected skip request in queue")
+	}
+}
+
 func TestControlPlane_Status(t *testing.T) {
 	cp := New()
 
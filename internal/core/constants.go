@@ -205,6 +205,26 @@ var AgentDefaultModels = map[string]string{
 	AgentOpenCode: "qwen2.5-coder:32b",
 }
 
+// ModelContextTokens lists models whose context window differs from the
+// MaxContextTokens their agent adapter reports.
+var ModelContextTokens = map[string]int{
+	"gpt-4.1":           128000,
+	"qwen2.5-coder:32b": 32768,
+	"qwen3-coder:30b":   262144,
+	"deepseek-r1:32b":   131072,
+	"codestral:22b":     32768,
+	"gpt-oss:20b":       131072,
+}
+
+// GetContextTokens returns the context window of a model, falling back to
+// agentMax (the agent's MaxContextTokens) for models not listed.
+func GetContextTokens(model string, agentMax int) int {
+	if tokens, ok := ModelContextTokens[model]; ok {
+		return tokens
+	}
+	return agentMax
+}
+
 // GetSupportedModels returns the list of supported models for an agent.
 // Returns nil if the agent is not recognized.
 func GetSupportedModels(agent string) []string {
@@ -3,6 +3,7 @@ package core
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // ErrorCategory classifies errors for handling decisions.
@@ -110,6 +111,12 @@ func ErrRateLimit(message string) *DomainError {
 	}
 }
 
+// ErrRateLimitRetryAfter creates a rate limit error carrying the provider's
+// hint of how long to wait before retrying.
+func ErrRateLimitRetryAfter(message string, retryAfter time.Duration) *DomainError {
+	return ErrRateLimit(message).WithDetail("retry_after", retryAfter)
+}
+
 // ErrState creates a state error.
 func ErrState(code, message string) *DomainError {
 	return &DomainError{
@@ -216,6 +223,18 @@ func IsCategory(err error, cat ErrorCategory) bool {
 	return GetCategory(err) == cat
 }
 
+// GetRetryAfter returns the retry-after hint of a rate limit error, or 0
+// when it has none.
+func GetRetryAfter(err error) time.Duration {
+	var domErr *DomainError
+	if errors.As(err, &domErr) {
+		if d, ok := domErr.Details["retry_after"].(time.Duration); ok {
+			return d
+		}
+	}
+	return 0
+}
+
 // Predefined error codes
 const (
 	CodeTaskNotFound        = "TASK_NOT_FOUND"
@@ -2,7 +2,9 @@ package core
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 )
 
 func TestDomainError_ErrorAndUnwrap(t *testing.T) {
@@ -84,6 +86,20 @@ func TestGetCategory(t *testing.T) {
 	}
 }
 
+func TestGetRetryAfter(t *testing.T) {
+	t.Parallel()
+	err := fmt.Errorf("analyze: %w", ErrRateLimitRetryAfter("slow down", 30*time.Second))
+	if !IsCategory(err, ErrCatRateLimit) {
+		t.Fatalf("expected rate_limit category")
+	}
+	if got := GetRetryAfter(err); got != 30*time.Second {
+		t.Fatalf("GetRetryAfter() = %v, want 30s", got)
+	}
+	if got := GetRetryAfter(ErrRateLimit("m")); got != 0 {
+		t.Fatalf("GetRetryAfter() without a hint = %v, want 0", got)
+	}
+}
+
 func TestErrHumanReviewRequired(t *testing.T) {
 	t.Parallel()
 	err := ErrHumanReviewRequired(0.45, 0.50)
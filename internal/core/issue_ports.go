@@ -153,3 +153,42 @@ type IssueClient interface {
 	// - GitLab: Creates "related to" link or adds child to parent's epic
 	LinkIssues(ctx context.Context, parent, child int) error
 }
+
+// IssueInbox is an optional IssueClient extension used to ingest issues as
+// workflow input: it reads the discussion of an issue and finds issues that
+// are labeled for import.
+type IssueInbox interface {
+	// ListIssueComments returns the comments of an issue, oldest first.
+	ListIssueComments(ctx context.Context, number int) ([]IssueComment, error)
+
+	// ListIssues returns open issues matching the options.
+	ListIssues(ctx context.Context, opts ListIssuesOptions) ([]*Issue, error)
+
+	// RemoveIssueLabel removes a label from an issue.
+	RemoveIssueLabel(ctx context.Context, number int, label string) error
+}
+
+// IssueComment is a single comment on an issue.
+type IssueComment struct {
+	Author    string
+	Body      string
+	CreatedAt time.Time
+}
+
+// ListIssuesOptions filters ListIssues.
+type ListIssuesOptions struct {
+	// Label restricts the result to issues carrying this label.
+	Label string
+
+	// Limit caps the number of returned issues (0 = provider default).
+	Limit int
+}
+
+// IssueLink references the issue a workflow was created from.
+type IssueLink struct {
+	Provider   IssueProvider `json:"provider"`
+	Repository string        `json:"repository,omitempty"` // owner/repo, empty = detected from the git remote
+	Number     int           `json:"number"`
+	URL        string        `json:"url,omitempty"`
+	Title      string        `json:"title,omitempty"`
+}
@@ -294,6 +294,9 @@ type WorkflowDefinition struct {
 	Blueprint       *Blueprint   `json:"blueprint"`
 	Attachments     []Attachment `json:"attachments,omitempty"`
 	CreatedAt       time.Time    `json:"created_at"`
+
+	// Registered projects a multi-repository workflow changes besides its own
+	Repositories []WorkflowRepository `json:"repositories,omitempty"`
 }
 
 // WorkflowRun holds the mutable execution state of a workflow.
@@ -332,6 +335,90 @@ type WorkflowRun struct {
 	KanbanCompletedAt    *time.Time `json:"kanban_completed_at,omitempty"`    // When execution completed in Kanban context
 	KanbanExecutionCount int        `json:"kanban_execution_count,omitempty"` // How many times Kanban engine executed this
 	KanbanLastError      string     `json:"kanban_last_error,omitempty"`      // Last error from Kanban execution
+
+	// PR babysitting (CI checks and automatic fix attempts after the workflow PR)
+	PRBabysit *PRBabysitState `json:"pr_babysit,omitempty"`
+
+	// Issue the workflow was created from (quorum run --from-issue, issue import)
+	SourceIssue *IssueLink `json:"source_issue,omitempty"`
+
+	// Chat session the workflow was promoted from
+	SourceChat *ChatLink `json:"source_chat,omitempty"`
+
+	// Config history version the latest execution ran with (0 when unknown)
+	ConfigVersion int `json:"config_version,omitempty"`
+
+	// Git state of the repositories of a multi-repository workflow, by name
+	RepositoryStates map[string]*RepositoryState `json:"repository_states,omitempty"`
+
+	// Agents replaced by their fallback while quarantined, in order
+	AgentSubstitutions []AgentSubstitution `json:"agent_substitutions,omitempty"`
+}
+
+// AgentSubstitution records a call that ran on a fallback agent because the
+// configured agent was quarantined by the agent health monitor.
+type AgentSubstitution struct {
+	Agent    string    `json:"agent"`
+	Fallback string    `json:"fallback"`
+	Phase    Phase     `json:"phase,omitempty"`
+	Reason   string    `json:"reason"`
+	At       time.Time `json:"at"`
+}
+
+// WorkflowRepository is a registered project a multi-repository workflow
+// changes. Tasks reference it by Name.
+type WorkflowRepository struct {
+	Name      string `json:"name"`
+	ProjectID string `json:"project_id"`
+	Path      string `json:"path"`
+}
+
+// RepositoryState is the git state of a repository of a multi-repository
+// workflow.
+type RepositoryState struct {
+	WorkflowBranch string `json:"workflow_branch,omitempty"`
+	PRURL          string `json:"pr_url,omitempty"`
+	PRNumber       int    `json:"pr_number,omitempty"`
+}
+
+// Repository returns the declared repository with the given name.
+func (ws *WorkflowState) Repository(name string) (WorkflowRepository, bool) {
+	for _, repo := range ws.Repositories {
+		if repo.Name == name {
+			return repo, true
+		}
+	}
+	return WorkflowRepository{}, false
+}
+
+// PR babysit statuses.
+const (
+	PRBabysitRunning   = "running"
+	PRBabysitPassed    = "passed"
+	PRBabysitExhausted = "exhausted" // checks still failing after max attempts
+	PRBabysitError     = "error"     // the loop could not continue (timeout, git, agent)
+)
+
+// PRBabysitState records the post-PR loop that waits for CI checks and feeds
+// failures back to an agent.
+type PRBabysitState struct {
+	PRNumber  int                `json:"pr_number"`
+	Status    string             `json:"status"`
+	Summary   string             `json:"summary,omitempty"`
+	Attempts  []PRBabysitAttempt `json:"attempts,omitempty"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// PRBabysitAttempt is a single fix iteration triggered by failed checks.
+type PRBabysitAttempt struct {
+	Attempt      int        `json:"attempt"`
+	FailedChecks []string   `json:"failed_checks"`
+	LogExcerpt   string     `json:"log_excerpt,omitempty"`
+	Agent        string     `json:"agent"`
+	CommitSHA    string     `json:"commit_sha,omitempty"`
+	Error        string     `json:"error,omitempty"`
+	StartedAt    time.Time  `json:"started_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
 }
 
 // WorkflowState represents the persisted state of a workflow.
@@ -393,6 +480,12 @@ type TaskState struct {
 	// Workflow isolation merge tracking
 	MergePending bool   `json:"merge_pending,omitempty"` // True if merge to workflow branch failed
 	MergeCommit  string `json:"merge_commit,omitempty"`  // Commit hash of merge commit
+
+	// Repository the task changes in a multi-repository workflow (empty = the workflow's project)
+	Repository string `json:"repository,omitempty"`
+
+	// RetryInstructions are added to the prompt when a failed task is retried.
+	RetryInstructions string `json:"retry_instructions,omitempty"`
 }
 
 // MaxInlineOutputSize is the maximum size of output to store inline.
@@ -531,6 +624,21 @@ type GitClient interface {
 	HasUncommittedChanges(ctx context.Context) (bool, error)
 }
 
+// GitSnapshotter is implemented by git clients that can save the working
+// tree on a ref without touching HEAD, the branch or the index. The executor
+// uses it for the work-in-progress checkpoints of long tasks.
+type GitSnapshotter interface {
+	// SnapshotWorkingTree commits the working tree, untracked files included,
+	// on top of ref (or HEAD when ref does not exist yet) and moves ref to
+	// the commit. It returns "" when nothing changed since the last snapshot.
+	SnapshotWorkingTree(ctx context.Context, ref, message string) (string, error)
+	// RestoreWorkingTree writes the files of commit into the working tree,
+	// leaving HEAD and the index as they are.
+	RestoreWorkingTree(ctx context.Context, commit string) error
+	// DeleteRef deletes ref. Deleting a missing ref is not an error.
+	DeleteRef(ctx context.Context, ref string) error
+}
+
 // Worktree represents a git worktree.
 type Worktree struct {
 	Path     string
@@ -634,6 +742,23 @@ type GitHubClient interface {
 	GetAuthenticatedUser(ctx context.Context) (string, error)
 }
 
+// PRChecksClient is an optional GitHubClient extension used to babysit pull
+// requests: it waits for the checks of a PR and reads the logs of failed runs.
+type PRChecksClient interface {
+	// WaitForPRChecks blocks until every (required) check of the PR completed.
+	WaitForPRChecks(ctx context.Context, prNumber int, opts PRChecksOptions) (*CheckStatus, error)
+	// GetFailedCheckLog returns the tail of the failed steps' log for a check,
+	// at most maxBytes long.
+	GetFailedCheckLog(ctx context.Context, check Check, maxBytes int) (string, error)
+}
+
+// PRChecksOptions configures WaitForPRChecks.
+type PRChecksOptions struct {
+	PollInterval   time.Duration
+	Timeout        time.Duration
+	RequiredChecks []string
+}
+
 // RepoInfo contains repository information.
 type RepoInfo struct {
 	Owner         string
@@ -740,6 +865,22 @@ func (cs *CheckStatus) IsPending() bool {
 	return cs.Pending > 0 || cs.State == "pending"
 }
 
+// FailedChecks returns the completed checks that did not pass.
+func (cs *CheckStatus) FailedChecks() []Check {
+	var failed []Check
+	for _, c := range cs.Checks {
+		if c.Status != "completed" {
+			continue
+		}
+		switch c.Conclusion {
+		case "success", "skipped", "neutral":
+		default:
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
 // =============================================================================
 // ChatStore Port (Chat Session Persistence)
 // =============================================================================
@@ -776,6 +917,17 @@ type ChatSessionState struct {
 	Agent       string    `json:"agent"`
 	Model       string    `json:"model,omitempty"`
 	ProjectRoot string    `json:"project_root,omitempty"`
+	// WorkflowID is the workflow this session was promoted into, if any.
+	WorkflowID string `json:"workflow_id,omitempty"`
+}
+
+// ChatLink references the chat session a workflow was promoted from.
+type ChatLink struct {
+	SessionID string `json:"session_id"`
+	Title     string `json:"title,omitempty"`
+	// TranscriptAttachment is the ID of the workflow attachment holding
+	// the conversation transcript.
+	TranscriptAttachment string `json:"transcript_attachment,omitempty"`
 }
 
 // ChatMessageState represents a persisted chat message.
@@ -788,6 +940,40 @@ type ChatMessageState struct {
 	Timestamp time.Time `json:"timestamp"`
 	TokensIn  int       `json:"tokens_in,omitempty"`
 	TokensOut int       `json:"tokens_out,omitempty"`
+	// Model is the model that produced an agent message.
+	Model string `json:"model,omitempty"`
+	// Attachments are the file paths sent along with a user message.
+	Attachments []string `json:"attachments,omitempty"`
+	// GroupID links the messages of a question asked to several agents at once:
+	// the question, each agent's reply and the moderator's synthesis.
+	GroupID string `json:"group_id,omitempty"`
+	// Consensus is the agreement score (0-1) of a moderator synthesis; nil for
+	// other messages.
+	Consensus *float64 `json:"consensus,omitempty"`
+}
+
+// ChatSummaryStore is implemented by chat stores that persist the rolling
+// summary of a session's older turns.
+type ChatSummaryStore interface {
+	// SaveSummary creates or replaces the summary of a session.
+	SaveSummary(ctx context.Context, summary *ChatSummaryState) error
+
+	// LoadSummary retrieves the summary of a session.
+	// Returns nil and no error if the session has no summary.
+	LoadSummary(ctx context.Context, sessionID string) (*ChatSummaryState, error)
+}
+
+// ChatSummaryState is the rolling summary of the oldest turns of a chat session.
+type ChatSummaryState struct {
+	SessionID string `json:"session_id"`
+	Content   string `json:"content"`
+	// ThroughMessageID is the last message covered by the summary.
+	ThroughMessageID string `json:"through_message_id"`
+	// MessageCount is the number of messages covered by the summary.
+	MessageCount int       `json:"message_count"`
+	Agent        string    `json:"agent,omitempty"`
+	Model        string    `json:"model,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at"`
 }
 
 // =============================================================================
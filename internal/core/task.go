@@ -37,6 +37,9 @@ type Task struct {
 	StartedAt    *time.Time
 	CompletedAt  *time.Time
 	Error        string
+	Repository   string // Repository of a multi-repository workflow (empty = the workflow's project)
+	// RetryInstructions are added to the prompt when a failed task is retried.
+	RetryInstructions string
 }
 
 // NewTask creates a new task with required fields.
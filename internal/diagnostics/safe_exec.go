@@ -6,6 +6,8 @@ import (
 	"os/exec"
 
 	"log/slog"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/sandbox"
 )
 
 // PreflightResult contains the result of pre-execution checks.
@@ -211,3 +213,25 @@ func (e *SafeExecutor) WrapExecution(fn func() error) (err error) {
 	}
 	return fn()
 }
+
+// Sandbox rewrites cmd to run inside the sandbox described by policy and
+// returns a cleanup to call once the command has exited. The executor's
+// logger receives blocked network access unless the policy has its own.
+func (e *SafeExecutor) Sandbox(cmd *exec.Cmd, policy sandbox.Policy) (func(), error) {
+	if policy.Logger == nil {
+		policy.Logger = e.logger
+	}
+	path := cmd.Path
+	cleanup, err := sandbox.Wrap(cmd, policy)
+	if err != nil {
+		return nil, err
+	}
+	if e.logger != nil {
+		e.logger.Debug("sandboxing command",
+			"path", path,
+			"network", policy.Network,
+			"write_paths", policy.WritePaths,
+		)
+	}
+	return cleanup, nil
+}
@@ -51,6 +51,7 @@ type Subscriber struct {
 	types     map[string]bool // Empty means all types
 	projectID string          // Empty means no project filtering (receives all)
 	priority  bool
+	complete  bool // Priority subscriber that also receives regular events
 }
 
 // EventBus provides pub/sub with backpressure control.
@@ -140,6 +141,30 @@ func (eb *EventBus) SubscribeForProjectWithPriority(projectID string, types ...s
 	return sub.ch
 }
 
+// SubscribeComplete creates a priority subscription that receives every
+// event, published with Publish or PublishPriority, and never drops one:
+// publishers block while its buffer is full. Use for consumers that must see
+// the complete stream, like the NDJSON output of the CLI.
+func (eb *EventBus) SubscribeComplete() <-chan Event {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	if eb.closed {
+		ch := make(chan Event)
+		close(ch)
+		return ch
+	}
+
+	sub := &Subscriber{
+		ch:       make(chan Event, 50),
+		types:    make(map[string]bool),
+		priority: true,
+		complete: true,
+	}
+	eb.prioritySubs = append(eb.prioritySubs, sub)
+	return sub.ch
+}
+
 // Unsubscribe removes a subscription.
 func (eb *EventBus) Unsubscribe(ch <-chan Event) {
 	eb.mu.Lock()
@@ -182,6 +207,13 @@ func (eb *EventBus) Publish(event Event) {
 		}
 		eb.deliverWithRingBuffer(sub, event)
 	}
+
+	// Complete subscribers see regular events too, without drops
+	for _, sub := range eb.prioritySubs {
+		if sub.complete {
+			sub.ch <- event
+		}
+	}
 }
 
 // shouldDeliver checks if an event should be delivered to a subscriber.
@@ -165,6 +165,33 @@ drainLoop:
 	}
 }
 
+func TestEventBus_SubscribeComplete(t *testing.T) {
+	t.Parallel()
+	bus := New(10)
+	defer bus.Close()
+
+	ch := bus.SubscribeComplete()
+	done := make(chan int)
+	go func() {
+		n := 0
+		for range ch {
+			n++
+		}
+		done <- n
+	}()
+
+	// More events than any buffer holds, through both publish paths.
+	for i := 0; i < 200; i++ {
+		bus.Publish(NewPhaseStartedEvent("wf-1", "", "analyze"))
+	}
+	bus.PublishPriority(NewWorkflowCompletedEvent("wf-1", "", time.Second))
+	bus.Unsubscribe(ch)
+
+	if n := <-done; n != 201 {
+		t.Errorf("received %d events, want 201", n)
+	}
+}
+
 func TestEventBus_Unsubscribe(t *testing.T) {
 	t.Parallel()
 	bus := New(10)
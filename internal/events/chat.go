@@ -27,6 +27,8 @@ type UserInputRequestedEvent struct {
 	Context   string        `json:"context,omitempty"`
 	Options   []string      `json:"options,omitempty"`
 	Timeout   time.Duration `json:"timeout,omitempty"`
+	TaskID    string        `json:"task_id,omitempty"` // Task waiting for the input, if any
+	Default   string        `json:"default,omitempty"` // Answer used when none is given, if any
 }
 
 // NewUserInputRequestedEvent creates a new user input request event.
@@ -45,6 +47,7 @@ type UserInputProvidedEvent struct {
 	RequestID string `json:"request_id"`
 	Input     string `json:"input"`
 	Cancelled bool   `json:"cancelled"`
+	TaskID    string `json:"task_id,omitempty"`
 }
 
 // NewUserInputProvidedEvent creates a new user input provided event.
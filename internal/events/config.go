@@ -2,7 +2,8 @@ package events
 
 // Event type constants for config-related events.
 const (
-	TypeConfigLoaded = "config_loaded"
+	TypeConfigLoaded       = "config_loaded"
+	TypeConfigReloadFailed = "config_reload_failed"
 )
 
 // ConfigLoadedEvent is emitted when an execution configuration is loaded for a workflow attempt.
@@ -33,3 +34,22 @@ func NewConfigLoadedEvent(workflowID, projectID, configPath, scope, mode, fileET
 		Warning:       warning,
 	}
 }
+
+// ConfigReloadFailedEvent is emitted when a config file changed on disk but the
+// new content was rejected. The previously loaded config stays active.
+type ConfigReloadFailedEvent struct {
+	BaseEvent
+	ConfigPath  string `json:"config_path"`
+	ConfigScope string `json:"config_scope"` // "global" | "project"
+	Error       string `json:"error"`
+}
+
+// NewConfigReloadFailedEvent creates a new config_reload_failed event.
+func NewConfigReloadFailedEvent(projectID, configPath, scope, errMsg string) ConfigReloadFailedEvent {
+	return ConfigReloadFailedEvent{
+		BaseEvent:   NewBaseEvent(TypeConfigReloadFailed, "", projectID),
+		ConfigPath:  configPath,
+		ConfigScope: scope,
+		Error:       errMsg,
+	}
+}
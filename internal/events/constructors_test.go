@@ -144,6 +144,19 @@ func TestNewConfigLoadedEvent(t *testing.T) {
 	}
 }
 
+func TestNewConfigReloadFailedEvent(t *testing.T) {
+	e := events.NewConfigReloadFailedEvent("proj-1", "/path/to/config.yaml", "project", "invalid log.level")
+	if e.EventType() != events.TypeConfigReloadFailed {
+		t.Errorf("got type %q", e.EventType())
+	}
+	if e.ProjectID() != "proj-1" || e.WorkflowID() != "" {
+		t.Errorf("got project %q, workflow %q", e.ProjectID(), e.WorkflowID())
+	}
+	if e.Error != "invalid log.level" {
+		t.Errorf("got error %q", e.Error)
+	}
+}
+
 // --- Control events ---
 
 func TestNewPauseRequestEvent(t *testing.T) {
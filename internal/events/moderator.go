@@ -0,0 +1,28 @@
+package events
+
+// Event type constants for moderator events.
+const (
+	TypeModeratorRound = "moderator_round"
+)
+
+// ModeratorRoundEvent is emitted after each semantic moderator evaluation.
+type ModeratorRoundEvent struct {
+	BaseEvent
+	Round       int     `json:"round"`
+	Score       float64 `json:"score"`
+	Threshold   float64 `json:"threshold"`
+	Agreements  int     `json:"agreements"`
+	Divergences int     `json:"divergences"`
+}
+
+// NewModeratorRoundEvent creates a new moderator round event.
+func NewModeratorRoundEvent(workflowID, projectID string, round int, score, threshold float64, agreements, divergences int) ModeratorRoundEvent {
+	return ModeratorRoundEvent{
+		BaseEvent:   NewBaseEvent(TypeModeratorRound, workflowID, projectID),
+		Round:       round,
+		Score:       score,
+		Threshold:   threshold,
+		Agreements:  agreements,
+		Divergences: divergences,
+	}
+}
@@ -0,0 +1,81 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// NDJSONSchemaVersion is the version of the NDJSON event stream format.
+// Bump it whenever a field is removed or changes meaning; adding new event
+// types or optional fields is backwards compatible and does not require a bump.
+// The matching JSON Schema lives in docs/schemas/events.v1.schema.json.
+const NDJSONSchemaVersion = 1
+
+// NDJSONWriter serializes events as newline-delimited JSON.
+//
+// Each line is the JSON encoding of the event struct (type, timestamp,
+// workflow_id, project_id and the type-specific fields) plus two envelope
+// fields: schema_version and a monotonically increasing seq starting at 1.
+type NDJSONWriter struct {
+	mu  sync.Mutex
+	w   io.Writer
+	seq uint64
+}
+
+// NewNDJSONWriter creates a new NDJSON writer.
+func NewNDJSONWriter(w io.Writer) *NDJSONWriter {
+	return &NDJSONWriter{w: w}
+}
+
+// Write encodes a single event as one line and assigns it the next sequence number.
+func (n *NDJSONWriter) Write(event Event) error {
+	raw, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshaling %s event: %w", event.EventType(), err)
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return fmt.Errorf("decoding %s event: %w", event.EventType(), err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.seq++
+	fields["schema_version"] = json.RawMessage(fmt.Sprintf("%d", NDJSONSchemaVersion))
+	fields["seq"] = json.RawMessage(fmt.Sprintf("%d", n.seq))
+
+	// encoding/json sorts map keys, which keeps lines stable for golden tests.
+	line, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("encoding %s event: %w", event.EventType(), err)
+	}
+	line = append(line, '\n')
+	if _, err := n.w.Write(line); err != nil {
+		return fmt.Errorf("writing %s event: %w", event.EventType(), err)
+	}
+	return nil
+}
+
+// Seq returns the sequence number of the last written event.
+func (n *NDJSONWriter) Seq() uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.seq
+}
+
+// Drain writes every event received on ch until the channel is closed.
+// Write errors do not stop draining (publishers must never block on a broken
+// writer); the first error is returned once the channel closes.
+func (n *NDJSONWriter) Drain(ch <-chan Event) error {
+	var firstErr error
+	for event := range ch {
+		if err := n.Write(event); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
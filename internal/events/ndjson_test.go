@@ -0,0 +1,136 @@
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/testutil"
+)
+
+var (
+	goldenDir   = filepath.Join("..", "..", "testdata", "golden")
+	timestampRe = regexp.MustCompile(`"\d{4}-\d{2}-\d{2}T[^"]*"`)
+)
+
+// runStreamEvents returns one event of every type a `quorum run -o json`
+// stream can contain, in the order a typical run would emit them.
+func runStreamEvents() []Event {
+	const wf, proj = "wf-golden", "proj-golden"
+	return []Event{
+		NewWorkflowStartedEvent(wf, proj, "Add a health endpoint"),
+		NewPhaseStartedEvent(wf, proj, "analyze"),
+		NewAgentStreamEvent(wf, proj, AgentStarted, "claude", "Running V1 analysis").
+			WithData(map[string]interface{}{"phase": "analyze", "round": 1}),
+		NewAgentStreamEvent(wf, proj, AgentChunk, "claude", "partial output"),
+		NewModeratorRoundEvent(wf, proj, 2, 0.92, 0.9, 7, 1),
+		NewLogEvent(wf, proj, "success", "[analyzer] Analysis phase completed successfully", nil),
+		NewPhaseCompletedEvent(wf, proj, "analyze", 90*time.Second),
+		NewPhaseAwaitingReviewEvent(wf, proj, "plan"),
+		NewWorkflowStateUpdatedEvent(wf, proj, "execute", 2, 0, 0, 0),
+		NewTaskStartedEvent(wf, proj, "task-1", ""),
+		NewTaskRetryEvent(wf, proj, "task-1", 1, 3, errors.New("rate limited")),
+		NewTaskCompletedEvent(wf, proj, "task-1", 45*time.Second, 1200, 800),
+		NewTaskStartedEvent(wf, proj, "task-2", ""),
+		NewTaskFailedEvent(wf, proj, "task-2", errors.New("exit status 1"), false),
+		NewTaskSkippedEvent(wf, proj, "task-2", "dependency failed"),
+		NewMetricsUpdateEvent(wf, proj, 1200, 800, 0.92, 3*time.Minute),
+		NewWorkflowFailedEvent(wf, proj, "execute", errors.New("1 task failed")),
+		NewWorkflowCompletedEvent(wf, proj, 3*time.Minute),
+	}
+}
+
+func TestNDJSONWriter_Golden(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	w := NewNDJSONWriter(&buf)
+	for _, e := range runStreamEvents() {
+		if err := w.Write(e); err != nil {
+			t.Fatalf("Write(%s) error = %v", e.EventType(), err)
+		}
+	}
+
+	// Timestamps are the only non-deterministic values; keep the quotes so the
+	// golden file still shows they are RFC 3339 strings.
+	scrubbed := timestampRe.ReplaceAllString(buf.String(), `"[TIMESTAMP]"`)
+	golden := testutil.NewGolden(t, goldenDir)
+	golden.AssertString("run_json_events", scrubbed)
+}
+
+func TestNDJSONWriter_Envelope(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	w := NewNDJSONWriter(&buf)
+	want := runStreamEvents()
+	for _, e := range want {
+		if err := w.Write(e); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if w.Seq() != uint64(len(want)) {
+		t.Errorf("Seq() = %d, want %d", w.Seq(), len(want))
+	}
+
+	scanner := bufio.NewScanner(&buf)
+	line := 0
+	for scanner.Scan() {
+		var got struct {
+			SchemaVersion int       `json:"schema_version"`
+			Seq           uint64    `json:"seq"`
+			Type          string    `json:"type"`
+			Timestamp     time.Time `json:"timestamp"`
+			WorkflowID    string    `json:"workflow_id"`
+			ProjectID     string    `json:"project_id"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &got); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v", line+1, err)
+		}
+		if got.SchemaVersion != NDJSONSchemaVersion {
+			t.Errorf("line %d schema_version = %d, want %d", line+1, got.SchemaVersion, NDJSONSchemaVersion)
+		}
+		if got.Seq != uint64(line+1) {
+			t.Errorf("line %d seq = %d, want %d", line+1, got.Seq, line+1)
+		}
+		if got.Type != want[line].EventType() {
+			t.Errorf("line %d type = %q, want %q", line+1, got.Type, want[line].EventType())
+		}
+		if got.WorkflowID != "wf-golden" || got.ProjectID != "proj-golden" {
+			t.Errorf("line %d ids = %q/%q", line+1, got.WorkflowID, got.ProjectID)
+		}
+		if got.Timestamp.IsZero() {
+			t.Errorf("line %d has zero timestamp", line+1)
+		}
+		line++
+	}
+	if line != len(want) {
+		t.Errorf("got %d lines, want %d", line, len(want))
+	}
+}
+
+func TestNDJSONWriter_DrainFromBus(t *testing.T) {
+	t.Parallel()
+	bus := New(10)
+	ch := bus.SubscribePriority()
+
+	var buf bytes.Buffer
+	w := NewNDJSONWriter(&buf)
+	done := make(chan error, 1)
+	go func() { done <- w.Drain(ch) }()
+
+	for i := 0; i < 200; i++ {
+		bus.PublishPriority(NewLogEvent("wf", "", "info", "msg", nil))
+	}
+	bus.Close()
+
+	if err := <-done; err != nil {
+		t.Fatalf("Drain() error = %v", err)
+	}
+	if w.Seq() != 200 {
+		t.Errorf("Seq() = %d, want 200 (priority subscription must not drop)", w.Seq())
+	}
+}
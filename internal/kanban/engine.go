@@ -61,6 +61,14 @@ type KanbanStateManager interface {
 	SaveKanbanEngineState(ctx context.Context, state *KanbanEngineState) error
 }
 
+// IssueImporter imports labeled issues as workflows into the To Do column of
+// a project. The context carries the project (see
+// ProjectStateProvider.GetProjectExecutionContext); implementations decide
+// whether the project has import enabled and how often it polls.
+type IssueImporter interface {
+	ImportIssues(ctx context.Context) (int, error)
+}
+
 // currentExecution tracks the currently executing workflow and its project.
 type currentExecution struct {
 	WorkflowID string
@@ -81,6 +89,9 @@ type Engine struct {
 	enabled    atomic.Bool
 	currentExe atomic.Value // *currentExecution
 
+	issueImporter atomic.Value // issueImporterHolder
+	importing     atomic.Bool
+
 	stopCh       chan struct{}
 	doneCh       chan struct{}
 	tickInterval time.Duration
@@ -193,8 +204,21 @@ func (e *Engine) runLoop(ctx context.Context, eventTypes []string) {
 	}
 }
 
+// issueImporterHolder wraps the importer so atomic.Value always stores the
+// same concrete type.
+type issueImporterHolder struct{ importer IssueImporter }
+
+// SetIssueImporter registers the importer that feeds labeled issues into
+// the To Do column on every tick.
+func (e *Engine) SetIssueImporter(importer IssueImporter) {
+	e.issueImporter.Store(issueImporterHolder{importer: importer})
+}
+
 // tick processes one iteration of the engine loop.
 func (e *Engine) tick(ctx context.Context) {
+	// Issue import feeds the board independently of the execution state.
+	e.importIssues(ctx)
+
 	// Check if we should pick a new workflow
 	if !e.enabled.Load() {
 		return
@@ -242,6 +266,40 @@ func (e *Engine) tick(ctx context.Context) {
 	}
 }
 
+// importIssues runs the issue importer for every loaded project in the
+// background. A run is skipped while the previous one is still going.
+func (e *Engine) importIssues(ctx context.Context) {
+	holder, _ := e.issueImporter.Load().(issueImporterHolder)
+	if holder.importer == nil || !e.importing.CompareAndSwap(false, true) {
+		return
+	}
+
+	projects, err := e.projectProvider.ListLoadedProjects(ctx)
+	if err != nil {
+		e.importing.Store(false)
+		e.logger.Warn("issue import: failed to list loaded projects", "error", err)
+		return
+	}
+
+	go func() {
+		defer e.importing.Store(false)
+		for _, proj := range projects {
+			projectCtx, err := e.projectProvider.GetProjectExecutionContext(context.WithoutCancel(ctx), proj.ID)
+			if err != nil {
+				e.logger.Warn("issue import: failed to create project context", "project_id", proj.ID, "error", err)
+				continue
+			}
+			n, err := holder.importer.ImportIssues(projectCtx)
+			if err != nil {
+				e.logger.Warn("issue import failed", "project_id", proj.ID, "error", err)
+			}
+			if n > 0 {
+				e.logger.Info("imported issues into kanban", "project_id", proj.ID, "count", n)
+			}
+		}
+	}()
+}
+
 // startExecutionForProject moves a workflow to in_progress and starts execution.
 // This is the project-aware version that uses project-specific StateManager.
 func (e *Engine) startExecutionForProject(ctx context.Context, workflow *core.WorkflowState, projectID string, stateManager KanbanStateManager) {
@@ -383,7 +441,9 @@ func (e *Engine) handleWorkflowCompletedForProject(ctx context.Context, workflow
 			"branch", workflow.WorkflowBranch)
 	}
 
-	// Move to to_verify using project-specific StateManager
+	// Move to to_verify using project-specific StateManager. The workflow
+	// succeeded: a failing PR babysitting loop is shown from its own state,
+	// not as an error.
 	if err := stateManager.UpdateKanbanStatus(ctx, workflowID, "to_verify", prURL, prNumber, ""); err != nil {
 		e.logger.Error("failed to move workflow to to_verify", "error", err)
 	}
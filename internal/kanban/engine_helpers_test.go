@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -1107,6 +1108,50 @@ func TestHandleWorkflowCompletedForProject_WithPRInfo(t *testing.T) {
 	}
 }
 
+func TestHandleWorkflowCompletedForProject_BabysitSummary(t *testing.T) {
+	t.Parallel()
+	stateMgr := newMockKanbanStateManager()
+	eventBus := events.New(100)
+
+	summary := "Checks still failing on PR #42 after 3 fix attempt(s): test"
+	wf := &core.WorkflowState{
+		WorkflowDefinition: core.WorkflowDefinition{WorkflowID: "wf-babysit"},
+		WorkflowRun: core.WorkflowRun{
+			Status:       core.WorkflowStatusCompleted,
+			KanbanColumn: "in_progress",
+			PRURL:        "https://github.com/org/repo/pull/42",
+			PRNumber:     42,
+			PRBabysit: &core.PRBabysitState{
+				PRNumber: 42,
+				Status:   core.PRBabysitExhausted,
+				Summary:  summary,
+			},
+		},
+	}
+	stateMgr.AddWorkflow(wf)
+
+	engine := NewEngine(EngineConfig{
+		Executor:     &mockWorkflowExecutor{},
+		StateManager: stateMgr,
+		EventBus:     eventBus,
+		Logger:       testLogger(),
+	})
+	engine.currentExe.Store(&currentExecution{WorkflowID: "wf-babysit", ProjectID: "default"})
+
+	engine.handleWorkflowCompletedForProject(context.Background(), "wf-babysit", "default")
+
+	storedWf := stateMgr.GetWorkflow("wf-babysit")
+	if storedWf.KanbanColumn != "to_verify" {
+		t.Errorf("expected to_verify, got %s", storedWf.KanbanColumn)
+	}
+	if storedWf.KanbanLastError != "" {
+		t.Errorf("expected no error on a completed card, got %q", storedWf.KanbanLastError)
+	}
+	if storedWf.PRBabysit == nil || storedWf.PRBabysit.Summary != summary {
+		t.Errorf("expected the babysit summary kept, got %+v", storedWf.PRBabysit)
+	}
+}
+
 func TestHandleWorkflowCompletedForProject_WithBranchNoPR(t *testing.T) {
 	t.Parallel()
 	stateMgr := newMockKanbanStateManager()
@@ -2006,3 +2051,44 @@ func TestStop_ContextTimeout(t *testing.T) {
 	// The error might or might not be nil depending on timing, but it should not panic
 	_ = err
 }
+
+// countingImporter records the projects it was asked to import into.
+type countingImporter struct {
+	mu    sync.Mutex
+	calls int
+	done  chan struct{}
+}
+
+func (c *countingImporter) ImportIssues(_ context.Context) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	if c.calls == 2 {
+		close(c.done)
+	}
+	return 1, nil
+}
+
+func TestTick_ImportsIssuesForLoadedProjects(t *testing.T) {
+	t.Parallel()
+	provider := newMockProjectStateProvider()
+	provider.loadedProjects = []ProjectInfo{{ID: "p1"}, {ID: "p2"}}
+
+	engine := NewEngine(EngineConfig{
+		Executor:        &mockWorkflowExecutor{},
+		ProjectProvider: provider,
+		EventBus:        events.New(10),
+		Logger:          testLogger(),
+	})
+	importer := &countingImporter{done: make(chan struct{})}
+	engine.SetIssueImporter(importer)
+
+	// Engine is disabled: import still runs, execution does not.
+	engine.tick(context.Background())
+
+	select {
+	case <-importer.done:
+	case <-time.After(time.Second):
+		t.Fatal("importer should run once per loaded project")
+	}
+}
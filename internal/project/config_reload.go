@@ -0,0 +1,134 @@
+package project
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
+)
+
+// ConfigSnapshot is a validated configuration and the file content it was
+// loaded from. Config is shared by every workflow started from the snapshot
+// and must be treated as read-only.
+type ConfigSnapshot struct {
+	Config   *config.Config
+	Raw      []byte
+	Path     string
+	Scope    string // "global" | "project"
+	LoadedAt time.Time
+}
+
+// FileETag returns the ETag of the snapshot's file content, computed like the
+// config API does for the file on disk.
+func (s *ConfigSnapshot) FileETag() string {
+	hash := sha256.Sum256(s.Raw)
+	return hex.EncodeToString(hash[:16])
+}
+
+// ActiveConfig returns the config new workflows should use, or nil when the
+// context does not watch its config or has not loaded a valid one yet.
+func (pc *ProjectContext) ActiveConfig() *ConfigSnapshot {
+	pc.configMu.RLock()
+	defer pc.configMu.RUnlock()
+	return pc.activeConfig
+}
+
+// ReloadConfig re-reads the effective config file of a context that watches
+// its config. A valid change replaces the active config and publishes
+// config_loaded; an invalid one publishes config_reload_failed and the active
+// config is kept. It returns the active config and the error that rejected
+// the file's content, if any. Workflows already running are not affected:
+// they keep the config they were built with.
+func (pc *ProjectContext) ReloadConfig() (*ConfigSnapshot, error) {
+	return pc.reloadConfig(true)
+}
+
+func (pc *ProjectContext) reloadConfig(announce bool) (*ConfigSnapshot, error) {
+	if !pc.watchConfig {
+		return nil, nil
+	}
+	pc.reloadMu.Lock()
+	defer pc.reloadMu.Unlock()
+
+	active := pc.ActiveConfig()
+	raw, err := os.ReadFile(pc.configPath) // #nosec G304 -- effective config path of the project
+	if os.IsNotExist(err) {
+		// Removed, e.g. while switching the project to inherit_global.
+		return active, nil
+	}
+	if err != nil {
+		return active, pc.rejectConfig(fmt.Errorf("reading config: %w", err))
+	}
+	if active != nil && bytes.Equal(active.Raw, raw) {
+		return active, nil
+	}
+
+	cfg, err := config.NewLoader().
+		WithConfigFile(pc.configPath).
+		WithProjectDir(pc.Root).
+		Load()
+	if err == nil {
+		err = config.ValidateConfig(cfg)
+	}
+	if err != nil {
+		return active, pc.rejectConfig(err)
+	}
+
+	snapshot := &ConfigSnapshot{
+		Config:   cfg,
+		Raw:      raw,
+		Path:     pc.configPath,
+		Scope:    pc.configScope(),
+		LoadedAt: time.Now(),
+	}
+	pc.configMu.Lock()
+	pc.activeConfig = snapshot
+	pc.configMu.Unlock()
+
+	if announce {
+		pc.logger.Info("config reloaded", "config_path", pc.configPath)
+		pc.publish(events.NewConfigLoadedEvent("", pc.ID, pc.configPath, snapshot.Scope, pc.ConfigMode,
+			snapshot.FileETag(), "", 0, "", ""))
+	}
+	return snapshot, nil
+}
+
+// rejectConfig reports a config file that could not be loaded.
+func (pc *ProjectContext) rejectConfig(err error) error {
+	pc.logger.Warn("config change rejected, keeping the active config",
+		"config_path", pc.configPath, "error", err)
+	pc.publish(events.NewConfigReloadFailedEvent(pc.ID, pc.configPath, pc.configScope(), err.Error()))
+	return err
+}
+
+func (pc *ProjectContext) configScope() string {
+	if pc.ConfigMode == ConfigModeInheritGlobal {
+		return "global"
+	}
+	return "project"
+}
+
+// publish sends an event on the context's bus, if it is still open.
+func (pc *ProjectContext) publish(event events.Event) {
+	if pc.EventBus != nil {
+		pc.EventBus.Publish(event)
+	}
+}
+
+// startConfigWatch loads the active config and watches its file for changes.
+func (pc *ProjectContext) startConfigWatch() error {
+	// An invalid initial config is reported by reloadConfig; workflows fail
+	// until the file is fixed.
+	_, _ = pc.reloadConfig(false)
+	w, err := config.NewWatcher(pc.configPath, func() { _, _ = pc.ReloadConfig() })
+	if err != nil {
+		return err
+	}
+	pc.configWatcher = w
+	return nil
+}
@@ -0,0 +1,97 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
+)
+
+func writeProjectConfig(t *testing.T, root, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(root, ".quorum", "config.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func nextEvent(t *testing.T, ch <-chan events.Event, eventType string) events.Event {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case e := <-ch:
+			if e.EventType() == eventType {
+				return e
+			}
+		case <-timeout:
+			t.Fatalf("no %s event", eventType)
+			return nil
+		}
+	}
+}
+
+func TestReloadConfig_SwapsValidAndRejectsInvalid(t *testing.T) {
+	t.Parallel()
+	projectDir, cleanup := setupTestProjectDir(t)
+	defer cleanup()
+	writeProjectConfig(t, projectDir, config.DefaultConfigYAML)
+
+	pc, err := NewProjectContext("proj-reload", projectDir, WithConfigWatch(true))
+	if err != nil {
+		t.Fatalf("NewProjectContext failed: %v", err)
+	}
+	defer pc.Close()
+	ch := pc.EventBus.Subscribe()
+
+	initial := pc.ActiveConfig()
+	if initial == nil || initial.Config.Log.Level != "info" {
+		t.Fatalf("initial active config = %+v", initial)
+	}
+
+	// A valid edit is picked up by the watcher.
+	writeProjectConfig(t, projectDir, config.DefaultConfigYAML+"\nlog:\n  level: debug\n")
+	loaded := nextEvent(t, ch, events.TypeConfigLoaded).(events.ConfigLoadedEvent)
+	if loaded.ProjectID() != "proj-reload" || loaded.ConfigScope != "project" || loaded.FileETag == "" {
+		t.Errorf("config_loaded = %+v", loaded)
+	}
+	if got := pc.ActiveConfig().Config.Log.Level; got != "debug" {
+		t.Errorf("active log level = %q, want debug", got)
+	}
+
+	// An invalid edit is rejected and the active config is kept.
+	writeProjectConfig(t, projectDir, config.DefaultConfigYAML+"\nlog:\n  level: loud\n")
+	failed := nextEvent(t, ch, events.TypeConfigReloadFailed).(events.ConfigReloadFailedEvent)
+	if !strings.Contains(failed.Error, "level") {
+		t.Errorf("config_reload_failed error = %q", failed.Error)
+	}
+	snapshot, err := pc.ReloadConfig()
+	if err == nil {
+		t.Error("ReloadConfig() should report the rejected file")
+	}
+	if snapshot == nil || snapshot.Config.Log.Level != "debug" {
+		t.Errorf("rejected edit replaced the active config: %+v", snapshot)
+	}
+}
+
+func TestReloadConfig_DisabledWithoutWatch(t *testing.T) {
+	t.Parallel()
+	projectDir, cleanup := setupTestProjectDir(t)
+	defer cleanup()
+
+	pc, err := NewProjectContext("proj-nowatch", projectDir)
+	if err != nil {
+		t.Fatalf("NewProjectContext failed: %v", err)
+	}
+	defer pc.Close()
+
+	if snapshot, err := pc.ReloadConfig(); snapshot != nil || err != nil {
+		t.Errorf("ReloadConfig() = %+v, %v; want nil, nil", snapshot, err)
+	}
+	if pc.ActiveConfig() != nil {
+		t.Error("a context without WithConfigWatch should not keep an active config")
+	}
+}
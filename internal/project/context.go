@@ -43,6 +43,14 @@ type ProjectContext struct {
 	mu     sync.RWMutex
 	logger *slog.Logger
 	closed bool
+
+	// Live config reload (WithConfigWatch)
+	configPath    string
+	watchConfig   bool
+	configWatcher *config.Watcher
+	activeConfig  *ConfigSnapshot
+	configMu      sync.RWMutex
+	reloadMu      sync.Mutex
 }
 
 // contextOptions holds configuration for context creation
@@ -50,6 +58,7 @@ type contextOptions struct {
 	logger          *slog.Logger
 	eventBufferSize int
 	configMode      string
+	watchConfig     bool
 }
 
 // ContextOption configures a ProjectContext
@@ -79,6 +88,14 @@ func WithConfigMode(mode string) ContextOption {
 	}
 }
 
+// WithConfigWatch makes the context watch its effective config file and keep
+// the last valid version active for new workflows (see ReloadConfig).
+func WithConfigWatch(enabled bool) ContextOption {
+	return func(o *contextOptions) {
+		o.watchConfig = enabled
+	}
+}
+
 // NewProjectContext creates a new context for the given project.
 // The id parameter is the unique project identifier from the registry.
 // The root parameter is the absolute path to the project directory.
@@ -114,6 +131,7 @@ func NewProjectContext(id, root string, opts ...ContextOption) (*ProjectContext,
 		LastAccessed: time.Now(),
 		logger:       options.logger.With("project_id", id, "root", absRoot),
 		ConfigMode:   options.configMode,
+		watchConfig:  options.watchConfig,
 	}
 
 	// Initialize all services in order
@@ -149,6 +167,14 @@ func NewProjectContext(id, root string, opts ...ContextOption) (*ProjectContext,
 		return nil, fmt.Errorf("initializing chat store: %w", initErr)
 	}
 
+	// 6. Config watcher (optional, needs the event bus)
+	if pc.watchConfig {
+		if initErr = pc.startConfigWatch(); initErr != nil {
+			_ = pc.Close()
+			return nil, fmt.Errorf("watching config: %w", initErr)
+		}
+	}
+
 	pc.logger.Info("project context initialized",
 		"state_backend", "sqlite",
 		"event_buffer_size", options.eventBufferSize)
@@ -213,25 +239,29 @@ func (pc *ProjectContext) initEventBus(opts *contextOptions) error {
 	return nil
 }
 
+// effectiveConfigPath returns the config file a project in the given config
+// mode uses: the global config for inherit_global, its own file otherwise.
+func effectiveConfigPath(root, mode string) (string, error) {
+	if mode == ConfigModeInheritGlobal {
+		return config.EnsureGlobalConfigFile()
+	}
+	return filepath.Join(root, ".quorum", "config.yaml"), nil
+}
+
 // initConfigLoader creates the config loader for this project
 func (pc *ProjectContext) initConfigLoader() error {
-	configPath := filepath.Join(pc.Root, ".quorum", "config.yaml")
-
 	mode := pc.ConfigMode
 	if mode != ConfigModeInheritGlobal && mode != ConfigModeCustom {
 		mode = ConfigModeCustom
 	}
-
-	if mode == ConfigModeInheritGlobal {
-		globalPath, err := config.EnsureGlobalConfigFile()
-		if err != nil {
-			return err
-		}
-		configPath = globalPath
+	configPath, err := effectiveConfigPath(pc.Root, mode)
+	if err != nil {
+		return err
 	}
 
 	// IMPORTANT: Resolve relative paths relative to the project root (not the config file location).
 	// This is required for global config inheritance where the config file lives outside the project.
+	pc.configPath = configPath
 	pc.ConfigLoader = config.NewLoader().
 		WithConfigFile(configPath).
 		WithProjectDir(pc.Root)
@@ -282,6 +312,14 @@ func (pc *ProjectContext) Close() error {
 
 	var errs []error
 
+	// Stop the config watcher first: a pending reload publishes on the event bus.
+	if pc.configWatcher != nil {
+		if err := pc.configWatcher.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("config watcher: %w", err))
+		}
+		pc.configWatcher = nil
+	}
+
 	// Close state manager using the factory helper
 	if pc.StateManager != nil {
 		if err := state.CloseStateManager(pc.StateManager); err != nil {
@@ -0,0 +1,226 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/state"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// overviewConcurrency caps the projects read at the same time by CollectOverview.
+const overviewConcurrency = 4
+
+// ProjectWorkflow is a workflow summary with the project it belongs to.
+type ProjectWorkflow struct {
+	core.WorkflowSummary
+	ProjectID    string     `json:"project_id"`
+	ProjectName  string     `json:"project_name"`
+	ProjectPath  string     `json:"project_path"`
+	ProjectColor string     `json:"project_color,omitempty"`
+	Running      bool       `json:"running"`
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+	HeartbeatAt  *time.Time `json:"heartbeat_at,omitempty"`
+}
+
+// ProjectError is a project whose workflows could not be read.
+type ProjectError struct {
+	ProjectID   string `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	Error       string `json:"error"`
+}
+
+// Overview is the merged view of the workflows of the enabled projects.
+type Overview struct {
+	Workflows []ProjectWorkflow `json:"workflows"`
+	// Projects is the number of projects read.
+	Projects int            `json:"projects"`
+	Errors   []ProjectError `json:"errors,omitempty"`
+}
+
+// OverviewOptions selects the workflows of an overview.
+type OverviewOptions struct {
+	// RunningOnly keeps the workflows that are executing.
+	RunningOnly bool
+	// Limit caps the number of merged workflows; 0 means no limit.
+	Limit int
+}
+
+// workflowLister is implemented by StateManagers and state.Reader.
+type workflowLister interface {
+	ListWorkflows(ctx context.Context) ([]core.WorkflowSummary, error)
+}
+
+// runningRecordLister is implemented by the SQLite StateManager and state.Reader.
+type runningRecordLister interface {
+	ListRunningWorkflowRecords(ctx context.Context) ([]core.RunningWorkflowRecord, error)
+}
+
+// CollectOverview reads the workflows of every enabled project in the registry
+// and merges them, most recently updated first. Projects loaded in pool are
+// read through their StateManager without counting as an access, so the
+// overview neither reorders nor grows the pool; the others are read over a
+// short-lived read-only connection. pool may be nil. A project that cannot be
+// read is reported in Errors and does not fail the overview.
+func CollectOverview(ctx context.Context, registry Registry, pool *StatePool, opts OverviewOptions) (*Overview, error) {
+	projects, err := registry.ListProjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing projects: %w", err)
+	}
+
+	overview := &Overview{Workflows: []ProjectWorkflow{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, overviewConcurrency)
+	for _, p := range projects {
+		if !p.IsEnabled() {
+			continue
+		}
+		wg.Add(1)
+		go func(p *Project) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			workflows, err := readProjectWorkflows(ctx, p, pool, opts.RunningOnly)
+
+			mu.Lock()
+			defer mu.Unlock()
+			overview.Projects++
+			if err != nil {
+				overview.Errors = append(overview.Errors, ProjectError{
+					ProjectID:   p.ID,
+					ProjectName: p.Name,
+					Error:       err.Error(),
+				})
+				return
+			}
+			overview.Workflows = append(overview.Workflows, workflows...)
+		}(p)
+	}
+	wg.Wait()
+
+	sort.Slice(overview.Workflows, func(i, j int) bool {
+		a, b := overview.Workflows[i], overview.Workflows[j]
+		if !a.UpdatedAt.Equal(b.UpdatedAt) {
+			return a.UpdatedAt.After(b.UpdatedAt)
+		}
+		if a.ProjectName != b.ProjectName {
+			return a.ProjectName < b.ProjectName
+		}
+		return a.WorkflowID < b.WorkflowID
+	})
+	sort.Slice(overview.Errors, func(i, j int) bool {
+		return overview.Errors[i].ProjectName < overview.Errors[j].ProjectName
+	})
+	if opts.Limit > 0 && len(overview.Workflows) > opts.Limit {
+		overview.Workflows = overview.Workflows[:opts.Limit]
+	}
+	return overview, nil
+}
+
+// readProjectWorkflows reads the workflows of a project, through its loaded
+// context when there is one.
+func readProjectWorkflows(ctx context.Context, p *Project, pool *StatePool, runningOnly bool) ([]ProjectWorkflow, error) {
+	if sm := pool.loadedStateManager(p.ID); sm != nil {
+		// The context may be evicted while it is read; fall back to a
+		// connection of our own then.
+		if workflows, err := listProjectWorkflows(ctx, p, sm, runningOnly); err == nil {
+			return workflows, nil
+		}
+	}
+	if !p.IsAccessible() {
+		return nil, fmt.Errorf("project is %s: %s", p.Status, p.StatusMessage)
+	}
+
+	r, err := state.OpenReader(projectStatePath(p))
+	if os.IsNotExist(err) {
+		// No workflow has been run in the project yet.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return listProjectWorkflows(ctx, p, r, runningOnly)
+}
+
+func listProjectWorkflows(ctx context.Context, p *Project, source workflowLister, runningOnly bool) ([]ProjectWorkflow, error) {
+	running := make(map[core.WorkflowID]core.RunningWorkflowRecord)
+	if rl, ok := source.(runningRecordLister); ok {
+		records, err := rl.ListRunningWorkflowRecords(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, rec := range records {
+			running[rec.WorkflowID] = rec
+		}
+	}
+	if runningOnly && len(running) == 0 {
+		return nil, nil
+	}
+
+	summaries, err := source.ListWorkflows(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workflows := make([]ProjectWorkflow, 0, len(summaries))
+	for _, s := range summaries {
+		rec, isRunning := running[s.WorkflowID]
+		if runningOnly && !isRunning {
+			continue
+		}
+		wf := ProjectWorkflow{
+			WorkflowSummary: s,
+			ProjectID:       p.ID,
+			ProjectName:     p.Name,
+			ProjectPath:     p.Path,
+			ProjectColor:    p.Color,
+			Running:         isRunning,
+		}
+		if isRunning {
+			startedAt := rec.StartedAt
+			wf.StartedAt = &startedAt
+			wf.HeartbeatAt = rec.HeartbeatAt
+		}
+		workflows = append(workflows, wf)
+	}
+	return workflows, nil
+}
+
+// projectStatePath returns the state database of a project, honoring
+// state.path in its effective config like its ProjectContext does.
+func projectStatePath(p *Project) string {
+	statePath := filepath.Join(p.Path, ".quorum", "state", "state.db")
+	configPath, err := effectiveConfigPath(p.Path, p.ConfigMode)
+	if err != nil {
+		return statePath
+	}
+	cfg, err := config.NewLoader().WithConfigFile(configPath).WithProjectDir(p.Path).Load()
+	if err == nil && strings.TrimSpace(cfg.State.Path) != "" {
+		statePath = cfg.State.Path
+	}
+	return statePath
+}
+
+// loadedStateManager returns the StateManager of a loaded, open context
+// without recording an access, or nil.
+func (p *StatePool) loadedStateManager(projectID string) core.StateManager {
+	if p == nil {
+		return nil
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	entry, ok := p.contexts[projectID]
+	if !ok || entry.context.IsClosed() {
+		return nil
+	}
+	return entry.context.StateManager
+}
@@ -0,0 +1,148 @@
+package project
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/state"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// saveOverviewWorkflow saves a workflow; Save stamps it with the current
+// time, so workflows saved later sort first.
+func saveOverviewWorkflow(t *testing.T, sm core.StateManager, id string) {
+	t.Helper()
+	time.Sleep(5 * time.Millisecond)
+	now := time.Now()
+	wf := &core.WorkflowState{
+		WorkflowDefinition: core.WorkflowDefinition{
+			Version:    1,
+			WorkflowID: core.WorkflowID(id),
+			Prompt:     "prompt for " + id,
+			CreatedAt:  now,
+		},
+		WorkflowRun: core.WorkflowRun{
+			Status:       core.WorkflowStatusRunning,
+			CurrentPhase: core.PhaseAnalyze,
+			Tasks:        make(map[core.TaskID]*core.TaskState),
+			TaskOrder:    []core.TaskID{},
+			UpdatedAt:    now,
+		},
+	}
+	if err := sm.Save(context.Background(), wf); err != nil {
+		t.Fatalf("Save(%s) error = %v", id, err)
+	}
+}
+
+func TestCollectOverview_MergesProjectsWithoutTouchingPool(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	baseDir := t.TempDir()
+	registry := newMockRegistry()
+
+	loaded, _ := registry.AddProject(ctx, createPoolTestProject(t, baseDir, "loaded"), nil)
+	idle, _ := registry.AddProject(ctx, createPoolTestProject(t, baseDir, "idle"), nil)
+	_, _ = registry.AddProject(ctx, createPoolTestProject(t, baseDir, "fresh"), nil)
+	disabled, _ := registry.AddProject(ctx, createPoolTestProject(t, baseDir, "disabled"), nil)
+	off := false
+	disabled.Enabled = &off
+	_ = registry.UpdateProject(ctx, disabled)
+
+	pool := NewStatePool(registry)
+	defer pool.Close()
+	pc, err := pool.GetContext(ctx, loaded.ID)
+	if err != nil {
+		t.Fatalf("GetContext() error = %v", err)
+	}
+	saveOverviewWorkflow(t, pc.StateManager, "wf-loaded-old")
+
+	sm, err := state.NewStateManager(filepath.Join(idle.Path, ".quorum", "state", "state.db"))
+	if err != nil {
+		t.Fatalf("NewStateManager() error = %v", err)
+	}
+	saveOverviewWorkflow(t, sm, "wf-idle")
+	_ = state.CloseStateManager(sm)
+
+	saveOverviewWorkflow(t, pc.StateManager, "wf-loaded-new")
+	if err := pc.StateManager.SetWorkflowRunning(ctx, "wf-loaded-new"); err != nil {
+		t.Fatalf("SetWorkflowRunning() error = %v", err)
+	}
+
+	_, accessesBefore, _ := pool.GetContextInfo(loaded.ID)
+	overview, err := CollectOverview(ctx, registry, pool, OverviewOptions{})
+	if err != nil {
+		t.Fatalf("CollectOverview() error = %v", err)
+	}
+	if len(overview.Errors) != 0 {
+		t.Fatalf("unexpected errors: %+v", overview.Errors)
+	}
+	if overview.Projects != 3 {
+		t.Errorf("Projects = %d, want 3 (disabled skipped)", overview.Projects)
+	}
+	var ids []core.WorkflowID
+	for _, wf := range overview.Workflows {
+		ids = append(ids, wf.WorkflowID)
+	}
+	want := []core.WorkflowID{"wf-loaded-new", "wf-idle", "wf-loaded-old"}
+	if len(ids) != len(want) {
+		t.Fatalf("workflows = %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("workflows = %v, want %v", ids, want)
+		}
+	}
+	first := overview.Workflows[0]
+	if first.ProjectID != loaded.ID || first.ProjectName != "loaded" || !first.Running || first.StartedAt == nil {
+		t.Errorf("first workflow = %+v", first)
+	}
+	if overview.Workflows[1].ProjectID != idle.ID || overview.Workflows[1].Running {
+		t.Errorf("idle workflow = %+v", overview.Workflows[1])
+	}
+
+	if pool.Size() != 1 || pool.IsLoaded(idle.ID) {
+		t.Errorf("overview loaded projects into the pool: %v", pool.GetActiveProjects())
+	}
+	if _, accessesAfter, _ := pool.GetContextInfo(loaded.ID); accessesAfter != accessesBefore {
+		t.Errorf("overview recorded an access: %d -> %d", accessesBefore, accessesAfter)
+	}
+
+	running, err := CollectOverview(ctx, registry, pool, OverviewOptions{RunningOnly: true})
+	if err != nil {
+		t.Fatalf("CollectOverview(running) error = %v", err)
+	}
+	if len(running.Workflows) != 1 || running.Workflows[0].WorkflowID != "wf-loaded-new" {
+		t.Errorf("running workflows = %+v", running.Workflows)
+	}
+
+	limited, err := CollectOverview(ctx, registry, nil, OverviewOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("CollectOverview(nil pool) error = %v", err)
+	}
+	if len(limited.Workflows) != 2 || limited.Workflows[0].WorkflowID != "wf-loaded-new" {
+		t.Errorf("limited workflows = %+v", limited.Workflows)
+	}
+}
+
+func TestCollectOverview_ReportsUnreadableProjects(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	registry := newMockRegistry()
+	p, _ := registry.AddProject(ctx, filepath.Join(t.TempDir(), "gone"), nil)
+	p.Status = StatusOffline
+	p.StatusMessage = "directory not found"
+	_ = registry.UpdateProject(ctx, p)
+
+	overview, err := CollectOverview(ctx, registry, nil, OverviewOptions{})
+	if err != nil {
+		t.Fatalf("CollectOverview() error = %v", err)
+	}
+	if len(overview.Errors) != 1 || overview.Errors[0].ProjectID != p.ID {
+		t.Errorf("Errors = %+v", overview.Errors)
+	}
+	if len(overview.Workflows) != 0 {
+		t.Errorf("Workflows = %+v", overview.Workflows)
+	}
+}
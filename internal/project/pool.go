@@ -34,6 +34,7 @@ type poolOptions struct {
 	minActiveContexts   int
 	evictionGracePeriod time.Duration
 	eventBufferSize     int
+	watchConfig         bool
 }
 
 // PoolOption configures a StatePool
@@ -82,6 +83,14 @@ func WithPoolEventBufferSize(size int) PoolOption {
 	}
 }
 
+// WithPoolConfigWatch makes new contexts watch their config file and reload it
+// on change (see ProjectContext.ReloadConfig).
+func WithPoolConfigWatch(enabled bool) PoolOption {
+	return func(o *poolOptions) {
+		o.watchConfig = enabled
+	}
+}
+
 // poolEntry wraps a ProjectContext with management metadata
 type poolEntry struct {
 	context      *ProjectContext
@@ -234,6 +243,7 @@ func (p *StatePool) GetContext(ctx context.Context, projectID string) (*ProjectC
 		WithContextLogger(p.logger),
 		WithEventBufferSize(p.opts.eventBufferSize),
 		WithConfigMode(project.ConfigMode),
+		WithConfigWatch(p.opts.watchConfig),
 	)
 	if err != nil {
 		atomic.AddInt64(&p.errors, 1)
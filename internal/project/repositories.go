@@ -0,0 +1,71 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// ResolveWorkflowRepositories resolves the registered projects a
+// multi-repository workflow changes besides the one at primaryPath. Each ref
+// is a project ID, name (case-insensitive) or path.
+func ResolveWorkflowRepositories(ctx context.Context, registry Registry, refs []string, primaryPath string) ([]core.WorkflowRepository, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	projects, err := registry.ListProjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing projects: %w", err)
+	}
+
+	primary := filepath.Clean(primaryPath)
+	repos := make([]core.WorkflowRepository, 0, len(refs))
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			continue
+		}
+		p := findProject(projects, ref)
+		if p == nil {
+			return nil, fmt.Errorf("repository %q: %w", ref, ErrProjectNotFound)
+		}
+		if filepath.Clean(p.Path) == primary {
+			return nil, fmt.Errorf("repository %q is the workflow's own project", ref)
+		}
+		if !p.IsAccessible() {
+			return nil, fmt.Errorf("repository %q is %s: %s", ref, p.Status, p.StatusMessage)
+		}
+		if seen[strings.ToLower(p.Name)] {
+			return nil, fmt.Errorf("repository %q is declared twice", p.Name)
+		}
+		seen[strings.ToLower(p.Name)] = true
+		repos = append(repos, core.WorkflowRepository{Name: p.Name, ProjectID: p.ID, Path: p.Path})
+	}
+	return repos, nil
+}
+
+// findProject returns the project with the given ID, name or path.
+func findProject(projects []*Project, ref string) *Project {
+	for _, p := range projects {
+		if p.ID == ref {
+			return p
+		}
+	}
+	for _, p := range projects {
+		if strings.EqualFold(p.Name, ref) {
+			return p
+		}
+	}
+	if abs, err := filepath.Abs(ref); err == nil {
+		for _, p := range projects {
+			if filepath.Clean(p.Path) == abs {
+				return p
+			}
+		}
+	}
+	return nil
+}
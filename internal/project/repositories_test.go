@@ -0,0 +1,42 @@
+package project
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestResolveWorkflowRepositories(t *testing.T) {
+	t.Parallel()
+
+	registry := newMockRegistry()
+	registry.projects["proj-api"] = &Project{ID: "proj-api", Name: "api", Path: "/src/api", Status: StatusHealthy}
+	registry.projects["proj-web"] = &Project{ID: "proj-web", Name: "Web", Path: "/src/web", Status: StatusHealthy}
+	registry.projects["proj-old"] = &Project{ID: "proj-old", Name: "old", Path: "/src/old", Status: StatusOffline}
+	ctx := context.Background()
+
+	repos, err := ResolveWorkflowRepositories(ctx, registry, []string{"web", "/src/api"}, "/src/core")
+	if err != nil {
+		t.Fatalf("ResolveWorkflowRepositories() error = %v", err)
+	}
+	if len(repos) != 2 || repos[0].ProjectID != "proj-web" || repos[0].Name != "Web" || repos[1].Path != "/src/api" {
+		t.Errorf("ResolveWorkflowRepositories() = %+v", repos)
+	}
+
+	tests := []struct {
+		name string
+		refs []string
+		want string
+	}{
+		{"unknown", []string{"mobile"}, "not found"},
+		{"primary", []string{"proj-api"}, "own project"},
+		{"offline", []string{"old"}, "offline"},
+		{"duplicate", []string{"web", "proj-web"}, "twice"},
+	}
+	for _, tt := range tests {
+		_, err := ResolveWorkflowRepositories(ctx, registry, tt.refs, "/src/api")
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package runqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// Dispatcher defaults.
+const (
+	DefaultLeaseTTL     = time.Minute
+	DefaultPollInterval = 2 * time.Second
+	// maxAttempts is the number of times a run that fails to start is
+	// retried before its item is dropped.
+	maxAttempts = 3
+	// retryDelay is multiplied by the attempt number.
+	retryDelay = 15 * time.Second
+)
+
+// ErrNotRunnable is returned by an Executor for a workflow that can never be
+// started from the queue, such as a completed or deleted workflow. Its item
+// is dropped instead of retried.
+var ErrNotRunnable = errors.New("workflow cannot run")
+
+// Executor starts the runs of leased items.
+type Executor interface {
+	// Start starts the workflow in its project and returns a channel closed
+	// when the run ends.
+	Start(ctx context.Context, projectID string, workflowID core.WorkflowID) (<-chan struct{}, error)
+	// Healthy reports whether the run's heartbeat is current.
+	Healthy(workflowID core.WorkflowID) bool
+}
+
+// DispatcherOptions configures a Dispatcher.
+type DispatcherOptions struct {
+	Store    *Store
+	Executor Executor
+	// Slots and ProjectLimit bound the runs executing at once (see
+	// LeaseOptions).
+	Slots        int
+	ProjectLimit int
+	// LeaseTTL defaults to DefaultLeaseTTL; leases are renewed every third
+	// of it.
+	LeaseTTL time.Duration
+	// PollInterval defaults to DefaultPollInterval.
+	PollInterval time.Duration
+	Logger       *slog.Logger
+}
+
+// Dispatcher leases queued items and runs them through an Executor while
+// their slot is held.
+type Dispatcher struct {
+	store    *Store
+	executor Executor
+	lease    LeaseOptions
+	poll     time.Duration
+	logger   *slog.Logger
+
+	wake   chan struct{}
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewDispatcher creates a dispatcher. Each dispatcher holds its leases under
+// its own owner ID, so the leases of a previous server process expire and
+// their runs are queued again.
+func NewDispatcher(opts DispatcherOptions) *Dispatcher {
+	if opts.LeaseTTL <= 0 {
+		opts.LeaseTTL = DefaultLeaseTTL
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	if opts.Slots < 1 {
+		opts.Slots = 1
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+	host, _ := os.Hostname()
+	return &Dispatcher{
+		store:    opts.Store,
+		executor: opts.Executor,
+		lease: LeaseOptions{
+			Owner:        fmt.Sprintf("%s:%d:%s", host, os.Getpid(), uuid.New().String()[:8]),
+			TTL:          opts.LeaseTTL,
+			Slots:        opts.Slots,
+			ProjectLimit: opts.ProjectLimit,
+		},
+		poll:   opts.PollInterval,
+		logger: opts.Logger,
+		wake:   make(chan struct{}, 1),
+		stopCh: make(chan struct{}),
+	}
+}
+
+// Store returns the queue the dispatcher leases from.
+func (d *Dispatcher) Store() *Store {
+	return d.store
+}
+
+// Enqueue adds a run to the queue and wakes the dispatcher.
+func (d *Dispatcher) Enqueue(ctx context.Context, projectID, workflowID string, priority int) (*Item, error) {
+	item, err := d.store.Enqueue(ctx, projectID, workflowID, priority)
+	if err == nil {
+		d.Notify()
+	}
+	return item, err
+}
+
+// Notify makes the dispatcher look for runnable items now, after the queue
+// changed.
+func (d *Dispatcher) Notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start runs the dispatch loop until Stop.
+func (d *Dispatcher) Start(ctx context.Context) {
+	d.wg.Add(1)
+	go d.loop(ctx)
+	d.logger.Info("run queue started",
+		slog.String("owner", d.lease.Owner), slog.Int("slots", d.lease.Slots),
+		slog.Int("project_limit", d.lease.ProjectLimit))
+}
+
+// Stop ends the dispatch loop and stops renewing leases. Runs still
+// executing keep their items, which are queued again when the leases expire.
+func (d *Dispatcher) Stop() {
+	close(d.stopCh)
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) loop(ctx context.Context) {
+	defer d.wg.Done()
+	ticker := time.NewTicker(d.poll)
+	defer ticker.Stop()
+	for {
+		d.dispatch(ctx)
+		select {
+		case <-d.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// dispatch requeues expired leases and starts queued items while slots are
+// free.
+func (d *Dispatcher) dispatch(ctx context.Context) {
+	expired, err := d.store.RequeueExpired(ctx)
+	if err != nil {
+		d.logger.Warn("failed to requeue expired leases", slog.String("error", err.Error()))
+	}
+	for _, item := range expired {
+		d.logger.Warn("run lease expired, queued again",
+			slog.String("workflow_id", item.WorkflowID), slog.String("project_id", item.ProjectID),
+			slog.String("owner", item.LeaseOwner))
+	}
+
+	for {
+		item, err := d.store.Lease(ctx, d.lease)
+		if err != nil {
+			d.logger.Warn("failed to lease queued run", slog.String("error", err.Error()))
+			return
+		}
+		if item == nil {
+			return
+		}
+		d.start(ctx, item)
+	}
+}
+
+// start starts the run of a leased item and holds its lease until the run
+// ends.
+func (d *Dispatcher) start(ctx context.Context, item *Item) {
+	logger := d.logger.With(slog.String("workflow_id", item.WorkflowID), slog.String("project_id", item.ProjectID))
+	done, err := d.executor.Start(ctx, item.ProjectID, core.WorkflowID(item.WorkflowID))
+	if err != nil {
+		if errors.Is(err, ErrNotRunnable) || item.Attempts >= maxAttempts {
+			logger.Error("dropping queued run", slog.Int("attempts", item.Attempts), slog.String("error", err.Error()))
+			if err := d.store.Complete(ctx, item.ID, d.lease.Owner); err != nil {
+				logger.Warn("failed to drop queued run", slog.String("error", err.Error()))
+			}
+			return
+		}
+		delay := time.Duration(item.Attempts) * retryDelay
+		logger.Warn("queued run failed to start, retrying",
+			slog.Int("attempts", item.Attempts), slog.Duration("delay", delay), slog.String("error", err.Error()))
+		if err := d.store.Release(ctx, item.ID, d.lease.Owner, err.Error(), delay); err != nil {
+			logger.Warn("failed to release queued run", slog.String("error", err.Error()))
+		}
+		return
+	}
+	logger.Info("queued run started", slog.Int("attempts", item.Attempts))
+
+	d.wg.Add(1)
+	go d.hold(ctx, item, done, logger)
+}
+
+// hold renews the lease of a running item while its heartbeat is healthy and
+// removes the item when the run ends. A run whose heartbeat goes stale loses
+// its lease, and its item is queued again once the zombie detector has
+// stopped it.
+func (d *Dispatcher) hold(ctx context.Context, item *Item, done <-chan struct{}, logger *slog.Logger) {
+	defer d.wg.Done()
+	ticker := time.NewTicker(d.lease.TTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		case <-done:
+			if err := d.store.Complete(context.WithoutCancel(ctx), item.ID, d.lease.Owner); err != nil {
+				logger.Warn("failed to complete queued run", slog.String("error", err.Error()))
+			}
+			d.Notify()
+			return
+		case <-ticker.C:
+			if !d.executor.Healthy(core.WorkflowID(item.WorkflowID)) {
+				logger.Warn("queued run heartbeat is stale, not renewing its lease")
+				continue
+			}
+			if err := d.store.Renew(ctx, item.ID, d.lease.Owner, d.lease.TTL); err != nil {
+				logger.Warn("failed to renew run lease", slog.String("error", err.Error()))
+				if errors.Is(err, ErrLeaseLost) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package runqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// fakeExecutor records started runs and ends them on demand.
+type fakeExecutor struct {
+	mu      sync.Mutex
+	started []string
+	done    map[string]chan struct{}
+	fail    map[string]error
+}
+
+func newFakeExecutor() *fakeExecutor {
+	return &fakeExecutor{done: map[string]chan struct{}{}, fail: map[string]error{}}
+}
+
+func (e *fakeExecutor) Start(_ context.Context, _ string, workflowID core.WorkflowID) (<-chan struct{}, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := e.fail[string(workflowID)]; err != nil {
+		return nil, err
+	}
+	e.started = append(e.started, string(workflowID))
+	done := make(chan struct{})
+	e.done[string(workflowID)] = done
+	return done, nil
+}
+
+func (e *fakeExecutor) Healthy(core.WorkflowID) bool { return true }
+
+func (e *fakeExecutor) finish(workflowID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	close(e.done[workflowID])
+}
+
+func (e *fakeExecutor) startedRuns() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.started...)
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDispatcher_RunsQueueWithinSlots(t *testing.T) {
+	t.Parallel()
+	s, _ := openTestStore(t)
+	ctx := context.Background()
+	executor := newFakeExecutor()
+	executor.fail["wf-gone"] = ErrNotRunnable
+	d := NewDispatcher(DispatcherOptions{Store: s, Executor: executor, Slots: 1, PollInterval: time.Hour})
+	d.Start(ctx)
+	defer d.Stop()
+
+	for _, id := range []string{"wf-gone", "wf-1", "wf-2"} {
+		if _, err := d.Enqueue(ctx, "", id, 0); err != nil {
+			t.Fatalf("Enqueue(%s) error = %v", id, err)
+		}
+	}
+	waitFor(t, "wf-1 to start", func() bool { return len(executor.startedRuns()) == 1 })
+
+	items, _ := s.List(ctx)
+	if ids := workflowIDs(items); len(ids) != 2 || ids[0] != "wf-1" || items[0].State != StateLeased {
+		t.Fatalf("queue = %v, want wf-1 running and wf-2 queued (wf-gone dropped)", ids)
+	}
+
+	// Ending the run frees the slot for wf-2.
+	executor.finish("wf-1")
+	waitFor(t, "wf-2 to start", func() bool { return len(executor.startedRuns()) == 2 })
+	if started := executor.startedRuns(); started[1] != "wf-2" {
+		t.Errorf("started = %v, want wf-2 second", started)
+	}
+	items, _ = s.List(ctx)
+	if ids := workflowIDs(items); len(ids) != 1 || ids[0] != "wf-2" {
+		t.Errorf("queue = %v, want only wf-2", ids)
+	}
+}
+
+func TestDispatcher_RetriesRunsThatFailToStart(t *testing.T) {
+	t.Parallel()
+	s, _ := openTestStore(t)
+	ctx := context.Background()
+	executor := newFakeExecutor()
+	executor.fail["wf-1"] = errors.New("workflow is already running")
+	d := NewDispatcher(DispatcherOptions{Store: s, Executor: executor, Slots: 1, PollInterval: time.Hour})
+
+	item, _ := d.Enqueue(ctx, "", "wf-1", 0)
+	d.dispatch(ctx)
+	retried, err := s.Get(ctx, item.ID)
+	if err != nil || retried.State != StateQueued || retried.NotBefore == nil || retried.LastError == "" {
+		t.Fatalf("item after a failed start = %+v, %v, want it queued with a delay", retried, err)
+	}
+
+	// After maxAttempts the item is dropped.
+	for i := 1; i < maxAttempts; i++ {
+		if _, err := s.db.Exec("UPDATE queue_items SET not_before = NULL"); err != nil {
+			t.Fatal(err)
+		}
+		d.dispatch(ctx)
+	}
+	if _, err := s.Get(ctx, item.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after %d failed starts error = %v, want ErrNotFound", maxAttempts, err)
+	}
+}
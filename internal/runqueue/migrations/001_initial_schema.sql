@@ -0,0 +1,19 @@
+-- Run queue: one row per queued or leased workflow run.
+-- not_before and lease_expires_at are Unix times in milliseconds.
+CREATE TABLE IF NOT EXISTS queue_items (
+    id TEXT PRIMARY KEY,
+    project_id TEXT NOT NULL,
+    workflow_id TEXT NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 0,
+    seq INTEGER NOT NULL,
+    state TEXT NOT NULL,
+    enqueued_at TEXT NOT NULL,
+    not_before INTEGER,
+    lease_owner TEXT,
+    lease_expires_at INTEGER,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    UNIQUE (project_id, workflow_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_queue_items_order ON queue_items(state, priority DESC, seq);
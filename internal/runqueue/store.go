@@ -0,0 +1,577 @@
+// Package runqueue is the durable queue of workflow runs started by quorum
+// serve. Runs wait in a SQLite database, ordered by priority, until a slot is
+// free; a running item holds a lease that its runner renews while the
+// workflow's heartbeat is current, and an item whose lease expires (the
+// server stopped or the run hung) is queued again and resumed.
+package runqueue
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	_ "modernc.org/sqlite"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
+)
+
+//go:embed migrations/001_initial_schema.sql
+var migrationV1 string
+
+// dbFilename is the queue database created next to the project registry.
+const dbFilename = "run-queue.db"
+
+// State is the state of a queue item.
+type State string
+
+// Item states.
+const (
+	// StateQueued items wait for a slot.
+	StateQueued State = "queued"
+	// StateLeased items are running under a lease.
+	StateLeased State = "leased"
+)
+
+var (
+	// ErrNotFound is returned when a queue item does not exist.
+	ErrNotFound = errors.New("queue item not found")
+	// ErrAlreadyQueued is returned when the workflow already has a queue item.
+	ErrAlreadyQueued = errors.New("workflow is already queued")
+	// ErrLeased is returned when changing an item that is running.
+	ErrLeased = errors.New("queue item is running")
+	// ErrLeaseLost is returned when the lease of an item is no longer held
+	// by the caller.
+	ErrLeaseLost = errors.New("queue lease lost")
+)
+
+// Item is a workflow run in the queue.
+type Item struct {
+	ID         string `json:"id"`
+	ProjectID  string `json:"project_id,omitempty"`
+	WorkflowID string `json:"workflow_id"`
+	// Priority orders the queue, highest first; items of equal priority run
+	// in queue order.
+	Priority int   `json:"priority"`
+	State    State `json:"state"`
+	// Position is the 1-based place of a queued item in the queue (0 when
+	// leased).
+	Position   int       `json:"position"`
+	EnqueuedAt time.Time `json:"enqueued_at"`
+	// NotBefore delays an item whose run failed to start.
+	NotBefore      *time.Time `json:"not_before,omitempty"`
+	LeaseOwner     string     `json:"lease_owner,omitempty"`
+	LeaseExpiresAt *time.Time `json:"lease_expires_at,omitempty"`
+	// Attempts counts the leases taken on the item.
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"last_error,omitempty"`
+
+	seq int64
+}
+
+// LeaseOptions limits the items Lease may hand out.
+type LeaseOptions struct {
+	// Owner identifies the runner taking the lease.
+	Owner string
+	// TTL is how long the lease lasts without renewal.
+	TTL time.Duration
+	// Slots is the number of leases held at once across all projects.
+	Slots int
+	// ProjectLimit is the number of leases held at once in one project
+	// (0 = no limit).
+	ProjectLimit int
+}
+
+// Store is the SQLite run queue.
+type Store struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+// DefaultPath returns the queue database next to the project registry.
+func DefaultPath() (string, error) {
+	registryPath, err := project.DefaultRegistryPath()
+	if err != nil {
+		return "", fmt.Errorf("resolving registry path: %w", err)
+	}
+	return filepath.Join(filepath.Dir(registryPath), dbFilename), nil
+}
+
+// Open opens, creating if needed, the queue database at dbPath.
+func Open(dbPath string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0o750); err != nil {
+		return nil, fmt.Errorf("creating run queue directory: %w", err)
+	}
+	// Immediate transactions serialize concurrent writers (CLI and server).
+	db, err := sql.Open("sqlite", dbPath+"?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)&_txlock=immediate")
+	if err != nil {
+		return nil, fmt.Errorf("opening run queue: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	s := &Store{db: db, now: time.Now}
+	if err := s.migrate(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("running run queue migrations: %w", err)
+	}
+	return s, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) migrate() error {
+	if _, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		applied_at TEXT NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("creating migrations table: %w", err)
+	}
+	var current int
+	if err := s.db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&current); err != nil {
+		return fmt.Errorf("checking schema version: %w", err)
+	}
+
+	migrations := []string{migrationV1}
+	for i, migration := range migrations {
+		version := i + 1
+		if version <= current {
+			continue
+		}
+		tx, err := s.db.Begin()
+		if err != nil {
+			return fmt.Errorf("beginning migration transaction: %w", err)
+		}
+		for _, stmt := range strings.Split(migration, ";") {
+			if strings.TrimSpace(stmt) == "" {
+				continue
+			}
+			if _, err := tx.Exec(stmt); err != nil {
+				_ = tx.Rollback()
+				return fmt.Errorf("executing migration v%d: %w", version, err)
+			}
+		}
+		if _, err := tx.Exec("INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)",
+			version, time.Now().UTC().Format(time.RFC3339)); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("recording migration v%d: %w", version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("committing migration v%d: %w", version, err)
+		}
+	}
+	return nil
+}
+
+const itemColumns = `id, project_id, workflow_id, priority, seq, state, enqueued_at,
+	not_before, lease_owner, lease_expires_at, attempts, last_error`
+
+// queueOrder orders queued items by priority, then by queue order.
+const queueOrder = "priority DESC, seq"
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanItem(row rowScanner) (*Item, error) {
+	var (
+		item                 Item
+		state, enqueuedAt    string
+		notBefore, expiresAt sql.NullInt64
+		owner, lastError     sql.NullString
+	)
+	if err := row.Scan(&item.ID, &item.ProjectID, &item.WorkflowID, &item.Priority, &item.seq, &state,
+		&enqueuedAt, &notBefore, &owner, &expiresAt, &item.Attempts, &lastError); err != nil {
+		return nil, err
+	}
+	item.State = State(state)
+	item.EnqueuedAt, _ = time.Parse(time.RFC3339Nano, enqueuedAt)
+	item.NotBefore = fromMillis(notBefore)
+	item.LeaseOwner = owner.String
+	item.LeaseExpiresAt = fromMillis(expiresAt)
+	item.LastError = lastError.String
+	return &item, nil
+}
+
+func fromMillis(v sql.NullInt64) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	t := time.UnixMilli(v.Int64).UTC()
+	return &t
+}
+
+// querier is satisfied by *sql.DB and *sql.Tx.
+type querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func getItem(ctx context.Context, q querier, id string) (*Item, error) {
+	item, err := scanItem(q.QueryRowContext(ctx, "SELECT "+itemColumns+" FROM queue_items WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading queue item: %w", err)
+	}
+	if err := setPosition(ctx, q, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// setPosition sets the place of a queued item in the queue.
+func setPosition(ctx context.Context, q querier, item *Item) error {
+	item.Position = 0
+	if item.State != StateQueued {
+		return nil
+	}
+	var ahead int
+	if err := q.QueryRowContext(ctx, `SELECT COUNT(*) FROM queue_items
+		WHERE state = ? AND (priority > ? OR (priority = ? AND seq < ?))`,
+		StateQueued, item.Priority, item.Priority, item.seq).Scan(&ahead); err != nil {
+		return fmt.Errorf("computing queue position: %w", err)
+	}
+	item.Position = ahead + 1
+	return nil
+}
+
+// Enqueue adds a run of the workflow to the end of its priority in the
+// queue. When the workflow already has an item, that item is returned with
+// ErrAlreadyQueued.
+func (s *Store) Enqueue(ctx context.Context, projectID, workflowID string, priority int) (*Item, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning run queue transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var existingID string
+	err = tx.QueryRowContext(ctx, "SELECT id FROM queue_items WHERE project_id = ? AND workflow_id = ?",
+		projectID, workflowID).Scan(&existingID)
+	if err == nil {
+		item, err := getItem(ctx, tx, existingID)
+		if err != nil {
+			return nil, err
+		}
+		return item, ErrAlreadyQueued
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("checking queue: %w", err)
+	}
+
+	id := uuid.New().String()
+	if _, err := tx.ExecContext(ctx, `INSERT INTO queue_items
+		(id, project_id, workflow_id, priority, seq, state, enqueued_at)
+		VALUES (?, ?, ?, ?, (SELECT COALESCE(MAX(seq), 0) + 1 FROM queue_items), ?, ?)`,
+		id, projectID, workflowID, priority, StateQueued, s.now().UTC().Format(time.RFC3339Nano)); err != nil {
+		return nil, fmt.Errorf("inserting queue item: %w", err)
+	}
+	item, err := getItem(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing queue item: %w", err)
+	}
+	return item, nil
+}
+
+// Get returns a queue item.
+func (s *Store) Get(ctx context.Context, id string) (*Item, error) {
+	return getItem(ctx, s.db, id)
+}
+
+// List returns the leased items followed by the queued items in queue order.
+func (s *Store) List(ctx context.Context) ([]*Item, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+itemColumns+` FROM queue_items
+		ORDER BY CASE state WHEN ? THEN 0 ELSE 1 END, `+queueOrder, StateLeased)
+	if err != nil {
+		return nil, fmt.Errorf("listing queue: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*Item
+	position := 0
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning queue item: %w", err)
+		}
+		if item.State == StateQueued {
+			position++
+			item.Position = position
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// Lease hands the first queued item that fits the slot limits to
+// opts.Owner. It returns nil when no item can run now.
+func (s *Store) Lease(ctx context.Context, opts LeaseOptions) (*Item, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning run queue transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := s.now()
+	running := map[string]int{}
+	total := 0
+	rows, err := tx.QueryContext(ctx, `SELECT project_id, COUNT(*) FROM queue_items
+		WHERE state = ? AND lease_expires_at > ? GROUP BY project_id`, StateLeased, now.UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("counting leases: %w", err)
+	}
+	for rows.Next() {
+		var projectID string
+		var n int
+		if err := rows.Scan(&projectID, &n); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("counting leases: %w", err)
+		}
+		running[projectID] = n
+		total += n
+	}
+	rows.Close()
+	if total >= opts.Slots {
+		return nil, nil
+	}
+
+	rows, err = tx.QueryContext(ctx, "SELECT "+itemColumns+` FROM queue_items
+		WHERE state = ? AND (not_before IS NULL OR not_before <= ?)
+		ORDER BY `+queueOrder, StateQueued, now.UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("listing queue: %w", err)
+	}
+	var next *Item
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning queue item: %w", err)
+		}
+		if opts.ProjectLimit <= 0 || running[item.ProjectID] < opts.ProjectLimit {
+			next = item
+			break
+		}
+	}
+	rows.Close()
+	if next == nil {
+		return nil, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE queue_items
+		SET state = ?, lease_owner = ?, lease_expires_at = ?, not_before = NULL, attempts = attempts + 1
+		WHERE id = ?`, StateLeased, opts.Owner, now.Add(opts.TTL).UnixMilli(), next.ID); err != nil {
+		return nil, fmt.Errorf("leasing queue item: %w", err)
+	}
+	item, err := getItem(ctx, tx, next.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing lease: %w", err)
+	}
+	return item, nil
+}
+
+// updateLeased runs an update on an item leased by owner, returning
+// ErrLeaseLost when the item is gone, queued again or leased by another
+// owner.
+func (s *Store) updateLeased(ctx context.Context, id, owner, query string, args ...any) error {
+	res, err := s.db.ExecContext(ctx, query+" WHERE id = ? AND state = ? AND lease_owner = ?",
+		append(args, id, StateLeased, owner)...)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+// Renew extends the lease of an item held by owner.
+func (s *Store) Renew(ctx context.Context, id, owner string, ttl time.Duration) error {
+	return s.updateLeased(ctx, id, owner, "UPDATE queue_items SET lease_expires_at = ?",
+		s.now().Add(ttl).UnixMilli())
+}
+
+// Complete removes an item whose run ended.
+func (s *Store) Complete(ctx context.Context, id, owner string) error {
+	return s.updateLeased(ctx, id, owner, "DELETE FROM queue_items")
+}
+
+// Release puts a leased item back in its place in the queue, where it waits
+// for delay before it can be leased again.
+func (s *Store) Release(ctx context.Context, id, owner, reason string, delay time.Duration) error {
+	return s.updateLeased(ctx, id, owner, `UPDATE queue_items
+		SET state = ?, lease_owner = NULL, lease_expires_at = NULL, not_before = ?, last_error = ?`,
+		StateQueued, s.now().Add(delay).UnixMilli(), reason)
+}
+
+// RequeueExpired puts the items whose lease expired back in their place in
+// the queue and returns them.
+func (s *Store) RequeueExpired(ctx context.Context) ([]*Item, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning run queue transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, "SELECT "+itemColumns+` FROM queue_items
+		WHERE state = ? AND lease_expires_at <= ? ORDER BY `+queueOrder, StateLeased, s.now().UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("listing expired leases: %w", err)
+	}
+	var expired []*Item
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning queue item: %w", err)
+		}
+		expired = append(expired, item)
+	}
+	rows.Close()
+
+	for _, item := range expired {
+		if _, err := tx.ExecContext(ctx, `UPDATE queue_items
+			SET state = ?, lease_owner = NULL, lease_expires_at = NULL, last_error = ?
+			WHERE id = ?`, StateQueued, "lease of "+item.LeaseOwner+" expired", item.ID); err != nil {
+			return nil, fmt.Errorf("requeueing queue item: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing requeue: %w", err)
+	}
+	return expired, nil
+}
+
+// SetPriority changes the priority of a queued item.
+func (s *Store) SetPriority(ctx context.Context, id string, priority int) (*Item, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning run queue transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	item, err := getItem(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if item.State != StateQueued {
+		return nil, ErrLeased
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE queue_items SET priority = ? WHERE id = ?", priority, id); err != nil {
+		return nil, fmt.Errorf("updating queue item: %w", err)
+	}
+	if item, err = getItem(ctx, tx, id); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing queue item: %w", err)
+	}
+	return item, nil
+}
+
+// Move places a queued item at the 1-based position in the queue. The item
+// takes the priority of the items around its new place, so the queue stays
+// ordered by priority.
+func (s *Store) Move(ctx context.Context, id string, position int) (*Item, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning run queue transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	moved, err := getItem(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if moved.State != StateQueued {
+		return nil, ErrLeased
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT "+itemColumns+" FROM queue_items WHERE state = ? AND id != ? ORDER BY "+queueOrder,
+		StateQueued, id)
+	if err != nil {
+		return nil, fmt.Errorf("listing queue: %w", err)
+	}
+	var queued []*Item
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning queue item: %w", err)
+		}
+		queued = append(queued, item)
+	}
+	rows.Close()
+
+	index := min(max(position, 1), len(queued)+1) - 1
+	switch {
+	case index < len(queued):
+		moved.Priority = queued[index].Priority
+	case index > 0:
+		moved.Priority = queued[index-1].Priority
+	}
+	queued = append(queued[:index], append([]*Item{moved}, queued[index:]...)...)
+
+	// Renumber the queued items in their new order; leased items keep
+	// their numbers, which only matter once they are queued again.
+	var base int64
+	if err := tx.QueryRowContext(ctx, "SELECT COALESCE(MAX(seq), 0) FROM queue_items").Scan(&base); err != nil {
+		return nil, fmt.Errorf("renumbering queue: %w", err)
+	}
+	for i, item := range queued {
+		if _, err := tx.ExecContext(ctx, "UPDATE queue_items SET seq = ?, priority = ? WHERE id = ?",
+			base+int64(i)+1, item.Priority, item.ID); err != nil {
+			return nil, fmt.Errorf("renumbering queue: %w", err)
+		}
+	}
+	if moved, err = getItem(ctx, tx, id); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing queue order: %w", err)
+	}
+	return moved, nil
+}
+
+// Remove deletes a queued item. Running items cannot be removed; cancel
+// their workflow instead.
+func (s *Store) Remove(ctx context.Context, id string) (*Item, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning run queue transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	item, err := getItem(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if item.State != StateQueued {
+		return nil, ErrLeased
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM queue_items WHERE id = ?", id); err != nil {
+		return nil, fmt.Errorf("removing queue item: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing queue removal: %w", err)
+	}
+	return item, nil
+}
@@ -0,0 +1,211 @@
+package runqueue
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testClock is a settable clock for lease expiry.
+type testClock struct{ t time.Time }
+
+func (c *testClock) now() time.Time { return c.t }
+
+func openTestStore(t *testing.T) (*Store, *testClock) {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	clock := &testClock{t: time.Now()}
+	s.now = clock.now
+	return s, clock
+}
+
+func enqueue(t *testing.T, s *Store, projectID, workflowID string, priority int) *Item {
+	t.Helper()
+	item, err := s.Enqueue(context.Background(), projectID, workflowID, priority)
+	if err != nil {
+		t.Fatalf("Enqueue(%s) error = %v", workflowID, err)
+	}
+	return item
+}
+
+func workflowIDs(items []*Item) []string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.WorkflowID
+	}
+	return ids
+}
+
+func assertOrder(t *testing.T, s *Store, want ...string) {
+	t.Helper()
+	items, err := s.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	got := workflowIDs(items)
+	if len(got) != len(want) {
+		t.Fatalf("List() = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("List() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestStore_OrdersByPriorityAndReorders(t *testing.T) {
+	t.Parallel()
+	s, _ := openTestStore(t)
+	ctx := context.Background()
+
+	a := enqueue(t, s, "p1", "wf-a", 0)
+	enqueue(t, s, "p1", "wf-b", 0)
+	c := enqueue(t, s, "p2", "wf-c", 5)
+	if c.Position != 1 || a.Position != 1 {
+		t.Errorf("positions at enqueue = %d, %d, want 1 and 1", a.Position, c.Position)
+	}
+	assertOrder(t, s, "wf-c", "wf-a", "wf-b")
+
+	if _, err := s.Enqueue(ctx, "p1", "wf-a", 9); !errors.Is(err, ErrAlreadyQueued) {
+		t.Errorf("Enqueue() of a queued workflow error = %v, want ErrAlreadyQueued", err)
+	}
+
+	if _, err := s.Move(ctx, "missing", 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Move() of a missing item error = %v, want ErrNotFound", err)
+	}
+
+	// Moving wf-b to the front gives it the priority of wf-c.
+	items, _ := s.List(ctx)
+	b, err := s.Move(ctx, items[2].ID, 1)
+	if err != nil {
+		t.Fatalf("Move() error = %v", err)
+	}
+	if b.Position != 1 || b.Priority != 5 {
+		t.Errorf("moved item = %+v, want position 1 with priority 5", b)
+	}
+	assertOrder(t, s, "wf-b", "wf-c", "wf-a")
+
+	if a, err = s.SetPriority(ctx, a.ID, 10); err != nil || a.Position != 1 {
+		t.Errorf("SetPriority() = %+v, %v, want position 1", a, err)
+	}
+	assertOrder(t, s, "wf-a", "wf-b", "wf-c")
+
+	if _, err := s.Remove(ctx, b.ID); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	assertOrder(t, s, "wf-a", "wf-c")
+}
+
+func TestStore_LeaseRespectsLimits(t *testing.T) {
+	t.Parallel()
+	s, _ := openTestStore(t)
+	ctx := context.Background()
+
+	enqueue(t, s, "p1", "wf-1", 0)
+	enqueue(t, s, "p1", "wf-2", 0)
+	enqueue(t, s, "p2", "wf-3", 0)
+	enqueue(t, s, "p2", "wf-4", 0)
+	opts := LeaseOptions{Owner: "server", TTL: time.Minute, Slots: 3, ProjectLimit: 1}
+
+	var leased []string
+	for {
+		item, err := s.Lease(ctx, opts)
+		if err != nil {
+			t.Fatalf("Lease() error = %v", err)
+		}
+		if item == nil {
+			break
+		}
+		if item.State != StateLeased || item.LeaseOwner != "server" || item.Attempts != 1 {
+			t.Errorf("leased item = %+v", item)
+		}
+		leased = append(leased, item.WorkflowID)
+	}
+	// One run per project, although a third slot is free.
+	if len(leased) != 2 || leased[0] != "wf-1" || leased[1] != "wf-3" {
+		t.Errorf("leased = %v, want [wf-1 wf-3]", leased)
+	}
+
+	items, _ := s.List(ctx)
+	if _, err := s.Remove(ctx, items[0].ID); !errors.Is(err, ErrLeased) {
+		t.Errorf("Remove() of a leased item error = %v, want ErrLeased", err)
+	}
+	if err := s.Complete(ctx, items[0].ID, "other"); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Complete() by another owner error = %v, want ErrLeaseLost", err)
+	}
+	if err := s.Complete(ctx, items[0].ID, "server"); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	next, err := s.Lease(ctx, opts)
+	if err != nil || next == nil || next.WorkflowID != "wf-2" {
+		t.Errorf("Lease() after completing wf-1 = %+v, %v, want wf-2", next, err)
+	}
+}
+
+func TestStore_RequeuesExpiredLeases(t *testing.T) {
+	t.Parallel()
+	s, clock := openTestStore(t)
+	ctx := context.Background()
+
+	enqueue(t, s, "p1", "wf-1", 0)
+	enqueue(t, s, "p1", "wf-2", 0)
+	opts := LeaseOptions{Owner: "old-server", TTL: time.Minute, Slots: 1}
+	item, err := s.Lease(ctx, opts)
+	if err != nil || item == nil {
+		t.Fatalf("Lease() = %+v, %v", item, err)
+	}
+
+	clock.t = clock.t.Add(40 * time.Second)
+	if err := s.Renew(ctx, item.ID, "old-server", time.Minute); err != nil {
+		t.Fatalf("Renew() error = %v", err)
+	}
+	clock.t = clock.t.Add(40 * time.Second)
+	if expired, err := s.RequeueExpired(ctx); err != nil || len(expired) != 0 {
+		t.Errorf("RequeueExpired() before expiry = %v, %v, want none", expired, err)
+	}
+
+	// The server stops renewing; the item goes back to the front of the queue.
+	clock.t = clock.t.Add(time.Minute)
+	expired, err := s.RequeueExpired(ctx)
+	if err != nil || len(expired) != 1 || expired[0].WorkflowID != "wf-1" {
+		t.Fatalf("RequeueExpired() = %v, %v, want wf-1", expired, err)
+	}
+	if err := s.Renew(ctx, item.ID, "old-server", time.Minute); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Renew() after expiry error = %v, want ErrLeaseLost", err)
+	}
+	requeued, err := s.Get(ctx, item.ID)
+	if err != nil || requeued.State != StateQueued || requeued.Position != 1 || requeued.LastError == "" {
+		t.Errorf("requeued item = %+v, %v", requeued, err)
+	}
+
+	opts.Owner = "new-server"
+	if next, err := s.Lease(ctx, opts); err != nil || next == nil || next.WorkflowID != "wf-1" || next.Attempts != 2 {
+		t.Errorf("Lease() by the new server = %+v, %v, want wf-1 on its second attempt", next, err)
+	}
+}
+
+func TestStore_ReleaseDelaysItem(t *testing.T) {
+	t.Parallel()
+	s, clock := openTestStore(t)
+	ctx := context.Background()
+
+	enqueue(t, s, "", "wf-1", 0)
+	opts := LeaseOptions{Owner: "server", TTL: time.Minute, Slots: 1}
+	item, _ := s.Lease(ctx, opts)
+	if err := s.Release(ctx, item.ID, "server", "boom", 30*time.Second); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if next, err := s.Lease(ctx, opts); err != nil || next != nil {
+		t.Errorf("Lease() during the delay = %+v, %v, want nothing", next, err)
+	}
+	clock.t = clock.t.Add(31 * time.Second)
+	if next, err := s.Lease(ctx, opts); err != nil || next == nil || next.LastError != "boom" {
+		t.Errorf("Lease() after the delay = %+v, %v, want the released item", next, err)
+	}
+}
@@ -0,0 +1,132 @@
+//go:build linux
+
+package sandbox
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// cgroupRoot is the cgroup v2 mount point.
+const cgroupRoot = "/sys/fs/cgroup"
+
+// cpuPeriod is the cpu.max period in microseconds.
+const cpuPeriod = 100000
+
+var cgroupSeq atomic.Int64
+
+// cgroup is a cgroup v2 sub-group holding one sandboxed process tree.
+type cgroup struct {
+	path string
+	fd   int
+}
+
+// newCgroup creates a sub-group of the current cgroup with the given limits.
+// It fails when cgroup v2 is not mounted or the current group is not
+// delegated to this user.
+func newCgroup(limits Limits) (*cgroup, error) {
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err != nil {
+		return nil, errors.New("cgroup v2 is not available")
+	}
+	self, err := currentCgroup()
+	if err != nil {
+		return nil, err
+	}
+	parent := filepath.Join(cgroupRoot, self)
+
+	var controllers []string
+	if limits.MemoryMB > 0 {
+		controllers = append(controllers, "memory")
+	}
+	if limits.CPUPercent > 0 {
+		controllers = append(controllers, "cpu")
+	}
+	if limits.MaxPids > 0 {
+		controllers = append(controllers, "pids")
+	}
+	// Best effort: fails when the parent has processes of its own, in which
+	// case the controllers must already be enabled by whoever delegated it.
+	for _, c := range controllers {
+		_ = os.WriteFile(filepath.Join(parent, "cgroup.subtree_control"), []byte("+"+c), 0)
+	}
+
+	path := filepath.Join(parent, fmt.Sprintf("quorum-sandbox-%d-%d", os.Getpid(), cgroupSeq.Add(1)))
+	if err := os.Mkdir(path, 0o755); err != nil {
+		return nil, fmt.Errorf("creating cgroup: %w", err)
+	}
+	cg := &cgroup{path: path, fd: -1}
+
+	available, _ := os.ReadFile(filepath.Join(path, "cgroup.controllers"))
+	for _, c := range controllers {
+		if !strings.Contains(" "+strings.TrimSpace(string(available))+" ", " "+c+" ") {
+			cg.remove()
+			return nil, fmt.Errorf("cgroup controller %s is not delegated", c)
+		}
+	}
+
+	settings := map[string]string{}
+	if limits.MemoryMB > 0 {
+		settings["memory.max"] = strconv.FormatInt(int64(limits.MemoryMB)*1024*1024, 10)
+		settings["memory.swap.max"] = "0"
+	}
+	if limits.CPUPercent > 0 {
+		settings["cpu.max"] = fmt.Sprintf("%d %d", limits.CPUPercent*cpuPeriod/100, cpuPeriod)
+	}
+	if limits.MaxPids > 0 {
+		settings["pids.max"] = strconv.Itoa(limits.MaxPids)
+	}
+	for file, value := range settings {
+		if err := os.WriteFile(filepath.Join(path, file), []byte(value), 0); err != nil && file != "memory.swap.max" {
+			cg.remove()
+			return nil, fmt.Errorf("setting %s: %w", file, err)
+		}
+	}
+
+	fd, err := unix.Open(path, unix.O_PATH|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		cg.remove()
+		return nil, fmt.Errorf("opening cgroup: %w", err)
+	}
+	cg.fd = fd
+	return cg, nil
+}
+
+// remove kills remaining processes and deletes the group.
+func (c *cgroup) remove() {
+	if c.fd >= 0 {
+		_ = unix.Close(c.fd)
+		c.fd = -1
+	}
+	_ = os.WriteFile(filepath.Join(c.path, "cgroup.kill"), []byte("1"), 0)
+	for i := 0; i < 20; i++ {
+		if err := os.Remove(c.path); err == nil || os.IsNotExist(err) {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// currentCgroup returns the cgroup v2 path of this process.
+func currentCgroup() (string, error) {
+	f, err := os.Open("/proc/self/cgroup")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if path, ok := strings.CutPrefix(sc.Text(), "0::"); ok {
+			return path, nil
+		}
+	}
+	return "", errors.New("cgroup v2 membership not found")
+}
@@ -0,0 +1,416 @@
+//go:build linux
+
+package sandbox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// initFailureExitCode is returned when the sandbox could not be set up.
+const initFailureExitCode = 125
+
+// MaybeRunInit turns the process into the sandbox init process when it was
+// started by Wrap, and never returns in that case. Otherwise it does nothing.
+func MaybeRunInit() {
+	if len(os.Args) < 2 || os.Args[1] != InitArg {
+		return
+	}
+	os.Exit(runInit())
+}
+
+func runInit() int {
+	// The mount setup, no_new_privs and the parent-death signal of the child
+	// are per-thread; keep them on one thread.
+	runtime.LockOSThread()
+
+	var spec initSpec
+	if err := json.Unmarshal([]byte(os.Getenv(specEnv)), &spec); err != nil {
+		fmt.Fprintf(os.Stderr, "quorum sandbox: invalid spec: %v\n", err)
+		return initFailureExitCode
+	}
+	_ = os.Unsetenv(specEnv)
+
+	if err := setupMounts(&spec); err != nil {
+		fmt.Fprintf(os.Stderr, "quorum sandbox: %v\n", err)
+		return initFailureExitCode
+	}
+	if spec.Network != NetworkHost {
+		if err := loopbackUp(); err != nil {
+			fmt.Fprintf(os.Stderr, "quorum sandbox: %v\n", err)
+			return initFailureExitCode
+		}
+	}
+	if spec.Network == NetworkAllowlist {
+		if err := startRelay(filepath.Join(spec.ProxyDir, proxySocketName)); err != nil {
+			fmt.Fprintf(os.Stderr, "quorum sandbox: %v\n", err)
+			return initFailureExitCode
+		}
+	}
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		fmt.Fprintf(os.Stderr, "quorum sandbox: setting no_new_privs: %v\n", err)
+		return initFailureExitCode
+	}
+
+	cmd := &exec.Cmd{
+		Path:   spec.Path,
+		Args:   spec.Args,
+		Dir:    spec.Dir,
+		Env:    os.Environ(),
+		Stdin:  os.Stdin,
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		// A nested user namespace locks the mounts set up above, so the
+		// command cannot remount or unmount them even as root.
+		SysProcAttr: &syscall.SysProcAttr{
+			Cloneflags:                 syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS,
+			UidMappings:                []syscall.SysProcIDMap{{ContainerID: spec.UID, HostID: 0, Size: 1}},
+			GidMappings:                []syscall.SysProcIDMap{{ContainerID: spec.GID, HostID: 0, Size: 1}},
+			GidMappingsEnableSetgroups: false,
+			Pdeathsig:                  syscall.SIGKILL,
+		},
+	}
+
+	// Termination signals sent to the init process are forwarded to the
+	// command; the init process outlives the command so its exit status is
+	// preserved.
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP)
+	defer signal.Stop(sigs)
+
+	if err := cmd.Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "quorum sandbox: starting %s: %v\n", spec.Path, err)
+		return 127
+	}
+	go func() {
+		for sig := range sigs {
+			_ = cmd.Process.Signal(sig)
+		}
+	}()
+	err := cmd.Wait()
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			return 128 + int(ws.Signal())
+		}
+		return exitErr.ExitCode()
+	}
+	fmt.Fprintf(os.Stderr, "quorum sandbox: %v\n", err)
+	return initFailureExitCode
+}
+
+// bindSource is a path that stays visible inside the sandbox, opened before
+// anything is hidden so it can be bound back later.
+type bindSource struct {
+	path     string
+	fd       int
+	isDir    bool
+	readOnly bool
+}
+
+// setupMounts builds the sandbox filesystem view:
+//
+//  1. every mount outside /proc, /sys and /dev becomes read-only;
+//  2. /home is covered by an empty mount and /tmp becomes a private tmpfs;
+//  3. the user's home is bound back read-only, and the working directory,
+//     the write paths and the proxy directory are bound back read-write;
+//  4. the deny paths are covered by empty read-only mounts.
+func setupMounts(spec *initSpec) error {
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("making mounts private: %w", err)
+	}
+
+	if resolved, err := filepath.EvalSymlinks(spec.Dir); err == nil {
+		spec.Dir = resolved
+	}
+
+	var sources []bindSource
+	addSource := func(path string, readOnly bool) error {
+		fd, err := unix.Open(path, unix.O_PATH|unix.O_CLOEXEC, 0)
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", path, err)
+		}
+		var st unix.Stat_t
+		if err := unix.Fstat(fd, &st); err != nil {
+			_ = unix.Close(fd)
+			return fmt.Errorf("stat %s: %w", path, err)
+		}
+		sources = append(sources, bindSource{path: path, fd: fd, isDir: st.Mode&unix.S_IFMT == unix.S_IFDIR, readOnly: readOnly})
+		return nil
+	}
+	defer func() {
+		for _, s := range sources {
+			_ = unix.Close(s.fd)
+		}
+	}()
+
+	if spec.Home != "" && strings.HasPrefix(spec.Home, "/home/") && !isDenied(spec.Home, spec.DenyPaths) {
+		if err := addSource(spec.Home, true); err != nil {
+			return err
+		}
+	}
+	if err := addSource(spec.Dir, false); err != nil {
+		return err
+	}
+	for _, p := range spec.WritePaths {
+		if isDenied(p, spec.DenyPaths) {
+			continue
+		}
+		if err := addSource(p, false); err != nil {
+			return err
+		}
+	}
+	if spec.ProxyDir != "" {
+		if err := addSource(spec.ProxyDir, false); err != nil {
+			return err
+		}
+	}
+
+	mountPoints, err := readMountPoints()
+	if err != nil {
+		return err
+	}
+	for _, mp := range mountPoints {
+		if isPseudoMount(mp) {
+			continue
+		}
+		if err := remount(mp, true); err != nil {
+			if errors.Is(err, unix.EACCES) || errors.Is(err, unix.ENOENT) {
+				continue // not reachable from here, nothing to protect
+			}
+			return fmt.Errorf("remounting %s read-only: %w", mp, err)
+		}
+	}
+
+	if _, err := os.Stat("/home"); err == nil {
+		if err := unix.Mount("tmpfs", "/home", "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, "mode=0755"); err != nil {
+			return fmt.Errorf("hiding /home: %w", err)
+		}
+	}
+	if err := unix.Mount("tmpfs", "/tmp", "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, "mode=1777"); err != nil {
+		return fmt.Errorf("mounting /tmp: %w", err)
+	}
+
+	// Parents first, so nested write paths are bound on top of the home.
+	sort.SliceStable(sources, func(i, j int) bool { return len(sources[i].path) < len(sources[j].path) })
+	for _, s := range sources {
+		if err := bindBack(s); err != nil {
+			return err
+		}
+	}
+	for _, p := range spec.DenyPaths {
+		if err := hidePath(p); err != nil {
+			return err
+		}
+	}
+
+	if _, err := os.Stat("/home"); err == nil {
+		if err := remount("/home", true); err != nil {
+			return fmt.Errorf("remounting /home read-only: %w", err)
+		}
+	}
+	return nil
+}
+
+// bindBack binds a source to its original path, creating the mount point
+// when the path is inside a hidden or fresh mount.
+func bindBack(s bindSource) error {
+	if _, err := os.Lstat(s.path); os.IsNotExist(err) {
+		if s.isDir {
+			err = os.MkdirAll(s.path, 0o755)
+		} else {
+			if err = os.MkdirAll(filepath.Dir(s.path), 0o755); err == nil {
+				var f *os.File
+				if f, err = os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY, 0o600); err == nil {
+					err = f.Close()
+				}
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("creating mount point %s: %w", s.path, err)
+		}
+	}
+	src := "/proc/self/fd/" + strconv.Itoa(s.fd)
+	if err := unix.Mount(src, s.path, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+		return fmt.Errorf("binding %s: %w", s.path, err)
+	}
+	if err := remount(s.path, s.readOnly); err != nil {
+		return fmt.Errorf("remounting %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// hidePath covers a directory with an empty read-only tmpfs and a file with
+// /dev/null.
+func hidePath(p string) error {
+	fi, err := os.Stat(p)
+	if err != nil {
+		return nil
+	}
+	if fi.IsDir() {
+		if err := unix.Mount("tmpfs", p, "tmpfs", unix.MS_RDONLY|unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, "mode=0755"); err != nil {
+			return fmt.Errorf("hiding %s: %w", p, err)
+		}
+		return nil
+	}
+	if err := unix.Mount("/dev/null", p, "", unix.MS_BIND, ""); err != nil {
+		return fmt.Errorf("hiding %s: %w", p, err)
+	}
+	return remount(p, true)
+}
+
+// remount changes the read-only flag of the mount at path. Flags the kernel
+// locks for mounts inherited from another user namespace are preserved.
+func remount(path string, readOnly bool) error {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return err
+	}
+	flags := uintptr(unix.MS_BIND | unix.MS_REMOUNT)
+	for _, f := range []struct{ st, ms uintptr }{
+		{unix.ST_NOSUID, unix.MS_NOSUID},
+		{unix.ST_NODEV, unix.MS_NODEV},
+		{unix.ST_NOEXEC, unix.MS_NOEXEC},
+		{unix.ST_NOATIME, unix.MS_NOATIME},
+		{unix.ST_NODIRATIME, unix.MS_NODIRATIME},
+		{unix.ST_RELATIME, unix.MS_RELATIME},
+	} {
+		if uintptr(st.Flags)&f.st != 0 {
+			flags |= f.ms
+		}
+	}
+	if readOnly {
+		flags |= unix.MS_RDONLY
+	}
+	return unix.Mount("", path, "", flags, "")
+}
+
+// readMountPoints returns the mount points of the current mount namespace,
+// parents before children.
+func readMountPoints() ([]string, error) {
+	data, err := os.ReadFile("/proc/self/mountinfo")
+	if err != nil {
+		return nil, fmt.Errorf("reading mountinfo: %w", err)
+	}
+	seen := make(map[string]bool)
+	var points []string
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+		mp := unescapeMountPath(fields[4])
+		if !seen[mp] {
+			seen[mp] = true
+			points = append(points, mp)
+		}
+	}
+	sort.SliceStable(points, func(i, j int) bool { return len(points[i]) < len(points[j]) })
+	return points, nil
+}
+
+// unescapeMountPath decodes the octal escapes (\040 etc.) of mountinfo.
+func unescapeMountPath(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if n, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func isPseudoMount(mp string) bool {
+	for _, prefix := range []string{"/proc", "/sys", "/dev"} {
+		if mp == prefix || strings.HasPrefix(mp, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func isDenied(p string, deny []string) bool {
+	for _, d := range deny {
+		if p == d || strings.HasPrefix(p, d+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// loopbackUp brings up the loopback interface of the new network namespace.
+func loopbackUp() error {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return fmt.Errorf("loopback socket: %w", err)
+	}
+	defer unix.Close(fd)
+	ifr, err := unix.NewIfreq("lo")
+	if err != nil {
+		return err
+	}
+	if err := unix.IoctlIfreq(fd, unix.SIOCGIFFLAGS, ifr); err != nil {
+		return fmt.Errorf("reading loopback flags: %w", err)
+	}
+	ifr.SetUint16(ifr.Uint16() | unix.IFF_UP)
+	if err := unix.IoctlIfreq(fd, unix.SIOCSIFFLAGS, ifr); err != nil {
+		return fmt.Errorf("bringing up loopback: %w", err)
+	}
+	return nil
+}
+
+// startRelay forwards connections to relayAddr inside the sandbox network
+// namespace to the filtering proxy socket on the host.
+func startRelay(socketPath string) error {
+	ln, err := net.Listen("tcp", relayAddr)
+	if err != nil {
+		return fmt.Errorf("starting proxy relay: %w", err)
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				upstream, err := net.Dial("unix", socketPath)
+				if err != nil {
+					return
+				}
+				defer upstream.Close()
+				done := make(chan struct{}, 2)
+				go func() { _, _ = io.Copy(upstream, conn); closeWrite(upstream); done <- struct{}{} }()
+				go func() { _, _ = io.Copy(conn, upstream); closeWrite(conn); done <- struct{}{} }()
+				<-done
+				<-done
+			}()
+		}
+	}()
+	return nil
+}
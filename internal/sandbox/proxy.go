@@ -0,0 +1,162 @@
+package sandbox
+
+import (
+	"bufio"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// proxySocketName is the name of the filtering proxy socket inside the
+// proxy directory.
+const proxySocketName = "proxy.sock"
+
+// proxyDialTimeout bounds connection attempts to allowed hosts.
+const proxyDialTimeout = 30 * time.Second
+
+// filteringProxy is an HTTP proxy on a Unix socket that only connects to
+// allowlisted hosts. It supports CONNECT tunnels and plain HTTP requests.
+type filteringProxy struct {
+	listener net.Listener
+	allow    []string
+	logger   *slog.Logger
+	dial     func(network, addr string) (net.Conn, error)
+
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+	wg    sync.WaitGroup
+}
+
+// startProxy listens on dir/proxy.sock and serves until Close.
+func startProxy(dir string, allow []string, logger *slog.Logger) (*filteringProxy, error) {
+	ln, err := net.Listen("unix", filepath.Join(dir, proxySocketName))
+	if err != nil {
+		return nil, err
+	}
+	p := &filteringProxy{
+		listener: ln,
+		allow:    allow,
+		logger:   logger,
+		dial: func(network, addr string) (net.Conn, error) {
+			return net.DialTimeout(network, addr, proxyDialTimeout)
+		},
+		conns: make(map[net.Conn]struct{}),
+	}
+	p.wg.Add(1)
+	go p.serve()
+	return p, nil
+}
+
+// Close stops the proxy and closes open connections.
+func (p *filteringProxy) Close() {
+	_ = p.listener.Close()
+	p.mu.Lock()
+	for c := range p.conns {
+		_ = c.Close()
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+func (p *filteringProxy) serve() {
+	defer p.wg.Done()
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+		p.track(conn, true)
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			defer p.track(conn, false)
+			p.handle(conn)
+		}()
+	}
+}
+
+func (p *filteringProxy) track(c net.Conn, add bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if add {
+		p.conns[c] = struct{}{}
+	} else {
+		delete(p.conns, c)
+		_ = c.Close()
+	}
+}
+
+func (p *filteringProxy) handle(conn net.Conn) {
+	br := bufio.NewReader(conn)
+	req, err := http.ReadRequest(br)
+	if err != nil {
+		return
+	}
+
+	target := req.Host
+	if req.Method != http.MethodConnect {
+		if req.URL.Host != "" {
+			target = req.URL.Host
+		}
+		if _, port := splitHostPort(target); port == "" {
+			target = net.JoinHostPort(target, "80")
+		}
+	}
+
+	if !HostAllowed(target, p.allow) {
+		p.logger.Warn("sandbox: blocked network access", "host", target)
+		_, _ = io.WriteString(conn, "HTTP/1.1 403 Forbidden\r\nContent-Type: text/plain\r\nConnection: close\r\n\r\n"+
+			"quorum sandbox: host not in network allowlist\n")
+		return
+	}
+
+	upstream, err := p.dial("tcp", target)
+	if err != nil {
+		_, _ = io.WriteString(conn, "HTTP/1.1 502 Bad Gateway\r\nConnection: close\r\n\r\n")
+		return
+	}
+	p.track(upstream, true)
+	defer p.track(upstream, false)
+
+	if req.Method == http.MethodConnect {
+		if _, err := io.WriteString(conn, "HTTP/1.1 200 Connection Established\r\n\r\n"); err != nil {
+			return
+		}
+	} else {
+		req.Close = true
+		req.Header.Del("Proxy-Connection")
+		req.Header.Del("Proxy-Authorization")
+		if err := req.Write(upstream); err != nil {
+			return
+		}
+	}
+	pipe(conn, br, upstream)
+}
+
+// pipe copies data in both directions until either side is done. Buffered
+// client data in br is forwarded first.
+func pipe(client net.Conn, br *bufio.Reader, upstream net.Conn) {
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(upstream, br)
+		closeWrite(upstream)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(client, upstream)
+		closeWrite(client)
+		done <- struct{}{}
+	}()
+	<-done
+	<-done
+}
+
+func closeWrite(c net.Conn) {
+	if cw, ok := c.(interface{ CloseWrite() error }); ok {
+		_ = cw.CloseWrite()
+	}
+}
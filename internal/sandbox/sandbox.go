@@ -0,0 +1,132 @@
+// Package sandbox runs agent CLI processes with a restricted view of the
+// system.
+//
+// On Linux a sandboxed command is started through a small init process (the
+// quorum binary re-executed with InitArg) inside new user, mount and,
+// optionally, network namespaces. The init process remounts the whole
+// filesystem read-only, re-binds the command's working directory and the
+// configured write paths read-write, hides other home directories and the
+// deny paths, gives the command a private /tmp and then starts the command in
+// a nested user namespace so the mounts cannot be undone. Network access is
+// either left untouched, removed, or limited to an allowlist of hosts through
+// a filtering HTTP proxy. CPU, memory and process limits are applied through
+// a cgroup v2 sub-group when the current cgroup is delegated.
+//
+// Binaries that start sandboxed commands must call MaybeRunInit at the very
+// beginning of main (and of TestMain in tests).
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+)
+
+// InitArg is the first argument of the re-executed binary that turns it into
+// the sandbox init process.
+const InitArg = "__quorum-sandbox-init"
+
+// NetworkMode controls network access inside the sandbox.
+type NetworkMode string
+
+const (
+	// NetworkHost shares the host network.
+	NetworkHost NetworkMode = "host"
+	// NetworkNone only provides a private loopback interface.
+	NetworkNone NetworkMode = "none"
+	// NetworkAllowlist only allows HTTP(S) connections to AllowHosts through
+	// a filtering proxy announced in HTTP_PROXY/HTTPS_PROXY.
+	NetworkAllowlist NetworkMode = "allowlist"
+)
+
+// ErrUnsupported is returned on platforms without a sandbox backend.
+var ErrUnsupported = errors.New("sandboxed execution is only supported on Linux")
+
+// Limits are resource limits for the sandboxed process tree. Zero values
+// mean unlimited.
+type Limits struct {
+	// MemoryMB caps the memory of the process tree.
+	MemoryMB int
+	// CPUPercent caps CPU time; 100 is one full core.
+	CPUPercent int
+	// MaxPids caps the number of processes and threads.
+	MaxPids int
+}
+
+// IsZero reports whether no limit is set.
+func (l Limits) IsZero() bool {
+	return l.MemoryMB <= 0 && l.CPUPercent <= 0 && l.MaxPids <= 0
+}
+
+// Policy describes what a sandboxed command may access. The command's
+// working directory is always writable.
+type Policy struct {
+	// WritePaths are writable in addition to the working directory. A leading
+	// "~" is expanded to the user's home directory; missing paths are skipped.
+	WritePaths []string
+	// DenyPaths are hidden from the command.
+	DenyPaths []string
+	// Network selects the network mode (default NetworkHost).
+	Network NetworkMode
+	// AllowHosts lists the hosts reachable in NetworkAllowlist mode. Entries
+	// may be "host", "host:port" or "*.domain".
+	AllowHosts []string
+	// Limits are the resource limits.
+	Limits Limits
+	// Logger receives blocked connections and setup warnings (optional).
+	Logger *slog.Logger
+}
+
+// Validate checks the policy for invalid values.
+func (p Policy) Validate() error {
+	switch p.Network {
+	case "", NetworkHost, NetworkNone, NetworkAllowlist:
+	default:
+		return fmt.Errorf("invalid sandbox network mode %q (valid: host, none, allowlist)", p.Network)
+	}
+	if p.Limits.MemoryMB < 0 || p.Limits.CPUPercent < 0 || p.Limits.MaxPids < 0 {
+		return errors.New("sandbox limits must be >= 0")
+	}
+	return nil
+}
+
+func (p Policy) logger() *slog.Logger {
+	if p.Logger != nil {
+		return p.Logger
+	}
+	return slog.Default()
+}
+
+// HostAllowed reports whether hostport ("host" or "host:port") matches one
+// of the allowlist entries. Entries without a port match any port; entries
+// starting with "*." match subdomains.
+func HostAllowed(hostport string, allow []string) bool {
+	host, port := splitHostPort(hostport)
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, entry := range allow {
+		eHost, ePort := splitHostPort(strings.TrimSpace(entry))
+		eHost = strings.ToLower(strings.TrimSuffix(eHost, "."))
+		if ePort != "" && ePort != port {
+			continue
+		}
+		if suffix, ok := strings.CutPrefix(eHost, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+			continue
+		}
+		if host == eHost {
+			return true
+		}
+	}
+	return false
+}
+
+func splitHostPort(s string) (host, port string) {
+	if h, p, err := net.SplitHostPort(s); err == nil {
+		return h, p
+	}
+	return strings.Trim(s, "[]"), ""
+}
@@ -0,0 +1,208 @@
+//go:build linux
+
+package sandbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// specEnv carries the JSON initSpec from Wrap to the init process.
+const specEnv = "QUORUM_SANDBOX_SPEC"
+
+// relayAddr is the proxy address inside the sandbox network namespace.
+const relayAddr = "127.0.0.1:3128"
+
+// initSpec is the setup the init process performs before starting the
+// sandboxed command.
+type initSpec struct {
+	Path       string      `json:"path"`
+	Args       []string    `json:"args"`
+	Dir        string      `json:"dir"`
+	WritePaths []string    `json:"write_paths"`
+	DenyPaths  []string    `json:"deny_paths"`
+	Home       string      `json:"home"`
+	Network    NetworkMode `json:"network"`
+	ProxyDir   string      `json:"proxy_dir,omitempty"`
+	UID        int         `json:"uid"`
+	GID        int         `json:"gid"`
+}
+
+// Wrap rewrites cmd so that it runs inside the sandbox described by policy.
+// It must be called after cmd is fully configured and before cmd.Start. The
+// returned cleanup releases the proxy and cgroup and must be called after
+// the command has exited, or when it failed to start.
+func Wrap(cmd *exec.Cmd, policy Policy) (cleanup func(), err error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	if cmd.Err != nil {
+		// Start reports the lookup error; there is nothing to sandbox.
+		return func() {}, nil
+	}
+	if cmd.Process != nil {
+		return nil, fmt.Errorf("sandbox: command already started")
+	}
+
+	dir := cmd.Dir
+	if dir == "" {
+		if dir, err = os.Getwd(); err != nil {
+			return nil, fmt.Errorf("sandbox: resolving working directory: %w", err)
+		}
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("sandbox: resolving working directory: %w", err)
+	}
+	home, _ := os.UserHomeDir()
+
+	spec := initSpec{
+		Path:       cmd.Path,
+		Args:       cmd.Args,
+		Dir:        dir,
+		WritePaths: resolvePaths(policy.WritePaths, home, dir),
+		DenyPaths:  resolvePaths(policy.DenyPaths, home, dir),
+		Home:       home,
+		Network:    policy.Network,
+		UID:        os.Getuid(),
+		GID:        os.Getgid(),
+	}
+	if spec.Network == "" {
+		spec.Network = NetworkHost
+	}
+
+	var cleanups []func()
+	cleanup = func() {
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			cleanups[i]()
+		}
+	}
+	fail := func(err error) (func(), error) {
+		cleanup()
+		return nil, err
+	}
+
+	env := cmd.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	env = withoutEnv(env, specEnv)
+
+	if spec.Network == NetworkAllowlist {
+		proxyDir, err := os.MkdirTemp("", "quorum-sandbox-*")
+		if err != nil {
+			return fail(fmt.Errorf("sandbox: creating proxy directory: %w", err))
+		}
+		cleanups = append(cleanups, func() { _ = os.RemoveAll(proxyDir) })
+		proxy, err := startProxy(proxyDir, policy.AllowHosts, policy.logger())
+		if err != nil {
+			return fail(fmt.Errorf("sandbox: starting network proxy: %w", err))
+		}
+		cleanups = append(cleanups, proxy.Close)
+		spec.ProxyDir = proxyDir
+		proxyURL := "http://" + relayAddr
+		for _, name := range []string{"HTTP_PROXY", "HTTPS_PROXY", "ALL_PROXY", "http_proxy", "https_proxy", "all_proxy"} {
+			env = append(withoutEnv(env, name), name+"="+proxyURL)
+		}
+		env = append(withoutEnv(withoutEnv(env, "NO_PROXY"), "no_proxy"),
+			"NO_PROXY=localhost,127.0.0.1", "no_proxy=localhost,127.0.0.1")
+	}
+
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	attr := cmd.SysProcAttr
+
+	if !policy.Limits.IsZero() {
+		cg, err := newCgroup(policy.Limits)
+		if err != nil {
+			policy.logger().Warn("sandbox: resource limits not applied", "error", err)
+		} else {
+			cleanups = append(cleanups, cg.remove)
+			attr.UseCgroupFD = true
+			attr.CgroupFD = cg.fd
+		}
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return fail(fmt.Errorf("sandbox: encoding spec: %w", err))
+	}
+	cmd.Env = append(env, specEnv+"="+string(data))
+	cmd.Path = "/proc/self/exe"
+	cmd.Args = []string{"quorum-sandbox", InitArg}
+
+	attr.Cloneflags |= syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS
+	if spec.Network != NetworkHost {
+		attr.Cloneflags |= syscall.CLONE_NEWNET
+	}
+	attr.UidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: spec.UID, Size: 1}}
+	attr.GidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: spec.GID, Size: 1}}
+	attr.GidMappingsEnableSetgroups = false
+
+	return cleanup, nil
+}
+
+// Probe starts a trivial sandboxed command to check that the kernel allows
+// the namespaces the sandbox needs. The calling binary must call
+// MaybeRunInit.
+func Probe(policy Policy) error {
+	truePath, err := exec.LookPath("true")
+	if err != nil {
+		return fmt.Errorf("sandbox probe: %w", err)
+	}
+	cmd := exec.Command(truePath)
+	cleanup, err := Wrap(cmd, policy)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("sandbox probe: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// resolvePaths expands "~", makes paths absolute relative to dir and drops
+// paths that do not exist.
+func resolvePaths(paths []string, home, dir string) []string {
+	var out []string
+	for _, p := range paths {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if p == "~" || strings.HasPrefix(p, "~/") {
+			if home == "" {
+				continue
+			}
+			p = filepath.Join(home, strings.TrimPrefix(p, "~"))
+		}
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(dir, p)
+		}
+		if _, err := os.Lstat(p); err != nil {
+			continue
+		}
+		if resolved, err := filepath.EvalSymlinks(p); err == nil {
+			p = resolved
+		}
+		out = append(out, filepath.Clean(p))
+	}
+	return out
+}
+
+func withoutEnv(env []string, name string) []string {
+	out := env[:0:0]
+	for _, kv := range env {
+		if !strings.HasPrefix(kv, name+"=") {
+			out = append(out, kv)
+		}
+	}
+	return out
+}
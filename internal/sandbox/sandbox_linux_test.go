@@ -0,0 +1,271 @@
+//go:build linux
+
+package sandbox
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// helperEnv makes the test binary act as a sandboxed helper command.
+const helperEnv = "QUORUM_SANDBOX_TEST_HELPER"
+
+func TestMain(m *testing.M) {
+	MaybeRunInit()
+	if mode := os.Getenv(helperEnv); mode != "" {
+		os.Exit(runHelper(mode))
+	}
+	os.Exit(m.Run())
+}
+
+// runHelper fetches the URL in os.Args[1], through HTTPS_PROXY when mode is
+// "proxy" and directly otherwise, and prints the body.
+func runHelper(mode string) int {
+	transport := &http.Transport{}
+	if mode == "proxy" {
+		proxyURL, err := url.Parse(os.Getenv("HTTPS_PROXY"))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	client := &http.Client{Transport: transport, Timeout: 5 * time.Second}
+	resp, err := client.Get(os.Args[len(os.Args)-1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	fmt.Printf("%d %s", resp.StatusCode, body)
+	return 0
+}
+
+// requireSandbox skips the test when the kernel does not allow unprivileged
+// user namespaces.
+func requireSandbox(t *testing.T) {
+	t.Helper()
+	if err := Probe(Policy{Network: NetworkNone}); err != nil {
+		t.Skipf("sandbox not available: %v", err)
+	}
+}
+
+// newRepo creates a repository directory with a worktree outside /tmp, since
+// the sandbox replaces /tmp with a private tmpfs.
+func newRepo(t *testing.T) (repo, worktree string) {
+	t.Helper()
+	repo, err := os.MkdirTemp(".", "sandbox-repo-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, _ = filepath.Abs(repo)
+	t.Cleanup(func() { _ = os.RemoveAll(repo) })
+	worktree = filepath.Join(repo, "worktree")
+	if err := os.Mkdir(worktree, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return repo, worktree
+}
+
+func runSandboxed(t *testing.T, policy Policy, dir string, name string, args ...string) (string, error) {
+	t.Helper()
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	cleanup, err := Wrap(cmd, policy)
+	if err != nil {
+		t.Fatalf("Wrap() error = %v", err)
+	}
+	defer cleanup()
+	err = cmd.Run()
+	return out.String(), err
+}
+
+func TestWrap_WritesOutsideWorktreeFail(t *testing.T) {
+	requireSandbox(t)
+	repo, worktree := newRepo(t)
+	home, _ := os.UserHomeDir()
+	homeFile := filepath.Join(home, fmt.Sprintf(".quorum-sandbox-test-%d", os.Getpid()))
+	t.Cleanup(func() { _ = os.Remove(homeFile) })
+
+	script := `echo inside > inside.txt || exit 10
+echo outside > ../outside.txt && exit 11
+echo home > "$1" && exit 12
+echo tmp > /tmp/scratch.txt || exit 13
+exit 0`
+	out, err := runSandboxed(t, Policy{Network: NetworkNone}, worktree, "/bin/sh", "-c", script, "sh", homeFile)
+	if err != nil {
+		t.Fatalf("sandboxed script failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Read-only file system") {
+		t.Errorf("expected read-only errors, got:\n%s", out)
+	}
+
+	if data, err := os.ReadFile(filepath.Join(worktree, "inside.txt")); err != nil || string(data) != "inside\n" {
+		t.Errorf("worktree write = %q, %v", data, err)
+	}
+	for _, p := range []string{filepath.Join(repo, "outside.txt"), homeFile} {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Errorf("%s was written outside the worktree", p)
+		}
+	}
+}
+
+func TestWrap_WritePathsAndDenyPaths(t *testing.T) {
+	requireSandbox(t)
+	repo, worktree := newRepo(t)
+	cache := filepath.Join(repo, "cache")
+	secret := filepath.Join(repo, "secret")
+	for _, d := range []string{cache, secret} {
+		if err := os.Mkdir(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(secret, "token"), []byte("s3cret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	policy := Policy{Network: NetworkNone, WritePaths: []string{cache}, DenyPaths: []string{secret}}
+	script := `echo cached > ../cache/entry || exit 10
+cat ../secret/token 2>/dev/null && exit 11
+exit 0`
+	out, err := runSandboxed(t, policy, worktree, "/bin/sh", "-c", script)
+	if err != nil {
+		t.Fatalf("sandboxed script failed: %v\n%s", err, out)
+	}
+	if strings.Contains(out, "s3cret") {
+		t.Error("deny path content visible inside the sandbox")
+	}
+	if data, _ := os.ReadFile(filepath.Join(cache, "entry")); string(data) != "cached\n" {
+		t.Errorf("write path content = %q", data)
+	}
+}
+
+func TestWrap_MountsCannotBeUndone(t *testing.T) {
+	requireSandbox(t)
+	if _, err := exec.LookPath("mount"); err != nil {
+		t.Skip("mount binary not available")
+	}
+	repo, worktree := newRepo(t)
+
+	script := `mount -o remount,bind,rw / 2>/dev/null
+mount -o remount,bind,rw "$1" 2>/dev/null
+umount /tmp 2>/dev/null
+echo escaped > ../outside.txt 2>/dev/null
+exit 0`
+	out, err := runSandboxed(t, Policy{Network: NetworkNone}, worktree, "/bin/sh", "-c", script, "sh", repo)
+	if err != nil {
+		t.Fatalf("sandboxed script failed: %v\n%s", err, out)
+	}
+	if _, err := os.Stat(filepath.Join(repo, "outside.txt")); !os.IsNotExist(err) {
+		t.Error("command escaped the read-only mounts")
+	}
+}
+
+func TestWrap_ExitCode(t *testing.T) {
+	requireSandbox(t)
+	_, worktree := newRepo(t)
+
+	_, err := runSandboxed(t, Policy{Network: NetworkNone}, worktree, "/bin/sh", "-c", "exit 7")
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok || exitErr.ExitCode() != 7 {
+		t.Errorf("err = %v, want exit status 7", err)
+	}
+}
+
+func TestWrap_ForwardsSignals(t *testing.T) {
+	requireSandbox(t)
+	_, worktree := newRepo(t)
+
+	cmd := exec.Command("/bin/sh", "-c", `trap "exit 3" TERM; echo ready; while :; do sleep 0.1; done`)
+	cmd.Dir = worktree
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cleanup, err := Wrap(cmd, Policy{Network: NetworkNone})
+	if err != nil {
+		t.Fatalf("Wrap() error = %v", err)
+	}
+	defer cleanup()
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if line, err := bufio.NewReader(stdout).ReadString('\n'); err != nil || line != "ready\n" {
+		_ = cmd.Process.Kill()
+		t.Fatalf("read %q, %v; want ready", line, err)
+	}
+	// A swallowed signal would leave the command running forever.
+	timer := time.AfterFunc(10*time.Second, func() { _ = cmd.Process.Kill() })
+	defer timer.Stop()
+	if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+
+	err = cmd.Wait()
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok || exitErr.ExitCode() != 3 {
+		t.Errorf("err = %v, want exit status 3 from the trap", err)
+	}
+}
+
+func TestWrap_Network(t *testing.T) {
+	requireSandbox(t)
+	_, worktree := newRepo(t)
+	self, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "reachable")
+	}))
+	defer backend.Close()
+
+	run := func(policy Policy, mode string) (string, error) {
+		t.Helper()
+		// The test binary lives under /tmp, which the sandbox replaces.
+		policy.WritePaths = append(policy.WritePaths, filepath.Dir(self))
+		cmd := exec.Command(self, backend.URL)
+		cmd.Dir = worktree
+		cmd.Env = append(os.Environ(), helperEnv+"="+mode)
+		var out bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+		cleanup, err := Wrap(cmd, policy)
+		if err != nil {
+			t.Fatalf("Wrap() error = %v", err)
+		}
+		defer cleanup()
+		err = cmd.Run()
+		return out.String(), err
+	}
+
+	if out, err := run(Policy{Network: NetworkNone}, "direct"); err == nil {
+		t.Errorf("network none: request succeeded: %s", out)
+	}
+	if out, err := run(Policy{Network: NetworkAllowlist, AllowHosts: []string{"127.0.0.1"}}, "proxy"); err != nil || out != "200 reachable" {
+		t.Errorf("allowlisted request = %q, %v", out, err)
+	}
+	if out, err := run(Policy{Network: NetworkAllowlist, AllowHosts: []string{"example.com"}}, "proxy"); err != nil || !strings.HasPrefix(out, "403") {
+		t.Errorf("blocked request = %q, %v; want 403", out, err)
+	}
+	if out, err := run(Policy{Network: NetworkAllowlist, AllowHosts: []string{"127.0.0.1"}}, "direct"); err == nil {
+		t.Errorf("allowlist mode: direct connection bypassed the proxy: %s", out)
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !linux
+
+package sandbox
+
+import "os/exec"
+
+// MaybeRunInit does nothing on platforms without a sandbox backend.
+func MaybeRunInit() {}
+
+// Wrap returns ErrUnsupported on platforms without a sandbox backend.
+func Wrap(_ *exec.Cmd, _ Policy) (func(), error) {
+	return nil, ErrUnsupported
+}
+
+// Probe returns ErrUnsupported on platforms without a sandbox backend.
+func Probe(_ Policy) error {
+	return ErrUnsupported
+}
@@ -0,0 +1,123 @@
+package sandbox
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHostAllowed(t *testing.T) {
+	t.Parallel()
+
+	allow := []string{"api.anthropic.com", "*.openai.com", "example.org:8443"}
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"api.anthropic.com:443", true},
+		{"API.Anthropic.com", true},
+		{"anthropic.com:443", false},
+		{"api.openai.com:443", true},
+		{"openai.com:443", false},
+		{"evil-openai.com:443", false},
+		{"example.org:8443", true},
+		{"example.org:443", false},
+	}
+	for _, tt := range tests {
+		if got := HostAllowed(tt.host, allow); got != tt.want {
+			t.Errorf("HostAllowed(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestPolicyValidate(t *testing.T) {
+	t.Parallel()
+
+	if err := (Policy{Network: NetworkAllowlist}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := (Policy{Network: "vpn"}).Validate(); err == nil {
+		t.Error("Validate() should reject unknown network modes")
+	}
+	if err := (Policy{Limits: Limits{MemoryMB: -1}}).Validate(); err == nil {
+		t.Error("Validate() should reject negative limits")
+	}
+}
+
+func TestFilteringProxy(t *testing.T) {
+	t.Parallel()
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "hello")
+	}))
+	defer backend.Close()
+	backendHost := strings.TrimPrefix(backend.URL, "http://")
+
+	dir := t.TempDir()
+	proxy, err := startProxy(dir, []string{"127.0.0.1"}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("startProxy() error = %v", err)
+	}
+	defer proxy.Close()
+	socket := filepath.Join(dir, proxySocketName)
+
+	// Plain HTTP request through the proxy.
+	status, body := proxyRequest(t, socket, fmt.Sprintf("GET %s/ HTTP/1.1\r\nHost: %s\r\n\r\n", backend.URL, backendHost))
+	if status != http.StatusOK || body != "hello" {
+		t.Errorf("allowed request = %d %q, want 200 hello", status, body)
+	}
+
+	// CONNECT tunnel followed by a request over the tunnel.
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		t.Fatalf("dial proxy: %v", err)
+	}
+	defer conn.Close()
+	fmt.Fprintf(conn, "CONNECT %s HTTP/1.1\r\nHost: %s\r\n\r\n", backendHost, backendHost)
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("CONNECT response = %v, %v", resp, err)
+	}
+	fmt.Fprintf(conn, "GET / HTTP/1.1\r\nHost: %s\r\nConnection: close\r\n\r\n", backendHost)
+	resp, err = http.ReadResponse(br, nil)
+	if err != nil {
+		t.Fatalf("tunneled response: %v", err)
+	}
+	tunneled, _ := io.ReadAll(resp.Body)
+	if string(tunneled) != "hello" {
+		t.Errorf("tunneled body = %q", tunneled)
+	}
+
+	// Hosts outside the allowlist are refused.
+	status, _ = proxyRequest(t, socket, "CONNECT blocked.example:443 HTTP/1.1\r\nHost: blocked.example:443\r\n\r\n")
+	if status != http.StatusForbidden {
+		t.Errorf("blocked CONNECT status = %d, want 403", status)
+	}
+}
+
+func proxyRequest(t *testing.T, socket, raw string) (int, string) {
+	t.Helper()
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		t.Fatalf("dial proxy: %v", err)
+	}
+	defer conn.Close()
+	if _, err := io.WriteString(conn, raw); err != nil {
+		t.Fatalf("write request: %v", err)
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if err != nil {
+		t.Fatalf("read response: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
@@ -0,0 +1,465 @@
+// Package chatcontext fits chat history into an agent's context window.
+//
+// The most recent turns are sent verbatim. Older turns that no longer fit the
+// token budget are folded into a rolling summary, which a (preferably cheap)
+// agent updates incrementally and which is stored alongside the session.
+package chatcontext
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+const (
+	// defaultRecentTurns is used when the configuration does not set recent_turns.
+	defaultRecentTurns = 6
+	// defaultContextTokens is assumed for agents that do not report a context window.
+	defaultContextTokens = 128000
+	// maxSummaryTokens bounds the size of the rolling summary.
+	maxSummaryTokens = 1500
+	// maxSummarizedTurnTokens bounds each turn sent to the summarizer.
+	maxSummarizedTurnTokens = 1500
+	// minTurnTokens is the smallest size a turn is truncated to.
+	minTurnTokens = 256
+	// summaryPromptTokens approximates the summarization instructions.
+	summaryPromptTokens = 300
+)
+
+// Turn is one message of a conversation.
+type Turn struct {
+	ID      string
+	Role    string // "user" or "agent"/"assistant"; other roles are not sent
+	Content string
+	// Group links the turns of a question asked to several agents at once.
+	// When the group has a synthesis, only the synthesis of its replies is sent.
+	Group     string
+	Synthesis bool
+}
+
+// Request describes the context to build for one chat message.
+type Request struct {
+	// SessionID keys the rolling summary. Summaries are not kept without one.
+	SessionID string
+	// Agent and Model answer the message; they determine the token budget.
+	Agent string
+	Model string
+	// Fixed holds the text sent regardless of history: system prompt, the
+	// current message and any attached files.
+	Fixed []string
+	// History holds the previous turns, oldest first, without the current message.
+	History []Turn
+	// Store persists the summary when it implements core.ChatSummaryStore.
+	// Summaries are kept in memory otherwise.
+	Store  core.ChatStore
+	Config config.ChatContextConfig
+}
+
+// Context is the conversation context selected for a message.
+type Context struct {
+	// Summary condenses the turns before Turns; empty when the whole history fits.
+	Summary string
+	// Turns are the turns sent verbatim, oldest first.
+	Turns []Turn
+	// SummarizedThrough is the ID of the last turn covered by Summary.
+	SummarizedThrough string
+	// Summarized is the number of turns covered by Summary.
+	Summarized int
+	// Omitted is the number of older turns left out because they could not be summarized.
+	Omitted int
+	// Truncated is the number of sent turns shortened to fit the budget.
+	Truncated int
+	// Tokens is the estimated size of the context, including the fixed text.
+	Tokens int
+	// Budget is the token budget of the agent and model.
+	Budget int
+	// SummaryErr reports why older turns could not be summarized.
+	SummaryErr error
+}
+
+// Builder builds chat contexts. It is safe for concurrent use.
+type Builder struct {
+	agents core.AgentRegistry
+
+	mu        sync.Mutex
+	summaries map[string]*core.ChatSummaryState // for stores without summary support
+}
+
+// NewBuilder creates a context builder that resolves agents from the registry.
+func NewBuilder(agents core.AgentRegistry) *Builder {
+	return &Builder{
+		agents:    agents,
+		summaries: make(map[string]*core.ChatSummaryState),
+	}
+}
+
+// Build selects the history to send with a message. Turns that do not fit are
+// summarized; a summarization failure is reported in Context.SummaryErr rather
+// than failing the build, since the message can still be answered without them.
+func (b *Builder) Build(ctx context.Context, req Request) (*Context, error) {
+	if b.agents == nil {
+		return nil, fmt.Errorf("no agent registry configured")
+	}
+	agent, err := b.agents.Get(req.Agent)
+	if err != nil {
+		return nil, fmt.Errorf("agent %s not available: %w", req.Agent, err)
+	}
+
+	out := &Context{Budget: tokenBudget(agent.Capabilities(), req.Model, req.Config.MaxTokens)}
+	for _, text := range req.Fixed {
+		out.Tokens += estimateTokens(text)
+	}
+
+	synthesized := make(map[string]bool)
+	for _, t := range req.History {
+		if t.Synthesis && t.Group != "" {
+			synthesized[t.Group] = true
+		}
+	}
+	turns := make([]Turn, 0, len(req.History))
+	historyTokens := 0
+	for _, t := range req.History {
+		if t.Role != "user" && t.Role != "agent" && t.Role != "assistant" {
+			continue
+		}
+		if t.Role != "user" && !t.Synthesis && synthesized[t.Group] {
+			continue
+		}
+		turns = append(turns, t)
+		historyTokens += estimateTokens(t.Content)
+	}
+
+	available := out.Budget - out.Tokens
+	if historyTokens <= available {
+		out.Turns = turns
+		out.Tokens += historyTokens
+		return out, nil
+	}
+	if available <= 0 {
+		out.Omitted = len(turns)
+		return out, nil
+	}
+
+	summaryTokens := min(available/4, maxSummaryTokens)
+	recent := req.Config.RecentTurns
+	if recent <= 0 {
+		recent = defaultRecentTurns
+	}
+	kept, truncated := selectRecent(turns, available-summaryTokens, recent)
+	start := len(turns) - len(kept)
+
+	previous := b.loadSummary(ctx, req)
+	covered := coveredTurns(turns, previous)
+	switch {
+	case start == 0:
+		// Shortening the turns was enough to fit the whole history.
+		covered = 0
+	case covered >= start:
+		// The stored summary already covers every turn that does not fit.
+		kept = kept[covered-start:]
+		out.Summary = previous.Content
+	default:
+		summary, err := b.summarize(ctx, req, previous, turns[covered:start], summaryTokens)
+		if err != nil {
+			out.SummaryErr = err
+			if previous != nil {
+				out.Summary = previous.Content
+			}
+			out.Omitted = start - covered
+		} else {
+			out.Summary = summary.Content
+			covered = start
+			summary.ThroughMessageID = turns[start-1].ID
+			summary.MessageCount = start
+			if err := b.saveSummary(ctx, req, summary); err != nil {
+				out.SummaryErr = fmt.Errorf("saving summary: %w", err)
+			}
+		}
+	}
+
+	if covered > 0 && out.Summary != "" {
+		out.Summarized = covered
+		out.SummarizedThrough = turns[covered-1].ID
+		out.Summary = truncateToTokens(out.Summary, summaryTokens)
+	}
+	out.Turns = kept
+	out.Tokens += estimateTokens(out.Summary)
+	for _, t := range kept {
+		out.Tokens += estimateTokens(t.Content)
+	}
+	for _, t := range truncated {
+		if t >= len(turns)-len(kept) {
+			out.Truncated++
+		}
+	}
+	return out, nil
+}
+
+// Transcript renders the context as plain text, for prompts that embed the
+// conversation directly.
+func (c *Context) Transcript() string {
+	var sb strings.Builder
+	if c.Summary != "" {
+		fmt.Fprintf(&sb, "[summary of %d earlier messages]: %s\n\n", c.Summarized, c.Summary)
+	}
+	for _, t := range c.Turns {
+		fmt.Fprintf(&sb, "[%s]: %s\n\n", messageRole(t.Role), t.Content)
+	}
+	return sb.String()
+}
+
+// Messages returns the context as structured messages. The summary, if any,
+// leads as a user message.
+func (c *Context) Messages() []core.Message {
+	messages := make([]core.Message, 0, len(c.Turns)+1)
+	if c.Summary != "" {
+		messages = append(messages, core.Message{
+			Role:    "user",
+			Content: fmt.Sprintf("Summary of the %d earlier messages of this conversation:\n%s", c.Summarized, c.Summary),
+		})
+	}
+	for _, t := range c.Turns {
+		messages = append(messages, core.Message{Role: messageRole(t.Role), Content: t.Content})
+	}
+	return messages
+}
+
+// Notice describes how the history was reduced, or returns "" when it was
+// sent unchanged.
+func (c *Context) Notice() string {
+	var parts []string
+	if c.Summarized > 0 {
+		parts = append(parts, fmt.Sprintf("%d earlier messages summarized", c.Summarized))
+	}
+	if c.Omitted > 0 {
+		parts = append(parts, fmt.Sprintf("%d omitted", c.Omitted))
+	}
+	if c.Truncated > 0 {
+		parts = append(parts, fmt.Sprintf("%d shortened", c.Truncated))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s to fit the context budget (~%s of %s tokens)",
+		strings.Join(parts, ", "), formatTokens(c.Tokens), formatTokens(c.Budget))
+}
+
+// tokenBudget returns the input token budget for an agent and model: the
+// context window minus room for the response, capped by maxTokens when set.
+func tokenBudget(caps core.Capabilities, model string, maxTokens int) int {
+	window := core.GetContextTokens(model, caps.MaxContextTokens)
+	if window <= 0 {
+		window = defaultContextTokens
+	}
+	reserve := caps.MaxOutputTokens
+	if reserve <= 0 || reserve > window/4 {
+		reserve = window / 4
+	}
+	budget := window - reserve
+	if maxTokens > 0 && maxTokens < budget {
+		budget = maxTokens
+	}
+	return budget
+}
+
+// selectRecent picks the newest turns that fit the budget. The last pinned
+// turns are always kept, shortened if necessary. It returns the kept turns,
+// oldest first, and the indexes of the turns that were shortened.
+func selectRecent(turns []Turn, budget, pinned int) ([]Turn, []int) {
+	pinned = min(pinned, len(turns))
+	limit := max(budget/4, minTurnTokens)
+	pinnedTokens := 0
+	for _, t := range turns[len(turns)-pinned:] {
+		pinnedTokens += min(estimateTokens(t.Content), limit)
+	}
+	if pinned > 0 && pinnedTokens > budget {
+		limit = max(budget/pinned, 1)
+	}
+
+	var kept []Turn
+	var truncated []int
+	used := 0
+	for i := len(turns) - 1; i >= 0; i-- {
+		t := turns[i]
+		tokens := estimateTokens(t.Content)
+		shortened := tokens > limit
+		if shortened {
+			t.Content = truncateToTokens(t.Content, limit)
+			tokens = estimateTokens(t.Content)
+		}
+		if i < len(turns)-pinned && used+tokens > budget {
+			break
+		}
+		if shortened {
+			truncated = append(truncated, i)
+		}
+		kept = append(kept, t)
+		used += tokens
+	}
+	for i, j := 0, len(kept)-1; i < j; i, j = i+1, j-1 {
+		kept[i], kept[j] = kept[j], kept[i]
+	}
+	return kept, truncated
+}
+
+// coveredTurns returns how many leading turns a stored summary covers, or 0
+// when the summary no longer matches the history.
+func coveredTurns(turns []Turn, summary *core.ChatSummaryState) int {
+	if summary == nil || summary.ThroughMessageID == "" {
+		return 0
+	}
+	for i, t := range turns {
+		if t.ID == summary.ThroughMessageID {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// summarize folds turns into the previous summary, in as many calls as the
+// summarizing agent's window requires.
+func (b *Builder) summarize(ctx context.Context, req Request, previous *core.ChatSummaryState, turns []Turn, summaryTokens int) (*core.ChatSummaryState, error) {
+	agentName, model := req.Config.SummaryAgent, req.Config.SummaryModel
+	if agentName == "" {
+		agentName = req.Agent
+		if model == "" {
+			model = req.Model
+		}
+	}
+	agent, err := b.agents.Get(agentName)
+	if err != nil {
+		return nil, fmt.Errorf("summary agent %s not available: %w", agentName, err)
+	}
+
+	summary := ""
+	if previous != nil {
+		summary = previous.Content
+	}
+	maxWords := summaryTokens * 3 / 4
+	budget := tokenBudget(agent.Capabilities(), model, 0) - summaryPromptTokens - summaryTokens
+
+	for len(turns) > 0 {
+		var batch strings.Builder
+		used := estimateTokens(summary)
+		n := 0
+		for _, t := range turns {
+			content := truncateToTokens(t.Content, maxSummarizedTurnTokens)
+			tokens := estimateTokens(content)
+			if n > 0 && used+tokens > budget {
+				break
+			}
+			fmt.Fprintf(&batch, "[%s]: %s\n\n", messageRole(t.Role), content)
+			used += tokens
+			n++
+		}
+		turns = turns[n:]
+
+		result, err := agent.Execute(ctx, core.ExecuteOptions{
+			Prompt: summaryPrompt(summary, batch.String(), maxWords),
+			Model:  model,
+			Format: core.OutputFormatText,
+			// No phase: phase settings (sandbox, models, remote routing)
+			// are meant for workflow work, not a read-only summary.
+		})
+		if err != nil {
+			return nil, fmt.Errorf("summarizing with %s: %w", agentName, err)
+		}
+		summary = strings.TrimSpace(result.Output)
+		if summary == "" {
+			return nil, fmt.Errorf("summarizing with %s: empty summary", agentName)
+		}
+	}
+
+	return &core.ChatSummaryState{
+		SessionID: req.SessionID,
+		Content:   summary,
+		Agent:     agentName,
+		Model:     model,
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+// summaryPrompt asks for an updated summary of the conversation.
+func summaryPrompt(previous, messages string, maxWords int) string {
+	if previous == "" {
+		previous = "(none yet)"
+	}
+	return fmt.Sprintf(`You maintain a running summary of a chat between a user and an AI coding assistant.
+Update the summary with the new messages below. Keep requirements, decisions, file paths,
+code identifiers, errors and open questions; drop greetings and repeated content.
+Reply with the updated summary only, in at most %d words.
+
+## Current summary
+%s
+
+## New messages
+%s`, maxWords, previous, messages)
+}
+
+func (b *Builder) loadSummary(ctx context.Context, req Request) *core.ChatSummaryState {
+	if req.SessionID == "" {
+		return nil
+	}
+	if store, ok := req.Store.(core.ChatSummaryStore); ok {
+		summary, err := store.LoadSummary(ctx, req.SessionID)
+		if err != nil {
+			return nil
+		}
+		return summary
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.summaries[req.SessionID]
+}
+
+func (b *Builder) saveSummary(ctx context.Context, req Request, summary *core.ChatSummaryState) error {
+	if req.SessionID == "" {
+		return nil
+	}
+	if store, ok := req.Store.(core.ChatSummaryStore); ok {
+		return store.SaveSummary(ctx, summary)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.summaries[req.SessionID] = summary
+	return nil
+}
+
+// messageRole maps chat roles to the roles agents expect.
+func messageRole(role string) string {
+	if role == "agent" {
+		return "assistant"
+	}
+	return role
+}
+
+// estimateTokens approximates the token count of text (~4 characters per token).
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// truncateToTokens shortens text to about maxTokens, keeping its beginning and end.
+func truncateToTokens(text string, maxTokens int) string {
+	maxChars := maxTokens * 4
+	if len(text) <= maxChars {
+		return text
+	}
+	marker := fmt.Sprintf("\n\n[... %d characters omitted to fit the context window ...]\n\n", len(text)-maxChars)
+	keep := max(maxChars-len(marker), 0)
+	head := keep * 2 / 3
+	tail := keep - head
+	return strings.ToValidUTF8(text[:head], "") + marker + strings.ToValidUTF8(text[len(text)-tail:], "")
+}
+
+// formatTokens renders a token count compactly (e.g. 12.3K).
+func formatTokens(n int) string {
+	if n >= 1000 {
+		return fmt.Sprintf("%.1fK", float64(n)/1000)
+	}
+	return fmt.Sprintf("%d", n)
+}
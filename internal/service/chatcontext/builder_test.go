@@ -0,0 +1,219 @@
+package chatcontext
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/testutil"
+)
+
+// memSummaryStore is a ChatStore that also persists summaries.
+type memSummaryStore struct {
+	core.ChatStore
+	summaries map[string]*core.ChatSummaryState
+}
+
+func (s *memSummaryStore) SaveSummary(_ context.Context, summary *core.ChatSummaryState) error {
+	s.summaries[summary.SessionID] = summary
+	return nil
+}
+
+func (s *memSummaryStore) LoadSummary(_ context.Context, sessionID string) (*core.ChatSummaryState, error) {
+	return s.summaries[sessionID], nil
+}
+
+// newRegistry returns a registry with a small-window chat agent and a
+// summarizer that records the prompts it receives. The summarizer rejects
+// calls made for a phase, so that phase settings never apply to summaries.
+func newRegistry(window int) (*testutil.MockRegistry, *[]string) {
+	var prompts []string
+	reg := testutil.NewMockRegistry()
+	reg.Add("small", testutil.NewMockAgent("small").WithCapabilities(core.Capabilities{MaxContextTokens: window}))
+	reg.Add("cheap", testutil.NewMockAgent("cheap").WithExecuteFunc(func(_ context.Context, opts core.ExecuteOptions) (*core.ExecuteResult, error) {
+		if opts.Phase != "" {
+			return nil, fmt.Errorf("summary requested for phase %s", opts.Phase)
+		}
+		prompts = append(prompts, opts.Prompt)
+		return &core.ExecuteResult{Output: fmt.Sprintf("summary #%d", len(prompts))}, nil
+	}))
+	return reg, &prompts
+}
+
+// history builds n alternating turns of roughly tokens tokens each.
+func history(n, tokens int) []Turn {
+	turns := make([]Turn, n)
+	for i := range turns {
+		role := "user"
+		if i%2 == 1 {
+			role = "agent"
+		}
+		turns[i] = Turn{ID: fmt.Sprintf("m%d", i), Role: role, Content: fmt.Sprintf("turn %d ", i) + strings.Repeat("x", tokens*4)}
+	}
+	return turns
+}
+
+func TestBuild_FitsWithoutSummary(t *testing.T) {
+	t.Parallel()
+	reg, prompts := newRegistry(100000)
+	b := NewBuilder(reg)
+
+	turns := append(history(4, 10), Turn{ID: "e", Role: "system", Content: "Error: boom"})
+	c, err := b.Build(context.Background(), Request{SessionID: "s", Agent: "small", History: turns, Fixed: []string{"hi"}})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if len(c.Turns) != 4 || c.Summary != "" || c.Notice() != "" {
+		t.Errorf("context = %+v, want the 4 conversation turns unchanged", c)
+	}
+	if len(*prompts) != 0 {
+		t.Error("summarizer should not run when the history fits")
+	}
+	if msgs := c.Messages(); len(msgs) != 4 || msgs[1].Role != "assistant" {
+		t.Errorf("Messages() = %+v", msgs)
+	}
+}
+
+func TestBuild_KeepsOnlySynthesisOfGroups(t *testing.T) {
+	t.Parallel()
+	reg, _ := newRegistry(100000)
+	b := NewBuilder(reg)
+
+	turns := []Turn{
+		{ID: "q", Role: "user", Content: "mutex or channel?", Group: "g"},
+		{ID: "a1", Role: "agent", Content: "mutex", Group: "g"},
+		{ID: "a2", Role: "agent", Content: "channel", Group: "g"},
+		{ID: "s", Role: "agent", Content: "either; prefer a mutex", Group: "g", Synthesis: true},
+		{ID: "q2", Role: "user", Content: "why?", Group: "h"},
+		{ID: "a3", Role: "agent", Content: "simpler", Group: "h"},
+	}
+	c, err := b.Build(context.Background(), Request{Agent: "small", History: turns})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, turn := range c.Turns {
+		ids = append(ids, turn.ID)
+	}
+	if got := strings.Join(ids, ","); got != "q,s,q2,a3" {
+		t.Errorf("turns = %s, want the question, the synthesis and groups without one", got)
+	}
+}
+
+func TestBuild_SummarizesIncrementally(t *testing.T) {
+	t.Parallel()
+	// 4000-token window: 3000 budget, 750 for the summary, the rest for turns.
+	reg, prompts := newRegistry(4000)
+	b := NewBuilder(reg)
+	store := &memSummaryStore{summaries: make(map[string]*core.ChatSummaryState)}
+	cfg := config.ChatContextConfig{RecentTurns: 2, SummaryAgent: "cheap"}
+
+	turns := history(20, 200)
+	c, err := b.Build(context.Background(), Request{SessionID: "s", Agent: "small", History: turns, Store: store, Config: cfg})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if c.SummaryErr != nil {
+		t.Fatalf("SummaryErr = %v", c.SummaryErr)
+	}
+	if c.Summary != "summary #1" || c.Summarized == 0 || c.Summarized+len(c.Turns) != len(turns) {
+		t.Fatalf("context = summary %q, summarized %d, turns %d", c.Summary, c.Summarized, len(c.Turns))
+	}
+	if c.SummarizedThrough != turns[c.Summarized-1].ID || c.Turns[0].ID != turns[c.Summarized].ID {
+		t.Errorf("summary boundary = %s, first turn %s", c.SummarizedThrough, c.Turns[0].ID)
+	}
+	if c.Tokens > c.Budget {
+		t.Errorf("Tokens = %d, over budget %d", c.Tokens, c.Budget)
+	}
+	if !strings.Contains(c.Transcript(), "[summary of") || !strings.Contains(c.Notice(), "earlier messages summarized") {
+		t.Errorf("transcript/notice do not mention the summary: %q", c.Notice())
+	}
+	saved := store.summaries["s"]
+	if saved == nil || saved.ThroughMessageID != c.SummarizedThrough || saved.Agent != "cheap" {
+		t.Fatalf("saved summary = %+v", saved)
+	}
+
+	// The same history reuses the stored summary.
+	if _, err := b.Build(context.Background(), Request{SessionID: "s", Agent: "small", History: turns, Store: store, Config: cfg}); err != nil {
+		t.Fatal(err)
+	}
+	if len(*prompts) != 1 {
+		t.Fatalf("summarizer calls = %d, want 1", len(*prompts))
+	}
+
+	// New turns only send the newly evicted turns to the summarizer.
+	turns = append(turns, history(24, 200)[20:]...)
+	c, err = b.Build(context.Background(), Request{SessionID: "s", Agent: "small", History: turns, Store: store, Config: cfg})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*prompts) != 2 || c.Summary != "summary #2" {
+		t.Fatalf("calls = %d, summary = %q", len(*prompts), c.Summary)
+	}
+	last := (*prompts)[1]
+	if !strings.Contains(last, "summary #1") || strings.Contains(last, "turn 0 ") {
+		t.Errorf("incremental prompt should build on the previous summary only:\n%.300s", last)
+	}
+}
+
+func TestBuild_TruncatesPinnedTurns(t *testing.T) {
+	t.Parallel()
+	reg, _ := newRegistry(4000)
+	b := NewBuilder(reg)
+
+	// A single huge paste in the most recent turn.
+	turns := []Turn{{ID: "a", Role: "user", Content: strings.Repeat("y", 40000)}}
+	c, err := b.Build(context.Background(), Request{SessionID: "s", Agent: "small", History: turns})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Turns) != 1 || c.Truncated != 1 || c.Summarized != 0 {
+		t.Fatalf("context = turns %d, truncated %d, summarized %d", len(c.Turns), c.Truncated, c.Summarized)
+	}
+	if !strings.Contains(c.Turns[0].Content, "characters omitted") || c.Tokens > c.Budget {
+		t.Errorf("turn was not shortened to the budget: %d tokens of %d", c.Tokens, c.Budget)
+	}
+}
+
+func TestBuild_SummaryFailure(t *testing.T) {
+	t.Parallel()
+	reg, _ := newRegistry(4000)
+	reg.Add("broken", testutil.NewMockAgent("broken").WithError(errors.New("quota exceeded")))
+	b := NewBuilder(reg)
+
+	c, err := b.Build(context.Background(), Request{
+		SessionID: "s",
+		Agent:     "small",
+		History:   history(20, 200),
+		Config:    config.ChatContextConfig{SummaryAgent: "broken"},
+	})
+	if err != nil {
+		t.Fatalf("Build() error = %v, want the failure reported in the context", err)
+	}
+	if c.SummaryErr == nil || c.Omitted == 0 || c.Summary != "" {
+		t.Errorf("context = %+v, want omitted turns and a summary error", c)
+	}
+	if !strings.Contains(c.Notice(), "omitted") {
+		t.Errorf("Notice() = %q", c.Notice())
+	}
+}
+
+func TestTokenBudget(t *testing.T) {
+	t.Parallel()
+
+	caps := core.Capabilities{MaxContextTokens: 200000, MaxOutputTokens: 128000}
+	if got := tokenBudget(caps, "", 0); got != 150000 {
+		t.Errorf("budget = %d, want output reserve capped at a quarter of the window", got)
+	}
+	caps = core.Capabilities{MaxContextTokens: 128000, MaxOutputTokens: 8192}
+	if got := tokenBudget(caps, "qwen2.5-coder:32b", 0); got != 32768-8192 {
+		t.Errorf("budget = %d, want the model's own window", got)
+	}
+	if got := tokenBudget(caps, "", 20000); got != 20000 {
+		t.Errorf("budget = %d, want max_tokens cap", got)
+	}
+}
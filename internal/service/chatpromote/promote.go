@@ -0,0 +1,307 @@
+// Package chatpromote turns a chat conversation into a workflow. An agent
+// drafts the workflow title and prompt from the discussion for the user to
+// review, and the transcript and the session attachments are carried over to
+// the workflow as attachments.
+package chatpromote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/attachments"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service"
+)
+
+const (
+	// maxDraftTranscriptChars bounds the conversation embedded in the draft
+	// prompt; older messages are left out first.
+	maxDraftTranscriptChars = 60000
+	// maxDraftMessageChars bounds a single message in the draft prompt.
+	maxDraftMessageChars = 8000
+	// maxTitleLen bounds the drafted workflow title.
+	maxTitleLen = 80
+	// transcriptFilename names the transcript attachment.
+	transcriptFilename = "chat-transcript.md"
+)
+
+// draftPattern splits the drafter's output into its frontmatter and the prompt.
+var draftPattern = regexp.MustCompile(`(?s)^\s*---\s*\n(.*?)\n---\s*\n?(.*)$`)
+
+// Conversation is a chat session with its messages.
+type Conversation struct {
+	Session  *core.ChatSessionState
+	Messages []*core.ChatMessageState
+	// Title is the reviewed workflow title; empty uses the session title.
+	Title string
+}
+
+// Target is the agent, and optionally the model, that drafts the prompt.
+type Target struct {
+	Agent string
+	Model string
+}
+
+// DraftTarget returns the agent that drafts workflow prompts: the chat
+// summary agent when configured, otherwise the session's agent and model.
+func DraftTarget(cfg config.ChatContextConfig, agent, model string) Target {
+	if cfg.SummaryAgent != "" {
+		return Target{Agent: cfg.SummaryAgent, Model: cfg.SummaryModel}
+	}
+	if cfg.SummaryModel != "" {
+		model = cfg.SummaryModel
+	}
+	return Target{Agent: agent, Model: model}
+}
+
+// Draft is a proposed workflow for a conversation, reviewed by the user
+// before the workflow is created.
+type Draft struct {
+	Title     string
+	Prompt    string
+	Agent     string
+	Model     string
+	TokensIn  int
+	TokensOut int
+}
+
+// Drafter writes workflow prompts from chat conversations.
+type Drafter struct {
+	agents  core.AgentRegistry
+	prompts *service.PromptRenderer
+}
+
+// NewDrafter creates a Drafter that resolves agents from the registry.
+func NewDrafter(agents core.AgentRegistry) (*Drafter, error) {
+	prompts, err := service.NewPromptRenderer()
+	if err != nil {
+		return nil, err
+	}
+	return &Drafter{agents: agents, prompts: prompts}, nil
+}
+
+// Draft asks the target agent for a workflow title and prompt that carry
+// over the requirements and decisions of the conversation.
+func (d *Drafter) Draft(ctx context.Context, conv *Conversation, target Target) (*Draft, error) {
+	if d.agents == nil {
+		return nil, fmt.Errorf("no agent registry configured")
+	}
+	transcript, omitted := conv.draftTranscript()
+	if transcript == "" {
+		return nil, fmt.Errorf("the conversation has no messages to promote")
+	}
+	agent, err := d.agents.Get(target.Agent)
+	if err != nil {
+		return nil, fmt.Errorf("agent %s not available: %w", target.Agent, err)
+	}
+
+	prompt, err := d.prompts.RenderChatPromote(service.ChatPromoteParams{
+		Title:      conv.sessionTitle(),
+		Transcript: transcript,
+		Omitted:    omitted,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rendering promote prompt: %w", err)
+	}
+	result, err := agent.Execute(ctx, core.ExecuteOptions{
+		Prompt: prompt,
+		Model:  target.Model,
+		Format: core.OutputFormatText,
+		// No phase: drafting from a chat is not refine-phase work, and phase
+		// settings (sandbox, models, remote routing) must not apply to it.
+	})
+	if err != nil {
+		return nil, fmt.Errorf("drafting with %s: %w", target.Agent, err)
+	}
+
+	title, body := parseDraft(result.Output)
+	if body == "" {
+		return nil, fmt.Errorf("drafting with %s: empty prompt", target.Agent)
+	}
+	if title == "" {
+		title = conv.sessionTitle()
+	}
+	model := target.Model
+	if result.Model != "" {
+		model = result.Model
+	}
+	return &Draft{
+		Title:     title,
+		Prompt:    body,
+		Agent:     target.Agent,
+		Model:     model,
+		TokensIn:  result.TokensIn,
+		TokensOut: result.TokensOut,
+	}, nil
+}
+
+// parseDraft extracts the title frontmatter and the prompt from the
+// drafter's output. Output without frontmatter is taken as the prompt.
+func parseDraft(output string) (title, prompt string) {
+	output = strings.TrimSpace(output)
+	m := draftPattern.FindStringSubmatch(output)
+	if m == nil {
+		return "", output
+	}
+	for _, line := range strings.Split(m[1], "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if ok && strings.TrimSpace(key) == "title" {
+			title = strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+	if r := []rune(title); len(r) > maxTitleLen {
+		title = strings.TrimSpace(string(r[:maxTitleLen]))
+	}
+	return title, strings.TrimSpace(m[2])
+}
+
+// draftTranscript renders the newest messages that fit the draft prompt,
+// oldest first, and reports how many older messages were left out.
+func (c *Conversation) draftTranscript() (string, int) {
+	var parts []string
+	used := 0
+	omitted := 0
+	for i := len(c.Messages) - 1; i >= 0; i-- {
+		msg := c.Messages[i]
+		if msg.Role == "system" || strings.TrimSpace(msg.Content) == "" {
+			continue
+		}
+		if omitted > 0 {
+			omitted++
+			continue
+		}
+		content := strings.TrimSpace(msg.Content)
+		if len(content) > maxDraftMessageChars {
+			content = content[:maxDraftMessageChars] + "\n[…truncated]"
+		}
+		part := fmt.Sprintf("[%s]: %s", speaker(msg), content)
+		if len(parts) > 0 && used+len(part) > maxDraftTranscriptChars {
+			omitted++
+			continue
+		}
+		parts = append(parts, part)
+		used += len(part)
+	}
+	for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+		parts[i], parts[j] = parts[j], parts[i]
+	}
+	return strings.Join(parts, "\n\n"), omitted
+}
+
+// Link returns the reference stored on the workflow. transcriptID is the
+// workflow attachment holding the transcript, if it was saved.
+func (c *Conversation) Link(transcriptID string) *core.ChatLink {
+	return &core.ChatLink{
+		SessionID:            c.Session.ID,
+		Title:                c.Session.Title,
+		TranscriptAttachment: transcriptID,
+	}
+}
+
+// LinkSession records the workflow on the chat session in store, so the
+// chat can follow the workflow's progress.
+func (c *Conversation) LinkSession(ctx context.Context, store core.ChatStore, workflowID core.WorkflowID) error {
+	c.Session.WorkflowID = string(workflowID)
+	c.Session.UpdatedAt = time.Now()
+	return store.SaveSession(ctx, c.Session)
+}
+
+// Prompt returns the workflow prompt for the reviewed draft prompt, with a
+// pointer to the attached transcript.
+func (c *Conversation) Prompt(draft string) string {
+	return fmt.Sprintf("%s\n\nThis workflow was promoted from a chat conversation; the full transcript is attached as %s.\n",
+		strings.TrimSpace(draft), transcriptFilename)
+}
+
+// TranscriptMarkdown renders the full conversation.
+func (c *Conversation) TranscriptMarkdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Chat: %s\n\n", c.sessionTitle())
+	fmt.Fprintf(&b, "Session `%s`, %d messages.\n", c.Session.ID, len(c.Messages))
+	for _, msg := range c.Messages {
+		heading := speaker(msg)
+		if msg.Model != "" {
+			heading += " (" + msg.Model + ")"
+		}
+		fmt.Fprintf(&b, "\n## %s · %s\n\n%s\n", heading, msg.Timestamp.UTC().Format(time.RFC3339), strings.TrimSpace(msg.Content))
+		if len(msg.Attachments) > 0 {
+			fmt.Fprintf(&b, "\nAttachments: %s\n", strings.Join(msg.Attachments, ", "))
+		}
+	}
+	return b.String()
+}
+
+// SaveAttachments stores the transcript and copies of the session's
+// attachments as workflow attachments. The transcript is the first
+// attachment returned. Attachments that cannot be copied are reported in the
+// error while the others are still returned.
+func (c *Conversation) SaveAttachments(store *attachments.Store, workflowID core.WorkflowID) ([]core.Attachment, error) {
+	transcript, err := store.Save(attachments.OwnerWorkflow, string(workflowID),
+		strings.NewReader(c.TranscriptMarkdown()), transcriptFilename)
+	if err != nil {
+		return nil, fmt.Errorf("saving chat transcript: %w", err)
+	}
+	saved := []core.Attachment{transcript}
+
+	sessionAttachments, err := store.List(attachments.OwnerChatSession, c.Session.ID)
+	if err != nil {
+		return saved, fmt.Errorf("listing chat attachments: %w", err)
+	}
+	var errs []error
+	for _, att := range sessionAttachments {
+		copied, err := copyAttachment(store, c.Session.ID, att, workflowID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("copying %s: %w", att.Name, err))
+			continue
+		}
+		saved = append(saved, copied)
+	}
+	return saved, errors.Join(errs...)
+}
+
+func copyAttachment(store *attachments.Store, sessionID string, att core.Attachment, workflowID core.WorkflowID) (core.Attachment, error) {
+	_, path, err := store.Resolve(attachments.OwnerChatSession, sessionID, att.ID)
+	if err != nil {
+		return core.Attachment{}, err
+	}
+	f, err := os.Open(path) // #nosec G304 -- path resolved by the attachment store
+	if err != nil {
+		return core.Attachment{}, err
+	}
+	defer f.Close()
+	return store.Save(attachments.OwnerWorkflow, string(workflowID), f, att.Name)
+}
+
+// WorkflowTitle returns the title of the promoted workflow.
+func (c *Conversation) WorkflowTitle() string {
+	if c.Title != "" {
+		return c.Title
+	}
+	return c.sessionTitle()
+}
+
+func (c *Conversation) sessionTitle() string {
+	if c.Session.Title != "" {
+		return c.Session.Title
+	}
+	return "Chat " + c.Session.ID
+}
+
+func speaker(msg *core.ChatMessageState) string {
+	switch msg.Role {
+	case "user":
+		return "user"
+	case "system":
+		return "system"
+	}
+	if msg.Agent != "" {
+		return msg.Agent
+	}
+	return "assistant"
+}
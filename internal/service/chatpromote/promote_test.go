@@ -0,0 +1,152 @@
+package chatpromote
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/attachments"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/testutil"
+)
+
+func conversation() *Conversation {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	return &Conversation{
+		Session: &core.ChatSessionState{ID: "chat-1", Title: "Retries", Agent: "claude"},
+		Messages: []*core.ChatMessageState{
+			{ID: "m1", Role: "user", Content: "Should the client retry on 503?", Timestamp: now},
+			{ID: "m2", Role: "agent", Agent: "claude", Model: "opus", Content: "Yes, with exponential backoff.", Timestamp: now.Add(time.Minute)},
+			{ID: "m3", Role: "system", Content: "gemini failed: rate limited", Timestamp: now.Add(time.Minute)},
+			{ID: "m4", Role: "user", Content: "Cap it at 5 attempts.", Timestamp: now.Add(2 * time.Minute), Attachments: []string{"client.go"}},
+		},
+	}
+}
+
+func TestDraft(t *testing.T) {
+	t.Parallel()
+	var prompt string
+	reg := testutil.NewMockRegistry()
+	reg.Add("gemini", testutil.NewMockAgent("gemini").WithExecuteFunc(
+		func(_ context.Context, opts core.ExecuteOptions) (*core.ExecuteResult, error) {
+			if opts.Phase != "" {
+				return nil, fmt.Errorf("draft requested for phase %s", opts.Phase)
+			}
+			prompt = opts.Prompt
+			return &core.ExecuteResult{
+				Output: "---\ntitle: \"Retry 503 responses\"\n---\n\nAdd retries with backoff, at most 5 attempts.",
+				Model:  "gemini-2.5-flash",
+			}, nil
+		}))
+
+	drafter, err := NewDrafter(reg)
+	if err != nil {
+		t.Fatalf("NewDrafter() error = %v", err)
+	}
+	draft, err := drafter.Draft(context.Background(), conversation(), Target{Agent: "gemini"})
+	if err != nil {
+		t.Fatalf("Draft() error = %v", err)
+	}
+	if draft.Title != "Retry 503 responses" || draft.Prompt != "Add retries with backoff, at most 5 attempts." {
+		t.Errorf("draft = %+v", draft)
+	}
+	if draft.Agent != "gemini" || draft.Model != "gemini-2.5-flash" {
+		t.Errorf("draft agent = %s/%s", draft.Agent, draft.Model)
+	}
+	for _, want := range []string{"[user]: Should the client retry", "[claude]: Yes, with exponential backoff.", "Retries"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("draft prompt should contain %q", want)
+		}
+	}
+	if strings.Contains(prompt, "rate limited") {
+		t.Error("draft prompt should skip system messages")
+	}
+
+	if _, err := drafter.Draft(context.Background(), &Conversation{Session: &core.ChatSessionState{ID: "empty"}}, Target{Agent: "gemini"}); err == nil {
+		t.Error("Draft() should fail for an empty conversation")
+	}
+}
+
+func TestParseDraft(t *testing.T) {
+	t.Parallel()
+	title, prompt := parseDraft("Just a prompt without frontmatter.")
+	if title != "" || prompt != "Just a prompt without frontmatter." {
+		t.Errorf("parseDraft(plain) = %q, %q", title, prompt)
+	}
+	title, prompt = parseDraft("---\ntitle: " + strings.Repeat("x", 100) + "\n---\nBody")
+	if len(title) != maxTitleLen || prompt != "Body" {
+		t.Errorf("parseDraft(long title) = %q, %q", title, prompt)
+	}
+}
+
+func TestDraftTranscript_OmitsOldestMessages(t *testing.T) {
+	t.Parallel()
+	conv := &Conversation{Session: &core.ChatSessionState{ID: "s"}}
+	for i := 0; i < 20; i++ {
+		conv.Messages = append(conv.Messages, &core.ChatMessageState{Role: "user", Content: strings.Repeat("a", maxDraftMessageChars)})
+	}
+	conv.Messages = append(conv.Messages, &core.ChatMessageState{Role: "user", Content: "latest"})
+
+	transcript, omitted := conv.draftTranscript()
+	if omitted == 0 || len(transcript) > maxDraftTranscriptChars+100 {
+		t.Errorf("omitted = %d, transcript = %d chars", omitted, len(transcript))
+	}
+	if !strings.HasSuffix(transcript, "[user]: latest") {
+		t.Error("transcript should keep the newest message last")
+	}
+}
+
+func TestDraftTarget(t *testing.T) {
+	t.Parallel()
+	if got := DraftTarget(config.ChatContextConfig{}, "claude", "opus"); got != (Target{Agent: "claude", Model: "opus"}) {
+		t.Errorf("DraftTarget(default) = %+v", got)
+	}
+	got := DraftTarget(config.ChatContextConfig{SummaryAgent: "gemini", SummaryModel: "flash"}, "claude", "opus")
+	if got != (Target{Agent: "gemini", Model: "flash"}) {
+		t.Errorf("DraftTarget(summary agent) = %+v", got)
+	}
+}
+
+func TestSaveAttachments(t *testing.T) {
+	t.Parallel()
+	store := attachments.NewStore(t.TempDir())
+	conv := conversation()
+	if _, err := store.Save(attachments.OwnerChatSession, "chat-1", strings.NewReader("log line"), "trace.log"); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	saved, err := conv.SaveAttachments(store, "wf-1")
+	if err != nil {
+		t.Fatalf("SaveAttachments() error = %v", err)
+	}
+	if len(saved) != 2 || saved[0].Name != transcriptFilename || saved[1].Name != "trace.log" {
+		t.Fatalf("saved = %+v", saved)
+	}
+
+	listed, err := store.List(attachments.OwnerWorkflow, "wf-1")
+	if err != nil || len(listed) != 2 {
+		t.Fatalf("workflow attachments = %+v, %v", listed, err)
+	}
+	data, err := os.ReadFile(filepath.Join(store.Root(), filepath.FromSlash(saved[0].Path)))
+	if err != nil {
+		t.Fatalf("reading transcript: %v", err)
+	}
+	for _, want := range []string{"# Chat: Retries", "## claude (opus)", "Cap it at 5 attempts.", "Attachments: client.go"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("transcript should contain %q:\n%s", want, data)
+		}
+	}
+
+	link := conv.Link(saved[0].ID)
+	if link.SessionID != "chat-1" || link.Title != "Retries" || link.TranscriptAttachment != saved[0].ID {
+		t.Errorf("Link() = %+v", link)
+	}
+	if p := conv.Prompt("  Add retries.\n"); !strings.HasPrefix(p, "Add retries.\n\n") || !strings.Contains(p, transcriptFilename) {
+		t.Errorf("Prompt() = %q", p)
+	}
+}
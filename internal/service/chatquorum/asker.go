@@ -0,0 +1,287 @@
+// Package chatquorum asks several agents the same chat question in parallel
+// and has a moderator agent score their agreement and synthesize one answer.
+//
+// It brings the consensus approach of the analyze phase to quick questions
+// that do not warrant a workflow.
+package chatquorum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/workflow"
+)
+
+// maxModeratedAnswerChars bounds each answer embedded in the moderator prompt.
+const maxModeratedAnswerChars = 24000
+
+// finalScorePattern matches the score anchor the synthesis prompt ends with.
+var finalScorePattern = regexp.MustCompile(`(?m)^\s*>>\s*FINAL\s*SCORE\s*:.*<<\s*$`)
+
+// Target is an agent, and optionally the model, asked a question.
+type Target struct {
+	Agent string
+	Model string
+}
+
+// Request describes a question asked to several agents.
+type Request struct {
+	// Question is the user's message, shown to the moderator.
+	Question string
+	// Context is the recent conversation shown to the moderator (optional).
+	Context string
+	// Targets are the agents asked, in display order. At least two are required.
+	Targets []Target
+	// Options builds the execution options (prompt, conversation history,
+	// working directory) for one target.
+	Options func(ctx context.Context, t Target) (core.ExecuteOptions, error)
+	// Moderator synthesizes the answers. Defaults to the first target.
+	Moderator Target
+}
+
+// Answer is one agent's reply.
+type Answer struct {
+	Target
+	Content   string
+	TokensIn  int
+	TokensOut int
+	Duration  time.Duration
+	// Err is set when the agent failed to answer.
+	Err error
+}
+
+// Synthesis is the moderator's verdict on the answers.
+type Synthesis struct {
+	Target
+	// Content is the synthesized answer with its agreements and divergences.
+	Content string
+	// Score is the agreement between the answers (0-1); valid if ScoreFound.
+	Score      float64
+	ScoreFound bool
+	// Divergences are the points where the answers disagree.
+	Divergences []string
+	TokensIn    int
+	TokensOut   int
+	// Err is set when the moderator failed; the answers are still usable.
+	Err error
+}
+
+// Result holds the answers to a question and their synthesis.
+type Result struct {
+	// Answers are in the order of Request.Targets.
+	Answers []Answer
+	// Synthesis is nil when fewer than two agents answered.
+	Synthesis *Synthesis
+}
+
+// Asker asks questions to several agents.
+type Asker struct {
+	agents  core.AgentRegistry
+	prompts *service.PromptRenderer
+}
+
+// NewAsker creates an Asker that resolves agents from the registry.
+func NewAsker(agents core.AgentRegistry) (*Asker, error) {
+	prompts, err := service.NewPromptRenderer()
+	if err != nil {
+		return nil, err
+	}
+	return &Asker{agents: agents, prompts: prompts}, nil
+}
+
+// Ask sends the question to every target in parallel, then asks the moderator
+// to synthesize the answers. It fails only when no agent answers.
+func (a *Asker) Ask(ctx context.Context, req Request) (*Result, error) {
+	if a.agents == nil {
+		return nil, fmt.Errorf("no agent registry configured")
+	}
+	if len(req.Targets) < 2 {
+		return nil, fmt.Errorf("asking all agents needs at least two agents, got %d", len(req.Targets))
+	}
+	if req.Options == nil {
+		return nil, fmt.Errorf("no execute options builder configured")
+	}
+
+	result := &Result{Answers: make([]Answer, len(req.Targets))}
+	var wg sync.WaitGroup
+	for i, target := range req.Targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result.Answers[i] = a.answer(ctx, req, target)
+		}()
+	}
+	wg.Wait()
+
+	var answered []Answer
+	var errs []error
+	for _, ans := range result.Answers {
+		if ans.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", ans.Agent, ans.Err))
+			continue
+		}
+		answered = append(answered, ans)
+	}
+	if len(answered) == 0 {
+		return nil, fmt.Errorf("no agent answered: %w", errors.Join(errs...))
+	}
+	if len(answered) >= 2 {
+		result.Synthesis = a.synthesize(ctx, req, answered)
+	}
+	return result, nil
+}
+
+// answer asks one target.
+func (a *Asker) answer(ctx context.Context, req Request, target Target) Answer {
+	ans := Answer{Target: target}
+	agent, err := a.agents.Get(target.Agent)
+	if err != nil {
+		ans.Err = fmt.Errorf("agent not available: %w", err)
+		return ans
+	}
+	opts, err := req.Options(ctx, target)
+	if err != nil {
+		ans.Err = err
+		return ans
+	}
+
+	start := time.Now()
+	res, err := agent.Execute(ctx, opts)
+	ans.Duration = time.Since(start)
+	if err != nil {
+		ans.Err = err
+		return ans
+	}
+	ans.Content = res.Output
+	ans.TokensIn = res.TokensIn
+	ans.TokensOut = res.TokensOut
+	if res.Model != "" {
+		ans.Model = res.Model
+	}
+	return ans
+}
+
+// synthesize asks the moderator to compare the answers.
+func (a *Asker) synthesize(ctx context.Context, req Request, answers []Answer) *Synthesis {
+	moderator := req.Moderator
+	if moderator.Agent == "" {
+		moderator = Target{Agent: req.Targets[0].Agent}
+	}
+	syn := &Synthesis{Target: moderator}
+
+	agent, err := a.agents.Get(moderator.Agent)
+	if err != nil {
+		syn.Err = fmt.Errorf("moderator %s not available: %w", moderator.Agent, err)
+		return syn
+	}
+
+	responses := make([]service.ChatSynthesisResponse, 0, len(answers))
+	for _, ans := range answers {
+		content := ans.Content
+		if len(content) > maxModeratedAnswerChars {
+			content = strings.ToValidUTF8(content[:maxModeratedAnswerChars], "") + "\n\n[... answer truncated ...]"
+		}
+		responses = append(responses, service.ChatSynthesisResponse{AgentName: ans.Agent, Content: content})
+	}
+	prompt, err := a.prompts.RenderChatSynthesize(service.ChatSynthesizeParams{
+		Question:  req.Question,
+		Context:   req.Context,
+		Responses: responses,
+	})
+	if err != nil {
+		syn.Err = err
+		return syn
+	}
+
+	res, err := agent.Execute(ctx, core.ExecuteOptions{
+		Prompt: prompt,
+		Model:  moderator.Model,
+		Format: core.OutputFormatText,
+		// No phase: phase settings (sandbox, models, remote routing) are
+		// meant for workflow work, not a chat answer.
+	})
+	if err != nil {
+		syn.Err = fmt.Errorf("moderator %s: %w", moderator.Agent, err)
+		return syn
+	}
+
+	eval := workflow.ParseModeratorOutput(res.Output)
+	syn.Content = synthesisBody(res.Output)
+	syn.Score = eval.Score
+	syn.ScoreFound = eval.ScoreFound
+	for _, d := range eval.Divergences {
+		if !strings.EqualFold(strings.TrimSuffix(d.Description, "."), "none") {
+			syn.Divergences = append(syn.Divergences, d.Description)
+		}
+	}
+	syn.TokensIn = res.TokensIn
+	syn.TokensOut = res.TokensOut
+	if res.Model != "" {
+		syn.Model = res.Model
+	}
+	return syn
+}
+
+// synthesisBody strips the score frontmatter and anchor from a moderator
+// response, leaving the Markdown shown to the user.
+func synthesisBody(output string) string {
+	body := strings.TrimSpace(output)
+	if rest, ok := strings.CutPrefix(body, "---\n"); ok {
+		if end := strings.Index(rest, "\n---"); end >= 0 {
+			body = rest[end+len("\n---"):]
+		}
+	}
+	body = finalScorePattern.ReplaceAllString(body, "")
+	return strings.TrimSpace(body)
+}
+
+// Targets resolves the agents to ask. requested takes precedence over the
+// configured agents; when both are empty, every available agent is asked.
+// Unknown or unavailable agents are rejected, and duplicates are dropped.
+func Targets(requested []string, cfg config.ChatAskAllConfig, available []string) ([]Target, error) {
+	names := requested
+	if len(names) == 0 {
+		names = cfg.Agents
+	}
+	if len(names) == 0 {
+		names = available
+	}
+
+	var targets []Target
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		if !slices.Contains(available, name) {
+			return nil, fmt.Errorf("agent %s is not available (available: %s)", name, strings.Join(available, ", "))
+		}
+		seen[name] = true
+		targets = append(targets, Target{Agent: name})
+	}
+	if len(targets) < 2 {
+		return nil, fmt.Errorf("asking all agents needs at least two available agents, got %d", len(targets))
+	}
+	return targets, nil
+}
+
+// Moderator returns the configured moderator, or the first target.
+func Moderator(cfg config.ChatAskAllConfig, targets []Target) Target {
+	if cfg.Moderator != "" {
+		return Target{Agent: cfg.Moderator, Model: cfg.ModeratorModel}
+	}
+	if len(targets) == 0 {
+		return Target{}
+	}
+	return Target{Agent: targets[0].Agent, Model: cfg.ModeratorModel}
+}
@@ -0,0 +1,185 @@
+package chatquorum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/testutil"
+)
+
+const moderatorOutput = `---
+consensus_score: 70
+---
+
+## Answer
+Prefer a mutex for simple shared state.
+
+## Agreements
+- Both work
+
+## Divergences
+- Default choice: claude: mutex; gemini: channel
+
+>> FINAL SCORE: 70 <<`
+
+func newAsker(t *testing.T, reg *testutil.MockRegistry) *Asker {
+	t.Helper()
+	asker, err := NewAsker(reg)
+	if err != nil {
+		t.Fatalf("NewAsker() error = %v", err)
+	}
+	return asker
+}
+
+func reply(output string) func(context.Context, core.ExecuteOptions) (*core.ExecuteResult, error) {
+	return func(_ context.Context, opts core.ExecuteOptions) (*core.ExecuteResult, error) {
+		if strings.Contains(opts.Prompt, "consensus_score") {
+			if opts.Phase != "" {
+				return nil, fmt.Errorf("synthesis requested for phase %s", opts.Phase)
+			}
+			return &core.ExecuteResult{Output: moderatorOutput, Model: "judge-model"}, nil
+		}
+		return &core.ExecuteResult{Output: output, TokensIn: 5, TokensOut: 7}, nil
+	}
+}
+
+func promptOptions(_ context.Context, t Target) (core.ExecuteOptions, error) {
+	return core.ExecuteOptions{Prompt: "mutex or channel? (" + t.Agent + ")"}, nil
+}
+
+func TestAsk_AnswersAndSynthesis(t *testing.T) {
+	t.Parallel()
+	reg := testutil.NewMockRegistry()
+	reg.Add("claude", testutil.NewMockAgent("claude").WithExecuteFunc(reply("mutex")))
+	reg.Add("gemini", testutil.NewMockAgent("gemini").WithExecuteFunc(reply("channel")))
+
+	res, err := newAsker(t, reg).Ask(context.Background(), Request{
+		Question:  "mutex or channel?",
+		Targets:   []Target{{Agent: "claude"}, {Agent: "gemini", Model: "flash"}},
+		Options:   promptOptions,
+		Moderator: Target{Agent: "gemini"},
+	})
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if len(res.Answers) != 2 || res.Answers[0].Content != "mutex" || res.Answers[1].Content != "channel" {
+		t.Fatalf("answers = %+v", res.Answers)
+	}
+	if res.Answers[1].Model != "flash" || res.Answers[0].TokensOut != 7 {
+		t.Errorf("answer details = %+v", res.Answers)
+	}
+
+	syn := res.Synthesis
+	if syn == nil || syn.Err != nil {
+		t.Fatalf("synthesis = %+v", syn)
+	}
+	if !syn.ScoreFound || syn.Score != 0.7 || syn.Agent != "gemini" || syn.Model != "judge-model" {
+		t.Errorf("synthesis = %+v", syn)
+	}
+	if len(syn.Divergences) != 1 || !strings.Contains(syn.Divergences[0], "Default choice") {
+		t.Errorf("divergences = %v", syn.Divergences)
+	}
+	if strings.Contains(syn.Content, "consensus_score") || strings.Contains(syn.Content, "FINAL SCORE") ||
+		!strings.HasPrefix(syn.Content, "## Answer") {
+		t.Errorf("content should be the Markdown body only:\n%s", syn.Content)
+	}
+}
+
+func TestAsk_PartialFailure(t *testing.T) {
+	t.Parallel()
+	reg := testutil.NewMockRegistry()
+	reg.Add("claude", testutil.NewMockAgent("claude").WithExecuteFunc(reply("mutex")))
+	reg.Add("gemini", testutil.NewMockAgent("gemini").WithError(errors.New("rate limited")))
+	reg.Add("codex", testutil.NewMockAgent("codex").WithExecuteFunc(reply("mutex too")))
+
+	res, err := newAsker(t, reg).Ask(context.Background(), Request{
+		Question: "q",
+		Targets:  []Target{{Agent: "claude"}, {Agent: "gemini"}, {Agent: "codex"}},
+		Options:  promptOptions,
+	})
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if res.Answers[1].Err == nil {
+		t.Error("gemini answer should carry its error")
+	}
+	if res.Synthesis == nil || res.Synthesis.Agent != "claude" {
+		t.Errorf("synthesis = %+v, want the first target as default moderator", res.Synthesis)
+	}
+
+	// A single answer has nothing to synthesize.
+	res, err = newAsker(t, reg).Ask(context.Background(), Request{
+		Question: "q",
+		Targets:  []Target{{Agent: "claude"}, {Agent: "gemini"}},
+		Options:  promptOptions,
+	})
+	if err != nil || res.Synthesis != nil {
+		t.Errorf("Ask() = %+v, %v; want one answer and no synthesis", res, err)
+	}
+
+	// No answer at all fails.
+	reg.Add("claude", testutil.NewMockAgent("claude").WithError(errors.New("down")))
+	if _, err := newAsker(t, reg).Ask(context.Background(), Request{
+		Question: "q",
+		Targets:  []Target{{Agent: "claude"}, {Agent: "gemini"}},
+		Options:  promptOptions,
+	}); err == nil || !strings.Contains(err.Error(), "no agent answered") {
+		t.Errorf("Ask() error = %v", err)
+	}
+}
+
+func TestAsk_ModeratorFailure(t *testing.T) {
+	t.Parallel()
+	reg := testutil.NewMockRegistry()
+	reg.Add("claude", testutil.NewMockAgent("claude").WithExecuteFunc(reply("mutex")))
+	reg.Add("gemini", testutil.NewMockAgent("gemini").WithExecuteFunc(reply("channel")))
+
+	res, err := newAsker(t, reg).Ask(context.Background(), Request{
+		Question:  "q",
+		Targets:   []Target{{Agent: "claude"}, {Agent: "gemini"}},
+		Options:   promptOptions,
+		Moderator: Target{Agent: "codex"},
+	})
+	if err != nil {
+		t.Fatalf("Ask() error = %v, want answers despite the moderator failure", err)
+	}
+	if res.Synthesis == nil || res.Synthesis.Err == nil {
+		t.Errorf("synthesis = %+v, want a moderator error", res.Synthesis)
+	}
+}
+
+func TestTargets(t *testing.T) {
+	t.Parallel()
+	available := []string{"claude", "gemini", "codex"}
+
+	targets, err := Targets(nil, config.ChatAskAllConfig{}, available)
+	if err != nil || len(targets) != 3 {
+		t.Errorf("Targets(default) = %v, %v; want every available agent", targets, err)
+	}
+	targets, err = Targets(nil, config.ChatAskAllConfig{Agents: []string{"gemini", "claude"}}, available)
+	if err != nil || targets[0].Agent != "gemini" || len(targets) != 2 {
+		t.Errorf("Targets(config) = %v, %v", targets, err)
+	}
+	targets, err = Targets([]string{"Codex", "claude", "codex"}, config.ChatAskAllConfig{Agents: []string{"gemini"}}, available)
+	if err != nil || len(targets) != 2 || targets[0].Agent != "codex" {
+		t.Errorf("Targets(requested) = %v, %v", targets, err)
+	}
+	if _, err := Targets([]string{"claude", "copilot"}, config.ChatAskAllConfig{}, available); err == nil {
+		t.Error("Targets() should reject unavailable agents")
+	}
+	if _, err := Targets([]string{"claude"}, config.ChatAskAllConfig{}, available); err == nil {
+		t.Error("Targets() should require two agents")
+	}
+
+	if m := Moderator(config.ChatAskAllConfig{ModeratorModel: "opus"}, targets); m.Agent != "codex" || m.Model != "opus" {
+		t.Errorf("Moderator(default) = %+v", m)
+	}
+	if m := Moderator(config.ChatAskAllConfig{Moderator: "gemini"}, targets); m.Agent != "gemini" {
+		t.Errorf("Moderator(configured) = %+v", m)
+	}
+}
@@ -37,6 +37,7 @@ const (
 	CheckpointModeratorRound   CheckpointType = "moderator_round"   // Checkpoint after each moderator evaluation
 	CheckpointAnalysisRound    CheckpointType = "analysis_round"    // Checkpoint after each V(n) refinement
 	CheckpointAnalysisComplete CheckpointType = "analysis_complete" // Per-agent analysis completion with metrics
+	CheckpointRollback         CheckpointType = "rollback"          // Phase reopened by a rollback
 )
 
 // CreateCheckpoint saves a checkpoint at the current state.
@@ -278,7 +279,7 @@ func (m *CheckpointManager) GetResumePoint(state *core.WorkflowState) (*ResumePo
 
 	// Determine restart behavior based on checkpoint type
 	switch CheckpointType(lastCP.Type) {
-	case CheckpointPhaseStart:
+	case CheckpointPhaseStart, CheckpointRollback:
 		resumePoint.RestartPhase = true
 	case CheckpointPhaseComplete:
 		// Phase completed - advance to the next phase
@@ -452,6 +452,21 @@ func TestCheckpointManager_GetResumePoint(t *testing.T) {
 		}
 	})
 
+	t.Run("rollback checkpoint restarts its phase", func(t *testing.T) {
+		state := newTestWorkflowState()
+		state.CurrentPhase = core.PhaseAnalyze
+		manager.PhaseCheckpoint(ctx, state, core.PhaseAnalyze, true)
+		manager.CreateCheckpoint(ctx, state, CheckpointRollback, map[string]interface{}{"from_phase": "execute"})
+
+		resumePoint, err := manager.GetResumePoint(state)
+		if err != nil {
+			t.Fatalf("GetResumePoint() error = %v", err)
+		}
+		if resumePoint.Phase != core.PhaseAnalyze || !resumePoint.RestartPhase {
+			t.Errorf("resume point = %s (restart %v), want a restart of analyze", resumePoint.Phase, resumePoint.RestartPhase)
+		}
+	})
+
 	t.Run("task start checkpoint", func(t *testing.T) {
 		state := newTestWorkflowState()
 		task := &core.Task{ID: "task-1", Name: "Test Task", Status: core.TaskStatusRunning}
@@ -0,0 +1,141 @@
+package ci
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// DefaultPromptEnv is the environment variable read when no prompt file is given.
+const DefaultPromptEnv = "QUORUM_PROMPT"
+
+// WorkflowRunner is the subset of workflow.Runner used by CI mode.
+type WorkflowRunner interface {
+	Run(ctx context.Context, prompt string) error
+	GetState(ctx context.Context) (*core.WorkflowState, error)
+}
+
+// Options configures a headless CI run.
+type Options struct {
+	// Prompt is the workflow prompt.
+	Prompt string
+	// Timeout is a hard limit for the whole workflow (0 = no limit).
+	Timeout time.Duration
+	// MinConsensus fails the run as low consensus when the final moderator
+	// score is below this value (0 = disabled).
+	MinConsensus float64
+	// GitHub posts the PR comment; nil disables commenting.
+	GitHub core.GitHubClient
+	// PRNumber is the pull request to comment on (0 = no comment).
+	PRNumber int
+	// SARIFPath is where findings are written ("" = no SARIF).
+	SARIFPath string
+	// ToolVersion is reported in the SARIF driver.
+	ToolVersion string
+	// Logger receives progress messages; nil uses slog.Default().
+	Logger *slog.Logger
+}
+
+// Run executes the workflow non-interactively and publishes the result.
+// It never returns an error: every failure is folded into the Result so the
+// caller can map it to an exit code.
+func Run(ctx context.Context, runner WorkflowRunner, opts Options) *Result {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	runCtx := ctx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	runErr := runner.Run(runCtx, opts.Prompt)
+	if runErr != nil && runCtx.Err() == context.DeadlineExceeded {
+		runErr = fmt.Errorf("workflow exceeded CI timeout of %s: %w", opts.Timeout, context.DeadlineExceeded)
+	}
+
+	// The run context may be expired; read the final state with a fresh one.
+	stateCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+	defer cancel()
+	state, stateErr := runner.GetState(stateCtx)
+	if stateErr != nil {
+		logger.Warn("ci: loading final workflow state", "error", stateErr)
+	}
+
+	if runErr == nil {
+		runErr = checkConsensus(state, opts.MinConsensus)
+	}
+
+	result := NewResult(state, runErr, time.Since(start))
+	Publish(stateCtx, result, opts, logger)
+	return result
+}
+
+// checkConsensus returns core.ErrHumanReviewRequired when the final moderator
+// score is below minConsensus. Runs without a moderator score are not checked.
+func checkConsensus(state *core.WorkflowState, minConsensus float64) error {
+	if minConsensus <= 0 || state == nil || state.Metrics == nil || state.Metrics.ConsensusScore <= 0 {
+		return nil
+	}
+	if state.Metrics.ConsensusScore < minConsensus {
+		return core.ErrHumanReviewRequired(state.Metrics.ConsensusScore, minConsensus)
+	}
+	return nil
+}
+
+// Publish writes the SARIF report and posts the PR comment configured in opts.
+// A successful run whose results cannot be published is downgraded to an
+// infrastructure error so the pipeline does not go green without its artifacts.
+func Publish(ctx context.Context, r *Result, opts Options, logger *slog.Logger) {
+	if opts.SARIFPath != "" {
+		if err := WriteSARIF(opts.SARIFPath, BuildSARIF(r, opts.ToolVersion)); err != nil {
+			logger.Error("ci: writing SARIF", "path", opts.SARIFPath, "error", err)
+			r.PublishErrors = append(r.PublishErrors, err.Error())
+		} else {
+			logger.Info("ci: SARIF written", "path", opts.SARIFPath)
+		}
+	}
+
+	if opts.GitHub != nil && opts.PRNumber > 0 {
+		if err := opts.GitHub.AddComment(ctx, opts.PRNumber, RenderComment(r)); err != nil {
+			logger.Error("ci: posting PR comment", "pr", opts.PRNumber, "error", err)
+			r.PublishErrors = append(r.PublishErrors, fmt.Sprintf("posting comment on PR #%d: %v", opts.PRNumber, err))
+		} else {
+			logger.Info("ci: PR comment posted", "pr", opts.PRNumber)
+		}
+	}
+
+	if len(r.PublishErrors) > 0 && r.Outcome == OutcomeSuccess {
+		r.Outcome = OutcomeInfraError
+		if r.Error == "" {
+			r.Error = strings.Join(r.PublishErrors, "; ")
+		}
+	}
+}
+
+var githubPullRefRe = regexp.MustCompile(`^refs/pull/(\d+)/`)
+
+// DetectPRNumber returns the pull request number of the current CI job, or 0.
+// It understands GitHub Actions (GITHUB_REF=refs/pull/N/merge) and an explicit
+// QUORUM_PR_NUMBER override. GitLab merge requests are not detected because
+// comments are posted through the gh CLI; GitLab jobs rely on exit codes.
+func DetectPRNumber(getenv func(string) string) int {
+	if n, err := strconv.Atoi(strings.TrimSpace(getenv("QUORUM_PR_NUMBER"))); err == nil && n > 0 {
+		return n
+	}
+	if m := githubPullRefRe.FindStringSubmatch(getenv("GITHUB_REF")); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return n
+	}
+	return 0
+}
@@ -0,0 +1,233 @@
+package ci
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/github"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+type fakeRunner struct {
+	state *core.WorkflowState
+	err   error
+	block bool
+}
+
+func (f *fakeRunner) Run(ctx context.Context, _ string) error {
+	if f.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return f.err
+}
+
+func (f *fakeRunner) GetState(_ context.Context) (*core.WorkflowState, error) {
+	return f.state, nil
+}
+
+func newState(score float64, tasks ...*core.TaskState) *core.WorkflowState {
+	st := &core.WorkflowState{}
+	st.WorkflowID = "wf-ci-1"
+	st.Status = core.WorkflowStatusCompleted
+	st.Metrics = &core.StateMetrics{ConsensusScore: score}
+	st.Tasks = make(map[core.TaskID]*core.TaskState)
+	for _, task := range tasks {
+		st.Tasks[task.ID] = task
+		st.TaskOrder = append(st.TaskOrder, task.ID)
+	}
+	return st
+}
+
+func TestClassify(t *testing.T) {
+	t.Parallel()
+
+	failed := newState(0.9, &core.TaskState{ID: "t1", Status: core.TaskStatusFailed})
+	ok := newState(0.9, &core.TaskState{ID: "t1", Status: core.TaskStatusCompleted})
+
+	tests := []struct {
+		name  string
+		state *core.WorkflowState
+		err   error
+		want  Outcome
+	}{
+		{"success", ok, nil, OutcomeSuccess},
+		{"low consensus", failed, core.ErrHumanReviewRequired(0.4, 0.8), OutcomeLowConsensus},
+		{"task failure", failed, errors.New("task t1 failed"), OutcomeTaskFailure},
+		{"timeout wins over failed tasks", failed, context.DeadlineExceeded, OutcomeInfraError},
+		{"infra error", nil, errors.New("agent not found"), OutcomeInfraError},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.state, tt.err); got != tt.want {
+			t.Errorf("%s: Classify() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOutcome_ExitCode(t *testing.T) {
+	t.Parallel()
+
+	want := map[Outcome]int{
+		OutcomeSuccess:      0,
+		OutcomeLowConsensus: 2,
+		OutcomeTaskFailure:  3,
+		OutcomeInfraError:   4,
+	}
+	for outcome, code := range want {
+		if got := outcome.ExitCode(); got != code {
+			t.Errorf("%s.ExitCode() = %d, want %d", outcome, got, code)
+		}
+	}
+}
+
+func TestRun_PostsPRComment(t *testing.T) {
+	t.Parallel()
+
+	mock := github.NewMockRunner()
+	mock.OnCommand("gh pr comment").Return("")
+	client := github.NewClientSkipAuth("acme", "widgets", mock)
+
+	runner := &fakeRunner{state: newState(0.92, &core.TaskState{
+		ID: "t1", Name: "Fix auth", Status: core.TaskStatusCompleted, CLI: "claude",
+	})}
+	result := Run(context.Background(), runner, Options{
+		Prompt:   "fix it",
+		GitHub:   client,
+		PRNumber: 42,
+	})
+
+	if result.Outcome != OutcomeSuccess {
+		t.Fatalf("Outcome = %s, want success (error: %s)", result.Outcome, result.Error)
+	}
+	if len(mock.Calls) != 1 {
+		t.Fatalf("expected 1 gh call, got %d", len(mock.Calls))
+	}
+	args := strings.Join(mock.Calls[0].Args, " ")
+	if !strings.HasPrefix(args, "pr comment 42 --repo acme/widgets") {
+		t.Errorf("unexpected gh args: %s", args)
+	}
+	if !strings.Contains(args, CommentMarker) || !strings.Contains(args, "Fix auth") {
+		t.Errorf("comment body missing marker or task: %s", args)
+	}
+}
+
+func TestRun_CommentFailureDowngradesSuccess(t *testing.T) {
+	t.Parallel()
+
+	mock := github.NewMockRunner()
+	mock.OnCommand("gh pr comment").ReturnError(errors.New("HTTP 403"))
+	client := github.NewClientSkipAuth("acme", "widgets", mock)
+
+	result := Run(context.Background(), &fakeRunner{state: newState(0.9)}, Options{
+		GitHub:   client,
+		PRNumber: 7,
+	})
+	if result.Outcome != OutcomeInfraError {
+		t.Errorf("Outcome = %s, want infra_error", result.Outcome)
+	}
+	if len(result.PublishErrors) != 1 {
+		t.Errorf("PublishErrors = %v, want 1 entry", result.PublishErrors)
+	}
+}
+
+func TestRun_MinConsensus(t *testing.T) {
+	t.Parallel()
+
+	result := Run(context.Background(), &fakeRunner{state: newState(0.55)}, Options{MinConsensus: 0.8})
+	if result.Outcome != OutcomeLowConsensus {
+		t.Errorf("Outcome = %s, want low_consensus", result.Outcome)
+	}
+	if result.ExitCode() != ExitLowConsensus {
+		t.Errorf("ExitCode() = %d, want %d", result.ExitCode(), ExitLowConsensus)
+	}
+
+	result = Run(context.Background(), &fakeRunner{state: newState(0.85)}, Options{MinConsensus: 0.8})
+	if result.Outcome != OutcomeSuccess {
+		t.Errorf("Outcome = %s, want success", result.Outcome)
+	}
+}
+
+func TestRun_Timeout(t *testing.T) {
+	t.Parallel()
+
+	result := Run(context.Background(), &fakeRunner{block: true, state: newState(0)}, Options{
+		Timeout: 10 * time.Millisecond,
+	})
+	if result.Outcome != OutcomeInfraError {
+		t.Errorf("Outcome = %s, want infra_error", result.Outcome)
+	}
+	if !strings.Contains(result.Error, "CI timeout") {
+		t.Errorf("Error = %q, want timeout message", result.Error)
+	}
+}
+
+func TestRun_WritesSARIF(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "quorum.sarif")
+	runner := &fakeRunner{
+		err: errors.New("task t2 failed"),
+		state: newState(0.9,
+			&core.TaskState{ID: "t1", Name: "ok", Status: core.TaskStatusCompleted},
+			&core.TaskState{ID: "t2", Name: "broken", Status: core.TaskStatusFailed,
+				Error: "tests failed", FilesModified: []string{"internal/auth/login.go"}},
+		),
+	}
+	result := Run(context.Background(), runner, Options{SARIFPath: path, ToolVersion: "1.2.3"})
+	if result.ExitCode() != ExitTaskFailure {
+		t.Fatalf("ExitCode() = %d, want %d", result.ExitCode(), ExitTaskFailure)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading SARIF: %v", err)
+	}
+	var log SARIFLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("invalid SARIF JSON: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected SARIF header: version=%s runs=%d", log.Version, len(log.Runs))
+	}
+	if log.Runs[0].Tool.Driver.Version != "1.2.3" {
+		t.Errorf("driver version = %q", log.Runs[0].Tool.Driver.Version)
+	}
+	results := log.Runs[0].Results
+	if len(results) != 1 {
+		t.Fatalf("expected 1 SARIF result, got %d", len(results))
+	}
+	if results[0].RuleID != RuleTaskFailed || results[0].Level != "error" {
+		t.Errorf("unexpected result: %+v", results[0])
+	}
+	if len(results[0].Locations) != 1 ||
+		results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI != "internal/auth/login.go" {
+		t.Errorf("unexpected locations: %+v", results[0].Locations)
+	}
+}
+
+func TestDetectPRNumber(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		env  map[string]string
+		want int
+	}{
+		{map[string]string{"GITHUB_REF": "refs/pull/123/merge"}, 123},
+		{map[string]string{"GITHUB_REF": "refs/heads/main"}, 0},
+		{map[string]string{"QUORUM_PR_NUMBER": "9", "GITHUB_REF": "refs/pull/123/merge"}, 9},
+		{map[string]string{"QUORUM_PR_NUMBER": "abc"}, 0},
+		{map[string]string{}, 0},
+	}
+	for _, tt := range tests {
+		getenv := func(k string) string { return tt.env[k] }
+		if got := DetectPRNumber(getenv); got != tt.want {
+			t.Errorf("DetectPRNumber(%v) = %d, want %d", tt.env, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package ci
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// CommentMarker identifies quorum CI comments so bots and humans can find them.
+const CommentMarker = "<!-- quorum-ci -->"
+
+// maxCommentErrorLen bounds error text in PR comments to keep them readable.
+const maxCommentErrorLen = 500
+
+var outcomeHeadlines = map[Outcome]string{
+	OutcomeSuccess:      "✅ quorum: workflow succeeded",
+	OutcomeLowConsensus: "⚠️ quorum: low consensus, human review required",
+	OutcomeTaskFailure:  "❌ quorum: task failure",
+	OutcomeInfraError:   "🛑 quorum: infrastructure error",
+}
+
+// RenderComment renders a Markdown PR comment summarizing the result.
+func RenderComment(r *Result) string {
+	var b strings.Builder
+	b.WriteString(CommentMarker + "\n")
+	fmt.Fprintf(&b, "### %s\n\n", outcomeHeadlines[r.Outcome])
+
+	b.WriteString("| | |\n|---|---|\n")
+	if r.WorkflowID != "" {
+		fmt.Fprintf(&b, "| Workflow | `%s` |\n", r.WorkflowID)
+	}
+	if r.Status != "" {
+		fmt.Fprintf(&b, "| Status | %s |\n", r.Status)
+	}
+	if r.ConsensusScore > 0 {
+		fmt.Fprintf(&b, "| Consensus | %.0f%% |\n", r.ConsensusScore*100)
+	}
+	fmt.Fprintf(&b, "| Duration | %s |\n", r.Duration.Round(time.Second))
+	fmt.Fprintf(&b, "| Exit code | %d |\n", r.ExitCode())
+	if r.PRURL != "" {
+		fmt.Fprintf(&b, "| Pull request | %s |\n", r.PRURL)
+	}
+
+	if r.Error != "" {
+		fmt.Fprintf(&b, "\n**Error:** `%s`\n", truncate(oneLine(r.Error), maxCommentErrorLen))
+	}
+
+	if len(r.Tasks) > 0 {
+		fmt.Fprintf(&b, "\n<details><summary>Tasks (%d completed, %d failed, %d skipped of %d)</summary>\n\n",
+			r.CountTasks(core.TaskStatusCompleted), r.CountTasks(core.TaskStatusFailed),
+			r.CountTasks(core.TaskStatusSkipped), len(r.Tasks))
+		b.WriteString("| Task | Agent | Status |\n|---|---|---|\n")
+		for _, t := range r.Tasks {
+			status := string(t.Status)
+			if t.Error != "" {
+				status += ": " + truncate(oneLine(t.Error), 120)
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", escapeCell(t.Name), t.Agent, escapeCell(status))
+		}
+		b.WriteString("\n</details>\n")
+	}
+
+	return b.String()
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "…"
+}
+
+func escapeCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
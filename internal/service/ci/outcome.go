@@ -0,0 +1,171 @@
+// Package ci runs workflows headlessly for CI systems (GitHub Actions, GitLab CI)
+// and publishes the results as exit codes, PR comments and SARIF reports.
+package ci
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// Outcome classifies how a CI run ended.
+type Outcome string
+
+const (
+	// OutcomeSuccess means the workflow completed and every task succeeded.
+	OutcomeSuccess Outcome = "success"
+	// OutcomeLowConsensus means agents did not agree enough to trust the result
+	// without a human (core.CodeHumanReviewRequired).
+	OutcomeLowConsensus Outcome = "low_consensus"
+	// OutcomeTaskFailure means at least one task failed.
+	OutcomeTaskFailure Outcome = "task_failure"
+	// OutcomeInfraError means the run could not complete for reasons unrelated
+	// to the change itself: timeouts, missing agents, state or GitHub errors.
+	OutcomeInfraError Outcome = "infra_error"
+)
+
+// Process exit codes for `quorum ci`. Exit code 1 is left to generic CLI
+// errors (bad flags, unreadable config) so pipelines can tell them apart.
+const (
+	ExitSuccess      = 0
+	ExitLowConsensus = 2
+	ExitTaskFailure  = 3
+	ExitInfraError   = 4
+)
+
+// ExitCode returns the process exit code for the outcome.
+func (o Outcome) ExitCode() int {
+	switch o {
+	case OutcomeSuccess:
+		return ExitSuccess
+	case OutcomeLowConsensus:
+		return ExitLowConsensus
+	case OutcomeTaskFailure:
+		return ExitTaskFailure
+	default:
+		return ExitInfraError
+	}
+}
+
+// Classify determines the outcome of a run from its final state and error.
+// Low consensus takes precedence over task failures because no task result
+// can be trusted when the analysis itself was contested.
+func Classify(state *core.WorkflowState, err error) Outcome {
+	var domErr *core.DomainError
+	if errors.As(err, &domErr) && domErr.Code == core.CodeHumanReviewRequired {
+		return OutcomeLowConsensus
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return OutcomeInfraError
+	}
+	if countTasks(state, core.TaskStatusFailed) > 0 {
+		return OutcomeTaskFailure
+	}
+	if err != nil {
+		return OutcomeInfraError
+	}
+	return OutcomeSuccess
+}
+
+// Result summarizes a finished CI run.
+type Result struct {
+	Outcome        Outcome
+	WorkflowID     string
+	Status         string
+	Error          string
+	ConsensusScore float64
+	Duration       time.Duration
+	PRURL          string
+	Tasks          []TaskSummary
+	// PublishErrors collects non-fatal errors from commenting or writing SARIF.
+	PublishErrors []string
+}
+
+// TaskSummary is the CI view of a single task.
+type TaskSummary struct {
+	ID            string
+	Name          string
+	Status        core.TaskStatus
+	Agent         string
+	Error         string
+	FilesModified []string
+}
+
+// ExitCode returns the process exit code for the result.
+func (r *Result) ExitCode() int {
+	return r.Outcome.ExitCode()
+}
+
+// NewResult builds a Result from the final workflow state and run error.
+func NewResult(state *core.WorkflowState, err error, duration time.Duration) *Result {
+	r := &Result{
+		Outcome:  Classify(state, err),
+		Duration: duration,
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	if state == nil {
+		return r
+	}
+
+	r.WorkflowID = string(state.WorkflowID)
+	r.Status = string(state.Status)
+	r.PRURL = state.PRURL
+	if r.Error == "" {
+		r.Error = state.Error
+	}
+	if state.Metrics != nil {
+		r.ConsensusScore = state.Metrics.ConsensusScore
+	}
+
+	order := state.TaskOrder
+	if len(order) == 0 {
+		for id := range state.Tasks {
+			order = append(order, id)
+		}
+		sort.Slice(order, func(i, j int) bool { return order[i] < order[j] })
+	}
+	for _, id := range order {
+		task, ok := state.Tasks[id]
+		if !ok || task == nil {
+			continue
+		}
+		r.Tasks = append(r.Tasks, TaskSummary{
+			ID:            string(task.ID),
+			Name:          task.Name,
+			Status:        task.Status,
+			Agent:         task.CLI,
+			Error:         task.Error,
+			FilesModified: task.FilesModified,
+		})
+	}
+	return r
+}
+
+// CountTasks returns the number of tasks with the given status.
+func (r *Result) CountTasks(status core.TaskStatus) int {
+	n := 0
+	for _, t := range r.Tasks {
+		if t.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+func countTasks(state *core.WorkflowState, status core.TaskStatus) int {
+	if state == nil {
+		return 0
+	}
+	n := 0
+	for _, t := range state.Tasks {
+		if t != nil && t.Status == status {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,167 @@
+package ci
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// SARIF 2.1.0 identifiers.
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SARIF rule IDs emitted by quorum.
+const (
+	RuleTaskFailed   = "quorum/task-failed"
+	RuleTaskSkipped  = "quorum/task-skipped"
+	RuleLowConsensus = "quorum/low-consensus"
+	RuleInfraError   = "quorum/infra-error"
+)
+
+// SARIFLog is the root of a SARIF 2.1.0 document (the subset quorum emits).
+type SARIFLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun is a single analysis run.
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+// SARIFTool describes the producing tool.
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver describes the tool component and its rules.
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+// SARIFRule describes a reporting rule.
+type SARIFRule struct {
+	ID               string       `json:"id"`
+	ShortDescription SARIFMessage `json:"shortDescription"`
+}
+
+// SARIFResult is a single finding.
+type SARIFResult struct {
+	RuleID     string                 `json:"ruleId"`
+	Level      string                 `json:"level"`
+	Message    SARIFMessage           `json:"message"`
+	Locations  []SARIFLocation        `json:"locations,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// SARIFMessage is a plain-text message.
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFLocation points at a file in the repository.
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation is the file part of a location.
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+}
+
+// SARIFArtifactLocation is a repository-relative URI.
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+var sarifRules = []SARIFRule{
+	{ID: RuleTaskFailed, ShortDescription: SARIFMessage{Text: "A workflow task failed"}},
+	{ID: RuleTaskSkipped, ShortDescription: SARIFMessage{Text: "A workflow task was skipped"}},
+	{ID: RuleLowConsensus, ShortDescription: SARIFMessage{Text: "Agents did not reach the required consensus"}},
+	{ID: RuleInfraError, ShortDescription: SARIFMessage{Text: "The workflow could not complete"}},
+}
+
+// BuildSARIF converts a result into review-style SARIF findings.
+// Task findings are located at the files the task modified, so code scanning
+// annotates them in the PR diff; workflow-level findings have no location.
+func BuildSARIF(r *Result, toolVersion string) *SARIFLog {
+	results := make([]SARIFResult, 0)
+
+	switch r.Outcome {
+	case OutcomeLowConsensus:
+		results = append(results, SARIFResult{
+			RuleID:  RuleLowConsensus,
+			Level:   "warning",
+			Message: SARIFMessage{Text: fmt.Sprintf("Consensus %.0f%%: %s", r.ConsensusScore*100, r.Error)},
+		})
+	case OutcomeInfraError:
+		results = append(results, SARIFResult{
+			RuleID:  RuleInfraError,
+			Level:   "error",
+			Message: SARIFMessage{Text: r.Error},
+		})
+	}
+
+	for _, t := range r.Tasks {
+		var rule, level string
+		switch t.Status {
+		case core.TaskStatusFailed:
+			rule, level = RuleTaskFailed, "error"
+		case core.TaskStatusSkipped:
+			rule, level = RuleTaskSkipped, "note"
+		default:
+			continue
+		}
+		msg := fmt.Sprintf("Task %q (%s)", t.Name, t.ID)
+		if t.Error != "" {
+			msg += ": " + t.Error
+		}
+		res := SARIFResult{
+			RuleID:     rule,
+			Level:      level,
+			Message:    SARIFMessage{Text: msg},
+			Properties: map[string]interface{}{"task_id": t.ID, "agent": t.Agent},
+		}
+		for _, f := range t.FilesModified {
+			res.Locations = append(res.Locations, SARIFLocation{
+				PhysicalLocation: SARIFPhysicalLocation{ArtifactLocation: SARIFArtifactLocation{URI: f}},
+			})
+		}
+		results = append(results, res)
+	}
+
+	return &SARIFLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []SARIFRun{{
+			Tool: SARIFTool{Driver: SARIFDriver{
+				Name:           "quorum",
+				Version:        toolVersion,
+				InformationURI: "https://github.com/hugo-lorenzo-mato/quorum-ai",
+				Rules:          sarifRules,
+			}},
+			Results: results,
+		}},
+	}
+}
+
+// WriteSARIF writes a SARIF log to path.
+func WriteSARIF(path string, log *SARIFLog) error {
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling SARIF: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("writing SARIF to %s: %w", path, err)
+	}
+	return nil
+}
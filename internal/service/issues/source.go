@@ -0,0 +1,295 @@
+package issues
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/attachments"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// StatusCommentMarker identifies the workflow status comments posted on
+// source issues.
+const StatusCommentMarker = "<!-- quorum-issue-status -->"
+
+// maxStatusErrorLen bounds the error text in status comments.
+const maxStatusErrorLen = 500
+
+var (
+	issueURLRe = regexp.MustCompile(`^https?://[^/]+/([^/]+/[^/]+)/issues/(\d+)/?(?:[?#].*)?$`)
+	issueRefRe = regexp.MustCompile(`^(?:([\w.-]+/[\w.-]+))?#?(\d+)$`)
+)
+
+// ParseIssueRef parses an issue reference given as "123", "#123",
+// "owner/repo#123" or an issue URL. The repository is empty when the
+// reference does not name one.
+func ParseIssueRef(ref string) (repository string, number int, err error) {
+	ref = strings.TrimSpace(ref)
+	var m []string
+	if m = issueURLRe.FindStringSubmatch(ref); m == nil {
+		m = issueRefRe.FindStringSubmatch(ref)
+	}
+	if m == nil {
+		return "", 0, fmt.Errorf("invalid issue reference %q: expected a number, owner/repo#number or an issue URL", ref)
+	}
+	number, err = strconv.Atoi(m[2])
+	if err != nil || number <= 0 {
+		return "", 0, fmt.Errorf("invalid issue number in %q", ref)
+	}
+	return m[1], number, nil
+}
+
+// Source is an issue fetched as workflow input.
+type Source struct {
+	Issue      *core.Issue
+	Comments   []core.IssueComment
+	Provider   core.IssueProvider
+	Repository string
+}
+
+// FetchSource reads an issue and, when the client implements
+// core.IssueInbox, its comments.
+func FetchSource(ctx context.Context, client core.IssueClient, provider core.IssueProvider, repository string, number int) (*Source, error) {
+	issue, err := client.GetIssue(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	if provider == "" {
+		provider = core.IssueProviderGitHub
+	}
+	src := &Source{Issue: issue, Provider: provider, Repository: repository}
+	if inbox, ok := client.(core.IssueInbox); ok {
+		comments, err := inbox.ListIssueComments(ctx, number)
+		if err != nil {
+			return nil, err
+		}
+		src.Comments = comments
+	}
+	return src, nil
+}
+
+// Link returns the reference stored on the workflow.
+func (s *Source) Link() *core.IssueLink {
+	return &core.IssueLink{
+		Provider:   s.Provider,
+		Repository: s.Repository,
+		Number:     s.Issue.Number,
+		URL:        s.Issue.URL,
+		Title:      s.Issue.Title,
+	}
+}
+
+// Title returns the workflow title for the issue.
+func (s *Source) Title() string {
+	return fmt.Sprintf("#%d %s", s.Issue.Number, s.Issue.Title)
+}
+
+// Prompt renders the workflow prompt from the issue title, labels and body.
+// The discussion is not inlined; it is attached by SaveAttachments.
+func (s *Source) Prompt() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Resolve issue #%d: %s\n", s.Issue.Number, s.Issue.Title)
+	if s.Issue.URL != "" {
+		fmt.Fprintf(&b, "Issue: %s\n", s.Issue.URL)
+	}
+	if len(s.Issue.Labels) > 0 {
+		fmt.Fprintf(&b, "Labels: %s\n", strings.Join(s.Issue.Labels, ", "))
+	}
+	if body := strings.TrimSpace(s.Issue.Body); body != "" {
+		b.WriteString("\n")
+		b.WriteString(body)
+		b.WriteString("\n")
+	}
+	if len(s.Comments) > 0 {
+		fmt.Fprintf(&b, "\nThe issue discussion (%d comments) is attached as %s.\n", len(s.Comments), s.commentsFilename())
+	}
+	return b.String()
+}
+
+// CommentsMarkdown renders the issue discussion, or "" without comments.
+func (s *Source) CommentsMarkdown() string {
+	if len(s.Comments) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Discussion of issue #%d: %s\n", s.Issue.Number, s.Issue.Title)
+	for _, c := range s.Comments {
+		author := c.Author
+		if author == "" {
+			author = "unknown"
+		}
+		fmt.Fprintf(&b, "\n## @%s (%s)\n\n%s\n", author, c.CreatedAt.UTC().Format(time.RFC3339), strings.TrimSpace(c.Body))
+	}
+	return b.String()
+}
+
+// SaveAttachments stores the issue discussion as a workflow attachment.
+// It returns no attachments when the issue has no comments.
+func (s *Source) SaveAttachments(store *attachments.Store, workflowID core.WorkflowID) ([]core.Attachment, error) {
+	md := s.CommentsMarkdown()
+	if md == "" {
+		return nil, nil
+	}
+	att, err := store.Save(attachments.OwnerWorkflow, string(workflowID), strings.NewReader(md), s.commentsFilename())
+	if err != nil {
+		return nil, fmt.Errorf("saving issue discussion: %w", err)
+	}
+	return []core.Attachment{att}, nil
+}
+
+func (s *Source) commentsFilename() string {
+	return fmt.Sprintf("issue-%d-comments.md", s.Issue.Number)
+}
+
+// StatusComment renders the comment posted on the source issue when the
+// workflow completes or fails.
+func StatusComment(state *core.WorkflowState) string {
+	var b strings.Builder
+	b.WriteString(StatusCommentMarker + "\n")
+
+	if state.Status == core.WorkflowStatusCompleted {
+		b.WriteString("### ✅ quorum: workflow completed\n\n")
+	} else {
+		b.WriteString("### ❌ quorum: workflow failed\n\n")
+	}
+
+	b.WriteString("| | |\n|---|---|\n")
+	fmt.Fprintf(&b, "| Workflow | `%s` |\n", state.WorkflowID)
+	if state.Status != core.WorkflowStatusCompleted && state.CurrentPhase != "" {
+		fmt.Fprintf(&b, "| Phase | %s |\n", state.CurrentPhase)
+	}
+	if len(state.Tasks) > 0 {
+		completed := 0
+		for _, ts := range state.Tasks {
+			if ts.Status == core.TaskStatusCompleted {
+				completed++
+			}
+		}
+		fmt.Fprintf(&b, "| Tasks | %d of %d completed |\n", completed, len(state.Tasks))
+	}
+	if state.PRURL != "" {
+		fmt.Fprintf(&b, "| Pull request | %s |\n", state.PRURL)
+	}
+	if state.PRBabysit != nil && state.PRBabysit.Summary != "" {
+		fmt.Fprintf(&b, "| CI | %s |\n", oneLine(state.PRBabysit.Summary))
+	}
+
+	if state.Status != core.WorkflowStatusCompleted && state.Error != "" {
+		errText := oneLine(state.Error)
+		if len(errText) > maxStatusErrorLen {
+			errText = errText[:maxStatusErrorLen] + "…"
+		}
+		fmt.Fprintf(&b, "\n**Error:** `%s`\n", errText)
+	}
+	return b.String()
+}
+
+// ReportStatus posts StatusComment on the workflow's source issue. It is a
+// no-op for workflows that were not created from an issue.
+func ReportStatus(ctx context.Context, client core.IssueClient, state *core.WorkflowState) error {
+	if state.SourceIssue == nil {
+		return nil
+	}
+	if err := client.AddIssueComment(ctx, state.SourceIssue.Number, StatusComment(state)); err != nil {
+		return fmt.Errorf("posting status on issue #%d: %w", state.SourceIssue.Number, err)
+	}
+	return nil
+}
+
+// ImportOptions configures ImportLabeled.
+type ImportOptions struct {
+	// Labels marks issues for import; an issue carrying any of them is imported.
+	Labels []string
+	// Limit caps the issues listed per label (0 = provider default).
+	Limit int
+	// Provider and Repository are recorded on the created workflows.
+	Provider   core.IssueProvider
+	Repository string
+}
+
+// ImportedIssue is an issue that ImportLabeled turned into a workflow.
+type ImportedIssue struct {
+	Number     int
+	WorkflowID core.WorkflowID
+}
+
+// ImportLabeled creates one workflow per open issue carrying any of the
+// import labels. The labels are removed from imported issues so the next
+// run does not import them again. Errors on single issues are collected and
+// returned together with the issues that were imported.
+func ImportLabeled(
+	ctx context.Context,
+	client core.IssueClient,
+	opts ImportOptions,
+	create func(ctx context.Context, src *Source) (core.WorkflowID, error),
+) ([]ImportedIssue, error) {
+	inbox, ok := client.(core.IssueInbox)
+	if !ok {
+		return nil, fmt.Errorf("issue provider does not support listing issues")
+	}
+
+	seen := make(map[int]bool)
+	var candidates []*core.Issue
+	for _, label := range opts.Labels {
+		found, err := inbox.ListIssues(ctx, core.ListIssuesOptions{Label: label, Limit: opts.Limit})
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range found {
+			if !seen[issue.Number] {
+				seen[issue.Number] = true
+				candidates = append(candidates, issue)
+			}
+		}
+	}
+
+	var imported []ImportedIssue
+	var errs []string
+	for _, issue := range candidates {
+		src, err := FetchSource(ctx, client, opts.Provider, opts.Repository, issue.Number)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("#%d: %v", issue.Number, err))
+			continue
+		}
+		workflowID, err := create(ctx, src)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("#%d: %v", issue.Number, err))
+			continue
+		}
+		imported = append(imported, ImportedIssue{Number: issue.Number, WorkflowID: workflowID})
+
+		for _, label := range opts.Labels {
+			if hasLabel(issue.Labels, label) {
+				if err := inbox.RemoveIssueLabel(ctx, issue.Number, label); err != nil {
+					errs = append(errs, fmt.Sprintf("#%d: %v", issue.Number, err))
+				}
+			}
+		}
+		comment := fmt.Sprintf("%s\nQueued as quorum workflow `%s`.", StatusCommentMarker, workflowID)
+		if err := client.AddIssueComment(ctx, issue.Number, comment); err != nil {
+			errs = append(errs, fmt.Sprintf("#%d: %v", issue.Number, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return imported, fmt.Errorf("importing issues: %s", strings.Join(errs, "; "))
+	}
+	return imported, nil
+}
+
+func hasLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
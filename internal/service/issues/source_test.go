@@ -0,0 +1,197 @@
+package issues
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/attachments"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// inboxClient extends mockIssueClient with core.IssueInbox and records
+// comments and label removals.
+type inboxClient struct {
+	mockIssueClient
+	comments      map[int][]core.IssueComment
+	posted        map[int][]string
+	removedLabels map[int][]string
+}
+
+func newInboxClient(issues ...*core.Issue) *inboxClient {
+	return &inboxClient{
+		mockIssueClient: mockIssueClient{issues: issues},
+		comments:        make(map[int][]core.IssueComment),
+		posted:          make(map[int][]string),
+		removedLabels:   make(map[int][]string),
+	}
+}
+
+func (c *inboxClient) AddIssueComment(_ context.Context, number int, comment string) error {
+	c.posted[number] = append(c.posted[number], comment)
+	return nil
+}
+
+func (c *inboxClient) ListIssueComments(_ context.Context, number int) ([]core.IssueComment, error) {
+	return c.comments[number], nil
+}
+
+func (c *inboxClient) ListIssues(_ context.Context, opts core.ListIssuesOptions) ([]*core.Issue, error) {
+	var out []*core.Issue
+	for _, issue := range c.issues {
+		if hasLabel(issue.Labels, opts.Label) {
+			out = append(out, issue)
+		}
+	}
+	return out, nil
+}
+
+func (c *inboxClient) RemoveIssueLabel(_ context.Context, number int, label string) error {
+	c.removedLabels[number] = append(c.removedLabels[number], label)
+	return nil
+}
+
+func TestParseIssueRef(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ref      string
+		wantRepo string
+		wantNum  int
+		wantErr  bool
+	}{
+		{"42", "", 42, false},
+		{"#42", "", 42, false},
+		{"acme/widgets#7", "acme/widgets", 7, false},
+		{"https://github.com/acme/widgets/issues/123", "acme/widgets", 123, false},
+		{"https://github.com/acme/widgets/issues/123#issuecomment-1", "acme/widgets", 123, false},
+		{"https://github.com/acme/widgets/pull/123", "", 0, true},
+		{"abc", "", 0, true},
+		{"0", "", 0, true},
+	}
+	for _, tt := range tests {
+		repo, num, err := ParseIssueRef(tt.ref)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseIssueRef(%q) error = %v, wantErr %v", tt.ref, err, tt.wantErr)
+			continue
+		}
+		if repo != tt.wantRepo || num != tt.wantNum {
+			t.Errorf("ParseIssueRef(%q) = (%q, %d), want (%q, %d)", tt.ref, repo, num, tt.wantRepo, tt.wantNum)
+		}
+	}
+}
+
+func TestFetchSource_PromptAndAttachments(t *testing.T) {
+	t.Parallel()
+
+	client := newInboxClient(&core.Issue{
+		Number: 17, Title: "Login fails", Body: "Steps to reproduce...",
+		URL: "https://github.com/acme/widgets/issues/17", Labels: []string{"bug", "auth"},
+	})
+	client.comments[17] = []core.IssueComment{
+		{Author: "alice", Body: "Happens on Safari only", CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)},
+	}
+
+	src, err := FetchSource(context.Background(), client, "", "acme/widgets", 17)
+	if err != nil {
+		t.Fatalf("FetchSource() error = %v", err)
+	}
+
+	prompt := src.Prompt()
+	for _, want := range []string{"Resolve issue #17: Login fails", "Labels: bug, auth", "Steps to reproduce", "issue-17-comments.md"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Prompt() missing %q:\n%s", want, prompt)
+		}
+	}
+	if strings.Contains(prompt, "Safari") {
+		t.Error("comments should be attached, not inlined in the prompt")
+	}
+
+	link := src.Link()
+	if link.Provider != core.IssueProviderGitHub || link.Repository != "acme/widgets" || link.Number != 17 {
+		t.Errorf("Link() = %+v", link)
+	}
+
+	store := attachments.NewStore(t.TempDir())
+	saved, err := src.SaveAttachments(store, "wf-1")
+	if err != nil {
+		t.Fatalf("SaveAttachments() error = %v", err)
+	}
+	if len(saved) != 1 || saved[0].Name != "issue-17-comments.md" {
+		t.Fatalf("saved = %+v", saved)
+	}
+}
+
+func TestReportStatus(t *testing.T) {
+	t.Parallel()
+
+	client := newInboxClient()
+	state := &core.WorkflowState{}
+	state.WorkflowID = "wf-1"
+	state.Status = core.WorkflowStatusFailed
+	state.CurrentPhase = core.PhaseExecute
+	state.Error = "task t1 failed"
+
+	if err := ReportStatus(context.Background(), client, state); err != nil {
+		t.Fatalf("ReportStatus() without source issue error = %v", err)
+	}
+	if len(client.posted) != 0 {
+		t.Fatal("no comment expected without a source issue")
+	}
+
+	state.SourceIssue = &core.IssueLink{Number: 17}
+	if err := ReportStatus(context.Background(), client, state); err != nil {
+		t.Fatalf("ReportStatus() error = %v", err)
+	}
+	body := strings.Join(client.posted[17], "")
+	for _, want := range []string{StatusCommentMarker, "workflow failed", "wf-1", "task t1 failed"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("status comment missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestImportLabeled(t *testing.T) {
+	t.Parallel()
+
+	client := newInboxClient(
+		&core.Issue{Number: 1, Title: "A", Labels: []string{"quorum"}},
+		&core.Issue{Number: 2, Title: "B", Labels: []string{"quorum", "quorum-urgent"}},
+		&core.Issue{Number: 3, Title: "C", Labels: []string{"bug"}},
+		&core.Issue{Number: 4, Title: "D", Labels: []string{"quorum"}},
+	)
+
+	var created []int
+	create := func(_ context.Context, src *Source) (core.WorkflowID, error) {
+		if src.Issue.Number == 4 {
+			return "", errors.New("duplicate prompt")
+		}
+		created = append(created, src.Issue.Number)
+		return core.WorkflowID("wf-" + src.Issue.Title), nil
+	}
+
+	imported, err := ImportLabeled(context.Background(), client, ImportOptions{
+		Labels: []string{"quorum", "quorum-urgent"},
+	}, create)
+
+	if err == nil || !strings.Contains(err.Error(), "#4: duplicate prompt") {
+		t.Errorf("error = %v, want failure for #4", err)
+	}
+	if len(imported) != 2 || imported[0].Number != 1 || imported[1].WorkflowID != "wf-B" {
+		t.Errorf("imported = %+v", imported)
+	}
+	if len(created) != 2 {
+		t.Errorf("issue #2 should be imported once, created = %v", created)
+	}
+	if got := client.removedLabels[2]; len(got) != 2 {
+		t.Errorf("removed labels on #2 = %v, want both import labels", got)
+	}
+	if len(client.removedLabels[4]) != 0 {
+		t.Error("labels of a failed import must be kept for the next run")
+	}
+	if len(client.posted[1]) != 1 || !strings.Contains(client.posted[1][0], "wf-A") {
+		t.Errorf("queued comment on #1 = %v", client.posted[1])
+	}
+}
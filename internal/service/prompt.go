@@ -117,6 +117,9 @@ type AnalyzeV1Params struct {
 	Context        string
 	Constraints    []string
 	OutputFilePath string // Optional: if set, LLM should write output to this file
+	// RelevantContext lists the files and symbols the codebase index ranked
+	// relevant to the prompt (optional).
+	RelevantContext string
 }
 
 // RenderAnalyzeV1 renders the initial analysis prompt.
@@ -151,6 +154,7 @@ type PlanParams struct {
 	ConsolidatedAnalysis string
 	Constraints          []string
 	MaxTasks             int
+	RelevantContext      string // Optional: files and symbols ranked relevant by the codebase index
 }
 
 // RenderPlanGenerate renders the plan generation prompt.
@@ -181,6 +185,11 @@ type ComprehensivePlanParams struct {
 	AvailableAgents      []AgentInfo // Agents available for task execution
 	TasksDir             string      // Directory where task files should be written
 	NamingConvention     string      // File naming convention (e.g., "{id}-{name}.md")
+	// Repositories are the other projects a multi-repository workflow changes.
+	Repositories []core.WorkflowRepository
+	// RelevantContext lists the files and symbols the codebase index ranked
+	// relevant (optional).
+	RelevantContext string
 }
 
 // RenderPlanComprehensive renders the comprehensive single-call planning prompt.
@@ -216,6 +225,27 @@ type TaskExecuteParams struct {
 	Context     string
 	WorkDir     string
 	Constraints []string
+	// Resume, when set, asks the agent to continue an interrupted execution.
+	Resume *TaskResumeParams
+	// Answers holds the questions the agent asked in earlier executions of
+	// the task and their answers.
+	Answers []TaskInputAnswer
+}
+
+// TaskInputAnswer is a question asked by the agent of a task and its answer.
+type TaskInputAnswer struct {
+	Question  string
+	Answer    string
+	Defaulted bool   // The question went unanswered and the agent's proposal is used
+	Output    string // Output of the execution that asked the question
+}
+
+// TaskResumeParams describes the work an interrupted task execution left
+// behind.
+type TaskResumeParams struct {
+	Checkpoint     string // Commit of the last work-in-progress checkpoint
+	Diff           string // Changes made so far, relative to the task's base
+	PreviousOutput string // Output of the interrupted attempt, if any
 }
 
 // RenderTaskExecute renders the task execution prompt.
@@ -333,6 +363,39 @@ func (r *PromptRenderer) RenderVnRefine(params VnRefineParams) (string, error) {
 	return r.render("vn-refine", params)
 }
 
+// ChatSynthesisResponse is one agent's answer to a chat question.
+type ChatSynthesisResponse struct {
+	AgentName string
+	Content   string
+}
+
+// ChatSynthesizeParams contains parameters for the chat synthesis prompt.
+type ChatSynthesizeParams struct {
+	Question  string
+	Context   string // Optional: recent conversation the question refers to
+	Responses []ChatSynthesisResponse
+}
+
+// RenderChatSynthesize renders the prompt that moderates the answers of several
+// agents to the same chat question.
+func (r *PromptRenderer) RenderChatSynthesize(params ChatSynthesizeParams) (string, error) {
+	return r.render("chat-synthesize", params)
+}
+
+// ChatPromoteParams contains parameters for the prompt that turns a chat
+// conversation into a workflow prompt.
+type ChatPromoteParams struct {
+	Title      string // Optional: session title
+	Transcript string
+	Omitted    int // Number of older messages left out of the transcript
+}
+
+// RenderChatPromote renders the prompt that drafts a workflow prompt from a
+// chat conversation.
+func (r *PromptRenderer) RenderChatPromote(params ChatPromoteParams) (string, error) {
+	return r.render("chat-promote", params)
+}
+
 // IssueTaskFile contains information about a task file for issue generation.
 type IssueTaskFile struct {
 	Path string // Absolute path to the task file
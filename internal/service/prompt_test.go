@@ -62,6 +62,7 @@ func TestPromptRenderer_Load(t *testing.T) {
 		"moderator-evaluate",
 		"vn-refine",
 		"synthesize-analysis",
+		"chat-synthesize",
 	}
 
 	for _, expected := range expectedPrompts {
@@ -102,6 +103,18 @@ func TestPromptRenderer_RenderAnalyzeV1(t *testing.T) {
 	if !strings.Contains(result, "Investigate the code") {
 		t.Error("result should contain analysis instructions")
 	}
+	if strings.Contains(result, "Likely Relevant Files") {
+		t.Error("result should not contain a relevant files section without an index")
+	}
+
+	params.RelevantContext = "- `internal/billing/invoice.go`: `ComputeTotal` (func, line 12)\n"
+	result, err = renderer.RenderAnalyzeV1(params)
+	if err != nil {
+		t.Fatalf("RenderAnalyzeV1() error = %v", err)
+	}
+	if !strings.Contains(result, "## Likely Relevant Files and Symbols") || !strings.Contains(result, "`ComputeTotal`") {
+		t.Error("result should contain the relevant files section")
+	}
 }
 
 func TestPromptRenderer_RenderPlanGenerate(t *testing.T) {
@@ -165,6 +178,101 @@ func TestPromptRenderer_RenderTaskExecute(t *testing.T) {
 	}
 }
 
+func TestPromptRenderer_RenderTaskExecute_Resume(t *testing.T) {
+	renderer, err := NewPromptRenderer()
+	if err != nil {
+		t.Fatalf("NewPromptRenderer() error = %v", err)
+	}
+
+	task := core.NewTask("task-1", "Implement login", core.PhaseExecute)
+	params := TaskExecuteParams{Task: task, WorkDir: "/path/to/project"}
+
+	result, err := renderer.RenderTaskExecute(params)
+	if err != nil {
+		t.Fatalf("RenderTaskExecute() error = %v", err)
+	}
+	if strings.Contains(result, "Resuming Interrupted Work") {
+		t.Error("result without Resume should not ask to continue")
+	}
+
+	params.Resume = &TaskResumeParams{
+		Checkpoint:     "abc123",
+		Diff:           "+func Login() {}",
+		PreviousOutput: "Created the handler skeleton",
+	}
+	result, err = renderer.RenderTaskExecute(params)
+	if err != nil {
+		t.Fatalf("RenderTaskExecute() error = %v", err)
+	}
+	for _, want := range []string{"Resuming Interrupted Work", "abc123", "+func Login() {}", "Created the handler skeleton"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("result should contain %q", want)
+		}
+	}
+}
+
+func TestPromptRenderer_RenderTaskExecute_RetryInstructions(t *testing.T) {
+	renderer, err := NewPromptRenderer()
+	if err != nil {
+		t.Fatalf("NewPromptRenderer() error = %v", err)
+	}
+
+	task := core.NewTask("task-1", "Add session cache", core.PhaseExecute)
+	result, err := renderer.RenderTaskExecute(TaskExecuteParams{Task: task})
+	if err != nil {
+		t.Fatalf("RenderTaskExecute() error = %v", err)
+	}
+	if strings.Contains(result, "Retry Instructions") {
+		t.Error("result of a first attempt should not have retry instructions")
+	}
+
+	task.RetryInstructions = "Use the v2 client; v1 is deprecated."
+	result, err = renderer.RenderTaskExecute(TaskExecuteParams{Task: task})
+	if err != nil {
+		t.Fatalf("RenderTaskExecute() error = %v", err)
+	}
+	if !strings.Contains(result, "## Retry Instructions") || !strings.Contains(result, task.RetryInstructions) {
+		t.Errorf("result should have the retry instructions:\n%s", result)
+	}
+}
+
+func TestPromptRenderer_RenderTaskExecute_Answers(t *testing.T) {
+	renderer, err := NewPromptRenderer()
+	if err != nil {
+		t.Fatalf("NewPromptRenderer() error = %v", err)
+	}
+
+	task := core.NewTask("task-1", "Add session cache", core.PhaseExecute)
+	params := TaskExecuteParams{Task: task, WorkDir: "/path/to/project"}
+
+	result, err := renderer.RenderTaskExecute(params)
+	if err != nil {
+		t.Fatalf("RenderTaskExecute() error = %v", err)
+	}
+	if !strings.Contains(result, "<<<QUORUM_INPUT_REQUEST") {
+		t.Error("result should describe the input request block")
+	}
+	if strings.Contains(result, "Answers to Your Questions") {
+		t.Error("result without Answers should not list answers")
+	}
+
+	params.Answers = []TaskInputAnswer{{
+		Question:  "Which cache backend?",
+		Answer:    "redis",
+		Defaulted: true,
+		Output:    "Added the cache interface",
+	}}
+	result, err = renderer.RenderTaskExecute(params)
+	if err != nil {
+		t.Fatalf("RenderTaskExecute() error = %v", err)
+	}
+	for _, want := range []string{"Answers to Your Questions", "Which cache backend?", "**Answer:** redis", "proposed default", "Added the cache interface"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("result should contain %q", want)
+		}
+	}
+}
+
 func TestPromptRenderer_RenderTaskDetailGenerate(t *testing.T) {
 	renderer, err := NewPromptRenderer()
 	if err != nil {
@@ -395,6 +503,55 @@ func TestPromptRenderer_RenderVnRefine(t *testing.T) {
 	}
 }
 
+func TestPromptRenderer_RenderChatSynthesize(t *testing.T) {
+	renderer, err := NewPromptRenderer()
+	if err != nil {
+		t.Fatalf("NewPromptRenderer() error = %v", err)
+	}
+
+	result, err := renderer.RenderChatSynthesize(ChatSynthesizeParams{
+		Question: "Should we use a mutex or a channel?",
+		Responses: []ChatSynthesisResponse{
+			{AgentName: "claude", Content: "Use a mutex."},
+			{AgentName: "gemini", Content: "Use a channel."},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RenderChatSynthesize() error = %v", err)
+	}
+
+	for _, want := range []string{"mutex or a channel", "Answer by claude", "Use a channel.", "consensus_score"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("result should contain %q", want)
+		}
+	}
+	if strings.Contains(result, "Conversation Context") {
+		t.Error("result should omit the context section when there is no context")
+	}
+}
+
+func TestPromptRenderer_RenderChatPromote(t *testing.T) {
+	renderer, err := NewPromptRenderer()
+	if err != nil {
+		t.Fatalf("NewPromptRenderer() error = %v", err)
+	}
+
+	result, err := renderer.RenderChatPromote(ChatPromoteParams{
+		Title:      "Retries",
+		Transcript: "[user]: Should the client retry on 503?",
+		Omitted:    3,
+	})
+	if err != nil {
+		t.Fatalf("RenderChatPromote() error = %v", err)
+	}
+
+	for _, want := range []string{"Retries", "retry on 503", "3 oldest messages", "title: <workflow title>"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("result should contain %q", want)
+		}
+	}
+}
+
 func TestPromptRenderer_RenderVnRefine_V2_NoArbiter(t *testing.T) {
 	renderer, err := NewPromptRenderer()
 	if err != nil {
@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
+	"math"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
 )
 
 // RateLimiter implements a token bucket rate limiter.
@@ -139,11 +143,78 @@ func minFloat(a, b float64) float64 {
 	return b
 }
 
-// RateLimiterRegistry manages rate limiters for multiple adapters.
+// Pauses after a rate limit error that carries no retry hint. The pause
+// doubles with each consecutive error up to maxRateLimitPause.
+const (
+	defaultRateLimitPause = 10 * time.Second
+	maxRateLimitPause     = 5 * time.Minute
+)
+
+// agentLimiter is the adaptive limiter of an agent, or of one of its models,
+// with the tokens-per-minute budget of the model and the pause set by its
+// last rate limit error.
+type agentLimiter struct {
+	*AdaptiveRateLimiter
+	// tokensPerMinute is the token budget; 0 means no budget.
+	tokensPerMinute float64
+	// tokensSpent are the tokens of recent calls not yet paid back; the debt
+	// drains at tokensPerMinute per minute.
+	tokensSpent     float64
+	spentAt         time.Time
+	pausedUntil     time.Time
+	rateLimitErrors int
+	// consecutiveRateLimits doubles the pause of errors without a hint.
+	consecutiveRateLimits int
+}
+
+// spent returns the token debt at now.
+func (l *agentLimiter) spent(now time.Time) float64 {
+	if l.tokensPerMinute <= 0 {
+		return 0
+	}
+	paid := now.Sub(l.spentAt).Minutes() * l.tokensPerMinute
+	return math.Max(0, l.tokensSpent-paid)
+}
+
+// delay returns how long a call must wait for the pause to end and the
+// token budget to have room.
+func (l *agentLimiter) delay(now time.Time) time.Duration {
+	var d time.Duration
+	if now.Before(l.pausedUntil) {
+		d = l.pausedUntil.Sub(now)
+	}
+	if spent := l.spent(now); l.tokensPerMinute > 0 && spent >= l.tokensPerMinute {
+		over := spent - l.tokensPerMinute + 1
+		budget := time.Duration(over / l.tokensPerMinute * float64(time.Minute))
+		if budget > d {
+			d = budget
+		}
+	}
+	return d
+}
+
+// RateLimiterRegistry manages rate limiters for multiple adapters. The
+// limiter of an adapter is adaptive: Report slows it down on rate limit
+// errors, pausing the agent or model for the provider's retry hint, and
+// speeds it back up after successful calls. Models get their own limiter and
+// the tokens-per-minute budget of the agent.
 type RateLimiterRegistry struct {
-	limiters map[string]*RateLimiter
-	configs  map[string]RateLimiterConfig
-	mu       sync.RWMutex
+	limiters map[string]*agentLimiter
+	// models are keyed by modelKey.
+	models  map[string]*agentLimiter
+	configs map[string]RateLimiterConfig
+	// budgets are the tokens per minute of each model of an adapter.
+	budgets map[string]int
+	// sized records the requests and tokens per minute last applied to the
+	// limiters of each adapter by ConfigureFromCapabilities.
+	sized map[string]capabilityLimits
+	now   func() time.Time
+	mu    sync.RWMutex
+}
+
+// capabilityLimits are the rate limits of an agent's capabilities.
+type capabilityLimits struct {
+	rpm, tpm int
 }
 
 var (
@@ -151,13 +222,11 @@ var (
 	globalRateLimiterRegistryOnce sync.Once
 )
 
-// GetGlobalRateLimiter returns the global rate limiter registry.
+// GetGlobalRateLimiter returns the global rate limiter registry, shared by
+// all the workflows and chats of the process.
 func GetGlobalRateLimiter() *RateLimiterRegistry {
 	globalRateLimiterRegistryOnce.Do(func() {
-		globalRateLimiterRegistry = &RateLimiterRegistry{
-			limiters: make(map[string]*RateLimiter),
-			configs:  defaultAdapterConfigs(),
-		}
+		globalRateLimiterRegistry = NewRateLimiterRegistry()
 	})
 	return globalRateLimiterRegistry
 }
@@ -165,8 +234,12 @@ func GetGlobalRateLimiter() *RateLimiterRegistry {
 // NewRateLimiterRegistry creates a new registry.
 func NewRateLimiterRegistry() *RateLimiterRegistry {
 	return &RateLimiterRegistry{
-		limiters: make(map[string]*RateLimiter),
+		limiters: make(map[string]*agentLimiter),
+		models:   make(map[string]*agentLimiter),
 		configs:  defaultAdapterConfigs(),
+		budgets:  make(map[string]int),
+		sized:    make(map[string]capabilityLimits),
+		now:      time.Now,
 	}
 }
 
@@ -196,31 +269,58 @@ func defaultAdapterConfigs() map[string]RateLimiterConfig {
 	}
 }
 
+// modelKey is the key of a model limiter.
+func modelKey(adapter, model string) string {
+	return adapter + "/" + model
+}
+
 // Get returns the rate limiter for an adapter.
 func (r *RateLimiterRegistry) Get(adapter string) *RateLimiter {
 	r.mu.RLock()
 	limiter, ok := r.limiters[adapter]
 	r.mu.RUnlock()
 	if ok {
-		return limiter
+		return limiter.RateLimiter
 	}
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	return r.limiter(adapter).RateLimiter
+}
 
-	if limiter, ok = r.limiters[adapter]; ok {
+// limiter returns the limiter of an adapter, creating it. r.mu must be held
+// for writing.
+func (r *RateLimiterRegistry) limiter(adapter string) *agentLimiter {
+	if limiter, ok := r.limiters[adapter]; ok {
 		return limiter
 	}
+	limiter := r.newLimiter(adapter, 0)
+	r.limiters[adapter] = limiter
+	return limiter
+}
 
-	// Create new limiter
+// modelLimiter returns the limiter of a model of an adapter, creating it.
+// r.mu must be held for writing.
+func (r *RateLimiterRegistry) modelLimiter(adapter, model string) *agentLimiter {
+	key := modelKey(adapter, model)
+	if limiter, ok := r.models[key]; ok {
+		return limiter
+	}
+	limiter := r.newLimiter(adapter, r.budgets[adapter])
+	r.models[key] = limiter
+	return limiter
+}
+
+func (r *RateLimiterRegistry) newLimiter(adapter string, tokensPerMinute int) *agentLimiter {
 	cfg, ok := r.configs[adapter]
 	if !ok {
 		cfg = DefaultRateLimiterConfig()
 	}
-
-	limiter = NewRateLimiter(cfg)
-	r.limiters[adapter] = limiter
-	return limiter
+	return &agentLimiter{
+		AdaptiveRateLimiter: NewAdaptiveRateLimiter(cfg),
+		tokensPerMinute:     float64(tokensPerMinute),
+		spentAt:             r.now(),
+	}
 }
 
 // SetConfig updates the configuration for an adapter.
@@ -230,7 +330,145 @@ func (r *RateLimiterRegistry) SetConfig(adapter string, cfg RateLimiterConfig) {
 
 	r.configs[adapter] = cfg
 	if limiter, ok := r.limiters[adapter]; ok {
-		limiter.updateConfig(cfg)
+		limiter.reconfigure(cfg)
+	}
+	for key, limiter := range r.models {
+		if strings.HasPrefix(key, adapter+"/") {
+			limiter.reconfigure(cfg)
+		}
+	}
+}
+
+// SetTokenBudget sets the tokens per minute each model of an adapter may
+// use. Zero removes the budget.
+func (r *RateLimiterRegistry) SetTokenBudget(adapter string, tokensPerMinute int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.budgets[adapter] = tokensPerMinute
+	for key, limiter := range r.models {
+		if strings.HasPrefix(key, adapter+"/") {
+			limiter.tokensPerMinute = float64(tokensPerMinute)
+		}
+	}
+}
+
+// ConfigureFromCapabilities sizes the limiters of an adapter from the
+// requests and tokens per minute in its capabilities. Limits equal to the
+// ones last applied leave the limiters alone, so that the adapted rate is
+// not reset; changed limits, such as those of a reloaded config, replace
+// them, and removed ones restore the defaults.
+func (r *RateLimiterRegistry) ConfigureFromCapabilities(adapter string, caps core.Capabilities) {
+	limits := capabilityLimits{rpm: caps.RateLimitRPM, tpm: caps.RateLimitTPM}
+	r.mu.Lock()
+	// An adapter seen for the first time has no limits applied yet.
+	applied := r.sized[adapter]
+	if applied == limits {
+		r.mu.Unlock()
+		return
+	}
+	r.sized[adapter] = limits
+	r.mu.Unlock()
+
+	if limits.rpm != applied.rpm {
+		cfg, ok := defaultAdapterConfigs()[adapter]
+		if !ok {
+			cfg = DefaultRateLimiterConfig()
+		}
+		if limits.rpm > 0 {
+			rpm := float64(limits.rpm)
+			// Allow bursts of ten seconds worth of requests.
+			cfg = RateLimiterConfig{MaxTokens: math.Max(1, rpm/6), RefillRate: rpm / 60}
+		}
+		r.SetConfig(adapter, cfg)
+	}
+	if limits.tpm != applied.tpm {
+		r.SetTokenBudget(adapter, limits.tpm)
+	}
+}
+
+// WaitForCall blocks until a call to model of adapter may start: until the
+// pauses of the adapter and model are over, the model's token budget has
+// room and its request limiter grants a token. The adapter's own request
+// limiter is acquired separately through Get or Wait.
+func (r *RateLimiterRegistry) WaitForCall(ctx context.Context, adapter, model string) error {
+	for {
+		r.mu.Lock()
+		now := r.now()
+		agent, limiter := r.limiter(adapter), r.modelLimiter(adapter, model)
+		d := agent.delay(now)
+		if md := limiter.delay(now); md > d {
+			d = md
+		}
+		r.mu.Unlock()
+
+		if d <= 0 {
+			return limiter.Acquire(ctx)
+		}
+		timer := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Report feeds the result of a call to model of adapter back into their
+// limiters. Successful calls speed the limiters up and spend their tokens
+// from the model's budget. Rate limit errors slow them down and pause the
+// model, or the whole adapter when the model is unknown, for the error's
+// retry hint. Other errors say nothing about rate limits and are ignored.
+func (r *RateLimiterRegistry) Report(adapter, model string, tokens int, err error) {
+	switch {
+	case err == nil:
+		r.mu.Lock()
+		agent, limiter := r.limiter(adapter), r.modelLimiter(adapter, model)
+		now := r.now()
+		limiter.tokensSpent = limiter.spent(now) + float64(tokens)
+		limiter.spentAt = now
+		limiter.consecutiveRateLimits = 0
+		agent.consecutiveRateLimits = 0
+		r.mu.Unlock()
+		agent.RecordSuccess()
+		limiter.RecordSuccess()
+
+	case core.IsCategory(err, core.ErrCatRateLimit):
+		r.mu.Lock()
+		agent, limiter := r.limiter(adapter), r.modelLimiter(adapter, model)
+		paused := limiter
+		if model == "" {
+			paused = agent
+		}
+		agent.rateLimitErrors++
+		limiter.rateLimitErrors++
+		paused.consecutiveRateLimits++
+		pause := core.GetRetryAfter(err)
+		if pause <= 0 {
+			pause = defaultRateLimitPause << (paused.consecutiveRateLimits - 1)
+			if pause > maxRateLimitPause || pause <= 0 {
+				pause = maxRateLimitPause
+			}
+		}
+		r.pause(paused, pause)
+		r.mu.Unlock()
+		agent.RecordError()
+		limiter.RecordError()
+	}
+}
+
+// Pause stops new calls to an adapter for d, as asked by a rate limit
+// reported while a call is still running.
+func (r *RateLimiterRegistry) Pause(adapter string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pause(r.limiter(adapter), d)
+}
+
+func (r *RateLimiterRegistry) pause(limiter *agentLimiter, d time.Duration) {
+	if until := r.now().Add(d); until.After(limiter.pausedUntil) {
+		limiter.pausedUntil = until
 	}
 }
 
@@ -239,17 +477,44 @@ func (r *RateLimiterRegistry) Status() map[string]RateLimiterStatus {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	now := r.now()
 	status := make(map[string]RateLimiterStatus)
 	for name, limiter := range r.limiters {
-		status[name] = RateLimiterStatus{
-			Available:  limiter.Available(),
-			MaxTokens:  limiter.MaxTokens(),
-			RefillRate: limiter.RefillRate(),
+		status[name] = limiter.status(now)
+	}
+	for key, limiter := range r.models {
+		adapter, model, _ := strings.Cut(key, "/")
+		s := status[adapter]
+		if s.Models == nil {
+			s.Models = make(map[string]RateLimiterStatus)
+		}
+		if model == "" {
+			model = "default"
 		}
+		s.Models[model] = limiter.status(now)
+		status[adapter] = s
 	}
 	return status
 }
 
+func (l *agentLimiter) status(now time.Time) RateLimiterStatus {
+	s := RateLimiterStatus{
+		Available:       l.Available(),
+		MaxTokens:       l.MaxTokens(),
+		RefillRate:      l.RefillRate(),
+		RateLimitErrors: l.rateLimitErrors,
+		TokensPerMinute: int(l.tokensPerMinute),
+	}
+	if now.Before(l.pausedUntil) {
+		until := l.pausedUntil
+		s.PausedUntil = &until
+	}
+	if l.tokensPerMinute > 0 {
+		s.TokensUsed = int(math.Ceil(l.spent(now)))
+	}
+	return s
+}
+
 // GetStatus returns rate limiter status for all adapters.
 func (r *RateLimiterRegistry) GetStatus() map[string]RateLimiterStatus {
 	return r.Status()
@@ -279,16 +544,28 @@ func (r *RateLimiterRegistry) Allow(adapter string) bool {
 
 // RateLimiterStatus contains status information.
 type RateLimiterStatus struct {
-	Available  float64
-	MaxTokens  float64
-	RefillRate float64
+	Available  float64 `json:"available"`
+	MaxTokens  float64 `json:"max_tokens"`
+	RefillRate float64 `json:"refill_rate"`
+	// PausedUntil is set while a rate limit error holds calls back.
+	PausedUntil     *time.Time `json:"paused_until,omitempty"`
+	RateLimitErrors int        `json:"rate_limit_errors"`
+	// TokensPerMinute is the token budget and TokensUsed the part of it
+	// spent in the last minute; both are zero without a budget.
+	TokensPerMinute int `json:"tokens_per_minute,omitempty"`
+	TokensUsed      int `json:"tokens_used,omitempty"`
+	// Models are the limiters of the models of an adapter; calls that did
+	// not name a model are under "default".
+	Models map[string]RateLimiterStatus `json:"models,omitempty"`
 }
 
 // Reset clears all rate limiters (useful for testing).
 func (r *RateLimiterRegistry) Reset() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.limiters = make(map[string]*RateLimiter)
+	r.limiters = make(map[string]*agentLimiter)
+	r.models = make(map[string]*agentLimiter)
+	r.sized = make(map[string]capabilityLimits)
 }
 
 // AdaptiveRateLimiter adjusts rate based on error feedback.
@@ -347,6 +624,16 @@ func (a *AdaptiveRateLimiter) RecordError() {
 	a.RateLimiter.mu.Unlock()
 }
 
+// reconfigure applies a new configuration, moving the bounds of the
+// adaptive rate with it.
+func (a *AdaptiveRateLimiter) reconfigure(cfg RateLimiterConfig) {
+	a.adaptiveMu.Lock()
+	defer a.adaptiveMu.Unlock()
+	a.updateConfig(cfg)
+	a.minRefillRate = cfg.RefillRate * 0.1
+	a.maxRefillRate = cfg.RefillRate * 2
+}
+
 // CurrentRefillRate returns the current refill rate (for testing).
 func (a *AdaptiveRateLimiter) CurrentRefillRate() float64 {
 	a.RateLimiter.mu.Lock()
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// RateLimitedRegistry wraps an agent registry so that the calls of the
+// agents it returns wait for the limiters of their agent and model, and
+// report their results back to them.
+type RateLimitedRegistry struct {
+	core.AgentRegistry
+	limits *RateLimiterRegistry
+}
+
+// WrapAgents wraps registry so that its agents are rate limited by r. A
+// registry already wrapped by r is returned as is.
+func (r *RateLimiterRegistry) WrapAgents(registry core.AgentRegistry) core.AgentRegistry {
+	if wrapped, ok := registry.(*RateLimitedRegistry); ok && wrapped.limits == r {
+		return wrapped
+	}
+	return &RateLimitedRegistry{AgentRegistry: registry, limits: r}
+}
+
+// Get returns the agent, rate limited. The first Get of an agent sizes its
+// limiters from its capabilities.
+func (l *RateLimitedRegistry) Get(name string) (core.Agent, error) {
+	agent, err := l.AgentRegistry.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	l.limits.ConfigureFromCapabilities(name, agent.Capabilities())
+	return &rateLimitedAgent{Agent: agent, name: name, limits: l.limits}, nil
+}
+
+// rateLimitedAgent waits for its limiters before each call and reports the
+// result of the call to them.
+type rateLimitedAgent struct {
+	core.Agent
+	name   string
+	limits *RateLimiterRegistry
+}
+
+// SetEventHandler sets the handler for streaming events. Rate limit errors
+// streamed with a retry hint pause the agent while its call is still running.
+func (a *rateLimitedAgent) SetEventHandler(handler core.AgentEventHandler) {
+	sc, ok := a.Agent.(core.StreamingCapable)
+	if !ok {
+		return
+	}
+	if handler == nil {
+		sc.SetEventHandler(nil)
+		return
+	}
+	sc.SetEventHandler(a.limits.observeEvents(a.name, handler))
+}
+
+// Execute waits for the limiters of the agent and model, runs the call and
+// reports its result.
+func (a *rateLimitedAgent) Execute(ctx context.Context, opts core.ExecuteOptions) (*core.ExecuteResult, error) {
+	if err := a.limits.WaitForCall(ctx, a.name, opts.Model); err != nil {
+		return nil, err
+	}
+	result, err := a.Agent.Execute(ctx, opts)
+	tokens := 0
+	if result != nil {
+		tokens = result.TokensIn + result.TokensOut
+	}
+	a.limits.Report(a.name, opts.Model, tokens, err)
+	return result, err
+}
+
+// ObserveEvents returns an event handler that pauses the agents whose
+// streamed rate limit errors carry a retry hint, and passes every event on to
+// handler, which may be nil. It is meant for handlers set on a whole agent
+// registry.
+func (r *RateLimiterRegistry) ObserveEvents(handler core.AgentEventHandler) core.AgentEventHandler {
+	return r.observeEvents("", handler)
+}
+
+// observeEvents observes the events of agent, or of the agent each event
+// names when agent is empty.
+func (r *RateLimiterRegistry) observeEvents(agent string, handler core.AgentEventHandler) core.AgentEventHandler {
+	return func(event core.AgentEvent) {
+		if event.Type == core.AgentEventError {
+			if retryAfter := eventRetryAfter(event); retryAfter > 0 {
+				name := agent
+				if name == "" {
+					name = event.Agent
+				}
+				r.Pause(name, retryAfter)
+			}
+		}
+		if handler != nil {
+			handler(event)
+		}
+	}
+}
+
+// eventRetryAfter returns the retry hint of a rate limit error event, or 0.
+func eventRetryAfter(event core.AgentEvent) time.Duration {
+	if limited, _ := event.Data["rate_limited"].(bool); !limited {
+		return 0
+	}
+	switch ms := event.Data["retry_after_ms"].(type) {
+	case int64:
+		return time.Duration(ms) * time.Millisecond
+	case int:
+		return time.Duration(ms) * time.Millisecond
+	case float64:
+		return time.Duration(ms * float64(time.Millisecond))
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/testutil"
+)
+
+func TestRateLimitedRegistry_ReportsCalls(t *testing.T) {
+	limits := NewRateLimiterRegistry()
+	calls := 0
+	codex := testutil.NewMockAgent("codex").
+		WithCapabilities(core.Capabilities{RateLimitRPM: 60, RateLimitTPM: 10000}).
+		WithExecuteFunc(func(context.Context, core.ExecuteOptions) (*core.ExecuteResult, error) {
+			calls++
+			if calls == 1 {
+				return &core.ExecuteResult{Output: "ok", TokensIn: 300, TokensOut: 200}, nil
+			}
+			return nil, core.ErrRateLimitRetryAfter("429", time.Minute)
+		})
+	base := testutil.NewMockRegistry()
+	base.Add("codex", codex)
+
+	registry := limits.WrapAgents(base)
+	if limits.WrapAgents(registry) != registry {
+		t.Error("WrapAgents() wrapped a registry twice")
+	}
+	agent, err := registry.Get("codex")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	ctx := context.Background()
+	opts := core.ExecuteOptions{Prompt: "hi", Model: "gpt-5"}
+
+	if _, err := agent.Execute(ctx, opts); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	status := limits.Status()["codex"]
+	if status.MaxTokens != 10 || status.RefillRate < 1 {
+		t.Errorf("codex status = %+v, want the limiter sized from 60 RPM", status)
+	}
+	if model := status.Models["gpt-5"]; model.TokensPerMinute != 10000 || model.TokensUsed != 500 {
+		t.Errorf("gpt-5 status = %+v, want 500 of 10000 tokens used", model)
+	}
+
+	if _, err := agent.Execute(ctx, opts); !core.IsCategory(err, core.ErrCatRateLimit) {
+		t.Fatalf("Execute() error = %v, want the rate limit error", err)
+	}
+	if until := limits.Status()["codex"].Models["gpt-5"].PausedUntil; until == nil {
+		t.Fatal("gpt-5 is not paused after a rate limit error")
+	}
+	short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := agent.Execute(short, opts); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Execute() while paused error = %v, want DeadlineExceeded", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want the paused call held back", calls)
+	}
+}
+
+func TestRateLimitedAgent_PausesOnStreamedRateLimit(t *testing.T) {
+	limits := NewRateLimiterRegistry()
+	agent := &rateLimitedAgent{Agent: &streamingAgent{MockAgent: testutil.NewMockAgent("gemini")}, name: "gemini", limits: limits}
+
+	var seen []core.AgentEvent
+	agent.SetEventHandler(func(event core.AgentEvent) { seen = append(seen, event) })
+	agent.Agent.(*streamingAgent).handler(core.NewAgentEvent(core.AgentEventError, "gemini", "quota exceeded").
+		WithData(map[string]any{"rate_limited": true, "retry_after_ms": int64(45000)}))
+
+	if len(seen) != 1 {
+		t.Errorf("handler saw %d events, want 1", len(seen))
+	}
+	if until := limits.Status()["gemini"].PausedUntil; until == nil || time.Until(*until) < 40*time.Second {
+		t.Errorf("gemini paused until %v, want ~45s from now", until)
+	}
+}
+
+// streamingAgent is a mock agent that keeps its event handler.
+type streamingAgent struct {
+	*testutil.MockAgent
+	handler core.AgentEventHandler
+}
+
+func (a *streamingAgent) SetEventHandler(handler core.AgentEventHandler) { a.handler = handler }
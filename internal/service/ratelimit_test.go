@@ -5,6 +5,8 @@ import (
 	"errors"
 	"testing"
 	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
 )
 
 func TestRateLimiter_Acquire(t *testing.T) {
@@ -442,3 +444,106 @@ func TestRateLimiter_MaxTokensCap(t *testing.T) {
 		t.Errorf("Available = %v, should not exceed MaxTokens = %v", available, cfg.MaxTokens)
 	}
 }
+
+func TestRateLimiterRegistry_ReportAdaptsAndPauses(t *testing.T) {
+	registry := NewRateLimiterRegistry()
+	now := time.Now()
+	registry.now = func() time.Time { return now }
+	initial := registry.Get("claude").RefillRate()
+
+	registry.Report("claude", "opus", 0, core.ErrRateLimitRetryAfter("429", 30*time.Second))
+	status := registry.Status()["claude"]
+	if status.RateLimitErrors != 1 || status.RefillRate >= initial {
+		t.Errorf("claude status after a rate limit = %+v, want a slower limiter", status)
+	}
+	opus := status.Models["opus"]
+	if opus.PausedUntil == nil || !opus.PausedUntil.Equal(now.Add(30*time.Second)) {
+		t.Errorf("opus paused until %v, want the retry hint", opus.PausedUntil)
+	}
+	if status.PausedUntil != nil {
+		t.Errorf("claude paused until %v, want only the model paused", status.PausedUntil)
+	}
+
+	// Without a hint, the pause doubles with each consecutive error.
+	registry.Report("claude", "", 0, core.ErrRateLimit("slow down"))
+	registry.Report("claude", "", 0, core.ErrRateLimit("slow down"))
+	if until := registry.Status()["claude"].PausedUntil; until == nil || !until.Equal(now.Add(2*defaultRateLimitPause)) {
+		t.Errorf("claude paused until %v, want %v", until, now.Add(2*defaultRateLimitPause))
+	}
+
+	// Other errors say nothing about rate limits.
+	registry.Report("claude", "opus", 0, errors.New("boom"))
+	if got := registry.Status()["claude"].RateLimitErrors; got != 3 {
+		t.Errorf("RateLimitErrors = %d, want 3", got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := registry.WaitForCall(ctx, "claude", "opus"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForCall() while paused error = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestRateLimiterRegistry_TokenBudget(t *testing.T) {
+	registry := NewRateLimiterRegistry()
+	now := time.Now()
+	registry.now = func() time.Time { return now }
+	registry.SetTokenBudget("gemini", 1000)
+
+	registry.Report("gemini", "flash", 1500, nil)
+	status := registry.Status()["gemini"].Models["flash"]
+	if status.TokensPerMinute != 1000 || status.TokensUsed != 1500 {
+		t.Errorf("flash status = %+v, want 1500 of 1000 tokens used", status)
+	}
+
+	registry.mu.Lock()
+	limiter := registry.modelLimiter("gemini", "flash")
+	// The debt drains at the budget's rate: 501 tokens take ~30s.
+	if d := limiter.delay(now); d < 29*time.Second || d > 31*time.Second {
+		t.Errorf("delay over budget = %v, want ~30s", d)
+	}
+	if d := limiter.delay(now.Add(time.Minute)); d != 0 {
+		t.Errorf("delay a minute later = %v, want 0", d)
+	}
+	registry.mu.Unlock()
+
+	// Other models of the agent have their own budget.
+	if err := registry.WaitForCall(context.Background(), "gemini", "pro"); err != nil {
+		t.Errorf("WaitForCall() on another model error = %v", err)
+	}
+}
+
+func TestRateLimiterRegistry_ConfigureFromCapabilities(t *testing.T) {
+	registry := NewRateLimiterRegistry()
+	registry.ConfigureFromCapabilities("codex", core.Capabilities{RateLimitRPM: 60, RateLimitTPM: 1000})
+	registry.Report("codex", "gpt-5", 100, nil)
+	registry.Get("codex")
+	registry.limiters["codex"].RecordError()
+	backedOff := registry.Status()["codex"].RefillRate
+
+	// Unchanged limits keep the adapted rate.
+	registry.ConfigureFromCapabilities("codex", core.Capabilities{RateLimitRPM: 60, RateLimitTPM: 1000})
+	if got := registry.Status()["codex"].RefillRate; got != backedOff || backedOff >= 1 {
+		t.Errorf("refill rate = %v, want the backed off %v kept", got, backedOff)
+	}
+
+	// Changed limits replace the applied ones.
+	registry.ConfigureFromCapabilities("codex", core.Capabilities{RateLimitRPM: 120, RateLimitTPM: 2000})
+	status := registry.Status()["codex"]
+	if status.MaxTokens != 20 || status.RefillRate != 2 {
+		t.Errorf("codex status = %+v, want the limiter sized from 120 RPM", status)
+	}
+	if tpm := status.Models["gpt-5"].TokensPerMinute; tpm != 2000 {
+		t.Errorf("gpt-5 tokens per minute = %v, want 2000", tpm)
+	}
+
+	// Removed limits restore the defaults.
+	registry.ConfigureFromCapabilities("codex", core.Capabilities{})
+	status = registry.Status()["codex"]
+	if want := defaultAdapterConfigs()["codex"]; status.MaxTokens != want.MaxTokens || status.RefillRate != want.RefillRate {
+		t.Errorf("codex status = %+v, want the defaults %+v", status, want)
+	}
+	if tpm := status.Models["gpt-5"].TokensPerMinute; tpm != 0 {
+		t.Errorf("gpt-5 tokens per minute = %v, want no budget", tpm)
+	}
+}
@@ -264,6 +264,74 @@ func (w *WorkflowReportWriter) WriteOriginalPrompt(prompt string) error {
 	return w.writeFile(path, fm, content)
 }
 
+// SourceChatData references the chat conversation a workflow was promoted from
+type SourceChatData struct {
+	SessionID      string
+	Title          string
+	TranscriptPath string // Project-relative path of the transcript attachment
+}
+
+// WriteSourceChat writes the reference to the originating chat conversation
+func (w *WorkflowReportWriter) WriteSourceChat(data SourceChatData) error {
+	if !w.config.Enabled {
+		return nil
+	}
+	if err := w.Initialize(); err != nil {
+		return err
+	}
+
+	path := filepath.Join(w.AnalyzePhasePath(), "00-source-chat.md")
+
+	fm := NewFrontmatter()
+	fm.Set("type", "source_chat")
+	fm.Set("timestamp", w.formatTime(time.Now()))
+	fm.Set("workflow_id", w.workflowID)
+	fm.Set("chat_session_id", data.SessionID)
+
+	var b strings.Builder
+	b.WriteString("# Source Chat\n\n")
+	b.WriteString("This workflow was promoted from a chat conversation.\n\n")
+	if data.Title != "" {
+		fmt.Fprintf(&b, "- **Title:** %s\n", data.Title)
+	}
+	fmt.Fprintf(&b, "- **Session:** `%s`\n", data.SessionID)
+	if data.TranscriptPath != "" {
+		fmt.Fprintf(&b, "- **Transcript:** `%s`\n", data.TranscriptPath)
+	}
+
+	return w.writeFile(path, fm, b.String())
+}
+
+// WriteRelevantContext writes the files and symbols the codebase index found
+// relevant to the request, as given to the agents of a phase ("analyze" or "plan")
+func (w *WorkflowReportWriter) WriteRelevantContext(phase, files string) error {
+	if !w.config.Enabled {
+		return nil
+	}
+	if err := w.Initialize(); err != nil {
+		return err
+	}
+
+	dir := w.AnalyzePhasePath()
+	if phase == "plan" {
+		dir = w.PlanPhasePath()
+	}
+	path := filepath.Join(dir, "00-relevant-context.md")
+
+	fm := NewFrontmatter()
+	fm.Set("type", "relevant_context")
+	fm.Set("timestamp", w.formatTime(time.Now()))
+	fm.Set("workflow_id", w.workflowID)
+	fm.Set("phase", phase)
+
+	var b strings.Builder
+	b.WriteString("# Likely Relevant Files and Symbols\n\n")
+	b.WriteString("Ranked by the local codebase index and added to the prompts of this phase.\n\n")
+	b.WriteString(files)
+
+	return w.writeFile(path, fm, b.String())
+}
+
 // WriteRefinedPrompt writes the refined prompt (raw content only, no metadata)
 func (w *WorkflowReportWriter) WriteRefinedPrompt(_, refined string, _ PromptMetrics) error {
 	if !w.config.Enabled {
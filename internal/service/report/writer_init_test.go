@@ -166,6 +166,32 @@ func TestWorkflowReportWriter_WriteRefinedPrompt(t *testing.T) {
 	}
 }
 
+// --- WriteRelevantContext ---
+
+func TestWorkflowReportWriter_WriteRelevantContext(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	cfg := Config{BaseDir: tmpDir, Enabled: true}
+	w := NewWorkflowReportWriter(cfg, "wf-relevant-test")
+
+	if err := w.WriteRelevantContext("analyze", "- `a.go`\n"); err != nil {
+		t.Fatalf("WriteRelevantContext() error = %v", err)
+	}
+	if err := w.WriteRelevantContext("plan", "- `b.go`\n"); err != nil {
+		t.Fatalf("WriteRelevantContext() error = %v", err)
+	}
+
+	for dir, want := range map[string]string{w.AnalyzePhasePath(): "a.go", w.PlanPhasePath(): "b.go"} {
+		data, err := os.ReadFile(filepath.Join(dir, "00-relevant-context.md"))
+		if err != nil {
+			t.Fatalf("failed to read relevant context: %v", err)
+		}
+		if !strings.Contains(string(data), want) {
+			t.Errorf("%s should list %s:\n%s", dir, want, data)
+		}
+	}
+}
+
 // --- WriteV1Analysis ---
 
 func TestWorkflowReportWriter_WriteV1Analysis(t *testing.T) {
@@ -586,3 +612,30 @@ func TestWorkflowReportWriter_TasksDir_Disabled(t *testing.T) {
 		t.Errorf("disabled TasksDir() = %q, want empty", got)
 	}
 }
+
+// --- WriteSourceChat ---
+
+func TestWorkflowReportWriter_WriteSourceChat(t *testing.T) {
+	t.Parallel()
+	cfg := Config{BaseDir: t.TempDir(), Enabled: true, UseUTC: true}
+	w := NewWorkflowReportWriter(cfg, "wf-chat-test")
+
+	err := w.WriteSourceChat(SourceChatData{
+		SessionID:      "chat-1",
+		Title:          "Retry strategy",
+		TranscriptPath: ".quorum/attachments/workflows/wf-chat-test/a1/chat-transcript.md",
+	})
+	if err != nil {
+		t.Fatalf("WriteSourceChat() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(w.AnalyzePhasePath(), "00-source-chat.md"))
+	if err != nil {
+		t.Fatalf("failed to read source chat file: %v", err)
+	}
+	for _, want := range []string{"source_chat", "chat_session_id: chat-1", "Retry strategy", "chat-transcript.md"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("source chat report should contain %q:\n%s", want, data)
+		}
+	}
+}
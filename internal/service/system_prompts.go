@@ -88,7 +88,7 @@ func validateSystemPromptMeta(meta systemPromptFrontmatter, idFromFilename strin
 	}
 	for _, v := range meta.UsedBy {
 		switch v {
-		case "workflow", "issues":
+		case "workflow", "issues", "chat":
 		default:
 			return fmt.Errorf("frontmatter: invalid used_by value %q (id=%s)", v, meta.ID)
 		}
@@ -124,9 +124,10 @@ func (a *PromptRendererAdapter) RenderRefinePrompt(params RefinePromptParams) (s
 // RenderAnalyzeV1 renders the initial analysis prompt.
 func (a *PromptRendererAdapter) RenderAnalyzeV1(params AnalyzeV1Params) (string, error) {
 	return a.renderer.RenderAnalyzeV1(service.AnalyzeV1Params{
-		Prompt:         params.Prompt,
-		Context:        params.Context,
-		OutputFilePath: params.OutputFilePath,
+		Prompt:          params.Prompt,
+		Context:         params.Context,
+		OutputFilePath:  params.OutputFilePath,
+		RelevantContext: params.RelevantContext,
 	})
 }
 
@@ -156,6 +157,7 @@ func (a *PromptRendererAdapter) RenderPlanGenerate(params PlanParams) (string, e
 		Prompt:               params.Prompt,
 		ConsolidatedAnalysis: params.ConsolidatedAnalysis,
 		MaxTasks:             params.MaxTasks,
+		RelevantContext:      params.RelevantContext,
 	})
 }
 
@@ -165,6 +167,7 @@ func (a *PromptRendererAdapter) RenderPlanManifest(params PlanParams) (string, e
 		Prompt:               params.Prompt,
 		ConsolidatedAnalysis: params.ConsolidatedAnalysis,
 		MaxTasks:             params.MaxTasks,
+		RelevantContext:      params.RelevantContext,
 	})
 }
 
@@ -187,6 +190,8 @@ func (a *PromptRendererAdapter) RenderPlanComprehensive(params ComprehensivePlan
 		AvailableAgents:      agents,
 		TasksDir:             params.TasksDir,
 		NamingConvention:     params.NamingConvention,
+		Repositories:         params.Repositories,
+		RelevantContext:      params.RelevantContext,
 	})
 }
 
@@ -223,11 +228,30 @@ func (a *PromptRendererAdapter) RenderSynthesizePlans(params SynthesizePlansPara
 
 // RenderTaskExecute renders the task execution prompt.
 func (a *PromptRendererAdapter) RenderTaskExecute(params TaskExecuteParams) (string, error) {
+	var resume *service.TaskResumeParams
+	if params.Resume != nil {
+		resume = &service.TaskResumeParams{
+			Checkpoint:     params.Resume.Checkpoint,
+			Diff:           params.Resume.Diff,
+			PreviousOutput: params.Resume.PreviousOutput,
+		}
+	}
+	answers := make([]service.TaskInputAnswer, len(params.Answers))
+	for i, answer := range params.Answers {
+		answers[i] = service.TaskInputAnswer{
+			Question:  answer.Question,
+			Answer:    answer.Answer,
+			Defaulted: answer.Defaulted,
+			Output:    answer.Output,
+		}
+	}
 	return a.renderer.RenderTaskExecute(service.TaskExecuteParams{
 		Task:        params.Task,
 		Context:     params.Context,
 		WorkDir:     params.WorkDir,
 		Constraints: params.Constraints,
+		Resume:      resume,
+		Answers:     answers,
 	})
 }
 
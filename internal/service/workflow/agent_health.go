@@ -0,0 +1,58 @@
+package workflow
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/agenthealth"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// NewAgentHealthMonitor creates the agent health monitor from the
+// agent_health configuration, or returns nil when health tracking is
+// disabled. logger may be nil.
+func NewAgentHealthMonitor(cfg config.AgentHealthConfig, logger *slog.Logger) *agenthealth.Monitor {
+	if !cfg.Enabled {
+		return nil
+	}
+	// Invalid durations are rejected by the validator; zero takes the default.
+	window, _ := time.ParseDuration(cfg.Window)
+	cooldown, _ := time.ParseDuration(cfg.Cooldown)
+	return agenthealth.New(agenthealth.Options{
+		Window:          window,
+		Cooldown:        cooldown,
+		MinCalls:        cfg.MinCalls,
+		MinSuccessRate:  cfg.MinSuccessRate,
+		RateLimitErrors: cfg.RateLimitErrors,
+		AuthErrors:      cfg.AuthErrors,
+		Fallbacks:       cfg.Fallbacks,
+		Logger:          logger,
+	})
+}
+
+// trackAgentHealth wraps the agents of the context so that their calls feed
+// the health monitor, and records the calls that ran on a fallback agent in
+// the workflow state. Repeated substitutions of an agent in a phase are
+// recorded once.
+func (c *Context) trackAgentHealth(monitor *agenthealth.Monitor) {
+	c.Agents = monitor.Wrap(c.Agents, func(sub core.AgentSubstitution) {
+		c.Lock()
+		recorded := false
+		for _, prev := range c.State.AgentSubstitutions {
+			if prev.Agent == sub.Agent && prev.Fallback == sub.Fallback && prev.Phase == sub.Phase {
+				recorded = true
+				break
+			}
+		}
+		if !recorded {
+			c.State.AgentSubstitutions = append(c.State.AgentSubstitutions, sub)
+		}
+		c.Unlock()
+		if !recorded && c.Output != nil {
+			c.Output.Log("warn", "workflow", fmt.Sprintf("Agent %s is quarantined (%s); running its %s calls on %s",
+				sub.Agent, sub.Reason, sub.Phase, sub.Fallback))
+		}
+	})
+}
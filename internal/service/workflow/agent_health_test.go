@@ -0,0 +1,44 @@
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/testutil"
+)
+
+func TestContext_RecordsAgentSubstitutions(t *testing.T) {
+	t.Parallel()
+	if NewAgentHealthMonitor(config.AgentHealthConfig{}, nil) != nil {
+		t.Fatal("monitor created while agent health is disabled")
+	}
+	monitor := NewAgentHealthMonitor(config.AgentHealthConfig{
+		Enabled: true, Window: "1h", Cooldown: "15m", MinCalls: 5, AuthErrors: 1,
+		Fallbacks: map[string]string{"claude": "gemini"},
+	}, nil)
+
+	registry := testutil.NewMockRegistry()
+	registry.Add("claude", testutil.NewMockAgent("claude").WithError(core.ErrAuth("expired")))
+	registry.Add("gemini", testutil.NewMockAgent("gemini"))
+	wctx := &Context{State: &core.WorkflowState{}, Agents: registry, Output: NopOutputNotifier{}}
+	wctx.trackAgentHealth(monitor)
+
+	agent, err := wctx.Agents.Get("claude")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	ctx := context.Background()
+	_, _ = agent.Execute(ctx, core.ExecuteOptions{Phase: core.PhaseAnalyze})
+	for _, phase := range []core.Phase{core.PhaseAnalyze, core.PhaseAnalyze, core.PhaseExecute} {
+		if _, err := agent.Execute(ctx, core.ExecuteOptions{Phase: phase}); err != nil {
+			t.Fatalf("Execute() on the fallback error = %v", err)
+		}
+	}
+
+	subs := wctx.State.AgentSubstitutions
+	if len(subs) != 2 || subs[0].Phase != core.PhaseAnalyze || subs[1].Phase != core.PhaseExecute || subs[1].Fallback != "gemini" {
+		t.Errorf("AgentSubstitutions = %+v, want one per phase", subs)
+	}
+}
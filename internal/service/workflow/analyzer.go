@@ -177,9 +177,10 @@ func (a *Analyzer) runSingleAgentAnalysis(ctx context.Context, wctx *Context) er
 
 	// Render analysis prompt
 	prompt, err := wctx.Prompts.RenderAnalyzeV1(AnalyzeV1Params{
-		Prompt:         GetEffectivePrompt(wctx.State),
-		Context:        BuildContextString(wctx.State),
-		OutputFilePath: outputFilePath,
+		Prompt:          GetEffectivePrompt(wctx.State),
+		Context:         BuildContextString(wctx.State),
+		OutputFilePath:  outputFilePath,
+		RelevantContext: wctx.RelevantContext(ctx, core.PhaseAnalyze, GetEffectivePrompt(wctx.State)),
 	})
 	if err != nil {
 		return fmt.Errorf("rendering prompt: %w", err)
@@ -513,6 +514,13 @@ func (a *Analyzer) runModeratorRound(ctx context.Context, wctx *Context, round i
 		}
 		wctx.Output.Log(level, "analyzer", fmt.Sprintf("%s Round %d: Semantic consensus %.0f%% (threshold: %.0f%%)",
 			statusIcon, round, evalResult.Score*100, effectiveThreshold*100))
+		// Emit a structured round event if the output supports it (web and JSON notifiers do)
+		type moderatorRoundNotifier interface {
+			ModeratorRound(round int, score, threshold float64, agreements, divergences int)
+		}
+		if mn, ok := wctx.Output.(moderatorRoundNotifier); ok {
+			mn.ModeratorRound(round, evalResult.Score, effectiveThreshold, len(evalResult.Agreements), len(evalResult.Divergences))
+		}
 	}
 
 	return evalResult, nil
@@ -1302,9 +1310,10 @@ func (a *Analyzer) runAnalysisWithAgent(ctx context.Context, wctx *Context, agen
 
 	// Render prompt (use optimized prompt if available)
 	prompt, err := wctx.Prompts.RenderAnalyzeV1(AnalyzeV1Params{
-		Prompt:         GetEffectivePrompt(wctx.State),
-		Context:        BuildContextString(wctx.State),
-		OutputFilePath: outputFilePath,
+		Prompt:          GetEffectivePrompt(wctx.State),
+		Context:         BuildContextString(wctx.State),
+		OutputFilePath:  outputFilePath,
+		RelevantContext: wctx.RelevantContext(ctx, core.PhaseAnalyze, GetEffectivePrompt(wctx.State)),
 	})
 	if err != nil {
 		return AnalysisOutput{}, fmt.Errorf("rendering prompt: %w", err)
@@ -8,6 +8,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/agenthealth"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/control"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
@@ -76,6 +77,8 @@ type RunnerBuilder struct {
 	githubClient     core.GitHubClient
 	gitClientFactory GitClientFactory
 	worktreeManager  WorktreeManager
+	repositories     *RepositorySet
+	agentHealth      *agenthealth.Monitor
 
 	// Git isolation configuration
 	gitIsolation *GitIsolationConfig
@@ -98,6 +101,9 @@ type RunnerBuilder struct {
 	// Project root directory (for multi-project support)
 	projectRoot string
 
+	// Config history version of the application config
+	configVersion int
+
 	// Error tracking
 	errors []error
 }
@@ -263,6 +269,21 @@ func (b *RunnerBuilder) WithWorktreeManager(wm WorktreeManager) *RunnerBuilder {
 	return b
 }
 
+// WithRepositorySet sets how the other repositories of multi-repository
+// workflows are opened.
+func (b *RunnerBuilder) WithRepositorySet(rs *RepositorySet) *RunnerBuilder {
+	b.repositories = rs
+	return b
+}
+
+// WithAgentHealth sets the agent health monitor shared with other runners.
+// Without one, a monitor for this runner is created when agent_health is
+// enabled.
+func (b *RunnerBuilder) WithAgentHealth(m *agenthealth.Monitor) *RunnerBuilder {
+	b.agentHealth = m
+	return b
+}
+
 // WithProjectRoot sets the project root directory for workflow execution.
 // This is used when running workflows in a different project than the server's CWD.
 func (b *RunnerBuilder) WithProjectRoot(root string) *RunnerBuilder {
@@ -270,6 +291,13 @@ func (b *RunnerBuilder) WithProjectRoot(root string) *RunnerBuilder {
 	return b
 }
 
+// WithConfigVersion sets the config history version of the application config,
+// recorded on the workflow state of each execution.
+func (b *RunnerBuilder) WithConfigVersion(version int) *RunnerBuilder {
+	b.configVersion = version
+	return b
+}
+
 // WithWorkflowConfig sets workflow-specific configuration overrides.
 // When provided, these settings take precedence over global application config.
 // This enables per-workflow execution mode selection (single-agent vs multi-agent).
@@ -398,11 +426,23 @@ func (b *RunnerBuilder) Build(ctx context.Context) (*Runner, error) {
 		}
 	}
 
-	// Create runner dependencies
+	// Repositories of multi-repository workflows are opened on first use
+	repositories := b.repositories
+	if repositories == nil && !b.skipGitAutoCreate {
+		repositories = NewGitRepositorySet(b.config.Git.Worktree.Dir, gitIsolation.Enabled, b.config.Git.Finalization.AutoPR, logger)
+	}
+
+	agentHealth := b.agentHealth
+	if agentHealth == nil {
+		agentHealth = NewAgentHealthMonitor(b.config.AgentHealth, logger.Logger)
+	}
+
+	// Create runner dependencies. Agent calls wait for and report to the
+	// rate limiters.
 	deps := RunnerDeps{
 		Config:            runnerConfig,
 		State:             b.stateManager,
-		Agents:            b.agentRegistry,
+		Agents:            rateLimiter.WrapAgents(b.agentRegistry),
 		DAG:               dagAdapter,
 		Checkpoint:        checkpointAdapter,
 		ResumeProvider:    resumeAdapter,
@@ -421,6 +461,9 @@ func (b *RunnerBuilder) Build(ctx context.Context) (*Runner, error) {
 		Control:           b.controlPlane,
 		Heartbeat:         b.heartbeat,
 		ProjectRoot:       b.projectRoot,
+		Repositories:      repositories,
+		ContextIndex:      NewContextIndexer(b.projectRoot, b.config.Index),
+		AgentHealth:       agentHealth,
 	}
 
 	// Create the runner
@@ -461,6 +504,7 @@ func (b *RunnerBuilder) buildRunnerConfig() *RunnerConfig {
 	}
 
 	runnerCfg.SingleAgent = b.buildSingleAgentConfig(cfg)
+	runnerCfg.ConfigVersion = b.configVersion
 
 	return runnerCfg
 }
@@ -553,14 +597,15 @@ func BuildRunnerConfigFromConfig(cfg *config.Config) *RunnerConfig {
 	}
 
 	return &RunnerConfig{
-		Timeout:           timeout,
-		MaxRetries:        cfg.Workflow.MaxRetries,
-		DryRun:            cfg.Workflow.DryRun,
-		DenyTools:         cfg.Workflow.DenyTools,
-		DefaultAgent:      cfg.Agents.Default,
-		AgentPhaseModels:  buildAgentPhaseModels(cfg.Agents),
-		WorktreeAutoClean: cfg.Git.Worktree.AutoClean,
-		WorktreeMode:      cfg.Git.Worktree.Mode,
+		Timeout:                timeout,
+		MaxRetries:             cfg.Workflow.MaxRetries,
+		DryRun:                 cfg.Workflow.DryRun,
+		DenyTools:              cfg.Workflow.DenyTools,
+		DefaultAgent:           cfg.Agents.Default,
+		AgentPhaseModels:       buildAgentPhaseModels(cfg.Agents),
+		WorktreeAutoClean:      cfg.Git.Worktree.AutoClean,
+		WorktreeMode:           cfg.Git.Worktree.Mode,
+		TaskCheckpointInterval: ParseTaskCheckpointInterval(cfg.Git.Task.CheckpointInterval),
 		Refiner: RefinerConfig{
 			Enabled:  cfg.Phases.Analyze.Refiner.Enabled,
 			Agent:    cfg.Phases.Analyze.Refiner.Agent,
@@ -595,6 +640,8 @@ func BuildRunnerConfigFromConfig(cfg *config.Config) *RunnerConfig {
 			Execute:            executeTimeout,
 			ProcessGracePeriod: processGracePeriod,
 		},
+		TaskInput:      NewTaskInputConfig(cfg.Phases.Execute.Input),
+		SkipDependents: cfg.Phases.Execute.SkipDependents,
 		Finalization: FinalizationConfig{
 			AutoCommit:    cfg.Git.Task.AutoCommit,
 			AutoPush:      cfg.Git.Finalization.AutoPush,
@@ -602,6 +649,8 @@ func BuildRunnerConfigFromConfig(cfg *config.Config) *RunnerConfig {
 			AutoMerge:     cfg.Git.Finalization.AutoMerge,
 			PRBaseBranch:  cfg.Git.Finalization.PRBaseBranch,
 			MergeStrategy: cfg.Git.Finalization.MergeStrategy,
+			Remote:        cfg.GitHub.Remote,
+			Babysit:       NewPRBabysitConfig(cfg.Git.Finalization.Babysit),
 		},
 		Report: report.Config{
 			Enabled:    cfg.Report.Enabled,
@@ -699,6 +748,7 @@ var (
 	createGitHubClient            = defaultCreateGitHubClient
 	createGitClientFactory        = defaultCreateGitClientFactory
 	createWorkflowWorktreeManager = defaultCreateWorkflowWorktreeManager
+	createRemoteGitHubClient      = defaultCreateRemoteGitHubClient
 )
 
 func defaultCreateGitClient(_ string) (core.GitClient, error) {
@@ -721,6 +771,18 @@ func defaultCreateWorkflowWorktreeManager(_ core.GitClient, _, _ string, _ *logg
 	return nil, fmt.Errorf("workflow worktree manager factory not configured")
 }
 
+func defaultCreateRemoteGitHubClient(_ string) (core.GitHubClient, error) {
+	return nil, fmt.Errorf("github client factory not configured")
+}
+
+// SetRemoteGitHubFactory sets the factory that creates GitHub clients for the
+// other repositories of multi-repository workflows from their remote URL.
+func SetRemoteGitHubFactory(fn func(remoteURL string) (core.GitHubClient, error)) {
+	if fn != nil {
+		createRemoteGitHubClient = fn
+	}
+}
+
 // SetGitFactories sets the factory functions for creating Git components.
 // This should be called during application initialization to wire up the git adapters.
 func SetGitFactories(
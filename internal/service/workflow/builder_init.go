@@ -49,4 +49,9 @@ func init() {
 			return git.NewWorkflowWorktreeManager(repoRoot, worktreeDir, gitClient, slogger)
 		},
 	)
+
+	// GitHub clients for the other repositories of multi-repository workflows
+	SetRemoteGitHubFactory(func(remoteURL string) (core.GitHubClient, error) {
+		return github.NewClientForRemote(remoteURL)
+	})
 }
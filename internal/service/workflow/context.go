@@ -83,6 +83,14 @@ type Context struct {
 	// Project root directory for multi-project support.
 	// Used as fallback working directory when worktrees are not enabled.
 	ProjectRoot string
+
+	// Repositories opens the other repositories of multi-repository workflows.
+	Repositories *RepositorySet
+
+	// ContextIndex finds the files relevant to the request (optional).
+	ContextIndex    ContextIndexer
+	indexMu         sync.Mutex
+	relevantContext map[core.Phase]string // Per-phase RelevantContext results
 }
 
 // ModeEnforcerInterface provides mode enforcement capabilities.
@@ -110,12 +118,17 @@ type Config struct {
 	DryRun       bool
 	DenyTools    []string
 	DefaultAgent string
+	// MaxRetries is the configured retry budget for agent executions (reported in retry events).
+	MaxRetries int
 	// AgentPhaseModels allows per-agent, per-phase model overrides.
 	AgentPhaseModels map[string]map[string]string
 	// WorktreeAutoClean controls automatic worktree cleanup after task execution.
 	WorktreeAutoClean bool
 	// WorktreeMode controls when worktrees are created for tasks.
 	WorktreeMode string
+	// TaskCheckpointInterval is how often the work in progress of a task
+	// running in a worktree is checkpointed (0 disables checkpoints).
+	TaskCheckpointInterval time.Duration
 	// SynthesizerAgent specifies which agent to use for analysis synthesis.
 	// The model is resolved from AgentPhaseModels[agent][analyze].
 	SynthesizerAgent string
@@ -126,6 +139,11 @@ type Config struct {
 	PlanSynthesizerAgent string
 	// PhaseTimeouts holds per-phase timeout durations.
 	PhaseTimeouts PhaseTimeouts
+	// TaskInput configures how tasks wait for the answers to their questions.
+	TaskInput TaskInputConfig
+	// SkipDependents is what happens to the dependents of a skipped task:
+	// "run" (default) or "skip".
+	SkipDependents string
 	// Moderator configures semantic consensus evaluation via a moderator LLM.
 	Moderator ModeratorConfig
 	// SingleAgent configures single-agent execution mode (bypasses multi-agent consensus).
@@ -186,6 +204,15 @@ type PhaseTimeouts struct {
 	ProcessGracePeriod time.Duration // Time to wait after logical completion before killing (default: 30s)
 }
 
+// TaskInputConfig configures how execute tasks wait for human answers.
+type TaskInputConfig struct {
+	// Timeout is how long a task waits for an answer (0 = no limit).
+	Timeout time.Duration
+	// OnTimeout is what happens to an unanswered question: "default" (the
+	// zero value), "skip" or "fail".
+	OnTimeout string
+}
+
 // FinalizationConfig configures post-task git operations.
 type FinalizationConfig struct {
 	// AutoCommit commits changes after each task completes.
@@ -202,6 +229,25 @@ type FinalizationConfig struct {
 	MergeStrategy string
 	// Remote is the git remote name (default: origin).
 	Remote string
+	// Babysit configures the post-PR CI loop (workflow isolation only).
+	Babysit PRBabysitConfig
+}
+
+// PRBabysitConfig configures the post-PR loop that waits for checks and
+// feeds CI failures back to an agent.
+type PRBabysitConfig struct {
+	// Enabled activates the loop after the workflow PR is created.
+	Enabled bool
+	// MaxAttempts is the number of fix attempts (0 = only wait for checks).
+	MaxAttempts int
+	// Agent runs the fix tasks (empty = default agent).
+	Agent string
+	// ChecksTimeout bounds each wait for checks (0 = adapter default).
+	ChecksTimeout time.Duration
+	// PollInterval is how often checks are polled (0 = adapter default).
+	PollInterval time.Duration
+	// RequiredChecks limits the loop to these checks (empty = all).
+	RequiredChecks []string
 }
 
 // PromptRenderer renders prompts for different phases.
@@ -243,9 +289,10 @@ type RefinePromptParams struct {
 
 // AnalyzeV1Params holds parameters for V1 analysis prompt.
 type AnalyzeV1Params struct {
-	Prompt         string
-	Context        string
-	OutputFilePath string // Path where LLM should write output
+	Prompt          string
+	Context         string
+	OutputFilePath  string // Path where LLM should write output
+	RelevantContext string // Files and symbols the codebase index ranked relevant (optional)
 }
 
 // PlanParams holds parameters for plan generation prompt.
@@ -253,6 +300,7 @@ type PlanParams struct {
 	Prompt               string
 	ConsolidatedAnalysis string
 	MaxTasks             int
+	RelevantContext      string // Files and symbols the codebase index ranked relevant (optional)
 }
 
 // AgentInfo contains information about an available agent for task assignment.
@@ -271,6 +319,11 @@ type ComprehensivePlanParams struct {
 	AvailableAgents      []AgentInfo // Agents available for task execution
 	TasksDir             string      // Directory where task files should be written
 	NamingConvention     string      // File naming convention (e.g., "{id}-{name}.md")
+	// Repositories are the other projects a multi-repository workflow changes.
+	Repositories []core.WorkflowRepository
+	// RelevantContext lists the files and symbols the codebase index ranked
+	// relevant (optional).
+	RelevantContext string
 }
 
 // TaskExecuteParams holds parameters for task execution prompt.
@@ -280,6 +333,27 @@ type TaskExecuteParams struct {
 	WorkDir string
 	// Constraints are optional additional rules for the agent (e.g., policy limits).
 	Constraints []string
+	// Resume, when set, asks the agent to continue an interrupted execution.
+	Resume *TaskResumeParams
+	// Answers holds the questions the agent asked in earlier executions of
+	// the task and their answers.
+	Answers []TaskInputAnswer
+}
+
+// TaskInputAnswer is a question asked by the agent of a task and its answer.
+type TaskInputAnswer struct {
+	Question  string
+	Answer    string
+	Defaulted bool   // The answer is the agent's proposal, the question went unanswered
+	Output    string // Output of the execution that asked the question
+}
+
+// TaskResumeParams describes the work an interrupted task execution left
+// behind in its last work-in-progress checkpoint.
+type TaskResumeParams struct {
+	Checkpoint     string // Commit of the checkpoint
+	Diff           string // Changes made so far, relative to the task's base
+	PreviousOutput string // Output of the interrupted attempt, if any
 }
 
 // ModeratorAnalysisSummary represents an analysis for moderator evaluation.
@@ -460,6 +534,56 @@ func (c *Context) UseWorkflowIsolation() bool {
 	return c.State != nil && c.State.WorkflowBranch != ""
 }
 
+// TaskRepository returns the git components of the repository a task targets,
+// or nil for tasks that target the workflow's own project.
+func (c *Context) TaskRepository(ctx context.Context, task *core.Task) (*RepositoryGit, error) {
+	if task == nil || task.Repository == "" {
+		return nil, nil
+	}
+	c.mu.RLock()
+	repo, ok := c.State.Repository(task.Repository)
+	c.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("task %s targets undeclared repository %q", task.ID, task.Repository)
+	}
+	return c.Repositories.Get(ctx, repo)
+}
+
+// repositoryWorkflowBranch returns the workflow branch of a declared
+// repository, or "" when it has none yet.
+func (c *Context) repositoryWorkflowBranch(name string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.State == nil {
+		return ""
+	}
+	if rs := c.State.RepositoryStates[name]; rs != nil {
+		return rs.WorkflowBranch
+	}
+	return ""
+}
+
+// useWorkflowIsolationFor reports whether a task runs in a workflow-scoped
+// worktree, in its own repository when it targets another one.
+func (c *Context) useWorkflowIsolationFor(task *core.Task, repo *RepositoryGit) bool {
+	if !c.UseWorkflowIsolation() {
+		return false
+	}
+	if repo == nil {
+		return true
+	}
+	return repo.Worktrees != nil && c.repositoryWorkflowBranch(task.Repository) != ""
+}
+
+// workflowWorktreesFor returns the workflow worktree manager of the repository
+// a task targets.
+func (c *Context) workflowWorktreesFor(repo *RepositoryGit) core.WorkflowWorktreeManager {
+	if repo != nil {
+		return repo.Worktrees
+	}
+	return c.WorkflowWorktrees
+}
+
 // UpdateMetrics safely updates workflow metrics.
 func (c *Context) UpdateMetrics(fn func(m *core.StateMetrics)) {
 	c.mu.Lock()
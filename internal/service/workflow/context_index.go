@@ -0,0 +1,78 @@
+package workflow
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/codeindex"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// ContextIndexer finds the files and symbols of the project most relevant to
+// a request, so that the analyze and plan agents start from them instead of
+// each exploring the repository from scratch.
+type ContextIndexer interface {
+	// RelevantContext returns a markdown list of the relevant files and
+	// symbols, or an empty string when nothing matches.
+	RelevantContext(ctx context.Context, query string) (string, error)
+}
+
+// NewContextIndexer creates the codebase index of the project at root from
+// the index configuration, or returns nil when the index is disabled.
+func NewContextIndexer(root string, cfg config.IndexConfig) ContextIndexer {
+	if !cfg.Enabled {
+		return nil
+	}
+	if root == "" {
+		root = "."
+	}
+	dbPath := cfg.Path
+	if dbPath != "" && !filepath.IsAbs(dbPath) {
+		dbPath = filepath.Join(root, dbPath)
+	}
+	return codeindex.New(root, codeindex.Options{
+		DBPath:      dbPath,
+		MaxResults:  cfg.MaxResults,
+		MaxBytes:    cfg.MaxBytes,
+		MaxFileSize: cfg.MaxFileSize,
+	})
+}
+
+// RelevantContext returns the files and symbols relevant to query for a
+// phase, or "" when no index is configured or it fails. It is computed once
+// per phase, so the agents of a phase share it, and recorded in the report.
+func (c *Context) RelevantContext(ctx context.Context, phase core.Phase, query string) string {
+	if c.ContextIndex == nil {
+		return ""
+	}
+	c.indexMu.Lock()
+	defer c.indexMu.Unlock()
+	if files, ok := c.relevantContext[phase]; ok {
+		return files
+	}
+
+	files, err := c.ContextIndex.RelevantContext(ctx, query)
+	if err != nil {
+		// The index only helps the agents; they explore the repository without it.
+		c.Logger.Warn("codebase index unavailable", "phase", phase, "error", err)
+		files = ""
+	}
+	if c.relevantContext == nil {
+		c.relevantContext = make(map[core.Phase]string)
+	}
+	c.relevantContext[phase] = files
+
+	if files != "" && c.Report != nil {
+		if err := c.Report.WriteRelevantContext(string(phase), files); err != nil {
+			c.Logger.Warn("failed to write relevant context report", "phase", phase, "error", err)
+		}
+	}
+	return files
+}
+
+// planIndexQuery is the index query of the plan phase: the request and the
+// consolidated analysis, which names the code the analysts looked at.
+func planIndexQuery(state *core.WorkflowState, analysis string) string {
+	return GetEffectivePrompt(state) + "\n\n" + analysis
+}
@@ -0,0 +1,77 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/report"
+)
+
+type fakeContextIndexer struct {
+	files   string
+	err     error
+	queries []string
+}
+
+func (f *fakeContextIndexer) RelevantContext(_ context.Context, query string) (string, error) {
+	f.queries = append(f.queries, query)
+	return f.files, f.err
+}
+
+func TestContext_RelevantContext(t *testing.T) {
+	t.Parallel()
+
+	index := &fakeContextIndexer{files: "- `internal/billing/invoice.go`\n"}
+	wctx := &Context{
+		ContextIndex: index,
+		Logger:       logging.NewNop(),
+		Report:       report.NewWorkflowReportWriter(report.Config{BaseDir: t.TempDir(), Enabled: true}, "wf-index"),
+	}
+
+	for i := 0; i < 2; i++ {
+		if got := wctx.RelevantContext(context.Background(), core.PhaseAnalyze, "fix invoices"); got != index.files {
+			t.Errorf("RelevantContext() = %q, want %q", got, index.files)
+		}
+	}
+	wctx.RelevantContext(context.Background(), core.PhasePlan, "fix invoices and refunds")
+	if len(index.queries) != 2 {
+		t.Errorf("index queried %d times, want once per phase", len(index.queries))
+	}
+	for _, dir := range []string{wctx.Report.AnalyzePhasePath(), wctx.Report.PlanPhasePath()} {
+		if _, err := os.Stat(filepath.Join(dir, "00-relevant-context.md")); err != nil {
+			t.Errorf("relevant context not recorded in the report: %v", err)
+		}
+	}
+}
+
+func TestContext_RelevantContext_IndexFailure(t *testing.T) {
+	t.Parallel()
+
+	wctx := &Context{
+		ContextIndex: &fakeContextIndexer{err: errors.New("database is locked")},
+		Logger:       logging.NewNop(),
+	}
+	if got := wctx.RelevantContext(context.Background(), core.PhaseAnalyze, "fix invoices"); got != "" {
+		t.Errorf("RelevantContext() = %q, want empty on index failure", got)
+	}
+	if got := (&Context{}).RelevantContext(context.Background(), core.PhaseAnalyze, "fix invoices"); got != "" {
+		t.Errorf("RelevantContext() without an index = %q, want empty", got)
+	}
+}
+
+func TestNewContextIndexer(t *testing.T) {
+	t.Parallel()
+
+	if ix := NewContextIndexer(t.TempDir(), config.IndexConfig{}); ix != nil {
+		t.Errorf("NewContextIndexer() = %v, want nil when disabled", ix)
+	}
+	if ix := NewContextIndexer(t.TempDir(), config.IndexConfig{Enabled: true, Path: ".quorum/index/index.db"}); ix == nil {
+		t.Error("NewContextIndexer() = nil, want an indexer when enabled")
+	}
+}
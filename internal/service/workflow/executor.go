@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -125,14 +126,15 @@ func (e *Executor) Run(ctx context.Context, wctx *Context) error {
 		wctx.Logger.Warn("failed to create phase checkpoint", "error", err)
 	}
 
-	completed := make(map[core.TaskID]bool)
+	// Skip and retry requests are served while the phase runs.
+	rc := newTaskRunControl()
+	watchCtx, stopWatching := context.WithCancel(ctx)
+	defer stopWatching()
+	go e.watchTaskControl(watchCtx, wctx, rc)
 
 	// Find already completed tasks
-	for id, task := range wctx.State.Tasks {
-		if task.Status == core.TaskStatusCompleted || task.Status == core.TaskStatusSkipped {
-			completed[id] = true
-		}
-	}
+	e.skipDependents(ctx, wctx)
+	completed := finishedTasks(wctx)
 
 	// Execute remaining tasks
 	for len(completed) < len(wctx.State.Tasks) {
@@ -146,6 +148,13 @@ func (e *Executor) Run(ctx context.Context, wctx *Context) error {
 			}
 		}
 
+		// Tasks may have been skipped while waiting.
+		e.skipDependents(ctx, wctx)
+		completed = finishedTasks(wctx)
+		if len(completed) == len(wctx.State.Tasks) {
+			break
+		}
+
 		ready := e.dag.GetReadyTasks(completed)
 		if len(ready) == 0 {
 			return core.ErrState(core.CodeExecutionStuck, "no ready tasks but not all completed")
@@ -173,27 +182,28 @@ func (e *Executor) Run(ctx context.Context, wctx *Context) error {
 
 		var wg sync.WaitGroup
 		var mu sync.Mutex
-		var firstErr error
-		var failedTasks []core.TaskID
+		var taskErrs []error
+		var erroredTasks []core.TaskID
 
 		for _, task := range ready {
 			task := task // Capture for closure
+			// A skip request cancels the task from the moment it is scheduled.
+			runCtx, stopRun := rc.start(ctx, task.ID)
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
+				defer stopRun()
 
 				// Each task gets its own context with timeout
 				// Parent ctx cancellation still propagates (workflow-level cancel)
-				taskCtx, taskCancel := context.WithTimeout(ctx, wctx.Config.PhaseTimeouts.Execute)
+				taskCtx, taskCancel := context.WithTimeout(runCtx, wctx.Config.PhaseTimeouts.Execute)
 				defer taskCancel()
 
 				err := e.executeTaskSafe(taskCtx, wctx, task, useWorktrees)
-				if err != nil {
+				if err != nil && !e.skippedOnRequest(runCtx, wctx, task.ID) {
 					mu.Lock()
-					if firstErr == nil {
-						firstErr = err
-					}
-					failedTasks = append(failedTasks, task.ID)
+					taskErrs = append(taskErrs, err)
+					erroredTasks = append(erroredTasks, task.ID)
 					mu.Unlock()
 				}
 			}()
@@ -201,6 +211,24 @@ func (e *Executor) Run(ctx context.Context, wctx *Context) error {
 
 		wg.Wait()
 
+		// Failed tasks with a retry request run again; tasks skipped after
+		// they failed no longer fail the phase.
+		retried := e.applyRetries(ctx, wctx, rc, ready)
+		var firstErr error
+		var failedTasks []core.TaskID
+		for i, id := range erroredTasks {
+			wctx.RLock()
+			skipped := wctx.State.Tasks[id].Status == core.TaskStatusSkipped
+			wctx.RUnlock()
+			if retried[id] || skipped {
+				continue
+			}
+			if firstErr == nil {
+				firstErr = taskErrs[i]
+			}
+			failedTasks = append(failedTasks, id)
+		}
+
 		if firstErr != nil {
 			wctx.Logger.Error("batch execution had failures",
 				"failed_count", len(failedTasks),
@@ -211,11 +239,8 @@ func (e *Executor) Run(ctx context.Context, wctx *Context) error {
 		}
 
 		// Update completed set
-		for _, task := range ready {
-			if wctx.State.Tasks[task.ID].Status == core.TaskStatusCompleted || wctx.State.Tasks[task.ID].Status == core.TaskStatusSkipped {
-				completed[task.ID] = true
-			}
-		}
+		e.skipDependents(ctx, wctx)
+		completed = finishedTasks(wctx)
 		// Note: Per-task state save now happens in executeTask() immediately after each task completes
 		// This eliminates the need for batch-level saves and enables finer-grained recovery
 	}
@@ -258,6 +283,10 @@ func (e *Executor) executeTask(ctx context.Context, wctx *Context, task *core.Ta
 
 	var taskErr error
 	defer func() {
+		// A task skipped on request was reported when it was skipped.
+		if e.skippedOnRequest(ctx, wctx, task.ID) {
+			return
+		}
 		e.notifyTaskCompletion(wctx, task, taskState, startTime, taskErr)
 	}()
 
@@ -269,10 +298,28 @@ func (e *Executor) executeTask(ctx context.Context, wctx *Context, task *core.Ta
 		return e.completeDryRun(wctx, taskState)
 	}
 
+	// Tasks of multi-repository workflows may target another repository.
+	repo, err := wctx.TaskRepository(ctx, task)
+	if err != nil {
+		e.setTaskFailed(wctx, taskState, err)
+		taskErr = err
+		return err
+	}
+
 	// Setup worktree (workflow-scoped if isolation enabled, otherwise legacy)
 	workDir, worktreeCreated := e.setupWorkflowScopedWorktree(ctx, wctx, task, taskState, useWorktrees)
 	defer e.cleanupWorkflowScopedWorktree(ctx, wctx, task, worktreeCreated)
 
+	// Work in progress is checkpointed while the task runs in its worktree, and
+	// a task interrupted before continues from its last checkpoint.
+	var resume *TaskResumeParams
+	stopCheckpoints := func() {}
+	if worktreeCreated {
+		resume = e.resumeFromCheckpoint(ctx, wctx, task, taskState, workDir)
+		stopCheckpoints = e.startTaskCheckpoints(ctx, wctx, task, taskState, workDir)
+	}
+	defer stopCheckpoints()
+
 	fail := func(err error) error {
 		e.setTaskFailed(wctx, taskState, err)
 		taskErr = err
@@ -295,10 +342,12 @@ func (e *Executor) executeTask(ctx context.Context, wctx *Context, task *core.Ta
 	}
 
 	// Build context, including any workflow attachments with paths reachable from the execution directory.
-	// Priority: worktree workDir > project root > current working directory
+	// Priority: worktree workDir > target repository > project root > current working directory
 	displayWorkDir := workDir
 	if strings.TrimSpace(displayWorkDir) == "" {
-		if wctx.ProjectRoot != "" {
+		if repo != nil {
+			displayWorkDir = repo.Repository.Path
+		} else if wctx.ProjectRoot != "" {
 			displayWorkDir = wctx.ProjectRoot
 		} else if wd, err := os.Getwd(); err == nil {
 			displayWorkDir = wd
@@ -312,12 +361,14 @@ func (e *Executor) executeTask(ctx context.Context, wctx *Context, task *core.Ta
 		execContext = execContext + "\n\n" + attCtx
 	}
 
-	prompt, err := wctx.Prompts.RenderTaskExecute(TaskExecuteParams{
+	promptParams := TaskExecuteParams{
 		Task:        task,
 		Context:     execContext,
 		WorkDir:     displayWorkDir,
 		Constraints: nil,
-	})
+		Resume:      resume,
+	}
+	prompt, err := wctx.Prompts.RenderTaskExecute(promptParams)
 	if err != nil {
 		return fail(err)
 	}
@@ -373,18 +424,31 @@ func (e *Executor) executeTask(ctx context.Context, wctx *Context, task *core.Ta
 		e.logTaskExecutionStart(wctx, task, agentName, model, workDir, prompt)
 		e.notifyAgentStarted(wctx, agentName, task, model, workDir)
 
-		result, retryCount, durationMS, execErr := e.executeWithRetry(ctx, wctx, agent, agentName, task, prompt, model, workDir, execStartTime)
+		result, retryCount, durationMS, execErr := e.executeWithAnswers(ctx, wctx, agent, agentName, task, promptParams, prompt, model, workDir, execStartTime)
 
 		wctx.Lock()
 		taskState.Retries = retryCount
 		wctx.Unlock()
 
 		if execErr != nil {
+			e.recordPartialOutput(wctx, taskState, result)
+
 			// Cancellation should not trigger retries/fallbacks. Propagate immediately.
 			if isWorkflowCancelled(execErr) {
 				return fail(execErr)
 			}
 
+			// An unanswered question ends the task: another agent would
+			// need the same answer.
+			if errors.Is(execErr, errTaskInputSkipped) {
+				e.skipTask(ctx, wctx, task, taskState, execErr)
+				return nil
+			}
+			if errors.Is(execErr, errTaskInputUnanswered) {
+				taskErr = execErr
+				return e.handleExecutionFailure(wctx, task, taskState, agentName, model, retryCount, durationMS, execErr)
+			}
+
 			lastErr = execErr
 			lastAgentName = agentName
 			lastModel = model
@@ -438,6 +502,7 @@ func (e *Executor) executeTask(ctx context.Context, wctx *Context, task *core.Ta
 
 			lastErr = validationErr
 			lastAgentName = agentName
+			e.recordPartialOutput(wctx, taskState, result)
 
 			// If there are more agents to try, continue with fallback
 			if agentIdx < len(agentsToTry)-1 {
@@ -460,7 +525,9 @@ func (e *Executor) executeTask(ctx context.Context, wctx *Context, task *core.Ta
 			return validationErr
 		}
 
-		// Success! Handle the successful execution (validation already passed)
+		// Success! Handle the successful execution (validation already passed).
+		// The last checkpoint is taken before finalization drops them all.
+		stopCheckpoints()
 		if err := e.handleExecutionSuccessValidated(ctx, wctx, task, taskState, agentName, result, workDir, durationMS, validation); err != nil {
 			taskErr = err
 			return err
@@ -543,6 +610,10 @@ func (e *Executor) notifyTaskCompletion(wctx *Context, task *core.Task, taskStat
 			taskErr = fmt.Errorf("task failed")
 		}
 		wctx.Output.TaskFailed(task, taskErr)
+		return
+	}
+	if taskState.Status == core.TaskStatusSkipped {
+		wctx.Output.TaskSkipped(task, taskState.Error)
 	}
 }
 
@@ -620,6 +691,13 @@ func (e *Executor) executeWithRetry(ctx context.Context, wctx *Context, agent co
 				"error":       retryErr.Error(),
 				"duration_ms": time.Since(execStartTime).Milliseconds(),
 			})
+			// Emit a structured retry event if the output supports it (web and JSON notifiers do)
+			type taskRetryNotifier interface {
+				TaskRetry(task *core.Task, attempt, maxAttempts int, err error)
+			}
+			if rn, ok := wctx.Output.(taskRetryNotifier); ok {
+				rn.TaskRetry(task, attempt, wctx.Config.MaxRetries, retryErr)
+			}
 		}
 		retryCount = attempt
 	})
@@ -736,6 +814,7 @@ func (e *Executor) handleExecutionSuccessValidated(ctx context.Context, wctx *Co
 		e.setTaskFailed(wctx, taskState, fmt.Errorf("finalization failed: %w", finalizeErr))
 		return finalizeErr
 	}
+	e.dropTaskCheckpoints(ctx, wctx, task, taskState, workDir)
 
 	// Merge task to workflow branch if using workflow isolation
 	// This happens after finalization so that the task's commits are merged
@@ -905,11 +984,26 @@ func (e *Executor) setupWorkflowScopedWorktree(ctx context.Context, wctx *Contex
 		return "", false
 	}
 
+	repo, err := wctx.TaskRepository(ctx, task)
+	if err != nil {
+		wctx.Logger.Warn("failed to open task repository, executing without worktree",
+			"task_id", task.ID,
+			"repository", task.Repository,
+			"error", err,
+		)
+		return "", false
+	}
+
 	// Check if we should use workflow isolation
-	if wctx.UseWorkflowIsolation() {
+	if wctx.useWorkflowIsolationFor(task, repo) {
 		return e.setupWorktreeWithIsolation(ctx, wctx, task, taskState)
 	}
 
+	// Legacy worktrees only exist in the workflow's own repository
+	if repo != nil {
+		return "", false
+	}
+
 	// Fall back to legacy worktree behavior
 	return e.setupWorktree(ctx, wctx, task, taskState, true)
 }
@@ -921,7 +1015,17 @@ func (e *Executor) setupWorktreeWithIsolation(ctx context.Context, wctx *Context
 	workflowID := string(wctx.State.WorkflowID)
 	wctx.RUnlock()
 
-	wtInfo, err := wctx.WorkflowWorktrees.CreateTaskWorktree(ctx, workflowID, task)
+	repo, err := wctx.TaskRepository(ctx, task)
+	if err != nil {
+		wctx.Logger.Warn("failed to open task repository, falling back to non-isolated execution",
+			"workflow_id", workflowID,
+			"task_id", task.ID,
+			"error", err,
+		)
+		return "", false
+	}
+
+	wtInfo, err := wctx.workflowWorktreesFor(repo).CreateTaskWorktree(ctx, workflowID, task)
 	if err != nil {
 		wctx.Logger.Warn("failed to create workflow-scoped worktree, falling back to non-isolated execution",
 			"workflow_id", workflowID,
@@ -955,13 +1059,22 @@ func (e *Executor) cleanupWorkflowScopedWorktree(ctx context.Context, wctx *Cont
 		return
 	}
 
-	if wctx.UseWorkflowIsolation() {
+	repo, err := wctx.TaskRepository(ctx, task)
+	if err != nil {
+		wctx.Logger.Warn("failed to open task repository, leaving worktree in place",
+			"task_id", task.ID,
+			"error", err,
+		)
+		return
+	}
+
+	if wctx.useWorkflowIsolationFor(task, repo) {
 		wctx.RLock()
 		workflowID := string(wctx.State.WorkflowID)
 		wctx.RUnlock()
 
 		// Don't remove the branch - it will be merged later
-		if err := wctx.WorkflowWorktrees.RemoveTaskWorktree(ctx, workflowID, task.ID, false); err != nil {
+		if err := wctx.workflowWorktreesFor(repo).RemoveTaskWorktree(ctx, workflowID, task.ID, false); err != nil {
 			wctx.Logger.Warn("failed to remove workflow-scoped worktree",
 				"workflow_id", workflowID,
 				"task_id", task.ID,
@@ -983,7 +1096,11 @@ func (e *Executor) cleanupWorkflowScopedWorktree(ctx context.Context, wctx *Cont
 // mergeTaskToWorkflow merges the task branch to the workflow branch after completion.
 // This integrates task changes into the workflow branch for subsequent tasks.
 func (e *Executor) mergeTaskToWorkflow(ctx context.Context, wctx *Context, task *core.Task) error {
-	if !wctx.UseWorkflowIsolation() {
+	repo, err := wctx.TaskRepository(ctx, task)
+	if err != nil {
+		return err
+	}
+	if !wctx.useWorkflowIsolationFor(task, repo) {
 		return nil // No merge needed without isolation
 	}
 
@@ -1002,7 +1119,7 @@ func (e *Executor) mergeTaskToWorkflow(ctx context.Context, wctx *Context, task
 		"strategy", strategy,
 	)
 
-	if err := wctx.WorkflowWorktrees.MergeTaskToWorkflow(ctx, workflowID, task.ID, strategy); err != nil {
+	if err := wctx.workflowWorktreesFor(repo).MergeTaskToWorkflow(ctx, workflowID, task.ID, strategy); err != nil {
 		// Update task state with merge failure info
 		// Note: The actual status change to Failed is done by the caller (setTaskFailed)
 		// Here we only set the recovery metadata (Resumable, MergePending)
@@ -1125,14 +1242,24 @@ func (e *Executor) finalizeTask(ctx context.Context, wctx *Context, task *core.T
 		return nil
 	}
 
+	// Tasks that target another repository commit and open PRs there.
+	repoGit, ghClient := wctx.Git, wctx.GitHub
+	repo, err := wctx.TaskRepository(ctx, task)
+	if err != nil {
+		return err
+	}
+	if repo != nil {
+		repoGit, ghClient = repo.Git, repo.GitHub
+	}
+
 	// Determine the git repo path and branch
 	gitPath := workDir
 	if gitPath == "" {
 		// No worktree, use main repo
-		if wctx.Git == nil {
+		if repoGit == nil {
 			return nil
 		}
-		gitPath, _ = wctx.Git.RepoRoot(ctx)
+		gitPath, _ = repoGit.RepoRoot(ctx)
 	}
 	if gitPath == "" {
 		return nil
@@ -1143,13 +1270,12 @@ func (e *Executor) finalizeTask(ctx context.Context, wctx *Context, task *core.T
 	// Create a git client for the specific path
 	var gitClient core.GitClient
 	if e.gitFactory != nil {
-		var err error
 		gitClient, err = e.gitFactory.NewClient(gitPath)
 		if err != nil {
 			return fmt.Errorf("creating git client for worktree: %w", err)
 		}
-	} else if wctx.Git != nil {
-		gitClient = wctx.Git
+	} else if repoGit != nil {
+		gitClient = repoGit
 	} else {
 		return nil
 	}
@@ -1160,8 +1286,8 @@ func (e *Executor) finalizeTask(ctx context.Context, wctx *Context, task *core.T
 			branch = strings.TrimSpace(b)
 		}
 	}
-	if branch == "" && wctx.Git != nil {
-		if b, err := wctx.Git.CurrentBranch(ctx); err == nil {
+	if branch == "" && repoGit != nil {
+		if b, err := repoGit.CurrentBranch(ctx); err == nil {
 			branch = strings.TrimSpace(b)
 		}
 	}
@@ -1182,7 +1308,7 @@ func (e *Executor) finalizeTask(ctx context.Context, wctx *Context, task *core.T
 	}
 
 	// Create and run finalizer
-	finalizer := NewTaskFinalizer(gitClient, wctx.GitHub, cfg)
+	finalizer := NewTaskFinalizer(gitClient, ghClient, cfg)
 	result, err := finalizer.Finalize(ctx, task, gitPath, branch)
 	if err != nil {
 		return err
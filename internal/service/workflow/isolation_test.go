@@ -76,6 +76,32 @@ func TestBuildWorkflowPRBody(t *testing.T) {
 			},
 			contains: []string{"## Prompt", "wf-nil"},
 		},
+		{
+			name: "source issue",
+			state: &core.WorkflowState{
+				WorkflowDefinition: core.WorkflowDefinition{
+					WorkflowID: "wf-issue",
+					Prompt:     "Resolve issue #17",
+				},
+				WorkflowRun: core.WorkflowRun{
+					SourceIssue: &core.IssueLink{Number: 17},
+				},
+			},
+			contains: []string{"Fixes #17", "wf-issue"},
+		},
+		{
+			name: "source chat",
+			state: &core.WorkflowState{
+				WorkflowDefinition: core.WorkflowDefinition{
+					WorkflowID: "wf-chat",
+					Prompt:     "Add retries",
+				},
+				WorkflowRun: core.WorkflowRun{
+					SourceChat: &core.ChatLink{SessionID: "chat-1", Title: "Retries"},
+				},
+			},
+			contains: []string{`Promoted from chat session "Retries" (` + "`chat-1`" + `)`, "wf-chat"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -317,6 +343,21 @@ func TestPrepareExecution(t *testing.T) {
 	}
 }
 
+func TestPrepareExecution_RecordsConfigVersion(t *testing.T) {
+	t.Parallel()
+
+	state := &core.WorkflowState{WorkflowRun: core.WorkflowRun{ConfigVersion: 3}}
+	(&Runner{config: &RunnerConfig{}}).prepareExecution(state, true)
+	if state.ConfigVersion != 3 {
+		t.Errorf("unknown version should keep ConfigVersion, got %d", state.ConfigVersion)
+	}
+
+	(&Runner{config: &RunnerConfig{ConfigVersion: 5}}).prepareExecution(state, true)
+	if state.ConfigVersion != 5 {
+		t.Errorf("ConfigVersion = %d, want 5", state.ConfigVersion)
+	}
+}
+
 func TestClearPlanPhaseData(t *testing.T) {
 	t.Parallel()
 
@@ -115,6 +115,8 @@ func parseTaskFile(filePath string) (*TaskManifestItem, error) {
 				item.Complexity = headerValue
 			case "Dependencies":
 				item.Dependencies = parseDependencies(headerValue)
+			case "Repository":
+				item.Repository = normalizeTaskRepository(headerValue)
 			}
 		}
 
@@ -58,6 +58,27 @@ func TestParseTaskFile_WithDependencies(t *testing.T) {
 	assert.Equal(t, []string{"task-3", "task-4"}, item.Dependencies)
 }
 
+func TestParseTaskFile_WithRepository(t *testing.T) {
+	t.Parallel()
+	content := `# Task: Call the new endpoint
+
+**Task ID**: task-2
+**Assigned Agent**: claude
+**Complexity**: low
+**Dependencies**: task-1
+**Repository**: web-client
+
+---
+`
+	tmpFile := createTempTaskFile(t, "task-2-call-the-new-endpoint.md", content)
+
+	item, err := parseTaskFile(tmpFile)
+	require.NoError(t, err)
+
+	assert.Equal(t, "web-client", item.Repository)
+	assert.Equal(t, []string{"task-1"}, item.Dependencies)
+}
+
 func TestParseTaskFile_MissingTaskID(t *testing.T) {
 	t.Parallel()
 	content := `# Task: No ID Task
@@ -484,6 +484,13 @@ type moderatorFrontmatter struct {
 	AgreementsCount         int         `yaml:"agreements_count"`
 }
 
+// ParseModeratorOutput extracts the consensus score and the agreement,
+// divergence and recommendation sections from a moderator response. It is
+// also used to moderate questions asked to several agents from the chat.
+func ParseModeratorOutput(output string) *ModeratorEvaluationResult {
+	return (&SemanticModerator{}).parseModeratorResponse(output)
+}
+
 // parseModeratorResponse parses the moderator's response to extract the consensus score and details.
 func (m *SemanticModerator) parseModeratorResponse(output string) *ModeratorEvaluationResult {
 	result := &ModeratorEvaluationResult{
@@ -68,12 +68,15 @@ func (p *Planner) RebuildDAGFromState(state *core.WorkflowState) error {
 			CLI:          taskState.CLI,
 			Model:        taskState.Model,
 			Dependencies: taskState.Dependencies,
+			Repository:   taskState.Repository,
 			TokensIn:     taskState.TokensIn,
 			TokensOut:    taskState.TokensOut,
 			Retries:      taskState.Retries,
 			StartedAt:    taskState.StartedAt,
 			CompletedAt:  taskState.CompletedAt,
 			Error:        taskState.Error,
+
+			RetryInstructions: taskState.RetryInstructions,
 		}
 		if err := p.dag.AddTask(task); err != nil {
 			return fmt.Errorf("adding task %s to DAG: %w", task.ID, err)
@@ -152,6 +155,7 @@ func (p *Planner) runSingleAgentPlanning(ctx context.Context, wctx *Context) err
 		Prompt:               GetEffectivePrompt(wctx.State),
 		ConsolidatedAnalysis: analysis,
 		MaxTasks:             10,
+		RelevantContext:      wctx.RelevantContext(ctx, core.PhasePlan, planIndexQuery(wctx.State, analysis)),
 	})
 	if err != nil {
 		return fmt.Errorf("rendering plan prompt: %w", err)
@@ -249,6 +253,7 @@ func (p *Planner) runSingleAgentPlanning(ctx context.Context, wctx *Context) err
 			CLI:          task.CLI,
 			Model:        task.Model,
 			Dependencies: task.Dependencies,
+			Repository:   task.Repository,
 		}
 		wctx.State.TaskOrder = append(wctx.State.TaskOrder, task.ID)
 		_ = p.dag.AddTask(task)
@@ -307,6 +312,7 @@ type TaskPlanItem struct {
 	CLI          string   `json:"cli"`
 	Agent        string   `json:"agent"`
 	Dependencies []string `json:"dependencies"`
+	Repository   string   `json:"repository,omitempty"`
 }
 
 // parsePlan parses the plan output into tasks.
@@ -333,6 +339,7 @@ func (p *Planner) parsePlan(ctx context.Context, wctx *Context, output string) (
 			Phase:       core.PhaseExecute,
 			Status:      core.TaskStatusPending,
 			CLI:         cli,
+			Repository:  normalizeTaskRepository(item.Repository),
 		}
 
 		for _, dep := range item.Dependencies {
@@ -342,6 +349,9 @@ func (p *Planner) parsePlan(ctx context.Context, wctx *Context, output string) (
 		tasks = append(tasks, task)
 	}
 
+	if err := validateTaskRepositories(wctx.State, tasks); err != nil {
+		return nil, err
+	}
 	return tasks, nil
 }
 
@@ -28,6 +28,9 @@ type TaskManifestItem struct {
 	Dependencies []string `json:"dependencies"`
 	Complexity   string   `json:"complexity"`
 	CLI          string   `json:"cli"`
+	// Repository is the declared repository the task changes; empty means
+	// the workflow's own project.
+	Repository string `json:"repository,omitempty"`
 }
 
 // runCLIGeneratedTaskPlanning executes the comprehensive single-call planning flow.
@@ -93,6 +96,8 @@ func (p *Planner) runCLIGeneratedTaskPlanning(ctx context.Context, wctx *Context
 		AvailableAgents:      availableAgents,
 		TasksDir:             tasksDir,
 		NamingConvention:     "{id}-{name}.md",
+		Repositories:         wctx.State.Repositories,
+		RelevantContext:      wctx.RelevantContext(ctx, core.PhasePlan, planIndexQuery(wctx.State, analysis)),
 	})
 	if err != nil {
 		return fmt.Errorf("comprehensive planning: %w", err)
@@ -137,6 +142,9 @@ func (p *Planner) runCLIGeneratedTaskPlanning(ctx context.Context, wctx *Context
 
 	// Create tasks from manifest
 	tasks := p.createTasksFromManifest(ctx, wctx, manifest)
+	if err := validateTaskRepositories(wctx.State, tasks); err != nil {
+		return err
+	}
 
 	// Add tasks to state and DAG
 	for _, task := range tasks {
@@ -148,6 +156,7 @@ func (p *Planner) runCLIGeneratedTaskPlanning(ctx context.Context, wctx *Context
 			CLI:          task.CLI,
 			Model:        task.Model,
 			Dependencies: task.Dependencies,
+			Repository:   task.Repository,
 		}
 		wctx.State.TaskOrder = append(wctx.State.TaskOrder, task.ID)
 		_ = p.dag.AddTask(task)
@@ -441,6 +450,7 @@ func (p *Planner) createTasksFromManifest(ctx context.Context, wctx *Context, ma
 			Phase:       core.PhaseExecute,
 			Status:      core.TaskStatusPending,
 			CLI:         cli,
+			Repository:  normalizeTaskRepository(item.Repository),
 		}
 
 		for _, dep := range item.Dependencies {
@@ -83,6 +83,7 @@ func (p *Planner) runMultiAgentPlanning(ctx context.Context, wctx *Context) erro
 			CLI:          task.CLI,
 			Model:        task.Model,
 			Dependencies: task.Dependencies,
+			Repository:   task.Repository,
 		}
 		wctx.State.TaskOrder = append(wctx.State.TaskOrder, task.ID)
 		_ = p.dag.AddTask(task)
@@ -240,6 +241,7 @@ func (p *Planner) runPlanningWithAgent(ctx context.Context, wctx *Context, agent
 		Prompt:               GetEffectivePrompt(wctx.State),
 		ConsolidatedAnalysis: analysis,
 		MaxTasks:             10,
+		RelevantContext:      wctx.RelevantContext(ctx, core.PhasePlan, planIndexQuery(wctx.State, analysis)),
 	})
 	if err != nil {
 		return PlanOutput{}, fmt.Errorf("rendering prompt: %w", err)
@@ -0,0 +1,283 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+)
+
+// maxBabysitLogBytes bounds the failed-check log excerpt that is fed to the
+// fix agent and stored on the workflow state.
+const maxBabysitLogBytes = 16 * 1024
+
+// NewPRBabysitConfig converts the file configuration into the runtime config.
+// Durations were validated on load; unparsable values fall back to defaults.
+func NewPRBabysitConfig(cfg config.PRBabysitConfig) PRBabysitConfig {
+	checksTimeout, _ := time.ParseDuration(cfg.ChecksTimeout)
+	pollInterval, _ := time.ParseDuration(cfg.PollInterval)
+	return PRBabysitConfig{
+		Enabled:        cfg.Enabled,
+		MaxAttempts:    cfg.MaxAttempts,
+		Agent:          cfg.Agent,
+		ChecksTimeout:  checksTimeout,
+		PollInterval:   pollInterval,
+		RequiredChecks: cfg.RequiredChecks,
+	}
+}
+
+// PRBabysitter waits for the checks of a workflow PR and, while they fail,
+// runs fix tasks on the workflow branch and pushes the result. Every
+// iteration is recorded in WorkflowState.PRBabysit.
+type PRBabysitter struct {
+	Config            PRBabysitConfig
+	Checks            core.PRChecksClient
+	Agents            core.AgentRegistry
+	Prompts           PromptRenderer
+	WorkflowWorktrees core.WorkflowWorktreeManager
+	GitFactory        GitClientFactory
+	Git               core.GitClient
+	Remote            string
+	MergeStrategy     string
+	Agent             string
+	Model             string
+	Timeout           time.Duration
+	DenyTools         []string
+	Logger            *logging.Logger
+	Output            OutputNotifier
+	// Save persists the workflow state after each iteration (optional).
+	Save func(ctx context.Context, state *core.WorkflowState) error
+}
+
+// Run babysits the PR until its checks pass, the fix budget is exhausted or
+// the loop cannot continue. It returns the final babysit state.
+func (b *PRBabysitter) Run(ctx context.Context, state *core.WorkflowState, prNumber int) *core.PRBabysitState {
+	bs := &core.PRBabysitState{PRNumber: prNumber, Status: core.PRBabysitRunning, UpdatedAt: time.Now()}
+	state.PRBabysit = bs
+	b.save(ctx, state)
+
+	for attempt := 1; ; attempt++ {
+		b.log("info", fmt.Sprintf("Waiting for checks on PR #%d", prNumber))
+		status, err := b.Checks.WaitForPRChecks(ctx, prNumber, core.PRChecksOptions{
+			PollInterval:   b.Config.PollInterval,
+			Timeout:        b.Config.ChecksTimeout,
+			RequiredChecks: b.Config.RequiredChecks,
+		})
+		if err != nil {
+			return b.finish(ctx, state, core.PRBabysitError,
+				fmt.Sprintf("Could not get checks for PR #%d: %v", prNumber, err))
+		}
+
+		failed := status.FailedChecks()
+		if len(failed) == 0 {
+			return b.finish(ctx, state, core.PRBabysitPassed,
+				fmt.Sprintf("All %d checks passed on PR #%d after %d fix attempt(s)", status.TotalCount, prNumber, len(bs.Attempts)))
+		}
+
+		names := make([]string, 0, len(failed))
+		for _, c := range failed {
+			names = append(names, c.Name)
+		}
+		if attempt > b.Config.MaxAttempts {
+			return b.finish(ctx, state, core.PRBabysitExhausted,
+				fmt.Sprintf("Checks still failing on PR #%d after %d fix attempt(s): %s",
+					prNumber, len(bs.Attempts), strings.Join(names, ", ")))
+		}
+
+		b.log("warn", fmt.Sprintf("Checks failed on PR #%d (%s), starting fix attempt %d/%d",
+			prNumber, strings.Join(names, ", "), attempt, b.Config.MaxAttempts))
+		rec := core.PRBabysitAttempt{
+			Attempt:      attempt,
+			FailedChecks: names,
+			Agent:        b.Agent,
+			LogExcerpt:   b.collectLogs(ctx, failed),
+			StartedAt:    time.Now(),
+		}
+		sha, fixErr := b.fix(ctx, state, &rec)
+		completedAt := time.Now()
+		rec.CompletedAt = &completedAt
+		rec.CommitSHA = sha
+		if fixErr != nil {
+			rec.Error = fixErr.Error()
+		}
+		bs.Attempts = append(bs.Attempts, rec)
+		bs.UpdatedAt = completedAt
+		b.save(ctx, state)
+
+		if fixErr != nil {
+			return b.finish(ctx, state, core.PRBabysitError,
+				fmt.Sprintf("Fix attempt %d for PR #%d failed: %v", attempt, prNumber, fixErr))
+		}
+		b.log("info", fmt.Sprintf("Pushed fix %s for PR #%d", shortSHA(sha), prNumber))
+
+		// Give CI time to register the checks of the pushed commit before polling again.
+		select {
+		case <-ctx.Done():
+			return b.finish(ctx, state, core.PRBabysitError, ctx.Err().Error())
+		case <-time.After(b.Config.PollInterval):
+		}
+	}
+}
+
+// collectLogs concatenates the failed-step logs of the failing checks,
+// bounded by maxBabysitLogBytes.
+func (b *PRBabysitter) collectLogs(ctx context.Context, failed []core.Check) string {
+	var sb strings.Builder
+	budget := maxBabysitLogBytes / len(failed)
+	for _, check := range failed {
+		fmt.Fprintf(&sb, "### %s (%s)\n", check.Name, check.Conclusion)
+		log, err := b.Checks.GetFailedCheckLog(ctx, check, budget)
+		if err != nil {
+			b.logWarn("pr babysit: fetching failed check log", "check", check.Name, "error", err)
+			fmt.Fprintf(&sb, "Log unavailable: %v\nDetails: %s\n\n", err, check.HTMLURL)
+			continue
+		}
+		sb.WriteString("```\n")
+		sb.WriteString(strings.TrimRight(log, "\n"))
+		sb.WriteString("\n```\n\n")
+	}
+	return sb.String()
+}
+
+// fix runs one fix task in a task worktree branched from the workflow branch,
+// merges it back and pushes the workflow branch. It returns the fix commit.
+func (b *PRBabysitter) fix(ctx context.Context, state *core.WorkflowState, rec *core.PRBabysitAttempt) (string, error) {
+	workflowID := string(state.WorkflowID)
+	task := &core.Task{
+		ID:    core.TaskID(fmt.Sprintf("ci-fix-%d", rec.Attempt)),
+		Phase: core.PhaseExecute,
+		Name:  fmt.Sprintf("Fix failing CI checks (attempt %d)", rec.Attempt),
+		Description: fmt.Sprintf("The pull request for this workflow has failing CI checks: %s. "+
+			"Use the failed log excerpts in the context to find the cause and make the smallest change "+
+			"that makes these checks pass. Do not disable, skip or weaken the checks.",
+			strings.Join(rec.FailedChecks, ", ")),
+		CLI:    b.Agent,
+		Status: core.TaskStatusPending,
+	}
+
+	agent, err := b.Agents.Get(b.Agent)
+	if err != nil {
+		return "", fmt.Errorf("getting fix agent %s: %w", b.Agent, err)
+	}
+
+	wt, err := b.WorkflowWorktrees.CreateTaskWorktree(ctx, workflowID, task)
+	if err != nil {
+		return "", fmt.Errorf("creating fix worktree: %w", err)
+	}
+	defer func() {
+		if rmErr := b.WorkflowWorktrees.RemoveTaskWorktree(context.WithoutCancel(ctx), workflowID, task.ID, true); rmErr != nil {
+			b.logWarn("pr babysit: removing fix worktree", "task_id", task.ID, "error", rmErr)
+		}
+	}()
+
+	prompt, err := b.Prompts.RenderTaskExecute(TaskExecuteParams{
+		Task:    task,
+		Context: "## Failed check logs\n\n" + rec.LogExcerpt,
+		WorkDir: wt.Path,
+	})
+	if err != nil {
+		return "", fmt.Errorf("rendering fix prompt: %w", err)
+	}
+
+	if b.Output != nil {
+		b.Output.TaskStarted(task)
+	}
+	if _, err := agent.Execute(ctx, core.ExecuteOptions{
+		Prompt:      prompt,
+		Format:      core.OutputFormatText,
+		Model:       b.Model,
+		Timeout:     b.Timeout,
+		DeniedTools: b.DenyTools,
+		WorkDir:     wt.Path,
+		Phase:       core.PhaseExecute,
+	}); err != nil {
+		b.notifyTaskFailed(task, err)
+		return "", fmt.Errorf("running fix agent: %w", err)
+	}
+
+	gitClient, err := b.GitFactory.NewClient(wt.Path)
+	if err != nil {
+		return "", fmt.Errorf("creating git client for fix worktree: %w", err)
+	}
+	result, err := NewTaskFinalizer(gitClient, nil, FinalizationConfig{AutoCommit: true}).Finalize(ctx, task, wt.Path, wt.Branch)
+	if err != nil {
+		return "", fmt.Errorf("committing fix: %w", err)
+	}
+	if result.CommitSHA == "" {
+		err := errors.New("fix agent made no changes")
+		b.notifyTaskFailed(task, err)
+		return "", err
+	}
+
+	strategy := b.MergeStrategy
+	if strategy == "" {
+		strategy = "sequential"
+	}
+	if err := b.WorkflowWorktrees.MergeTaskToWorkflow(ctx, workflowID, task.ID, strategy); err != nil {
+		return result.CommitSHA, fmt.Errorf("merging fix into workflow branch: %w", err)
+	}
+	if err := b.Git.Push(ctx, b.Remote, state.WorkflowBranch); err != nil {
+		return result.CommitSHA, fmt.Errorf("pushing workflow branch: %w", err)
+	}
+	if b.Output != nil {
+		b.Output.TaskCompleted(task, time.Since(rec.StartedAt))
+	}
+	return result.CommitSHA, nil
+}
+
+func (b *PRBabysitter) finish(ctx context.Context, state *core.WorkflowState, status, summary string) *core.PRBabysitState {
+	bs := state.PRBabysit
+	bs.Status = status
+	bs.Summary = summary
+	bs.UpdatedAt = time.Now()
+	b.save(context.WithoutCancel(ctx), state)
+
+	level := "info"
+	if status != core.PRBabysitPassed {
+		level = "warn"
+	}
+	b.log(level, summary)
+	return bs
+}
+
+func (b *PRBabysitter) save(ctx context.Context, state *core.WorkflowState) {
+	if b.Save == nil {
+		return
+	}
+	if err := b.Save(ctx, state); err != nil {
+		b.logWarn("pr babysit: saving workflow state", "error", err)
+	}
+}
+
+func (b *PRBabysitter) notifyTaskFailed(task *core.Task, err error) {
+	if b.Output != nil {
+		b.Output.TaskFailed(task, err)
+	}
+}
+
+func (b *PRBabysitter) log(level, msg string) {
+	if b.Output != nil {
+		b.Output.Log(level, "babysit", msg)
+	}
+	if b.Logger != nil {
+		b.Logger.Info("pr babysit", "message", msg)
+	}
+}
+
+func (b *PRBabysitter) logWarn(msg string, args ...any) {
+	if b.Logger != nil {
+		b.Logger.Warn(msg, args...)
+	}
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
@@ -0,0 +1,174 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// fakePRChecks returns one CheckStatus per WaitForPRChecks call.
+type fakePRChecks struct {
+	statuses []*core.CheckStatus
+	waits    int
+	logErr   error
+}
+
+func (f *fakePRChecks) WaitForPRChecks(_ context.Context, _ int, _ core.PRChecksOptions) (*core.CheckStatus, error) {
+	if f.waits >= len(f.statuses) {
+		return nil, errors.New("no more statuses")
+	}
+	st := f.statuses[f.waits]
+	f.waits++
+	return st, nil
+}
+
+func (f *fakePRChecks) GetFailedCheckLog(_ context.Context, check core.Check, _ int) (string, error) {
+	if f.logErr != nil {
+		return "", f.logErr
+	}
+	return "FAIL: " + check.Name + "\n", nil
+}
+
+// dirtyGitClient reports uncommitted changes so the fix gets committed.
+type dirtyGitClient struct {
+	mockGitClient
+}
+
+func (d *dirtyGitClient) IsClean(_ context.Context) (bool, error) { return false, nil }
+
+func checksFailing(names ...string) *core.CheckStatus {
+	st := &core.CheckStatus{State: "failure", TotalCount: len(names) + 1, Failed: len(names), Passed: 1}
+	st.Checks = append(st.Checks, core.Check{Name: "build", Status: "completed", Conclusion: "success"})
+	for _, n := range names {
+		st.Checks = append(st.Checks, core.Check{
+			Name: n, Status: "completed", Conclusion: "failure",
+			HTMLURL: "https://github.com/o/r/actions/runs/1/job/2",
+		})
+	}
+	return st
+}
+
+func checksPassing() *core.CheckStatus {
+	return &core.CheckStatus{State: "success", TotalCount: 1, Passed: 1, Checks: []core.Check{
+		{Name: "build", Status: "completed", Conclusion: "success"},
+	}}
+}
+
+func newTestBabysitter(checks *fakePRChecks, maxAttempts int) (*PRBabysitter, *mockWorkflowWorktreeManager, *mockGitClient) {
+	registry := &mockAgentRegistry{}
+	_ = registry.Register("claude", &mockAgent{result: &core.ExecuteResult{Output: "fixed"}})
+	wwm := &mockWorkflowWorktreeManager{createInfo: &core.WorktreeInfo{Path: "/tmp/wt", Branch: "quorum/wf-1/ci-fix-1"}}
+	mainGit := &mockGitClient{}
+	return &PRBabysitter{
+		Config:            PRBabysitConfig{Enabled: true, MaxAttempts: maxAttempts},
+		Checks:            checks,
+		Agents:            registry,
+		Prompts:           &mockPromptRenderer{},
+		WorkflowWorktrees: wwm,
+		GitFactory:        &mockGitClientFactory{client: &dirtyGitClient{mockGitClient{commitSHA: "abc123def456"}}},
+		Git:               mainGit,
+		Remote:            "origin",
+		Agent:             "claude",
+	}, wwm, mainGit
+}
+
+func newBabysitState() *core.WorkflowState {
+	return &core.WorkflowState{
+		WorkflowDefinition: core.WorkflowDefinition{WorkflowID: "wf-1"},
+		WorkflowRun:        core.WorkflowRun{WorkflowBranch: "quorum/wf-1"},
+	}
+}
+
+func TestPRBabysitter_FixesThenPasses(t *testing.T) {
+	t.Parallel()
+	checks := &fakePRChecks{statuses: []*core.CheckStatus{checksFailing("test"), checksPassing()}}
+	b, wwm, _ := newTestBabysitter(checks, 3)
+	saves := 0
+	b.Save = func(_ context.Context, _ *core.WorkflowState) error { saves++; return nil }
+
+	state := newBabysitState()
+	bs := b.Run(context.Background(), state, 42)
+
+	if bs.Status != core.PRBabysitPassed {
+		t.Fatalf("Status = %s, want passed (summary: %s)", bs.Status, bs.Summary)
+	}
+	if state.PRBabysit != bs {
+		t.Error("babysit state should be recorded on the workflow state")
+	}
+	if len(bs.Attempts) != 1 {
+		t.Fatalf("Attempts = %d, want 1", len(bs.Attempts))
+	}
+	att := bs.Attempts[0]
+	if att.CommitSHA != "abc123def456" || att.Agent != "claude" || att.Error != "" {
+		t.Errorf("unexpected attempt: %+v", att)
+	}
+	if len(att.FailedChecks) != 1 || att.FailedChecks[0] != "test" {
+		t.Errorf("FailedChecks = %v, want [test]", att.FailedChecks)
+	}
+	if !strings.Contains(att.LogExcerpt, "FAIL: test") {
+		t.Errorf("LogExcerpt = %q, want failed log", att.LogExcerpt)
+	}
+	if len(wwm.mergeCalls) != 1 || wwm.mergeCalls[0].taskID != "ci-fix-1" || wwm.mergeCalls[0].strategy != "sequential" {
+		t.Errorf("mergeCalls = %+v", wwm.mergeCalls)
+	}
+	if len(wwm.removeCalls) != 1 {
+		t.Errorf("fix worktree should be removed, removeCalls = %+v", wwm.removeCalls)
+	}
+	if saves < 3 {
+		t.Errorf("state saved %d times, want at least start, attempt and finish", saves)
+	}
+}
+
+func TestPRBabysitter_Exhausted(t *testing.T) {
+	t.Parallel()
+	checks := &fakePRChecks{statuses: []*core.CheckStatus{
+		checksFailing("test"), checksFailing("test"), checksFailing("test", "lint"),
+	}}
+	b, _, _ := newTestBabysitter(checks, 2)
+
+	bs := b.Run(context.Background(), newBabysitState(), 42)
+
+	if bs.Status != core.PRBabysitExhausted {
+		t.Fatalf("Status = %s, want exhausted", bs.Status)
+	}
+	if len(bs.Attempts) != 2 {
+		t.Errorf("Attempts = %d, want 2", len(bs.Attempts))
+	}
+	if !strings.Contains(bs.Summary, "after 2 fix attempt(s): test, lint") {
+		t.Errorf("Summary = %q", bs.Summary)
+	}
+}
+
+func TestPRBabysitter_PushFailureStops(t *testing.T) {
+	t.Parallel()
+	checks := &fakePRChecks{statuses: []*core.CheckStatus{checksFailing("test")}}
+	b, _, mainGit := newTestBabysitter(checks, 3)
+	mainGit.pushErr = errors.New("rejected")
+
+	bs := b.Run(context.Background(), newBabysitState(), 42)
+
+	if bs.Status != core.PRBabysitError {
+		t.Fatalf("Status = %s, want error", bs.Status)
+	}
+	if len(bs.Attempts) != 1 || !strings.Contains(bs.Attempts[0].Error, "rejected") {
+		t.Errorf("Attempts = %+v, want push error recorded", bs.Attempts)
+	}
+}
+
+func TestPRBabysitter_NoAttemptsOnlyWaits(t *testing.T) {
+	t.Parallel()
+	checks := &fakePRChecks{statuses: []*core.CheckStatus{checksFailing("test")}}
+	b, wwm, _ := newTestBabysitter(checks, 0)
+
+	bs := b.Run(context.Background(), newBabysitState(), 42)
+
+	if bs.Status != core.PRBabysitExhausted {
+		t.Errorf("Status = %s, want exhausted", bs.Status)
+	}
+	if len(wwm.createCalls) != 0 {
+		t.Error("no fix task should run with MaxAttempts=0")
+	}
+}
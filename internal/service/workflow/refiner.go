@@ -36,6 +36,11 @@ func (r *Refiner) Run(ctx context.Context, wctx *Context) error {
 		if reportErr := wctx.Report.WriteOriginalPrompt(wctx.State.Prompt); reportErr != nil {
 			wctx.Logger.Warn("failed to write original prompt report", "error", reportErr)
 		}
+		if wctx.State.SourceChat != nil {
+			if reportErr := wctx.Report.WriteSourceChat(sourceChatReport(wctx.State)); reportErr != nil {
+				wctx.Logger.Warn("failed to write source chat report", "error", reportErr)
+			}
+		}
 	}
 
 	// Skip if refinement is disabled
@@ -0,0 +1,189 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+)
+
+// RepositoryGit holds the git components of a repository of a
+// multi-repository workflow.
+type RepositoryGit struct {
+	Repository core.WorkflowRepository
+	Git        core.GitClient
+	// GitHub is nil when PR creation is disabled or unavailable.
+	GitHub core.GitHubClient
+	// Worktrees is nil when workflow isolation is disabled.
+	Worktrees core.WorkflowWorktreeManager
+}
+
+// RepositoryOpener opens the git components of a repository.
+type RepositoryOpener interface {
+	Open(ctx context.Context, repo core.WorkflowRepository) (*RepositoryGit, error)
+}
+
+// RepositorySet opens the repositories of a multi-repository workflow on
+// first use and keeps them open for the rest of the run.
+type RepositorySet struct {
+	opener RepositoryOpener
+	mu     sync.Mutex
+	open   map[string]*RepositoryGit
+}
+
+// NewRepositorySet creates a RepositorySet that opens repositories with opener.
+func NewRepositorySet(opener RepositoryOpener) *RepositorySet {
+	return &RepositorySet{opener: opener, open: make(map[string]*RepositoryGit)}
+}
+
+// Get returns the git components of a repository, opening it if needed.
+func (s *RepositorySet) Get(ctx context.Context, repo core.WorkflowRepository) (*RepositoryGit, error) {
+	if s == nil || s.opener == nil {
+		return nil, fmt.Errorf("repository %s: multi-repository workflows are not configured", repo.Name)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rg, ok := s.open[repo.Name]; ok {
+		return rg, nil
+	}
+	rg, err := s.opener.Open(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("opening repository %s: %w", repo.Name, err)
+	}
+	s.open[repo.Name] = rg
+	return rg, nil
+}
+
+// NewGitRepositorySet creates a RepositorySet that opens repositories with the
+// git adapters. Workflow worktree managers are created when isolation is
+// enabled and GitHub clients when autoPR is.
+func NewGitRepositorySet(worktreeDir string, isolation, autoPR bool, logger *logging.Logger) *RepositorySet {
+	return NewRepositorySet(&gitRepositoryOpener{
+		worktreeDir: worktreeDir,
+		isolation:   isolation,
+		autoPR:      autoPR,
+		logger:      logger,
+	})
+}
+
+// gitRepositoryOpener opens repositories with the git adapter factories.
+type gitRepositoryOpener struct {
+	worktreeDir string
+	isolation   bool
+	autoPR      bool
+	logger      *logging.Logger
+}
+
+func (o *gitRepositoryOpener) Open(ctx context.Context, repo core.WorkflowRepository) (*RepositoryGit, error) {
+	gc, err := createGitClient(repo.Path)
+	if err != nil {
+		return nil, err
+	}
+	if gc == nil {
+		return nil, fmt.Errorf("git client unavailable")
+	}
+	rg := &RepositoryGit{Repository: repo, Git: gc}
+
+	if o.isolation {
+		root, err := gc.RepoRoot(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("detecting repo root: %w", err)
+		}
+		wt, err := createWorkflowWorktreeManager(gc, root, o.worktreeDir, o.logger)
+		if err != nil {
+			return nil, fmt.Errorf("creating workflow worktree manager: %w", err)
+		}
+		rg.Worktrees = wt
+	}
+
+	if o.autoPR {
+		remoteURL, err := gc.RemoteURL(ctx)
+		if err == nil {
+			rg.GitHub, err = createRemoteGitHubClient(remoteURL)
+		}
+		if err != nil && o.logger != nil {
+			o.logger.Warn("failed to create GitHub client for repository, PR creation disabled",
+				"repository", repo.Name,
+				"error", err,
+			)
+		}
+	}
+	return rg, nil
+}
+
+// SetRepositories makes the next Run or Analyze create a multi-repository
+// workflow that changes the given registered projects besides its own.
+func (r *Runner) SetRepositories(repos []core.WorkflowRepository) {
+	r.declaredRepositories = repos
+}
+
+// applyRepositories records the declared repositories on a new workflow. The
+// declaration is consumed: later workflows of the runner change their own
+// repository only.
+func (r *Runner) applyRepositories(state *core.WorkflowState) {
+	repos := r.declaredRepositories
+	if len(repos) == 0 {
+		return
+	}
+	r.declaredRepositories = nil
+	state.Repositories = append([]core.WorkflowRepository(nil), repos...)
+}
+
+// ensureRepositoryIsolation creates the workflow branch of every declared
+// repository that does not have one yet.
+func (r *Runner) ensureRepositoryIsolation(ctx context.Context, state *core.WorkflowState) (changed bool, _ error) {
+	for _, repo := range state.Repositories {
+		if rs := state.RepositoryStates[repo.Name]; rs != nil && rs.WorkflowBranch != "" {
+			continue
+		}
+		rg, err := r.repositories.Get(ctx, repo)
+		if err != nil {
+			return changed, err
+		}
+		if rg.Worktrees == nil {
+			return changed, fmt.Errorf("repository %s: workflow worktree manager unavailable", repo.Name)
+		}
+		info, err := rg.Worktrees.InitializeWorkflow(ctx, string(state.WorkflowID), r.gitIsolation.BaseBranch)
+		if err != nil {
+			return changed, fmt.Errorf("repository %s: %w", repo.Name, err)
+		}
+		if info == nil || info.WorkflowBranch == "" {
+			return changed, fmt.Errorf("repository %s: workflow isolation init returned empty branch", repo.Name)
+		}
+		if state.RepositoryStates == nil {
+			state.RepositoryStates = make(map[string]*core.RepositoryState)
+		}
+		state.RepositoryStates[repo.Name] = &core.RepositoryState{WorkflowBranch: info.WorkflowBranch}
+		changed = true
+	}
+	return changed, nil
+}
+
+// normalizeTaskRepository maps the repository a plan assigns to a task to a
+// declared repository name; the workflow's own project is the empty name.
+func normalizeTaskRepository(name string) string {
+	name = strings.TrimSpace(name)
+	switch strings.ToLower(name) {
+	case "", "none", "primary", "default":
+		return ""
+	}
+	return name
+}
+
+// validateTaskRepositories checks that every task targets the workflow's own
+// project or one of its declared repositories.
+func validateTaskRepositories(state *core.WorkflowState, tasks []*core.Task) error {
+	for _, task := range tasks {
+		if task.Repository == "" {
+			continue
+		}
+		if _, ok := state.Repository(task.Repository); !ok {
+			return core.ErrValidation("UNKNOWN_REPOSITORY",
+				fmt.Sprintf("task %s targets repository %q, which the workflow does not declare", task.ID, task.Repository))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+)
+
+// fakePRClient records the PR operations of a repository.
+type fakePRClient struct {
+	core.GitHubClient
+	repo      string
+	number    int
+	createErr error
+	created   []core.CreatePROptions
+	bodies    map[int]string
+	closed    []int
+}
+
+func (f *fakePRClient) GetDefaultBranch(_ context.Context) (string, error) { return "main", nil }
+
+func (f *fakePRClient) CreatePR(_ context.Context, opts core.CreatePROptions) (*core.PullRequest, error) {
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
+	f.created = append(f.created, opts)
+	return &core.PullRequest{
+		Number:  f.number,
+		Title:   opts.Title,
+		HTMLURL: fmt.Sprintf("https://github.com/acme/%s/pull/%d", f.repo, f.number),
+	}, nil
+}
+
+func (f *fakePRClient) UpdatePR(_ context.Context, number int, opts core.UpdatePROptions) error {
+	if f.bodies == nil {
+		f.bodies = make(map[int]string)
+	}
+	f.bodies[number] = *opts.Body
+	return nil
+}
+
+func (f *fakePRClient) ClosePR(_ context.Context, number int) error {
+	f.closed = append(f.closed, number)
+	return nil
+}
+
+// branchDeletingGit records the remote branches deleted during rollback.
+type branchDeletingGit struct {
+	mockGitClient
+	pushed  []string
+	deleted []string
+}
+
+func (g *branchDeletingGit) Push(_ context.Context, _, branch string) error {
+	g.pushed = append(g.pushed, branch)
+	return nil
+}
+
+func (g *branchDeletingGit) DeleteRemoteBranch(_ context.Context, _, branch string) error {
+	g.deleted = append(g.deleted, branch)
+	return nil
+}
+
+type staticRepositoryOpener map[string]*RepositoryGit
+
+func (o staticRepositoryOpener) Open(_ context.Context, repo core.WorkflowRepository) (*RepositoryGit, error) {
+	rg, ok := o[repo.Name]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return rg, nil
+}
+
+func newMultiRepoState() *core.WorkflowState {
+	return &core.WorkflowState{
+		WorkflowDefinition: core.WorkflowDefinition{
+			WorkflowID:   "wf-multi",
+			Prompt:       "Add the orders endpoint and use it",
+			Repositories: []core.WorkflowRepository{{Name: "web", ProjectID: "proj-web", Path: "/src/web"}},
+		},
+		WorkflowRun: core.WorkflowRun{
+			WorkflowBranch: "quorum/wf-multi",
+			RepositoryStates: map[string]*core.RepositoryState{
+				"web": {WorkflowBranch: "quorum/wf-multi"},
+			},
+			Tasks: map[core.TaskID]*core.TaskState{
+				"task-1": {ID: "task-1", Name: "Add endpoint"},
+				"task-2": {ID: "task-2", Name: "Use endpoint", Repository: "web", Dependencies: []core.TaskID{"task-1"}},
+			},
+			TaskOrder: []core.TaskID{"task-1", "task-2"},
+		},
+	}
+}
+
+func newMultiRepoFinalizer(webGitHub *fakePRClient) (*WorkflowIsolationFinalizer, *branchDeletingGit, *fakePRClient) {
+	apiGit := &branchDeletingGit{mockGitClient: mockGitClient{repoRoot: "/src/api"}}
+	apiGitHub := &fakePRClient{repo: "api", number: 7}
+	f := &WorkflowIsolationFinalizer{
+		Finalization:      FinalizationConfig{AutoPR: true, AutoMerge: true},
+		GitIsolation:      &GitIsolationConfig{Enabled: true},
+		WorkflowWorktrees: &mockWorkflowWorktreeManager{},
+		Git:               apiGit,
+		GitHub:            apiGitHub,
+		Logger:            logging.NewNop(),
+		Repositories: NewRepositorySet(staticRepositoryOpener{
+			"web": {
+				Git:       &branchDeletingGit{},
+				GitHub:    webGitHub,
+				Worktrees: &mockWorkflowWorktreeManager{},
+			},
+		}),
+	}
+	return f, apiGit, apiGitHub
+}
+
+func TestWorkflowIsolationFinalizer_Repositories_LinkedPRs(t *testing.T) {
+	t.Parallel()
+
+	webGitHub := &fakePRClient{repo: "web", number: 3}
+	f, apiGit, apiGitHub := newMultiRepoFinalizer(webGitHub)
+	state := newMultiRepoState()
+
+	f.Finalize(context.Background(), state)
+
+	if len(apiGit.pushed) != 1 || len(apiGitHub.created) != 1 || len(webGitHub.created) != 1 {
+		t.Fatalf("expected one push and PR per repository, got pushes=%v api=%d web=%d",
+			apiGit.pushed, len(apiGitHub.created), len(webGitHub.created))
+	}
+	if got := webGitHub.created[0].Body; !strings.Contains(got, "task-2") || strings.Contains(got, "task-1") {
+		t.Errorf("web PR body should list only its tasks:\n%s", got)
+	}
+	if !strings.Contains(apiGitHub.bodies[7], "https://github.com/acme/web/pull/3") {
+		t.Errorf("api PR should link the web PR:\n%s", apiGitHub.bodies[7])
+	}
+	if !strings.Contains(webGitHub.bodies[3], "api: https://github.com/acme/api/pull/7") {
+		t.Errorf("web PR should link the api PR:\n%s", webGitHub.bodies[3])
+	}
+	if state.PRNumber != 7 {
+		t.Errorf("PRNumber = %d, want 7", state.PRNumber)
+	}
+	if rs := state.RepositoryStates["web"]; rs.PRNumber != 3 || rs.PRURL == "" {
+		t.Errorf("web repository state = %+v, want PR 3", rs)
+	}
+}
+
+func TestWorkflowIsolationFinalizer_Repositories_RollbackOnFailure(t *testing.T) {
+	t.Parallel()
+
+	webGitHub := &fakePRClient{repo: "web", createErr: errors.New("no permission")}
+	f, apiGit, apiGitHub := newMultiRepoFinalizer(webGitHub)
+	state := newMultiRepoState()
+
+	f.Finalize(context.Background(), state)
+
+	if len(apiGitHub.closed) != 1 || apiGitHub.closed[0] != 7 {
+		t.Errorf("api PR should be closed on rollback, closed = %v", apiGitHub.closed)
+	}
+	if len(apiGit.deleted) != 1 || apiGit.deleted[0] != "quorum/wf-multi" {
+		t.Errorf("api workflow branch should be deleted from the remote, deleted = %v", apiGit.deleted)
+	}
+	webGit := f.Repositories.open["web"].Git.(*branchDeletingGit)
+	if len(webGit.deleted) != 1 {
+		t.Errorf("web workflow branch should be deleted from the remote, deleted = %v", webGit.deleted)
+	}
+	if state.PRURL != "" || state.RepositoryStates["web"].PRURL != "" {
+		t.Error("no PR should be recorded after a rollback")
+	}
+}
+
+func TestRunner_ApplyRepositories(t *testing.T) {
+	t.Parallel()
+
+	r := &Runner{config: DefaultRunnerConfig(), logger: logging.NewNop()}
+	r.SetRepositories([]core.WorkflowRepository{{Name: "web"}})
+
+	state := r.initializeState("Rename the login endpoint")
+	r.applyRepositories(state)
+	if len(state.Repositories) != 1 || state.Repositories[0].Name != "web" {
+		t.Fatalf("Repositories = %+v, want web", state.Repositories)
+	}
+
+	// The declaration is used by one workflow only.
+	next := r.initializeState("Something else")
+	r.applyRepositories(next)
+	if len(next.Repositories) != 0 {
+		t.Errorf("Repositories = %+v, want none for the next workflow", next.Repositories)
+	}
+}
+
+func TestValidateTaskRepositories(t *testing.T) {
+	t.Parallel()
+
+	state := newMultiRepoState()
+	tasks := []*core.Task{
+		{ID: "task-1", Repository: normalizeTaskRepository("None")},
+		{ID: "task-2", Repository: normalizeTaskRepository(" web ")},
+	}
+	if err := validateTaskRepositories(state, tasks); err != nil {
+		t.Fatalf("validateTaskRepositories() error = %v", err)
+	}
+
+	tasks = append(tasks, &core.Task{ID: "task-3", Repository: "mobile"})
+	err := validateTaskRepositories(state, tasks)
+	if err == nil || !strings.Contains(err.Error(), "mobile") {
+		t.Errorf("validateTaskRepositories() error = %v, want unknown repository", err)
+	}
+}
+
+func TestEnsureWorkflowGitIsolation_Repositories(t *testing.T) {
+	t.Parallel()
+
+	r := &Runner{
+		gitIsolation:      &GitIsolationConfig{Enabled: true},
+		workflowWorktrees: &mockWorkflowWorktreeManager{},
+		logger:            logging.NewNop(),
+		repositories: NewRepositorySet(staticRepositoryOpener{
+			"web": {Worktrees: &mockWorkflowWorktreeManager{}},
+		}),
+	}
+	state := newMultiRepoState()
+	state.WorkflowBranch = ""
+	state.RepositoryStates = nil
+	state.Tasks = map[core.TaskID]*core.TaskState{}
+
+	changed, err := r.ensureWorkflowGitIsolation(context.Background(), state)
+	if err != nil || !changed {
+		t.Fatalf("ensureWorkflowGitIsolation() = %v, %v", changed, err)
+	}
+	if rs := state.RepositoryStates["web"]; rs == nil || rs.WorkflowBranch != "quorum/wf-multi" {
+		t.Errorf("web repository state = %+v, want workflow branch", rs)
+	}
+
+	r.gitIsolation.Enabled = false
+	if _, err := r.ensureWorkflowGitIsolation(context.Background(), state); err == nil {
+		t.Error("expected an error for a multi-repository workflow without isolation")
+	}
+}
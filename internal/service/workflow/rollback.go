@@ -0,0 +1,334 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/report"
+)
+
+// rollbackArchiveDir is the directory of a workflow's report directory that
+// keeps the outputs of the attempts discarded by a rollback, one
+// subdirectory per execution.
+const rollbackArchiveDir = "attempts"
+
+// refineReportFiles are the files the refine phase writes to the
+// analyze-phase report directory. A rollback to analyze keeps them.
+var refineReportFiles = map[string]bool{
+	"00-original-prompt.md":  true,
+	"00-source-chat.md":      true,
+	"01-optimized-prompt.md": true,
+	"01-refined-prompt.md":   true,
+}
+
+// RollbackResult describes a completed rollback.
+type RollbackResult struct {
+	FromPhase core.Phase
+	ToPhase   core.Phase
+	// ArchivePath is the directory holding the report outputs of the
+	// discarded attempt; empty when there was nothing to archive.
+	ArchivePath string
+	// DiscardedTasks is the number of tasks removed or reset to pending.
+	DiscardedTasks int
+}
+
+// PhaseRollback reopens an earlier phase of a workflow and discards what the
+// workflow produced from that phase on: the checkpoints, the tasks with their
+// worktrees and branches, and the workflow branch. The report outputs of the
+// discarded attempt are moved under the attempts directory of the workflow's
+// report directory rather than deleted. The next run restarts at the phase.
+type PhaseRollback struct {
+	State      StateSaver
+	Checkpoint CheckpointCreator
+	// WorkflowWorktrees removes the worktrees and branches of the workflow.
+	// When nil, they are left in place.
+	WorkflowWorktrees core.WorkflowWorktreeManager
+	// Git deletes the work-in-progress checkpoint refs of the tasks.
+	Git core.GitClient
+	// Repositories opens the other repositories of multi-repository workflows.
+	Repositories *RepositorySet
+	// ReportBaseDir locates the report directory when the state has none.
+	ReportBaseDir string
+	// ProjectRoot resolves a relative report directory.
+	ProjectRoot string
+	Logger      *logging.Logger
+}
+
+// Rollback reopens phase to of the workflow and saves the state. Only a
+// phase the workflow has reached can be reopened, and not while it runs.
+func (p *PhaseRollback) Rollback(ctx context.Context, state *core.WorkflowState, to core.Phase) (*RollbackResult, error) {
+	if state == nil {
+		return nil, core.ErrState("NIL_STATE", "workflow state cannot be nil")
+	}
+	if err := ValidateRollback(state, to); err != nil {
+		return nil, err
+	}
+
+	result := &RollbackResult{FromPhase: state.CurrentPhase, ToPhase: to}
+
+	archivePath, err := p.archiveReports(state, to)
+	if err != nil {
+		return nil, fmt.Errorf("archiving report outputs: %w", err)
+	}
+	result.ArchivePath = archivePath
+
+	p.cleanupGit(ctx, state)
+
+	if core.PhaseOrder(to) <= core.PhaseOrder(core.PhasePlan) {
+		result.DiscardedTasks = len(state.Tasks)
+		state.Tasks = make(map[core.TaskID]*core.TaskState)
+		state.TaskOrder = nil
+	} else {
+		for id, ts := range state.Tasks {
+			if ts == nil {
+				continue
+			}
+			if ts.Status != core.TaskStatusPending {
+				result.DiscardedTasks++
+			}
+			state.Tasks[id] = &core.TaskState{
+				ID:           ts.ID,
+				Phase:        ts.Phase,
+				Name:         ts.Name,
+				Description:  ts.Description,
+				Status:       core.TaskStatusPending,
+				CLI:          ts.CLI,
+				Model:        ts.Model,
+				Dependencies: ts.Dependencies,
+				Repository:   ts.Repository,
+			}
+		}
+	}
+	if to == core.PhaseRefine {
+		state.OptimizedPrompt = ""
+	}
+
+	var kept []core.Checkpoint
+	for _, cp := range state.Checkpoints {
+		if core.PhaseOrder(cp.Phase) < core.PhaseOrder(to) {
+			kept = append(kept, cp)
+		}
+	}
+	state.Checkpoints = kept
+
+	// Like a rejected review, an analysis to redo leaves the workflow pending
+	// and a plan or execution to redo leaves it with the phase before completed.
+	if core.PhaseOrder(to) <= core.PhaseOrder(core.PhaseAnalyze) {
+		state.Status = core.WorkflowStatusPending
+	} else {
+		state.Status = core.WorkflowStatusCompleted
+	}
+	state.CurrentPhase = to
+	state.Error = ""
+	state.InteractiveReview = nil
+	state.ExecutionID++
+	state.UpdatedAt = time.Now()
+
+	metadata := map[string]interface{}{
+		"from_phase":      string(result.FromPhase),
+		"to_phase":        string(to),
+		"discarded_tasks": result.DiscardedTasks,
+	}
+	if archivePath != "" {
+		metadata["archive_path"] = archivePath
+	}
+	if p.Checkpoint != nil {
+		if err := p.Checkpoint.CreateCheckpoint(state, string(service.CheckpointRollback), metadata); err != nil {
+			return nil, fmt.Errorf("creating rollback checkpoint: %w", err)
+		}
+	}
+	if err := p.State.Save(ctx, state); err != nil {
+		return nil, fmt.Errorf("saving state: %w", err)
+	}
+
+	p.logInfo("workflow rolled back",
+		"workflow_id", state.WorkflowID,
+		"from_phase", result.FromPhase,
+		"to_phase", to,
+		"discarded_tasks", result.DiscardedTasks,
+		"archive_path", archivePath,
+	)
+	return result, nil
+}
+
+// ValidateRollback checks that phase to of the workflow can be reopened.
+func ValidateRollback(state *core.WorkflowState, to core.Phase) error {
+	if core.PhaseOrder(to) < 0 || to == core.PhaseDone {
+		return core.ErrValidation("INVALID_PHASE",
+			fmt.Sprintf("invalid phase %q: must be refine, analyze, plan or execute", to))
+	}
+	if state.Status == core.WorkflowStatusRunning {
+		return core.ErrState("WORKFLOW_RUNNING", "cannot roll back a running workflow; cancel it first")
+	}
+	if core.PhaseOrder(to) > core.PhaseOrder(state.CurrentPhase) {
+		return core.ErrValidation("PHASE_NOT_REACHED",
+			fmt.Sprintf("cannot roll back to %s: the workflow is at %s", to, state.CurrentPhase))
+	}
+	return nil
+}
+
+// archiveReports moves the report outputs of phase to and the later phases to
+// attempts/<execution id> and returns that directory, or "" when there was
+// nothing to move.
+func (p *PhaseRollback) archiveReports(state *core.WorkflowState, to core.Phase) (string, error) {
+	reportPath := state.ReportPath
+	if reportPath == "" && state.WorkflowID != "" {
+		baseDir := p.ReportBaseDir
+		if baseDir == "" {
+			baseDir = report.DefaultConfig().BaseDir
+		}
+		reportPath = filepath.Join(baseDir, string(state.WorkflowID))
+	}
+	if reportPath == "" {
+		return "", nil
+	}
+	if !filepath.IsAbs(reportPath) && p.ProjectRoot != "" {
+		reportPath = filepath.Join(p.ProjectRoot, reportPath)
+	}
+
+	archivePath := filepath.Join(reportPath, rollbackArchiveDir, strconv.Itoa(state.ExecutionID))
+	moved := false
+	move := func(rel string) error {
+		src := filepath.Join(reportPath, rel)
+		if _, err := os.Stat(src); errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		dst := filepath.Join(archivePath, rel)
+		if err := os.MkdirAll(filepath.Dir(dst), 0o750); err != nil {
+			return err
+		}
+		if err := os.Rename(src, dst); err != nil {
+			return err
+		}
+		moved = true
+		return nil
+	}
+
+	var dirs []string
+	switch to {
+	case core.PhaseRefine:
+		dirs = []string{"analyze-phase", "plan-phase", "execute-phase"}
+	case core.PhaseAnalyze:
+		// The refine outputs share the analyze-phase directory and stay.
+		entries, err := os.ReadDir(filepath.Join(reportPath, "analyze-phase"))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		for _, e := range entries {
+			if refineReportFiles[e.Name()] {
+				continue
+			}
+			if err := move(filepath.Join("analyze-phase", e.Name())); err != nil {
+				return "", err
+			}
+		}
+		dirs = []string{"plan-phase", "execute-phase"}
+	case core.PhasePlan:
+		dirs = []string{"plan-phase", "execute-phase"}
+	case core.PhaseExecute:
+		dirs = []string{"execute-phase"}
+	}
+	for _, dir := range dirs {
+		if err := move(dir); err != nil {
+			return "", err
+		}
+	}
+
+	if !moved {
+		return "", nil
+	}
+	return archivePath, nil
+}
+
+// cleanupGit removes the worktrees, task branches and workflow branches of the
+// workflow, and the checkpoint refs of its tasks. Failures are logged: the
+// next run creates fresh branches either way.
+func (p *PhaseRollback) cleanupGit(ctx context.Context, state *core.WorkflowState) {
+	workflowID := string(state.WorkflowID)
+
+	if snapshotter, ok := p.Git.(core.GitSnapshotter); ok {
+		for id := range state.Tasks {
+			ref := taskCheckpointRef(state.WorkflowID, id)
+			if err := snapshotter.DeleteRef(ctx, ref); err != nil {
+				p.logWarn("rollback: failed to delete task checkpoints", "ref", ref, "error", err)
+			}
+		}
+	}
+
+	if state.WorkflowBranch != "" {
+		if p.WorkflowWorktrees == nil {
+			p.logWarn("rollback: workflow worktree manager unavailable, leaving branches in place",
+				"workflow_branch", state.WorkflowBranch)
+		} else if err := p.WorkflowWorktrees.CleanupWorkflow(ctx, workflowID, true); err != nil {
+			p.logWarn("rollback: workflow cleanup failed", "workflow_id", workflowID, "error", err)
+		}
+		state.WorkflowBranch = ""
+	}
+
+	for _, repo := range state.Repositories {
+		if rs := state.RepositoryStates[repo.Name]; rs == nil || rs.WorkflowBranch == "" {
+			continue
+		}
+		rg, err := p.Repositories.Get(ctx, repo)
+		if err != nil || rg.Worktrees == nil {
+			p.logWarn("rollback: cannot clean up repository", "repository", repo.Name, "error", err)
+			continue
+		}
+		if err := rg.Worktrees.CleanupWorkflow(ctx, workflowID, true); err != nil {
+			p.logWarn("rollback: repository cleanup failed", "repository", repo.Name, "error", err)
+		}
+	}
+	state.RepositoryStates = nil
+}
+
+func (p *PhaseRollback) logWarn(msg string, args ...any) {
+	if p.Logger != nil {
+		p.Logger.Warn(msg, args...)
+	}
+}
+
+func (p *PhaseRollback) logInfo(msg string, args ...any) {
+	if p.Logger != nil {
+		p.Logger.Info(msg, args...)
+	}
+}
+
+// RollbackWithState reopens phase to of a workflow loaded by ID; see
+// PhaseRollback.
+func (r *Runner) RollbackWithState(ctx context.Context, state *core.WorkflowState, to core.Phase) (*RollbackResult, error) {
+	releaseLock, err := r.acquireStateLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseLock()
+
+	rollback := &PhaseRollback{
+		State:             r.state,
+		Checkpoint:        r.checkpoint,
+		WorkflowWorktrees: r.workflowWorktrees,
+		Git:               r.git,
+		Repositories:      r.repositories,
+		ProjectRoot:       r.projectRoot,
+		Logger:            r.logger,
+	}
+	if r.config != nil {
+		rollback.ReportBaseDir = r.config.Report.BaseDir
+	}
+	result, err := rollback.Rollback(ctx, state, to)
+	if err != nil {
+		return nil, err
+	}
+	if r.output != nil {
+		r.output.Log("info", "workflow", fmt.Sprintf("Rolled back to %s phase: %d tasks discarded",
+			to, result.DiscardedTasks))
+	}
+	return result, nil
+}
@@ -0,0 +1,255 @@
+package workflow
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+)
+
+// newRollbackTestState returns a workflow that finished executing two tasks
+// under workflow isolation, with report outputs for every phase in reportDir.
+func newRollbackTestState(t *testing.T, reportDir string) *core.WorkflowState {
+	t.Helper()
+	for _, rel := range []string{
+		"analyze-phase/00-original-prompt.md",
+		"analyze-phase/01-refined-prompt.md",
+		"analyze-phase/consolidated.md",
+		"plan-phase/tasks/task-1.md",
+		"execute-phase/outputs/task-1.md",
+	} {
+		path := filepath.Join(reportDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(strings.Repeat(rel+"\n", 64)), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return &core.WorkflowState{
+		WorkflowDefinition: core.WorkflowDefinition{WorkflowID: "wf-rollback", OptimizedPrompt: "refined prompt"},
+		WorkflowRun: core.WorkflowRun{
+			Status:         core.WorkflowStatusCompleted,
+			CurrentPhase:   core.PhaseDone,
+			ExecutionID:    3,
+			ReportPath:     reportDir,
+			WorkflowBranch: "quorum/wf-rollback",
+			Tasks: map[core.TaskID]*core.TaskState{
+				"task-1": {ID: "task-1", Name: "First", CLI: "claude", Status: core.TaskStatusCompleted,
+					Branch: "quorum/wf-rollback__task-1", LastCommit: "abc123", Output: "done"},
+				"task-2": {ID: "task-2", Name: "Second", CLI: "claude", Status: core.TaskStatusFailed,
+					Dependencies: []core.TaskID{"task-1"}, Error: "tests failed"},
+			},
+			TaskOrder: []core.TaskID{"task-1", "task-2"},
+			Checkpoints: []core.Checkpoint{
+				{ID: "cp-1", Type: "phase_complete", Phase: core.PhaseRefine},
+				{ID: "cp-2", Type: "consolidated_analysis", Phase: core.PhaseAnalyze},
+				{ID: "cp-3", Type: "phase_complete", Phase: core.PhaseAnalyze},
+				{ID: "cp-4", Type: "phase_complete", Phase: core.PhasePlan},
+				{ID: "cp-5", Type: "task_complete", Phase: core.PhaseExecute, TaskID: "task-1"},
+			},
+		},
+	}
+}
+
+type cleanupCall struct {
+	workflowID   string
+	removeBranch bool
+}
+
+func newTestRollback(reportDir string) (*PhaseRollback, *mockStateSaver, *mockCheckpointCreator, *[]cleanupCall) {
+	saver := &mockStateSaver{}
+	checkpoints := &mockCheckpointCreator{}
+	var calls []cleanupCall
+	worktrees := &mockWWTMWithCleanup{cleanupFn: func(_ context.Context, workflowID string, removeBranch bool) error {
+		calls = append(calls, cleanupCall{workflowID, removeBranch})
+		return nil
+	}}
+	return &PhaseRollback{
+		State:             saver,
+		Checkpoint:        checkpoints,
+		WorkflowWorktrees: worktrees,
+		ReportBaseDir:     reportDir,
+		Logger:            logging.NewNop(),
+	}, saver, checkpoints, &calls
+}
+
+func checkpointIDs(state *core.WorkflowState) string {
+	ids := make([]string, 0, len(state.Checkpoints))
+	for _, cp := range state.Checkpoints {
+		ids = append(ids, cp.ID)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestPhaseRollback_ToPlan(t *testing.T) {
+	t.Parallel()
+	reportDir := t.TempDir()
+	state := newRollbackTestState(t, reportDir)
+	rollback, saver, checkpoints, calls := newTestRollback(reportDir)
+
+	result, err := rollback.Rollback(context.Background(), state, core.PhasePlan)
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+
+	if result.FromPhase != core.PhaseDone || result.DiscardedTasks != 2 {
+		t.Errorf("result = %+v", result)
+	}
+	if len(state.Tasks) != 0 || len(state.TaskOrder) != 0 {
+		t.Errorf("tasks = %v, order = %v; want none", state.Tasks, state.TaskOrder)
+	}
+	if got := checkpointIDs(state); got != "cp-1,cp-2,cp-3" {
+		t.Errorf("kept checkpoints = %s, want cp-1,cp-2,cp-3", got)
+	}
+	if state.CurrentPhase != core.PhasePlan || state.Status != core.WorkflowStatusCompleted {
+		t.Errorf("phase = %s, status = %s", state.CurrentPhase, state.Status)
+	}
+	if state.ExecutionID != 4 {
+		t.Errorf("ExecutionID = %d, want 4", state.ExecutionID)
+	}
+	if state.WorkflowBranch != "" {
+		t.Errorf("WorkflowBranch = %q, want it cleared", state.WorkflowBranch)
+	}
+	if len(*calls) != 1 || (*calls)[0] != (cleanupCall{"wf-rollback", true}) {
+		t.Errorf("CleanupWorkflow calls = %+v", *calls)
+	}
+	if len(checkpoints.checkpoints) != 1 || checkpoints.checkpoints[0] != "rollback" {
+		t.Errorf("checkpoints created = %v, want [rollback]", checkpoints.checkpoints)
+	}
+	if saver.state != state {
+		t.Error("state was not saved")
+	}
+
+	archive := filepath.Join(reportDir, "attempts", "3")
+	if result.ArchivePath != archive {
+		t.Errorf("ArchivePath = %q, want %q", result.ArchivePath, archive)
+	}
+	for _, rel := range []string{"plan-phase/tasks/task-1.md", "execute-phase/outputs/task-1.md"} {
+		if _, err := os.Stat(filepath.Join(archive, rel)); err != nil {
+			t.Errorf("archived %s: %v", rel, err)
+		}
+		if _, err := os.Stat(filepath.Join(reportDir, rel)); !os.IsNotExist(err) {
+			t.Errorf("%s still in the report directory", rel)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(reportDir, "analyze-phase", "consolidated.md")); err != nil {
+		t.Errorf("analysis was not kept: %v", err)
+	}
+}
+
+func TestPhaseRollback_ToAnalyzeKeepsRefineOutputs(t *testing.T) {
+	t.Parallel()
+	reportDir := t.TempDir()
+	state := newRollbackTestState(t, reportDir)
+	rollback, _, _, _ := newTestRollback(reportDir)
+
+	result, err := rollback.Rollback(context.Background(), state, core.PhaseAnalyze)
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+
+	if got := checkpointIDs(state); got != "cp-1" {
+		t.Errorf("kept checkpoints = %s, want cp-1", got)
+	}
+	if state.Status != core.WorkflowStatusPending || state.OptimizedPrompt != "refined prompt" {
+		t.Errorf("status = %s, optimized prompt = %q", state.Status, state.OptimizedPrompt)
+	}
+	if _, err := os.Stat(filepath.Join(result.ArchivePath, "analyze-phase", "consolidated.md")); err != nil {
+		t.Errorf("analysis was not archived: %v", err)
+	}
+	for _, name := range []string{"00-original-prompt.md", "01-refined-prompt.md"} {
+		if _, err := os.Stat(filepath.Join(reportDir, "analyze-phase", name)); err != nil {
+			t.Errorf("refine output %s was not kept: %v", name, err)
+		}
+	}
+
+	// Nothing reconciles the archived analysis back into the state.
+	runner := &Runner{config: &RunnerConfig{}, logger: logging.NewNop(), checkpoint: &mockCheckpointCreator{}}
+	if err := runner.reconcileAnalysisArtifacts(context.Background(), state); err != nil {
+		t.Fatalf("reconcileAnalysisArtifacts() error = %v", err)
+	}
+	if GetConsolidatedAnalysis(state) != "" {
+		t.Error("the archived analysis was restored")
+	}
+}
+
+func TestPhaseRollback_ToRefineClearsOptimizedPrompt(t *testing.T) {
+	t.Parallel()
+	reportDir := t.TempDir()
+	state := newRollbackTestState(t, reportDir)
+	rollback, _, _, _ := newTestRollback(reportDir)
+
+	if _, err := rollback.Rollback(context.Background(), state, core.PhaseRefine); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if len(state.Checkpoints) != 0 || state.OptimizedPrompt != "" {
+		t.Errorf("checkpoints = %d, optimized prompt = %q; want none", len(state.Checkpoints), state.OptimizedPrompt)
+	}
+	if _, err := os.Stat(filepath.Join(reportDir, "analyze-phase")); !os.IsNotExist(err) {
+		t.Error("analyze-phase was not archived")
+	}
+}
+
+func TestPhaseRollback_ToExecuteResetsTasks(t *testing.T) {
+	t.Parallel()
+	reportDir := t.TempDir()
+	state := newRollbackTestState(t, reportDir)
+	rollback, _, _, _ := newTestRollback(reportDir)
+
+	result, err := rollback.Rollback(context.Background(), state, core.PhaseExecute)
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+
+	if result.DiscardedTasks != 2 || len(state.TaskOrder) != 2 {
+		t.Errorf("discarded = %d, order = %v", result.DiscardedTasks, state.TaskOrder)
+	}
+	for id, ts := range state.Tasks {
+		if ts.Status != core.TaskStatusPending || ts.Branch != "" || ts.LastCommit != "" || ts.Error != "" || ts.Output != "" {
+			t.Errorf("task %s not reset: %+v", id, ts)
+		}
+	}
+	if deps := state.Tasks["task-2"].Dependencies; len(deps) != 1 || deps[0] != "task-1" {
+		t.Errorf("task-2 dependencies = %v", deps)
+	}
+	if got := checkpointIDs(state); got != "cp-1,cp-2,cp-3,cp-4" {
+		t.Errorf("kept checkpoints = %s", got)
+	}
+	if _, err := os.Stat(filepath.Join(reportDir, "plan-phase", "tasks", "task-1.md")); err != nil {
+		t.Errorf("plan was not kept: %v", err)
+	}
+}
+
+func TestValidateRollback(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		status  core.WorkflowStatus
+		current core.Phase
+		to      core.Phase
+		wantErr bool
+	}{
+		{"earlier phase", core.WorkflowStatusFailed, core.PhaseExecute, core.PhaseAnalyze, false},
+		{"current phase", core.WorkflowStatusFailed, core.PhaseExecute, core.PhaseExecute, false},
+		{"completed workflow", core.WorkflowStatusCompleted, core.PhaseDone, core.PhaseExecute, false},
+		{"phase not reached", core.WorkflowStatusCompleted, core.PhasePlan, core.PhaseExecute, true},
+		{"running", core.WorkflowStatusRunning, core.PhaseExecute, core.PhasePlan, true},
+		{"done", core.WorkflowStatusCompleted, core.PhaseDone, core.PhaseDone, true},
+		{"unknown phase", core.WorkflowStatusCompleted, core.PhaseDone, "review", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			state := &core.WorkflowState{WorkflowRun: core.WorkflowRun{Status: tt.status, CurrentPhase: tt.current}}
+			if err := ValidateRollback(state, tt.to); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRollback() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -15,9 +15,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/agenthealth"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/control"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/chatpromote"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/issues"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/report"
 )
 
@@ -61,6 +64,9 @@ type RunnerConfig struct {
 	WorktreeAutoClean bool
 	// WorktreeMode controls when worktrees are created for tasks.
 	WorktreeMode string
+	// TaskCheckpointInterval is how often the work in progress of a task
+	// running in a worktree is checkpointed (0 disables checkpoints).
+	TaskCheckpointInterval time.Duration
 	// Refiner configures the prompt refinement phase.
 	Refiner RefinerConfig
 	// Synthesizer configures the analysis synthesis phase.
@@ -71,6 +77,10 @@ type RunnerConfig struct {
 	Report report.Config
 	// PhaseTimeouts holds per-phase timeout durations.
 	PhaseTimeouts PhaseTimeouts
+	// TaskInput configures how tasks wait for the answers to their questions.
+	TaskInput TaskInputConfig
+	// SkipDependents is what happens to the dependents of a skipped task.
+	SkipDependents string
 	// Moderator configures the semantic moderator for consensus evaluation.
 	Moderator ModeratorConfig
 	// SingleAgent configures single-agent execution mode (bypasses multi-agent consensus).
@@ -81,6 +91,9 @@ type RunnerConfig struct {
 	// This overrides the global agent phases from the server config.
 	// Empty list means all phases are enabled.
 	ProjectAgentPhases map[string][]string
+	// ConfigVersion is the config history version the runner was built from;
+	// 0 when unknown.
+	ConfigVersion int
 }
 
 // SynthesizerConfig configures the analysis synthesis phase.
@@ -156,14 +169,30 @@ type Runner struct {
 	worktrees         WorktreeManager
 	workflowWorktrees core.WorkflowWorktreeManager
 	gitIsolation      *GitIsolationConfig
+	gitFactory        GitClientFactory
 	git               core.GitClient
 	github            core.GitHubClient
+	issueClient       core.IssueClient
 	logger            *logging.Logger
 	output            OutputNotifier
 	modeEnforcer      ModeEnforcerInterface
 	control           *control.ControlPlane
 	heartbeat         *HeartbeatManager
 	projectRoot       string // Project root directory for multi-project support
+	repositories      *RepositorySet
+	contextIndex      ContextIndexer
+	agentHealth       *agenthealth.Monitor
+
+	// declaredRepositories are the other repositories the next Run or
+	// Analyze creates a multi-repository workflow for.
+	declaredRepositories []core.WorkflowRepository
+
+	// sourceIssue is the issue the next Run creates its workflow from.
+	sourceIssue *issues.Source
+	// sourceChat is the chat conversation the next Run or Analyze creates
+	// its workflow from; sourceChatStore records the link on the session.
+	sourceChat      *chatpromote.Conversation
+	sourceChatStore core.ChatStore
 }
 
 // RunnerDeps holds dependencies for creating a Runner.
@@ -186,12 +215,16 @@ type RunnerDeps struct {
 	GitClientFactory  GitClientFactory
 	Git               core.GitClient
 	GitHub            core.GitHubClient
+	Issues            core.IssueClient // Reports status on source issues (optional, created on demand)
 	Logger            *logging.Logger
 	Output            OutputNotifier
 	ModeEnforcer      ModeEnforcerInterface
 	Control           *control.ControlPlane
 	Heartbeat         *HeartbeatManager
-	ProjectRoot       string // Project root directory for multi-project support
+	ProjectRoot       string               // Project root directory for multi-project support
+	Repositories      *RepositorySet       // Opens the other repositories of multi-repository workflows
+	ContextIndex      ContextIndexer       // Grounds analyze and plan prompts with relevant files (optional)
+	AgentHealth       *agenthealth.Monitor // Quarantines failing agents and substitutes their fallback (optional)
 }
 
 // NewRunner creates a new workflow runner with all dependencies.
@@ -229,14 +262,19 @@ func NewRunner(deps RunnerDeps) (*Runner, error) {
 		worktrees:         deps.Worktrees,
 		workflowWorktrees: deps.WorkflowWorktrees,
 		gitIsolation:      deps.GitIsolation,
+		gitFactory:        deps.GitClientFactory,
 		git:               deps.Git,
 		github:            deps.GitHub,
+		issueClient:       deps.Issues,
 		logger:            deps.Logger,
 		output:            deps.Output,
 		modeEnforcer:      deps.ModeEnforcer,
 		control:           deps.Control,
 		heartbeat:         deps.Heartbeat,
 		projectRoot:       deps.ProjectRoot,
+		repositories:      deps.Repositories,
+		contextIndex:      deps.ContextIndex,
+		agentHealth:       deps.AgentHealth,
 	}, nil
 }
 
@@ -275,6 +313,9 @@ func (r *Runner) Run(ctx context.Context, prompt string) error {
 
 	// Initialize state
 	workflowState := r.initializeState(prompt)
+	r.applySourceIssue(workflowState)
+	r.applySourceChat(ctx, workflowState)
+	r.applyRepositories(workflowState)
 
 	// Ensure workflow-level Git isolation (creates workflow branch/worktree namespace).
 	if _, err := r.ensureWorkflowGitIsolation(ctx, workflowState); err != nil {
@@ -341,6 +382,8 @@ func (r *Runner) Run(ctx context.Context, prompt string) error {
 			workflowState.Metrics.Duration.Round(time.Second)))
 	}
 
+	r.reportSourceIssue(ctx, workflowState)
+
 	return r.state.Save(ctx, workflowState)
 }
 
@@ -498,6 +541,8 @@ func (r *Runner) RunWithState(ctx context.Context, state *core.WorkflowState) er
 			workflowState.Metrics.Duration.Round(time.Second)))
 	}
 
+	r.reportSourceIssue(ctx, workflowState)
+
 	return r.state.Save(ctx, workflowState)
 }
 
@@ -780,6 +825,8 @@ func (r *Runner) Resume(ctx context.Context) error {
 			workflowState.Metrics.Duration.Round(time.Second)))
 	}
 
+	r.reportSourceIssue(ctx, workflowState)
+
 	return r.state.Save(ctx, workflowState)
 }
 
@@ -889,6 +936,8 @@ func (r *Runner) ResumeWithState(ctx context.Context, state *core.WorkflowState)
 			state.Metrics.Duration.Round(time.Second)))
 	}
 
+	r.reportSourceIssue(ctx, state)
+
 	return r.state.Save(ctx, state)
 }
 
@@ -966,6 +1015,9 @@ func (r *Runner) buildBlueprint() *core.Blueprint {
 // Call this at the start of RunWithState or ResumeWithState to distinguish event sets.
 func (r *Runner) prepareExecution(state *core.WorkflowState, isResume bool) {
 	state.ExecutionID++
+	if r.config != nil && r.config.ConfigVersion > 0 {
+		state.ConfigVersion = r.config.ConfigVersion
+	}
 	if !isResume {
 		// New execution: clear previous events
 		state.AgentEvents = nil
@@ -988,14 +1040,15 @@ func (r *Runner) ensureWorkflowGitIsolation(ctx context.Context, state *core.Wor
 		return false, nil
 	}
 
-	if r.gitIsolation == nil || !r.gitIsolation.Enabled {
-		return false, nil
-	}
-	if r.workflowWorktrees == nil {
+	if r.gitIsolation == nil || !r.gitIsolation.Enabled || r.workflowWorktrees == nil {
+		if len(state.Repositories) > 0 {
+			return false, core.ErrValidation("ISOLATION_REQUIRED",
+				"multi-repository workflows require workflow git isolation")
+		}
 		return false, nil
 	}
 	if state.WorkflowBranch != "" {
-		return false, nil
+		return r.ensureRepositoryIsolation(ctx, state)
 	}
 
 	// Safety: don't enable workflow isolation mid-workflow if tasks already executed or have
@@ -1033,6 +1086,9 @@ func (r *Runner) ensureWorkflowGitIsolation(ctx context.Context, state *core.Wor
 	}
 
 	state.WorkflowBranch = info.WorkflowBranch
+	if _, err := r.ensureRepositoryIsolation(ctx, state); err != nil {
+		return true, err
+	}
 	return true, nil
 }
 
@@ -1081,7 +1137,7 @@ func (r *Runner) createContext(state *core.WorkflowState) *Context {
 		finalizationCfg.AutoMerge = false
 	}
 
-	return &Context{
+	wctx := &Context{
 		State:             state,
 		Agents:            r.agents,
 		Prompts:           r.prompts,
@@ -1102,20 +1158,30 @@ func (r *Runner) createContext(state *core.WorkflowState) *Context {
 			DryRun:                 r.config.DryRun,
 			DenyTools:              r.config.DenyTools,
 			DefaultAgent:           r.config.DefaultAgent,
+			MaxRetries:             r.config.MaxRetries,
 			AgentPhaseModels:       r.config.AgentPhaseModels,
 			WorktreeAutoClean:      r.config.WorktreeAutoClean,
 			WorktreeMode:           r.config.WorktreeMode,
+			TaskCheckpointInterval: r.config.TaskCheckpointInterval,
 			SynthesizerAgent:       r.config.Synthesizer.Agent,
 			PlanSynthesizerEnabled: r.config.PlanSynthesizer.Enabled,
 			PlanSynthesizerAgent:   r.config.PlanSynthesizer.Agent,
 			PhaseTimeouts:          r.config.PhaseTimeouts,
+			TaskInput:              r.config.TaskInput,
+			SkipDependents:         r.config.SkipDependents,
 			Moderator:              r.config.Moderator,
 			SingleAgent:            r.config.SingleAgent,
 			Finalization:           finalizationCfg,
 			ProjectAgentPhases:     r.config.ProjectAgentPhases,
 		},
-		ProjectRoot: r.projectRoot,
+		ProjectRoot:  r.projectRoot,
+		Repositories: r.repositories,
+		ContextIndex: r.contextIndex,
+	}
+	if r.agentHealth != nil {
+		wctx.trackAgentHealth(r.agentHealth)
 	}
+	return wctx
 }
 
 // handleAbort maps a cancellation to an aborted workflow and persists it using a
@@ -1215,6 +1281,8 @@ func (r *Runner) handleError(ctx context.Context, state *core.WorkflowState, err
 	// Write error details to the report directory for debugging and traceability.
 	r.writeErrorToReportDir(state, err)
 
+	r.reportSourceIssue(ctx, state)
+
 	// Deactivate workflow when it fails to prevent ghost workflows.
 	// A failed workflow should not remain as the active workflow.
 	deactCtx, deactCancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
@@ -1439,6 +1507,8 @@ func (r *Runner) Analyze(ctx context.Context, prompt string) error {
 
 	// Initialize state
 	workflowState := r.initializeState(prompt)
+	r.applySourceChat(ctx, workflowState)
+	r.applyRepositories(workflowState)
 
 	r.logger.Info("starting analyze-only workflow",
 		"workflow_id", workflowState.WorkflowID,
@@ -1955,6 +2025,16 @@ func (r *Runner) UsePlan(ctx context.Context) error {
 			len(manifest.Tasks), len(manifest.ExecutionLevels)))
 	}
 
+	for _, item := range manifest.Tasks {
+		if item.Repository == "" {
+			continue
+		}
+		if _, ok := workflowState.Repository(item.Repository); !ok {
+			return core.ErrValidation("UNKNOWN_REPOSITORY",
+				fmt.Sprintf("task %s targets repository %q, which the workflow does not declare", item.ID, item.Repository))
+		}
+	}
+
 	// Clear existing plan data
 	r.clearPlanPhaseData(workflowState)
 
@@ -1973,6 +2053,7 @@ func (r *Runner) UsePlan(ctx context.Context) error {
 			Status:       core.TaskStatusPending,
 			CLI:          cli,
 			Dependencies: make([]core.TaskID, 0, len(item.Dependencies)),
+			Repository:   item.Repository,
 		}
 
 		for _, dep := range item.Dependencies {
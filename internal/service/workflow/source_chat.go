@@ -0,0 +1,75 @@
+package workflow
+
+import (
+	"context"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/attachments"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/chatpromote"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/report"
+)
+
+// SetSourceChat makes the next Run or Analyze create its workflow from a chat
+// conversation: the workflow records the chat link, the transcript and the
+// session attachments are stored as workflow attachments, and the session is
+// linked back to the workflow in store (optional).
+func (r *Runner) SetSourceChat(conv *chatpromote.Conversation, store core.ChatStore) {
+	r.sourceChat = conv
+	r.sourceChatStore = store
+}
+
+// applySourceChat records the configured source chat on a new workflow. The
+// source is consumed: later workflows of the runner are not linked to it.
+func (r *Runner) applySourceChat(ctx context.Context, state *core.WorkflowState) {
+	conv, store := r.sourceChat, r.sourceChatStore
+	if conv == nil {
+		return
+	}
+	r.sourceChat, r.sourceChatStore = nil, nil
+
+	if state.Title == "" {
+		state.Title = conv.WorkflowTitle()
+	}
+	transcriptID := ""
+	if r.projectRoot != "" {
+		saved, err := conv.SaveAttachments(attachments.NewStore(r.projectRoot), state.WorkflowID)
+		if err != nil {
+			r.logger.Warn("failed to attach chat conversation",
+				"workflow_id", state.WorkflowID,
+				"chat_session", conv.Session.ID,
+				"error", err,
+			)
+		}
+		if len(saved) > 0 {
+			transcriptID = saved[0].ID
+		}
+		state.Attachments = append(state.Attachments, saved...)
+	}
+	state.SourceChat = conv.Link(transcriptID)
+
+	if store == nil {
+		return
+	}
+	if err := conv.LinkSession(ctx, store, state.WorkflowID); err != nil {
+		r.logger.Warn("failed to link chat session to workflow",
+			"workflow_id", state.WorkflowID,
+			"chat_session", conv.Session.ID,
+			"error", err,
+		)
+	}
+}
+
+// sourceChatReport returns the report reference to the workflow's source chat.
+func sourceChatReport(state *core.WorkflowState) report.SourceChatData {
+	data := report.SourceChatData{
+		SessionID: state.SourceChat.SessionID,
+		Title:     state.SourceChat.Title,
+	}
+	for _, att := range state.Attachments {
+		if att.ID == state.SourceChat.TranscriptAttachment {
+			data.TranscriptPath = att.Path
+			break
+		}
+	}
+	return data
+}
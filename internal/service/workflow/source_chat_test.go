@@ -0,0 +1,65 @@
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/chatpromote"
+)
+
+// sessionRecorder is a core.ChatStore that records saved sessions.
+type sessionRecorder struct {
+	core.ChatStore
+	saved []core.ChatSessionState
+}
+
+func (s *sessionRecorder) SaveSession(_ context.Context, session *core.ChatSessionState) error {
+	s.saved = append(s.saved, *session)
+	return nil
+}
+
+func TestRunner_SourceChat(t *testing.T) {
+	t.Parallel()
+
+	store := &sessionRecorder{}
+	r := &Runner{
+		config:      DefaultRunnerConfig(),
+		logger:      logging.NewNop(),
+		projectRoot: t.TempDir(),
+	}
+	r.SetSourceChat(&chatpromote.Conversation{
+		Session: &core.ChatSessionState{ID: "chat-1", Title: "Retries"},
+		Messages: []*core.ChatMessageState{
+			{ID: "m1", Role: "user", Content: "Retry on 503?"},
+		},
+		Title: "Retry 503 responses",
+	}, store)
+
+	state := r.initializeState("Add retries.")
+	r.applySourceChat(context.Background(), state)
+
+	if state.SourceChat == nil || state.SourceChat.SessionID != "chat-1" || state.SourceChat.Title != "Retries" {
+		t.Fatalf("SourceChat = %+v", state.SourceChat)
+	}
+	if state.Title != "Retry 503 responses" {
+		t.Errorf("Title = %q", state.Title)
+	}
+	if len(state.Attachments) != 1 || state.Attachments[0].ID != state.SourceChat.TranscriptAttachment {
+		t.Errorf("Attachments = %+v, want the transcript", state.Attachments)
+	}
+	if len(store.saved) != 1 || store.saved[0].WorkflowID != string(state.WorkflowID) {
+		t.Errorf("saved sessions = %+v, want the session linked to the workflow", store.saved)
+	}
+	if data := sourceChatReport(state); data.TranscriptPath != state.Attachments[0].Path {
+		t.Errorf("report transcript path = %q", data.TranscriptPath)
+	}
+
+	// The source is used by one workflow only.
+	next := r.initializeState("Something else")
+	r.applySourceChat(context.Background(), next)
+	if next.SourceChat != nil {
+		t.Errorf("SourceChat = %+v, want nil for the next workflow", next.SourceChat)
+	}
+}
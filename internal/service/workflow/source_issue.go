@@ -0,0 +1,96 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/github"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/attachments"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/issues"
+)
+
+// createSourceIssueClient creates the client used to report on a source
+// issue when the runner was built without one. Tests replace it.
+var createSourceIssueClient = func(link *core.IssueLink) (core.IssueClient, error) {
+	if link.Provider != "" && link.Provider != core.IssueProviderGitHub {
+		return nil, fmt.Errorf("issue provider %s not supported", link.Provider)
+	}
+	if owner, repo, ok := strings.Cut(link.Repository, "/"); ok {
+		return github.NewIssueClient(owner, repo)
+	}
+	return github.NewIssueClientFromRepo()
+}
+
+// SetSourceIssue makes the next Run create its workflow from an issue: the
+// workflow records the issue link and title, and the issue discussion is
+// stored as a workflow attachment.
+func (r *Runner) SetSourceIssue(src *issues.Source) {
+	r.sourceIssue = src
+}
+
+// applySourceIssue records the configured source issue on a new workflow. The
+// source is consumed: later workflows of the runner are not linked to it.
+func (r *Runner) applySourceIssue(state *core.WorkflowState) {
+	src := r.sourceIssue
+	if src == nil {
+		return
+	}
+	r.sourceIssue = nil
+
+	state.SourceIssue = src.Link()
+	if state.Title == "" {
+		state.Title = src.Title()
+	}
+	if r.projectRoot == "" {
+		return
+	}
+	saved, err := src.SaveAttachments(attachments.NewStore(r.projectRoot), state.WorkflowID)
+	if err != nil {
+		r.logger.Warn("failed to attach issue discussion",
+			"workflow_id", state.WorkflowID,
+			"issue", state.SourceIssue.Number,
+			"error", err,
+		)
+		return
+	}
+	state.Attachments = append(state.Attachments, saved...)
+}
+
+// reportSourceIssue posts the workflow outcome on its source issue.
+// Failures are logged and never change the workflow result.
+func (r *Runner) reportSourceIssue(ctx context.Context, state *core.WorkflowState) {
+	if state.SourceIssue == nil || (r.config != nil && r.config.DryRun) {
+		return
+	}
+
+	client := r.issueClient
+	if client == nil {
+		var err error
+		client, err = createSourceIssueClient(state.SourceIssue)
+		if err != nil {
+			r.logger.Warn("cannot report on source issue",
+				"workflow_id", state.WorkflowID,
+				"issue", state.SourceIssue.Number,
+				"error", err,
+			)
+			return
+		}
+	}
+
+	reportCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+	defer cancel()
+	if err := issues.ReportStatus(reportCtx, client, state); err != nil {
+		r.logger.Warn("failed to report on source issue",
+			"workflow_id", state.WorkflowID,
+			"issue", state.SourceIssue.Number,
+			"error", err,
+		)
+		return
+	}
+	if r.output != nil {
+		r.output.Log("info", "workflow", fmt.Sprintf("Posted status on issue #%d", state.SourceIssue.Number))
+	}
+}
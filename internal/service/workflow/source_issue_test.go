@@ -0,0 +1,93 @@
+package workflow
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/issues"
+)
+
+// commentRecorder is a core.IssueClient that records issue comments.
+type commentRecorder struct {
+	core.IssueClient
+	comments map[int][]string
+}
+
+func (c *commentRecorder) AddIssueComment(_ context.Context, number int, comment string) error {
+	if c.comments == nil {
+		c.comments = make(map[int][]string)
+	}
+	c.comments[number] = append(c.comments[number], comment)
+	return nil
+}
+
+func TestRunner_SourceIssue(t *testing.T) {
+	t.Parallel()
+
+	recorder := &commentRecorder{}
+	r := &Runner{
+		config:      DefaultRunnerConfig(),
+		logger:      logging.NewNop(),
+		issueClient: recorder,
+		projectRoot: t.TempDir(),
+	}
+	r.SetSourceIssue(&issues.Source{
+		Issue: &core.Issue{Number: 17, Title: "Login fails", URL: "https://github.com/acme/widgets/issues/17"},
+		Comments: []core.IssueComment{
+			{Author: "alice", Body: "Happens on Safari only"},
+		},
+		Provider: core.IssueProviderGitHub,
+	})
+
+	state := r.initializeState("Resolve issue #17")
+	r.applySourceIssue(state)
+
+	if state.SourceIssue == nil || state.SourceIssue.Number != 17 {
+		t.Fatalf("SourceIssue = %+v", state.SourceIssue)
+	}
+	if state.Title != "#17 Login fails" {
+		t.Errorf("Title = %q", state.Title)
+	}
+	if len(state.Attachments) != 1 {
+		t.Errorf("Attachments = %+v, want issue discussion", state.Attachments)
+	}
+
+	// The source is used by one workflow only.
+	next := r.initializeState("Something else")
+	r.applySourceIssue(next)
+	if next.SourceIssue != nil {
+		t.Errorf("SourceIssue = %+v, want nil for the next workflow", next.SourceIssue)
+	}
+
+	state.Status = core.WorkflowStatusCompleted
+	state.PRURL = "https://github.com/acme/widgets/pull/99"
+	r.reportSourceIssue(context.Background(), state)
+
+	if len(recorder.comments[17]) != 1 {
+		t.Fatalf("comments = %v, want one status comment", recorder.comments)
+	}
+	body := recorder.comments[17][0]
+	if !strings.Contains(body, "workflow completed") || !strings.Contains(body, "pull/99") {
+		t.Errorf("unexpected status comment:\n%s", body)
+	}
+}
+
+func TestRunner_ReportSourceIssue_SkipsDryRun(t *testing.T) {
+	t.Parallel()
+
+	recorder := &commentRecorder{}
+	cfg := DefaultRunnerConfig()
+	cfg.DryRun = true
+	r := &Runner{config: cfg, logger: logging.NewNop(), issueClient: recorder}
+
+	state := &core.WorkflowState{}
+	state.SourceIssue = &core.IssueLink{Number: 17}
+	r.reportSourceIssue(context.Background(), state)
+
+	if len(recorder.comments) != 0 {
+		t.Errorf("dry run must not comment, got %v", recorder.comments)
+	}
+}
@@ -0,0 +1,274 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// Work-in-progress checkpoints of execute tasks.
+//
+// While a task runs in a worktree, its working tree is committed at the
+// configured interval to a hidden ref, refs/quorum/wip/<workflow>/<task>,
+// each checkpoint on top of the previous one. The checkpoints never touch the
+// task branch. When the task runs again after a crash or a failure, its new
+// worktree is restored from the last checkpoint and the agent is asked to
+// continue from there. Finalization squashes the checkpoints: the task is
+// committed as a single commit and the ref is deleted.
+
+const (
+	// taskCheckpointRefPrefix is the namespace of the checkpoint refs.
+	taskCheckpointRefPrefix = "refs/quorum/wip/"
+	// maxResumeDiffSize bounds the diff included in a resume prompt.
+	maxResumeDiffSize = 64 * 1024
+	// maxResumeOutputSize bounds the previous output included in a resume
+	// prompt; the end of the output is kept.
+	maxResumeOutputSize = 16 * 1024
+)
+
+// ParseTaskCheckpointInterval returns the interval of git.task.checkpoint_interval.
+// Empty, zero and invalid values disable checkpoints.
+func ParseTaskCheckpointInterval(value string) time.Duration {
+	d, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+// taskCheckpointRef returns the ref holding the checkpoints of a task.
+func taskCheckpointRef(workflowID core.WorkflowID, taskID core.TaskID) string {
+	return taskCheckpointRefPrefix + string(workflowID) + "/" + string(taskID)
+}
+
+// checkpointRef returns the checkpoint ref of task in the current workflow.
+func (c *Context) checkpointRef(taskID core.TaskID) string {
+	c.RLock()
+	defer c.RUnlock()
+	return taskCheckpointRef(c.State.WorkflowID, taskID)
+}
+
+// checkpointGit returns the git client of workDir and its snapshot support,
+// or nils when the client cannot save snapshots.
+func (e *Executor) checkpointGit(workDir string) (core.GitClient, core.GitSnapshotter) {
+	if e.gitFactory == nil || workDir == "" {
+		return nil, nil
+	}
+	client, err := e.gitFactory.NewClient(workDir)
+	if err != nil {
+		return nil, nil
+	}
+	snapshotter, ok := client.(core.GitSnapshotter)
+	if !ok {
+		return nil, nil
+	}
+	return client, snapshotter
+}
+
+// resumeFromCheckpoint restores the worktree of task from its last
+// checkpoint and returns what the agent needs to continue, or nil when the
+// task has no usable checkpoint. A worktree that survived the interruption
+// with uncommitted changes is kept as is, since its changes are at least as
+// recent as the checkpoint.
+func (e *Executor) resumeFromCheckpoint(ctx context.Context, wctx *Context, task *core.Task, taskState *core.TaskState, workDir string) *TaskResumeParams {
+	client, snapshotter := e.checkpointGit(workDir)
+	if snapshotter == nil {
+		return nil
+	}
+	ref := wctx.checkpointRef(task.ID)
+	checkpoint, err := client.RevParse(ctx, ref)
+	if err != nil || checkpoint == "" {
+		return nil
+	}
+
+	// Checkpoints made on another base would revert the changes the task now
+	// starts from.
+	if ok, err := client.IsAncestor(ctx, "HEAD", checkpoint); err != nil || !ok {
+		wctx.Logger.Warn("discarding task checkpoint made on another base",
+			"task_id", task.ID,
+			"checkpoint", checkpoint,
+			"error", err,
+		)
+		if err := snapshotter.DeleteRef(ctx, ref); err != nil {
+			wctx.Logger.Warn("failed to delete task checkpoint", "task_id", task.ID, "error", err)
+		}
+		return nil
+	}
+
+	dirty, err := client.HasUncommittedChanges(ctx)
+	if err != nil {
+		wctx.Logger.Warn("failed to inspect task worktree, not resuming from checkpoint",
+			"task_id", task.ID,
+			"error", err,
+		)
+		return nil
+	}
+	if !dirty {
+		if err := snapshotter.RestoreWorkingTree(ctx, checkpoint); err != nil {
+			wctx.Logger.Warn("failed to restore task checkpoint, starting from scratch",
+				"task_id", task.ID,
+				"checkpoint", checkpoint,
+				"error", err,
+			)
+			return nil
+		}
+	}
+
+	diff, err := client.Diff(ctx, "HEAD", checkpoint)
+	if err != nil {
+		wctx.Logger.Warn("failed to diff task checkpoint", "task_id", task.ID, "error", err)
+	}
+
+	wctx.RLock()
+	previousOutput := taskState.Output
+	wctx.RUnlock()
+
+	wctx.Logger.Info("resuming task from checkpoint",
+		"task_id", task.ID,
+		"checkpoint", checkpoint,
+		"restored", !dirty,
+	)
+	if wctx.Output != nil {
+		wctx.Output.Log("info", "executor", fmt.Sprintf("Resuming task %s from checkpoint %s", task.Name, shortCommit(checkpoint)))
+	}
+
+	return &TaskResumeParams{
+		Checkpoint:     checkpoint,
+		Diff:           truncateHead(diff, maxResumeDiffSize),
+		PreviousOutput: truncateTail(previousOutput, maxResumeOutputSize),
+	}
+}
+
+// startTaskCheckpoints checkpoints the worktree of task at the configured
+// interval. The returned function stops the checkpoints after a last one; it
+// may be called more than once.
+func (e *Executor) startTaskCheckpoints(ctx context.Context, wctx *Context, task *core.Task, taskState *core.TaskState, workDir string) (stop func()) {
+	interval := wctx.Config.TaskCheckpointInterval
+	if interval <= 0 || wctx.Config.DryRun {
+		return func() {}
+	}
+	_, snapshotter := e.checkpointGit(workDir)
+	if snapshotter == nil {
+		return func() {}
+	}
+	ref := wctx.checkpointRef(task.ID)
+
+	tickCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-tickCtx.Done():
+				return
+			case <-ticker.C:
+				e.checkpointTask(tickCtx, wctx, task, taskState, snapshotter, ref)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			cancel()
+			<-done
+			// The last checkpoint also runs when the task was cancelled, so
+			// that its work survives the removal of the worktree.
+			e.checkpointTask(context.WithoutCancel(ctx), wctx, task, taskState, snapshotter, ref)
+		})
+	}
+}
+
+// checkpointTask commits the work in progress of task to ref and records the
+// checkpoint in the task state.
+func (e *Executor) checkpointTask(ctx context.Context, wctx *Context, task *core.Task, taskState *core.TaskState, snapshotter core.GitSnapshotter, ref string) {
+	commit, err := snapshotter.SnapshotWorkingTree(ctx, ref, fmt.Sprintf("quorum: work in progress on %s", task.ID))
+	if err != nil {
+		if ctx.Err() == nil {
+			wctx.Logger.Warn("failed to checkpoint task", "task_id", task.ID, "error", err)
+		}
+		return
+	}
+	if commit == "" {
+		return
+	}
+
+	wctx.Lock()
+	taskState.Resumable = true
+	taskState.ResumeHint = fmt.Sprintf("work in progress checkpointed at %s (%s)", shortCommit(commit), ref)
+	wctx.Unlock()
+
+	wctx.Logger.Info("task checkpointed",
+		"task_id", task.ID,
+		"checkpoint", commit,
+		"ref", ref,
+	)
+	if e.stateSaver != nil {
+		if err := e.stateSaver.Save(ctx, wctx.State); err != nil {
+			wctx.Logger.Warn("failed to save state after task checkpoint", "task_id", task.ID, "error", err)
+		}
+	}
+}
+
+// dropTaskCheckpoints deletes the checkpoints of a finalized task, whose
+// commit supersedes them.
+func (e *Executor) dropTaskCheckpoints(ctx context.Context, wctx *Context, task *core.Task, taskState *core.TaskState, workDir string) {
+	client, snapshotter := e.checkpointGit(workDir)
+	if snapshotter == nil {
+		return
+	}
+	ref := wctx.checkpointRef(task.ID)
+	if _, err := client.RevParse(ctx, ref); err != nil {
+		return
+	}
+	if err := snapshotter.DeleteRef(ctx, ref); err != nil {
+		wctx.Logger.Warn("failed to delete task checkpoints", "task_id", task.ID, "ref", ref, "error", err)
+		return
+	}
+
+	wctx.Lock()
+	taskState.ResumeHint = ""
+	wctx.Unlock()
+	wctx.Logger.Info("squashed task checkpoints", "task_id", task.ID, "ref", ref)
+}
+
+// recordPartialOutput keeps the output of a failed attempt, so that a later
+// run of the task can show it to the agent.
+func (e *Executor) recordPartialOutput(wctx *Context, taskState *core.TaskState, result *core.ExecuteResult) {
+	if result == nil || strings.TrimSpace(result.Output) == "" {
+		return
+	}
+	wctx.Lock()
+	taskState.Output = truncateTail(result.Output, core.MaxInlineOutputSize)
+	wctx.Unlock()
+}
+
+// shortCommit abbreviates a commit SHA.
+func shortCommit(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
+
+// truncateHead keeps the first maxLen bytes of s.
+func truncateHead(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen] + "\n... [truncated]"
+}
+
+// truncateTail keeps the last maxLen bytes of s.
+func truncateTail(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return "[truncated] ...\n" + s[len(s)-maxLen:]
+}
@@ -0,0 +1,147 @@
+package workflow
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/git"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/testutil"
+)
+
+func newCheckpointTestContext(taskState *core.TaskState, interval time.Duration) *Context {
+	return &Context{
+		State: &core.WorkflowState{
+			WorkflowDefinition: core.WorkflowDefinition{WorkflowID: "wf-test"},
+			WorkflowRun: core.WorkflowRun{
+				Tasks: map[core.TaskID]*core.TaskState{taskState.ID: taskState},
+			},
+		},
+		Logger: logging.NewNop(),
+		Config: &Config{TaskCheckpointInterval: interval},
+	}
+}
+
+func TestParseTaskCheckpointInterval(t *testing.T) {
+	t.Parallel()
+	tests := map[string]time.Duration{"5m": 5 * time.Minute, "0": 0, "": 0, "soon": 0, "-1m": 0}
+	for value, want := range tests {
+		if got := ParseTaskCheckpointInterval(value); got != want {
+			t.Errorf("ParseTaskCheckpointInterval(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestExecutor_TaskCheckpointsResumeAndSquash(t *testing.T) {
+	t.Parallel()
+	repo := testutil.NewGitRepo(t)
+	repo.WriteFile("main.go", "package main\n")
+	repo.Commit("Initial commit")
+
+	executor := NewExecutor(nil, nil, nil).WithGitFactory(git.NewClientFactory())
+	task := &core.Task{ID: "task-1", Name: "Long task"}
+	taskState := &core.TaskState{ID: "task-1", Status: core.TaskStatusRunning}
+	wctx := newCheckpointTestContext(taskState, 10*time.Millisecond)
+	ctx := context.Background()
+	ref := taskCheckpointRef("wf-test", "task-1")
+
+	// The agent writes files while checkpoints run.
+	stop := executor.startTaskCheckpoints(ctx, wctx, task, taskState, repo.Path)
+	repo.WriteFile("handler.go", "package main\n\nfunc Handle() {}\n")
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := repo.Run("rev-parse", "--verify", ref); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("no checkpoint was taken")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	repo.WriteFile("handler_test.go", "package main\n")
+	stop()
+	stop()
+
+	if !taskState.Resumable || !strings.Contains(taskState.ResumeHint, ref) {
+		t.Errorf("task state after checkpoints = resumable %v, hint %q", taskState.Resumable, taskState.ResumeHint)
+	}
+	if branch := repo.CurrentBranch(); branch != "main" {
+		t.Errorf("checkpoints moved the branch to %q", branch)
+	}
+	if log, _ := repo.Run("log", "--oneline", "main"); strings.Count(log, "\n") != 0 {
+		t.Errorf("checkpoints committed to the branch: %s", log)
+	}
+
+	// The process dies and the task runs again in a fresh worktree.
+	if _, err := repo.Run("clean", "-fd"); err != nil {
+		t.Fatalf("git clean: %v", err)
+	}
+	taskState.Output = "Wrote the handler, tests next"
+	resume := executor.resumeFromCheckpoint(ctx, wctx, task, taskState, repo.Path)
+	if resume == nil {
+		t.Fatal("resumeFromCheckpoint() = nil, want the last checkpoint")
+	}
+	for _, name := range []string{"handler.go", "handler_test.go"} {
+		if _, err := os.Stat(filepath.Join(repo.Path, name)); err != nil {
+			t.Errorf("%s not restored: %v", name, err)
+		}
+	}
+	if !strings.Contains(resume.Diff, "func Handle()") {
+		t.Errorf("resume diff = %q, want the checkpointed changes", resume.Diff)
+	}
+	if resume.PreviousOutput != taskState.Output {
+		t.Errorf("resume previous output = %q, want %q", resume.PreviousOutput, taskState.Output)
+	}
+
+	// Finalization commits the task once and drops the checkpoints.
+	repo.Commit("Task 1")
+	executor.dropTaskCheckpoints(ctx, wctx, task, taskState, repo.Path)
+	if _, err := repo.Run("rev-parse", "--verify", ref); err == nil {
+		t.Error("checkpoint ref still exists after finalization")
+	}
+	if taskState.ResumeHint != "" {
+		t.Errorf("resume hint after finalization = %q, want empty", taskState.ResumeHint)
+	}
+}
+
+func TestExecutor_ResumeDiscardsCheckpointOnAnotherBase(t *testing.T) {
+	t.Parallel()
+	repo := testutil.NewGitRepo(t)
+	repo.WriteFile("main.go", "package main\n")
+	repo.Commit("Initial commit")
+
+	executor := NewExecutor(nil, nil, nil).WithGitFactory(git.NewClientFactory())
+	task := &core.Task{ID: "task-1", Name: "Long task"}
+	taskState := &core.TaskState{ID: "task-1", Status: core.TaskStatusRunning}
+	wctx := newCheckpointTestContext(taskState, time.Hour)
+	ctx := context.Background()
+	ref := taskCheckpointRef("wf-test", "task-1")
+
+	repo.WriteFile("handler.go", "package main\n")
+	executor.startTaskCheckpoints(ctx, wctx, task, taskState, repo.Path)()
+	if _, err := repo.Run("rev-parse", "--verify", ref); err != nil {
+		t.Fatalf("stopping the checkpoints took no last checkpoint: %v", err)
+	}
+
+	// The task now starts from a base the checkpoint does not descend from.
+	if _, err := repo.Run("clean", "-fd"); err != nil {
+		t.Fatalf("git clean: %v", err)
+	}
+	repo.WriteFile("other.go", "package main\n")
+	repo.Commit("Another base")
+
+	if resume := executor.resumeFromCheckpoint(ctx, wctx, task, taskState, repo.Path); resume != nil {
+		t.Errorf("resumeFromCheckpoint() = %+v, want nil", resume)
+	}
+	if _, err := repo.Run("rev-parse", "--verify", ref); err == nil {
+		t.Error("checkpoint on another base was not discarded")
+	}
+	if _, err := os.Stat(filepath.Join(repo.Path, "handler.go")); !os.IsNotExist(err) {
+		t.Errorf("checkpoint on another base was restored: %v", err)
+	}
+}
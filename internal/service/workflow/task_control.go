@@ -0,0 +1,311 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/control"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// Skip and retry requests for the tasks of a running execute phase.
+//
+// The control plane queues the requests of the TUI, the web UI and the API;
+// the executor reads them while it runs. A skipped pending task is skipped at
+// once and a skipped running task is cancelled. A retry request re-queues its
+// task when the task has failed by the end of its batch, so that the phase
+// goes on instead of failing.
+
+// errTaskSkipRequested is the cancellation cause of a running task skipped on
+// request.
+var errTaskSkipRequested = errors.New("task skipped on request")
+
+// taskRunControl tracks the running tasks and the retry requests of an execute
+// phase.
+type taskRunControl struct {
+	mu      sync.Mutex
+	running map[core.TaskID]context.CancelCauseFunc
+	retries map[core.TaskID]control.RetryRequest
+}
+
+func newTaskRunControl() *taskRunControl {
+	return &taskRunControl{
+		running: make(map[core.TaskID]context.CancelCauseFunc),
+		retries: make(map[core.TaskID]control.RetryRequest),
+	}
+}
+
+// start registers the context of a task about to run.
+func (rc *taskRunControl) start(ctx context.Context, taskID core.TaskID) (context.Context, context.CancelFunc) {
+	taskCtx, cancel := context.WithCancelCause(ctx)
+	rc.mu.Lock()
+	rc.running[taskID] = cancel
+	rc.mu.Unlock()
+	return taskCtx, func() {
+		rc.mu.Lock()
+		delete(rc.running, taskID)
+		rc.mu.Unlock()
+		cancel(context.Canceled)
+	}
+}
+
+// watchTaskControl serves the skip and retry requests of the control plane
+// until ctx is done.
+func (e *Executor) watchTaskControl(ctx context.Context, wctx *Context, rc *taskRunControl) {
+	if wctx.Control == nil {
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case req := <-wctx.Control.GetSkipQueue():
+			e.handleSkipRequest(ctx, wctx, rc, req)
+		case req := <-wctx.Control.GetRetryQueue():
+			e.handleRetryRequest(wctx, rc, req)
+		}
+	}
+}
+
+func (e *Executor) handleSkipRequest(ctx context.Context, wctx *Context, rc *taskRunControl, req control.SkipRequest) {
+	reason := req.Reason
+	if reason == "" {
+		reason = "skipped on request"
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	wctx.RLock()
+	taskState := wctx.State.Tasks[req.TaskID]
+	var status core.TaskStatus
+	if taskState != nil {
+		status = taskState.Status
+	}
+	wctx.RUnlock()
+
+	// Scheduled and running tasks stop through their context.
+	cancel, scheduled := rc.running[req.TaskID]
+	if scheduled && (status == core.TaskStatusPending || status == core.TaskStatusRunning) {
+		wctx.Logger.Info("skipping running task on request", "task_id", req.TaskID, "reason", reason)
+		cancel(fmt.Errorf("%w: %s", errTaskSkipRequested, reason))
+		return
+	}
+
+	switch status {
+	case core.TaskStatusPending, core.TaskStatusFailed:
+		e.markTaskSkipped(ctx, wctx, taskState, reason)
+	case "":
+		wctx.Logger.Warn("skip requested for unknown task", "task_id", req.TaskID)
+	default:
+		wctx.Logger.Info("skip request ignored: task already finished", "task_id", req.TaskID, "status", status)
+	}
+}
+
+func (e *Executor) handleRetryRequest(wctx *Context, rc *taskRunControl, req control.RetryRequest) {
+	wctx.RLock()
+	taskState := wctx.State.Tasks[req.TaskID]
+	var status core.TaskStatus
+	if taskState != nil {
+		status = taskState.Status
+	}
+	wctx.RUnlock()
+
+	switch status {
+	case core.TaskStatusFailed, core.TaskStatusRunning:
+		rc.mu.Lock()
+		rc.retries[req.TaskID] = req
+		rc.mu.Unlock()
+		wctx.Logger.Info("retry requested", "task_id", req.TaskID, "agent", req.Agent, "model", req.Model)
+		if wctx.Output != nil {
+			wctx.Output.Log("info", "executor", fmt.Sprintf("Retry of task %s requested", req.TaskID))
+		}
+	case "":
+		wctx.Logger.Warn("retry requested for unknown task", "task_id", req.TaskID)
+	default:
+		wctx.Logger.Info("retry request ignored: task has not failed", "task_id", req.TaskID, "status", status)
+	}
+}
+
+// skippedOnRequest marks a task skipped when its context was cancelled by a
+// skip request, and reports whether it was.
+func (e *Executor) skippedOnRequest(ctx context.Context, wctx *Context, taskID core.TaskID) bool {
+	cause := context.Cause(ctx)
+	if !errors.Is(cause, errTaskSkipRequested) {
+		return false
+	}
+	wctx.RLock()
+	taskState := wctx.State.Tasks[taskID]
+	var status core.TaskStatus
+	if taskState != nil {
+		status = taskState.Status
+	}
+	wctx.RUnlock()
+	switch status {
+	case "", core.TaskStatusCompleted:
+		return false
+	case core.TaskStatusSkipped:
+		return true
+	}
+	e.markTaskSkipped(context.WithoutCancel(ctx), wctx, taskState, cause.Error())
+	return true
+}
+
+// markTaskSkipped marks a task that is not running skipped and reports it.
+func (e *Executor) markTaskSkipped(ctx context.Context, wctx *Context, taskState *core.TaskState, reason string) {
+	now := time.Now()
+	wctx.Lock()
+	taskState.Status = core.TaskStatusSkipped
+	taskState.Error = reason
+	taskState.CompletedAt = &now
+	task := taskFromState(taskState)
+	wctx.Unlock()
+
+	wctx.Logger.Info("task skipped", "task_id", task.ID, "reason", reason)
+	if wctx.Output != nil {
+		wctx.Output.TaskSkipped(task, reason)
+	}
+	if e.stateSaver != nil {
+		if err := e.stateSaver.Save(ctx, wctx.State); err != nil {
+			wctx.Logger.Warn("failed to save state after skipping task", "task_id", task.ID, "error", err)
+		}
+	}
+}
+
+// skipDependents skips the pending tasks depending on a skipped task when
+// phases.execute.skip_dependents is "skip".
+func (e *Executor) skipDependents(ctx context.Context, wctx *Context) {
+	if wctx.Config.SkipDependents != config.SkipDependentsSkip {
+		return
+	}
+	for {
+		var taskState *core.TaskState
+		var reason string
+		wctx.RLock()
+	find:
+		for _, ts := range wctx.State.Tasks {
+			if ts.Status != core.TaskStatusPending {
+				continue
+			}
+			for _, dep := range ts.Dependencies {
+				if depState := wctx.State.Tasks[dep]; depState != nil && depState.Status == core.TaskStatusSkipped {
+					taskState, reason = ts, fmt.Sprintf("dependency %s was skipped", dep)
+					break find
+				}
+			}
+		}
+		wctx.RUnlock()
+		if taskState == nil {
+			return
+		}
+		e.markTaskSkipped(ctx, wctx, taskState, reason)
+	}
+}
+
+// applyRetries re-queues the failed tasks of a batch that have a retry
+// request, with the requested agent, model and instructions. Requests for the
+// other tasks of the batch are dropped. It returns the re-queued tasks.
+func (e *Executor) applyRetries(ctx context.Context, wctx *Context, rc *taskRunControl, batch []*core.Task) map[core.TaskID]bool {
+	// Requests still queued were sent before the batch ended.
+	if wctx.Control != nil {
+	drain:
+		for {
+			select {
+			case req := <-wctx.Control.GetRetryQueue():
+				e.handleRetryRequest(wctx, rc, req)
+			default:
+				break drain
+			}
+		}
+	}
+
+	rc.mu.Lock()
+	requests := make(map[core.TaskID]control.RetryRequest, len(batch))
+	for _, task := range batch {
+		if req, ok := rc.retries[task.ID]; ok {
+			requests[task.ID] = req
+			delete(rc.retries, task.ID)
+		}
+	}
+	rc.mu.Unlock()
+
+	retried := make(map[core.TaskID]bool)
+	for _, task := range batch {
+		req, ok := requests[task.ID]
+		if !ok {
+			continue
+		}
+		wctx.Lock()
+		taskState := wctx.State.Tasks[task.ID]
+		if taskState == nil || taskState.Status != core.TaskStatusFailed {
+			wctx.Unlock()
+			continue
+		}
+		if req.Agent != "" {
+			task.CLI, taskState.CLI = req.Agent, req.Agent
+		}
+		if req.Model != "" {
+			task.Model, taskState.Model = req.Model, req.Model
+		}
+		if req.Instructions != "" {
+			task.RetryInstructions, taskState.RetryInstructions = req.Instructions, req.Instructions
+		}
+		previousErr := taskState.Error
+		taskState.Status = core.TaskStatusPending
+		taskState.Error = ""
+		taskState.StartedAt = nil
+		taskState.CompletedAt = nil
+		wctx.Unlock()
+
+		retried[task.ID] = true
+		wctx.Logger.Info("retrying failed task on request",
+			"task_id", task.ID,
+			"agent", task.CLI,
+			"model", task.Model,
+			"previous_error", previousErr,
+		)
+		if wctx.Output != nil {
+			wctx.Output.Log("info", "executor", fmt.Sprintf("Retrying task %s on request", task.Name))
+		}
+	}
+	if len(retried) > 0 && e.stateSaver != nil {
+		if err := e.stateSaver.Save(ctx, wctx.State); err != nil {
+			wctx.Logger.Warn("failed to save state after re-queuing tasks", "error", err)
+		}
+	}
+	return retried
+}
+
+// finishedTasks returns the completed and skipped tasks.
+func finishedTasks(wctx *Context) map[core.TaskID]bool {
+	wctx.RLock()
+	defer wctx.RUnlock()
+	finished := make(map[core.TaskID]bool, len(wctx.State.Tasks))
+	for id, ts := range wctx.State.Tasks {
+		if ts.Status == core.TaskStatusCompleted || ts.Status == core.TaskStatusSkipped {
+			finished[id] = true
+		}
+	}
+	return finished
+}
+
+// taskFromState returns the task of a task state.
+func taskFromState(ts *core.TaskState) *core.Task {
+	return &core.Task{
+		ID:                ts.ID,
+		Phase:             ts.Phase,
+		Name:              ts.Name,
+		Description:       ts.Description,
+		Status:            ts.Status,
+		CLI:               ts.CLI,
+		Model:             ts.Model,
+		Dependencies:      ts.Dependencies,
+		Repository:        ts.Repository,
+		Error:             ts.Error,
+		RetryInstructions: ts.RetryInstructions,
+	}
+}
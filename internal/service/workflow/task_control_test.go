@@ -0,0 +1,260 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/control"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+)
+
+// gateAgent runs like its mockAgent once released, and stops with its context
+// until then.
+type gateAgent struct {
+	mockAgent
+	once    sync.Once
+	started chan struct{}
+	release chan struct{}
+}
+
+func newGateAgent(result *core.ExecuteResult, err error) *gateAgent {
+	return &gateAgent{
+		mockAgent: mockAgent{result: result, err: err},
+		started:   make(chan struct{}),
+		release:   make(chan struct{}),
+	}
+}
+
+func (a *gateAgent) Execute(ctx context.Context, opts core.ExecuteOptions) (*core.ExecuteResult, error) {
+	a.once.Do(func() { close(a.started) })
+	select {
+	case <-a.release:
+		return a.mockAgent.Execute(ctx, opts)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// noFallbackRegistry resolves every registered agent but enables none for
+// fallback, so that a task only runs with its own agent.
+type noFallbackRegistry struct {
+	mockAgentRegistry
+}
+
+func (r *noFallbackRegistry) ListEnabledForPhase(_ string) []string {
+	return nil
+}
+
+// retryLogRecorder signals the retry requests the executor takes up.
+type retryLogRecorder struct {
+	NopOutputNotifier
+	requested chan string
+}
+
+func (r *retryLogRecorder) Log(_, _, message string) {
+	if strings.HasPrefix(message, "Retry of task") {
+		r.requested <- message
+	}
+}
+
+// newTaskControlTestRun returns an executor and a context running tasks a, b
+// and c, where b depends on a and c depends on b.
+func newTaskControlTestRun(agents map[string]core.Agent, skipDependents string) (*Executor, *Context) {
+	dag := &mockDAGBuilder{}
+	tasks := map[core.TaskID]*core.TaskState{}
+	var previous core.TaskID
+	for _, id := range []core.TaskID{"a", "b", "c"} {
+		task := &core.Task{ID: id, Name: "Task " + string(id), CLI: "claude"}
+		state := &core.TaskState{ID: id, Name: task.Name, CLI: "claude", Status: core.TaskStatusPending}
+		if previous != "" {
+			task.Dependencies = []core.TaskID{previous}
+			state.Dependencies = task.Dependencies
+			_ = dag.AddDependency(id, previous)
+		}
+		if _, ok := agents[string(id)]; ok {
+			task.CLI, state.CLI = string(id), string(id)
+		}
+		_ = dag.AddTask(task)
+		tasks[id] = state
+		previous = id
+	}
+	_, _ = dag.Build()
+
+	registry := &noFallbackRegistry{}
+	_ = registry.Register("claude", &mockAgent{result: &core.ExecuteResult{Output: "success", TokensIn: 100, TokensOut: 200}})
+	for name, agent := range agents {
+		_ = registry.Register(name, agent)
+	}
+
+	wctx := &Context{
+		State: &core.WorkflowState{
+			WorkflowDefinition: core.WorkflowDefinition{WorkflowID: "wf-test"},
+			WorkflowRun: core.WorkflowRun{
+				CurrentPhase: core.PhaseExecute,
+				Tasks:        tasks,
+				TaskOrder:    []core.TaskID{"a", "b", "c"},
+				Checkpoints:  []core.Checkpoint{},
+				Metrics:      &core.StateMetrics{},
+			},
+		},
+		Agents:     registry,
+		Prompts:    &mockPromptRenderer{},
+		Checkpoint: &mockCheckpointCreator{},
+		Retry:      &mockRetryExecutor{},
+		RateLimits: &mockRateLimiterGetter{},
+		Logger:     logging.NewNop(),
+		Output:     NopOutputNotifier{},
+		Control:    control.New(),
+		Config: &Config{
+			DefaultAgent:   "claude",
+			WorktreeMode:   "disabled",
+			PhaseTimeouts:  PhaseTimeouts{Execute: time.Minute},
+			SkipDependents: skipDependents,
+		},
+	}
+	return NewExecutor(dag, &mockStateSaver{}, nil), wctx
+}
+
+func runAsync(executor *Executor, wctx *Context) <-chan error {
+	done := make(chan error, 1)
+	go func() { done <- executor.Run(context.Background(), wctx) }()
+	return done
+}
+
+func waitForRun(t *testing.T, done <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() did not return")
+		return nil
+	}
+}
+
+func waitForTaskStatus(t *testing.T, wctx *Context, id core.TaskID, want core.TaskStatus) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		wctx.RLock()
+		status := wctx.State.Tasks[id].Status
+		wctx.RUnlock()
+		if status == want {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("task %s never became %s", id, want)
+}
+
+func taskStatuses(wctx *Context) map[core.TaskID]core.TaskStatus {
+	wctx.RLock()
+	defer wctx.RUnlock()
+	statuses := make(map[core.TaskID]core.TaskStatus, len(wctx.State.Tasks))
+	for id, ts := range wctx.State.Tasks {
+		statuses[id] = ts.Status
+	}
+	return statuses
+}
+
+func TestExecutor_Run_SkipPendingTask(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		policy string
+		wantC  core.TaskStatus
+	}{
+		{config.SkipDependentsRun, core.TaskStatusCompleted},
+		{config.SkipDependentsSkip, core.TaskStatusSkipped},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			t.Parallel()
+			gate := newGateAgent(&core.ExecuteResult{Output: "success", TokensIn: 100, TokensOut: 200}, nil)
+			executor, wctx := newTaskControlTestRun(map[string]core.Agent{"a": gate}, tt.policy)
+
+			done := runAsync(executor, wctx)
+			<-gate.started
+			wctx.Control.SkipTask("b", "covered by task a")
+			waitForTaskStatus(t, wctx, "b", core.TaskStatusSkipped)
+			close(gate.release)
+
+			if err := waitForRun(t, done); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			statuses := taskStatuses(wctx)
+			if statuses["a"] != core.TaskStatusCompleted || statuses["c"] != tt.wantC {
+				t.Errorf("task statuses = %v, want a completed and c %s", statuses, tt.wantC)
+			}
+			if reason := wctx.State.Tasks["b"].Error; reason != "covered by task a" {
+				t.Errorf("skip reason = %q", reason)
+			}
+		})
+	}
+}
+
+func TestExecutor_Run_SkipRunningTask(t *testing.T) {
+	t.Parallel()
+	gate := newGateAgent(nil, nil)
+	executor, wctx := newTaskControlTestRun(map[string]core.Agent{"a": gate}, config.SkipDependentsSkip)
+
+	done := runAsync(executor, wctx)
+	<-gate.started
+	wctx.Control.SkipTask("a", "")
+
+	if err := waitForRun(t, done); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	for id, status := range taskStatuses(wctx) {
+		if status != core.TaskStatusSkipped {
+			t.Errorf("task %s status = %s, want skipped", id, status)
+		}
+	}
+}
+
+func TestExecutor_Run_RetryFailedTask(t *testing.T) {
+	t.Parallel()
+	gate := newGateAgent(nil, errors.New("tests failed"))
+	executor, wctx := newTaskControlTestRun(map[string]core.Agent{"a": gate}, config.SkipDependentsRun)
+
+	recorder := &retryLogRecorder{requested: make(chan string, 1)}
+	wctx.Output = recorder
+
+	done := runAsync(executor, wctx)
+	<-gate.started
+	wctx.Control.RequestRetry(control.RetryRequest{TaskID: "a", Agent: "claude", Instructions: "Run the tests first."})
+	<-recorder.requested
+	close(gate.release)
+
+	if err := waitForRun(t, done); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	for id, status := range taskStatuses(wctx) {
+		if status != core.TaskStatusCompleted {
+			t.Errorf("task %s status = %s, want completed", id, status)
+		}
+	}
+	taskState := wctx.State.Tasks["a"]
+	if taskState.CLI != "claude" || taskState.RetryInstructions != "Run the tests first." {
+		t.Errorf("retried task agent = %q, instructions = %q", taskState.CLI, taskState.RetryInstructions)
+	}
+}
+
+func TestExecutor_Run_FailedTaskWithoutRetry(t *testing.T) {
+	t.Parallel()
+	gate := newGateAgent(nil, errors.New("tests failed"))
+	executor, wctx := newTaskControlTestRun(map[string]core.Agent{"a": gate}, config.SkipDependentsRun)
+	close(gate.release)
+
+	if err := executor.Run(context.Background(), wctx); err == nil {
+		t.Fatal("Run() error = nil, want the task failure")
+	}
+	if status := wctx.State.Tasks["a"].Status; status != core.TaskStatusFailed {
+		t.Errorf("task a status = %s, want failed", status)
+	}
+}
@@ -0,0 +1,264 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/control"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// Questions asked by execute tasks.
+//
+// An agent that cannot finish a task without a human decision ends its output
+// with an input request block, as the task-execute prompt describes. The task
+// then waits on the control plane for the answer, which the TUI, the web UI or
+// the API provide, and runs again with it in the same working directory. A
+// question nobody answers in time follows the configured policy: the task
+// continues with the answer the agent proposed, is skipped, or fails.
+
+const (
+	inputRequestStart = "<<<QUORUM_INPUT_REQUEST"
+	inputRequestEnd   = "QUORUM_INPUT_REQUEST>>>"
+	// maxTaskInputRequests bounds the questions asked by one execution of a
+	// task.
+	maxTaskInputRequests = 3
+)
+
+var (
+	// errTaskInputUnanswered fails a task whose question went unanswered.
+	errTaskInputUnanswered = errors.New("task question went unanswered")
+	// errTaskInputSkipped skips a task whose question went unanswered.
+	errTaskInputSkipped = errors.New("task skipped, its question went unanswered")
+)
+
+// TaskInputRequest is a question the agent of a task asked.
+type TaskInputRequest struct {
+	Question string
+	Context  string
+	Options  []string
+	Default  string // Answer proposed by the agent, if any
+}
+
+// taskInputNotifier is implemented by the outputs that show task questions.
+type taskInputNotifier interface {
+	TaskInputRequested(task *core.Task, req control.InputRequest)
+	TaskInputResolved(task *core.Task, requestID, answer string, cancelled bool)
+}
+
+// NewTaskInputConfig returns the task input settings of phases.execute.input.
+// Empty and invalid timeouts wait without limit.
+func NewTaskInputConfig(cfg config.ExecuteInputConfig) TaskInputConfig {
+	timeout, err := time.ParseDuration(strings.TrimSpace(cfg.Timeout))
+	if err != nil || timeout < 0 {
+		timeout = 0
+	}
+	return TaskInputConfig{Timeout: timeout, OnTimeout: cfg.OnTimeout}
+}
+
+// ParseTaskInputRequest returns the input request block ending an agent's
+// output, or nil when the output asks nothing.
+func ParseTaskInputRequest(output string) *TaskInputRequest {
+	start := strings.LastIndex(output, inputRequestStart)
+	if start < 0 {
+		return nil
+	}
+	body := output[start+len(inputRequestStart):]
+	end := strings.Index(body, inputRequestEnd)
+	if end < 0 {
+		return nil
+	}
+
+	req := &TaskInputRequest{}
+	var field *string
+	for _, line := range strings.Split(body[:end], "\n") {
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			known := true
+			switch strings.ToLower(strings.TrimSpace(key)) {
+			case "question":
+				field = &req.Question
+			case "context":
+				field = &req.Context
+			case "default":
+				field = &req.Default
+			case "options":
+				field = nil
+				for _, option := range strings.Split(value, "|") {
+					if option = strings.TrimSpace(option); option != "" {
+						req.Options = append(req.Options, option)
+					}
+				}
+				continue
+			default:
+				known = false
+			}
+			if known {
+				*field = strings.TrimSpace(value)
+				continue
+			}
+		}
+		// Other lines continue the previous field.
+		if line = strings.TrimSpace(line); line != "" && field != nil {
+			*field = strings.TrimSpace(*field + "\n" + line)
+		}
+	}
+	if req.Question == "" {
+		return nil
+	}
+	return req
+}
+
+// executeWithAnswers runs task on agent like executeWithRetry. An agent that
+// ends with a question waits for the answer and runs again with it, up to
+// maxTaskInputRequests times. The returned result counts the tokens of every
+// run.
+func (e *Executor) executeWithAnswers(ctx context.Context, wctx *Context, agent core.Agent, agentName string, task *core.Task, params TaskExecuteParams, prompt, model, workDir string, execStartTime time.Time) (result *core.ExecuteResult, retryCount int, durationMS int64, err error) {
+	var tokensIn, tokensOut int
+	for asked := 0; ; asked++ {
+		result, retryCount, durationMS, err = e.executeWithRetry(ctx, wctx, agent, agentName, task, prompt, model, workDir, execStartTime)
+		if result != nil {
+			tokensIn += result.TokensIn
+			tokensOut += result.TokensOut
+			result.TokensIn, result.TokensOut = tokensIn, tokensOut
+		}
+		if err != nil {
+			return result, retryCount, durationMS, err
+		}
+		req := ParseTaskInputRequest(result.Output)
+		if req == nil {
+			return result, retryCount, durationMS, nil
+		}
+		if asked == maxTaskInputRequests {
+			return result, retryCount, durationMS, fmt.Errorf("agent asked more than %d questions", maxTaskInputRequests)
+		}
+
+		answer, defaulted, err := e.askTaskInput(ctx, wctx, task, agentName, req)
+		if err != nil {
+			return result, retryCount, durationMS, err
+		}
+		params.Answers = append(params.Answers, TaskInputAnswer{
+			Question:  req.Question,
+			Answer:    answer,
+			Defaulted: defaulted,
+			Output:    truncateTail(result.Output, maxResumeOutputSize),
+		})
+		if prompt, err = wctx.Prompts.RenderTaskExecute(params); err != nil {
+			return result, retryCount, durationMS, err
+		}
+		wctx.Logger.Info("continuing task with the answer to its question",
+			"task_id", task.ID,
+			"agent", agentName,
+			"defaulted", defaulted,
+		)
+	}
+}
+
+// askTaskInput asks a human the question of a task and waits for the answer.
+// A question that goes unanswered, or is dismissed, follows the timeout
+// policy: defaulted reports that the agent's proposed answer is returned,
+// otherwise the error fails or skips the task.
+func (e *Executor) askTaskInput(ctx context.Context, wctx *Context, task *core.Task, agentName string, req *TaskInputRequest) (answer string, defaulted bool, err error) {
+	inputReq := control.InputRequest{
+		ID:          fmt.Sprintf("%s-%d", task.ID, time.Now().UnixNano()),
+		Prompt:      req.Question,
+		Context:     req.Context,
+		Options:     req.Options,
+		Timeout:     wctx.Config.TaskInput.Timeout,
+		TaskID:      task.ID,
+		Default:     req.Default,
+		RequestedAt: time.Now(),
+	}
+
+	wctx.Logger.Info("task is waiting for input",
+		"task_id", task.ID,
+		"agent", agentName,
+		"request_id", inputReq.ID,
+		"question", req.Question,
+	)
+	notifier, _ := wctx.Output.(taskInputNotifier)
+	if wctx.Output != nil {
+		wctx.Output.Log("warn", "executor", fmt.Sprintf("Task %s needs input: %s", task.Name, req.Question))
+	}
+	if notifier != nil {
+		notifier.TaskInputRequested(task, inputReq)
+	}
+
+	// Without a control plane nobody can answer.
+	var resp control.InputResponse
+	askErr := errors.New("no control plane to ask through")
+	if wctx.Control != nil {
+		resp, askErr = wctx.Control.RequestUserInput(ctx, inputReq)
+	}
+	if ctx.Err() != nil {
+		return "", false, ctx.Err()
+	}
+	if askErr == nil && !resp.Cancelled {
+		// An empty answer accepts the proposed one.
+		answer = strings.TrimSpace(resp.Input)
+		if answer == "" {
+			answer = req.Default
+		}
+		if answer != "" {
+			if notifier != nil {
+				notifier.TaskInputResolved(task, inputReq.ID, answer, false)
+			}
+			return answer, false, nil
+		}
+	}
+
+	reason := "dismissed"
+	if askErr != nil {
+		reason = askErr.Error()
+	}
+	policy := wctx.Config.TaskInput.OnTimeout
+	if policy == "" {
+		policy = config.InputTimeoutDefault
+	}
+	wctx.Logger.Warn("task question went unanswered",
+		"task_id", task.ID,
+		"request_id", inputReq.ID,
+		"reason", reason,
+		"policy", policy,
+	)
+
+	switch {
+	case policy == config.InputTimeoutDefault && req.Default != "":
+		answer, defaulted = req.Default, true
+	case policy == config.InputTimeoutSkip:
+		err = fmt.Errorf("%w: %s", errTaskInputSkipped, req.Question)
+	default:
+		err = fmt.Errorf("%w (%s): %s", errTaskInputUnanswered, reason, req.Question)
+	}
+	if notifier != nil {
+		notifier.TaskInputResolved(task, inputReq.ID, answer, true)
+	}
+	if wctx.Output != nil {
+		if defaulted {
+			wctx.Output.Log("warn", "executor", fmt.Sprintf("Task %s: no answer, continuing with %q", task.Name, answer))
+		} else {
+			wctx.Output.Log("warn", "executor", fmt.Sprintf("Task %s: no answer to its question (%s)", task.Name, policy))
+		}
+	}
+	return answer, defaulted, err
+}
+
+// skipTask marks a task skipped.
+func (e *Executor) skipTask(ctx context.Context, wctx *Context, task *core.Task, taskState *core.TaskState, reason error) {
+	now := time.Now()
+	wctx.Lock()
+	taskState.Status = core.TaskStatusSkipped
+	taskState.Error = reason.Error()
+	taskState.CompletedAt = &now
+	wctx.Unlock()
+
+	wctx.Logger.Warn("task skipped", "task_id", task.ID, "reason", reason)
+	if e.stateSaver != nil {
+		if err := e.stateSaver.Save(ctx, wctx.State); err != nil {
+			wctx.Logger.Warn("failed to save state after skipping task", "task_id", task.ID, "error", err)
+		}
+	}
+}
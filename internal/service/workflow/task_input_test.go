@@ -0,0 +1,232 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/control"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+)
+
+const cacheQuestion = `Added the cache interface.
+
+<<<QUORUM_INPUT_REQUEST
+question: Which cache backend should the session store use?
+context: Both are in docker-compose.yml.
+Note: the task does not say.
+options: redis | memcached
+default: redis
+QUORUM_INPUT_REQUEST>>>`
+
+// scriptedAgent returns its outputs in turn, the last one from then on, and
+// records the prompts it gets.
+type scriptedAgent struct {
+	mockAgent
+	mu      sync.Mutex
+	outputs []string
+	prompts []string
+}
+
+func (a *scriptedAgent) Execute(_ context.Context, opts core.ExecuteOptions) (*core.ExecuteResult, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.prompts = append(a.prompts, opts.Prompt)
+	output := a.outputs[0]
+	if len(a.outputs) > 1 {
+		a.outputs = a.outputs[1:]
+	}
+	return &core.ExecuteResult{Output: output, TokensIn: 10, TokensOut: 200}, nil
+}
+
+// answersPromptRenderer renders the answers of a task into its prompt.
+type answersPromptRenderer struct {
+	mockPromptRenderer
+}
+
+func (r *answersPromptRenderer) RenderTaskExecute(params TaskExecuteParams) (string, error) {
+	prompt := "task prompt"
+	for _, answer := range params.Answers {
+		prompt += "\nQ: " + answer.Question + "\nA: " + answer.Answer
+	}
+	return prompt, nil
+}
+
+// taskInputRecorder records the task questions the executor reports.
+type taskInputRecorder struct {
+	NopOutputNotifier
+	mu        sync.Mutex
+	requested []control.InputRequest
+	resolved  []string
+}
+
+func (r *taskInputRecorder) TaskInputRequested(_ *core.Task, req control.InputRequest) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requested = append(r.requested, req)
+}
+
+func (r *taskInputRecorder) TaskInputResolved(_ *core.Task, _, answer string, cancelled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cancelled {
+		answer += " (unanswered)"
+	}
+	r.resolved = append(r.resolved, answer)
+}
+
+func newTaskInputTestContext(agent core.Agent, input TaskInputConfig) *Context {
+	return &Context{
+		State: &core.WorkflowState{
+			WorkflowRun: core.WorkflowRun{
+				Tasks: map[core.TaskID]*core.TaskState{
+					"task-1": {ID: "task-1", Status: core.TaskStatusPending},
+				},
+			},
+		},
+		Agents:     &mockAgentRegistry{agents: map[string]core.Agent{"mock": agent}},
+		Prompts:    &answersPromptRenderer{},
+		Checkpoint: &mockCheckpointCreator{},
+		Retry:      &mockRetryExecutor{},
+		RateLimits: &mockRateLimiterGetter{limiter: &mockRateLimiter{}},
+		Config:     &Config{DefaultAgent: "mock", TaskInput: input},
+		Logger:     logging.NewNop(),
+		Output:     &taskInputRecorder{},
+	}
+}
+
+func TestParseTaskInputRequest(t *testing.T) {
+	t.Parallel()
+	req := ParseTaskInputRequest(cacheQuestion)
+	if req == nil {
+		t.Fatal("ParseTaskInputRequest() = nil")
+	}
+	if req.Question != "Which cache backend should the session store use?" {
+		t.Errorf("Question = %q", req.Question)
+	}
+	if req.Context != "Both are in docker-compose.yml.\nNote: the task does not say." {
+		t.Errorf("Context = %q", req.Context)
+	}
+	if len(req.Options) != 2 || req.Options[0] != "redis" || req.Options[1] != "memcached" {
+		t.Errorf("Options = %q", req.Options)
+	}
+	if req.Default != "redis" {
+		t.Errorf("Default = %q", req.Default)
+	}
+
+	for name, output := range map[string]string{
+		"no block":     "Task completed successfully",
+		"unterminated": "<<<QUORUM_INPUT_REQUEST\nquestion: Which one?",
+		"no question":  "<<<QUORUM_INPUT_REQUEST\noptions: a | b\nQUORUM_INPUT_REQUEST>>>",
+	} {
+		if req := ParseTaskInputRequest(output); req != nil {
+			t.Errorf("%s: ParseTaskInputRequest() = %+v, want nil", name, req)
+		}
+	}
+}
+
+func TestNewTaskInputConfig(t *testing.T) {
+	t.Parallel()
+	cfg := NewTaskInputConfig(config.ExecuteInputConfig{Timeout: "30m", OnTimeout: config.InputTimeoutSkip})
+	if cfg.Timeout != 30*time.Minute || cfg.OnTimeout != config.InputTimeoutSkip {
+		t.Errorf("NewTaskInputConfig() = %+v", cfg)
+	}
+	if cfg := NewTaskInputConfig(config.ExecuteInputConfig{Timeout: "soon"}); cfg.Timeout != 0 {
+		t.Errorf("NewTaskInputConfig() with invalid timeout = %+v, want no limit", cfg)
+	}
+}
+
+func TestExecutor_TaskInputAnswered(t *testing.T) {
+	t.Parallel()
+	agent := &scriptedAgent{outputs: []string{cacheQuestion, "Task completed successfully\nFiles modified: 3"}}
+	wctx := newTaskInputTestContext(agent, TaskInputConfig{Timeout: time.Minute})
+	wctx.Control = control.New()
+	recorder := wctx.Output.(*taskInputRecorder)
+
+	go func() {
+		req := <-wctx.Control.InputRequestCh()
+		if req.TaskID != "task-1" || req.Default != "redis" || req.Timeout != time.Minute {
+			t.Errorf("input request = %+v", req)
+		}
+		_ = wctx.Control.ProvideUserInput(req.ID, "memcached")
+	}()
+
+	task := &core.Task{ID: "task-1", Name: "Session cache", CLI: "mock"}
+	if err := NewExecutor(nil, nil, nil).executeTask(context.Background(), wctx, task, false); err != nil {
+		t.Fatalf("executeTask() error = %v", err)
+	}
+
+	taskState := wctx.State.Tasks["task-1"]
+	if taskState.Status != core.TaskStatusCompleted {
+		t.Errorf("task status = %s, want completed", taskState.Status)
+	}
+	if taskState.TokensOut != 400 {
+		t.Errorf("task tokens out = %d, want both executions counted", taskState.TokensOut)
+	}
+	if len(agent.prompts) != 2 || !strings.Contains(agent.prompts[1], "A: memcached") {
+		t.Errorf("prompts = %q, want a follow-up with the answer", agent.prompts)
+	}
+	if len(recorder.requested) != 1 || len(recorder.resolved) != 1 || recorder.resolved[0] != "memcached" {
+		t.Errorf("notified requests %+v, resolutions %q", recorder.requested, recorder.resolved)
+	}
+}
+
+func TestExecutor_TaskInputTimeoutPolicies(t *testing.T) {
+	t.Parallel()
+	noDefault := strings.Replace(cacheQuestion, "default: redis\n", "", 1)
+	tests := []struct {
+		name       string
+		policy     string
+		output     string
+		wantStatus core.TaskStatus
+		wantErr    error
+	}{
+		{"default", config.InputTimeoutDefault, cacheQuestion, core.TaskStatusCompleted, nil},
+		{"default without proposal", config.InputTimeoutDefault, noDefault, core.TaskStatusFailed, errTaskInputUnanswered},
+		{"skip", config.InputTimeoutSkip, cacheQuestion, core.TaskStatusSkipped, nil},
+		{"fail", config.InputTimeoutFail, cacheQuestion, core.TaskStatusFailed, errTaskInputUnanswered},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			agent := &scriptedAgent{outputs: []string{tt.output, "Task completed successfully\nFiles modified: 3"}}
+			wctx := newTaskInputTestContext(agent, TaskInputConfig{Timeout: 10 * time.Millisecond, OnTimeout: tt.policy})
+			wctx.Control = control.New()
+
+			task := &core.Task{ID: "task-1", Name: "Session cache", CLI: "mock"}
+			err := NewExecutor(nil, nil, nil).executeTask(context.Background(), wctx, task, false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("executeTask() error = %v, want %v", err, tt.wantErr)
+			}
+			if status := wctx.State.Tasks["task-1"].Status; status != tt.wantStatus {
+				t.Errorf("task status = %s, want %s", status, tt.wantStatus)
+			}
+			if tt.wantStatus == core.TaskStatusCompleted && !strings.Contains(agent.prompts[len(agent.prompts)-1], "A: redis") {
+				t.Errorf("prompts = %q, want a follow-up with the proposed answer", agent.prompts)
+			}
+			if len(agent.prompts) > 1 && tt.wantStatus != core.TaskStatusCompleted {
+				t.Errorf("task ran %d times, want no follow-up", len(agent.prompts))
+			}
+		})
+	}
+}
+
+func TestExecutor_TaskInputLimit(t *testing.T) {
+	t.Parallel()
+	agent := &scriptedAgent{outputs: []string{cacheQuestion}}
+	wctx := newTaskInputTestContext(agent, TaskInputConfig{OnTimeout: config.InputTimeoutDefault})
+
+	task := &core.Task{ID: "task-1", Name: "Session cache", CLI: "mock"}
+	err := NewExecutor(nil, nil, nil).executeTask(context.Background(), wctx, task, false)
+	if err == nil || !strings.Contains(err.Error(), "questions") {
+		t.Errorf("executeTask() error = %v, want too many questions", err)
+	}
+	if len(agent.prompts) != maxTaskInputRequests+1 {
+		t.Errorf("task ran %d times, want %d", len(agent.prompts), maxTaskInputRequests+1)
+	}
+}
@@ -3,6 +3,7 @@ package workflow
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
@@ -20,6 +21,10 @@ type WorkflowIsolationFinalizer struct {
 	GitHub            core.GitHubClient
 	Logger            *logging.Logger
 	Output            OutputNotifier
+	// Babysitter runs the post-PR CI loop when Finalization.Babysit is enabled.
+	Babysitter *PRBabysitter
+	// Repositories opens the other repositories of multi-repository workflows.
+	Repositories *RepositorySet
 }
 
 func (f *WorkflowIsolationFinalizer) logWarn(msg string, args ...any) {
@@ -58,6 +63,11 @@ func (f *WorkflowIsolationFinalizer) Finalize(ctx context.Context, state *core.W
 	workflowID := string(state.WorkflowID)
 	workflowBranch := state.WorkflowBranch
 
+	if len(state.Repositories) > 0 {
+		f.finalizeRepositories(ctx, state, remote)
+		return
+	}
+
 	// If configured, push the workflow branch and open a single PR to the base branch.
 	// Task-level PRs are disabled under workflow isolation to avoid incorrect targets/noise.
 	var prMerged bool
@@ -97,8 +107,21 @@ func (f *WorkflowIsolationFinalizer) Finalize(ctx context.Context, state *core.W
 					if f.Output != nil {
 						f.Output.Log("info", "workflow", fmt.Sprintf("Workflow PR created: %s", pr.HTMLURL))
 					}
+					state.PRURL = pr.HTMLURL
+					state.PRNumber = pr.Number
+
+					checksPassed := true
+					if cfg.Babysit.Enabled {
+						if f.Babysitter == nil {
+							f.logWarn("workflow isolation: PR babysitting enabled but GitHub checks are unavailable")
+						} else {
+							checksPassed = f.Babysitter.Run(ctx, state, pr.Number).Status == core.PRBabysitPassed
+						}
+					}
 
-					if cfg.AutoMerge {
+					if cfg.AutoMerge && !checksPassed {
+						f.logWarn("workflow isolation: skipping auto-merge, PR checks are not passing", "pr_number", pr.Number)
+					} else if cfg.AutoMerge {
 						method := cfg.MergeStrategy
 						if method == "" {
 							method = "squash"
@@ -138,11 +161,265 @@ func (r *Runner) finalizeWorkflowIsolation(ctx context.Context, state *core.Work
 		GitHub:            r.github,
 		Logger:            r.logger,
 		Output:            r.output,
+		Repositories:      r.repositories,
+	}
+	if r.config.Finalization.Babysit.Enabled {
+		finalizer.Babysitter = r.newPRBabysitter()
 	}
 	finalizer.Finalize(ctx, state)
 }
 
+// newPRBabysitter builds the post-PR CI loop from the runner dependencies.
+// It returns nil when the GitHub client cannot read checks.
+func (r *Runner) newPRBabysitter() *PRBabysitter {
+	checks, ok := r.github.(core.PRChecksClient)
+	if !ok || r.gitFactory == nil {
+		return nil
+	}
+	cfg := r.config.Finalization.Babysit
+	agent := cfg.Agent
+	if agent == "" {
+		agent = r.config.DefaultAgent
+	}
+	remote := r.config.Finalization.Remote
+	if remote == "" {
+		remote = "origin"
+	}
+	var mergeStrategy string
+	if r.gitIsolation != nil {
+		mergeStrategy = r.gitIsolation.MergeStrategy
+	}
+	return &PRBabysitter{
+		Config:            cfg,
+		Checks:            checks,
+		Agents:            r.agents,
+		Prompts:           r.prompts,
+		WorkflowWorktrees: r.workflowWorktrees,
+		GitFactory:        r.gitFactory,
+		Git:               r.git,
+		Remote:            remote,
+		MergeStrategy:     mergeStrategy,
+		Agent:             agent,
+		Model:             ResolvePhaseModel(&Config{AgentPhaseModels: r.config.AgentPhaseModels}, agent, core.PhaseExecute, ""),
+		Timeout:           r.config.PhaseTimeouts.Execute,
+		DenyTools:         r.config.DenyTools,
+		Logger:            r.logger,
+		Output:            r.output,
+		Save:              r.state.Save,
+	}
+}
+
+// prTarget is a repository a multi-repository workflow opens a PR in.
+type prTarget struct {
+	name       string // "" for the workflow's own project
+	label      string
+	branch     string
+	baseBranch string
+	git        core.GitClient
+	github     core.GitHubClient
+	worktrees  core.WorkflowWorktreeManager
+
+	pushed bool
+	pr     *core.PullRequest
+}
+
+// remoteBranchDeleter is implemented by git clients that can delete remote
+// branches.
+type remoteBranchDeleter interface {
+	DeleteRemoteBranch(ctx context.Context, remote, branch string) error
+}
+
+// finalizeRepositories finalizes a multi-repository workflow: it pushes the
+// workflow branch of every repository with tasks and opens a PR in each, then
+// links the PRs to each other. If any repository fails, the PRs already
+// opened are closed and the pushed branches deleted from the remotes, so no
+// repository is left with a partial change; the local workflow branches are
+// kept so the workflow can be finalized again.
+func (f *WorkflowIsolationFinalizer) finalizeRepositories(ctx context.Context, state *core.WorkflowState, remote string) {
+	workflowID := string(state.WorkflowID)
+	targets, err := f.repositoryTargets(ctx, state)
+	if err == nil && f.Finalization.AutoPR {
+		err = f.openLinkedPRs(ctx, state, targets, remote)
+		if err != nil {
+			f.rollbackRepositories(ctx, targets, remote)
+		}
+	}
+	if err != nil {
+		f.logWarn("workflow isolation: multi-repository finalization failed", "workflow_id", workflowID, "error", err)
+		if f.Output != nil {
+			f.Output.Log("error", "workflow", fmt.Sprintf("Multi-repository finalization failed: %s", err))
+		}
+	}
+
+	for _, t := range targets {
+		if t.worktrees == nil {
+			continue
+		}
+		if cleanupErr := t.worktrees.CleanupWorkflow(ctx, workflowID, false); cleanupErr != nil {
+			f.logWarn("workflow isolation: cleanup failed", "workflow_id", workflowID, "repository", t.label, "error", cleanupErr)
+		}
+	}
+}
+
+// repositoryTargets returns the repositories of a workflow that tasks changed,
+// the workflow's own project first.
+func (f *WorkflowIsolationFinalizer) repositoryTargets(ctx context.Context, state *core.WorkflowState) ([]*prTarget, error) {
+	changed := make(map[string]bool)
+	for _, ts := range state.Tasks {
+		if ts != nil {
+			changed[ts.Repository] = true
+		}
+	}
+
+	var targets []*prTarget
+	if changed[""] {
+		label := "primary"
+		if f.Git != nil {
+			if root, err := f.Git.RepoRoot(ctx); err == nil {
+				label = filepath.Base(root)
+			}
+		}
+		targets = append(targets, &prTarget{
+			label:      label,
+			branch:     state.WorkflowBranch,
+			baseBranch: strings.TrimSpace(f.Finalization.PRBaseBranch),
+			git:        f.Git,
+			github:     f.GitHub,
+			worktrees:  f.WorkflowWorktrees,
+		})
+	}
+	for _, repo := range state.Repositories {
+		if !changed[repo.Name] {
+			continue
+		}
+		rs := state.RepositoryStates[repo.Name]
+		if rs == nil || rs.WorkflowBranch == "" {
+			return targets, fmt.Errorf("repository %s has no workflow branch", repo.Name)
+		}
+		rg, err := f.Repositories.Get(ctx, repo)
+		if err != nil {
+			return targets, err
+		}
+		targets = append(targets, &prTarget{
+			name:      repo.Name,
+			label:     repo.Name,
+			branch:    rs.WorkflowBranch,
+			git:       rg.Git,
+			github:    rg.GitHub,
+			worktrees: rg.Worktrees,
+		})
+	}
+	return targets, nil
+}
+
+// openLinkedPRs opens a PR in every target and links them to each other.
+func (f *WorkflowIsolationFinalizer) openLinkedPRs(ctx context.Context, state *core.WorkflowState, targets []*prTarget, remote string) error {
+	title := fmt.Sprintf("[quorum] Workflow %s", state.WorkflowID)
+	for _, t := range targets {
+		if t.git == nil {
+			return fmt.Errorf("repository %s: git client not configured", t.label)
+		}
+		if t.github == nil {
+			return fmt.Errorf("repository %s: GitHub client not configured", t.label)
+		}
+		if err := t.git.Push(ctx, remote, t.branch); err != nil {
+			return fmt.Errorf("repository %s: pushing %s: %w", t.label, t.branch, err)
+		}
+		t.pushed = true
+
+		if t.baseBranch == "" {
+			b, err := t.github.GetDefaultBranch(ctx)
+			if err != nil {
+				return fmt.Errorf("repository %s: detecting default branch: %w", t.label, err)
+			}
+			t.baseBranch = b
+		}
+		pr, err := t.github.CreatePR(ctx, core.CreatePROptions{
+			Title: title,
+			Body:  buildRepositoryPRBody(state, t.name, nil),
+			Head:  t.branch,
+			Base:  t.baseBranch,
+		})
+		if err != nil {
+			return fmt.Errorf("repository %s: creating PR: %w", t.label, err)
+		}
+		t.pr = pr
+		f.logInfo("workflow isolation: workflow PR created", "repository", t.label, "pr_number", pr.Number, "pr_url", pr.HTMLURL)
+		if f.Output != nil {
+			f.Output.Log("info", "workflow", fmt.Sprintf("Workflow PR created in %s: %s", t.label, pr.HTMLURL))
+		}
+	}
+
+	for _, t := range targets {
+		if len(targets) > 1 {
+			body := buildRepositoryPRBody(state, t.name, linkedPRs(targets, t))
+			if err := t.github.UpdatePR(ctx, t.pr.Number, core.UpdatePROptions{Body: &body}); err != nil {
+				f.logWarn("workflow isolation: failed to link workflow PR", "repository", t.label, "pr_number", t.pr.Number, "error", err)
+			}
+		}
+		if t.name == "" {
+			state.PRURL = t.pr.HTMLURL
+			state.PRNumber = t.pr.Number
+			continue
+		}
+		rs := state.RepositoryStates[t.name]
+		rs.PRURL = t.pr.HTMLURL
+		rs.PRNumber = t.pr.Number
+	}
+
+	if f.Finalization.AutoMerge || f.Finalization.Babysit.Enabled {
+		f.logInfo("workflow isolation: skipping auto-merge and PR babysitting for multi-repository workflow",
+			"workflow_id", state.WorkflowID)
+	}
+	return nil
+}
+
+// rollbackRepositories closes the PRs and deletes the remote branches of a
+// failed multi-repository finalization.
+func (f *WorkflowIsolationFinalizer) rollbackRepositories(ctx context.Context, targets []*prTarget, remote string) {
+	ctx = context.WithoutCancel(ctx)
+	for _, t := range targets {
+		if t.pr != nil {
+			if err := t.github.ClosePR(ctx, t.pr.Number); err != nil {
+				f.logWarn("workflow isolation: failed to close PR during rollback", "repository", t.label, "pr_number", t.pr.Number, "error", err)
+			}
+			t.pr = nil
+		}
+		if !t.pushed {
+			continue
+		}
+		deleter, ok := t.git.(remoteBranchDeleter)
+		if !ok {
+			f.logWarn("workflow isolation: cannot delete pushed branch during rollback", "repository", t.label, "branch", t.branch)
+			continue
+		}
+		if err := deleter.DeleteRemoteBranch(ctx, remote, t.branch); err != nil {
+			f.logWarn("workflow isolation: failed to delete pushed branch during rollback", "repository", t.label, "branch", t.branch, "error", err)
+			continue
+		}
+		t.pushed = false
+	}
+}
+
+// linkedPRs lists the PRs of the other repositories of a workflow.
+func linkedPRs(targets []*prTarget, self *prTarget) []string {
+	var links []string
+	for _, t := range targets {
+		if t != self && t.pr != nil {
+			links = append(links, fmt.Sprintf("%s: %s", t.label, t.pr.HTMLURL))
+		}
+	}
+	return links
+}
+
 func buildWorkflowPRBody(state *core.WorkflowState) string {
+	return buildRepositoryPRBody(state, "", nil)
+}
+
+// buildRepositoryPRBody builds the PR body for one repository of a workflow,
+// listing the tasks that target it and the linked PRs of the other
+// repositories.
+func buildRepositoryPRBody(state *core.WorkflowState, repository string, linked []string) string {
 	var b strings.Builder
 
 	b.WriteString("## Prompt\n\n")
@@ -153,7 +430,7 @@ func buildWorkflowPRBody(state *core.WorkflowState) string {
 		b.WriteString("## Tasks\n\n")
 		for _, id := range state.TaskOrder {
 			ts := state.Tasks[id]
-			if ts == nil {
+			if ts == nil || ts.Repository != repository {
 				continue
 			}
 			b.WriteString(fmt.Sprintf("- %s (`%s`)\n", ts.Name, ts.ID))
@@ -161,6 +438,32 @@ func buildWorkflowPRBody(state *core.WorkflowState) string {
 		b.WriteString("\n")
 	}
 
+	if issue := state.SourceIssue; issue != nil && repository == "" {
+		// Closing keyword so merging the PR closes the source issue.
+		if issue.Repository != "" {
+			b.WriteString(fmt.Sprintf("Fixes %s#%d\n\n", issue.Repository, issue.Number))
+		} else {
+			b.WriteString(fmt.Sprintf("Fixes #%d\n\n", issue.Number))
+		}
+	}
+
+	if chat := state.SourceChat; chat != nil {
+		if chat.Title != "" {
+			b.WriteString(fmt.Sprintf("Promoted from chat session \"%s\" (`%s`).\n\n", chat.Title, chat.SessionID))
+		} else {
+			b.WriteString(fmt.Sprintf("Promoted from chat session `%s`.\n\n", chat.SessionID))
+		}
+	}
+
+	if len(linked) > 0 {
+		b.WriteString("## Linked pull requests\n\n")
+		b.WriteString("This change spans several repositories; merge these together:\n\n")
+		for _, link := range linked {
+			b.WriteString("- " + link + "\n")
+		}
+		b.WriteString("\n")
+	}
+
 	b.WriteString("---\n")
 	b.WriteString(fmt.Sprintf("Workflow ID: `%s`\n", state.WorkflowID))
 	b.WriteString("Generated by quorum-ai\n")
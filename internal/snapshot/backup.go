@@ -0,0 +1,349 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
+)
+
+// Backups are named quorum-backup-<UTC time>-<full|incr>.tar.gz, with an .age
+// suffix when encrypted. An incremental backup builds on the backup taken
+// just before it, so the chains are recovered from the names alone, without
+// decrypting the archives.
+const (
+	backupPrefix     = "quorum-backup-"
+	backupTimeLayout = "20060102-150405"
+	backupFullTag    = "full"
+	backupIncrTag    = "incr"
+)
+
+// BackupOptions configures automatic backups.
+type BackupOptions struct {
+	// Dir holds the backups.
+	Dir string
+	// KeepDaily and KeepWeekly are the number of days and weeks for which
+	// the newest backup is kept. Older backups are pruned, except those the
+	// kept incremental backups build on.
+	KeepDaily  int
+	KeepWeekly int
+	// FullEvery is the length of a backup chain: a full backup followed by
+	// FullEvery-1 incremental ones. 1 makes every backup full.
+	FullEvery int
+
+	IncludeWorktrees bool
+	Encryption       Encryption
+
+	RegistryPath     string
+	GlobalConfigPath string
+	QuorumVersion    string
+
+	// now returns the current time; tests override it.
+	now func() time.Time
+}
+
+// BackupResult describes one automatic backup.
+type BackupResult struct {
+	Path        string    `json:"path"`
+	Incremental bool      `json:"incremental"`
+	Manifest    *Manifest `json:"manifest"`
+	// Pruned are the backups removed by retention.
+	Pruned   []string `json:"pruned,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// backupFile is a backup found in the backup directory.
+type backupFile struct {
+	Path      string
+	CreatedAt time.Time
+	Full      bool
+}
+
+// DefaultBackupDir returns the default backup directory, next to the project
+// registry.
+func DefaultBackupDir() (string, error) {
+	registryPath, err := project.DefaultRegistryPath()
+	if err != nil {
+		return "", fmt.Errorf("resolving registry path: %w", err)
+	}
+	return filepath.Join(filepath.Dir(registryPath), "backups"), nil
+}
+
+// Backup takes a backup of every registered project into opts.Dir and prunes
+// the backups retention no longer keeps. The backup is incremental on top of
+// the latest one until the chain reaches FullEvery backups.
+func Backup(opts *BackupOptions) (*BackupResult, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("options are required")
+	}
+	if strings.TrimSpace(opts.Dir) == "" {
+		return nil, fmt.Errorf("backup directory is required")
+	}
+	now := time.Now
+	if opts.now != nil {
+		now = opts.now
+	}
+
+	backups, err := listBackups(opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BackupResult{}
+	exportOpts := &ExportOptions{
+		IncludeWorktrees: opts.IncludeWorktrees,
+		RegistryPath:     opts.RegistryPath,
+		GlobalConfigPath: opts.GlobalConfigPath,
+		QuorumVersion:    opts.QuorumVersion,
+		Encryption:       opts.Encryption,
+	}
+	if len(backups) > 0 && chainLength(backups) < opts.FullEvery {
+		latest := backups[len(backups)-1]
+		// The parent manifest is read before exporting; a parent that
+		// cannot be read starts a new chain instead of failing the backup.
+		if _, _, err := readArchiveManifest(latest.Path, &opts.Encryption); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("taking a full backup: reading %s: %v", latest.Path, err))
+		} else {
+			exportOpts.ParentPath = latest.Path
+			result.Incremental = true
+		}
+	}
+
+	tag := backupFullTag
+	if result.Incremental {
+		tag = backupIncrTag
+	}
+	name := backupPrefix + now().UTC().Format(backupTimeLayout) + "-" + tag + ".tar.gz"
+	if opts.Encryption.encrypting() {
+		name += ".age"
+	}
+	exportOpts.OutputPath = filepath.Join(opts.Dir, name)
+	if _, err := os.Stat(exportOpts.OutputPath); err == nil {
+		return nil, fmt.Errorf("backup %s already exists", exportOpts.OutputPath)
+	}
+
+	exported, err := Export(exportOpts)
+	if err != nil {
+		return nil, err
+	}
+	result.Path = exported.OutputPath
+	result.Manifest = exported.Manifest
+
+	backups, err = listBackups(opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range selectBackupsToPrune(backups, opts.KeepDaily, opts.KeepWeekly) {
+		if err := os.Remove(b.Path); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("pruning %s: %v", b.Path, err))
+			continue
+		}
+		result.Pruned = append(result.Pruned, b.Path)
+	}
+	return result, nil
+}
+
+// listBackups returns the backups in dir, oldest first.
+func listBackups(dir string) ([]backupFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("listing backups: %w", err)
+	}
+	var backups []backupFile
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		createdAt, full, ok := parseBackupName(e.Name())
+		if !ok {
+			continue
+		}
+		backups = append(backups, backupFile{
+			Path:      filepath.Join(dir, e.Name()),
+			CreatedAt: createdAt,
+			Full:      full,
+		})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.Before(backups[j].CreatedAt)
+	})
+	return backups, nil
+}
+
+func parseBackupName(name string) (createdAt time.Time, full, ok bool) {
+	rest, ok := strings.CutPrefix(name, backupPrefix)
+	if !ok {
+		return time.Time{}, false, false
+	}
+	if r, enc := strings.CutSuffix(rest, ".age"); enc {
+		rest = r
+	}
+	rest, ok = strings.CutSuffix(rest, ".tar.gz")
+	if !ok {
+		return time.Time{}, false, false
+	}
+	var stamp string
+	if stamp, full = strings.CutSuffix(rest, "-"+backupFullTag); !full {
+		if stamp, ok = strings.CutSuffix(rest, "-"+backupIncrTag); !ok {
+			return time.Time{}, false, false
+		}
+	}
+	createdAt, err := time.Parse(backupTimeLayout, stamp)
+	if err != nil {
+		return time.Time{}, false, false
+	}
+	return createdAt, full, true
+}
+
+// chainLength returns the number of backups in the chain of the latest backup.
+func chainLength(backups []backupFile) int {
+	n := 0
+	for i := len(backups) - 1; i >= 0; i-- {
+		n++
+		if backups[i].Full {
+			break
+		}
+	}
+	return n
+}
+
+// selectBackupsToPrune returns the backups, sorted oldest first, that are
+// neither the newest of one of the last keepDaily days or keepWeekly ISO
+// weeks, nor part of the chain of such a backup.
+func selectBackupsToPrune(backups []backupFile, keepDaily, keepWeekly int) []backupFile {
+	keep := make([]bool, len(backups))
+	keepNewestPer := func(limit int, period func(time.Time) string) {
+		last := ""
+		for i := len(backups) - 1; i >= 0 && limit > 0; i-- {
+			p := period(backups[i].CreatedAt)
+			if p == last {
+				continue
+			}
+			keep[i] = true
+			last = p
+			limit--
+		}
+	}
+	keepNewestPer(keepDaily, func(t time.Time) string { return t.Format("2006-01-02") })
+	keepNewestPer(keepWeekly, func(t time.Time) string {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-%02d", year, week)
+	})
+
+	// An incremental backup needs every backup back to its full one.
+	for i := len(backups) - 1; i > 0; i-- {
+		if keep[i] && !backups[i].Full {
+			keep[i-1] = true
+		}
+	}
+
+	var prune []backupFile
+	for i, b := range backups {
+		if !keep[i] {
+			prune = append(prune, b)
+		}
+	}
+	return prune
+}
+
+// BackupScheduler takes automatic backups at a fixed interval.
+type BackupScheduler struct {
+	opts     BackupOptions
+	interval time.Duration
+	logger   *slog.Logger
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+	// lastAttempt is when the scheduler last tried a backup, so that a
+	// failing backup is retried one interval later rather than immediately.
+	lastAttempt time.Time
+}
+
+// NewBackupScheduler creates a scheduler taking a backup every interval.
+func NewBackupScheduler(opts BackupOptions, interval time.Duration, logger *slog.Logger) *BackupScheduler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &BackupScheduler{opts: opts, interval: interval, logger: logger}
+}
+
+// Start runs the scheduler in the background until Stop is called or ctx is
+// done. The next backup is due one interval after the latest one in the
+// backup directory, so restarting the server does not take extra backups.
+func (s *BackupScheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return
+	}
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+		for {
+			timer := time.NewTimer(s.nextDelay())
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				s.runOnce()
+			}
+		}
+	}()
+}
+
+// Stop stops the scheduler and waits for a running backup to finish.
+func (s *BackupScheduler) Stop() {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.cancel = nil
+	s.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// nextDelay returns the time until the next backup is due.
+func (s *BackupScheduler) nextDelay() time.Duration {
+	last := s.lastAttempt
+	if backups, err := listBackups(s.opts.Dir); err == nil && len(backups) > 0 {
+		if latest := backups[len(backups)-1].CreatedAt; latest.After(last) {
+			last = latest
+		}
+	}
+	if last.IsZero() {
+		return 0
+	}
+	return max(time.Until(last.Add(s.interval)), 0)
+}
+
+func (s *BackupScheduler) runOnce() {
+	s.lastAttempt = time.Now()
+	result, err := Backup(&s.opts)
+	if err != nil {
+		s.logger.Error("automatic backup failed", slog.String("dir", s.opts.Dir), slog.String("error", err.Error()))
+		return
+	}
+	for _, w := range result.Warnings {
+		s.logger.Warn("automatic backup", slog.String("warning", w))
+	}
+	s.logger.Info("automatic backup taken",
+		slog.String("path", result.Path),
+		slog.Bool("incremental", result.Incremental),
+		slog.Int("pruned", len(result.Pruned)))
+}
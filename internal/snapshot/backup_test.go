@@ -0,0 +1,112 @@
+package snapshot
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
+)
+
+func TestBackup_ChainsAndPrunes(t *testing.T) {
+	sourceRoot := t.TempDir()
+	registryPath := filepath.Join(sourceRoot, "registry", "projects.yaml")
+	backupDir := filepath.Join(sourceRoot, "backups")
+
+	p := mustCreateProjectFixture(t, sourceRoot, "proj-1", "project-one", false, false)
+	mustWriteRegistryFixture(t, registryPath, &project.RegistryConfig{Version: 1, Projects: []*project.Project{p}})
+
+	now := time.Date(2026, 3, 2, 3, 0, 0, 0, time.UTC)
+	opts := &BackupOptions{
+		Dir:          backupDir,
+		KeepDaily:    2,
+		KeepWeekly:   1,
+		FullEvery:    2,
+		RegistryPath: registryPath,
+		now:          func() time.Time { return now },
+	}
+
+	var incremental []bool
+	for day := 0; day < 5; day++ {
+		result, err := Backup(opts)
+		if err != nil {
+			t.Fatalf("Backup() day %d error = %v", day, err)
+		}
+		incremental = append(incremental, result.Incremental)
+		now = now.Add(24 * time.Hour)
+	}
+	want := []bool{false, true, false, true, false}
+	for i := range want {
+		if incremental[i] != want[i] {
+			t.Errorf("backup %d incremental = %t, want %t", i, incremental[i], want[i])
+		}
+	}
+
+	backups, err := listBackups(backupDir)
+	if err != nil {
+		t.Fatalf("listBackups() error = %v", err)
+	}
+	// The last two days are kept, with the full backup the fourth builds on.
+	if len(backups) != 3 || !backups[0].Full || backups[1].Full || !backups[2].Full {
+		t.Fatalf("backups after pruning = %+v, want full, incr, full", backups)
+	}
+	for _, b := range backups {
+		if _, err := ValidateSnapshot(&ValidateOptions{InputPath: b.Path}); err != nil {
+			t.Errorf("ValidateSnapshot(%s) error = %v", b.Path, err)
+		}
+	}
+}
+
+func TestSelectBackupsToPrune(t *testing.T) {
+	t.Parallel()
+
+	day := func(d, hour int, full bool) backupFile {
+		createdAt := time.Date(2026, 3, d, hour, 0, 0, 0, time.UTC)
+		return backupFile{Path: createdAt.Format(time.DateTime), CreatedAt: createdAt, Full: full}
+	}
+	backups := []backupFile{
+		day(1, 1, true), // Sunday of ISO week 9
+		day(2, 1, true), // week 10
+		day(3, 1, false),
+		day(9, 1, true), // week 11
+		day(10, 1, false),
+		day(10, 5, false), // newest of its day
+	}
+
+	pruned := selectBackupsToPrune(backups, 1, 2)
+	var got []string
+	for _, b := range pruned {
+		got = append(got, b.Path)
+	}
+	// Kept: the newest backup (daily and week 11) with its chain, and the
+	// newest of week 10 with its full backup. Week 9 is beyond keep_weekly.
+	want := []string{backups[0].Path}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Errorf("selectBackupsToPrune() = %v, want %v", got, want)
+	}
+}
+
+func TestParseBackupName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		ok   bool
+		full bool
+	}{
+		{"quorum-backup-20260302-030000-full.tar.gz", true, true},
+		{"quorum-backup-20260302-030000-incr.tar.gz.age", true, false},
+		{"quorum-backup-20260302-030000-full.tar.gz.tmp", false, false},
+		{"quorum-backup-latest-full.tar.gz", false, false},
+		{"notes.txt", false, false},
+	}
+	for _, tt := range tests {
+		createdAt, full, ok := parseBackupName(tt.name)
+		if ok != tt.ok || full != tt.full {
+			t.Errorf("parseBackupName(%q) = %t, %t, want %t, %t", tt.name, full, ok, tt.full, tt.ok)
+		}
+		if ok && !createdAt.Equal(time.Date(2026, 3, 2, 3, 0, 0, 0, time.UTC)) {
+			t.Errorf("parseBackupName(%q) time = %v", tt.name, createdAt)
+		}
+	}
+}
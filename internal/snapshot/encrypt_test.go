@@ -0,0 +1,110 @@
+package snapshot
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
+)
+
+func TestExportImport_Encrypted(t *testing.T) {
+	sourceRoot := t.TempDir()
+	registryPath := filepath.Join(sourceRoot, "registry", "projects.yaml")
+	snapshotPath := filepath.Join(sourceRoot, "snapshot.tar.gz.age")
+
+	p := mustCreateProjectFixture(t, sourceRoot, "proj-1", "project-one", true, false)
+	mustWriteRegistryFixture(t, registryPath, &project.RegistryConfig{Version: 1, Projects: []*project.Project{p}})
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("GenerateX25519Identity() error = %v", err)
+	}
+	identityPath := filepath.Join(sourceRoot, "key.txt")
+	mustWriteFile(t, identityPath, []byte(identity.String()+"\n"), 0o600)
+
+	if _, err := Export(&ExportOptions{
+		OutputPath:   snapshotPath,
+		RegistryPath: registryPath,
+		Encryption:   Encryption{Recipients: []string{identity.Recipient().String()}},
+	}); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if encrypted, err := IsEncrypted(snapshotPath); err != nil || !encrypted {
+		t.Fatalf("IsEncrypted() = %t, %v, want true", encrypted, err)
+	}
+
+	if _, err := ValidateSnapshot(&ValidateOptions{InputPath: snapshotPath}); !errors.Is(err, ErrEncrypted) {
+		t.Errorf("ValidateSnapshot() without keys error = %v, want ErrEncrypted", err)
+	}
+	withKey := Encryption{IdentityFiles: []string{identityPath}}
+	if _, err := ValidateSnapshot(&ValidateOptions{InputPath: snapshotPath, Encryption: withKey}); err != nil {
+		t.Fatalf("ValidateSnapshot() error = %v", err)
+	}
+
+	destRoot := t.TempDir()
+	report, err := Import(&ImportOptions{
+		InputPath:          snapshotPath,
+		Mode:               ImportModeReplace,
+		ConflictPolicy:     ConflictOverwrite,
+		PathMap:            map[string]string{p.Path: filepath.Join(destRoot, "project-one")},
+		PreserveProjectIDs: true,
+		RegistryPath:       filepath.Join(destRoot, "registry", "projects.yaml"),
+		GlobalConfigPath:   filepath.Join(destRoot, "registry", "global-config.yaml"),
+		Encryption:         withKey,
+	})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if report.RestoredFiles == 0 {
+		t.Error("Import() restored no files")
+	}
+
+	// Any modification of the ciphertext is detected.
+	data, err := os.ReadFile(snapshotPath)
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+	data[len(data)-20] ^= 0xff
+	mustWriteFile(t, snapshotPath, data, 0o600)
+	if _, err := ValidateSnapshot(&ValidateOptions{InputPath: snapshotPath, Encryption: withKey}); err == nil {
+		t.Error("ValidateSnapshot() of a modified archive error = nil")
+	}
+}
+
+func TestExportImport_Passphrase(t *testing.T) {
+	sourceRoot := t.TempDir()
+	registryPath := filepath.Join(sourceRoot, "registry", "projects.yaml")
+	snapshotPath := filepath.Join(sourceRoot, "snapshot.tar.gz.age")
+
+	p := mustCreateProjectFixture(t, sourceRoot, "proj-1", "project-one", false, false)
+	mustWriteRegistryFixture(t, registryPath, &project.RegistryConfig{Version: 1, Projects: []*project.Project{p}})
+
+	if _, err := Export(&ExportOptions{
+		OutputPath:   snapshotPath,
+		RegistryPath: registryPath,
+		Encryption:   Encryption{Passphrase: "correct horse"},
+	}); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if _, err := ValidateSnapshot(&ValidateOptions{InputPath: snapshotPath, Encryption: Encryption{Passphrase: "wrong"}}); err == nil {
+		t.Error("ValidateSnapshot() with a wrong passphrase error = nil")
+	}
+	if _, err := ValidateSnapshot(&ValidateOptions{InputPath: snapshotPath, Encryption: Encryption{Passphrase: "correct horse"}}); err != nil {
+		t.Errorf("ValidateSnapshot() error = %v", err)
+	}
+}
+
+func TestEncryption_PassphraseWithRecipients(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("GenerateX25519Identity() error = %v", err)
+	}
+	enc := Encryption{Passphrase: "secret", Recipients: []string{identity.Recipient().String()}}
+	if _, err := enc.recipients(); err == nil {
+		t.Error("recipients() error = nil, want an error for a passphrase combined with recipients")
+	}
+}
@@ -3,9 +3,9 @@ package snapshot
 import (
 	"archive/tar"
 	"compress/gzip"
-	"crypto/sha256"
-	"encoding/hex"
+	"context"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -33,22 +33,115 @@ func Export(opts *ExportOptions) (*ExportResult, error) {
 		return nil, err
 	}
 
+	var parent *snapshotParent
+	if opts.ParentPath != "" {
+		if parent, err = readSnapshotParent(opts.ParentPath, opts.OutputPath, &opts.Encryption); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := os.MkdirAll(filepath.Dir(opts.OutputPath), 0o750); err != nil {
 		return nil, fmt.Errorf("creating output directory: %w", err)
 	}
 
-	out, err := os.Create(opts.OutputPath)
+	// The archive is written next to its destination and renamed once
+	// complete, so that an interrupted export never leaves a partial snapshot.
+	tmpPath := opts.OutputPath + ".tmp"
+	out, err := os.Create(tmpPath)
 	if err != nil {
 		return nil, fmt.Errorf("creating snapshot file: %w", err)
 	}
-	defer out.Close()
+	manifest, err := writeSnapshotArchive(out, opts, registryCfg, selectedProjects, parent)
+	if closeErr := out.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("writing snapshot file: %w", closeErr)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return nil, err
+	}
+	if err := os.Rename(tmpPath, opts.OutputPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return nil, fmt.Errorf("writing snapshot file: %w", err)
+	}
+
+	return &ExportResult{
+		OutputPath: opts.OutputPath,
+		Manifest:   manifest,
+	}, nil
+}
+
+// snapshotParent is the snapshot an incremental export builds on.
+type snapshotParent struct {
+	ref ParentRef
+	// files maps the parent's archive paths to their checksums.
+	files map[string]string
+}
+
+// readSnapshotParent reads the manifest of the parent of an incremental
+// snapshot written to outputPath.
+func readSnapshotParent(parentPath, outputPath string, enc *Encryption) (*snapshotParent, error) {
+	manifest, manifestSum, err := readArchiveManifest(parentPath, enc)
+	if err != nil {
+		return nil, fmt.Errorf("reading parent snapshot: %w", err)
+	}
+	absParent, err := filepath.Abs(parentPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolving parent snapshot path: %w", err)
+	}
+	absOutput, err := filepath.Abs(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolving output path: %w", err)
+	}
+	if absParent == absOutput {
+		return nil, fmt.Errorf("invalid parent snapshot: it is the output path")
+	}
+	rel, err := filepath.Rel(filepath.Dir(absOutput), absParent)
+	if err != nil {
+		return nil, fmt.Errorf("resolving parent snapshot path: %w", err)
+	}
 
-	gzWriter := gzip.NewWriter(out)
-	defer gzWriter.Close()
+	parent := &snapshotParent{
+		ref: ParentRef{
+			File:           filepath.ToSlash(rel),
+			ManifestSHA256: manifestSum,
+			CreatedAt:      manifest.CreatedAt,
+		},
+		files: make(map[string]string, len(manifest.Files)),
+	}
+	for _, f := range manifest.Files {
+		parent.files[f.Path] = f.SHA256
+	}
+	return parent, nil
+}
 
-	tarWriter := tar.NewWriter(gzWriter)
-	defer tarWriter.Close()
+func writeSnapshotArchive(
+	out io.Writer,
+	opts *ExportOptions,
+	registryCfg *project.RegistryConfig,
+	selectedProjects []*project.Project,
+	parent *snapshotParent,
+) (*Manifest, error) {
+	archive, err := newArchiveWriter(out, &opts.Encryption)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := writeSnapshotEntries(archive.tw, opts, registryCfg, selectedProjects, parent)
+	if closeErr := archive.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("writing snapshot archive: %w", closeErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
 
+func writeSnapshotEntries(
+	tarWriter *tar.Writer,
+	opts *ExportOptions,
+	registryCfg *project.RegistryConfig,
+	selectedProjects []*project.Project,
+	parent *snapshotParent,
+) (*Manifest, error) {
 	subsetCfg := &project.RegistryConfig{
 		Version:        registryCfg.Version,
 		DefaultProject: "",
@@ -66,6 +159,9 @@ func Export(opts *ExportOptions) (*ExportResult, error) {
 		Projects:            make([]ProjectEntry, 0, len(selectedProjects)),
 		Files:               make([]FileEntry, 0),
 	}
+	if parent != nil {
+		manifest.Parent = &parent.ref
+	}
 
 	for _, p := range selectedProjects {
 		subsetCfg.Projects = append(subsetCfg.Projects, p.Clone())
@@ -113,6 +209,7 @@ func Export(opts *ExportOptions) (*ExportResult, error) {
 		if listErr != nil {
 			return nil, fmt.Errorf("listing files for project %s: %w", p.ID, listErr)
 		}
+		files = withoutSQLiteSidecars(files)
 
 		for _, filePath := range files {
 			relPath, relErr := filepath.Rel(p.Path, filePath)
@@ -128,11 +225,21 @@ func Export(opts *ExportOptions) (*ExportResult, error) {
 				return nil, fmt.Errorf("invalid archive path for %s: %w", filePath, cleanErr)
 			}
 
-			data, mode, readErr := readFileWithMode(filePath)
+			data, mode, readErr := readProjectFile(filePath)
 			if readErr != nil {
 				return nil, fmt.Errorf("reading file %s: %w", filePath, readErr)
 			}
-			if err := addBytesToArchive(tarWriter, manifest, archivePath, data, mode); err != nil {
+			if data.database {
+				manifest.Databases = append(manifest.Databases, DatabaseEntry{
+					Path:          archivePath,
+					Kind:          data.kind,
+					SchemaVersion: data.schemaVersion,
+				})
+			}
+			if parent.inherit(manifest, archivePath, data.bytes, mode) {
+				continue
+			}
+			if err := addBytesToArchive(tarWriter, manifest, archivePath, data.bytes, mode); err != nil {
 				return nil, err
 			}
 		}
@@ -146,10 +253,55 @@ func Export(opts *ExportOptions) (*ExportResult, error) {
 		return nil, fmt.Errorf("writing manifest: %w", err)
 	}
 
-	return &ExportResult{
-		OutputPath: opts.OutputPath,
-		Manifest:   manifest,
-	}, nil
+	return manifest, nil
+}
+
+// inherit records a file unchanged since the parent snapshot as inherited,
+// instead of storing it again, and reports whether it did.
+func (p *snapshotParent) inherit(manifest *Manifest, archivePath string, data []byte, mode int64) bool {
+	if p == nil {
+		return false
+	}
+	sum := checksum(data)
+	if p.files[archivePath] != sum {
+		return false
+	}
+	manifest.Files = append(manifest.Files, FileEntry{
+		Path:      archivePath,
+		SHA256:    sum,
+		Size:      int64(len(data)),
+		Mode:      mode,
+		Inherited: true,
+	})
+	return true
+}
+
+// archiveWriter writes the tar.gz stream of a snapshot, encrypted when
+// encryption is configured.
+type archiveWriter struct {
+	tw  *tar.Writer
+	gz  *gzip.Writer
+	enc io.WriteCloser
+}
+
+func newArchiveWriter(out io.Writer, enc *Encryption) (*archiveWriter, error) {
+	encWriter, err := encryptWriter(out, enc)
+	if err != nil {
+		return nil, err
+	}
+	gz := gzip.NewWriter(encWriter)
+	return &archiveWriter{tw: tar.NewWriter(gz), gz: gz, enc: encWriter}, nil
+}
+
+// Close flushes the tar, gzip and encryption streams in order.
+func (a *archiveWriter) Close() error {
+	if err := a.tw.Close(); err != nil {
+		return err
+	}
+	if err := a.gz.Close(); err != nil {
+		return err
+	}
+	return a.enc.Close()
 }
 
 func selectProjectsForExport(cfg *project.RegistryConfig, selectedIDs []string) ([]*project.Project, error) {
@@ -192,6 +344,9 @@ func selectProjectsForExport(cfg *project.RegistryConfig, selectedIDs []string)
 
 func listProjectFiles(projectPath string, includeWorktrees bool) ([]string, error) {
 	roots := []string{filepath.Join(projectPath, ".quorum")}
+	// The code index is a cache rebuilt from the sources, so it stays out of
+	// snapshots and backups.
+	indexDir := filepath.Join(projectPath, ".quorum", "index")
 	if includeWorktrees {
 		roots = append(roots, filepath.Join(projectPath, ".worktrees"))
 	}
@@ -214,6 +369,9 @@ func listProjectFiles(projectPath string, includeWorktrees bool) ([]string, erro
 				return walkErr
 			}
 			if d.IsDir() {
+				if path == indexDir {
+					return filepath.SkipDir
+				}
 				return nil
 			}
 			if !d.Type().IsRegular() {
@@ -264,6 +422,39 @@ func readFileWithMode(path string) (data []byte, mode int64, err error) {
 	return data, int64(info.Mode().Perm()), nil
 }
 
+// projectFileData is the archived content of a project file.
+type projectFileData struct {
+	bytes []byte
+	// database is true for SQLite databases, archived as consistent copies.
+	database      bool
+	kind          string
+	schemaVersion int
+}
+
+// readProjectFile reads a project file for archiving. SQLite databases are
+// read through a consistent copy, since quorum serve may be writing to them.
+func readProjectFile(path string) (data projectFileData, mode int64, err error) {
+	isDB, err := isSQLiteFile(path)
+	if err != nil {
+		return data, 0, err
+	}
+	if !isDB {
+		data.bytes, mode, err = readFileWithMode(path)
+		return data, mode, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return data, 0, err
+	}
+	data.database = true
+	data.bytes, data.kind, data.schemaVersion, err = consistentDatabaseCopy(context.Background(), path)
+	if err != nil {
+		return data, 0, err
+	}
+	return data, int64(info.Mode().Perm()), nil
+}
+
 func addBytesToArchive(tw *tar.Writer, manifest *Manifest, archivePath string, data []byte, mode int64) error {
 	cleanPath, err := cleanArchivePath(archivePath)
 	if err != nil {
@@ -274,10 +465,9 @@ func addBytesToArchive(tw *tar.Writer, manifest *Manifest, archivePath string, d
 		return fmt.Errorf("writing archive entry %s: %w", cleanPath, err)
 	}
 
-	hash := sha256.Sum256(data)
 	manifest.Files = append(manifest.Files, FileEntry{
 		Path:   cleanPath,
-		SHA256: hex.EncodeToString(hash[:]),
+		SHA256: checksum(data),
 		Size:   int64(len(data)),
 		Mode:   mode,
 	})
@@ -2,6 +2,7 @@ package snapshot
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -201,6 +202,11 @@ func sortFileEntries(entries []FileEntry) {
 	})
 }
 
+func checksum(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
 func encodeManifest(manifest *Manifest) ([]byte, error) {
 	sortFileEntries(manifest.Files)
 	return json.MarshalIndent(manifest, "", "  ")
@@ -211,7 +217,7 @@ func decodeManifest(data []byte) (*Manifest, error) {
 	if err := json.Unmarshal(data, &manifest); err != nil {
 		return nil, err
 	}
-	if manifest.Version != FormatVersion {
+	if manifest.Version < minFormatVersion || manifest.Version > FormatVersion {
 		return nil, fmt.Errorf("unsupported snapshot version: %d", manifest.Version)
 	}
 	return &manifest, nil
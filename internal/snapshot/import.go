@@ -1,6 +1,8 @@
 package snapshot
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"math"
 	"os"
@@ -17,7 +19,7 @@ func Import(opts *ImportOptions) (*ImportReport, error) {
 		return nil, err
 	}
 
-	manifest, archiveFiles, err := loadSnapshotArchive(opts.InputPath)
+	manifest, archiveFiles, err := loadSnapshotArchive(opts.InputPath, &opts.Encryption)
 	if err != nil {
 		return nil, err
 	}
@@ -223,6 +225,8 @@ func restoreProjectFiles(
 	restoreTargets map[string]*project.Project,
 	report *ImportReport,
 ) error {
+	// Databases are migrated once every file is restored.
+	var databases []string
 	for _, fileEntry := range manifest.Files {
 		if fileEntry.Path == registryArchivePath || fileEntry.Path == globalConfigArchivePath {
 			continue
@@ -293,10 +297,27 @@ func restoreProjectFiles(
 		if mode == 0 {
 			mode = 0o600
 		}
+		isDatabase := bytes.HasPrefix(archiveFile.Data, sqliteHeader)
+		if isDatabase {
+			if err := removeSQLiteSidecars(targetFilePath); err != nil {
+				return fmt.Errorf("removing stale database files for %s: %w", targetFilePath, err)
+			}
+		}
 		if err := os.WriteFile(targetFilePath, archiveFile.Data, mode); err != nil {
 			return fmt.Errorf("writing file %s: %w", targetFilePath, err)
 		}
 		report.RestoredFiles++
+		if isDatabase {
+			databases = append(databases, targetFilePath)
+		}
+	}
+
+	for _, path := range databases {
+		dbReport, err := migrateRestoredDatabase(context.Background(), path)
+		if err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("migrating restored database %s: %v", path, err))
+		}
+		report.Databases = append(report.Databases, dbReport)
 	}
 
 	if !opts.DryRun {
@@ -0,0 +1,131 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
+)
+
+func TestExportImport_IncrementalChain(t *testing.T) {
+	sourceRoot := t.TempDir()
+	registryPath := filepath.Join(sourceRoot, "registry", "projects.yaml")
+	snapshotDir := filepath.Join(sourceRoot, "snapshots")
+
+	p := mustCreateProjectFixture(t, sourceRoot, "proj-1", "project-one", true, false)
+	mustWriteRegistryFixture(t, registryPath, &project.RegistryConfig{Version: 1, Projects: []*project.Project{p}})
+	statePath := filepath.Join(p.Path, ".quorum", "state", "state.json")
+	configPath := filepath.Join(p.Path, ".quorum", "config.yaml")
+	logPath := filepath.Join(p.Path, ".quorum", "logs", "run.log")
+	mustWriteFile(t, logPath, []byte("first run\n"), 0o600)
+
+	export := func(name, parent string) *Manifest {
+		t.Helper()
+		result, err := Export(&ExportOptions{
+			OutputPath:   filepath.Join(snapshotDir, name),
+			RegistryPath: registryPath,
+			ParentPath:   parent,
+		})
+		if err != nil {
+			t.Fatalf("Export(%s) error = %v", name, err)
+		}
+		return result.Manifest
+	}
+
+	full := export("full.tar.gz", "")
+	if full.Parent != nil {
+		t.Fatalf("full snapshot has a parent: %+v", full.Parent)
+	}
+
+	mustWriteFile(t, statePath, []byte(`{"ok":"changed"}`), 0o600)
+	incr1 := export("incr-1.tar.gz", filepath.Join(snapshotDir, "full.tar.gz"))
+
+	mustWriteFile(t, configPath, []byte("log:\n  level: debug\n"), 0o600)
+	if err := os.Remove(logPath); err != nil {
+		t.Fatalf("os.Remove() error = %v", err)
+	}
+	incr2 := export("incr-2.tar.gz", filepath.Join(snapshotDir, "incr-1.tar.gz"))
+
+	if incr1.Parent == nil || incr1.Parent.File != "full.tar.gz" {
+		t.Fatalf("incr-1 parent = %+v, want full.tar.gz", incr1.Parent)
+	}
+	stored := func(m *Manifest) []string {
+		var paths []string
+		for _, f := range m.Files {
+			if !f.Inherited && strings.HasPrefix(f.Path, projectsArchiveRoot+"/") {
+				paths = append(paths, f.Path)
+			}
+		}
+		return paths
+	}
+	if got := stored(incr1); len(got) != 1 || got[0] != "projects/proj-1/.quorum/state/state.json" {
+		t.Errorf("incr-1 stores %v, want only the changed state", got)
+	}
+	if got := stored(incr2); len(got) != 1 || got[0] != "projects/proj-1/.quorum/config.yaml" {
+		t.Errorf("incr-2 stores %v, want only the changed config", got)
+	}
+
+	if _, err := ValidateSnapshot(&ValidateOptions{InputPath: filepath.Join(snapshotDir, "incr-2.tar.gz")}); err != nil {
+		t.Fatalf("ValidateSnapshot() error = %v", err)
+	}
+
+	destPath := filepath.Join(t.TempDir(), "project-one")
+	if _, err := Import(&ImportOptions{
+		InputPath:          filepath.Join(snapshotDir, "incr-2.tar.gz"),
+		Mode:               ImportModeReplace,
+		ConflictPolicy:     ConflictOverwrite,
+		PathMap:            map[string]string{p.Path: destPath},
+		PreserveProjectIDs: true,
+		RegistryPath:       filepath.Join(t.TempDir(), "projects.yaml"),
+		GlobalConfigPath:   filepath.Join(t.TempDir(), "global-config.yaml"),
+	}); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	for path, want := range map[string]string{
+		filepath.Join(destPath, ".quorum", "state", "state.json"): `{"ok":"changed"}`,
+		filepath.Join(destPath, ".quorum", "config.yaml"):         "log:\n  level: debug\n",
+	} {
+		got, err := os.ReadFile(path)
+		if err != nil || string(got) != want {
+			t.Errorf("restored %s = %q, %v, want %q", path, got, err, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(destPath, ".quorum", "logs", "run.log")); !os.IsNotExist(err) {
+		t.Errorf("file deleted before the incremental snapshot was restored: %v", err)
+	}
+}
+
+func TestValidateSnapshot_BrokenChain(t *testing.T) {
+	sourceRoot := t.TempDir()
+	registryPath := filepath.Join(sourceRoot, "registry", "projects.yaml")
+	fullPath := filepath.Join(sourceRoot, "full.tar.gz")
+	incrPath := filepath.Join(sourceRoot, "incr.tar.gz")
+
+	p := mustCreateProjectFixture(t, sourceRoot, "proj-1", "project-one", true, false)
+	mustWriteRegistryFixture(t, registryPath, &project.RegistryConfig{Version: 1, Projects: []*project.Project{p}})
+
+	if _, err := Export(&ExportOptions{OutputPath: fullPath, RegistryPath: registryPath}); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if _, err := Export(&ExportOptions{OutputPath: incrPath, RegistryPath: registryPath, ParentPath: fullPath}); err != nil {
+		t.Fatalf("Export() incremental error = %v", err)
+	}
+
+	// A parent replaced by another snapshot is rejected.
+	if _, err := Export(&ExportOptions{OutputPath: fullPath, RegistryPath: registryPath}); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	_, err := ValidateSnapshot(&ValidateOptions{InputPath: incrPath})
+	if err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("ValidateSnapshot() with a replaced parent error = %v", err)
+	}
+
+	if err := os.Remove(fullPath); err != nil {
+		t.Fatalf("os.Remove() error = %v", err)
+	}
+	if _, err := ValidateSnapshot(&ValidateOptions{InputPath: incrPath}); err == nil {
+		t.Error("ValidateSnapshot() with a missing parent error = nil")
+	}
+}
@@ -18,6 +18,8 @@ func TestExportImportRoundTrip(t *testing.T) {
 
 	p1 := mustCreateProjectFixture(t, sourceRoot, "proj-1", "project-one", true, true)
 	p2 := mustCreateProjectFixture(t, sourceRoot, "proj-2", "project-two", false, true)
+	mustMkdirAll(t, filepath.Join(p1.Path, ".quorum", "index"))
+	mustWriteFile(t, filepath.Join(p1.Path, ".quorum", "index", "index.db"), []byte("index"), 0o600)
 
 	cfg := &project.RegistryConfig{
 		Version:        1,
@@ -47,6 +49,9 @@ func TestExportImportRoundTrip(t *testing.T) {
 		if file.Path == "projects/proj-1/.worktrees/task-1/note.txt" {
 			t.Fatalf("worktree file should not be exported when include_worktrees=false")
 		}
+		if file.Path == "projects/proj-1/.quorum/index/index.db" {
+			t.Fatalf("code index should not be exported")
+		}
 	}
 
 	destRoot := t.TempDir()
@@ -346,7 +351,7 @@ func TestValidateSnapshot_InvalidFile(t *testing.T) {
 	invalidPath := filepath.Join(t.TempDir(), "invalid.tar.gz")
 	mustWriteFile(t, invalidPath, []byte("not-a-gzip"), 0o600)
 
-	if _, err := ValidateSnapshot(invalidPath); err == nil {
+	if _, err := ValidateSnapshot(&ValidateOptions{InputPath: invalidPath}); err == nil {
 		t.Fatalf("expected ValidateSnapshot() to fail for invalid archive")
 	}
 }
@@ -0,0 +1,196 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/chat"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/state"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/confighistory"
+	_ "modernc.org/sqlite"
+)
+
+// Kinds of the quorum databases, recognized by their migrations table and a
+// table of their schema.
+const (
+	DatabaseKindState         = "state"
+	DatabaseKindChat          = "chat"
+	DatabaseKindConfigHistory = "config_history"
+)
+
+// sqliteHeader starts every SQLite database file.
+var sqliteHeader = []byte("SQLite format 3\x00")
+
+// sqliteSidecarSuffixes are the files SQLite keeps next to a database while
+// it is open. A consistent copy of the database already includes their
+// content.
+var sqliteSidecarSuffixes = []string{"-wal", "-shm", "-journal"}
+
+// migrationTables maps the migrations table of each quorum database, with a
+// table only that database has, to its kind. The state and config history
+// databases share the migrations table name.
+var migrationTables = []struct{ table, marker, kind string }{
+	{"schema_migrations", "workflows", DatabaseKindState},
+	{"chat_schema_migrations", "chat_sessions", DatabaseKindChat},
+	{"schema_migrations", "config_versions", DatabaseKindConfigHistory},
+}
+
+// isSQLiteFile reports whether the file at path is a SQLite database.
+func isSQLiteFile(path string) (bool, error) {
+	f, err := os.Open(path) // #nosec G304 -- path is discovered from project roots
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	header := make([]byte, len(sqliteHeader))
+	if _, err := io.ReadFull(f, header); err != nil {
+		return false, nil
+	}
+	return bytes.Equal(header, sqliteHeader), nil
+}
+
+// withoutSQLiteSidecars drops the WAL, shared-memory and journal files of the
+// databases in files.
+func withoutSQLiteSidecars(files []string) []string {
+	present := make(map[string]bool, len(files))
+	for _, f := range files {
+		present[f] = true
+	}
+	kept := files[:0:0]
+	for _, f := range files {
+		sidecar := false
+		for _, suffix := range sqliteSidecarSuffixes {
+			if base, ok := strings.CutSuffix(f, suffix); ok && present[base] {
+				sidecar = true
+				break
+			}
+		}
+		if !sidecar {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// consistentDatabaseCopy returns a transactionally consistent copy of the
+// SQLite database at path, made with VACUUM INTO so that it can be taken
+// while quorum serve is writing to the database, and the database's kind and
+// schema version.
+func consistentDatabaseCopy(ctx context.Context, path string) (data []byte, kind string, version int, err error) {
+	tmpDir, err := os.MkdirTemp("", "quorum-snapshot-db-*")
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("creating temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	copyPath := filepath.Join(tmpDir, filepath.Base(path))
+
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("opening database: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	_, err = db.ExecContext(ctx, "VACUUM INTO ?", copyPath)
+	_ = db.Close()
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("copying database: %w", err)
+	}
+
+	kind, version, err = databaseSchemaVersion(ctx, copyPath)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	data, err = os.ReadFile(copyPath) // #nosec G304 -- temporary copy created above
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("reading database copy: %w", err)
+	}
+	return data, kind, version, nil
+}
+
+// databaseSchemaVersion returns the kind and schema version of the database
+// at path. Databases without a known migrations table have no kind and report
+// their user_version.
+func databaseSchemaVersion(ctx context.Context, path string) (kind string, version int, err error) {
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)")
+	if err != nil {
+		return "", 0, fmt.Errorf("opening database: %w", err)
+	}
+	defer db.Close()
+
+	for _, mt := range migrationTables {
+		var n int
+		if err := db.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN (?, ?)`, mt.table, mt.marker).Scan(&n); err != nil {
+			return "", 0, fmt.Errorf("reading database schema: %w", err)
+		}
+		if n < 2 {
+			continue
+		}
+		// #nosec G202 -- table name comes from migrationTables
+		if err := db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM "+mt.table).Scan(&version); err != nil {
+			return "", 0, fmt.Errorf("reading schema version: %w", err)
+		}
+		return mt.kind, version, nil
+	}
+	if err := db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return "", 0, fmt.Errorf("reading schema version: %w", err)
+	}
+	return "", version, nil
+}
+
+// removeSQLiteSidecars removes the WAL, shared-memory and journal files left
+// next to path by a database it replaces, which would otherwise be applied to
+// the restored database.
+func removeSQLiteSidecars(path string) error {
+	for _, suffix := range sqliteSidecarSuffixes {
+		if err := os.Remove(path + suffix); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateRestoredDatabase brings a restored quorum database to the
+// current schema by opening it, which runs its migrations.
+func migrateRestoredDatabase(ctx context.Context, path string) (DatabaseImportReport, error) {
+	report := DatabaseImportReport{Path: path}
+	kind, from, err := databaseSchemaVersion(ctx, path)
+	if err != nil {
+		return report, err
+	}
+	report.Kind, report.FromVersion, report.ToVersion = kind, from, from
+
+	switch kind {
+	case DatabaseKindState:
+		sm, err := state.NewSQLiteStateManager(path)
+		if err != nil {
+			return report, fmt.Errorf("migrating state database: %w", err)
+		}
+		_ = sm.Close()
+	case DatabaseKindChat:
+		store, err := chat.NewSQLiteChatStore(path)
+		if err != nil {
+			return report, fmt.Errorf("migrating chat database: %w", err)
+		}
+		_ = store.Close()
+	case DatabaseKindConfigHistory:
+		store, err := confighistory.Open(path)
+		if err != nil {
+			return report, fmt.Errorf("migrating config history database: %w", err)
+		}
+		_ = store.Close()
+	default:
+		return report, nil
+	}
+
+	if _, report.ToVersion, err = databaseSchemaVersion(ctx, path); err != nil {
+		return report, err
+	}
+	report.Upgraded = report.ToVersion > report.FromVersion
+	return report, nil
+}
@@ -0,0 +1,179 @@
+package snapshot
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/adapters/state"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/confighistory"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
+)
+
+func TestExportImport_LiveStateDatabase(t *testing.T) {
+	sourceRoot := t.TempDir()
+	registryPath := filepath.Join(sourceRoot, "registry", "projects.yaml")
+	snapshotPath := filepath.Join(sourceRoot, "snapshot.tar.gz")
+
+	p := mustCreateProjectFixture(t, sourceRoot, "proj-1", "project-one", false, false)
+	mustWriteRegistryFixture(t, registryPath, &project.RegistryConfig{
+		Version:  1,
+		Projects: []*project.Project{p},
+	})
+
+	// Keep the database open, as quorum serve would, so that its WAL and
+	// shared-memory files exist while the snapshot is taken.
+	dbPath := filepath.Join(p.Path, ".quorum", "state", "state.db")
+	sm, err := state.NewSQLiteStateManager(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteStateManager() error = %v", err)
+	}
+	defer sm.Close()
+	if _, err := os.Stat(dbPath + "-wal"); err != nil {
+		t.Fatalf("expected a WAL file next to the live database: %v", err)
+	}
+
+	exportResult, err := Export(&ExportOptions{
+		OutputPath:   snapshotPath,
+		RegistryPath: registryPath,
+	})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	for _, file := range exportResult.Manifest.Files {
+		if strings.HasSuffix(file.Path, "-wal") || strings.HasSuffix(file.Path, "-shm") {
+			t.Errorf("SQLite sidecar %s should not be exported", file.Path)
+		}
+	}
+	if len(exportResult.Manifest.Databases) != 1 {
+		t.Fatalf("len(Manifest.Databases) = %d, want 1", len(exportResult.Manifest.Databases))
+	}
+	db := exportResult.Manifest.Databases[0]
+	if db.Path != "projects/proj-1/.quorum/state/state.db" || db.Kind != DatabaseKindState || db.SchemaVersion == 0 {
+		t.Errorf("Manifest.Databases[0] = %+v, want the state database with its schema version", db)
+	}
+	if _, err := ValidateSnapshot(&ValidateOptions{InputPath: snapshotPath}); err != nil {
+		t.Fatalf("ValidateSnapshot() error = %v", err)
+	}
+
+	destRoot := t.TempDir()
+	report, err := Import(&ImportOptions{
+		InputPath:          snapshotPath,
+		Mode:               ImportModeReplace,
+		ConflictPolicy:     ConflictOverwrite,
+		PathMap:            map[string]string{p.Path: filepath.Join(destRoot, "project-one")},
+		PreserveProjectIDs: true,
+		RegistryPath:       filepath.Join(destRoot, "registry", "projects.yaml"),
+	})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if len(report.Databases) != 1 {
+		t.Fatalf("len(report.Databases) = %d, want 1", len(report.Databases))
+	}
+	if got := report.Databases[0]; got.Upgraded || got.ToVersion != db.SchemaVersion {
+		t.Errorf("report.Databases[0] = %+v, want v%d restored without upgrade", got, db.SchemaVersion)
+	}
+}
+
+func TestExportImport_ConfigHistoryDatabase(t *testing.T) {
+	sourceRoot := t.TempDir()
+	registryPath := filepath.Join(sourceRoot, "registry", "projects.yaml")
+	snapshotPath := filepath.Join(sourceRoot, "snapshot.tar.gz")
+
+	p := mustCreateProjectFixture(t, sourceRoot, "proj-1", "project-one", true, false)
+	mustWriteRegistryFixture(t, registryPath, &project.RegistryConfig{
+		Version:  1,
+		Projects: []*project.Project{p},
+	})
+	sm, err := state.NewSQLiteStateManager(filepath.Join(p.Path, ".quorum", "state", "state.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteStateManager() error = %v", err)
+	}
+	_ = sm.Close()
+	// Both databases have a schema_migrations table.
+	if _, err := confighistory.CurrentVersion(context.Background(), filepath.Join(p.Path, ".quorum", "config.yaml")); err != nil {
+		t.Fatalf("CurrentVersion() error = %v", err)
+	}
+
+	exportResult, err := Export(&ExportOptions{
+		OutputPath:   snapshotPath,
+		RegistryPath: registryPath,
+	})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	kinds := map[string]string{}
+	for _, db := range exportResult.Manifest.Databases {
+		kinds[db.Path] = db.Kind
+	}
+	if got := kinds["projects/proj-1/.quorum/config-history.db"]; got != DatabaseKindConfigHistory {
+		t.Errorf("config history kind = %q, want %q", got, DatabaseKindConfigHistory)
+	}
+	if got := kinds["projects/proj-1/.quorum/state/state.db"]; got != DatabaseKindState {
+		t.Errorf("state kind = %q, want %q", got, DatabaseKindState)
+	}
+
+	destRoot := t.TempDir()
+	report, err := Import(&ImportOptions{
+		InputPath:          snapshotPath,
+		Mode:               ImportModeReplace,
+		ConflictPolicy:     ConflictOverwrite,
+		PathMap:            map[string]string{p.Path: filepath.Join(destRoot, "project-one")},
+		PreserveProjectIDs: true,
+		RegistryPath:       filepath.Join(destRoot, "registry", "projects.yaml"),
+	})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if len(report.Warnings) != 0 {
+		t.Errorf("Import() warnings = %v, want none", report.Warnings)
+	}
+	if len(report.Databases) != 2 {
+		t.Errorf("len(report.Databases) = %d, want 2", len(report.Databases))
+	}
+	store, err := confighistory.OpenFor(filepath.Join(destRoot, "project-one", ".quorum", "config.yaml"))
+	if err != nil {
+		t.Fatalf("OpenFor() error = %v", err)
+	}
+	defer store.Close()
+	if versions, err := store.List(context.Background(), 0); err != nil || len(versions) != 1 {
+		t.Errorf("restored history = %d versions, err %v; want 1", len(versions), err)
+	}
+}
+
+func TestMigrateRestoredDatabase_Upgrades(t *testing.T) {
+	initial, err := os.ReadFile(filepath.Join("..", "adapters", "state", "migrations", "001_initial_schema.sql"))
+	if err != nil {
+		t.Fatalf("reading initial migration: %v", err)
+	}
+	dbPath := filepath.Join(t.TempDir(), "state.db")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	if _, err := db.Exec(string(initial)); err != nil {
+		t.Fatalf("applying initial migration: %v", err)
+	}
+	_ = db.Close()
+
+	report, err := migrateRestoredDatabase(context.Background(), dbPath)
+	if err != nil {
+		t.Fatalf("migrateRestoredDatabase() error = %v", err)
+	}
+	if report.Kind != DatabaseKindState || report.FromVersion != 1 || !report.Upgraded || report.ToVersion <= 1 {
+		t.Errorf("migrateRestoredDatabase() = %+v, want the state database upgraded from v1", report)
+	}
+}
+
+func TestWithoutSQLiteSidecars(t *testing.T) {
+	files := []string{"state.db", "state.db-wal", "state.db-shm", "notes-wal", "chat.db-journal"}
+	got := withoutSQLiteSidecars(files)
+	want := []string{"state.db", "notes-wal", "chat.db-journal"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("withoutSQLiteSidecars() = %v, want %v", got, want)
+	}
+}
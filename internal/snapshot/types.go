@@ -6,7 +6,10 @@ import (
 
 const (
 	// FormatVersion is the current snapshot manifest format version.
-	FormatVersion = 1
+	// Version 2 adds incremental snapshots.
+	FormatVersion = 2
+	// minFormatVersion is the oldest manifest format import still reads.
+	minFormatVersion = 1
 
 	manifestArchivePath     = "manifest.json"
 	registryArchivePath     = "registry/projects.yaml"
@@ -37,6 +40,29 @@ type FileEntry struct {
 	SHA256 string `json:"sha256"`
 	Size   int64  `json:"size"`
 	Mode   int64  `json:"mode"`
+	// Inherited files are unchanged since the parent snapshot and are read
+	// from it instead of being stored again.
+	Inherited bool `json:"inherited,omitempty"`
+}
+
+// ParentRef links an incremental snapshot to the snapshot it builds on.
+type ParentRef struct {
+	// File is the parent archive, relative to the directory of this snapshot.
+	File string `json:"file"`
+	// ManifestSHA256 is the checksum of the parent's manifest, so that a
+	// replaced parent is detected.
+	ManifestSHA256 string    `json:"manifest_sha256"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// DatabaseEntry describes an archived SQLite database. Databases are archived
+// as consistent copies, without their WAL and shared-memory files.
+type DatabaseEntry struct {
+	Path string `json:"path"`
+	// Kind is "state", "chat" or "config_history" for quorum's own
+	// databases, empty otherwise.
+	Kind          string `json:"kind,omitempty"`
+	SchemaVersion int    `json:"schema_version"`
 }
 
 // ProjectEntry captures project metadata embedded in the manifest.
@@ -63,6 +89,20 @@ type Manifest struct {
 	DefaultProjectID    string         `json:"default_project_id,omitempty"`
 	Projects            []ProjectEntry `json:"projects"`
 	Files               []FileEntry    `json:"files"`
+	// Databases lists the files that are SQLite databases.
+	Databases []DatabaseEntry `json:"databases,omitempty"`
+	// Parent is set on incremental snapshots. Files still lists every file,
+	// with the unchanged ones marked inherited.
+	Parent *ParentRef `json:"parent,omitempty"`
+}
+
+// Encryption configures the age encryption of snapshot archives. A passphrase
+// both encrypts and decrypts; recipients (age public keys) encrypt and
+// identity files decrypt. The zero value reads and writes plain archives.
+type Encryption struct {
+	Passphrase    string
+	Recipients    []string
+	IdentityFiles []string
 }
 
 // ExportOptions configures snapshot export behavior.
@@ -73,6 +113,11 @@ type ExportOptions struct {
 	RegistryPath     string
 	GlobalConfigPath string
 	QuorumVersion    string
+
+	// ParentPath makes the export incremental: files unchanged since the
+	// parent snapshot are not stored again.
+	ParentPath string
+	Encryption Encryption
 }
 
 // ExportResult describes an export operation.
@@ -95,6 +140,15 @@ type ImportOptions struct {
 
 	RegistryPath     string
 	GlobalConfigPath string
+
+	// Encryption decrypts the snapshot and its parents.
+	Encryption Encryption
+}
+
+// ValidateOptions configures snapshot validation.
+type ValidateOptions struct {
+	InputPath  string
+	Encryption Encryption
 }
 
 // ProjectImportReport is the per-project result from import.
@@ -106,6 +160,16 @@ type ProjectImportReport struct {
 	Reason   string `json:"reason,omitempty"`
 }
 
+// DatabaseImportReport describes a restored SQLite database and the
+// migrations import ran on it.
+type DatabaseImportReport struct {
+	Path        string `json:"path"`
+	Kind        string `json:"kind,omitempty"`
+	FromVersion int    `json:"from_version"`
+	ToVersion   int    `json:"to_version"`
+	Upgraded    bool   `json:"upgraded"`
+}
+
 // ImportReport summarizes import execution.
 type ImportReport struct {
 	Mode           ImportMode            `json:"mode"`
@@ -117,4 +181,6 @@ type ImportReport struct {
 	Warnings       []string              `json:"warnings,omitempty"`
 	RestoredFiles  int                   `json:"restored_files"`
 	SkippedFiles   int                   `json:"skipped_files"`
+	// Databases are the restored SQLite databases.
+	Databases []DatabaseImportReport `json:"databases,omitempty"`
 }
@@ -3,35 +3,58 @@ package snapshot
 import (
 	"archive/tar"
 	"compress/gzip"
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 )
 
-// ValidateSnapshot verifies archive structure and file checksums and returns the manifest.
-func ValidateSnapshot(inputPath string) (*Manifest, error) {
-	manifest, _, err := loadSnapshotArchive(inputPath)
+// ValidateSnapshot verifies archive structure and file checksums and returns
+// the manifest. Incremental snapshots are verified with their whole chain of
+// parents.
+func ValidateSnapshot(opts *ValidateOptions) (*Manifest, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("options are required")
+	}
+	manifest, _, err := loadSnapshotArchive(opts.InputPath, &opts.Encryption)
 	if err != nil {
 		return nil, err
 	}
 	return manifest, nil
 }
 
+// maxChainLength bounds the snapshots resolved for one incremental snapshot.
+const maxChainLength = 256
+
 type archivedFile struct {
 	Path string
 	Data []byte
 	Mode int64
 }
 
-func loadSnapshotArchive(inputPath string) (*Manifest, map[string]archivedFile, error) {
+// loadSnapshotArchive reads and verifies a snapshot. The returned files
+// include those an incremental snapshot inherits from its parents.
+func loadSnapshotArchive(inputPath string, enc *Encryption) (*Manifest, map[string]archivedFile, error) {
 	if inputPath == "" {
 		return nil, nil, fmt.Errorf("input path is required")
 	}
+	return loadSnapshotChain(inputPath, enc, make(map[string]bool))
+}
 
-	archiveFiles, err := readArchiveFiles(inputPath)
+func loadSnapshotChain(inputPath string, enc *Encryption, seen map[string]bool) (*Manifest, map[string]archivedFile, error) {
+	absPath, err := filepath.Abs(inputPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolving snapshot path: %w", err)
+	}
+	if seen[absPath] {
+		return nil, nil, fmt.Errorf("invalid snapshot chain: %s is its own ancestor", inputPath)
+	}
+	if len(seen) >= maxChainLength {
+		return nil, nil, fmt.Errorf("invalid snapshot chain: more than %d snapshots", maxChainLength)
+	}
+	seen[absPath] = true
+
+	archiveFiles, err := readArchiveFiles(inputPath, enc)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -46,6 +69,12 @@ func loadSnapshotArchive(inputPath string) (*Manifest, map[string]archivedFile,
 		return nil, nil, fmt.Errorf("decoding manifest: %w", err)
 	}
 
+	if manifest.Parent != nil {
+		if err := resolveInheritedFiles(inputPath, manifest, archiveFiles, enc, seen); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	if err := validateArchiveAgainstManifest(manifest, archiveFiles); err != nil {
 		return nil, nil, err
 	}
@@ -53,21 +82,110 @@ func loadSnapshotArchive(inputPath string) (*Manifest, map[string]archivedFile,
 	return manifest, archiveFiles, nil
 }
 
-func readArchiveFiles(inputPath string) (map[string]archivedFile, error) {
+// resolveInheritedFiles loads the parent of an incremental snapshot and adds
+// the files it inherits to archiveFiles.
+func resolveInheritedFiles(inputPath string, manifest *Manifest, archiveFiles map[string]archivedFile, enc *Encryption, seen map[string]bool) error {
+	parentPath := parentSnapshotPath(inputPath, manifest.Parent)
+	_, parentFiles, err := loadSnapshotChain(parentPath, enc, seen)
+	if err != nil {
+		return fmt.Errorf("loading parent snapshot %s: %w", parentPath, err)
+	}
+	if checksum(parentFiles[manifestArchivePath].Data) != manifest.Parent.ManifestSHA256 {
+		return fmt.Errorf("parent snapshot %s does not match the manifest: it was replaced or modified", parentPath)
+	}
+
+	for _, fileEntry := range manifest.Files {
+		if !fileEntry.Inherited {
+			continue
+		}
+		if _, ok := archiveFiles[fileEntry.Path]; ok {
+			return fmt.Errorf("inherited file %s is also stored in the archive", fileEntry.Path)
+		}
+		parentFile, ok := parentFiles[fileEntry.Path]
+		if !ok {
+			return fmt.Errorf("inherited file %s not found in parent snapshot %s", fileEntry.Path, parentPath)
+		}
+		archiveFiles[fileEntry.Path] = archivedFile{
+			Path: fileEntry.Path,
+			Data: parentFile.Data,
+			Mode: fileEntry.Mode,
+		}
+	}
+	return nil
+}
+
+// parentSnapshotPath resolves the parent archive of the snapshot at path.
+func parentSnapshotPath(path string, parent *ParentRef) string {
+	return filepath.Join(filepath.Dir(path), filepath.FromSlash(parent.File))
+}
+
+func readArchiveFiles(inputPath string, enc *Encryption) (map[string]archivedFile, error) {
+	files := make(map[string]archivedFile)
+	err := walkArchive(inputPath, enc, func(entryPath string, header *tar.Header, r io.Reader) error {
+		data, readErr := io.ReadAll(r)
+		if readErr != nil {
+			return fmt.Errorf("reading tar entry %s: %w", entryPath, readErr)
+		}
+		files[entryPath] = archivedFile{
+			Path: entryPath,
+			Data: data,
+			Mode: header.Mode,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// readArchiveManifest returns the manifest of a snapshot and its checksum,
+// without keeping the other entries in memory. The archive itself is not
+// verified.
+func readArchiveManifest(inputPath string, enc *Encryption) (*Manifest, string, error) {
+	var data []byte
+	err := walkArchive(inputPath, enc, func(entryPath string, _ *tar.Header, r io.Reader) error {
+		if entryPath != manifestArchivePath {
+			return nil
+		}
+		var readErr error
+		data, readErr = io.ReadAll(r)
+		return readErr
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if data == nil {
+		return nil, "", fmt.Errorf("snapshot is missing %s", manifestArchivePath)
+	}
+	manifest, err := decodeManifest(data)
+	if err != nil {
+		return nil, "", fmt.Errorf("decoding manifest: %w", err)
+	}
+	return manifest, checksum(data), nil
+}
+
+// walkArchive calls fn for each regular file of a snapshot archive, decrypting
+// it first when it is encrypted.
+func walkArchive(inputPath string, enc *Encryption, fn func(entryPath string, header *tar.Header, r io.Reader) error) error {
 	file, err := os.Open(inputPath) // #nosec G304 -- caller controls path
 	if err != nil {
-		return nil, fmt.Errorf("opening snapshot: %w", err)
+		return fmt.Errorf("opening snapshot: %w", err)
 	}
 	defer file.Close()
 
-	gzReader, err := gzip.NewReader(file)
+	plain, err := decryptReader(file, enc)
 	if err != nil {
-		return nil, fmt.Errorf("opening gzip stream: %w", err)
+		return err
+	}
+
+	gzReader, err := gzip.NewReader(plain)
+	if err != nil {
+		return fmt.Errorf("opening gzip stream: %w", err)
 	}
 	defer gzReader.Close()
 
 	tarReader := tar.NewReader(gzReader)
-	files := make(map[string]archivedFile)
 
 	for {
 		header, err := tarReader.Next()
@@ -75,7 +193,7 @@ func readArchiveFiles(inputPath string) (map[string]archivedFile, error) {
 			break
 		}
 		if err != nil {
-			return nil, fmt.Errorf("reading tar entry: %w", err)
+			return fmt.Errorf("reading tar entry: %w", err)
 		}
 
 		switch header.Typeflag {
@@ -84,27 +202,25 @@ func readArchiveFiles(inputPath string) (map[string]archivedFile, error) {
 		case tar.TypeReg, tar.TypeRegA: //nolint:staticcheck // TypeRegA kept for backward compat with older archives
 			// Regular files are expected.
 		default:
-			return nil, fmt.Errorf("unsupported tar entry type %d for %s", header.Typeflag, header.Name)
+			return fmt.Errorf("unsupported tar entry type %d for %s", header.Typeflag, header.Name)
 		}
 
 		entryPath, cleanErr := cleanArchivePath(filepath.ToSlash(header.Name))
 		if cleanErr != nil {
-			return nil, fmt.Errorf("invalid archive path %q: %w", header.Name, cleanErr)
-		}
-
-		data, readErr := io.ReadAll(tarReader)
-		if readErr != nil {
-			return nil, fmt.Errorf("reading tar entry %s: %w", entryPath, readErr)
+			return fmt.Errorf("invalid archive path %q: %w", header.Name, cleanErr)
 		}
 
-		files[entryPath] = archivedFile{
-			Path: entryPath,
-			Data: data,
-			Mode: header.Mode,
+		if err := fn(entryPath, header, tarReader); err != nil {
+			return err
 		}
 	}
 
-	return files, nil
+	// Read the rest of the stream so that the end of an encrypted archive is
+	// authenticated too.
+	if _, err := io.Copy(io.Discard, plain); err != nil {
+		return fmt.Errorf("reading snapshot: %w", err)
+	}
+	return nil
 }
 
 func validateArchiveAgainstManifest(manifest *Manifest, archiveFiles map[string]archivedFile) error {
@@ -118,12 +234,17 @@ func validateArchiveAgainstManifest(manifest *Manifest, archiveFiles map[string]
 			return fmt.Errorf("size mismatch for %s: manifest=%d archive=%d", fileEntry.Path, fileEntry.Size, len(archiveFile.Data))
 		}
 
-		hash := sha256.Sum256(archiveFile.Data)
-		if hex.EncodeToString(hash[:]) != fileEntry.SHA256 {
+		if checksum(archiveFile.Data) != fileEntry.SHA256 {
 			return fmt.Errorf("checksum mismatch for %s", fileEntry.Path)
 		}
 	}
 
+	for _, db := range manifest.Databases {
+		if _, ok := archiveFiles[db.Path]; !ok {
+			return fmt.Errorf("manifest database not found in archive: %s", db.Path)
+		}
+	}
+
 	if _, ok := archiveFiles[registryArchivePath]; !ok {
 		return fmt.Errorf("snapshot is missing required entry: %s", registryArchivePath)
 	}
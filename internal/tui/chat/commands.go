@@ -154,6 +154,12 @@ func NewCommandRegistry() *CommandRegistry {
 		Usage:       "/retry [task_id]",
 	})
 
+	r.Register(&Command{
+		Name:        "skip",
+		Description: "Skip a pending or running task",
+		Usage:       "/skip <task_id> [reason]",
+	})
+
 	r.Register(&Command{
 		Name:        "cancel",
 		Aliases:     []string{"c", "stop"},
@@ -175,13 +181,46 @@ func NewCommandRegistry() *CommandRegistry {
 		Usage:       "/agent [name]",
 	})
 
+	r.Register(&Command{
+		Name:        "ask-all",
+		Aliases:     []string{"aa"},
+		Description: "Ask several agents at once and synthesize their answers",
+		Usage:       "/ask-all [agent,agent,...] <question>",
+	})
+
+	r.Register(&Command{
+		Name:        "promote",
+		Description: "Draft a workflow from this conversation, then start it",
+		Usage:       "/promote [accept [prompt] | cancel]",
+	})
+
+	r.Register(&Command{
+		Name:        "config",
+		Description: "Set a key in the configuration file",
+		Usage:       "/config set <key> <value>",
+	})
+
 	r.Register(&Command{
 		Name:        "clear",
 		Aliases:     []string{"cls"},
-		Description: "Clear conversation history",
+		Description: "Clear conversation and start a new session",
 		Usage:       "/clear",
 	})
 
+	r.Register(&Command{
+		Name:        "sessions",
+		Aliases:     []string{"ss"},
+		Description: "List saved chat sessions for this project",
+		Usage:       "/sessions",
+	})
+
+	r.Register(&Command{
+		Name:        "resume",
+		Aliases:     []string{"rs"},
+		Description: "Resume a saved chat session",
+		Usage:       "/resume <#n|id>",
+	})
+
 	r.Register(&Command{
 		Name:        "quit",
 		Aliases:     []string{"q", "exit"},
@@ -7,7 +7,11 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/chatcontext"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/chatpromote"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/chatquorum"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/tui"
 )
 
@@ -477,7 +481,144 @@ func (m Model) handleCommandUsePlan(args []string, addSystem func(string)) (tea.
 	return m, m.runUsePlanPhase()
 }
 
-// handleCommandUI handles UI-related commands: help, clear, model, agent, copy, logs, explorer, theme.
+// handleCommandAskAll handles the "/ask-all" command: sends the question to
+// several agents in parallel and has a moderator synthesize their answers.
+// A comma-separated first argument selects the agents.
+func (m Model) handleCommandAskAll(args []string, addSystem func(string)) (tea.Model, tea.Cmd) {
+	if m.agents == nil {
+		addSystem("No agents configured")
+		m.updateViewport()
+		return m, nil
+	}
+	if m.streaming {
+		addSystem("Waiting for the current response. Press Esc to cancel it.")
+		m.updateViewport()
+		return m, nil
+	}
+
+	var requested []string
+	if len(args) > 0 && strings.Contains(args[0], ",") {
+		requested = strings.Split(args[0], ",")
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		addSystem("Usage: /ask-all [agent,agent,...] <question>")
+		m.updateViewport()
+		return m, nil
+	}
+	question := strings.Join(args, " ")
+
+	available := m.availableAgents
+	if len(available) == 0 {
+		available = m.agents.ListEnabled()
+	}
+	targets, err := chatquorum.Targets(requested, m.askAllConfig, available)
+	if err != nil {
+		addSystem("Error: " + err.Error())
+		m.updateViewport()
+		return m, nil
+	}
+	for i := range targets {
+		if targets[i].Agent == m.currentAgent {
+			targets[i].Model = m.currentModel
+		}
+	}
+	moderator := chatquorum.Moderator(m.askAllConfig, targets)
+
+	// Persist the question under the ID of the echoed command so that the
+	// conversation summary can refer to it.
+	group := uuid.New().String()
+	userMsg := NewUserMessage(question)
+	if last := m.history.LastMessage(); last != nil && last.Role == RoleUser {
+		userMsg.ID = last.ID
+	}
+	userMsg.Metadata = map[string]interface{}{"group": group}
+	m.persistUserMessage(userMsg)
+
+	timeout := m.chatTimeout
+	if timeout == 0 {
+		timeout = 20 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	m.cancelFunc = cancel
+	m.streaming = true
+	m.chatStartedAt = time.Now()
+	m.chatAgent = "Quorum"
+	m.chatModel = ""
+
+	names := make([]string, len(targets))
+	for i, t := range targets {
+		names[i] = t.Agent
+	}
+	m.logsPanel.AddInfo("chat", fmt.Sprintf("▶ ask-all %s (moderator: %s, timeout: %s)",
+		strings.Join(names, ", "), moderator.Agent, formatDuration(timeout)))
+	m.updateViewport()
+
+	return m, tea.Batch(m.spinner.Tick, m.askAllWithCtx(ctx, question, group, targets, moderator))
+}
+
+// askAllWithCtx asks the targets the question and synthesizes their answers.
+func (m Model) askAllWithCtx(ctx context.Context, question, group string, targets []chatquorum.Target, moderator chatquorum.Target) tea.Cmd {
+	agents := m.agents
+	builder := m.contextBuilder
+	req := chatcontext.Request{
+		SessionID: m.contextSessionID(),
+		Fixed:     []string{quorumSystemPrompt, question},
+		History:   m.contextTurns(),
+		Config:    m.contextConfig,
+	}
+	if m.sessions != nil {
+		req.Store = m.sessions.store
+	}
+
+	return func() tea.Msg {
+		asker, err := chatquorum.NewAsker(agents)
+		if err != nil {
+			return AskAllResponseMsg{Group: group, Error: err}
+		}
+
+		// Fit the history into each agent's window up front: summarizing
+		// persists state and should not run concurrently.
+		prepared := make(map[string]core.ExecuteOptions, len(targets))
+		var first *chatcontext.Context
+		for _, t := range targets {
+			tReq := req
+			tReq.Agent = t.Agent
+			tReq.Model = t.Model
+			convCtx, err := builder.Build(ctx, tReq)
+			if err != nil {
+				return AskAllResponseMsg{Group: group, Error: err}
+			}
+			if first == nil {
+				first = convCtx
+			}
+			prepared[t.Agent] = core.ExecuteOptions{
+				Prompt:       question,
+				SystemPrompt: quorumSystemPrompt,
+				Messages:     convCtx.Messages(),
+				Model:        t.Model,
+				Format:       core.OutputFormatText,
+				Phase:        core.PhaseExecute,
+			}
+		}
+
+		result, err := asker.Ask(ctx, chatquorum.Request{
+			Question: question,
+			Context:  first.Transcript(),
+			Targets:  targets,
+			Options: func(_ context.Context, t chatquorum.Target) (core.ExecuteOptions, error) {
+				return prepared[t.Agent], nil
+			},
+			Moderator: moderator,
+		})
+		if err != nil && ctx.Err() == context.Canceled {
+			err = fmt.Errorf("request cancelled")
+		}
+		return AskAllResponseMsg{Group: group, Result: result, Error: err, Context: first}
+	}
+}
+
+// handleCommandUI handles UI-related commands: help, clear, sessions, resume, model, agent, copy, logs, explorer, theme.
 // Returns (model, cmd, handled).
 func (m Model) handleCommandUI(cmd *Command, args []string, addSystem func(string)) (tea.Model, tea.Cmd, bool) {
 	switch cmd.Name {
@@ -494,12 +635,49 @@ func (m Model) handleCommandUI(cmd *Command, args []string, addSystem func(strin
 
 	case "clear":
 		m.history.Clear()
+		if m.sessions != nil {
+			// The next message starts a new persisted session.
+			m.sessions.session = nil
+		}
+		m.updateViewport()
+		return m, nil, true
+
+	case "sessions":
+		sessions, err := m.projectSessions(context.Background())
+		if err != nil {
+			addSystem("Error: " + err.Error())
+		} else {
+			addSystem(formatSessionList(sessions, m.SessionID()))
+		}
+		m.updateViewport()
+		return m, nil, true
+
+	case "resume":
+		if len(args) == 0 {
+			addSystem("Usage: /resume <#n|session-id> (use /sessions to list them)")
+			m.updateViewport()
+			return m, nil, true
+		}
+		ctx := context.Background()
+		sess, err := m.findSession(ctx, args[0])
+		if err == nil {
+			err = m.restoreSession(ctx, sess)
+		}
+		if err != nil {
+			addSystem("Error: " + err.Error())
+		} else {
+			m.updateLogsPanelTokenStats()
+			m.updateTokenPanelStats()
+			addSystem(fmt.Sprintf("Resumed session %s (%s, %d messages)",
+				shortSessionID(sess.ID), m.currentAgent, m.history.Len()))
+		}
 		m.updateViewport()
 		return m, nil, true
 
 	case "model":
 		if len(args) > 0 {
 			m.currentModel = args[0]
+			m.persistSessionSettings()
 			addSystem("Model: " + m.currentModel)
 		} else {
 			modelInfo := m.currentModel
@@ -519,6 +697,7 @@ func (m Model) handleCommandUI(cmd *Command, args []string, addSystem func(strin
 		if len(args) > 0 {
 			m.currentAgent = args[0]
 			m.currentModel = ""
+			m.persistSessionSettings()
 			addSystem("Agent: " + m.currentAgent + " (using default model)")
 		} else {
 			modelInfo := m.currentModel
@@ -742,6 +921,168 @@ func (m Model) handleCommandWorkflowOps(cmd *Command, args []string, addSystem f
 		}
 		m.updateViewport()
 		return m, nil, true
+
+	case "skip":
+		if m.controlPlane == nil {
+			addSystem("No control plane")
+			m.updateViewport()
+			return m, nil, true
+		}
+		if len(args) > 0 {
+			m.controlPlane.SkipTask(core.TaskID(args[0]), strings.Join(args[1:], " "))
+			addSystem(fmt.Sprintf("Skipping: %s", args[0]))
+		} else {
+			addSystem("Usage: /skip <task_id> [reason]")
+		}
+		m.updateViewport()
+		return m, nil, true
 	}
 	return m, nil, false
 }
+
+// handleCommandConfig handles the "/config set <key> <value>" command. The
+// change applies to new sessions; the running one keeps its configuration.
+func (m Model) handleCommandConfig(args []string, addSystem func(string)) (tea.Model, tea.Cmd) {
+	if len(args) < 3 || strings.ToLower(args[0]) != "set" {
+		addSystem("Usage: /config set <key> <value>")
+		m.updateViewport()
+		return m, nil
+	}
+	if m.configSetter == nil {
+		addSystem("Configuration file not available")
+		m.updateViewport()
+		return m, nil
+	}
+	key, value := args[1], strings.Join(args[2:], " ")
+	path, err := m.configSetter(key, value)
+	if err != nil {
+		addSystem("Error: " + err.Error())
+	} else {
+		addSystem(fmt.Sprintf("Set %s = %s in %s. Restart the chat to apply it.", key, value, path))
+	}
+	m.updateViewport()
+	return m, nil
+}
+
+// sourceChatRunner is implemented by workflow runners that can link the
+// workflows they create to a chat session.
+type sourceChatRunner interface {
+	SetSourceChat(conv *chatpromote.Conversation, store core.ChatStore)
+}
+
+// handleCommandPromote handles the "/promote" command. Without arguments an
+// agent drafts a workflow prompt from the conversation for review;
+// "accept" starts the workflow with the draft, or with the prompt given after
+// it, and "cancel" discards the draft.
+func (m Model) handleCommandPromote(args []string, addSystem func(string)) (tea.Model, tea.Cmd) {
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+	switch sub {
+	case "cancel":
+		if m.promoteDraft == nil {
+			addSystem("No workflow draft to discard.")
+		} else {
+			m.promoteDraft = nil
+			addSystem("Workflow draft discarded.")
+		}
+		m.updateViewport()
+		return m, nil
+	case "accept":
+		return m.acceptPromoteDraft(strings.TrimSpace(strings.Join(args[1:], " ")), addSystem)
+	case "":
+	default:
+		addSystem("Usage: /promote [accept [prompt] | cancel]")
+		m.updateViewport()
+		return m, nil
+	}
+
+	if m.agents == nil {
+		addSystem("No agents configured")
+		m.updateViewport()
+		return m, nil
+	}
+	if m.streaming {
+		addSystem("Waiting for the current response. Press Esc to cancel it.")
+		m.updateViewport()
+		return m, nil
+	}
+	conv, err := m.promoteConversation(context.Background())
+	if err != nil {
+		addSystem("Error: " + err.Error())
+		m.updateViewport()
+		return m, nil
+	}
+
+	target := chatpromote.DraftTarget(m.contextConfig, m.currentAgent, m.currentModel)
+	timeout := m.chatTimeout
+	if timeout == 0 {
+		timeout = 20 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	m.cancelFunc = cancel
+	m.streaming = true
+	m.chatStartedAt = time.Now()
+	m.chatAgent = target.Agent
+	m.chatModel = target.Model
+	m.logsPanel.AddInfo("chat", fmt.Sprintf("▶ promote: drafting workflow with %s (%d messages)", target.Agent, len(conv.Messages)))
+	m.updateViewport()
+
+	agents := m.agents
+	return m, tea.Batch(m.spinner.Tick, func() tea.Msg {
+		drafter, err := chatpromote.NewDrafter(agents)
+		if err != nil {
+			return PromoteDraftMsg{Error: err}
+		}
+		draft, err := drafter.Draft(ctx, conv, target)
+		if err != nil && ctx.Err() == context.Canceled {
+			err = fmt.Errorf("request cancelled")
+		}
+		return PromoteDraftMsg{Draft: draft, Error: err}
+	})
+}
+
+// acceptPromoteDraft starts the workflow drafted by /promote, linked to the
+// chat session. A non-empty prompt replaces the drafted one.
+func (m Model) acceptPromoteDraft(prompt string, addSystem func(string)) (tea.Model, tea.Cmd) {
+	if m.runner == nil {
+		addSystem("Workflow runner not configured")
+		m.updateViewport()
+		return m, nil
+	}
+	if m.workflowRunning {
+		addSystem("Workflow already running. Use /cancel first.")
+		m.updateViewport()
+		return m, nil
+	}
+	if prompt == "" && m.promoteDraft == nil {
+		addSystem("No workflow draft. Use /promote to draft one, or /promote accept <prompt>.")
+		m.updateViewport()
+		return m, nil
+	}
+	runner, ok := m.runner.(sourceChatRunner)
+	if !ok {
+		addSystem("The workflow runner cannot link workflows to chat sessions.")
+		m.updateViewport()
+		return m, nil
+	}
+	conv, err := m.promoteConversation(context.Background())
+	if err != nil {
+		addSystem("Error: " + err.Error())
+		m.updateViewport()
+		return m, nil
+	}
+
+	if m.promoteDraft != nil {
+		conv.Title = m.promoteDraft.Title
+		if prompt == "" {
+			prompt = m.promoteDraft.Prompt
+		}
+	}
+	m.promoteDraft = nil
+	runner.SetSourceChat(conv, m.sessions.store)
+	addSystem(fmt.Sprintf("Starting workflow %q from this conversation.", conv.WorkflowTitle()))
+	m.updateViewport()
+	return m, m.runWorkflow(conv.Prompt(prompt))
+}
@@ -2,6 +2,7 @@ package chat
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -25,7 +26,9 @@ func (m *Model) handleAgentResponse(msg AgentResponseMsg) {
 			m.logsPanel.AddError(agentLower, fmt.Sprintf("✗ Error after %s: %s", formatDuration(elapsed), errMsg))
 		}
 	} else {
-		m.history.Add(NewAgentMessage(msg.Agent, msg.Content))
+		agentMsg := NewAgentMessage(msg.Agent, msg.Content)
+		m.history.Add(agentMsg)
+		m.persistAgentMessage(agentMsg, msg.Model, msg.TokensIn, msg.TokensOut)
 		// Update token counts for the agent (validate to avoid corrupted values)
 		// Cap matches the adapter-level cap (500k) to ensure consistency
 		const maxReasonableTokens = 500_000
@@ -51,11 +54,119 @@ func (m *Model) handleAgentResponse(msg AgentResponseMsg) {
 		stats = append(stats, formatDuration(elapsed))
 		m.logsPanel.AddSuccess(agentLower, fmt.Sprintf("✓ Response [%s]", strings.Join(stats, " | ")))
 	}
+	if c := msg.Context; c != nil {
+		if c.SummarizedThrough != "" {
+			m.history.MarkSummarized(c.SummarizedThrough)
+		}
+		if notice := c.Notice(); notice != "" {
+			m.logsPanel.AddInfo("chat", "Context: "+notice)
+		}
+		if c.SummaryErr != nil {
+			m.logsPanel.AddWarn("chat", "Summary failed: "+c.SummaryErr.Error())
+		}
+	}
 	m.updateViewport()
 	m.updateLogsPanelTokenStats()
 	m.updateTokenPanelStats()
 }
 
+// handleAskAllResponse processes an AskAllResponseMsg: adds each agent's
+// answer and the moderator's synthesis to the conversation.
+func (m *Model) handleAskAllResponse(msg AskAllResponseMsg) {
+	elapsed := time.Since(m.chatStartedAt)
+	m.streaming = false
+	if msg.Error != nil {
+		m.history.Add(NewSystemMessage("Error: " + msg.Error.Error()))
+		m.logsPanel.AddError("chat", fmt.Sprintf("✗ ask-all failed after %s: %s", formatDuration(elapsed), msg.Error))
+		m.updateViewport()
+		return
+	}
+
+	for _, ans := range msg.Result.Answers {
+		agentLower := strings.ToLower(ans.Agent)
+		if ans.Err != nil {
+			m.history.Add(NewSystemMessage(fmt.Sprintf("Error from %s: %v", ans.Agent, ans.Err)))
+			m.logsPanel.AddError(agentLower, fmt.Sprintf("✗ Error after %s: %s", formatDuration(ans.Duration), ans.Err))
+			continue
+		}
+		agentMsg := NewAgentMessage(ans.Agent, ans.Content)
+		agentMsg.Metadata = map[string]interface{}{"group": msg.Group}
+		m.history.Add(agentMsg)
+		m.persistAgentMessage(agentMsg, ans.Model, ans.TokensIn, ans.TokensOut)
+		m.addAgentTokens(ans.Agent, ans.TokensIn, ans.TokensOut)
+		m.logsPanel.AddSuccess(agentLower, fmt.Sprintf("✓ Response [%d chars | %s]", len(ans.Content), formatDuration(ans.Duration)))
+	}
+
+	if syn := msg.Result.Synthesis; syn != nil {
+		if syn.Err != nil {
+			m.history.Add(NewSystemMessage("Error synthesizing the answers: " + syn.Err.Error()))
+			m.logsPanel.AddWarn("chat", "Synthesis failed: "+syn.Err.Error())
+		} else {
+			synMsg := NewAgentMessage(syn.Agent, syn.Content)
+			synMsg.Metadata = map[string]interface{}{
+				"group":     msg.Group,
+				"consensus": int(math.Round(syn.Score * 100)),
+			}
+			m.history.Add(synMsg)
+			m.persistAgentMessage(synMsg, syn.Model, syn.TokensIn, syn.TokensOut)
+			m.addAgentTokens(syn.Agent, syn.TokensIn, syn.TokensOut)
+			info := fmt.Sprintf("✓ Synthesis by %s: %d%% agreement", syn.Agent, int(math.Round(syn.Score*100)))
+			if len(syn.Divergences) > 0 {
+				info += fmt.Sprintf(", %d divergences", len(syn.Divergences))
+			}
+			m.logsPanel.AddSuccess("chat", fmt.Sprintf("%s (%s)", info, formatDuration(elapsed)))
+		}
+	}
+
+	if c := msg.Context; c != nil && c.SummarizedThrough != "" {
+		m.history.MarkSummarized(c.SummarizedThrough)
+	}
+	m.updateViewport()
+	m.updateLogsPanelTokenStats()
+	m.updateTokenPanelStats()
+}
+
+// handlePromoteDraft processes a PromoteDraftMsg: shows the drafted workflow
+// and keeps it for /promote accept.
+func (m *Model) handlePromoteDraft(msg PromoteDraftMsg) {
+	elapsed := time.Since(m.chatStartedAt)
+	m.streaming = false
+	if msg.Error != nil {
+		m.history.Add(NewSystemMessage("Error: " + msg.Error.Error()))
+		m.logsPanel.AddError("chat", fmt.Sprintf("✗ promote failed after %s: %s", formatDuration(elapsed), msg.Error))
+		m.updateViewport()
+		return
+	}
+
+	draft := msg.Draft
+	m.promoteDraft = draft
+	m.addAgentTokens(draft.Agent, draft.TokensIn, draft.TokensOut)
+	m.history.Add(NewSystemBubbleMessage(fmt.Sprintf(
+		"Workflow draft: %s\n\n%s\n\n/promote accept: start this workflow\n/promote accept <prompt>: start it with your own prompt\n/promote cancel: discard the draft",
+		draft.Title, draft.Prompt)))
+	m.logsPanel.AddSuccess("chat", fmt.Sprintf("✓ Workflow drafted by %s (%s)", draft.Agent, formatDuration(elapsed)))
+	m.updateViewport()
+	m.updateLogsPanelTokenStats()
+	m.updateTokenPanelStats()
+}
+
+// addAgentTokens adds a response's token usage to the agent's totals.
+func (m *Model) addAgentTokens(agent string, tokensIn, tokensOut int) {
+	// Cap matches the adapter-level cap (500k) to ensure consistency
+	const maxReasonableTokens = 500_000
+	for _, a := range m.agentInfos {
+		if strings.EqualFold(a.Name, agent) {
+			if tokensIn > 0 && tokensIn <= maxReasonableTokens {
+				a.TokensIn += tokensIn
+			}
+			if tokensOut > 0 && tokensOut <= maxReasonableTokens {
+				a.TokensOut += tokensOut
+			}
+			return
+		}
+	}
+}
+
 // handleShellOutput processes a ShellOutputMsg: handles shell command output/error
 // and refreshes the explorer panel.
 func (m *Model) handleShellOutput(msg ShellOutputMsg) {
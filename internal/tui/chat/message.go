@@ -167,6 +167,30 @@ func (h *ConversationHistory) Clear() {
 	h.messages = h.messages[:0]
 }
 
+// MarkSummarized flags the messages up to and including the given message as
+// covered by the conversation summary. It does nothing if the message is not
+// in the history.
+func (h *ConversationHistory) MarkSummarized(throughID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i := range h.messages {
+		if h.messages[i].ID != throughID {
+			continue
+		}
+		for j := 0; j <= i; j++ {
+			// Copy the metadata: messages returned by All share their maps.
+			meta := make(map[string]interface{}, len(h.messages[j].Metadata)+1)
+			for k, v := range h.messages[j].Metadata {
+				meta[k] = v
+			}
+			meta["summarized"] = true
+			h.messages[j].Metadata = meta
+		}
+		return
+	}
+}
+
 // LastMessage returns the most recent message, or nil if empty.
 func (h *ConversationHistory) LastMessage() *Message {
 	h.mu.RLock()
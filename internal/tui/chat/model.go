@@ -5,8 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -18,13 +20,19 @@ import (
 	"github.com/charmbracelet/glamour/ansi"
 	"github.com/charmbracelet/glamour/styles"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
 
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/clip"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/control"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/diagnostics"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/logging"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/chatcontext"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/chatpromote"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/chatquorum"
 )
 
 // Color palette - modern dark theme (default)
@@ -308,6 +316,18 @@ type Model struct {
 	currentModel  string
 	workflowState *core.WorkflowState
 
+	// Session persistence in the chat store shared with the web UI (nil when disabled)
+	sessions *sessionStore
+
+	// Conversation context sent to agents: recent turns plus a rolling summary
+	contextBuilder *chatcontext.Builder
+	contextConfig  config.ChatContextConfig
+	askAllConfig   config.ChatAskAllConfig
+	conversationID string // keys the in-memory summary when sessions are not persisted
+
+	// Workflow drafted by /promote, waiting for /promote accept
+	promoteDraft *chatpromote.Draft
+
 	// Agent display state (for compact bar and pipeline)
 	agentInfos     []*AgentInfo
 	workflowPhase  string // "idle", "running", "done"
@@ -325,6 +345,9 @@ type Model struct {
 
 	// Configuration
 	editorCmd string // Editor command for file editing (from config)
+	// configSetter writes a key to the config file for /config set and
+	// returns the file it wrote.
+	configSetter func(key, value string) (string, error)
 
 	// Display state
 	width, height     int
@@ -480,6 +503,8 @@ func NewModel(cp *control.ControlPlane, agents core.AgentRegistry, defaultAgent,
 		machineCollector: diagnostics.NewSystemMetricsCollector(),
 		darkTheme:        true,                 // Default to dark theme
 		messageStyles:    NewMessageStyles(80), // Default width, updated on resize
+		contextBuilder:   chatcontext.NewBuilder(agents),
+		conversationID:   uuid.New().String(),
 	}
 }
 
@@ -502,6 +527,19 @@ func (m Model) WithVersion(version string) Model {
 	return m
 }
 
+// WithContextConfig sets how the conversation history is fitted into the
+// agent's context window.
+func (m Model) WithContextConfig(cfg config.ChatContextConfig) Model {
+	m.contextConfig = cfg
+	return m
+}
+
+// WithAskAllConfig sets the default agents and moderator of /ask-all.
+func (m Model) WithAskAllConfig(cfg config.ChatAskAllConfig) Model {
+	m.askAllConfig = cfg
+	return m
+}
+
 // WithChatConfig sets the chat configuration (timeout, progress interval).
 func (m Model) WithChatConfig(timeout, progressInterval time.Duration) Model {
 	if timeout > 0 {
@@ -530,6 +568,12 @@ func (m Model) WithEditor(editor string) Model {
 	return m
 }
 
+// WithConfigSetter sets the function /config set writes config keys with.
+func (m Model) WithConfigSetter(setter func(key, value string) (string, error)) Model {
+	m.configSetter = setter
+	return m
+}
+
 // Init initializes the model.
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
@@ -711,9 +755,23 @@ type (
 	AgentResponseMsg struct {
 		Agent     string
 		Content   string
+		Model     string
 		TokensIn  int
 		TokensOut int
 		Error     error
+		// Context describes the conversation context sent with the message.
+		Context *chatcontext.Context
+	}
+	AskAllResponseMsg struct {
+		Group  string
+		Result *chatquorum.Result
+		Error  error
+		// Context describes the conversation context sent with the question.
+		Context *chatcontext.Context
+	}
+	PromoteDraftMsg struct {
+		Draft *chatpromote.Draft
+		Error error
 	}
 	WorkflowUpdateMsg struct {
 		State *core.WorkflowState
@@ -1328,10 +1386,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case InputRequestMsg:
 			m.pendingInputRequest = &msg.Request
-			m.history.Add(NewSystemMessage(msg.Request.Prompt))
+			if msg.Request.TaskID != "" {
+				m.history.Add(NewSystemMessage(fmt.Sprintf("Task %s asks: %s", msg.Request.TaskID, msg.Request.Prompt)))
+			} else {
+				m.history.Add(NewSystemMessage(msg.Request.Prompt))
+			}
+			if msg.Request.Context != "" {
+				m.history.Add(NewSystemMessage(msg.Request.Context))
+			}
 			if len(msg.Request.Options) > 0 {
 				m.history.Add(NewSystemMessage("Options: " + strings.Join(msg.Request.Options, ", ")))
 			}
+			if msg.Request.Default != "" {
+				m.history.Add(NewSystemMessage("Press Enter to accept the proposed answer: " + msg.Request.Default))
+			}
 			m.updateViewport()
 			cmds = append(cmds, m.listenForInputRequests())
 
@@ -1375,6 +1443,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case AgentResponseMsg:
 			m.handleAgentResponse(msg)
 
+		case AskAllResponseMsg:
+			m.handleAskAllResponse(msg)
+
+		case PromoteDraftMsg:
+			m.handlePromoteDraft(msg)
+
 		case ShellOutputMsg:
 			m.handleShellOutput(msg)
 
@@ -1461,7 +1535,9 @@ func (m Model) handleSubmit() (tea.Model, tea.Cmd) {
 	}
 
 	// Regular message
-	m.history.Add(NewUserMessage(input))
+	userMsg := NewUserMessage(input)
+	m.history.Add(userMsg)
+	m.persistUserMessage(userMsg)
 	m.updateViewport()
 
 	// Try to send to agent if available
@@ -1585,53 +1661,56 @@ func (m Model) executeShellCommand(cmdStr string) (tea.Model, tea.Cmd) {
 	}
 }
 
-// buildConversationMessages creates a core.Message array from conversation history.
-// This is the preferred method for passing context to API-based adapters.
-func (m Model) buildConversationMessages() []core.Message {
+// contextTurns returns the conversation turns preceding the current message.
+// System messages are not part of the conversation sent to agents.
+func (m Model) contextTurns() []chatcontext.Turn {
 	messages := m.history.All()
-	if len(messages) == 0 {
-		return nil
-	}
-
-	// Include recent messages (limit to avoid token overflow)
-	maxMessages := 20 // Keep last 20 messages for context
-	start := 0
-	if len(messages) > maxMessages {
-		start = len(messages) - maxMessages
-	}
-
-	var result []core.Message
-	for i := start; i < len(messages); i++ {
-		msg := messages[i]
-		switch msg.Role {
-		case RoleUser:
-			result = append(result, core.Message{
-				Role:    "user",
-				Content: msg.Content,
+	// The current message is sent as the prompt.
+	if n := len(messages); n > 0 && messages[n-1].Role == RoleUser {
+		messages = messages[:n-1]
+	}
+	turns := make([]chatcontext.Turn, 0, len(messages))
+	for _, msg := range messages {
+		if msg.Role == RoleUser || msg.Role == RoleAgent {
+			group, _ := msg.Metadata["group"].(string)
+			_, synthesis := msg.Metadata["consensus"]
+			turns = append(turns, chatcontext.Turn{
+				ID:        msg.ID,
+				Role:      string(msg.Role),
+				Content:   msg.Content,
+				Group:     group,
+				Synthesis: synthesis,
 			})
-		case RoleAgent:
-			// Truncate very long responses to save tokens
-			content := msg.Content
-			if len(content) > 2000 {
-				content = content[:2000] + "... [truncated]"
-			}
-			result = append(result, core.Message{
-				Role:    "assistant",
-				Content: content,
-			})
-			// Skip system messages in context
 		}
 	}
+	return turns
+}
 
-	return result
+// contextSessionID returns the ID that keys the conversation's rolling summary.
+func (m Model) contextSessionID() string {
+	if id := m.SessionID(); id != "" {
+		return id
+	}
+	return m.conversationID
 }
 
 // sendToAgentWithCtx sends a message to the specified agent with a cancellable context.
 func (m Model) sendToAgentWithCtx(ctx context.Context, input, agentName string) tea.Cmd {
 	agents := m.agents
 	// Capture values before the goroutine to avoid race conditions
-	conversationMessages := m.buildConversationMessages()
 	currentModel := m.currentModel // Pass the selected model to the agent
+	builder := m.contextBuilder
+	req := chatcontext.Request{
+		SessionID: m.contextSessionID(),
+		Agent:     agentName,
+		Model:     currentModel,
+		Fixed:     []string{quorumSystemPrompt, input},
+		History:   m.contextTurns(),
+		Config:    m.contextConfig,
+	}
+	if m.sessions != nil {
+		req.Store = m.sessions.store
+	}
 
 	return func() tea.Msg {
 		agent, err := agents.Get(agentName)
@@ -1642,11 +1721,20 @@ func (m Model) sendToAgentWithCtx(ctx context.Context, input, agentName string)
 			}
 		}
 
+		// Fit the history into the agent's context window, summarizing older turns.
+		convCtx, err := builder.Build(ctx, req)
+		if err != nil {
+			return AgentResponseMsg{
+				Agent: "Quorum",
+				Error: err,
+			}
+		}
+
 		opts := core.ExecuteOptions{
 			Prompt:       input,
 			SystemPrompt: quorumSystemPrompt,
-			Messages:     conversationMessages, // Pass structured messages
-			Model:        currentModel,         // Use selected model (empty = adapter default)
+			Messages:     convCtx.Messages(), // Pass structured messages
+			Model:        currentModel,       // Use selected model (empty = adapter default)
 			Format:       core.OutputFormatText,
 			Phase:        core.PhaseExecute,
 		}
@@ -1666,11 +1754,17 @@ func (m Model) sendToAgentWithCtx(ctx context.Context, input, agentName string)
 			}
 		}
 
+		model := result.Model
+		if model == "" {
+			model = currentModel
+		}
 		return AgentResponseMsg{
 			Agent:     agentName,
 			Content:   result.Output,
+			Model:     model,
 			TokensIn:  result.TokensIn,
 			TokensOut: result.TokensOut,
+			Context:   convCtx,
 		}
 	}
 }
@@ -1695,13 +1789,19 @@ func (m Model) handleCommand(cmd *Command, args []string) (tea.Model, tea.Cmd) {
 		return m.handleCommandReplan(args, addSystem)
 	case "useplan", "up", "useplans":
 		return m.handleCommandUsePlan(args, addSystem)
+	case "ask-all":
+		return m.handleCommandAskAll(args, addSystem)
+	case "promote":
+		return m.handleCommandPromote(args, addSystem)
+	case "config":
+		return m.handleCommandConfig(args, addSystem)
 	case "quit":
 		m.quitting = true
 		m.explorerPanel.Close()
 		return m, tea.Quit
 	}
 
-	// Dispatch to UI commands (help, clear, model, agent, copy, logs, explorer, theme)
+	// Dispatch to UI commands (help, clear, sessions, resume, model, agent, copy, logs, explorer, theme)
 	if newModel, teaCmd, handled := m.handleCommandUI(cmd, args, addSystem); handled {
 		return newModel, teaCmd
 	}
@@ -1753,6 +1853,36 @@ func (m *Model) updateTokenPanelStats() {
 	}
 	entries := m.collectTokenEntries()
 	m.tokenPanel.SetEntries(entries)
+	m.tokenPanel.SetRateLimits(collectRateLimitEntries(service.GetGlobalRateLimiter().Status(), time.Now()))
+}
+
+// collectRateLimitEntries flattens the status of the rate limiters into
+// panel entries, each agent followed by its models.
+func collectRateLimitEntries(status map[string]service.RateLimiterStatus, now time.Time) []RateLimitEntry {
+	entry := func(cli, model string, s service.RateLimiterStatus) RateLimitEntry {
+		e := RateLimitEntry{
+			CLI:             cli,
+			Model:           model,
+			RequestsPerMin:  s.RefillRate * 60,
+			TokensUsed:      s.TokensUsed,
+			TokensPerMinute: s.TokensPerMinute,
+			RateLimitErrors: s.RateLimitErrors,
+		}
+		if s.PausedUntil != nil && s.PausedUntil.After(now) {
+			e.PausedFor = s.PausedUntil.Sub(now)
+		}
+		return e
+	}
+
+	var entries []RateLimitEntry
+	for _, cli := range slices.Sorted(maps.Keys(status)) {
+		s := status[cli]
+		entries = append(entries, entry(cli, "", s))
+		for _, model := range slices.Sorted(maps.Keys(s.Models)) {
+			entries = append(entries, entry(cli, model, s.Models[model]))
+		}
+	}
+	return entries
 }
 
 func (m *Model) collectTokenEntries() []TokenEntry {
@@ -2060,6 +2190,9 @@ func (m Model) renderHistory() string {
 	for _, msg := range msgs {
 		// Format timestamp
 		timestamp := msg.Timestamp.Format("15:04")
+		if summarized, ok := msg.Metadata["summarized"].(bool); ok && summarized {
+			timestamp += " · summarized"
+		}
 
 		switch msg.Role {
 		case RoleUser:
@@ -98,7 +98,7 @@ func TestModel_UpdateAndView_Smoke(t *testing.T) {
 	}
 }
 
-func TestModel_BuildConversationMessages_Truncates(t *testing.T) {
+func TestModel_ContextTurns(t *testing.T) {
 	m := NewModel(nil, nil, "claude", "default")
 	t.Cleanup(func() {
 		if m.explorerPanel != nil {
@@ -106,16 +106,17 @@ func TestModel_BuildConversationMessages_Truncates(t *testing.T) {
 		}
 	})
 
-	long := strings.Repeat("x", 2500)
 	m.history.Add(NewUserMessage("hi"))
-	m.history.Add(NewAgentMessage("Claude", long))
+	m.history.Add(NewAgentMessage("Claude", "hello"))
+	m.history.Add(NewSystemMessage("Error: boom"))
+	m.history.Add(NewUserMessage("current"))
 
-	msgs := m.buildConversationMessages()
-	if len(msgs) != 2 {
-		t.Fatalf("expected 2 messages, got %d", len(msgs))
+	turns := m.contextTurns()
+	if len(turns) != 2 {
+		t.Fatalf("expected 2 turns, got %d", len(turns))
 	}
-	if got := msgs[1].Content; len(got) <= 2000 {
-		t.Fatalf("expected agent message to be truncated, got len=%d", len(got))
+	if turns[0].Content != "hi" || turns[1].Role != "agent" {
+		t.Fatalf("unexpected turns: %+v", turns)
 	}
 }
 
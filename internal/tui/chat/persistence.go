@@ -0,0 +1,354 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/chatpromote"
+)
+
+// sessionTitleMaxLen bounds the session title derived from the first message.
+const sessionTitleMaxLen = 60
+
+// fileRefPattern matches @path/to/file.ext references inserted from the explorer.
+// It mirrors the pattern the web chat uses to resolve attachments.
+var fileRefPattern = regexp.MustCompile(`@([^\s@]+\.[a-zA-Z0-9]+)`)
+
+// sessionStore links the conversation to a session in the shared chat store, so
+// it can be resumed later from the terminal or continued from the web UI.
+// It is held by pointer so that every copy of the Model shares the same session.
+type sessionStore struct {
+	store       core.ChatStore
+	projectRoot string
+	session     *core.ChatSessionState // nil until the first message is sent
+}
+
+// WithChatStore enables session persistence in the given chat store.
+// projectRoot scopes /sessions to the sessions of the current project.
+func (m Model) WithChatStore(store core.ChatStore, projectRoot string) Model {
+	if store != nil {
+		m.sessions = &sessionStore{store: store, projectRoot: projectRoot}
+	}
+	return m
+}
+
+// ResumeSession restores a persisted session by ID (or unique ID prefix).
+func (m Model) ResumeSession(ctx context.Context, ref string) (Model, error) {
+	sess, err := m.findSession(ctx, ref)
+	if err != nil {
+		return m, err
+	}
+	if err := m.restoreSession(ctx, sess); err != nil {
+		return m, err
+	}
+	return m, nil
+}
+
+// SessionID returns the ID of the persisted session, or "" before the first message.
+func (m Model) SessionID() string {
+	if m.sessions == nil || m.sessions.session == nil {
+		return ""
+	}
+	return m.sessions.session.ID
+}
+
+// projectSessions returns the sessions of the current project, most recent first.
+func (m *Model) projectSessions(ctx context.Context) ([]*core.ChatSessionState, error) {
+	if m.sessions == nil {
+		return nil, fmt.Errorf("chat sessions are not available (no chat store)")
+	}
+	all, err := m.sessions.store.ListSessions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing sessions: %w", err)
+	}
+	sessions := make([]*core.ChatSessionState, 0, len(all))
+	for _, sess := range all {
+		// Sessions without a project root predate project scoping and belong to every project.
+		if sess.ProjectRoot != "" && m.sessions.projectRoot != "" && sess.ProjectRoot != m.sessions.projectRoot {
+			continue
+		}
+		sessions = append(sessions, sess)
+	}
+	return sessions, nil
+}
+
+// findSession resolves a session reference: "#n" or "n" for the n-th entry of
+// /sessions, otherwise a full session ID or a unique prefix of one.
+func (m *Model) findSession(ctx context.Context, ref string) (*core.ChatSessionState, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, fmt.Errorf("session ID required")
+	}
+	sessions, err := m.projectSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if n, err := strconv.Atoi(strings.TrimPrefix(ref, "#")); err == nil {
+		if n < 1 || n > len(sessions) {
+			return nil, fmt.Errorf("no session #%d (use /sessions to list them)", n)
+		}
+		return sessions[n-1], nil
+	}
+
+	var match *core.ChatSessionState
+	for _, sess := range sessions {
+		if sess.ID == ref {
+			return sess, nil
+		}
+		if strings.HasPrefix(sess.ID, ref) {
+			if match != nil {
+				return nil, fmt.Errorf("session prefix %q is ambiguous", ref)
+			}
+			match = sess
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("session not found: %s", ref)
+	}
+	return match, nil
+}
+
+// restoreSession replaces the conversation with a persisted session, restoring
+// its agent, model and per-agent token totals.
+func (m *Model) restoreSession(ctx context.Context, sess *core.ChatSessionState) error {
+	messages, err := m.sessions.store.LoadMessages(ctx, sess.ID)
+	if err != nil {
+		return fmt.Errorf("loading messages: %w", err)
+	}
+
+	m.history.Clear()
+	for _, a := range m.agentInfos {
+		a.TokensIn, a.TokensOut = 0, 0
+	}
+	for _, msg := range messages {
+		m.history.Add(messageFromState(msg))
+		if msg.Role != string(RoleAgent) {
+			continue
+		}
+		for _, a := range m.agentInfos {
+			if strings.EqualFold(a.Name, msg.Agent) {
+				a.TokensIn += msg.TokensIn
+				a.TokensOut += msg.TokensOut
+				if msg.Model != "" {
+					a.Model = msg.Model
+				}
+				break
+			}
+		}
+	}
+
+	if summaries, ok := m.sessions.store.(core.ChatSummaryStore); ok {
+		if summary, err := summaries.LoadSummary(ctx, sess.ID); err == nil && summary != nil {
+			m.history.MarkSummarized(summary.ThroughMessageID)
+		}
+	}
+
+	if sess.Agent != "" {
+		m.currentAgent = sess.Agent
+	}
+	m.currentModel = sess.Model
+	m.sessions.session = sess
+	return nil
+}
+
+// messageFromState converts a persisted message to a conversation message.
+func messageFromState(msg *core.ChatMessageState) Message {
+	m := Message{
+		ID:        msg.ID,
+		Role:      MessageRole(msg.Role),
+		Agent:     msg.Agent,
+		Content:   msg.Content,
+		Timestamp: msg.Timestamp,
+	}
+	if msg.GroupID != "" {
+		m.Metadata = map[string]interface{}{"group": msg.GroupID}
+		if msg.Consensus != nil {
+			m.Metadata["consensus"] = int(math.Round(*msg.Consensus * 100))
+		}
+	}
+	return m
+}
+
+// groupFields returns the ask-all group and consensus score of a message.
+func groupFields(msg Message) (string, *float64) {
+	group, _ := msg.Metadata["group"].(string)
+	consensus, ok := msg.Metadata["consensus"].(int)
+	if !ok {
+		return group, nil
+	}
+	score := float64(consensus) / 100
+	return group, &score
+}
+
+// ensureSession creates the persisted session on the first message.
+func (m *Model) ensureSession(ctx context.Context, firstMessage string) (*core.ChatSessionState, error) {
+	if m.sessions.session != nil {
+		return m.sessions.session, nil
+	}
+	now := time.Now()
+	sess := &core.ChatSessionState{
+		ID:          uuid.New().String(),
+		Title:       sessionTitle(firstMessage),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Agent:       m.currentAgent,
+		Model:       m.currentModel,
+		ProjectRoot: m.sessions.projectRoot,
+	}
+	if err := m.sessions.store.SaveSession(ctx, sess); err != nil {
+		return nil, err
+	}
+	m.sessions.session = sess
+	return sess, nil
+}
+
+// persistUserMessage stores a message typed by the user, recording its @file
+// references as attachments.
+func (m *Model) persistUserMessage(msg Message) {
+	if m.sessions == nil {
+		return
+	}
+	ctx := context.Background()
+	sess, err := m.ensureSession(ctx, msg.Content)
+	if err == nil {
+		group, _ := groupFields(msg)
+		err = m.sessions.store.SaveMessage(ctx, &core.ChatMessageState{
+			ID:          msg.ID,
+			SessionID:   sess.ID,
+			Role:        string(RoleUser),
+			Content:     msg.Content,
+			Timestamp:   msg.Timestamp,
+			Attachments: fileReferences(msg.Content),
+			GroupID:     group,
+		})
+	}
+	if err != nil {
+		m.logsPanel.AddWarn("chat", "Failed to save message: "+err.Error())
+	}
+}
+
+// persistAgentMessage stores an agent response with the model and tokens used.
+func (m *Model) persistAgentMessage(msg Message, model string, tokensIn, tokensOut int) {
+	if m.sessions == nil || m.sessions.session == nil {
+		return
+	}
+	group, consensus := groupFields(msg)
+	err := m.sessions.store.SaveMessage(context.Background(), &core.ChatMessageState{
+		ID:        msg.ID,
+		SessionID: m.sessions.session.ID,
+		Role:      string(RoleAgent),
+		Agent:     msg.Agent,
+		Content:   msg.Content,
+		Timestamp: msg.Timestamp,
+		TokensIn:  tokensIn,
+		TokensOut: tokensOut,
+		Model:     model,
+		GroupID:   group,
+		Consensus: consensus,
+	})
+	if err != nil {
+		m.logsPanel.AddWarn("chat", "Failed to save response: "+err.Error())
+	}
+}
+
+// persistSessionSettings records an agent or model switch on the session.
+func (m *Model) persistSessionSettings() {
+	if m.sessions == nil || m.sessions.session == nil {
+		return
+	}
+	sess := *m.sessions.session
+	sess.Agent = m.currentAgent
+	sess.Model = m.currentModel
+	sess.UpdatedAt = time.Now()
+	if err := m.sessions.store.SaveSession(context.Background(), &sess); err != nil {
+		m.logsPanel.AddWarn("chat", "Failed to save session: "+err.Error())
+		return
+	}
+	m.sessions.session = &sess
+}
+
+// promoteConversation returns the persisted session with its messages, for
+// promotion into a workflow. The session is a copy: the workflow runner
+// records the link on it from another goroutine.
+func (m *Model) promoteConversation(ctx context.Context) (*chatpromote.Conversation, error) {
+	if m.sessions == nil {
+		return nil, fmt.Errorf("promoting needs persisted chat sessions")
+	}
+	if m.sessions.session == nil {
+		return nil, fmt.Errorf("nothing to promote yet: send a message first")
+	}
+	messages, err := m.sessions.store.LoadMessages(ctx, m.sessions.session.ID)
+	if err != nil {
+		return nil, fmt.Errorf("loading messages: %w", err)
+	}
+	sess := *m.sessions.session
+	sess.Agent = m.currentAgent
+	sess.Model = m.currentModel
+	return &chatpromote.Conversation{Session: &sess, Messages: messages}, nil
+}
+
+// sessionTitle derives a session title from the first message.
+func sessionTitle(content string) string {
+	title := strings.Join(strings.Fields(content), " ")
+	if runes := []rune(title); len(runes) > sessionTitleMaxLen {
+		title = string(runes[:sessionTitleMaxLen-3]) + "..."
+	}
+	return title
+}
+
+// fileReferences returns the unique @file references in content.
+func fileReferences(content string) []string {
+	var refs []string
+	seen := make(map[string]bool)
+	for _, match := range fileRefPattern.FindAllStringSubmatch(content, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			refs = append(refs, match[1])
+		}
+	}
+	return refs
+}
+
+// formatSessionList renders the /sessions output.
+func formatSessionList(sessions []*core.ChatSessionState, currentID string) string {
+	if len(sessions) == 0 {
+		return "No saved chat sessions for this project."
+	}
+	var sb strings.Builder
+	sb.WriteString("Chat sessions (most recent first):\n\n")
+	for i, sess := range sessions {
+		marker := " "
+		if sess.ID == currentID {
+			marker = "*"
+		}
+		title := sess.Title
+		if title == "" {
+			title = "(untitled)"
+		}
+		agent := sess.Agent
+		if sess.Model != "" {
+			agent += "/" + sess.Model
+		}
+		fmt.Fprintf(&sb, "%s #%d  %s  %s\n     %s · %s\n", marker, i+1, shortSessionID(sess.ID), title,
+			agent, sess.UpdatedAt.Local().Format("2006-01-02 15:04"))
+	}
+	sb.WriteString("\nUse /resume <#n|id> to continue a session.")
+	return sb.String()
+}
+
+// shortSessionID returns the display prefix of a session ID.
+func shortSessionID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
@@ -0,0 +1,436 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/chatpromote"
+)
+
+// memChatStore is an in-memory core.ChatStore for persistence tests.
+type memChatStore struct {
+	sessions []*core.ChatSessionState
+	messages map[string][]*core.ChatMessageState
+}
+
+func newMemChatStore() *memChatStore {
+	return &memChatStore{messages: make(map[string][]*core.ChatMessageState)}
+}
+
+func (s *memChatStore) SaveSession(_ context.Context, sess *core.ChatSessionState) error {
+	cp := *sess
+	for i, existing := range s.sessions {
+		if existing.ID == sess.ID {
+			s.sessions[i] = &cp
+			return nil
+		}
+	}
+	s.sessions = append([]*core.ChatSessionState{&cp}, s.sessions...)
+	return nil
+}
+
+func (s *memChatStore) LoadSession(_ context.Context, id string) (*core.ChatSessionState, error) {
+	for _, sess := range s.sessions {
+		if sess.ID == id {
+			return sess, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *memChatStore) ListSessions(_ context.Context) ([]*core.ChatSessionState, error) {
+	return s.sessions, nil
+}
+
+func (s *memChatStore) DeleteSession(_ context.Context, id string) error {
+	delete(s.messages, id)
+	return nil
+}
+
+func (s *memChatStore) SaveMessage(_ context.Context, msg *core.ChatMessageState) error {
+	s.messages[msg.SessionID] = append(s.messages[msg.SessionID], msg)
+	return nil
+}
+
+func (s *memChatStore) LoadMessages(_ context.Context, sessionID string) ([]*core.ChatMessageState, error) {
+	return s.messages[sessionID], nil
+}
+
+// submit types input into the model and runs the resulting agent call.
+func submit(t *testing.T, m Model, input string) Model {
+	t.Helper()
+	m.textarea.SetValue(input)
+	updated, cmd := m.handleSubmit()
+	m = updated.(Model)
+	if cmd == nil {
+		return m
+	}
+	if batch, ok := cmd().(tea.BatchMsg); ok {
+		for _, c := range batch {
+			switch resp := c().(type) {
+			case AgentResponseMsg, AskAllResponseMsg, PromoteDraftMsg:
+				updated, _ = m.Update(resp)
+				m = updated.(Model)
+			}
+		}
+	}
+	return m
+}
+
+func TestPersistence_SavesConversation(t *testing.T) {
+	reg := newMockRegistry("claude")
+	reg.agents["claude"] = &mockAgent{name: "claude", execFunc: func(_ context.Context, opts core.ExecuteOptions) (*core.ExecuteResult, error) {
+		return &core.ExecuteResult{Output: "done", Model: "opus", TokensIn: 120, TokensOut: 30}, nil
+	}}
+	store := newMemChatStore()
+	m := NewModel(nil, reg, "claude", "").WithChatConfig(0, 0).WithChatStore(store, "/repo")
+	cleanupModel(t, &m)
+
+	m = submit(t, m, "Review @internal/core/ports.go please")
+
+	if len(store.sessions) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(store.sessions))
+	}
+	sess := store.sessions[0]
+	if sess.Title != "Review @internal/core/ports.go please" || sess.Agent != "claude" || sess.ProjectRoot != "/repo" {
+		t.Errorf("session = %+v", sess)
+	}
+	if m.SessionID() != sess.ID {
+		t.Errorf("SessionID() = %q, want %q", m.SessionID(), sess.ID)
+	}
+
+	msgs := store.messages[sess.ID]
+	if len(msgs) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(msgs))
+	}
+	if msgs[0].Role != "user" || len(msgs[0].Attachments) != 1 || msgs[0].Attachments[0] != "internal/core/ports.go" {
+		t.Errorf("user message = %+v", msgs[0])
+	}
+	if msgs[1].Role != "agent" || msgs[1].Agent != "claude" || msgs[1].Model != "opus" ||
+		msgs[1].TokensIn != 120 || msgs[1].TokensOut != 30 {
+		t.Errorf("agent message = %+v", msgs[1])
+	}
+
+	// Commands are not part of the persisted conversation, but switches are recorded.
+	m.textarea.SetValue("/agent gemini")
+	updated, _ := m.handleSubmit()
+	m = updated.(Model)
+	if store.sessions[0].Agent != "gemini" {
+		t.Errorf("session agent = %q, want gemini", store.sessions[0].Agent)
+	}
+	m.textarea.SetValue("/model flash")
+	updated, _ = m.handleSubmit()
+	m = updated.(Model)
+	if store.sessions[0].Model != "flash" {
+		t.Errorf("session model = %q, want flash", store.sessions[0].Model)
+	}
+	if len(store.messages[sess.ID]) != 2 {
+		t.Errorf("commands should not be persisted, got %d messages", len(store.messages[sess.ID]))
+	}
+
+	// /clear starts a new session on the next message.
+	m.textarea.SetValue("/clear")
+	updated, _ = m.handleSubmit()
+	m = updated.(Model)
+	if m.SessionID() != "" {
+		t.Error("/clear should detach the session")
+	}
+}
+
+func TestPersistence_ResumeSession(t *testing.T) {
+	store := newMemChatStore()
+	now := time.Now()
+	_ = store.SaveSession(context.Background(), &core.ChatSessionState{
+		ID: "a1b2c3d4-0000", Title: "web chat", Agent: "codex", Model: "gpt-5",
+		CreatedAt: now, UpdatedAt: now, ProjectRoot: "/repo",
+	})
+	store.messages["a1b2c3d4-0000"] = []*core.ChatMessageState{
+		{ID: "m1", SessionID: "a1b2c3d4-0000", Role: "user", Content: "hi", Timestamp: now},
+		{ID: "m2", SessionID: "a1b2c3d4-0000", Role: "agent", Agent: "codex", Model: "gpt-5", Content: "hello", Timestamp: now, TokensIn: 10, TokensOut: 4},
+	}
+
+	reg := newMockRegistry("claude", "codex")
+	m := NewModel(nil, reg, "claude", "").WithChatStore(store, "/repo")
+	cleanupModel(t, &m)
+
+	m, err := m.ResumeSession(context.Background(), "a1b2")
+	if err != nil {
+		t.Fatalf("ResumeSession() error = %v", err)
+	}
+	if m.currentAgent != "codex" || m.currentModel != "gpt-5" {
+		t.Errorf("agent/model = %s/%s, want codex/gpt-5", m.currentAgent, m.currentModel)
+	}
+	if m.history.Len() != 2 || m.history.All()[1].Content != "hello" {
+		t.Errorf("history = %+v", m.history.All())
+	}
+	for _, a := range m.agentInfos {
+		if a.Name == "Codex" && (a.TokensIn != 10 || a.TokensOut != 4) {
+			t.Errorf("codex tokens = %d/%d, want 10/4", a.TokensIn, a.TokensOut)
+		}
+	}
+
+	// New messages continue the resumed session.
+	m = submit(t, m, "and then?")
+	if len(store.sessions) != 1 || len(store.messages["a1b2c3d4-0000"]) != 4 {
+		t.Errorf("sessions = %d, messages = %d; want the resumed session to grow",
+			len(store.sessions), len(store.messages["a1b2c3d4-0000"]))
+	}
+}
+
+func TestContext_SummarizesLongConversation(t *testing.T) {
+	var sent []core.Message
+	reg := newMockRegistry("claude")
+	reg.agents["claude"] = &mockAgent{name: "claude", execFunc: func(_ context.Context, opts core.ExecuteOptions) (*core.ExecuteResult, error) {
+		if strings.Contains(opts.Prompt, "running summary") {
+			return &core.ExecuteResult{Output: "they discussed turns 0-15"}, nil
+		}
+		sent = opts.Messages
+		return &core.ExecuteResult{Output: "ok"}, nil
+	}}
+	m := NewModel(nil, reg, "claude", "").
+		WithChatConfig(0, 0).
+		WithContextConfig(config.ChatContextConfig{MaxTokens: 3000, RecentTurns: 2})
+	cleanupModel(t, &m)
+
+	for i := 0; i < 20; i++ {
+		if i%2 == 0 {
+			m.history.Add(NewUserMessage(fmt.Sprintf("question %d %s", i, strings.Repeat("x", 1000))))
+		} else {
+			m.history.Add(NewAgentMessage("Claude", fmt.Sprintf("answer %d %s", i, strings.Repeat("y", 1000))))
+		}
+	}
+	m = submit(t, m, "and now?")
+
+	if len(sent) == 0 || !strings.Contains(sent[0].Content, "they discussed turns 0-15") {
+		t.Fatalf("first message should carry the summary, got %+v", sent)
+	}
+	for _, msg := range sent {
+		if strings.Contains(msg.Content, "and now?") {
+			t.Error("the current message is sent as the prompt, not in the history")
+		}
+	}
+	msgs := m.history.All()
+	if summarized, _ := msgs[0].Metadata["summarized"].(bool); !summarized {
+		t.Error("oldest message should be marked as summarized")
+	}
+	if summarized, _ := msgs[len(msgs)-1].Metadata["summarized"].(bool); summarized {
+		t.Error("latest message should not be marked as summarized")
+	}
+	if !strings.Contains(m.renderHistory(), "summarized") {
+		t.Error("summarized messages should be labelled in the conversation")
+	}
+}
+
+func TestAskAll_AddsAnswersAndSynthesis(t *testing.T) {
+	reg := newMockRegistry("claude", "gemini", "codex")
+	reg.agents["claude"] = &mockAgent{name: "claude", execFunc: func(_ context.Context, opts core.ExecuteOptions) (*core.ExecuteResult, error) {
+		if strings.Contains(opts.Prompt, "consensus_score") {
+			return &core.ExecuteResult{Output: "---\nconsensus_score: 75\n---\n\n## Answer\nUse a mutex.\n\n>> FINAL SCORE: 75 <<"}, nil
+		}
+		return &core.ExecuteResult{Output: "mutex", Model: "opus"}, nil
+	}}
+	reg.agents["gemini"] = &mockAgent{name: "gemini", execFunc: func(context.Context, core.ExecuteOptions) (*core.ExecuteResult, error) {
+		return &core.ExecuteResult{Output: "channel"}, nil
+	}}
+	reg.agents["codex"] = &mockAgent{name: "codex", execFunc: func(context.Context, core.ExecuteOptions) (*core.ExecuteResult, error) {
+		return nil, fmt.Errorf("rate limited")
+	}}
+	store := newMemChatStore()
+	m := NewModel(nil, reg, "claude", "").WithChatConfig(0, 0).WithChatStore(store, "/repo")
+	cleanupModel(t, &m)
+
+	m = submit(t, m, "/ask-all claude,gemini,codex mutex or channel?")
+
+	var agents []string
+	var consensus int
+	for _, msg := range m.history.All() {
+		if msg.Role == RoleAgent {
+			agents = append(agents, msg.Agent)
+			if c, ok := msg.Metadata["consensus"].(int); ok {
+				consensus = c
+			}
+		}
+	}
+	if strings.Join(agents, ",") != "claude,gemini,claude" || consensus != 75 {
+		t.Fatalf("agent messages = %v, consensus = %d; want two answers and the synthesis", agents, consensus)
+	}
+	if !strings.Contains(m.renderHistory(), "rate limited") {
+		t.Error("the failed agent should be reported in the conversation")
+	}
+
+	msgs := store.messages[m.SessionID()]
+	if len(msgs) != 4 || msgs[0].Content != "mutex or channel?" {
+		t.Fatalf("persisted %d messages (%+v), want the question, 2 answers and the synthesis", len(msgs), msgs)
+	}
+	for _, msg := range msgs {
+		if msg.GroupID == "" || msg.GroupID != msgs[0].GroupID {
+			t.Errorf("message %q group = %q, want the question's group", msg.Content, msg.GroupID)
+		}
+	}
+	if msgs[3].Consensus == nil || *msgs[3].Consensus != 0.75 {
+		t.Errorf("synthesis consensus = %v, want 0.75", msgs[3].Consensus)
+	}
+
+	// Resuming keeps the group and the score.
+	restored := messageFromState(msgs[3])
+	if restored.Metadata["group"] != msgs[0].GroupID || restored.Metadata["consensus"] != 75 {
+		t.Errorf("restored metadata = %v", restored.Metadata)
+	}
+
+	m = submit(t, m, "/ask-all claude, what now?")
+	if !strings.Contains(m.renderHistory(), "at least two") {
+		t.Error("a single agent should be rejected")
+	}
+}
+
+// promoteRunner records the chat source and prompt of the workflow it runs.
+type promoteRunner struct {
+	mockWorkflowRunner
+	source *chatpromote.Conversation
+	prompt string
+}
+
+func (r *promoteRunner) SetSourceChat(conv *chatpromote.Conversation, _ core.ChatStore) {
+	r.source = conv
+}
+
+func (r *promoteRunner) Run(_ context.Context, prompt string) error {
+	r.prompt = prompt
+	return nil
+}
+
+func TestPromote_DraftThenAccept(t *testing.T) {
+	reg := newMockRegistry("claude")
+	reg.agents["claude"] = &mockAgent{name: "claude", execFunc: func(_ context.Context, opts core.ExecuteOptions) (*core.ExecuteResult, error) {
+		if strings.Contains(opts.Prompt, "workflow title") {
+			return &core.ExecuteResult{Output: "---\ntitle: Retry 503 responses\n---\nAdd retries with backoff."}, nil
+		}
+		return &core.ExecuteResult{Output: "Use exponential backoff."}, nil
+	}}
+	store := newMemChatStore()
+	runner := &promoteRunner{}
+	m := NewModel(nil, reg, "claude", "").WithChatConfig(0, 0).WithChatStore(store, "/repo")
+	m = m.WithWorkflowRunner(runner, nil, nil)
+	cleanupModel(t, &m)
+
+	m = submit(t, m, "/promote accept")
+	if !strings.Contains(m.renderHistory(), "No workflow draft") {
+		t.Fatal("accept without a draft should be rejected")
+	}
+
+	m = submit(t, m, "Should the client retry on 503?")
+	m = submit(t, m, "/promote")
+	if m.promoteDraft == nil || m.promoteDraft.Title != "Retry 503 responses" {
+		t.Fatalf("draft = %+v", m.promoteDraft)
+	}
+	if !strings.Contains(m.renderHistory(), "Add retries with backoff.") {
+		t.Error("the draft should be shown for review")
+	}
+
+	m = submit(t, m, "/promote accept")
+	if runner.source == nil || runner.source.Session.ID != m.SessionID() || len(runner.source.Messages) != 2 {
+		t.Fatalf("source chat = %+v", runner.source)
+	}
+	if runner.source.WorkflowTitle() != "Retry 503 responses" {
+		t.Errorf("workflow title = %q", runner.source.WorkflowTitle())
+	}
+	if !strings.HasPrefix(runner.prompt, "Add retries with backoff.") {
+		t.Errorf("workflow prompt = %q", runner.prompt)
+	}
+	if m.promoteDraft != nil {
+		t.Error("the draft should be consumed")
+	}
+}
+
+func TestPersistence_FindSession(t *testing.T) {
+	store := newMemChatStore()
+	for _, sess := range []*core.ChatSessionState{
+		{ID: "aaaa1111", ProjectRoot: "/repo"},
+		{ID: "aaaa2222", ProjectRoot: "/repo"},
+		{ID: "bbbb3333", ProjectRoot: "/other"},
+	} {
+		_ = store.SaveSession(context.Background(), sess)
+	}
+	m := NewModel(nil, nil, "claude", "").WithChatStore(store, "/repo")
+	cleanupModel(t, &m)
+	ctx := context.Background()
+
+	sessions, err := m.projectSessions(ctx)
+	if err != nil || len(sessions) != 2 {
+		t.Fatalf("projectSessions() = %d sessions, %v; want 2 (other projects excluded)", len(sessions), err)
+	}
+	if sess, err := m.findSession(ctx, "#1"); err != nil || sess.ID != sessions[0].ID {
+		t.Errorf("findSession(#1) = %v, %v", sess, err)
+	}
+	if sess, err := m.findSession(ctx, "aaaa1"); err != nil || sess.ID != "aaaa1111" {
+		t.Errorf("findSession(prefix) = %v, %v", sess, err)
+	}
+	if _, err := m.findSession(ctx, "aaaa"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("findSession(ambiguous) error = %v", err)
+	}
+	if _, err := m.findSession(ctx, "bbbb3333"); err == nil {
+		t.Error("findSession() should not resolve sessions of other projects")
+	}
+	if _, err := m.findSession(ctx, "#3"); err == nil {
+		t.Error("findSession(#3) should fail")
+	}
+}
+
+func TestPersistence_Disabled(t *testing.T) {
+	m := NewModel(nil, nil, "claude", "")
+	cleanupModel(t, &m)
+
+	m.textarea.SetValue("/sessions")
+	updated, _ := m.handleSubmit()
+	m = updated.(Model)
+	last := m.history.LastMessage()
+	if last == nil || !strings.Contains(last.Content, "not available") {
+		t.Errorf("last message = %+v, want a not-available notice", last)
+	}
+}
+
+func TestSessionTitle(t *testing.T) {
+	if got := sessionTitle("  fix\nthe   bug "); got != "fix the bug" {
+		t.Errorf("sessionTitle() = %q", got)
+	}
+	long := strings.Repeat("x", 100)
+	if got := sessionTitle(long); len([]rune(got)) != sessionTitleMaxLen || !strings.HasSuffix(got, "...") {
+		t.Errorf("sessionTitle(long) = %q", got)
+	}
+}
+
+func TestConfigSet(t *testing.T) {
+	var gotKey, gotValue string
+	m := NewModel(nil, nil, "claude", "").WithChatConfig(0, 0)
+	cleanupModel(t, &m)
+
+	m = submit(t, m, "/config set log.level debug")
+	if !strings.Contains(m.renderHistory(), "Configuration file not available") {
+		t.Fatal("set without a config setter should be rejected")
+	}
+
+	m = m.WithConfigSetter(func(key, value string) (string, error) {
+		gotKey, gotValue = key, value
+		return "/repo/.quorum/config.yaml", nil
+	})
+	m = submit(t, m, "/config set git.pr.title_prefix [quorum] fix")
+	if gotKey != "git.pr.title_prefix" || gotValue != "[quorum] fix" {
+		t.Errorf("setter called with %q = %q", gotKey, gotValue)
+	}
+	if !strings.Contains(m.renderHistory(), "/repo/.quorum/config.yaml") {
+		t.Error("the written file should be reported")
+	}
+
+	gotKey = ""
+	m = submit(t, m, "/config get log.level")
+	if gotKey != "" || !strings.Contains(m.renderHistory(), "Usage: /config set") {
+		t.Error("unknown subcommands should print the usage")
+	}
+}
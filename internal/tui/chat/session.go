@@ -192,6 +192,15 @@ func (s *ChatSession) RetryTask(taskID core.TaskID) error {
 	return nil
 }
 
+// SkipTask queues a task to be skipped.
+func (s *ChatSession) SkipTask(taskID core.TaskID, reason string) error {
+	if s.controlPlane == nil {
+		return fmt.Errorf("no control plane configured")
+	}
+	s.controlPlane.SkipTask(taskID, reason)
+	return nil
+}
+
 // Close cleans up the session.
 func (s *ChatSession) Close() {
 	// Cleanup if needed
@@ -425,6 +425,22 @@ func TestChatSession_RetryTask_WithControlPlane(t *testing.T) {
 	}
 }
 
+func TestChatSession_SkipTask(t *testing.T) {
+	s := NewChatSession(nil, nil, nil)
+	if err := s.SkipTask("task-1", ""); err == nil {
+		t.Error("expected error when no control plane")
+	}
+
+	cp := control.New()
+	s = NewChatSession(nil, cp, nil)
+	if err := s.SkipTask("task-1", "not needed"); err != nil {
+		t.Errorf("SkipTask should succeed: %v", err)
+	}
+	if req := <-cp.GetSkipQueue(); req.TaskID != "task-1" || req.Reason != "not needed" {
+		t.Errorf("skip request = %+v", req)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Close
 // ---------------------------------------------------------------------------
@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/lipgloss"
@@ -20,6 +21,19 @@ type TokenEntry struct {
 	TokensOut int
 }
 
+// RateLimitEntry is the state of the rate limiter of an agent, or of one
+// of its models.
+type RateLimitEntry struct {
+	CLI   string
+	Model string // empty for the agent's own limiter
+	// RequestsPerMin is the current, adapted request rate.
+	RequestsPerMin  float64
+	TokensUsed      int
+	TokensPerMinute int // 0 when the model has no token budget
+	PausedFor       time.Duration
+	RateLimitErrors int
+}
+
 // TokenPanel displays token usage details.
 type TokenPanel struct {
 	mu       sync.Mutex
@@ -29,6 +43,7 @@ type TokenPanel struct {
 	ready    bool
 
 	entries []TokenEntry
+	limits  []RateLimitEntry
 }
 
 // NewTokenPanel creates a new token panel.
@@ -70,6 +85,14 @@ func (p *TokenPanel) SetEntries(entries []TokenEntry) {
 	p.updateContent()
 }
 
+// SetRateLimits updates the rate limiter entries.
+func (p *TokenPanel) SetRateLimits(limits []RateLimitEntry) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.limits = limits
+	p.updateContent()
+}
+
 // Update handles viewport updates.
 func (p *TokenPanel) Update(msg interface{}) {
 	p.mu.Lock()
@@ -175,7 +198,12 @@ func (p *TokenPanel) renderContent() string {
 
 	if len(p.entries) == 0 {
 		sb.WriteString(dimStyle.Render("  No token data yet"))
-		return sb.String()
+		if len(p.limits) == 0 {
+			return sb.String()
+		}
+		sb.WriteString("\n\n")
+		p.renderRateLimits(&sb, innerWidth)
+		return strings.TrimRight(sb.String(), "\n")
 	}
 
 	// Group by scope
@@ -261,9 +289,59 @@ func (p *TokenPanel) renderContent() string {
 	sb.WriteString(truncateToWidth(grandLine, innerWidth))
 	sb.WriteString("\n")
 
+	if len(p.limits) > 0 {
+		sb.WriteString("\n")
+		p.renderRateLimits(&sb, innerWidth)
+	}
+
 	return strings.TrimRight(sb.String(), "\n")
 }
 
+// renderRateLimits writes the rate limits section: the request rate of each
+// agent and model, the tokens used of their budget, and their pauses.
+func (p *TokenPanel) renderRateLimits(sb *strings.Builder, innerWidth int) {
+	sectionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#22d3ee")).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9ca3af"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#f0fdf4")).Bold(true)
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#fbbf24")).Bold(true)
+
+	sb.WriteString(sectionStyle.Render("Rate limits"))
+	sb.WriteString("\n")
+	header := fmt.Sprintf("  %-6s %-10s %-5s %s",
+		labelStyle.Render("CLI"),
+		labelStyle.Render("Model"),
+		labelStyle.Render("req/m"),
+		labelStyle.Render("tok/min"),
+	)
+	sb.WriteString(truncateToWidth(header, innerWidth))
+	sb.WriteString("\n")
+
+	for _, l := range p.limits {
+		model := l.Model
+		if model == "" {
+			model = "-"
+		}
+		budget := "-"
+		if l.TokensPerMinute > 0 {
+			budget = fmt.Sprintf("%s/%s", formatTokenCount(l.TokensUsed), formatTokenCount(l.TokensPerMinute))
+		}
+		line := fmt.Sprintf("  %s %s %s %s",
+			labelStyle.Render(padOrTrim(l.CLI, 6)),
+			labelStyle.Render(padOrTrim(model, 10)),
+			valueStyle.Render(padOrTrim(fmt.Sprintf("%.0f", l.RequestsPerMin), 5)),
+			valueStyle.Render(budget),
+		)
+		switch {
+		case l.PausedFor > 0:
+			line += " " + warnStyle.Render("paused "+l.PausedFor.Round(time.Second).String())
+		case l.RateLimitErrors > 0:
+			line += " " + warnStyle.Render(fmt.Sprintf("%d limited", l.RateLimitErrors))
+		}
+		sb.WriteString(truncateToWidth(line, innerWidth))
+		sb.WriteString("\n")
+	}
+}
+
 func padOrTrim(s string, width int) string {
 	trimmed := truncateToWidth(s, width)
 	pad := width - lipgloss.Width(trimmed)
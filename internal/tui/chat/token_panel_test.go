@@ -3,6 +3,9 @@ package chat
 import (
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service"
 )
 
 // ---------------------------------------------------------------------------
@@ -380,3 +383,39 @@ func TestTokenPanel_Render_WithScroll(t *testing.T) {
 		t.Error("Should render something with scroll indicator")
 	}
 }
+
+func TestTokenPanel_RenderContent_RateLimits(t *testing.T) {
+	p := NewTokenPanel()
+	p.mu.Lock()
+	p.width = 80
+	p.limits = []RateLimitEntry{
+		{CLI: "claude", RequestsPerMin: 30, RateLimitErrors: 2},
+		{CLI: "claude", Model: "opus", RequestsPerMin: 15, TokensUsed: 12000, TokensPerMinute: 40000, PausedFor: 25 * time.Second},
+	}
+	p.mu.Unlock()
+
+	content := p.renderContent()
+	for _, want := range []string{"No token data yet", "Rate limits", "2 limited", "paused 25s", "12.0k/40.0k"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("content should contain %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestCollectRateLimitEntries(t *testing.T) {
+	now := time.Now()
+	until := now.Add(time.Minute)
+	entries := collectRateLimitEntries(map[string]service.RateLimiterStatus{
+		"gemini": {RefillRate: 1},
+		"claude": {RefillRate: 0.5, RateLimitErrors: 1, Models: map[string]service.RateLimiterStatus{
+			"opus": {RefillRate: 0.25, PausedUntil: &until, TokensPerMinute: 40000, TokensUsed: 100},
+		}},
+	}, now)
+
+	if len(entries) != 3 || entries[0].CLI != "claude" || entries[1].Model != "opus" || entries[2].CLI != "gemini" {
+		t.Fatalf("entries = %+v, want claude, claude/opus, gemini", entries)
+	}
+	if entries[0].RequestsPerMin != 30 || entries[1].PausedFor != time.Minute || entries[1].TokensPerMinute != 40000 {
+		t.Errorf("entries = %+v", entries)
+	}
+}
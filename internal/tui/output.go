@@ -1,12 +1,16 @@
 package tui
 
 import (
+	"io"
+	"os"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/control"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
 )
 
 // Output is the interface for all output handlers.
@@ -334,81 +338,237 @@ func (f *FallbackOutputAdapter) Close() error {
 	return nil
 }
 
-// JSONOutputAdapter adapts JSONOutput to the Output interface.
+// JSONOutputAdapter implements Output as a versioned NDJSON event stream.
+// Callbacks are converted to internal/events types and published on the
+// command's EventBus (the same bus type the server streams over SSE); a
+// subscriber serializes everything published on the bus with
+// events.NDJSONWriter, so every line carries a sequence number, the schema
+// version, workflow/project IDs and a timestamp.
 type JSONOutputAdapter struct {
-	json *JSONOutput
+	bus     *events.EventBus
+	ownsBus bool
+	sub     <-chan events.Event
+	writer  *events.NDJSONWriter
+	done    chan struct{}
+
+	mu         sync.Mutex
+	workflowID string
+	projectID  string
+	phase      string
+	startedAt  time.Time
+	closeOnce  sync.Once
 }
 
-// NewJSONOutputAdapter creates a new adapter.
+// NewJSONOutputAdapter creates a new adapter writing to stdout.
 func NewJSONOutputAdapter() *JSONOutputAdapter {
-	return &JSONOutputAdapter{
-		json: NewJSONOutput(),
+	return NewJSONOutputAdapterWithWriter(os.Stdout)
+}
+
+// NewJSONOutputAdapterWithWriter creates a new adapter writing to w.
+func NewJSONOutputAdapterWithWriter(w io.Writer) *JSONOutputAdapter {
+	return NewJSONOutputAdapterWithBus(w, nil)
+}
+
+// NewJSONOutputAdapterWithBus creates a new adapter writing to w every event
+// published on bus, its own and those of the other publishers of the bus.
+// A nil bus is replaced by one owned by the adapter; a shared bus stays open
+// when the adapter is closed.
+func NewJSONOutputAdapterWithBus(w io.Writer, bus *events.EventBus) *JSONOutputAdapter {
+	j := &JSONOutputAdapter{
+		bus:    bus,
+		writer: events.NewNDJSONWriter(w),
+		done:   make(chan struct{}),
+	}
+	if j.bus == nil {
+		j.bus = events.New(100)
+		j.ownsBus = true
 	}
+	// Complete subscription: CI consumers must never miss an event, so
+	// publishers block instead of dropping when the writer falls behind.
+	j.sub = j.bus.SubscribeComplete()
+	go func() {
+		defer close(j.done)
+		_ = j.writer.Drain(j.sub) // Errors on stdout encoding are non-recoverable
+	}()
+	return j
+}
+
+// SetProjectID sets the project ID attached to every emitted event.
+func (j *JSONOutputAdapter) SetProjectID(projectID string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.projectID = projectID
+}
+
+// ids returns the current workflow and project IDs.
+func (j *JSONOutputAdapter) ids() (workflowID, projectID string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.workflowID, j.projectID
+}
+
+// trackState records the workflow ID once the runner has assigned one.
+func (j *JSONOutputAdapter) trackState(state *core.WorkflowState) {
+	if state == nil || state.WorkflowID == "" {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.workflowID = string(state.WorkflowID)
+}
+
+func (j *JSONOutputAdapter) publish(event events.Event) {
+	j.bus.PublishPriority(event)
 }
 
 // WorkflowStarted implements Output.
 func (j *JSONOutputAdapter) WorkflowStarted(prompt string) {
-	j.json.WorkflowStarted(prompt)
+	j.mu.Lock()
+	j.startedAt = time.Now()
+	j.mu.Unlock()
+	wf, proj := j.ids()
+	j.publish(events.NewWorkflowStartedEvent(wf, proj, prompt))
 }
 
 // PhaseStarted implements Output.
 func (j *JSONOutputAdapter) PhaseStarted(phase core.Phase) {
-	j.json.PhaseStarted(phase)
+	j.mu.Lock()
+	j.phase = string(phase)
+	j.mu.Unlock()
+	wf, proj := j.ids()
+	j.publish(events.NewPhaseStartedEvent(wf, proj, string(phase)))
+}
+
+// PhaseAwaitingReview emits a phase_awaiting_review event for interactive review gates.
+func (j *JSONOutputAdapter) PhaseAwaitingReview(phase string) {
+	wf, proj := j.ids()
+	j.publish(events.NewPhaseAwaitingReviewEvent(wf, proj, phase))
 }
 
 // TaskStarted implements Output.
 func (j *JSONOutputAdapter) TaskStarted(task *core.Task) {
-	j.json.emit("task_started", map[string]interface{}{
-		"task_id": task.ID,
-		"name":    task.Name,
-	})
+	wf, proj := j.ids()
+	j.publish(events.NewTaskStartedEvent(wf, proj, string(task.ID), ""))
 }
 
 // TaskCompleted implements Output.
 func (j *JSONOutputAdapter) TaskCompleted(task *core.Task, duration time.Duration) {
-	j.json.TaskCompleted(task, duration)
+	wf, proj := j.ids()
+	j.publish(events.NewTaskCompletedEvent(wf, proj, string(task.ID), duration, task.TokensIn, task.TokensOut))
 }
 
 // TaskFailed implements Output.
 func (j *JSONOutputAdapter) TaskFailed(task *core.Task, err error) {
-	j.json.TaskFailed(task, err)
+	wf, proj := j.ids()
+	j.publish(events.NewTaskFailedEvent(wf, proj, string(task.ID), err, false))
+}
+
+// TaskRetry emits a task_retry event.
+func (j *JSONOutputAdapter) TaskRetry(task *core.Task, attempt, maxAttempts int, err error) {
+	wf, proj := j.ids()
+	j.publish(events.NewTaskRetryEvent(wf, proj, string(task.ID), attempt, maxAttempts, err))
+}
+
+// TaskInputRequested emits a user_input_requested event for a task question.
+func (j *JSONOutputAdapter) TaskInputRequested(task *core.Task, req control.InputRequest) {
+	wf, proj := j.ids()
+	evt := events.NewUserInputRequestedEvent(wf, proj, req.ID, req.Prompt, req.Options)
+	evt.TaskID = string(task.ID)
+	evt.Context = req.Context
+	evt.Default = req.Default
+	evt.Timeout = req.Timeout
+	j.publish(evt)
+}
+
+// TaskInputResolved emits a user_input_provided event for a task question.
+func (j *JSONOutputAdapter) TaskInputResolved(task *core.Task, requestID, answer string, cancelled bool) {
+	wf, proj := j.ids()
+	evt := events.NewUserInputProvidedEvent(wf, proj, requestID, answer, cancelled)
+	evt.TaskID = string(task.ID)
+	j.publish(evt)
 }
 
 // WorkflowStateUpdated implements Output.
 func (j *JSONOutputAdapter) WorkflowStateUpdated(state *core.WorkflowState) {
-	j.json.emit("workflow_state_updated", map[string]interface{}{
-		"phase":         string(state.CurrentPhase),
-		"total_tasks":   len(state.Tasks),
-		"current_phase": string(state.CurrentPhase),
-	})
+	j.trackState(state)
+	var completed, failed, skipped int
+	for _, task := range state.Tasks {
+		switch task.Status {
+		case core.TaskStatusCompleted:
+			completed++
+		case core.TaskStatusFailed:
+			failed++
+		case core.TaskStatusSkipped:
+			skipped++
+		}
+	}
+	wf, proj := j.ids()
+	j.publish(events.NewWorkflowStateUpdatedEvent(wf, proj, string(state.CurrentPhase), len(state.Tasks), completed, failed, skipped))
 }
 
 // TaskSkipped implements Output.
 func (j *JSONOutputAdapter) TaskSkipped(task *core.Task, reason string) {
-	j.json.emit("task_skipped", map[string]interface{}{
-		"task_id": task.ID,
-		"name":    task.Name,
-		"reason":  reason,
-	})
+	wf, proj := j.ids()
+	j.publish(events.NewTaskSkippedEvent(wf, proj, string(task.ID), reason))
+}
+
+// ModeratorRound emits a moderator_round event.
+func (j *JSONOutputAdapter) ModeratorRound(round int, score, threshold float64, agreements, divergences int) {
+	wf, proj := j.ids()
+	j.publish(events.NewModeratorRoundEvent(wf, proj, round, score, threshold, agreements, divergences))
+}
+
+// AgentEvent emits an agent_event for agent streaming events, including chunks.
+func (j *JSONOutputAdapter) AgentEvent(kind, agent, message string, data map[string]interface{}) {
+	wf, proj := j.ids()
+	j.publish(events.NewAgentStreamEvent(wf, proj, events.AgentEventType(kind), agent, message).WithData(data))
 }
 
 // WorkflowCompleted implements Output.
+// It emits a final metrics_update followed by workflow_completed.
 func (j *JSONOutputAdapter) WorkflowCompleted(state *core.WorkflowState) {
-	j.json.WorkflowCompleted(state)
+	j.trackState(state)
+	j.mu.Lock()
+	duration := time.Duration(0)
+	if !j.startedAt.IsZero() {
+		duration = time.Since(j.startedAt)
+	}
+	j.mu.Unlock()
+	wf, proj := j.ids()
+	if state.Metrics != nil {
+		j.publish(events.NewMetricsUpdateEvent(wf, proj,
+			state.Metrics.TotalTokensIn, state.Metrics.TotalTokensOut, state.Metrics.ConsensusScore, duration))
+	}
+	j.publish(events.NewWorkflowCompletedEvent(wf, proj, duration))
 }
 
 // WorkflowFailed implements Output.
 func (j *JSONOutputAdapter) WorkflowFailed(err error) {
-	j.json.WorkflowFailed(err)
+	j.mu.Lock()
+	phase := j.phase
+	j.mu.Unlock()
+	wf, proj := j.ids()
+	j.publish(events.NewWorkflowFailedEvent(wf, proj, phase, err))
 }
 
 // Log implements Output.
 func (j *JSONOutputAdapter) Log(level, message string) {
-	j.json.Log(level, message)
+	wf, proj := j.ids()
+	j.publish(events.NewLogEvent(wf, proj, level, message, nil))
 }
 
 // Close implements Output.
+// It stops the subscription, closing the bus when the adapter owns it, and
+// waits until every event has been written.
 func (j *JSONOutputAdapter) Close() error {
+	j.closeOnce.Do(func() {
+		if j.ownsBus {
+			j.bus.Close()
+		} else {
+			j.bus.Unsubscribe(j.sub)
+		}
+		<-j.done
+	})
 	return nil
 }
 
@@ -457,13 +617,20 @@ func (q *QuietOutput) Close() error {
 
 // NewOutput creates an output handler based on the output mode.
 func NewOutput(mode OutputMode, useColor, verbose bool) Output {
+	return NewOutputWithEventBus(mode, useColor, verbose, nil)
+}
+
+// NewOutputWithEventBus creates an output handler based on the output mode.
+// In JSON mode the handler streams the events of bus (see
+// NewJSONOutputAdapterWithBus).
+func NewOutputWithEventBus(mode OutputMode, useColor, verbose bool, bus *events.EventBus) Output {
 	switch mode {
 	case ModeTUI:
 		return NewTUIOutput()
 	case ModePlain:
 		return NewFallbackOutputAdapter(useColor, verbose)
 	case ModeJSON:
-		return NewJSONOutputAdapter()
+		return NewJSONOutputAdapterWithBus(os.Stdout, bus)
 	case ModeQuiet:
 		return NewQuietOutput()
 	default:
@@ -521,9 +688,66 @@ func (a *OutputNotifierAdapter) Log(level, source, message string) {
 	a.output.Log(level, fullMessage)
 }
 
+// agentEventOutput is implemented by outputs that stream agent events natively.
+type agentEventOutput interface {
+	AgentEvent(kind, agent, message string, data map[string]interface{})
+}
+
+// PhaseAwaitingReview forwards interactive review gates to outputs that support them.
+func (a *OutputNotifierAdapter) PhaseAwaitingReview(phase string) {
+	if o, ok := a.output.(interface{ PhaseAwaitingReview(phase string) }); ok {
+		o.PhaseAwaitingReview(phase)
+	}
+}
+
+// ModeratorRound forwards moderator evaluations to outputs that support them.
+func (a *OutputNotifierAdapter) ModeratorRound(round int, score, threshold float64, agreements, divergences int) {
+	type moderatorRoundOutput interface {
+		ModeratorRound(round int, score, threshold float64, agreements, divergences int)
+	}
+	if o, ok := a.output.(moderatorRoundOutput); ok {
+		o.ModeratorRound(round, score, threshold, agreements, divergences)
+	}
+}
+
+// TaskRetry forwards task retries to outputs that support them.
+func (a *OutputNotifierAdapter) TaskRetry(task *core.Task, attempt, maxAttempts int, err error) {
+	type taskRetryOutput interface {
+		TaskRetry(task *core.Task, attempt, maxAttempts int, err error)
+	}
+	if o, ok := a.output.(taskRetryOutput); ok {
+		o.TaskRetry(task, attempt, maxAttempts, err)
+	}
+}
+
+// TaskInputRequested forwards task questions to outputs that support them.
+func (a *OutputNotifierAdapter) TaskInputRequested(task *core.Task, req control.InputRequest) {
+	type taskInputOutput interface {
+		TaskInputRequested(task *core.Task, req control.InputRequest)
+	}
+	if o, ok := a.output.(taskInputOutput); ok {
+		o.TaskInputRequested(task, req)
+	}
+}
+
+// TaskInputResolved forwards the outcome of task questions to outputs that support them.
+func (a *OutputNotifierAdapter) TaskInputResolved(task *core.Task, requestID, answer string, cancelled bool) {
+	type taskInputOutput interface {
+		TaskInputResolved(task *core.Task, requestID, answer string, cancelled bool)
+	}
+	if o, ok := a.output.(taskInputOutput); ok {
+		o.TaskInputResolved(task, requestID, answer, cancelled)
+	}
+}
+
 // AgentEvent implements workflow.OutputNotifier.
-// For the run command, we log agent events as regular log messages.
-func (a *OutputNotifierAdapter) AgentEvent(kind, agent, message string, _ map[string]interface{}) {
+// Outputs that stream agent events natively (JSON) receive them as-is;
+// otherwise agent events are logged as regular log messages.
+func (a *OutputNotifierAdapter) AgentEvent(kind, agent, message string, data map[string]interface{}) {
+	if o, ok := a.output.(agentEventOutput); ok {
+		o.AgentEvent(kind, agent, message, data)
+		return
+	}
 	// Format agent events as log messages for the run command output
 	prefix := "[" + agent + "]"
 	switch kind {
@@ -644,6 +868,41 @@ func (t *TracingOutputNotifierAdapter) AgentEvent(kind, agent, message string, d
 	}
 }
 
+// PhaseAwaitingReview delegates to base.
+func (t *TracingOutputNotifierAdapter) PhaseAwaitingReview(phase string) {
+	if t.base != nil {
+		t.base.PhaseAwaitingReview(phase)
+	}
+}
+
+// ModeratorRound delegates to base.
+func (t *TracingOutputNotifierAdapter) ModeratorRound(round int, score, threshold float64, agreements, divergences int) {
+	if t.base != nil {
+		t.base.ModeratorRound(round, score, threshold, agreements, divergences)
+	}
+}
+
+// TaskRetry delegates to base.
+func (t *TracingOutputNotifierAdapter) TaskRetry(task *core.Task, attempt, maxAttempts int, err error) {
+	if t.base != nil {
+		t.base.TaskRetry(task, attempt, maxAttempts, err)
+	}
+}
+
+// TaskInputRequested delegates to base.
+func (t *TracingOutputNotifierAdapter) TaskInputRequested(task *core.Task, req control.InputRequest) {
+	if t.base != nil {
+		t.base.TaskInputRequested(task, req)
+	}
+}
+
+// TaskInputResolved delegates to base.
+func (t *TracingOutputNotifierAdapter) TaskInputResolved(task *core.Task, requestID, answer string, cancelled bool) {
+	if t.base != nil {
+		t.base.TaskInputResolved(task, requestID, answer, cancelled)
+	}
+}
+
 // Close closes the trace notifier.
 func (t *TracingOutputNotifierAdapter) Close() error {
 	if t.tracer != nil {
@@ -2,7 +2,6 @@ package tui
 
 import (
 	"bytes"
-	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -111,10 +110,8 @@ func TestOutputNotifierAdapter_WithFallback(t *testing.T) {
 
 func TestJSONOutputAdapter_AllMethods(t *testing.T) {
 	t.Parallel()
-	adapter := NewJSONOutputAdapter()
 	buf := &bytes.Buffer{}
-	adapter.json.writer = buf
-	adapter.json.enc = json.NewEncoder(buf)
+	adapter := NewJSONOutputAdapterWithWriter(buf)
 
 	// Test all methods
 	adapter.WorkflowStarted("test prompt")
@@ -8,7 +8,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/control"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
 )
 
 func TestNewOutput(t *testing.T) {
@@ -67,7 +69,9 @@ func TestFallbackOutputAdapter_Interface(t *testing.T) {
 
 func TestJSONOutputAdapter_Interface(t *testing.T) {
 	t.Parallel()
-	adapter := NewJSONOutputAdapter()
+	// Redirect output for testing
+	buf := &bytes.Buffer{}
+	adapter := NewJSONOutputAdapterWithWriter(buf)
 
 	// Test that it implements Output interface
 	var _ Output = adapter
@@ -77,11 +81,6 @@ func TestJSONOutputAdapter_Interface(t *testing.T) {
 		Name: "Test Task",
 	}
 
-	// Redirect output for testing
-	buf := &bytes.Buffer{}
-	adapter.json.writer = buf
-	adapter.json.enc = json.NewEncoder(buf)
-
 	testErr := core.ErrValidation("TEST", "test error")
 
 	adapter.WorkflowStarted("test prompt")
@@ -250,3 +249,103 @@ func TestTUIOutput_CriticalEventsDelivered(t *testing.T) {
 	t.Logf("Sent %d critical events, dropped %d normal events",
 		criticalSent, output.DroppedEvents())
 }
+
+func TestJSONOutputAdapter_NDJSONStream(t *testing.T) {
+	t.Parallel()
+	buf := &bytes.Buffer{}
+	adapter := NewJSONOutputAdapterWithWriter(buf)
+	adapter.SetProjectID("proj-1")
+	notifier := NewOutputNotifierAdapter(adapter)
+
+	task := &core.Task{ID: "task-1", Name: "Test Task"}
+	adapter.WorkflowStarted("prompt")
+	adapter.WorkflowStateUpdated(&core.WorkflowState{
+		WorkflowDefinition: core.WorkflowDefinition{WorkflowID: "wf-1"},
+		WorkflowRun:        core.WorkflowRun{Tasks: make(map[core.TaskID]*core.TaskState)},
+	})
+	notifier.AgentEvent("chunk", "claude", "partial", nil)
+	notifier.ModeratorRound(1, 0.8, 0.9, 3, 2)
+	notifier.TaskRetry(task, 1, 3, fmt.Errorf("rate limited"))
+	notifier.PhaseAwaitingReview("plan")
+	notifier.TaskInputRequested(task, control.InputRequest{ID: "req-1", Prompt: "Which cache?"})
+	notifier.TaskInputResolved(task, "req-1", "redis", false)
+	_ = adapter.Close()
+
+	wantTypes := []string{
+		"workflow_started", "workflow_state_updated", "agent_event",
+		"moderator_round", "task_retry", "phase_awaiting_review",
+		"user_input_requested", "user_input_provided",
+	}
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != len(wantTypes) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(wantTypes), buf.String())
+	}
+	for i, line := range lines {
+		var got struct {
+			Type       string `json:"type"`
+			Seq        int    `json:"seq"`
+			WorkflowID string `json:"workflow_id"`
+			ProjectID  string `json:"project_id"`
+		}
+		if err := json.Unmarshal(line, &got); err != nil {
+			t.Fatalf("line %d: %v", i+1, err)
+		}
+		if got.Type != wantTypes[i] {
+			t.Errorf("line %d type = %q, want %q", i+1, got.Type, wantTypes[i])
+		}
+		if got.Seq != i+1 {
+			t.Errorf("line %d seq = %d, want %d", i+1, got.Seq, i+1)
+		}
+		if got.ProjectID != "proj-1" {
+			t.Errorf("line %d project_id = %q, want proj-1", i+1, got.ProjectID)
+		}
+		// The workflow ID is only known once the runner reports state.
+		if i > 0 && got.WorkflowID != "wf-1" {
+			t.Errorf("line %d workflow_id = %q, want wf-1", i+1, got.WorkflowID)
+		}
+	}
+}
+
+func TestJSONOutputAdapter_SharedEventBus(t *testing.T) {
+	t.Parallel()
+	bus := events.New(10)
+	defer bus.Close()
+	other := bus.SubscribeComplete()
+
+	buf := &bytes.Buffer{}
+	adapter := NewJSONOutputAdapterWithBus(buf, bus)
+	adapter.WorkflowStarted("prompt")
+	bus.Publish(events.NewConfigLoadedEvent("wf-1", "", "config.yaml", "project", "custom", "", "", 1, "", ""))
+	_ = adapter.Close()
+
+	wantTypes := []string{"workflow_started", "config_loaded"}
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != len(wantTypes) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(wantTypes), buf.String())
+	}
+	for i, line := range lines {
+		var got struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(line, &got); err != nil {
+			t.Fatalf("line %d: %v", i+1, err)
+		}
+		if got.Type != wantTypes[i] {
+			t.Errorf("line %d type = %q, want %q", i+1, got.Type, wantTypes[i])
+		}
+	}
+
+	// The other subscribers see the same stream, and the shared bus stays
+	// open after the adapter is closed.
+	bus.PublishPriority(events.NewLogEvent("", "", "info", "after close", nil))
+	for _, want := range append(wantTypes, "log") {
+		select {
+		case event := <-other:
+			if event.EventType() != want {
+				t.Errorf("other subscriber got %q, want %q", event.EventType(), want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("other subscriber did not get %q", want)
+		}
+	}
+}
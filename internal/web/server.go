@@ -12,6 +12,7 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/rs/cors"
 
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/agenthealth"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/api"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/config"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
@@ -19,7 +20,9 @@ import (
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/events"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/kanban"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/project"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/runqueue"
 	"github.com/hugo-lorenzo-mato/quorum-ai/internal/service/workflow"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/worker"
 )
 
 // Server represents the HTTP server for the Quorum web interface.
@@ -40,6 +43,9 @@ type Server struct {
 	unifiedTracker   *api.UnifiedTracker        // for centralized workflow tracking
 	projectRegistry  project.Registry           // for multi-project support
 	statePool        *project.StatePool         // for multi-project context management
+	workers          *worker.Coordinator        // for remote task execution
+	runQueue         *runqueue.Dispatcher       // for queued workflow runs
+	agentHealth      *agenthealth.Monitor       // for agent quarantine and failover
 	apiServer        *api.Server
 }
 
@@ -158,6 +164,27 @@ func WithStatePool(pool *project.StatePool) ServerOption {
 	}
 }
 
+// WithWorkerCoordinator sets the coordinator of the remote workers.
+func WithWorkerCoordinator(coordinator *worker.Coordinator) ServerOption {
+	return func(s *Server) {
+		s.workers = coordinator
+	}
+}
+
+// WithRunQueue sets the dispatcher of the durable run queue.
+func WithRunQueue(dispatcher *runqueue.Dispatcher) ServerOption {
+	return func(s *Server) {
+		s.runQueue = dispatcher
+	}
+}
+
+// WithAgentHealth sets the agent health monitor shared by all runners.
+func WithAgentHealth(monitor *agenthealth.Monitor) ServerOption {
+	return func(s *Server) {
+		s.agentHealth = monitor
+	}
+}
+
 // New creates a new Server instance with the given configuration.
 func New(cfg Config, logger *slog.Logger, opts ...ServerOption) *Server {
 	if logger == nil {
@@ -204,6 +231,15 @@ func New(cfg Config, logger *slog.Logger, opts ...ServerOption) *Server {
 		if s.statePool != nil {
 			apiOpts = append(apiOpts, api.WithStatePool(s.statePool))
 		}
+		if s.workers != nil {
+			apiOpts = append(apiOpts, api.WithWorkerCoordinator(s.workers))
+		}
+		if s.runQueue != nil {
+			apiOpts = append(apiOpts, api.WithRunQueue(s.runQueue))
+		}
+		if s.agentHealth != nil {
+			apiOpts = append(apiOpts, api.WithAgentHealth(s.agentHealth))
+		}
 		s.apiServer = api.NewServer(s.stateManager, s.eventBus, apiOpts...)
 		if s.agentRegistry != nil && s.stateManager != nil {
 			s.logger.Info("API server initialized with event bus, agent registry, and state manager")
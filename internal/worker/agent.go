@@ -0,0 +1,107 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// transferGrace is added to the task timeout for moving bundles to and from
+// the worker.
+const transferGrace = time.Minute
+
+// Registry wraps an agent registry so that the agents it returns run
+// execute-phase tasks on a remote worker when one has the agent. Other
+// phases, and tasks no worker can take, run locally.
+type Registry struct {
+	core.AgentRegistry
+	coordinator *Coordinator
+	handler     core.AgentEventHandler
+	logger      *slog.Logger
+}
+
+// NewRegistry wraps registry. Events streamed by workers are passed to
+// handler, which may be nil.
+func NewRegistry(registry core.AgentRegistry, coordinator *Coordinator, handler core.AgentEventHandler) *Registry {
+	return &Registry{
+		AgentRegistry: registry,
+		coordinator:   coordinator,
+		handler:       handler,
+		logger:        coordinator.logger,
+	}
+}
+
+// Get returns the agent, dispatching its execute-phase tasks to workers.
+func (r *Registry) Get(name string) (core.Agent, error) {
+	agent, err := r.AgentRegistry.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return &remoteAgent{Agent: agent, name: name, registry: r, handler: r.handler}, nil
+}
+
+// remoteAgent runs execute-phase tasks on a worker, falling back to the
+// local agent.
+type remoteAgent struct {
+	core.Agent
+	name     string
+	registry *Registry
+
+	mu      sync.RWMutex
+	handler core.AgentEventHandler
+}
+
+// SetEventHandler sets the handler for local and remote events.
+func (a *remoteAgent) SetEventHandler(handler core.AgentEventHandler) {
+	a.mu.Lock()
+	a.handler = handler
+	a.mu.Unlock()
+	if sc, ok := a.Agent.(core.StreamingCapable); ok {
+		sc.SetEventHandler(handler)
+	}
+}
+
+// Execute runs the task on a worker when it is an execute-phase task in a
+// clean git checkout and a worker has the agent.
+func (a *remoteAgent) Execute(ctx context.Context, opts core.ExecuteOptions) (*core.ExecuteResult, error) {
+	coordinator := a.registry.coordinator
+	if opts.Phase != core.PhaseExecute || opts.WorkDir == "" || !coordinator.HasWorkerFor(a.name) {
+		return a.Agent.Execute(ctx, opts)
+	}
+
+	base, bundle, err := bundleHead(ctx, opts.WorkDir)
+	if err != nil {
+		a.registry.logger.Info("running task locally",
+			slog.String("agent", a.name), slog.String("work_dir", opts.WorkDir), slog.String("reason", err.Error()))
+		return a.Agent.Execute(ctx, opts)
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout+transferGrace)
+		defer cancel()
+	}
+	a.mu.RLock()
+	handler := a.handler
+	a.mu.RUnlock()
+
+	result, err := coordinator.Run(ctx, newJob(a.name, opts, base, bundle), handler)
+	if errors.Is(err, ErrNoWorker) {
+		// The last worker left since HasWorkerFor.
+		return a.Agent.Execute(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Bundle) > 0 {
+		if err := applyResult(ctx, opts.WorkDir, base, result.Bundle); err != nil {
+			return nil, fmt.Errorf("applying worker changes: %w", err)
+		}
+	}
+	return result.executeResult(), nil
+}
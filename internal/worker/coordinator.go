@@ -0,0 +1,471 @@
+package worker
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// ErrNoWorker is returned when no registered worker has the requested agent.
+var ErrNoWorker = errors.New("no worker available for the agent")
+
+const (
+	// DefaultTimeout is how long a worker may stay silent before it is
+	// considered lost.
+	DefaultTimeout = time.Minute
+	// pollTimeout bounds a worker's long poll for its next job.
+	pollTimeout = 25 * time.Second
+	// maxResultBytes bounds job results, which carry a git bundle.
+	maxResultBytes = 1 << 30
+)
+
+// CoordinatorOptions configures a Coordinator.
+type CoordinatorOptions struct {
+	// Token, when set, must be presented by workers as a bearer token.
+	Token string
+	// Timeout after which a silent worker is considered lost and its jobs
+	// fail (default DefaultTimeout).
+	Timeout time.Duration
+	Logger  *slog.Logger
+}
+
+// Coordinator tracks the registered workers and dispatches jobs to them.
+type Coordinator struct {
+	token   string
+	timeout time.Duration
+	logger  *slog.Logger
+
+	mu      sync.Mutex
+	workers map[string]*workerState
+}
+
+// workerState is a registered worker and its jobs.
+type workerState struct {
+	id           string
+	reg          Registration
+	registeredAt time.Time
+	lastSeen     time.Time
+	queue        []*jobState
+	running      map[string]*jobState
+	// wake is signaled when a job is queued for the worker.
+	wake chan struct{}
+}
+
+// jobState is a dispatched job awaiting its result.
+type jobState struct {
+	job     *Job
+	handler core.AgentEventHandler
+	done    chan struct{}
+	result  *JobResult
+	err     error
+	// cancelled is set when the result is no longer awaited; the worker
+	// learns it from its next heartbeat.
+	cancelled bool
+}
+
+// NewCoordinator creates a coordinator.
+func NewCoordinator(opts CoordinatorOptions) *Coordinator {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+	return &Coordinator{
+		token:   opts.Token,
+		timeout: opts.Timeout,
+		logger:  opts.Logger,
+		workers: make(map[string]*workerState),
+	}
+}
+
+// HasWorkerFor reports whether a registered worker has the agent.
+func (c *Coordinator) HasWorkerFor(agent string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expireLocked(time.Now())
+	for _, w := range c.workers {
+		if slices.Contains(w.reg.Agents, agent) {
+			return true
+		}
+	}
+	return false
+}
+
+// Workers returns the registered workers, sorted by name.
+func (c *Coordinator) Workers() []Info {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expireLocked(time.Now())
+	infos := make([]Info, 0, len(c.workers))
+	for _, w := range c.workers {
+		infos = append(infos, Info{
+			ID:           w.id,
+			Name:         w.reg.Name,
+			Agents:       w.reg.Agents,
+			Capacity:     w.reg.Capacity,
+			Version:      w.reg.Version,
+			RunningJobs:  len(w.running),
+			QueuedJobs:   len(w.queue),
+			RegisteredAt: w.registeredAt,
+			LastSeen:     w.lastSeen,
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Name != infos[j].Name {
+			return infos[i].Name < infos[j].Name
+		}
+		return infos[i].ID < infos[j].ID
+	})
+	return infos
+}
+
+// Run dispatches job to the least loaded worker having its agent and waits
+// for the result. Events streamed by the worker are passed to handler. It
+// returns ErrNoWorker when no worker has the agent.
+func (c *Coordinator) Run(ctx context.Context, job *Job, handler core.AgentEventHandler) (*JobResult, error) {
+	job.ID = uuid.New().String()
+	js := &jobState{job: job, handler: handler, done: make(chan struct{})}
+
+	c.mu.Lock()
+	c.expireLocked(time.Now())
+	w := c.pickLocked(job.Agent)
+	if w == nil {
+		c.mu.Unlock()
+		return nil, ErrNoWorker
+	}
+	w.queue = append(w.queue, js)
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+	c.mu.Unlock()
+
+	c.logger.Info("dispatched task to worker",
+		slog.String("job_id", job.ID), slog.String("agent", job.Agent), slog.String("worker", w.reg.Name))
+
+	// Lost workers are only noticed when the coordinator looks at them, so
+	// look regularly while waiting.
+	ticker := time.NewTicker(c.timeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-js.done:
+			return js.result, js.err
+		case <-ticker.C:
+			c.mu.Lock()
+			c.expireLocked(time.Now())
+			c.mu.Unlock()
+		case <-ctx.Done():
+			c.cancel(w, js)
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// pickLocked returns the live worker having agent with the lowest load
+// relative to its capacity.
+func (c *Coordinator) pickLocked(agent string) *workerState {
+	var best *workerState
+	var bestLoad float64
+	for _, w := range c.workers {
+		if !slices.Contains(w.reg.Agents, agent) {
+			continue
+		}
+		load := float64(len(w.queue)+len(w.running)) / float64(w.reg.Capacity)
+		if best == nil || load < bestLoad || (load == bestLoad && w.registeredAt.Before(best.registeredAt)) {
+			best, bestLoad = w, load
+		}
+	}
+	return best
+}
+
+// cancel gives up on js: a queued job is dropped, a running one is reported
+// as cancelled to the worker.
+func (c *Coordinator) cancel(w *workerState, js *jobState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if i := slices.Index(w.queue, js); i >= 0 {
+		w.queue = slices.Delete(w.queue, i, i+1)
+		return
+	}
+	js.cancelled = true
+}
+
+// expireLocked drops the workers not seen within the timeout and fails
+// their jobs.
+func (c *Coordinator) expireLocked(now time.Time) {
+	for id, w := range c.workers {
+		if now.Sub(w.lastSeen) > c.timeout {
+			c.logger.Warn("worker lost", slog.String("worker", w.reg.Name), slog.String("worker_id", id))
+			c.removeLocked(w, fmt.Errorf("worker %s lost", w.reg.Name))
+		}
+	}
+}
+
+// removeLocked unregisters w and fails its jobs with err.
+func (c *Coordinator) removeLocked(w *workerState, err error) {
+	delete(c.workers, w.id)
+	for _, js := range w.queue {
+		js.finish(nil, err)
+	}
+	for _, js := range w.running {
+		js.finish(nil, err)
+	}
+	w.queue = nil
+	w.running = map[string]*jobState{}
+}
+
+func (js *jobState) finish(result *JobResult, err error) {
+	js.result, js.err = result, err
+	close(js.done)
+}
+
+// RegisterRoutes registers the endpoints used by workers.
+func (c *Coordinator) RegisterRoutes(r chi.Router) {
+	r.Route("/workers", func(r chi.Router) {
+		r.Use(c.authenticate)
+		r.Get("/", c.handleList)
+		r.Post("/register", c.handleRegister)
+		r.Route("/{workerID}", func(r chi.Router) {
+			r.Delete("/", c.handleUnregister)
+			r.Post("/heartbeat", c.handleHeartbeat)
+			r.Get("/jobs/next", c.handleNextJob)
+			r.Post("/jobs/{jobID}/events", c.handleEvents)
+			r.Post("/jobs/{jobID}/result", c.handleResult)
+		})
+	})
+}
+
+// authenticate checks the bearer token when one is configured.
+func (c *Coordinator) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c.token != "" {
+			got, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(got), []byte(c.token)) != 1 {
+				respondError(w, http.StatusUnauthorized, "invalid worker token")
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (c *Coordinator) handleList(w http.ResponseWriter, _ *http.Request) {
+	respondJSON(w, http.StatusOK, c.Workers())
+}
+
+func (c *Coordinator) handleRegister(w http.ResponseWriter, r *http.Request) {
+	var reg Registration
+	if err := json.NewDecoder(r.Body).Decode(&reg); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if strings.TrimSpace(reg.Name) == "" {
+		respondError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	if len(reg.Agents) == 0 {
+		respondError(w, http.StatusBadRequest, "at least one agent is required")
+		return
+	}
+	if reg.Capacity < 1 {
+		reg.Capacity = 1
+	}
+
+	now := time.Now()
+	ws := &workerState{
+		id:           uuid.New().String(),
+		reg:          reg,
+		registeredAt: now,
+		lastSeen:     now,
+		running:      make(map[string]*jobState),
+		wake:         make(chan struct{}, 1),
+	}
+	c.mu.Lock()
+	c.workers[ws.id] = ws
+	c.mu.Unlock()
+
+	c.logger.Info("worker registered",
+		slog.String("worker", reg.Name), slog.String("worker_id", ws.id),
+		slog.Any("agents", reg.Agents), slog.Int("capacity", reg.Capacity))
+	respondJSON(w, http.StatusOK, RegisterResponse{WorkerID: ws.id, HeartbeatInterval: c.timeout / 3})
+}
+
+// worker returns the worker of the request and marks it as seen, or
+// responds 404 so that the worker registers again.
+func (c *Coordinator) worker(w http.ResponseWriter, r *http.Request) *workerState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expireLocked(time.Now())
+	ws, ok := c.workers[chi.URLParam(r, "workerID")]
+	if !ok {
+		respondError(w, http.StatusNotFound, "worker not registered")
+		return nil
+	}
+	ws.lastSeen = time.Now()
+	return ws
+}
+
+func (c *Coordinator) handleUnregister(w http.ResponseWriter, r *http.Request) {
+	ws := c.worker(w, r)
+	if ws == nil {
+		return
+	}
+	c.mu.Lock()
+	c.removeLocked(ws, fmt.Errorf("worker %s stopped", ws.reg.Name))
+	c.mu.Unlock()
+	c.logger.Info("worker unregistered", slog.String("worker", ws.reg.Name), slog.String("worker_id", ws.id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *Coordinator) handleHeartbeat(w http.ResponseWriter, r *http.Request) {
+	ws := c.worker(w, r)
+	if ws == nil {
+		return
+	}
+	var hb Heartbeat
+	c.mu.Lock()
+	for id, js := range ws.running {
+		if js.cancelled {
+			hb.CancelledJobs = append(hb.CancelledJobs, id)
+			delete(ws.running, id)
+		}
+	}
+	c.mu.Unlock()
+	respondJSON(w, http.StatusOK, hb)
+}
+
+// handleNextJob long-polls for the next job queued for the worker.
+func (c *Coordinator) handleNextJob(w http.ResponseWriter, r *http.Request) {
+	ws := c.worker(w, r)
+	if ws == nil {
+		return
+	}
+	timer := time.NewTimer(pollTimeout)
+	defer timer.Stop()
+	for {
+		c.mu.Lock()
+		if _, ok := c.workers[ws.id]; !ok {
+			c.mu.Unlock()
+			respondError(w, http.StatusNotFound, "worker not registered")
+			return
+		}
+		ws.lastSeen = time.Now()
+		if len(ws.queue) > 0 {
+			js := ws.queue[0]
+			ws.queue = ws.queue[1:]
+			ws.running[js.job.ID] = js
+			if len(ws.queue) > 0 {
+				// Wake another poll of the worker for the next job.
+				select {
+				case ws.wake <- struct{}{}:
+				default:
+				}
+			}
+			c.mu.Unlock()
+			respondJSON(w, http.StatusOK, js.job)
+			return
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-ws.wake:
+		case <-timer.C:
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// job returns the running job of the request, or responds 404 when the
+// result is no longer awaited.
+func (c *Coordinator) job(w http.ResponseWriter, r *http.Request) (*workerState, *jobState) {
+	ws := c.worker(w, r)
+	if ws == nil {
+		return nil, nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	js, ok := ws.running[chi.URLParam(r, "jobID")]
+	if !ok || js.cancelled {
+		respondError(w, http.StatusNotFound, "job not found")
+		return nil, nil
+	}
+	return ws, js
+}
+
+func (c *Coordinator) handleEvents(w http.ResponseWriter, r *http.Request) {
+	_, js := c.job(w, r)
+	if js == nil {
+		return
+	}
+	var events []core.AgentEvent
+	if err := json.NewDecoder(r.Body).Decode(&events); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if js.handler != nil {
+		for _, event := range events {
+			js.handler(event)
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *Coordinator) handleResult(w http.ResponseWriter, r *http.Request) {
+	ws, js := c.job(w, r)
+	if js == nil {
+		return
+	}
+	var result JobResult
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxResultBytes)).Decode(&result); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	c.mu.Lock()
+	if _, ok := ws.running[js.job.ID]; !ok {
+		// Failed meanwhile because the worker was considered lost.
+		c.mu.Unlock()
+		respondError(w, http.StatusNotFound, "job not found")
+		return
+	}
+	delete(ws.running, js.job.ID)
+	c.mu.Unlock()
+
+	var err error
+	if result.Error != "" {
+		err = fmt.Errorf("worker %s: %s", ws.reg.Name, result.Error)
+	}
+	js.finish(&result, err)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func respondJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		slog.Error("failed to encode response", "error", err)
+	}
+}
+
+func respondError(w http.ResponseWriter, status int, message string) {
+	respondJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,109 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func newTestCoordinator(t *testing.T, opts CoordinatorOptions) (*Coordinator, *httptest.Server) {
+	t.Helper()
+	c := NewCoordinator(opts)
+	r := chi.NewRouter()
+	r.Route("/api/v1", c.RegisterRoutes)
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return c, srv
+}
+
+func registerWorker(t *testing.T, srv *httptest.Server, token string, reg Registration) (*http.Response, RegisterResponse) {
+	t.Helper()
+	body, _ := json.Marshal(reg)
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+APIPath+"/register", bytes.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("registering worker: %v", err)
+	}
+	defer resp.Body.Close()
+	var out RegisterResponse
+	_ = json.NewDecoder(resp.Body).Decode(&out)
+	return resp, out
+}
+
+func TestCoordinator_PicksByCapabilityAndLoad(t *testing.T) {
+	t.Parallel()
+
+	c := NewCoordinator(CoordinatorOptions{})
+	now := time.Now()
+	w1 := &workerState{id: "w1", reg: Registration{Name: "w1", Agents: []string{"claude"}, Capacity: 1},
+		registeredAt: now, lastSeen: now, running: map[string]*jobState{}}
+	w2 := &workerState{id: "w2", reg: Registration{Name: "w2", Agents: []string{"claude", "gemini"}, Capacity: 2},
+		registeredAt: now.Add(time.Second), lastSeen: now, running: map[string]*jobState{}}
+	c.workers = map[string]*workerState{"w1": w1, "w2": w2}
+
+	if got := c.pickLocked("gemini"); got != w2 {
+		t.Errorf("pickLocked(gemini) = %v, want w2", got)
+	}
+	if got := c.pickLocked("claude"); got != w1 {
+		t.Errorf("pickLocked(claude) with idle workers = %v, want the earliest registered w1", got)
+	}
+	w1.running["a"] = &jobState{}
+	w2.running["b"] = &jobState{}
+	if got := c.pickLocked("claude"); got != w2 {
+		t.Errorf("pickLocked(claude) with w1 full = %v, want w2 at half capacity", got)
+	}
+	if got := c.pickLocked("codex"); got != nil {
+		t.Errorf("pickLocked(codex) = %v, want nil", got)
+	}
+	if _, err := c.Run(context.Background(), &Job{Agent: "codex"}, nil); !errors.Is(err, ErrNoWorker) {
+		t.Errorf("Run(codex) error = %v, want ErrNoWorker", err)
+	}
+}
+
+func TestCoordinator_RequiresToken(t *testing.T) {
+	t.Parallel()
+
+	c, srv := newTestCoordinator(t, CoordinatorOptions{Token: "secret"})
+	reg := Registration{Name: "w1", Agents: []string{"claude"}}
+	if resp, _ := registerWorker(t, srv, "wrong", reg); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("register with a wrong token status = %d, want 401", resp.StatusCode)
+	}
+	resp, out := registerWorker(t, srv, "secret", reg)
+	if resp.StatusCode != http.StatusOK || out.WorkerID == "" {
+		t.Fatalf("register status = %d, response = %+v", resp.StatusCode, out)
+	}
+	if workers := c.Workers(); len(workers) != 1 || workers[0].Capacity != 1 {
+		t.Errorf("Workers() = %+v, want one worker with capacity 1", workers)
+	}
+}
+
+func TestCoordinator_FailsJobsOfLostWorker(t *testing.T) {
+	t.Parallel()
+
+	c, srv := newTestCoordinator(t, CoordinatorOptions{Timeout: 100 * time.Millisecond})
+	if resp, _ := registerWorker(t, srv, "", Registration{Name: "w1", Agents: []string{"claude"}}); resp.StatusCode != http.StatusOK {
+		t.Fatalf("register status = %d", resp.StatusCode)
+	}
+
+	// The worker never polls, so it is lost before taking the job.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := c.Run(ctx, &Job{Agent: "claude"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "w1 lost") {
+		t.Errorf("Run() error = %v, want the worker lost", err)
+	}
+	if workers := c.Workers(); len(workers) != 0 {
+		t.Errorf("Workers() = %+v, want none", workers)
+	}
+}
@@ -0,0 +1,155 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// errDirtyWorkDir is returned for a directory with uncommitted changes, which
+// a bundle of its HEAD would not carry to the worker.
+var errDirtyWorkDir = errors.New("working directory has uncommitted changes")
+
+// Commits made by workers are only transport: the coordinator applies their
+// tree as staged changes and the executor commits them with its own identity.
+const (
+	workerCommitName  = "quorum-worker"
+	workerCommitEmail = "quorum-worker@localhost"
+)
+
+// runGit runs git in dir and returns its trimmed standard output.
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %s: %w", strings.Join(args, " "), strings.TrimSpace(stderr.String()), err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// bundleHead returns the commit checked out in workDir and a bundle of it.
+// The directory must be a git checkout without uncommitted changes.
+func bundleHead(ctx context.Context, workDir string) (base string, bundle []byte, err error) {
+	status, err := runGit(ctx, workDir, "status", "--porcelain")
+	if err != nil {
+		return "", nil, err
+	}
+	if status != "" {
+		return "", nil, errDirtyWorkDir
+	}
+	base, err = runGit(ctx, workDir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", nil, err
+	}
+	bundle, err = createBundle(ctx, workDir, "HEAD")
+	if err != nil {
+		return "", nil, err
+	}
+	return base, bundle, nil
+}
+
+// createBundle returns a git bundle of the given revisions of the repository
+// in dir.
+func createBundle(ctx context.Context, dir string, revs ...string) ([]byte, error) {
+	tmp, err := os.MkdirTemp("", "quorum-bundle-")
+	if err != nil {
+		return nil, fmt.Errorf("creating bundle directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "repo.bundle")
+	if _, err := runGit(ctx, dir, append([]string{"bundle", "create", "--quiet", path}, revs...)...); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading bundle: %w", err)
+	}
+	return data, nil
+}
+
+// fetchBundle fetches the HEAD of bundle into the repository in dir and
+// returns the fetched commit.
+func fetchBundle(ctx context.Context, dir string, bundle []byte) (string, error) {
+	tmp, err := os.MkdirTemp("", "quorum-bundle-")
+	if err != nil {
+		return "", fmt.Errorf("creating bundle directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "repo.bundle")
+	if err := os.WriteFile(path, bundle, 0o600); err != nil {
+		return "", fmt.Errorf("writing bundle: %w", err)
+	}
+	if _, err := runGit(ctx, dir, "fetch", "--quiet", "--no-tags", path, "HEAD"); err != nil {
+		return "", err
+	}
+	return runGit(ctx, dir, "rev-parse", "FETCH_HEAD")
+}
+
+// checkoutJob creates a repository in dir with the job's base commit
+// checked out.
+func checkoutJob(ctx context.Context, dir string, job *Job) error {
+	if _, err := runGit(ctx, "", "init", "--quiet", dir); err != nil {
+		return err
+	}
+	if _, err := fetchBundle(ctx, dir, job.Bundle); err != nil {
+		return err
+	}
+	_, err := runGit(ctx, dir, "checkout", "--quiet", "--detach", job.BaseCommit)
+	return err
+}
+
+// commitJob commits the changes the agent left in dir and returns a bundle
+// of the commits made on top of base, or nil when there are none.
+func commitJob(ctx context.Context, dir, base, message string) ([]byte, error) {
+	if _, err := runGit(ctx, dir, "add", "--all"); err != nil {
+		return nil, err
+	}
+	status, err := runGit(ctx, dir, "status", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	if status != "" {
+		if _, err := runGit(ctx, dir,
+			"-c", "user.name="+workerCommitName, "-c", "user.email="+workerCommitEmail,
+			"commit", "--quiet", "--no-verify", "-m", message); err != nil {
+			return nil, err
+		}
+	}
+	head, err := runGit(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	if head == base {
+		return nil, nil
+	}
+	return createBundle(ctx, dir, base+"..HEAD")
+}
+
+// applyResult applies the commits of a job result to workDir as staged
+// changes on top of base, which must still be checked out.
+func applyResult(ctx context.Context, workDir, base string, bundle []byte) error {
+	head, err := runGit(ctx, workDir, "rev-parse", "HEAD")
+	if err != nil {
+		return err
+	}
+	if head != base {
+		return fmt.Errorf("%s moved from %s to %s while the task ran remotely", workDir, base, head)
+	}
+	if _, err := fetchBundle(ctx, workDir, bundle); err != nil {
+		return err
+	}
+	// A two-tree merge updates the index and the files to the fetched tree
+	// and leaves HEAD on base.
+	_, err = runGit(ctx, workDir, "read-tree", "-m", "-u", "HEAD", "FETCH_HEAD")
+	return err
+}
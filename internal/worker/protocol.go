@@ -0,0 +1,152 @@
+// Package worker runs execute-phase agent tasks on other machines.
+//
+// A Coordinator, served by quorum serve under /api/v1/workers, dispatches
+// tasks to the workers registered with it. A Worker (quorum worker) advertises
+// the agents that pass its local Ping checks, runs each task in a clone of the
+// task's branch received as a git bundle, streams the agent events back, and
+// returns the resulting commits as a bundle. The coordinator applies them to
+// the task's worktree as staged changes, so the executor finalizes remote
+// tasks exactly like local ones.
+package worker
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+// APIPath is the path under which the coordinator endpoints are served.
+const APIPath = "/api/v1/workers"
+
+// Registration is sent by a worker when it connects to the coordinator.
+type Registration struct {
+	Name string `json:"name"`
+	// Agents are the agents available on the worker for the execute phase.
+	Agents []string `json:"agents"`
+	// Capacity is the number of tasks the worker runs at once.
+	Capacity int    `json:"capacity"`
+	Version  string `json:"version,omitempty"`
+}
+
+// RegisterResponse is the coordinator's answer to a registration.
+type RegisterResponse struct {
+	WorkerID string `json:"worker_id"`
+	// HeartbeatInterval is how often the worker must report in to not be
+	// considered lost.
+	HeartbeatInterval time.Duration `json:"heartbeat_interval"`
+}
+
+// Heartbeat is the coordinator's answer to a worker heartbeat.
+type Heartbeat struct {
+	// CancelledJobs are running jobs of the worker whose result is no longer
+	// awaited.
+	CancelledJobs []string `json:"cancelled_jobs,omitempty"`
+}
+
+// Job is a task execution dispatched to a worker.
+type Job struct {
+	ID    string `json:"id"`
+	Agent string `json:"agent"`
+
+	Prompt          string            `json:"prompt"`
+	SystemPrompt    string            `json:"system_prompt,omitempty"`
+	Model           string            `json:"model,omitempty"`
+	Format          core.OutputFormat `json:"format,omitempty"`
+	Timeout         time.Duration     `json:"timeout,omitempty"`
+	AllowedTools    []string          `json:"allowed_tools,omitempty"`
+	DeniedTools     []string          `json:"denied_tools,omitempty"`
+	Phase           core.Phase        `json:"phase"`
+	ReasoningEffort string            `json:"reasoning_effort,omitempty"`
+
+	// WorkDir is the task's directory on the coordinator. Prompts mention
+	// it, so the worker substitutes its own checkout for it.
+	WorkDir string `json:"work_dir"`
+	// BaseCommit is the commit the task starts from.
+	BaseCommit string `json:"base_commit"`
+	// Bundle is a git bundle containing BaseCommit.
+	Bundle []byte `json:"bundle"`
+}
+
+// newJob builds the job running opts through agent, starting from base.
+func newJob(agent string, opts core.ExecuteOptions, base string, bundle []byte) *Job {
+	return &Job{
+		Agent:           agent,
+		Prompt:          opts.Prompt,
+		SystemPrompt:    opts.SystemPrompt,
+		Model:           opts.Model,
+		Format:          opts.Format,
+		Timeout:         opts.Timeout,
+		AllowedTools:    opts.AllowedTools,
+		DeniedTools:     opts.DeniedTools,
+		Phase:           opts.Phase,
+		ReasoningEffort: opts.ReasoningEffort,
+		WorkDir:         opts.WorkDir,
+		BaseCommit:      base,
+		Bundle:          bundle,
+	}
+}
+
+// executeOptions returns the options to run the job in workDir.
+func (j *Job) executeOptions(workDir string) core.ExecuteOptions {
+	replace := func(s string) string {
+		if j.WorkDir == "" {
+			return s
+		}
+		return strings.ReplaceAll(s, j.WorkDir, workDir)
+	}
+	return core.ExecuteOptions{
+		Prompt:          replace(j.Prompt),
+		SystemPrompt:    replace(j.SystemPrompt),
+		Model:           j.Model,
+		Format:          j.Format,
+		Timeout:         j.Timeout,
+		WorkDir:         workDir,
+		AllowedTools:    j.AllowedTools,
+		DeniedTools:     j.DeniedTools,
+		Phase:           j.Phase,
+		ReasoningEffort: j.ReasoningEffort,
+	}
+}
+
+// JobResult is the outcome of a job, posted back by the worker.
+type JobResult struct {
+	Output       string          `json:"output"`
+	TokensIn     int             `json:"tokens_in"`
+	TokensOut    int             `json:"tokens_out"`
+	Duration     time.Duration   `json:"duration"`
+	Model        string          `json:"model,omitempty"`
+	FinishReason string          `json:"finish_reason,omitempty"`
+	ToolCalls    []core.ToolCall `json:"tool_calls,omitempty"`
+	// Error is set when the agent failed.
+	Error string `json:"error,omitempty"`
+	// Bundle holds the commits made on top of the job's base commit; it is
+	// empty when the task changed nothing.
+	Bundle []byte `json:"bundle,omitempty"`
+}
+
+// executeResult converts the result back to the agent result.
+func (r *JobResult) executeResult() *core.ExecuteResult {
+	return &core.ExecuteResult{
+		Output:       r.Output,
+		TokensIn:     r.TokensIn,
+		TokensOut:    r.TokensOut,
+		Duration:     r.Duration,
+		Model:        r.Model,
+		FinishReason: r.FinishReason,
+		ToolCalls:    r.ToolCalls,
+	}
+}
+
+// Info describes a registered worker.
+type Info struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Agents       []string  `json:"agents"`
+	Capacity     int       `json:"capacity"`
+	Version      string    `json:"version,omitempty"`
+	RunningJobs  int       `json:"running_jobs"`
+	QueuedJobs   int       `json:"queued_jobs"`
+	RegisteredAt time.Time `json:"registered_at"`
+	LastSeen     time.Time `json:"last_seen"`
+}
@@ -0,0 +1,432 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+)
+
+const (
+	// retryDelay is the wait before contacting an unreachable coordinator
+	// again.
+	retryDelay = 5 * time.Second
+	// eventFlushInterval is how often streamed agent events are sent.
+	eventFlushInterval = 500 * time.Millisecond
+	// resultAttempts bounds the attempts to post a job result.
+	resultAttempts = 3
+)
+
+// errNotRegistered is returned when the coordinator no longer knows the
+// worker, e.g. after a restart.
+var errNotRegistered = errors.New("worker not registered")
+
+// Options configures a Worker.
+type Options struct {
+	// CoordinatorURL is the base URL of quorum serve.
+	CoordinatorURL string
+	// Token is the bearer token expected by the coordinator, if any.
+	Token string
+	// Name identifies the worker in the coordinator (default: host name).
+	Name string
+	// Capacity is the number of tasks run at once (default 1).
+	Capacity int
+	// WorkDir holds the task checkouts (default: the temp directory).
+	WorkDir string
+	Version string
+	// NewRegistry returns the agent registry. Every task gets its own
+	// registry, so that the events of concurrent tasks are told apart.
+	NewRegistry func() (core.AgentRegistry, error)
+	HTTPClient  *http.Client
+	Logger      *slog.Logger
+}
+
+// Worker runs tasks dispatched by a coordinator.
+type Worker struct {
+	opts   Options
+	client *http.Client
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	id       string
+	interval time.Duration
+	// running holds the cancel functions of the running jobs.
+	running map[string]context.CancelFunc
+}
+
+// New creates a worker.
+func New(opts Options) (*Worker, error) {
+	if strings.TrimSpace(opts.CoordinatorURL) == "" {
+		return nil, fmt.Errorf("coordinator URL is required")
+	}
+	if opts.NewRegistry == nil {
+		return nil, fmt.Errorf("agent registry is required")
+	}
+	opts.CoordinatorURL = strings.TrimRight(opts.CoordinatorURL, "/")
+	if opts.Name == "" {
+		host, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("resolving host name: %w", err)
+		}
+		opts.Name = host
+	}
+	if opts.Capacity < 1 {
+		opts.Capacity = 1
+	}
+	if opts.WorkDir == "" {
+		opts.WorkDir = os.TempDir()
+	}
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{}
+	}
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Worker{opts: opts, client: client, logger: logger, running: make(map[string]context.CancelFunc)}, nil
+}
+
+// Run registers the worker and runs the tasks it receives until ctx is done.
+func (w *Worker) Run(ctx context.Context) error {
+	if err := os.MkdirAll(w.opts.WorkDir, 0o750); err != nil {
+		return fmt.Errorf("creating work directory: %w", err)
+	}
+	if err := w.register(ctx, ""); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.heartbeatLoop(ctx)
+	}()
+	for i := 0; i < w.opts.Capacity; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.pollLoop(ctx)
+		}()
+	}
+	wg.Wait()
+
+	// Let the coordinator reschedule right away rather than after the
+	// worker times out.
+	unregisterCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := w.call(unregisterCtx, http.MethodDelete, "/"+w.workerID(), nil, nil); err != nil {
+		w.logger.Debug("unregistering worker", slog.String("error", err.Error()))
+	}
+	return nil
+}
+
+// register registers the worker with the agents passing their Ping checks.
+// A re-registration, after the coordinator forgot staleID, is skipped when
+// another goroutine already did it.
+func (w *Worker) register(ctx context.Context, staleID string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.id != staleID {
+		return nil
+	}
+
+	registry, err := w.opts.NewRegistry()
+	if err != nil {
+		return fmt.Errorf("creating agent registry: %w", err)
+	}
+	agents := registry.AvailableForPhase(ctx, string(core.PhaseExecute))
+	if len(agents) == 0 {
+		return fmt.Errorf("no agent is available for the execute phase")
+	}
+	sort.Strings(agents)
+
+	var resp RegisterResponse
+	reg := Registration{Name: w.opts.Name, Agents: agents, Capacity: w.opts.Capacity, Version: w.opts.Version}
+	if err := w.call(ctx, http.MethodPost, "/register", reg, &resp); err != nil {
+		return fmt.Errorf("registering with %s: %w", w.opts.CoordinatorURL, err)
+	}
+	w.id = resp.WorkerID
+	w.interval = resp.HeartbeatInterval
+	if w.interval <= 0 {
+		w.interval = DefaultTimeout / 3
+	}
+	w.logger.Info("registered with coordinator",
+		slog.String("coordinator", w.opts.CoordinatorURL), slog.String("worker_id", w.id), slog.Any("agents", agents))
+	return nil
+}
+
+func (w *Worker) workerID() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.id
+}
+
+// retryAfter handles a failed coordinator call, registering again when the
+// coordinator forgot the worker, and reports whether to keep going.
+func (w *Worker) retryAfter(ctx context.Context, id string, err error) bool {
+	if errors.Is(err, errNotRegistered) {
+		if err = w.register(ctx, id); err == nil {
+			return true
+		}
+	}
+	if ctx.Err() != nil {
+		return false
+	}
+	w.logger.Warn("coordinator unreachable", slog.String("error", err.Error()))
+	return sleep(ctx, retryDelay)
+}
+
+func (w *Worker) heartbeatLoop(ctx context.Context) {
+	for {
+		w.mu.Lock()
+		interval := w.interval
+		w.mu.Unlock()
+		if !sleep(ctx, interval) {
+			return
+		}
+
+		id := w.workerID()
+		var hb Heartbeat
+		if err := w.call(ctx, http.MethodPost, "/"+id+"/heartbeat", nil, &hb); err != nil {
+			if !w.retryAfter(ctx, id, err) {
+				return
+			}
+			continue
+		}
+		w.mu.Lock()
+		for _, jobID := range hb.CancelledJobs {
+			if cancel, ok := w.running[jobID]; ok {
+				w.logger.Info("job cancelled by the coordinator", slog.String("job_id", jobID))
+				cancel()
+			}
+		}
+		w.mu.Unlock()
+	}
+}
+
+func (w *Worker) pollLoop(ctx context.Context) {
+	for ctx.Err() == nil {
+		id := w.workerID()
+		var job Job
+		err := w.call(ctx, http.MethodGet, "/"+id+"/jobs/next", nil, &job)
+		if err != nil {
+			if !w.retryAfter(ctx, id, err) {
+				return
+			}
+			continue
+		}
+		if job.ID == "" {
+			continue // no job within the poll timeout
+		}
+		w.runJob(ctx, id, &job)
+	}
+}
+
+// runJob runs job and posts its result.
+func (w *Worker) runJob(ctx context.Context, workerID string, job *Job) {
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	w.mu.Lock()
+	w.running[job.ID] = cancel
+	w.mu.Unlock()
+	defer func() {
+		w.mu.Lock()
+		delete(w.running, job.ID)
+		w.mu.Unlock()
+	}()
+
+	w.logger.Info("running job", slog.String("job_id", job.ID), slog.String("agent", job.Agent))
+	prefix := "/" + workerID + "/jobs/" + job.ID
+	events := newEventStream(func(batch []core.AgentEvent) {
+		if err := w.call(ctx, http.MethodPost, prefix+"/events", batch, nil); err != nil {
+			w.logger.Debug("sending job events", slog.String("job_id", job.ID), slog.String("error", err.Error()))
+		}
+	})
+
+	result := w.execute(jobCtx, job, events.add)
+	events.close()
+	if jobCtx.Err() != nil && ctx.Err() == nil {
+		return // cancelled by the coordinator
+	}
+	if result.Error != "" {
+		w.logger.Warn("job failed", slog.String("job_id", job.ID), slog.String("error", result.Error))
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := w.call(ctx, http.MethodPost, prefix+"/result", result, nil)
+		if err == nil || errors.Is(err, errNotRegistered) || attempt == resultAttempts || !sleep(ctx, retryDelay) {
+			if err != nil {
+				w.logger.Error("posting job result", slog.String("job_id", job.ID), slog.String("error", err.Error()))
+			}
+			return
+		}
+	}
+}
+
+// execute runs job in a fresh checkout.
+func (w *Worker) execute(ctx context.Context, job *Job, handler core.AgentEventHandler) *JobResult {
+	fail := func(err error) *JobResult { return &JobResult{Error: err.Error()} }
+
+	tmp, err := os.MkdirTemp(w.opts.WorkDir, "quorum-job-")
+	if err != nil {
+		return fail(fmt.Errorf("creating job directory: %w", err))
+	}
+	defer os.RemoveAll(tmp)
+	dir := filepath.Join(tmp, "repo")
+	if err := checkoutJob(ctx, dir, job); err != nil {
+		return fail(fmt.Errorf("checking out %s: %w", job.BaseCommit, err))
+	}
+
+	registry, err := w.opts.NewRegistry()
+	if err != nil {
+		return fail(fmt.Errorf("creating agent registry: %w", err))
+	}
+	agent, err := registry.Get(job.Agent)
+	if err != nil {
+		return fail(err)
+	}
+	if sc, ok := agent.(core.StreamingCapable); ok {
+		sc.SetEventHandler(handler)
+	}
+
+	res, err := agent.Execute(ctx, job.executeOptions(dir))
+	if err != nil {
+		return fail(err)
+	}
+	result := &JobResult{
+		Output:       res.Output,
+		TokensIn:     res.TokensIn,
+		TokensOut:    res.TokensOut,
+		Duration:     res.Duration,
+		Model:        res.Model,
+		FinishReason: res.FinishReason,
+		ToolCalls:    res.ToolCalls,
+	}
+	result.Bundle, err = commitJob(ctx, dir, job.BaseCommit, "quorum worker job "+job.ID)
+	if err != nil {
+		return fail(fmt.Errorf("collecting changes: %w", err))
+	}
+	return result
+}
+
+// call sends a JSON request to the coordinator and decodes the JSON
+// response into out. A 204 response leaves out untouched.
+func (w *Worker) call(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, w.opts.CoordinatorURL+APIPath+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if w.opts.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+w.opts.Token)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNoContent:
+		return nil
+	case resp.StatusCode == http.StatusNotFound:
+		return errNotRegistered
+	case resp.StatusCode >= http.StatusBadRequest:
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, apiErr.Error)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// sleep waits for d and reports whether ctx is still active.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// eventStream batches agent events and sends them in order.
+type eventStream struct {
+	send func([]core.AgentEvent)
+
+	mu      sync.Mutex
+	pending []core.AgentEvent
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func newEventStream(send func([]core.AgentEvent)) *eventStream {
+	s := &eventStream{send: send, stop: make(chan struct{}), done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(eventFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.flush()
+			case <-s.stop:
+				s.flush()
+				return
+			}
+		}
+	}()
+	return s
+}
+
+func (s *eventStream) add(event core.AgentEvent) {
+	s.mu.Lock()
+	s.pending = append(s.pending, event)
+	s.mu.Unlock()
+}
+
+func (s *eventStream) flush() {
+	s.mu.Lock()
+	batch := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+	if len(batch) > 0 {
+		s.send(batch)
+	}
+}
+
+// close sends the remaining events.
+func (s *eventStream) close() {
+	close(s.stop)
+	<-s.done
+}
@@ -0,0 +1,143 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/core"
+	"github.com/hugo-lorenzo-mato/quorum-ai/internal/testutil"
+)
+
+// streamingAgent is a mock agent emitting events.
+type streamingAgent struct {
+	*testutil.MockAgent
+	handler core.AgentEventHandler
+}
+
+func (a *streamingAgent) SetEventHandler(handler core.AgentEventHandler) { a.handler = handler }
+
+// streamingRegistry returns a new streaming agent from every Get.
+type streamingRegistry struct {
+	*testutil.MockRegistry
+	execute func(context.Context, core.ExecuteOptions) (*core.ExecuteResult, error)
+}
+
+func (r *streamingRegistry) Get(name string) (core.Agent, error) {
+	agent := &streamingAgent{MockAgent: testutil.NewMockAgent(name)}
+	agent.WithExecuteFunc(func(ctx context.Context, opts core.ExecuteOptions) (*core.ExecuteResult, error) {
+		if agent.handler != nil {
+			agent.handler(core.NewAgentEvent(core.AgentEventToolUse, name, "writing files"))
+		}
+		return r.execute(ctx, opts)
+	})
+	return agent, nil
+}
+
+func TestWorker_RunsTaskRemotely(t *testing.T) {
+	repo := testutil.NewGitRepo(t)
+	repo.WriteFile("README.md", "hello\n")
+	base := repo.Commit("initial")
+
+	coordinator, srv := newTestCoordinator(t, CoordinatorOptions{Token: "secret", Timeout: 10 * time.Second})
+
+	remote := &streamingRegistry{MockRegistry: testutil.NewMockRegistry()}
+	remote.Add("claude", testutil.NewMockAgent("claude"))
+	remote.execute = func(_ context.Context, opts core.ExecuteOptions) (*core.ExecuteResult, error) {
+		if opts.WorkDir == repo.Path || !strings.Contains(opts.Prompt, opts.WorkDir) {
+			return nil, fmt.Errorf("prompt %q not rewritten for %s", opts.Prompt, opts.WorkDir)
+		}
+		if err := os.WriteFile(filepath.Join(opts.WorkDir, "README.md"), []byte("hello, world\n"), 0o600); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(filepath.Join(opts.WorkDir, "added.txt"), []byte("new\n"), 0o600); err != nil {
+			return nil, err
+		}
+		return &core.ExecuteResult{Output: "done remotely", TokensOut: 500}, nil
+	}
+	w, err := New(Options{
+		CoordinatorURL: srv.URL,
+		Token:          "secret",
+		Name:           "laptop",
+		WorkDir:        t.TempDir(),
+		NewRegistry:    func() (core.AgentRegistry, error) { return remote, nil },
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- w.Run(ctx) }()
+	defer func() {
+		cancel()
+		if err := <-runErr; err != nil {
+			t.Errorf("Run() error = %v", err)
+		}
+		if workers := coordinator.Workers(); len(workers) != 0 {
+			t.Errorf("Workers() after stop = %+v, want none", workers)
+		}
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for !coordinator.HasWorkerFor("claude") {
+		if time.Now().After(deadline) {
+			t.Fatal("worker did not register")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	local := testutil.NewMockRegistry()
+	local.Add("claude", testutil.NewMockAgent("claude").WithResponse("done locally"))
+	var mu sync.Mutex
+	var events []core.AgentEvent
+	registry := NewRegistry(local, coordinator, func(e core.AgentEvent) {
+		mu.Lock()
+		events = append(events, e)
+		mu.Unlock()
+	})
+	agent, err := registry.Get("claude")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	result, err := agent.Execute(context.Background(), core.ExecuteOptions{
+		Prompt:  "Edit the files in " + repo.Path,
+		Phase:   core.PhaseExecute,
+		WorkDir: repo.Path,
+		Timeout: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if result.Output != "done remotely" || result.TokensOut != 500 {
+		t.Errorf("Execute() = %+v, want the remote result", result)
+	}
+
+	// The changes are staged on top of the unchanged base commit.
+	if head, _ := repo.Run("rev-parse", "HEAD"); head != base {
+		t.Errorf("HEAD = %s, want %s", head, base)
+	}
+	status, _ := repo.Run("status", "--porcelain")
+	if status != "M  README.md\nA  added.txt" {
+		t.Errorf("git status = %q, want README.md modified and added.txt added", status)
+	}
+	mu.Lock()
+	if len(events) != 1 || events[0].Message != "writing files" {
+		t.Errorf("events = %+v, want the remote tool_use event", events)
+	}
+	mu.Unlock()
+
+	// Other phases, and checkouts with uncommitted changes, run locally.
+	result, err = agent.Execute(context.Background(), core.ExecuteOptions{Prompt: "p", Phase: core.PhaseAnalyze, WorkDir: repo.Path})
+	if err != nil || result.Output != "done locally" {
+		t.Errorf("Execute() in the analyze phase = %+v, %v, want the local result", result, err)
+	}
+	result, err = agent.Execute(context.Background(), core.ExecuteOptions{Prompt: "p", Phase: core.PhaseExecute, WorkDir: repo.Path})
+	if err != nil || result.Output != "done locally" {
+		t.Errorf("Execute() in a dirty checkout = %+v, %v, want the local result", result, err)
+	}
+}